@@ -0,0 +1,165 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// syncEnvVar set to "1" runs convex-gen inside the hook call instead of
+	// in a background process
+	syncEnvVar = "CLAUDE_HOOKS_SYNC_GEN"
+
+	// backgroundFlag is the hidden argument the hook re-executes itself with
+	// to run one background regeneration
+	backgroundFlag = "--background"
+)
+
+// staleLockAge is how long a run lock may be held before it is assumed to
+// belong to a crashed process and taken over.
+const staleLockAge = 10 * time.Minute
+
+// generate runs convex-gen for a project; tests replace it
+var generate = runConvexGen
+
+// syncGen reports whether CLAUDE_HOOKS_SYNC_GEN asks for synchronous runs.
+func syncGen() bool {
+	return os.Getenv(syncEnvVar) == "1"
+}
+
+// logPath returns the file background runs append their output to.
+func logPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".claude", "logs", "auto-convex-gen.log"), nil
+}
+
+// startBackground re-executes the hook as a detached process that
+// regenerates projectRoot, and returns without waiting for it.
+func startBackground(projectRoot string) error {
+	self, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("locating hook binary: %w", err)
+	}
+
+	cmd := exec.Command(self, backgroundFlag, projectRoot)
+	cmd.Dir = projectRoot
+	detach(cmd)
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("starting background run: %w", err)
+	}
+	return cmd.Process.Release()
+}
+
+// backgroundMain is the entry point of the detached process. Its output goes
+// to the log file, or nowhere when the log cannot be opened.
+func backgroundMain(projectRoot string) {
+	var logw io.Writer = io.Discard
+	if path, err := logPath(); err == nil {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err == nil {
+			if f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644); err == nil {
+				defer f.Close()
+				logw = f
+			}
+		}
+	}
+	runBackground(projectRoot, logw)
+}
+
+// runBackground regenerates projectRoot unless another background run holds
+// its lock. Every call first leaves a pending marker; the lock holder keeps
+// regenerating while markers appear, so an edit that lands mid-run is picked
+// up by one follow-up run instead of a second concurrent one.
+func runBackground(projectRoot string, logw io.Writer) {
+	if err := markPending(projectRoot); err != nil {
+		fmt.Fprintf(logw, "%s %s: %v\n", now().Format(time.RFC3339), projectRoot, err)
+		return
+	}
+
+	// Checking the marker again after releasing closes the gap where a
+	// caller marked it just as the holder was finishing.
+	for hasPending(projectRoot) {
+		if !acquireRunLock(projectRoot) {
+			return
+		}
+		for takePending(projectRoot) {
+			logRun(projectRoot, logw)
+		}
+		releaseRunLock(projectRoot)
+	}
+}
+
+// logRun runs one regeneration, bracketing its output with start and result
+// lines in the log.
+func logRun(projectRoot string, logw io.Writer) {
+	started := now()
+	fmt.Fprintf(logw, "%s %s: regenerating\n", started.Format(time.RFC3339), projectRoot)
+	if err := generate(projectRoot, logw); err != nil {
+		fmt.Fprintf(logw, "%s %s: failed: %v\n", now().Format(time.RFC3339), projectRoot, err)
+		return
+	}
+	fmt.Fprintf(logw, "%s %s: finished in %s\n", now().Format(time.RFC3339), projectRoot,
+		now().Sub(started).Round(100*time.Millisecond))
+}
+
+func lockPath(projectRoot string) string {
+	return filepath.Join(stampDir, projectKey(projectRoot)+".lock")
+}
+
+func pendingPath(projectRoot string) string {
+	return filepath.Join(stampDir, projectKey(projectRoot)+".pending")
+}
+
+// acquireRunLock creates the project's lock file exclusively. A lock older
+// than staleLockAge is removed and retried once.
+func acquireRunLock(projectRoot string) bool {
+	path := lockPath(projectRoot)
+	for attempt := 0; attempt < 2; attempt++ {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			fmt.Fprintf(f, "pid=%d\n", os.Getpid())
+			_ = f.Close()
+			return true
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		if time.Since(info.ModTime()) < staleLockAge {
+			return false
+		}
+		_ = os.Remove(path)
+	}
+	return false
+}
+
+func releaseRunLock(projectRoot string) {
+	_ = os.Remove(lockPath(projectRoot))
+}
+
+func markPending(projectRoot string) error {
+	if err := os.MkdirAll(stampDir, 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(pendingPath(projectRoot), os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func hasPending(projectRoot string) bool {
+	_, err := os.Stat(pendingPath(projectRoot))
+	return err == nil
+}
+
+// takePending removes the pending marker, reporting whether there was one.
+func takePending(projectRoot string) bool {
+	return os.Remove(pendingPath(projectRoot)) == nil
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// backgroundFixture points the stamp dir and generate at test values.
+// The generator sleeps briefly so overlapping runs would be observed.
+func backgroundFixture(t *testing.T) (root string, runs, maxActive *int32) {
+	t.Helper()
+	oldDir, oldGenerate := stampDir, generate
+	stampDir = t.TempDir()
+	t.Cleanup(func() { stampDir, generate = oldDir, oldGenerate })
+
+	var active, total, peak int32
+	generate = func(string, io.Writer) error {
+		n := atomic.AddInt32(&active, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		atomic.AddInt32(&total, 1)
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&active, -1)
+		return nil
+	}
+	return t.TempDir(), &total, &peak
+}
+
+func TestRunBackground_LockPreventsConcurrentRuns(t *testing.T) {
+	root, runs, maxActive := backgroundFixture(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			runBackground(root, io.Discard)
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(maxActive); got != 1 {
+		t.Errorf("max concurrent runs = %d, want 1", got)
+	}
+	if got := atomic.LoadInt32(runs); got < 1 || got > 8 {
+		t.Errorf("runs = %d, want between 1 and 8", got)
+	}
+	if hasPending(root) {
+		t.Error("pending marker left behind")
+	}
+	if _, err := os.Stat(lockPath(root)); !os.IsNotExist(err) {
+		t.Error("lock file left behind")
+	}
+}
+
+func TestRunBackground_HeldLockDefersToHolder(t *testing.T) {
+	root, runs, _ := backgroundFixture(t)
+
+	if !acquireRunLock(root) {
+		t.Fatal("could not take the lock")
+	}
+	runBackground(root, io.Discard)
+	if got := atomic.LoadInt32(runs); got != 0 {
+		t.Errorf("runs while lock held = %d, want 0", got)
+	}
+	if !hasPending(root) {
+		t.Fatal("caller should leave a pending marker for the holder")
+	}
+
+	// The holder finishing picks up the marker
+	releaseRunLock(root)
+	runBackground(root, io.Discard)
+	if got := atomic.LoadInt32(runs); got != 1 {
+		t.Errorf("runs after release = %d, want 1", got)
+	}
+}
+
+func TestAcquireRunLock_TakesOverStaleLock(t *testing.T) {
+	root, _, _ := backgroundFixture(t)
+
+	if !acquireRunLock(root) {
+		t.Fatal("could not take the lock")
+	}
+	if acquireRunLock(root) {
+		t.Fatal("fresh lock should not be taken twice")
+	}
+	old := time.Now().Add(-staleLockAge - time.Minute)
+	if err := os.Chtimes(lockPath(root), old, old); err != nil {
+		t.Fatal(err)
+	}
+	if !acquireRunLock(root) {
+		t.Error("stale lock should be taken over")
+	}
+}
+
+func TestLogRun_RecordsResult(t *testing.T) {
+	root, _, _ := backgroundFixture(t)
+
+	var log bytes.Buffer
+	logRun(root, &log)
+	out := log.String()
+	if !strings.Contains(out, root+": regenerating") || !strings.Contains(out, root+": finished in") {
+		t.Errorf("log = %q, want start and finish lines", out)
+	}
+
+	generate = func(string, io.Writer) error { return io.ErrUnexpectedEOF }
+	log.Reset()
+	logRun(root, &log)
+	if !strings.Contains(log.String(), "failed: unexpected EOF") {
+		t.Errorf("log = %q, want failure line", log.String())
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+const (
+	// debounceEnvVar sets the debounce window in seconds; 0 disables it
+	debounceEnvVar = "CLAUDE_HOOKS_CONVEX_GEN_DEBOUNCE"
+
+	defaultDebounce = 5 * time.Second
+)
+
+// now is the clock used for run stamps; tests replace it
+var now = time.Now
+
+// stampDir holds one run stamp per project; tests replace it
+var stampDir = filepath.Join(os.TempDir(), "auto-convex-gen")
+
+// debounceWindow reads the window from CLAUDE_HOOKS_CONVEX_GEN_DEBOUNCE,
+// falling back to the default when it is unset or not a whole number.
+func debounceWindow() time.Duration {
+	seconds, err := strconv.Atoi(os.Getenv(debounceEnvVar))
+	if err != nil || seconds < 0 {
+		return defaultDebounce
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// stampPath returns the run stamp for projectRoot. Its mtime is when the
+// last regeneration started.
+func stampPath(projectRoot string) string {
+	return filepath.Join(stampDir, projectKey(projectRoot)+".stamp")
+}
+
+// projectKey names the per-project files in stampDir.
+func projectKey(projectRoot string) string {
+	sum := sha256.Sum256([]byte(projectRoot))
+	return hex.EncodeToString(sum[:8])
+}
+
+// claimRun reports whether an edit of filePath should regenerate projectRoot,
+// and stamps the run when it should. An edit is skipped when a regeneration
+// started within window and after filePath was last written, since that run
+// already read the edit; this folds the hook calls of a multi-file edit into
+// one run while a later edit of any file still regenerates. Stamp errors
+// never skip a run.
+func claimRun(projectRoot, filePath string, window time.Duration) bool {
+	if window <= 0 {
+		return true
+	}
+	if err := os.MkdirAll(stampDir, 0755); err != nil {
+		return true
+	}
+	path := stampPath(projectRoot)
+
+	// The stamp is created exclusively, so of several hooks racing for a
+	// fresh stamp only one runs. A stale stamp is removed and retried once.
+	for attempt := 0; attempt < 2; attempt++ {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			_ = f.Close()
+			started := now()
+			_ = os.Chtimes(path, started, started)
+			return true
+		}
+
+		stamp, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		started := stamp.ModTime()
+		if age := now().Sub(started); age >= 0 && age < window && editedBefore(filePath, started) {
+			return false
+		}
+		_ = os.Remove(path)
+	}
+	return true
+}
+
+// editedBefore reports whether filePath was last written no later than t.
+func editedBefore(filePath string, t time.Time) bool {
+	info, err := os.Stat(filePath)
+	return err == nil && !info.ModTime().After(t)
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// debounceFixture points the stamp dir and clock at test values and returns
+// a project root with an edited file written at base.
+func debounceFixture(t *testing.T, base time.Time) (root, file string, setClock func(time.Time)) {
+	t.Helper()
+	clock := base
+	oldNow, oldDir := now, stampDir
+	now = func() time.Time { return clock }
+	stampDir = t.TempDir()
+	t.Cleanup(func() { now, stampDir = oldNow, oldDir })
+
+	root = t.TempDir()
+	file = filepath.Join(root, "convex", "users.ts")
+	writeAt(t, file, base)
+	return root, file, func(t time.Time) { clock = t }
+}
+
+// writeAt writes path and sets its mtime to at.
+func writeAt(t *testing.T, path string, at time.Time) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("export const x = 1;"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, at, at); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClaimRun_DebounceWindow(t *testing.T) {
+	base := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	window := 5 * time.Second
+	root, file, setClock := debounceFixture(t, base)
+	other := filepath.Join(root, "convex", "posts.ts")
+	writeAt(t, other, base)
+
+	setClock(base.Add(time.Second))
+	if !claimRun(root, file, window) {
+		t.Fatal("first edit should run")
+	}
+
+	// The other hook calls of the same multi-file edit fold into that run
+	setClock(base.Add(2 * time.Second))
+	if claimRun(root, file, window) {
+		t.Error("repeat call for an already-seen edit should be skipped")
+	}
+	if claimRun(root, other, window) {
+		t.Error("sibling file written before the run should be skipped")
+	}
+
+	// After the window the stamp is stale, so even an old edit runs again
+	setClock(base.Add(7 * time.Second))
+	if !claimRun(root, file, window) {
+		t.Error("edit after the window should run")
+	}
+}
+
+func TestClaimRun_LaterEditInsideWindow(t *testing.T) {
+	base := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	window := 5 * time.Second
+	root, file, setClock := debounceFixture(t, base)
+
+	setClock(base.Add(time.Second))
+	if !claimRun(root, file, window) {
+		t.Fatal("first edit should run")
+	}
+
+	// A distinct edit made after that run started was not seen by it
+	writeAt(t, file, base.Add(2*time.Second))
+	setClock(base.Add(2 * time.Second))
+	if !claimRun(root, file, window) {
+		t.Error("edit written after the last run started should run")
+	}
+	setClock(base.Add(3 * time.Second))
+	if claimRun(root, file, window) {
+		t.Error("the new run should debounce the next call again")
+	}
+}
+
+func TestClaimRun_PerProject(t *testing.T) {
+	base := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	root, file, setClock := debounceFixture(t, base)
+	otherRoot := t.TempDir()
+	otherFile := filepath.Join(otherRoot, "convex", "users.ts")
+	writeAt(t, otherFile, base)
+
+	setClock(base.Add(time.Second))
+	if !claimRun(root, file, 5*time.Second) {
+		t.Fatal("first project should run")
+	}
+	if !claimRun(otherRoot, otherFile, 5*time.Second) {
+		t.Error("a different project has its own window")
+	}
+}
+
+func TestClaimRun_Disabled(t *testing.T) {
+	base := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	root, file, _ := debounceFixture(t, base)
+	for i := 0; i < 2; i++ {
+		if !claimRun(root, file, 0) {
+			t.Errorf("call %d: a zero window should never skip", i+1)
+		}
+	}
+}
+
+func TestDebounceWindow(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{value: "", want: defaultDebounce},
+		{value: "10", want: 10 * time.Second},
+		{value: "0", want: 0},
+		{value: "-1", want: defaultDebounce},
+		{value: "soon", want: defaultDebounce},
+	}
+	for _, tt := range tests {
+		t.Setenv(debounceEnvVar, tt.value)
+		if got := debounceWindow(); got != tt.want {
+			t.Errorf("debounceWindow() with %q = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
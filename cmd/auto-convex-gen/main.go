@@ -29,6 +29,11 @@ type Config struct {
 }
 
 func main() {
+	if len(os.Args) == 3 && os.Args[1] == backgroundFlag {
+		backgroundMain(os.Args[2])
+		os.Exit(0)
+	}
+
 	if err := run(os.Stdin, os.Stderr); err != nil {
 		fmt.Fprintf(os.Stderr, "auto-convex-gen: %v\n", err)
 	}
@@ -84,8 +89,16 @@ func run(stdin io.Reader, stderr io.Writer) error {
 		return nil
 	}
 
-	// File is relevant — run convex-gen.
-	return runConvexGen(projectRoot, stderr)
+	// Skip when a regeneration that already saw this edit just ran.
+	if !claimRun(projectRoot, filePath, debounceWindow()) {
+		return nil
+	}
+
+	// File is relevant — run convex-gen, in the background unless asked not to.
+	if syncGen() {
+		return runConvexGen(projectRoot, stderr)
+	}
+	return startBackground(projectRoot)
 }
 
 func readInput(r io.Reader) (*HookInput, error) {
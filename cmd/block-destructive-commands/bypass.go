@@ -0,0 +1,376 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxBypassTTL caps how long a bypass token stays valid.
+const maxBypassTTL = time.Hour
+
+// bypassToken lets one blocked command through. The user creates it with
+// `block-destructive-commands bypass`; the hook consumes the first unexpired
+// token whose pattern the blocked command contains and whose lift lets the
+// rest of the command pass (see applyBypass). Sig is an HMAC over the
+// other fields with a key only the user's home holds, so a token written by
+// anyone without the key is ignored.
+type bypassToken struct {
+	Pattern string `json:"pattern"`
+	Expires int64  `json:"expires"` // Unix seconds
+	Nonce   string `json:"nonce"`
+	Sig     string `json:"sig"`
+}
+
+// bypassPaths locates the signing key and the token directory.
+type bypassPaths struct {
+	home string // expands ~ and $HOME in commands
+	key  string // ~/.claude/block-destructive-commands.key
+	dir  string // <tmp>/block-destructive-commands-bypass
+}
+
+// defaultBypassPaths returns the key in the user's home and the token
+// directory in the temp dir. key is "" when the home directory is unknown,
+// which disables bypasses.
+func defaultBypassPaths() bypassPaths {
+	p := bypassPaths{dir: filepath.Join(os.TempDir(), "block-destructive-commands-bypass")}
+	if home, err := os.UserHomeDir(); err == nil {
+		p.home = home
+		p.key = filepath.Join(home, ".claude", "block-destructive-commands.key")
+	}
+	return p
+}
+
+// shellWordSeparators splits a command into the words that may be paths.
+func shellWordSeparators(r rune) bool {
+	return strings.ContainsRune(" \t\n;&|()<>=`", r)
+}
+
+// shellQuotes are dropped from words, so "$HOME"/.claude reads as one path.
+var shellQuotes = strings.NewReplacer(`"`, "", `'`, "")
+
+// touchedBy reports whether a word of cmd resolves to the signing key or
+// into the token directory (see reaches). It catches what
+// bypassCommandPatterns can't see by name, e.g. `cat ~/.claude/*.key`. A
+// program that builds the path at runtime is not caught.
+func (p bypassPaths) touchedBy(cmd, cwd string) bool {
+	for _, word := range strings.FieldsFunc(cmd, shellWordSeparators) {
+		if p.reaches(shellQuotes.Replace(word), cwd, false) {
+			return true
+		}
+	}
+	return false
+}
+
+// fileToolTouches reports whether a file tool call reaches the signing key
+// or the token directory: Read, Edit, Write and MultiEdit through
+// file_path; Grep and Glob through a search root that is, contains or lies
+// inside either, and Glob also through its pattern.
+func (p bypassPaths) fileToolTouches(tool, filePath, root, pattern, cwd string) bool {
+	switch tool {
+	case "Read", "Edit", "Write", "MultiEdit":
+		return filePath != "" && p.reaches(filePath, cwd, false)
+	case "Grep", "Glob":
+		if root == "" {
+			root = cwd
+		}
+		if root != "" && p.reaches(root, cwd, true) {
+			return true
+		}
+		if tool == "Glob" && pattern != "" {
+			if !filepath.IsAbs(pattern) && pattern != "~" && !strings.HasPrefix(pattern, "~/") && root != "" {
+				pattern = filepath.Join(root, pattern)
+			}
+			return p.reaches(pattern, cwd, false)
+		}
+	}
+	return false
+}
+
+// reaches reports whether path resolves to the signing key or into the
+// token directory: after expanding ~ and $HOME, relative to cwd, through
+// symlinks and as a glob. With search set, a directory that contains either
+// counts too, since searching it reads them.
+func (p bypassPaths) reaches(path, cwd string, search bool) bool {
+	if p.home != "" {
+		if path == "~" || strings.HasPrefix(path, "~/") {
+			path = p.home + path[1:]
+		}
+		path = strings.NewReplacer("${HOME}", p.home, "$HOME", p.home).Replace(path)
+	}
+	if !filepath.IsAbs(path) {
+		if cwd == "" {
+			return false
+		}
+		path = filepath.Join(cwd, path)
+	}
+	candidates := []string{filepath.Clean(path)}
+	if strings.ContainsAny(path, "*?[") {
+		matches, _ := filepath.Glob(path)
+		candidates = append(candidates, matches...)
+	}
+	for _, c := range candidates {
+		if resolved, err := filepath.EvalSymlinks(c); err == nil {
+			candidates = append(candidates, resolved)
+		}
+	}
+	sep := string(filepath.Separator)
+	for _, c := range candidates {
+		for _, target := range []string{p.key, p.dir} {
+			if target == "" {
+				continue
+			}
+			if matched, _ := filepath.Match(c, target); matched || c == target || strings.HasPrefix(c, target+sep) {
+				return true
+			}
+			if search && (c == sep || strings.HasPrefix(target, c+sep)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// bypassSubcommand is reported for any run of the binary with a bypass
+// argument (see invokesBypass).
+var bypassSubcommand = pattern{name: "block-destructive-commands bypass (only the user may create bypass tokens)", category: categoryHookBypass}
+
+// bypassCommandPatterns keep the agent away from the bypass machinery: it
+// must not read the key that signs tokens or write to their directory.
+var bypassCommandPatterns = []pattern{
+	{regex: regexp.MustCompile(`(?i)block-destructive-commands\.key\b`), name: "bypass signing key access", category: categoryHookBypass},
+	{regex: regexp.MustCompile(`(?i)block-destructive-commands-bypass\b`), name: "bypass token directory access", category: categoryHookBypass},
+}
+
+// shellUnquote drops quotes and backslashes, so `"bypass"`, `b""ypass` and
+// `b\ypass` read as the word the shell passes on.
+var shellUnquote = strings.NewReplacer(`"`, "", `'`, "", `\`, "")
+
+// bypassAttempt returns the bypass machinery pattern cmd reaches once
+// unquoted: running the binary to mint a token, or naming the key or the
+// token directory.
+func bypassAttempt(cmd string) (pattern, bool) {
+	plain := shellUnquote.Replace(cmd)
+	if invokesBypass(plain) {
+		return bypassSubcommand, true
+	}
+	for _, p := range bypassCommandPatterns {
+		if p.regex.MatchString(plain) {
+			return p, true
+		}
+	}
+	return pattern{}, false
+}
+
+// invokesBypass reports whether a word of cmd names the binary and a later
+// word is "bypass", or is built at runtime ($SUB, $(echo bypass)) so the
+// hook can't tell what it is.
+func invokesBypass(cmd string) bool {
+	named := false
+	for _, word := range strings.FieldsFunc(cmd, shellWordSeparators) {
+		if named && (strings.EqualFold(word, "bypass") || strings.Contains(word, "$")) {
+			return true
+		}
+		base := strings.TrimSuffix(strings.ToLower(filepath.Base(word)), ".exe")
+		named = named || base == "block-destructive-commands"
+	}
+	return false
+}
+
+// sign returns the hex HMAC-SHA256 of the token's fields under key.
+func (t bypassToken) sign(key []byte) string {
+	mac := hmac.New(sha256.New, key)
+	fmt.Fprintf(mac, "%s\n%d\n%s", t.Pattern, t.Expires, t.Nonce)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// valid reports whether the token is signed with key and unexpired at now.
+func (t bypassToken) valid(key []byte, now time.Time) bool {
+	want, err := hex.DecodeString(t.Sig)
+	if err != nil {
+		return false
+	}
+	got, _ := hex.DecodeString(t.sign(key))
+	return hmac.Equal(got, want) && now.Unix() < t.Expires
+}
+
+// loadBypassKey reads the signing key. create makes a new random key (mode
+// 0600) when there is none; the hook never creates one.
+func loadBypassKey(path string, create bool) ([]byte, error) {
+	if path == "" {
+		return nil, errors.New("home directory unknown; no place for the bypass key")
+	}
+	data, err := os.ReadFile(path)
+	if err == nil {
+		key, err := hex.DecodeString(strings.TrimSpace(string(data)))
+		if err != nil || len(key) < 32 {
+			return nil, fmt.Errorf("bypass key %s is malformed", path)
+		}
+		return key, nil
+	}
+	if !os.IsNotExist(err) || !create {
+		return nil, err
+	}
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, []byte(hex.EncodeToString(key)+"\n"), 0o600); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// createBypassToken writes a token allowing one command containing pattern
+// until now+ttl, and returns its path.
+func createBypassToken(paths bypassPaths, pattern string, ttl time.Duration, now time.Time) (string, error) {
+	if strings.TrimSpace(pattern) == "" {
+		return "", errors.New("--pattern is required")
+	}
+	if ttl <= 0 || ttl > maxBypassTTL {
+		return "", fmt.Errorf("--ttl must be positive and at most %s", maxBypassTTL)
+	}
+	key, err := loadBypassKey(paths.key, true)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	t := bypassToken{Pattern: pattern, Expires: now.Add(ttl).Unix(), Nonce: hex.EncodeToString(nonce)}
+	t.Sig = t.sign(key)
+
+	data, err := json.Marshal(t)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(paths.dir, 0o700); err != nil {
+		return "", err
+	}
+	path := filepath.Join(paths.dir, t.Nonce+".json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// consumeBypassToken looks for a valid token whose pattern cmd contains and
+// that accept approves, and deletes it, so it allows exactly one command.
+// Expired tokens are deleted along the way; forged or unreadable ones are
+// ignored. Without a key there are no valid tokens.
+func consumeBypassToken(paths bypassPaths, cmd string, now time.Time, accept func(bypassToken) bool) (bypassToken, bool) {
+	key, err := loadBypassKey(paths.key, false)
+	if err != nil {
+		return bypassToken{}, false
+	}
+	entries, err := os.ReadDir(paths.dir)
+	if err != nil {
+		return bypassToken{}, false
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(paths.dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var t bypassToken
+		if json.Unmarshal(data, &t) != nil {
+			continue
+		}
+		if !t.valid(key, now) {
+			if now.Unix() >= t.Expires {
+				_ = os.Remove(path)
+			}
+			continue
+		}
+		if !strings.Contains(cmd, t.Pattern) || !accept(t) {
+			continue
+		}
+		// Whoever removes the file owns the token; a concurrent hook that
+		// loses the race keeps blocking.
+		if os.Remove(path) == nil {
+			return t, true
+		}
+	}
+	return bypassToken{}, false
+}
+
+// applyBypass runs after evaluate. It denies commands that reach the bypass
+// key or token directory by path (touchedBy), and those are never bypassed.
+// For any other deny it looks for a bypass token for cmd: the token lifts
+// only the patterns its text matches, and the command is evaluated again
+// without them, so `git reset x; rm -rf ~` stays blocked under a "git reset"
+// token. A token is consumed, and logged to auditOutput, only when that
+// re-evaluation no longer denies; its verdict (allow, or ask for a confirm
+// match elsewhere) is returned.
+func applyBypass(v verdict, cmd, cwd string, cfg hookConfig, paths bypassPaths, now time.Time) verdict {
+	if paths.touchedBy(cmd, cwd) {
+		return verdict{decisionDeny, fmt.Sprintf("BLOCKED [%s]: bypass signing key access — %s reaches the bypass key or token directory. Bypass tokens must be created by the user in their own terminal.", categoryHookBypass, cmd)}
+	}
+	if v.decision != decisionDeny {
+		return v
+	}
+	if _, ok := bypassAttempt(cmd); ok {
+		return v
+	}
+	lifted := v
+	t, ok := consumeBypassToken(paths, cmd, now, func(t bypassToken) bool {
+		cfg.bypass = t.Pattern
+		lifted = evaluate(cmd, cfg)
+		return lifted.decision != decisionDeny
+	})
+	if !ok {
+		return v
+	}
+	fmt.Fprintf(auditOutput, "block-destructive-commands: allowed once by bypass token for %s: %s\n", strconv.Quote(t.Pattern), cmd)
+	return lifted
+}
+
+// fileToolVerdict denies a file tool call that reaches the bypass key or
+// token directory, so the agent can't read the key or drop a token in place
+// without going through Bash. Other calls are allowed.
+func fileToolVerdict(tool, filePath, root, pattern, cwd string, paths bypassPaths) verdict {
+	if paths.fileToolTouches(tool, filePath, root, pattern, cwd) {
+		return verdict{decisionDeny, fmt.Sprintf("BLOCKED [%s]: bypass signing key access — %s reaches the bypass key or token directory. Bypass tokens must be created by the user in their own terminal.", categoryHookBypass, tool)}
+	}
+	return verdict{decision: decisionAllow}
+}
+
+// runBypass implements `block-destructive-commands bypass --pattern <text>
+// --ttl <duration>`, run by the user in their own terminal.
+func runBypass(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("bypass", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	pattern := fs.String("pattern", "", "text the blocked command must contain (e.g. \"git reset\")")
+	ttl := fs.Duration("ttl", 5*time.Minute, "how long the token stays valid (max "+maxBypassTTL.String()+")")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	path, err := createBypassToken(defaultBypassPaths(), *pattern, *ttl, time.Now())
+	if err != nil {
+		fmt.Fprintf(stderr, "bypass: %v\n", err)
+		return 1
+	}
+	fmt.Fprintf(stdout, "Bypass token written to %s\nThe next blocked command containing %s is allowed once within %s.\n",
+		path, strconv.Quote(*pattern), *ttl)
+	return 0
+}
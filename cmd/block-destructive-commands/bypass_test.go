@@ -0,0 +1,296 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testBypassPaths returns a key and token dir inside a temp dir, and
+// silences the audit log for the test.
+func testBypassPaths(t *testing.T) bypassPaths {
+	t.Helper()
+	auditOutput = io.Discard
+	t.Cleanup(func() { auditOutput = os.Stderr })
+	root := t.TempDir()
+	home := filepath.Join(root, "home")
+	return bypassPaths{home: home, key: filepath.Join(home, ".claude", "block-destructive-commands.key"), dir: filepath.Join(root, "tokens")}
+}
+
+func denyVerdict(cmd string) verdict {
+	return evaluate(cmd, hookConfig{})
+}
+
+func TestBypassTokenAllowsOnce(t *testing.T) {
+	paths := testBypassPaths(t)
+	var audit bytes.Buffer
+	auditOutput = &audit
+	now := time.Unix(1_700_000_000, 0)
+	if _, err := createBypassToken(paths, "git reset", 5*time.Minute, now); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(paths.key); err != nil || info.Mode().Perm() != 0o600 {
+		t.Fatalf("key file = %v, %v; want mode 0600", info, err)
+	}
+
+	cmd := "git reset --hard HEAD~1"
+	if got := applyBypass(denyVerdict(cmd), cmd, "", hookConfig{}, paths, now.Add(time.Minute)); got.decision != decisionAllow {
+		t.Fatalf("first run = %s, want allow", got.decision)
+	}
+	if !strings.Contains(audit.String(), `bypass token for "git reset": git reset --hard HEAD~1`) {
+		t.Errorf("audit log = %q, want the bypass recorded", audit.String())
+	}
+	if got := applyBypass(denyVerdict(cmd), cmd, "", hookConfig{}, paths, now.Add(time.Minute)); got.decision != decisionDeny {
+		t.Errorf("second run = %s, want deny (token consumed)", got.decision)
+	}
+}
+
+func TestBypassTokenOnlyMatchingCommand(t *testing.T) {
+	paths := testBypassPaths(t)
+	now := time.Unix(1_700_000_000, 0)
+	if _, err := createBypassToken(paths, "git reset", 5*time.Minute, now); err != nil {
+		t.Fatal(err)
+	}
+
+	other := "git clean -fd"
+	if got := applyBypass(denyVerdict(other), other, "", hookConfig{}, paths, now); got.decision != decisionDeny {
+		t.Errorf("unrelated command = %s, want deny", got.decision)
+	}
+	cmd := "git reset --hard"
+	if got := applyBypass(denyVerdict(cmd), cmd, "", hookConfig{}, paths, now); got.decision != decisionAllow {
+		t.Errorf("matching command = %s, want allow (token kept for it)", got.decision)
+	}
+}
+
+func TestBypassTokenExpires(t *testing.T) {
+	paths := testBypassPaths(t)
+	now := time.Unix(1_700_000_000, 0)
+	path, err := createBypassToken(paths, "git reset", 5*time.Minute, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := "git reset --hard"
+	if got := applyBypass(denyVerdict(cmd), cmd, "", hookConfig{}, paths, now.Add(5*time.Minute)); got.decision != decisionDeny {
+		t.Errorf("expired token = %s, want deny", got.decision)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expired token file still present: %v", err)
+	}
+}
+
+func TestBypassTokenRejectsForgery(t *testing.T) {
+	paths := testBypassPaths(t)
+	now := time.Unix(1_700_000_000, 0)
+	path, err := createBypassToken(paths, "git reset", 5*time.Minute, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var genuine bypassToken
+	if err := json.Unmarshal(data, &genuine); err != nil {
+		t.Fatal(err)
+	}
+
+	forge := func(name string, tok bypassToken) {
+		t.Helper()
+		data, _ := json.Marshal(tok)
+		if err := os.WriteFile(filepath.Join(paths.dir, name+".json"), data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Widened pattern and extended expiry, keeping the genuine signature.
+	widened := genuine
+	widened.Pattern = "git clean"
+	widened.Expires = now.Add(24 * time.Hour).Unix()
+	forge("widened", widened)
+	// Signed with a key the attacker made up.
+	selfSigned := bypassToken{Pattern: "git clean", Expires: now.Add(time.Hour).Unix(), Nonce: "00"}
+	selfSigned.Sig = selfSigned.sign(bytes.Repeat([]byte{1}, 32))
+	forge("self-signed", selfSigned)
+	forge("unsigned", bypassToken{Pattern: "git clean", Expires: now.Add(time.Hour).Unix()})
+
+	cmd := "git clean -fd"
+	if got := applyBypass(denyVerdict(cmd), cmd, "", hookConfig{}, paths, now); got.decision != decisionDeny {
+		t.Errorf("forged tokens = %s, want deny", got.decision)
+	}
+}
+
+func TestBypassWithoutKey(t *testing.T) {
+	paths := testBypassPaths(t)
+	if err := os.MkdirAll(paths.dir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	tok := bypassToken{Pattern: "git reset", Expires: time.Now().Add(time.Hour).Unix(), Nonce: "00", Sig: "00"}
+	data, _ := json.Marshal(tok)
+	if err := os.WriteFile(filepath.Join(paths.dir, "t.json"), data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cmd := "git reset --hard"
+	if got := applyBypass(denyVerdict(cmd), cmd, "", hookConfig{}, paths, time.Now()); got.decision != decisionDeny {
+		t.Errorf("token without key = %s, want deny", got.decision)
+	}
+}
+
+func TestCreateBypassTokenValidation(t *testing.T) {
+	paths := testBypassPaths(t)
+	now := time.Now()
+	if _, err := createBypassToken(paths, " ", time.Minute, now); err == nil {
+		t.Error("empty pattern: want error")
+	}
+	if _, err := createBypassToken(paths, "git reset", 2*time.Hour, now); err == nil {
+		t.Error("ttl over the maximum: want error")
+	}
+	if _, err := createBypassToken(paths, "git reset", 0, now); err == nil {
+		t.Error("zero ttl: want error")
+	}
+}
+
+func TestBypassMachineryIsBlocked(t *testing.T) {
+	paths := testBypassPaths(t)
+	now := time.Unix(1_700_000_000, 0)
+	if _, err := createBypassToken(paths, "cat", 5*time.Minute, now); err != nil {
+		t.Fatal(err)
+	}
+	for _, cmd := range []string{
+		`block-destructive-commands bypass --pattern "git reset" --ttl 5m`,
+		`block-destructive-commands "bypass" --pattern rm-rf`,
+		`block-destructive-commands 'bypass' --pattern rm-rf`,
+		`block-destructive-commands b""ypass --pattern rm-rf`,
+		`block-destructive-commands b\ypass --pattern rm-rf`,
+		`block-destructive-commands $(echo bypass) --pattern rm-rf`,
+		`SUB=bypass; ~/bin/block-destructive-commands $SUB --pattern rm-rf`,
+		`./bin/Block-Destructive-Commands --ttl 5m BYPASS --pattern rm-rf`,
+		`cat ~/.claude/block-destructive-commands.key`,
+		`cat ~/.claude/block-destructive-commands.k""ey`,
+		`ls /tmp/block-destructive-commands-bypass`,
+	} {
+		v := evaluate(cmd, hookConfig{})
+		if v.decision != decisionDeny {
+			t.Errorf("evaluate(%q) = %s, want deny", cmd, v.decision)
+		}
+		if got := applyBypass(v, cmd, "", hookConfig{}, paths, now); got.decision != decisionDeny {
+			t.Errorf("applyBypass(%q) = %s, want deny even with a matching token", cmd, got.decision)
+		}
+	}
+}
+
+func TestBypassAttemptAllowsUnrelatedCommands(t *testing.T) {
+	for _, cmd := range []string{
+		`echo bypass`,
+		`go test ./cmd/block-destructive-commands`,
+		`grep -n bypass docs/block-destructive-commands.md`,
+	} {
+		if p, ok := bypassAttempt(cmd); ok {
+			t.Errorf("bypassAttempt(%q) = %q, want no match", cmd, p.name)
+		}
+	}
+}
+
+func TestBypassTokenLiftsOnlyItsPattern(t *testing.T) {
+	paths := testBypassPaths(t)
+	now := time.Unix(1_700_000_000, 0)
+	path, err := createBypassToken(paths, "git reset", 5*time.Minute, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := "git reset x; rm -rf ~"
+	got := applyBypass(denyVerdict(cmd), cmd, "", hookConfig{}, paths, now)
+	if got.decision != decisionDeny || !strings.Contains(got.reason, "rm -rf") {
+		t.Fatalf("compound command = %s (%s), want deny for the rm", got.decision, got.reason)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("token consumed by a command it couldn't allow: %v", err)
+	}
+
+	// A confirm match the token doesn't cover still asks.
+	cmd = "git reset --hard && npm cache clean --force"
+	if got := applyBypass(denyVerdict(cmd), cmd, "", hookConfig{}, paths, now); got.decision != decisionAsk {
+		t.Errorf("reset plus cache clean = %s (%s), want ask", got.decision, got.reason)
+	}
+}
+
+func TestBypassTokenLiftsGitWhitelist(t *testing.T) {
+	paths := testBypassPaths(t)
+	now := time.Unix(1_700_000_000, 0)
+	if _, err := createBypassToken(paths, "git update-ref", 5*time.Minute, now); err != nil {
+		t.Fatal(err)
+	}
+	cmd := "git update-ref refs/heads/main HEAD~5"
+	if got := applyBypass(denyVerdict(cmd), cmd, "", hookConfig{}, paths, now); got.decision != decisionAllow {
+		t.Errorf("unlisted subcommand named by the token = %s (%s), want allow", got.decision, got.reason)
+	}
+}
+
+func TestBypassFilesBlockedByPath(t *testing.T) {
+	paths := testBypassPaths(t)
+	now := time.Unix(1_700_000_000, 0)
+	if _, err := createBypassToken(paths, "cat", 5*time.Minute, now); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(t.TempDir(), "k")
+	if err := os.Symlink(paths.key, link); err != nil {
+		t.Fatal(err)
+	}
+	cwd := filepath.Dir(paths.key)
+
+	for _, cmd := range []string{
+		`cat ~/.claude/*.key`,
+		`cat "$HOME"/.*/block-*`,
+		`cat *.key`,
+		`cat ` + link,
+		`ls ` + paths.dir,
+	} {
+		if got := applyBypass(verdict{decision: decisionAllow}, cmd, cwd, hookConfig{}, paths, now); got.decision != decisionDeny {
+			t.Errorf("applyBypass(%q) = %s, want deny", cmd, got.decision)
+		}
+	}
+	for _, cmd := range []string{`cat ~/.claude/settings.json`, `ls ~/.claude`, `cat *.json`} {
+		if got := applyBypass(verdict{decision: decisionAllow}, cmd, cwd, hookConfig{}, paths, now); got.decision != decisionAllow {
+			t.Errorf("applyBypass(%q) = %s (%s), want allow", cmd, got.decision, got.reason)
+		}
+	}
+}
+
+func TestBypassFilesBlockedForFileTools(t *testing.T) {
+	paths := testBypassPaths(t)
+	if _, err := createBypassToken(paths, "cat", 5*time.Minute, time.Unix(1_700_000_000, 0)); err != nil {
+		t.Fatal(err)
+	}
+	project := filepath.Join(paths.home, "project")
+	forged := filepath.Join(paths.dir, "forged.json")
+
+	tests := []struct {
+		name                          string
+		tool, filePath, root, pattern string
+		deny                          bool
+	}{
+		{"Read the key", "Read", "~/.claude/block-destructive-commands.key", "", "", true},
+		{"Edit the key relative to cwd", "Edit", "../.claude/block-destructive-commands.key", "", "", true},
+		{"Write a token", "Write", forged, "", "", true},
+		{"MultiEdit a token", "MultiEdit", forged, "", "", true},
+		{"Grep the home directory", "Grep", "", paths.home, "[0-9a-f]{64}", true},
+		{"Grep the token directory", "Grep", "", paths.dir, "sig", true},
+		{"Glob for the key", "Glob", "", paths.home, "**/*.key", true},
+		{"Read a project file", "Read", filepath.Join(project, "main.go"), "", "", false},
+		{"Grep the project", "Grep", "", "", "TODO", false},
+		{"Glob the project", "Glob", "", "", "**/*.ts", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fileToolVerdict(tt.tool, tt.filePath, tt.root, tt.pattern, project, paths)
+			if deny := got.decision == decisionDeny; deny != tt.deny {
+				t.Errorf("deny = %v, want %v (%s)", deny, tt.deny, got.reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+)
+
+// diskDeviceTargetRegex finds each disk device a command writes to, through
+// dd's of= or a shell redirect, and captures the device path.
+var diskDeviceTargetRegex = regexp.MustCompile(`(?i)(?:\bof\s*=\s*|>\s*)["']?(/dev/(?:sd|hd|nvme|vd|xvd|disk)[^\s"';&|)]*)`)
+
+// auditOutput receives a line for every command let through by
+// allowedDevices; tests replace it.
+var auditOutput io.Writer = os.Stderr
+
+// allowsDevices reports whether every disk device cmd writes to is listed in
+// AllowedDevices. Nothing is allowed without the setting, or when no target
+// can be read from the command.
+func (c hookConfig) allowsDevices(cmd string) bool {
+	if len(c.AllowedDevices) == 0 {
+		return false
+	}
+	targets := diskDeviceTargetRegex.FindAllStringSubmatch(cmd, -1)
+	if len(targets) == 0 {
+		return false
+	}
+	for _, t := range targets {
+		if !slices.Contains(c.AllowedDevices, t[1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// auditAllowedDevice records a disk write that allowedDevices let through.
+func auditAllowedDevice(p pattern, cmd string) {
+	fmt.Fprintf(auditOutput, "block-destructive-commands: allowed %s to a whitelisted device: %s\n", p.name, cmd)
+}
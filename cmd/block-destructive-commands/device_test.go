@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEvaluateAllowedDevices(t *testing.T) {
+	var audit bytes.Buffer
+	auditOutput = &audit
+	t.Cleanup(func() { auditOutput = os.Stderr })
+
+	cfg := hookConfig{AllowedDevices: []string{"/dev/disk4", "/dev/sdb"}}
+	tests := []struct {
+		command  string
+		decision string
+	}{
+		{"dd if=pi.img of=/dev/disk4 bs=4m", decisionAllow},
+		{"dd if=image.iso of=/dev/sdb status=progress", decisionAllow},
+		{"cat image.img > /dev/sdb", decisionAllow},
+		{"dd if=pi.img of=/dev/disk5 bs=4m", decisionDeny},
+		{"dd if=pi.img of=/dev/disk4s1", decisionDeny},
+		{"dd if=/dev/zero of=/dev/sda", decisionDeny},
+		{"dd if=pi.img of=/dev/disk4 && dd if=/dev/zero of=/dev/disk2", decisionDeny},
+		{"dd if=pi.img of=/dev/disk4 && rm -rf /", decisionDeny},
+	}
+	for _, tt := range tests {
+		if got := evaluate(tt.command, cfg); got.decision != tt.decision {
+			t.Errorf("evaluate(%q) = %s (%s), want %s", tt.command, got.decision, got.reason, tt.decision)
+		}
+	}
+	if !strings.Contains(audit.String(), "whitelisted device: dd if=pi.img of=/dev/disk4 bs=4m") {
+		t.Errorf("audit log = %q, want the allowed dd recorded", audit.String())
+	}
+}
+
+func TestEvaluateDiskDevicesBlockedByDefault(t *testing.T) {
+	for _, cmd := range []string{"dd if=pi.img of=/dev/disk4", "echo x > /dev/sdb"} {
+		if got := evaluate(cmd, hookConfig{}); got.decision != decisionDeny {
+			t.Errorf("evaluate(%q) = %s, want deny without allowedDevices", cmd, got.decision)
+		}
+	}
+}
+
+func TestLoadHookConfigAllowedDevices(t *testing.T) {
+	root := t.TempDir()
+	config := `{"blockDestructiveCommandsConfig": {"allowedDevices": ["/dev/disk4"]}}`
+	if err := os.WriteFile(filepath.Join(root, preCommitConfigFile), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if cfg := loadHookConfig(root); len(cfg.AllowedDevices) != 1 || cfg.AllowedDevices[0] != "/dev/disk4" {
+		t.Errorf("AllowedDevices = %v, want [/dev/disk4]", cfg.AllowedDevices)
+	}
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/milehighideas/claude-hooks/internal/jsonc"
+)
+
+// preCommitConfigFile is the shared project config; this hook reads only its
+// blockDestructiveCommandsConfig block.
+const preCommitConfigFile = ".pre-commit.json"
+
+// forcePushPolicy narrows the force-push block to protected targets. The zero
+// value (nothing configured) blocks every force push.
+type forcePushPolicy struct {
+	// ProtectedRemotes are remote names (e.g. "origin") that never accept a
+	// force push.
+	ProtectedRemotes []string `json:"protectedRemotes,omitempty"`
+	// ProtectedBranches are branch names or path.Match globs (e.g. "main",
+	// "release/*") that never accept a force push, on any remote.
+	ProtectedBranches []string `json:"protectedBranches,omitempty"`
+}
+
+// configured reports whether the policy relaxes the default full block.
+func (p forcePushPolicy) configured() bool {
+	return len(p.ProtectedRemotes) > 0 || len(p.ProtectedBranches) > 0
+}
+
+// findPreCommitRoot walks up from dir looking for .pre-commit.json. Returns
+// "" when none is found.
+func findPreCommitRoot(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(abs, preCommitConfigFile)); err == nil {
+			return abs
+		}
+		parent := filepath.Dir(abs)
+		if parent == abs {
+			return ""
+		}
+		abs = parent
+	}
+}
+
+// loadHookConfig reads blockDestructiveCommandsConfig from the nearest
+// .pre-commit.json above cwd. Missing or malformed config yields the zero
+// config, which keeps force push fully blocked and every pattern at its
+// built-in severity.
+func loadHookConfig(cwd string) hookConfig {
+	root := findPreCommitRoot(cwd)
+	if root == "" {
+		return hookConfig{}
+	}
+	data, err := jsonc.ReadFile(filepath.Join(root, preCommitConfigFile))
+	if err != nil || len(bytes.TrimSpace(data)) == 0 {
+		return hookConfig{}
+	}
+	var rc struct {
+		BlockDestructiveCommandsConfig hookConfig `json:"blockDestructiveCommandsConfig"`
+	}
+	if err := json.Unmarshal(data, &rc); err != nil {
+		return hookConfig{}
+	}
+	return rc.BlockDestructiveCommandsConfig
+}
+
+// loadForcePushPolicy returns the force-push part of loadHookConfig.
+func loadForcePushPolicy(cwd string) forcePushPolicy {
+	return loadHookConfig(cwd).forcePushPolicy
+}
+
+// commandSeparatorRegex splits a shell line into the commands it chains.
+var commandSeparatorRegex = regexp.MustCompile(`&&|\|\||[;|\n]`)
+
+// pushValueFlags are git push options that take their value as the next
+// argument.
+var pushValueFlags = map[string]bool{
+	"--repo":         true,
+	"-o":             true,
+	"--push-option":  true,
+	"--receive-pack": true,
+	"--exec":         true,
+}
+
+// pushTarget is one parsed `git push`: the remote, the destination branches,
+// and whether any form of force was requested.
+type pushTarget struct {
+	remote   string
+	branches []string
+	force    bool
+	// unresolved is set when the destination cannot be determined from the
+	// command alone (no refspec, HEAD, --all, --mirror, ...).
+	unresolved bool
+}
+
+// parsePushTargets returns every `git push` in cmd.
+func parsePushTargets(cmd string) []pushTarget {
+	var targets []pushTarget
+	for _, segment := range commandSeparatorRegex.Split(cmd, -1) {
+		fields := strings.Fields(segment)
+		pushAt := -1
+		for i := 0; i+1 < len(fields); i++ {
+			if fields[i] == "git" {
+				for j := i + 1; j < len(fields); j++ {
+					if fields[j] == "push" {
+						pushAt = j
+						break
+					}
+					// Skip global flags such as -C <dir> or -c key=val.
+					if fields[j] == "-C" || fields[j] == "-c" {
+						j++
+						continue
+					}
+					if !strings.HasPrefix(fields[j], "-") {
+						break
+					}
+				}
+				break
+			}
+		}
+		if pushAt < 0 {
+			continue
+		}
+		targets = append(targets, parsePushArgs(fields[pushAt+1:]))
+	}
+	return targets
+}
+
+// parsePushArgs interprets the arguments after `git push`.
+func parsePushArgs(args []string) pushTarget {
+	var t pushTarget
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--force" || arg == "--force-if-includes" || strings.HasPrefix(arg, "--force-with-lease"):
+			t.force = true
+		case arg == "--all" || arg == "--mirror" || arg == "--branches" || arg == "--tags":
+			t.unresolved = true
+		case pushValueFlags[arg]:
+			i++
+		case strings.HasPrefix(arg, "--"):
+		case strings.HasPrefix(arg, "-"):
+			// Bundled short flags: -f, -fu, -uf ...
+			if strings.Contains(arg, "f") {
+				t.force = true
+			}
+		default:
+			positional = append(positional, strings.Trim(arg, `"'`))
+		}
+	}
+
+	if len(positional) == 0 {
+		t.unresolved = true
+		return t
+	}
+	t.remote = positional[0]
+	refspecs := positional[1:]
+	if len(refspecs) == 0 {
+		t.unresolved = true
+	}
+	for _, ref := range refspecs {
+		if strings.HasPrefix(ref, "+") {
+			t.force = true
+			ref = ref[1:]
+		}
+		if i := strings.Index(ref, ":"); i >= 0 {
+			ref = ref[i+1:]
+		}
+		ref = strings.TrimPrefix(ref, "refs/heads/")
+		if ref == "" || ref == "HEAD" {
+			t.unresolved = true
+			continue
+		}
+		t.branches = append(t.branches, ref)
+	}
+	return t
+}
+
+// isProtectedBranch reports whether branch matches a protected name or glob.
+func (p forcePushPolicy) isProtectedBranch(branch string) bool {
+	for _, pattern := range p.ProtectedBranches {
+		if pattern == branch {
+			return true
+		}
+		if ok, _ := path.Match(pattern, branch); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsForcePush reports whether every force push in cmd targets an
+// unprotected remote and branch. Unconfigured policies, and pushes whose
+// destination cannot be read from the command, are never allowed.
+func (p forcePushPolicy) allowsForcePush(cmd string) bool {
+	if !p.configured() {
+		return false
+	}
+	forced := 0
+	for _, t := range parsePushTargets(cmd) {
+		if !t.force {
+			continue
+		}
+		forced++
+		if t.unresolved {
+			return false
+		}
+		for _, remote := range p.ProtectedRemotes {
+			if t.remote == remote {
+				return false
+			}
+		}
+		for _, branch := range t.branches {
+			if p.isProtectedBranch(branch) {
+				return false
+			}
+		}
+	}
+	return forced > 0
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestForcePushPolicy(t *testing.T) {
+	policy := forcePushPolicy{
+		ProtectedRemotes:  []string{"origin"},
+		ProtectedBranches: []string{"main", "release/*"},
+	}
+
+	tests := []struct {
+		name    string
+		command string
+		allowed bool
+	}{
+		// Unprotected remote and branch
+		{"fork feature force", "git push --force fork feature/login", true},
+		{"fork feature short", "git push -f fork feature/login", true},
+		{"fork feature lease", "git push --force-with-lease fork feature/login", true},
+		{"lease with expect", "git push --force-with-lease=feature/login:abc123 fork feature/login", true},
+		{"bundled short flags", "git push -fu fork feature/login", true},
+		{"flag after refspec", "git push fork feature/login --force-with-lease", true},
+		{"src:dst refspec", "git push -f fork HEAD:feature/login", true},
+		{"refs/heads prefix", "git push -f fork refs/heads/feature/login", true},
+		{"global -C flag", "git -C repo push -f fork feature/login", true},
+		{"chained after cd", "cd app && git push -f fork feature/login", true},
+
+		// Protected remote
+		{"origin feature", "git push --force origin feature/login", false},
+		{"origin lease", "git push --force-with-lease origin feature/login", false},
+
+		// Protected branch on any remote
+		{"fork main", "git push -f fork main", false},
+		{"fork release glob", "git push -f fork release/2.0", false},
+		{"dst is protected", "git push -f fork feature/login:main", false},
+		{"plus refspec to main", "git push fork +main", false},
+		{"one of several protected", "git push -f fork feature/login main", false},
+
+		// Destination cannot be read from the command
+		{"no remote", "git push --force", false},
+		{"no refspec", "git push -f fork", false},
+		{"HEAD refspec", "git push -f fork HEAD", false},
+		{"all branches", "git push -f --all fork", false},
+		{"mirror", "git push --mirror --force fork", false},
+
+		// Every force push in a chain must be allowed
+		{"chained protected", "git push -f fork feature/a && git push -f origin feature/b", false},
+		{"chained unprotected", "git push -f fork feature/a; git push -f fork feature/b", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.allowsForcePush(tt.command); got != tt.allowed {
+				t.Errorf("allowsForcePush(%q) = %v, want %v", tt.command, got, tt.allowed)
+			}
+		})
+	}
+}
+
+func TestForcePushPolicyUnconfiguredBlocksAll(t *testing.T) {
+	for _, cmd := range []string{
+		"git push --force fork feature/login",
+		"git push --force-with-lease fork feature/login",
+	} {
+		if (forcePushPolicy{}).allowsForcePush(cmd) {
+			t.Errorf("unconfigured policy allowed %q", cmd)
+		}
+	}
+}
+
+func TestForcePushPolicyOnlyBranches(t *testing.T) {
+	policy := forcePushPolicy{ProtectedBranches: []string{"main"}}
+	if !policy.allowsForcePush("git push -f origin feature/login") {
+		t.Error("expected force push of a feature branch to origin to be allowed")
+	}
+	if policy.allowsForcePush("git push -f origin main") {
+		t.Error("expected force push to main to be blocked")
+	}
+}
+
+func TestLoadForcePushPolicy(t *testing.T) {
+	root := t.TempDir()
+	sub := filepath.Join(root, "apps", "web")
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := loadForcePushPolicy(sub); got.configured() {
+		t.Errorf("no config: got %+v, want zero policy", got)
+	}
+
+	config := `{
+  // JSONC comments are allowed
+  "blockDestructiveCommandsConfig": {
+    "protectedRemotes": ["origin"],
+    "protectedBranches": ["main", "release/*"]
+  }
+}`
+	if err := os.WriteFile(filepath.Join(root, preCommitConfigFile), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got := loadForcePushPolicy(sub)
+	if len(got.ProtectedRemotes) != 1 || got.ProtectedRemotes[0] != "origin" {
+		t.Errorf("ProtectedRemotes = %v, want [origin]", got.ProtectedRemotes)
+	}
+	if len(got.ProtectedBranches) != 2 {
+		t.Errorf("ProtectedBranches = %v, want [main release/*]", got.ProtectedBranches)
+	}
+}
@@ -2,7 +2,8 @@
 // dangerous git commands, repository destruction, and hook bypass attempts before they execute.
 //
 // Exit codes:
-//   - 0: Allow the command
+//   - 0: Allow the command, or ask the user to confirm it (prints an "ask"
+//     decision to stdout)
 //   - 2: Block the command (prints reason to stderr)
 package main
 
@@ -12,6 +13,7 @@ import (
 	"os"
 	"regexp"
 	"strings"
+	"time"
 )
 
 // pattern represents a blocked command pattern with its compiled regex and description.
@@ -19,318 +21,364 @@ type pattern struct {
 	regex   *regexp.Regexp
 	name    string
 	exclude *regexp.Regexp // If set, pattern doesn't match when exclude also matches
+	// forcePush marks force-push patterns, which a configured forcePushPolicy
+	// may allow for unprotected targets.
+	forcePush bool
+	// diskDevice marks writes to disk devices, which allowedDevices may allow
+	// for specific devices.
+	diskDevice bool
+	// category is the pattern group reported in the block message, e.g.
+	// "git-history-rewrite" or "database".
+	category string
+	// severity is severityBlock (the default when empty) or severityConfirm;
+	// blockDestructiveCommandsConfig.severity can override it per name.
+	severity string
 }
 
+// Pattern categories, reported as "BLOCKED [<category>]: ..." so the reason
+// shows which group of protections fired.
+const (
+	categoryHistoryRewrite  = "git-history-rewrite"
+	categoryDiscardChanges  = "git-discard-changes"
+	categoryRecovery        = "git-recovery-destruction"
+	categoryPlumbing        = "git-plumbing-bypass"
+	categoryGitModification = "git-modification"
+	categoryGitUnlisted     = "git-unlisted-subcommand"
+	categoryRepository      = "repository-destruction"
+	categoryFilesystem      = "filesystem"
+	categoryDisk            = "disk"
+	categorySystem          = "system"
+	categoryPermissions     = "permissions"
+	categoryDatabase        = "database"
+	categoryContainer       = "container"
+	categoryCloud           = "cloud"
+	categoryCodeExecution   = "code-execution"
+	categoryPrivilege       = "privilege-escalation"
+	categoryDeploy          = "deploy-safety"
+	categoryHookBypass      = "hook-bypass"
+)
+
 // hookInput represents the JSON structure from Claude Code's PreToolUse hook.
 // Claude Code sends nested JSON: {"tool_input": {"command": "git status"}, ...}
 type hookInput struct {
+	ToolName  string `json:"tool_name"`
 	ToolInput struct {
-		Command string `json:"command"`
+		Command  string `json:"command"`
+		FilePath string `json:"file_path"` // Read, Edit, Write, MultiEdit
+		Path     string `json:"path"`      // Grep and Glob search root
+		Pattern  string `json:"pattern"`   // Glob pattern (a regex for Grep)
 	} `json:"tool_input"`
 	Command string `json:"command"` // fallback for flat format (testing)
+	Cwd     string `json:"cwd"`
 }
 
 // destructivePatterns contains patterns that can cause catastrophic data loss or system damage.
 var destructivePatterns = []pattern{
 	// git reset - all forms can lose work
-	{regex: regexp.MustCompile(`(?i)\bgit\s+reset\b`), name: "git reset"},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+reset\b`), name: "git reset", category: categoryHistoryRewrite},
 
 	// git restore - discards uncommitted changes
-	{regex: regexp.MustCompile(`(?i)\bgit\s+restore\b`), name: "git restore"},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+restore\b`), name: "git restore", category: categoryDiscardChanges},
 
 	// git revert - modifies history unexpectedly
-	{regex: regexp.MustCompile(`(?i)\bgit\s+revert\b`), name: "git revert"},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+revert\b`), name: "git revert", category: categoryHistoryRewrite},
 
 	// git checkout - all forms blocked, user must do it manually
-	{regex: regexp.MustCompile(`(?i)\bgit\s+checkout\b`), name: "git checkout (user must run manually)"},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+checkout\b`), name: "git checkout (user must run manually)", category: categoryDiscardChanges},
 
 	// git clean - removes untracked files
-	{regex: regexp.MustCompile(`(?i)\bgit\s+clean\b`), name: "git clean"},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+clean\b`), name: "git clean", category: categoryDiscardChanges},
 
 	// git stash - all stash operations can disrupt workflow
 	// Match bare "git stash" at end of command OR followed by && or ; or |
-	{regex: regexp.MustCompile(`(?i)\bgit\s+stash\s*($|[;&|])`), name: "git stash (bare command)"},
-	{regex: regexp.MustCompile(`(?i)\bgit\s+stash\s+(push|drop|clear|pop|apply|save|branch|create|store)`), name: "git stash subcommands"},
-	{regex: regexp.MustCompile(`(?i)\bgit\s+stash\s+--`), name: "git stash with flags"},
-	{regex: regexp.MustCompile(`(?i)\bgit\s+stash\s+-[a-zA-Z]`), name: "git stash with flags"},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+stash\s*($|[;&|])`), name: "git stash (bare command)", category: categoryDiscardChanges},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+stash\s+(push|drop|clear|pop|apply|save|branch|create|store)`), name: "git stash subcommands", category: categoryDiscardChanges},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+stash\s+--`), name: "git stash with flags", category: categoryDiscardChanges},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+stash\s+-[a-zA-Z]`), name: "git stash with flags", category: categoryDiscardChanges},
 
 	// git push --force - rewrites remote history
-	{regex: regexp.MustCompile(`(?i)\bgit\s+push\s+.*--force`), name: "git push --force"},
-	{regex: regexp.MustCompile(`(?i)\bgit\s+push\s+-f\b`), name: "git push -f"},
+	// (blockDestructiveCommandsConfig can narrow this to protected targets)
+	{regex: regexp.MustCompile(`(?i)\bgit\s+push\s+.*--force`), name: "git push --force", forcePush: true, category: categoryHistoryRewrite},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+push\s+-f\b`), name: "git push -f", forcePush: true, category: categoryHistoryRewrite},
 
 	// git branch -D - force deletes branch (case-sensitive: -D is force, -d is safe)
-	{regex: regexp.MustCompile(`\bgit\s+branch\s+.*-D\b`), name: "git branch -D (force delete)"},
+	{regex: regexp.MustCompile(`\bgit\s+branch\s+.*-D\b`), name: "git branch -D (force delete)", category: categoryRecovery},
 
 	// git rm without --cached (deletes files)
-	{regex: regexp.MustCompile(`(?i)\bgit\s+rm\b`), name: "git rm (use --cached to keep files)", exclude: regexp.MustCompile(`(?i)--cached`)},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+rm\b`), name: "git rm (use --cached to keep files)", exclude: regexp.MustCompile(`(?i)--cached`), category: categoryDiscardChanges},
 
 	// === History Rewriting ===
 
 	// git rebase - rewrites commit history, can lose work during conflicts
-	{regex: regexp.MustCompile(`(?i)\bgit\s+rebase\b`), name: "git rebase"},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+rebase\b`), name: "git rebase", category: categoryHistoryRewrite},
 
 	// git commit --amend - rewrites the last commit
-	{regex: regexp.MustCompile(`(?i)\bgit\s+commit\s+.*--amend\b`), name: "git commit --amend"},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+commit\s+.*--amend\b`), name: "git commit --amend", category: categoryHistoryRewrite},
 
 	// git filter-branch / git filter-repo - rewrites entire repository history
-	{regex: regexp.MustCompile(`(?i)\bgit\s+filter-branch\b`), name: "git filter-branch"},
-	{regex: regexp.MustCompile(`(?i)\bgit\s+filter-repo\b`), name: "git filter-repo"},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+filter-branch\b`), name: "git filter-branch", category: categoryHistoryRewrite},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+filter-repo\b`), name: "git filter-repo", category: categoryHistoryRewrite},
 
 	// === Recovery Destruction ===
 
 	// git reflog - expire/delete removes safety net for recovering commits
-	{regex: regexp.MustCompile(`(?i)\bgit\s+reflog\s+(expire|delete)\b`), name: "git reflog expire/delete"},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+reflog\s+(expire|delete)\b`), name: "git reflog expire/delete", category: categoryRecovery},
 
 	// git gc --prune - permanently removes unreachable objects
-	{regex: regexp.MustCompile(`(?i)\bgit\s+gc\s+.*--prune`), name: "git gc --prune"},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+gc\s+.*--prune`), name: "git gc --prune", category: categoryRecovery},
 
 	// git update-ref -d - can delete refs including HEAD
-	{regex: regexp.MustCompile(`(?i)\bgit\s+update-ref\s+.*-d\b`), name: "git update-ref -d"},
-	{regex: regexp.MustCompile(`(?i)\bgit\s+update-ref\s+.*--delete\b`), name: "git update-ref --delete"},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+update-ref\s+.*-d\b`), name: "git update-ref -d", category: categoryRecovery},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+update-ref\s+.*--delete\b`), name: "git update-ref --delete", category: categoryRecovery},
 
 	// === Discard Changes ===
 
 	// git switch - all forms blocked, user must switch branches manually
-	{regex: regexp.MustCompile(`(?i)\bgit\s+switch\b`), name: "git switch (user must switch branches manually)"},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+switch\b`), name: "git switch (user must switch branches manually)", category: categoryDiscardChanges},
 
 	// git cherry-pick --abort - discards in-progress cherry-pick work
-	{regex: regexp.MustCompile(`(?i)\bgit\s+cherry-pick\s+.*--abort\b`), name: "git cherry-pick --abort"},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+cherry-pick\s+.*--abort\b`), name: "git cherry-pick --abort", category: categoryDiscardChanges},
 
 	// git merge --abort - discards merge in progress
-	{regex: regexp.MustCompile(`(?i)\bgit\s+merge\s+.*--abort\b`), name: "git merge --abort"},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+merge\s+.*--abort\b`), name: "git merge --abort", category: categoryDiscardChanges},
 
 	// git worktree remove --force - force removes worktree
-	{regex: regexp.MustCompile(`(?i)\bgit\s+worktree\s+remove\s+.*--force\b`), name: "git worktree remove --force"},
-	{regex: regexp.MustCompile(`(?i)\bgit\s+worktree\s+remove\s+.*-f\b`), name: "git worktree remove -f"},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+worktree\s+remove\s+.*--force\b`), name: "git worktree remove --force", category: categoryDiscardChanges},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+worktree\s+remove\s+.*-f\b`), name: "git worktree remove -f", category: categoryDiscardChanges},
 
 	// git submodule deinit --force - removes submodule working directory
-	{regex: regexp.MustCompile(`(?i)\bgit\s+submodule\s+deinit\s+.*--force\b`), name: "git submodule deinit --force"},
-	{regex: regexp.MustCompile(`(?i)\bgit\s+submodule\s+deinit\s+.*-f\b`), name: "git submodule deinit -f"},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+submodule\s+deinit\s+.*--force\b`), name: "git submodule deinit --force", category: categoryDiscardChanges},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+submodule\s+deinit\s+.*-f\b`), name: "git submodule deinit -f", category: categoryDiscardChanges},
 
 	// === Non-Git Repository Destruction ===
 
 	// rm -rf .git - destroys the entire repository
-	{regex: regexp.MustCompile(`(?i)\brm\s+.*-[a-zA-Z]*r[a-zA-Z]*f[a-zA-Z]*\s+.*\.git\b`), name: "rm -rf .git"},
-	{regex: regexp.MustCompile(`(?i)\brm\s+.*-[a-zA-Z]*f[a-zA-Z]*r[a-zA-Z]*\s+.*\.git\b`), name: "rm -fr .git"},
-	{regex: regexp.MustCompile(`(?i)\brm\s+.*\.git/`), name: "rm .git/ (repository file deletion)"},
-	{regex: regexp.MustCompile(`(?i)\brm\s+.*\.git/index\b`), name: "rm .git/index (staging area corruption)"},
+	{regex: regexp.MustCompile(`(?i)\brm\s+.*-[a-zA-Z]*r[a-zA-Z]*f[a-zA-Z]*\s+.*\.git\b`), name: "rm -rf .git", category: categoryRepository},
+	{regex: regexp.MustCompile(`(?i)\brm\s+.*-[a-zA-Z]*f[a-zA-Z]*r[a-zA-Z]*\s+.*\.git\b`), name: "rm -fr .git", category: categoryRepository},
+	{regex: regexp.MustCompile(`(?i)\brm\s+.*\.git/`), name: "rm .git/ (repository file deletion)", category: categoryRepository},
+	{regex: regexp.MustCompile(`(?i)\brm\s+.*\.git/index\b`), name: "rm .git/index (staging area corruption)", category: categoryRepository},
 
 	// Lock file deletion - can corrupt in-progress git operations
-	{regex: regexp.MustCompile(`(?i)\brm\s+.*\.git/index\.lock\b`), name: "rm .git/index.lock (can corrupt staging)"},
-	{regex: regexp.MustCompile(`(?i)\brm\s+.*\.git/.*\.lock\b`), name: "rm .git/*.lock (can corrupt git operations)"},
+	{regex: regexp.MustCompile(`(?i)\brm\s+.*\.git/index\.lock\b`), name: "rm .git/index.lock (can corrupt staging)", category: categoryRepository},
+	{regex: regexp.MustCompile(`(?i)\brm\s+.*\.git/.*\.lock\b`), name: "rm .git/*.lock (can corrupt git operations)", category: categoryRepository},
 
 	// === Filesystem Destruction ===
 
 	// rm -rf on critical paths
-	{regex: regexp.MustCompile(`(?i)\brm\s+.*-[a-zA-Z]*r[a-zA-Z]*f[a-zA-Z]*\s+/\s*$`), name: "rm -rf / (system wipe)"},
-	{regex: regexp.MustCompile(`(?i)\brm\s+.*-[a-zA-Z]*r[a-zA-Z]*f[a-zA-Z]*\s+/\*`), name: "rm -rf /* (system wipe)"},
-	{regex: regexp.MustCompile(`(?i)\brm\s+.*-[a-zA-Z]*r[a-zA-Z]*f[a-zA-Z]*\s+~/?`), name: "rm -rf ~ (home directory wipe)"},
-	{regex: regexp.MustCompile(`(?i)\brm\s+.*-[a-zA-Z]*r[a-zA-Z]*f[a-zA-Z]*\s+\$HOME`), name: "rm -rf $HOME (home directory wipe)"},
-	{regex: regexp.MustCompile(`(?i)\brm\s+.*-[a-zA-Z]*r[a-zA-Z]*f[a-zA-Z]*\s+\.\./?`), name: "rm -rf .. (parent directory wipe)"},
-	{regex: regexp.MustCompile(`(?i)\brm\s+.*-[a-zA-Z]*r[a-zA-Z]*f[a-zA-Z]*\s+\.\s*$`), name: "rm -rf . (current directory wipe)"},
-	{regex: regexp.MustCompile(`(?i)\brm\s+.*-[a-zA-Z]*r[a-zA-Z]*f[a-zA-Z]*\s+\*\s*$`), name: "rm -rf * (current directory wipe)"},
+	{regex: regexp.MustCompile(`(?i)\brm\s+.*-[a-zA-Z]*r[a-zA-Z]*f[a-zA-Z]*\s+/\s*$`), name: "rm -rf / (system wipe)", category: categoryFilesystem},
+	{regex: regexp.MustCompile(`(?i)\brm\s+.*-[a-zA-Z]*r[a-zA-Z]*f[a-zA-Z]*\s+/\*`), name: "rm -rf /* (system wipe)", category: categoryFilesystem},
+	{regex: regexp.MustCompile(`(?i)\brm\s+.*-[a-zA-Z]*r[a-zA-Z]*f[a-zA-Z]*\s+~/?`), name: "rm -rf ~ (home directory wipe)", category: categoryFilesystem},
+	{regex: regexp.MustCompile(`(?i)\brm\s+.*-[a-zA-Z]*r[a-zA-Z]*f[a-zA-Z]*\s+\$HOME`), name: "rm -rf $HOME (home directory wipe)", category: categoryFilesystem},
+	{regex: regexp.MustCompile(`(?i)\brm\s+.*-[a-zA-Z]*r[a-zA-Z]*f[a-zA-Z]*\s+\.\./?`), name: "rm -rf .. (parent directory wipe)", category: categoryFilesystem},
+	{regex: regexp.MustCompile(`(?i)\brm\s+.*-[a-zA-Z]*r[a-zA-Z]*f[a-zA-Z]*\s+\.\s*$`), name: "rm -rf . (current directory wipe)", category: categoryFilesystem},
+	{regex: regexp.MustCompile(`(?i)\brm\s+.*-[a-zA-Z]*r[a-zA-Z]*f[a-zA-Z]*\s+\*\s*$`), name: "rm -rf * (current directory wipe)", category: categoryFilesystem},
+
+	// find with a deletion action - one glob can remove a whole tree. The
+	// [^;&|]* keeps the match inside the find command itself.
+	{regex: regexp.MustCompile(`(?i)\bfind\b[^;&|]*\s-delete\b`), name: "find -delete (mass file deletion)", category: categoryFilesystem},
+	{regex: regexp.MustCompile(`(?i)\bfind\b[^;&|]*\s-(exec|execdir)\s+(\S*/)?rm\b`), name: "find -exec rm (mass file deletion)", category: categoryFilesystem},
 
 	// Critical system directories
-	{regex: regexp.MustCompile(`(?i)\brm\s+.*-[a-zA-Z]*r[a-zA-Z]*f[a-zA-Z]*\s+/(etc|var|usr|bin|sbin|lib|boot|root|home)\b`), name: "rm -rf system directory"},
-	{regex: regexp.MustCompile(`(?i)\brm\s+.*-[a-zA-Z]*r[a-zA-Z]*f[a-zA-Z]*\s+/Applications\b`), name: "rm -rf /Applications (macOS apps)"},
-	{regex: regexp.MustCompile(`(?i)\brm\s+.*-[a-zA-Z]*r[a-zA-Z]*f[a-zA-Z]*\s+/System\b`), name: "rm -rf /System (macOS system)"},
-	{regex: regexp.MustCompile(`(?i)\brm\s+.*-[a-zA-Z]*r[a-zA-Z]*f[a-zA-Z]*\s+/Library\b`), name: "rm -rf /Library (macOS library)"},
+	{regex: regexp.MustCompile(`(?i)\brm\s+.*-[a-zA-Z]*r[a-zA-Z]*f[a-zA-Z]*\s+/(etc|var|usr|bin|sbin|lib|boot|root|home)\b`), name: "rm -rf system directory", category: categoryFilesystem},
+	{regex: regexp.MustCompile(`(?i)\brm\s+.*-[a-zA-Z]*r[a-zA-Z]*f[a-zA-Z]*\s+/Applications\b`), name: "rm -rf /Applications (macOS apps)", category: categoryFilesystem},
+	{regex: regexp.MustCompile(`(?i)\brm\s+.*-[a-zA-Z]*r[a-zA-Z]*f[a-zA-Z]*\s+/System\b`), name: "rm -rf /System (macOS system)", category: categoryFilesystem},
+	{regex: regexp.MustCompile(`(?i)\brm\s+.*-[a-zA-Z]*r[a-zA-Z]*f[a-zA-Z]*\s+/Library\b`), name: "rm -rf /Library (macOS library)", category: categoryFilesystem},
 
 	// === Disk/Partition Destruction ===
 
 	// dd to disk devices - can wipe entire drives
-	{regex: regexp.MustCompile(`(?i)\bdd\s+.*of\s*=\s*/dev/(sd|hd|nvme|vd|xvd|disk)`), name: "dd to disk device (disk wipe)"},
-	{regex: regexp.MustCompile(`(?i)\bdd\s+.*of\s*=\s*/dev/null`), name: "dd to /dev/null", exclude: regexp.MustCompile(`.*`)}, // Allow this one actually
-	{regex: regexp.MustCompile(`(?i)>\s*/dev/(sd|hd|nvme|vd|xvd|disk)`), name: "redirect to disk device (disk wipe)"},
+	{regex: regexp.MustCompile(`(?i)\bdd\s+.*of\s*=\s*/dev/(sd|hd|nvme|vd|xvd|disk)`), name: "dd to disk device (disk wipe)", diskDevice: true, category: categoryDisk},
+	{regex: regexp.MustCompile(`(?i)\bdd\s+.*of\s*=\s*/dev/null`), name: "dd to /dev/null", exclude: regexp.MustCompile(`.*`), category: categoryDisk}, // Allow this one actually
+	{regex: regexp.MustCompile(`(?i)>\s*/dev/(sd|hd|nvme|vd|xvd|disk)`), name: "redirect to disk device (disk wipe)", diskDevice: true, category: categoryDisk},
 
 	// Filesystem formatting
-	{regex: regexp.MustCompile(`(?i)\bmkfs\b`), name: "mkfs (filesystem format)"},
-	{regex: regexp.MustCompile(`(?i)\bmkswap\b`), name: "mkswap (swap format)"},
-	{regex: regexp.MustCompile(`(?i)\bfdisk\b`), name: "fdisk (partition table modification)"},
-	{regex: regexp.MustCompile(`(?i)\bparted\b`), name: "parted (partition modification)"},
-	{regex: regexp.MustCompile(`(?i)\bgdisk\b`), name: "gdisk (GPT partition modification)"},
-	{regex: regexp.MustCompile(`(?i)\bdiskutil\s+(eraseDisk|eraseVolume|partitionDisk|secureErase)`), name: "diskutil destructive operation"},
+	{regex: regexp.MustCompile(`(?i)\bmkfs\b`), name: "mkfs (filesystem format)", category: categoryDisk},
+	{regex: regexp.MustCompile(`(?i)\bmkswap\b`), name: "mkswap (swap format)", category: categoryDisk},
+	{regex: regexp.MustCompile(`(?i)\bfdisk\b`), name: "fdisk (partition table modification)", category: categoryDisk},
+	{regex: regexp.MustCompile(`(?i)\bparted\b`), name: "parted (partition modification)", category: categoryDisk},
+	{regex: regexp.MustCompile(`(?i)\bgdisk\b`), name: "gdisk (GPT partition modification)", category: categoryDisk},
+	{regex: regexp.MustCompile(`(?i)\bdiskutil\s+(eraseDisk|eraseVolume|partitionDisk|secureErase)`), name: "diskutil destructive operation", category: categoryDisk},
 
 	// === System Commands ===
 
 	// System shutdown/reboot
-	{regex: regexp.MustCompile(`(?i)\bshutdown\b`), name: "shutdown"},
-	{regex: regexp.MustCompile(`(?i)\breboot\b`), name: "reboot", exclude: regexp.MustCompile(`(?i)\badb\s+reboot\b`)},
-	{regex: regexp.MustCompile(`(?i)\bhalt\b`), name: "halt"},
-	{regex: regexp.MustCompile(`(?i)\bpoweroff\b`), name: "poweroff"},
-	{regex: regexp.MustCompile(`(?i)\binit\s+[0-6]\b`), name: "init runlevel change"},
-	{regex: regexp.MustCompile(`(?i)\bsystemctl\s+(halt|poweroff|reboot|suspend|hibernate)`), name: "systemctl power command"},
+	{regex: regexp.MustCompile(`(?i)\bshutdown\b`), name: "shutdown", category: categorySystem},
+	{regex: regexp.MustCompile(`(?i)\breboot\b`), name: "reboot", exclude: regexp.MustCompile(`(?i)\badb\s+reboot\b`), category: categorySystem},
+	{regex: regexp.MustCompile(`(?i)\bhalt\b`), name: "halt", category: categorySystem},
+	{regex: regexp.MustCompile(`(?i)\bpoweroff\b`), name: "poweroff", category: categorySystem},
+	{regex: regexp.MustCompile(`(?i)\binit\s+[0-6]\b`), name: "init runlevel change", category: categorySystem},
+	{regex: regexp.MustCompile(`(?i)\bsystemctl\s+(halt|poweroff|reboot|suspend|hibernate)`), name: "systemctl power command", category: categorySystem},
 
 	// Process destruction
-	{regex: regexp.MustCompile(`(?i)\bkill\s+.*-9\s+(-1|1)\b`), name: "kill -9 -1 (kill all processes)"},
-	{regex: regexp.MustCompile(`(?i)\bkillall\s+-9\b`), name: "killall -9"},
-	{regex: regexp.MustCompile(`(?i)\bpkill\s+-9\b`), name: "pkill -9"},
+	{regex: regexp.MustCompile(`(?i)\bkill\s+.*-9\s+(-1|1)\b`), name: "kill -9 -1 (kill all processes)", category: categorySystem},
+	{regex: regexp.MustCompile(`(?i)\bkillall\s+-9\b`), name: "killall -9", category: categorySystem},
+	{regex: regexp.MustCompile(`(?i)\bpkill\s+-9\b`), name: "pkill -9", category: categorySystem},
 
 	// Fork bomb patterns
-	{regex: regexp.MustCompile(`:\(\)\s*\{\s*:\s*\|\s*:\s*&\s*\}\s*;`), name: "fork bomb"},
-	{regex: regexp.MustCompile(`(?i)\bforkbomb\b`), name: "fork bomb"},
+	{regex: regexp.MustCompile(`:\(\)\s*\{\s*:\s*\|\s*:\s*&\s*\}\s*;`), name: "fork bomb", category: categorySystem},
+	{regex: regexp.MustCompile(`(?i)\bforkbomb\b`), name: "fork bomb", category: categorySystem},
 
 	// === Permission Destruction ===
 
 	// Recursive chmod on system paths
-	{regex: regexp.MustCompile(`(?i)\bchmod\s+.*-[rR].*\s+/\s*$`), name: "chmod -R / (system permission change)"},
-	{regex: regexp.MustCompile(`(?i)\bchmod\s+.*-[rR].*\s+/(etc|var|usr|bin|sbin|lib|boot|root|home)\b`), name: "chmod -R system directory"},
-	{regex: regexp.MustCompile(`(?i)\bchmod\s+.*000\s`), name: "chmod 000 (remove all permissions)"},
-	{regex: regexp.MustCompile(`(?i)\bchmod\s+.*777\s+/`), name: "chmod 777 on system path"},
+	{regex: regexp.MustCompile(`(?i)\bchmod\s+.*-[rR].*\s+/\s*$`), name: "chmod -R / (system permission change)", category: categoryPermissions},
+	{regex: regexp.MustCompile(`(?i)\bchmod\s+.*-[rR].*\s+/(etc|var|usr|bin|sbin|lib|boot|root|home)\b`), name: "chmod -R system directory", category: categoryPermissions},
+	{regex: regexp.MustCompile(`(?i)\bchmod\s+.*000\s`), name: "chmod 000 (remove all permissions)", category: categoryPermissions},
+	{regex: regexp.MustCompile(`(?i)\bchmod\s+.*777\s+/`), name: "chmod 777 on system path", category: categoryPermissions},
 
 	// Recursive chown on system paths
-	{regex: regexp.MustCompile(`(?i)\bchown\s+.*-[rR].*\s+/\s*$`), name: "chown -R / (system ownership change)"},
-	{regex: regexp.MustCompile(`(?i)\bchown\s+.*-[rR].*\s+/(etc|var|usr|bin|sbin|lib|boot|root|home)\b`), name: "chown -R system directory"},
+	{regex: regexp.MustCompile(`(?i)\bchown\s+.*-[rR].*\s+/\s*$`), name: "chown -R / (system ownership change)", category: categoryPermissions},
+	{regex: regexp.MustCompile(`(?i)\bchown\s+.*-[rR].*\s+/(etc|var|usr|bin|sbin|lib|boot|root|home)\b`), name: "chown -R system directory", category: categoryPermissions},
 
 	// === Database Destruction ===
 
 	// SQL destructive commands
-	{regex: regexp.MustCompile(`(?i)\bDROP\s+(DATABASE|SCHEMA)\b`), name: "DROP DATABASE/SCHEMA"},
-	{regex: regexp.MustCompile(`(?i)\bDROP\s+TABLE\b`), name: "DROP TABLE"},
-	{regex: regexp.MustCompile(`(?i)\bTRUNCATE\s+(TABLE\s+)?\w`), name: "TRUNCATE TABLE"},
-	{regex: regexp.MustCompile(`(?i)\bDELETE\s+FROM\s+\w+\s*;`), name: "DELETE FROM without WHERE clause"},
-	{regex: regexp.MustCompile(`(?i)\bDELETE\s+FROM\s+\w+\s*$`), name: "DELETE FROM without WHERE clause"},
+	{regex: regexp.MustCompile(`(?i)\bDROP\s+(DATABASE|SCHEMA)\b`), name: "DROP DATABASE/SCHEMA", category: categoryDatabase},
+	{regex: regexp.MustCompile(`(?i)\bDROP\s+TABLE\b`), name: "DROP TABLE", category: categoryDatabase},
+	{regex: regexp.MustCompile(`(?i)\bTRUNCATE\s+(TABLE\s+)?\w`), name: "TRUNCATE TABLE", category: categoryDatabase},
+	{regex: regexp.MustCompile(`(?i)\bDELETE\s+FROM\s+\w+\s*;`), name: "DELETE FROM without WHERE clause", category: categoryDatabase},
+	{regex: regexp.MustCompile(`(?i)\bDELETE\s+FROM\s+\w+\s*$`), name: "DELETE FROM without WHERE clause", category: categoryDatabase},
 
 	// MongoDB destructive commands
-	{regex: regexp.MustCompile(`(?i)\.drop\s*\(\s*\)`), name: "MongoDB .drop()"},
-	{regex: regexp.MustCompile(`(?i)\.dropDatabase\s*\(\s*\)`), name: "MongoDB .dropDatabase()"},
-	{regex: regexp.MustCompile(`(?i)\.deleteMany\s*\(\s*\{\s*\}\s*\)`), name: "MongoDB .deleteMany({}) (delete all)"},
+	{regex: regexp.MustCompile(`(?i)\.drop\s*\(\s*\)`), name: "MongoDB .drop()", category: categoryDatabase},
+	{regex: regexp.MustCompile(`(?i)\.dropDatabase\s*\(\s*\)`), name: "MongoDB .dropDatabase()", category: categoryDatabase},
+	{regex: regexp.MustCompile(`(?i)\.deleteMany\s*\(\s*\{\s*\}\s*\)`), name: "MongoDB .deleteMany({}) (delete all)", category: categoryDatabase},
 
 	// Redis destructive commands
-	{regex: regexp.MustCompile(`(?i)\bFLUSHALL\b`), name: "Redis FLUSHALL"},
-	{regex: regexp.MustCompile(`(?i)\bFLUSHDB\b`), name: "Redis FLUSHDB"},
+	{regex: regexp.MustCompile(`(?i)\bFLUSHALL\b`), name: "Redis FLUSHALL", category: categoryDatabase},
+	{regex: regexp.MustCompile(`(?i)\bFLUSHDB\b`), name: "Redis FLUSHDB", category: categoryDatabase},
 
 	// === Docker/Container Destruction ===
 
 	// Docker system-wide destruction
-	{regex: regexp.MustCompile(`(?i)\bdocker\s+system\s+prune\s+.*-a`), name: "docker system prune -a (remove all)"},
-	{regex: regexp.MustCompile(`(?i)\bdocker\s+system\s+prune\s+.*--all`), name: "docker system prune --all"},
-	{regex: regexp.MustCompile(`(?i)\bdocker\s+(rm|rmi|volume\s+rm|network\s+rm)\s+.*-f`), name: "docker force remove"},
-	{regex: regexp.MustCompile(`(?i)\bdocker\s+(rm|rmi)\s+.*\$\(docker\s+(ps|images)`), name: "docker remove all containers/images"},
-	{regex: regexp.MustCompile(`(?i)\bdocker\s+container\s+prune\s+-f`), name: "docker container prune -f"},
-	{regex: regexp.MustCompile(`(?i)\bdocker\s+image\s+prune\s+-a`), name: "docker image prune -a"},
-	{regex: regexp.MustCompile(`(?i)\bdocker\s+volume\s+prune\s+-f`), name: "docker volume prune -f"},
+	{regex: regexp.MustCompile(`(?i)\bdocker\s+system\s+prune\s+.*-a`), name: "docker system prune -a (remove all)", category: categoryContainer},
+	{regex: regexp.MustCompile(`(?i)\bdocker\s+system\s+prune\s+.*--all`), name: "docker system prune --all", category: categoryContainer},
+	{regex: regexp.MustCompile(`(?i)\bdocker\s+(rm|rmi|volume\s+rm|network\s+rm)\s+.*-f`), name: "docker force remove", category: categoryContainer},
+	{regex: regexp.MustCompile(`(?i)\bdocker\s+(rm|rmi)\s+.*\$\(docker\s+(ps|images)`), name: "docker remove all containers/images", category: categoryContainer},
+	{regex: regexp.MustCompile(`(?i)\bdocker\s+container\s+prune\s+-f`), name: "docker container prune -f", category: categoryContainer},
+	{regex: regexp.MustCompile(`(?i)\bdocker\s+image\s+prune\s+-a`), name: "docker image prune -a", category: categoryContainer},
+	{regex: regexp.MustCompile(`(?i)\bdocker\s+volume\s+prune\s+-f`), name: "docker volume prune -f", category: categoryContainer},
 
 	// Docker Compose destruction
-	{regex: regexp.MustCompile(`(?i)\bdocker-compose\s+down\s+.*-v`), name: "docker-compose down -v (removes volumes)"},
-	{regex: regexp.MustCompile(`(?i)\bdocker\s+compose\s+down\s+.*-v`), name: "docker compose down -v (removes volumes)"},
+	{regex: regexp.MustCompile(`(?i)\bdocker-compose\s+down\s+.*-v`), name: "docker-compose down -v (removes volumes)", category: categoryContainer},
+	{regex: regexp.MustCompile(`(?i)\bdocker\s+compose\s+down\s+.*-v`), name: "docker compose down -v (removes volumes)", category: categoryContainer},
 
 	// === Kubernetes Destruction ===
 
 	// Namespace/cluster-wide deletion
-	{regex: regexp.MustCompile(`(?i)\bkubectl\s+delete\s+(namespace|ns)\b`), name: "kubectl delete namespace"},
-	{regex: regexp.MustCompile(`(?i)\bkubectl\s+delete\s+.*--all\s+--all-namespaces`), name: "kubectl delete all in all namespaces"},
-	{regex: regexp.MustCompile(`(?i)\bkubectl\s+delete\s+.*-A\s+--all`), name: "kubectl delete all cluster-wide"},
-	{regex: regexp.MustCompile(`(?i)\bkubectl\s+delete\s+all\s+--all`), name: "kubectl delete all --all"},
+	{regex: regexp.MustCompile(`(?i)\bkubectl\s+delete\s+(namespace|ns)\b`), name: "kubectl delete namespace", category: categoryContainer},
+	{regex: regexp.MustCompile(`(?i)\bkubectl\s+delete\s+.*--all\s+--all-namespaces`), name: "kubectl delete all in all namespaces", category: categoryContainer},
+	{regex: regexp.MustCompile(`(?i)\bkubectl\s+delete\s+.*-A\s+--all`), name: "kubectl delete all cluster-wide", category: categoryContainer},
+	{regex: regexp.MustCompile(`(?i)\bkubectl\s+delete\s+all\s+--all`), name: "kubectl delete all --all", category: categoryContainer},
 
 	// Helm destructive commands
-	{regex: regexp.MustCompile(`(?i)\bhelm\s+uninstall\s+.*--no-hooks`), name: "helm uninstall --no-hooks"},
+	{regex: regexp.MustCompile(`(?i)\bhelm\s+uninstall\s+.*--no-hooks`), name: "helm uninstall --no-hooks", category: categoryContainer},
 
 	// === Cloud/Infrastructure Destruction ===
 
 	// Terraform destruction
-	{regex: regexp.MustCompile(`(?i)\bterraform\s+destroy\b`), name: "terraform destroy"},
-	{regex: regexp.MustCompile(`(?i)\bterraform\s+apply\s+.*-destroy`), name: "terraform apply -destroy"},
-	{regex: regexp.MustCompile(`(?i)\btofu\s+destroy\b`), name: "tofu destroy"},
-	{regex: regexp.MustCompile(`(?i)\bpulumi\s+destroy\b`), name: "pulumi destroy"},
+	{regex: regexp.MustCompile(`(?i)\bterraform\s+destroy\b`), name: "terraform destroy", category: categoryCloud},
+	{regex: regexp.MustCompile(`(?i)\bterraform\s+apply\s+.*-destroy`), name: "terraform apply -destroy", category: categoryCloud},
+	{regex: regexp.MustCompile(`(?i)\btofu\s+destroy\b`), name: "tofu destroy", category: categoryCloud},
+	{regex: regexp.MustCompile(`(?i)\bpulumi\s+destroy\b`), name: "pulumi destroy", category: categoryCloud},
 
 	// AWS destructive commands
-	{regex: regexp.MustCompile(`(?i)\baws\s+s3\s+rm\s+.*--recursive`), name: "aws s3 rm --recursive"},
-	{regex: regexp.MustCompile(`(?i)\baws\s+s3\s+rb\s+.*--force`), name: "aws s3 rb --force (bucket deletion)"},
-	{regex: regexp.MustCompile(`(?i)\baws\s+ec2\s+terminate-instances\b`), name: "aws ec2 terminate-instances"},
-	{regex: regexp.MustCompile(`(?i)\baws\s+rds\s+delete-db-instance\b`), name: "aws rds delete-db-instance"},
-	{regex: regexp.MustCompile(`(?i)\baws\s+rds\s+delete-db-cluster\b`), name: "aws rds delete-db-cluster"},
-	{regex: regexp.MustCompile(`(?i)\baws\s+cloudformation\s+delete-stack\b`), name: "aws cloudformation delete-stack"},
+	{regex: regexp.MustCompile(`(?i)\baws\s+s3\s+rm\s+.*--recursive`), name: "aws s3 rm --recursive", category: categoryCloud},
+	{regex: regexp.MustCompile(`(?i)\baws\s+s3\s+rb\s+.*--force`), name: "aws s3 rb --force (bucket deletion)", category: categoryCloud},
+	{regex: regexp.MustCompile(`(?i)\baws\s+ec2\s+terminate-instances\b`), name: "aws ec2 terminate-instances", category: categoryCloud},
+	{regex: regexp.MustCompile(`(?i)\baws\s+rds\s+delete-db-instance\b`), name: "aws rds delete-db-instance", category: categoryCloud},
+	{regex: regexp.MustCompile(`(?i)\baws\s+rds\s+delete-db-cluster\b`), name: "aws rds delete-db-cluster", category: categoryCloud},
+	{regex: regexp.MustCompile(`(?i)\baws\s+cloudformation\s+delete-stack\b`), name: "aws cloudformation delete-stack", category: categoryCloud},
 
 	// GCP destructive commands
-	{regex: regexp.MustCompile(`(?i)\bgcloud\s+.*\s+delete\b`), name: "gcloud delete command"},
-	{regex: regexp.MustCompile(`(?i)\bgsutil\s+rm\s+.*-r`), name: "gsutil rm -r (recursive delete)"},
+	{regex: regexp.MustCompile(`(?i)\bgcloud\s+.*\s+delete\b`), name: "gcloud delete command", category: categoryCloud},
+	{regex: regexp.MustCompile(`(?i)\bgsutil\s+rm\s+.*-r`), name: "gsutil rm -r (recursive delete)", category: categoryCloud},
 
 	// Azure destructive commands
-	{regex: regexp.MustCompile(`(?i)\baz\s+group\s+delete\b`), name: "az group delete (resource group)"},
-	{regex: regexp.MustCompile(`(?i)\baz\s+.*\s+delete\b`), name: "az delete command"},
+	{regex: regexp.MustCompile(`(?i)\baz\s+group\s+delete\b`), name: "az group delete (resource group)", category: categoryCloud},
+	{regex: regexp.MustCompile(`(?i)\baz\s+.*\s+delete\b`), name: "az delete command", category: categoryCloud},
 
 	// === Arbitrary Code Execution ===
 
 	// Piping to shell - dangerous remote code execution
-	{regex: regexp.MustCompile(`(?i)\bcurl\s+.*\|\s*(ba)?sh\b`), name: "curl | sh (remote code execution)"},
-	{regex: regexp.MustCompile(`(?i)\bwget\s+.*\|\s*(ba)?sh\b`), name: "wget | sh (remote code execution)"},
-	{regex: regexp.MustCompile(`(?i)\bcurl\s+.*\|\s*sudo\b`), name: "curl | sudo (remote code as root)"},
-	{regex: regexp.MustCompile(`(?i)\bwget\s+.*\|\s*sudo\b`), name: "wget | sudo (remote code as root)"},
-	{regex: regexp.MustCompile(`(?i)\bcurl\s+.*\|\s*bash\s+-`), name: "curl | bash - (remote code execution)"},
-	{regex: regexp.MustCompile(`(?i)\bwget\s+.*-O\s*-\s*\|\s*(ba)?sh`), name: "wget -O - | sh (remote code execution)"},
+	{regex: regexp.MustCompile(`(?i)\bcurl\s+.*\|\s*(ba)?sh\b`), name: "curl | sh (remote code execution)", category: categoryCodeExecution},
+	{regex: regexp.MustCompile(`(?i)\bwget\s+.*\|\s*(ba)?sh\b`), name: "wget | sh (remote code execution)", category: categoryCodeExecution},
+	{regex: regexp.MustCompile(`(?i)\bcurl\s+.*\|\s*sudo\b`), name: "curl | sudo (remote code as root)", category: categoryCodeExecution},
+	{regex: regexp.MustCompile(`(?i)\bwget\s+.*\|\s*sudo\b`), name: "wget | sudo (remote code as root)", category: categoryCodeExecution},
+	{regex: regexp.MustCompile(`(?i)\bcurl\s+.*\|\s*bash\s+-`), name: "curl | bash - (remote code execution)", category: categoryCodeExecution},
+	{regex: regexp.MustCompile(`(?i)\bwget\s+.*-O\s*-\s*\|\s*(ba)?sh`), name: "wget -O - | sh (remote code execution)", category: categoryCodeExecution},
 
 	// eval with external input
-	{regex: regexp.MustCompile(`(?i)\beval\s+.*\$\(`), name: "eval with command substitution"},
-	{regex: regexp.MustCompile(`(?i)\beval\s+.*\bcurl\b`), name: "eval with curl"},
-	{regex: regexp.MustCompile(`(?i)\beval\s+.*\bwget\b`), name: "eval with wget"},
+	{regex: regexp.MustCompile(`(?i)\beval\s+.*\$\(`), name: "eval with command substitution", category: categoryCodeExecution},
+	{regex: regexp.MustCompile(`(?i)\beval\s+.*\bcurl\b`), name: "eval with curl", category: categoryCodeExecution},
+	{regex: regexp.MustCompile(`(?i)\beval\s+.*\bwget\b`), name: "eval with wget", category: categoryCodeExecution},
 
 	// === Privilege Escalation ===
 
 	// sudo - all sudo commands require user approval
-	{regex: regexp.MustCompile(`(?i)\bsudo\b`), name: "sudo (requires user approval)"},
+	{regex: regexp.MustCompile(`(?i)\bsudo\b`), name: "sudo (requires user approval)", category: categoryPrivilege},
 
 	// === Git Plumbing Bypasses ===
 	// Low-level plumbing commands that bypass the high-level protections above.
 
 	// git read-tree - resets/overwrites the index (staging area) to any tree-ish
 	// Bypasses: git reset, git restore --staged
-	{regex: regexp.MustCompile(`(?i)\bgit\s+read-tree\b`), name: "git read-tree (index manipulation bypass)"},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+read-tree\b`), name: "git read-tree (index manipulation bypass)", category: categoryPlumbing},
 
 	// git update-index - directly manipulates the index entries
 	// Can unstage files, hide changes (--assume-unchanged, --skip-worktree), or remove entries
 	// Bypasses: git reset, git restore --staged, git add
-	{regex: regexp.MustCompile(`(?i)\bgit\s+update-index\b`), name: "git update-index (direct index manipulation)"},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+update-index\b`), name: "git update-index (direct index manipulation)", category: categoryPlumbing},
 
 	// git symbolic-ref - changes what HEAD points to, effectively switching branches
 	// Bypasses: git checkout, git switch
 	// Allow read-only usage: git symbolic-ref [--short|-q] HEAD
 	// Block write usage: git symbolic-ref HEAD refs/heads/main (2 non-flag args)
-	{regex: regexp.MustCompile(`(?i)\bgit\s+symbolic-ref\s+(-\S+\s+)*[^-\s]\S*\s+[^-\s]`), name: "git symbolic-ref (HEAD manipulation bypass)"},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+symbolic-ref\s+(-\S+\s+)*[^-\s]\S*\s+[^-\s]`), name: "git symbolic-ref (HEAD manipulation bypass)", category: categoryPlumbing},
 
 	// git checkout-index - overwrites working tree files from the index
 	// Bypasses: git checkout -- <file>, git restore <file>
-	{regex: regexp.MustCompile(`(?i)\bgit\s+checkout-index\b`), name: "git checkout-index (working tree overwrite)"},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+checkout-index\b`), name: "git checkout-index (working tree overwrite)", category: categoryPlumbing},
 
 	// git replace - replaces any git object with another, can silently rewrite history
-	{regex: regexp.MustCompile(`(?i)\bgit\s+replace\b`), name: "git replace (object replacement)"},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+replace\b`), name: "git replace (object replacement)", category: categoryHistoryRewrite},
 
 	// === Convex Typecheck Bypass ===
 
 	// Convex commands with typecheck disabled - prevents deploying unchecked code
-	{regex: regexp.MustCompile(`(?i)\b(npx\s+)?convex\s+(dev|deploy)\s+.*--typecheck\s*=\s*disable`), name: "convex with --typecheck=disable (unsafe deployment)"},
-	{regex: regexp.MustCompile(`(?i)\b(npx\s+)?convex\s+(dev|deploy)\s+.*--typecheck\s+disable`), name: "convex with --typecheck disable (unsafe deployment)"},
+	{regex: regexp.MustCompile(`(?i)\b(npx\s+)?convex\s+(dev|deploy)\s+.*--typecheck\s*=\s*disable`), name: "convex with --typecheck=disable (unsafe deployment)", category: categoryDeploy},
+	{regex: regexp.MustCompile(`(?i)\b(npx\s+)?convex\s+(dev|deploy)\s+.*--typecheck\s+disable`), name: "convex with --typecheck disable (unsafe deployment)", category: categoryDeploy},
 }
 
 // hookBypassPatterns contains patterns that attempt to skip pre-commit hooks or checks.
 var hookBypassPatterns = []pattern{
 	// Environment variables that skip checks
-	{regex: regexp.MustCompile(`(?i)\bSKIP_PRECOMMIT_CHECKS\s*=`), name: "SKIP_PRECOMMIT_CHECKS"},
-	{regex: regexp.MustCompile(`(?i)\bSKIP_PRE_COMMIT\s*=`), name: "SKIP_PRE_COMMIT"},
-	{regex: regexp.MustCompile(`(?i)\bSKIP_HOOKS?\s*=`), name: "SKIP_HOOK(S)"},
-	{regex: regexp.MustCompile(`(?i)\bSKIP_TESTS\s*=`), name: "SKIP_TESTS"},
-	{regex: regexp.MustCompile(`(?i)\bHUSKY\s*=\s*0\b`), name: "HUSKY=0"},
-	{regex: regexp.MustCompile(`(?i)\bHUSKY_SKIP_HOOKS\s*=`), name: "HUSKY_SKIP_HOOKS"},
-	{regex: regexp.MustCompile(`(?i)\bPRE_COMMIT_ALLOW_NO_CONFIG\s*=`), name: "PRE_COMMIT_ALLOW_NO_CONFIG"},
+	{regex: regexp.MustCompile(`(?i)\bSKIP_PRECOMMIT_CHECKS\s*=`), name: "SKIP_PRECOMMIT_CHECKS", category: categoryHookBypass},
+	{regex: regexp.MustCompile(`(?i)\bSKIP_PRE_COMMIT\s*=`), name: "SKIP_PRE_COMMIT", category: categoryHookBypass},
+	{regex: regexp.MustCompile(`(?i)\bSKIP_HOOKS?\s*=`), name: "SKIP_HOOK(S)", category: categoryHookBypass},
+	{regex: regexp.MustCompile(`(?i)\bSKIP_TESTS\s*=`), name: "SKIP_TESTS", category: categoryHookBypass},
+	{regex: regexp.MustCompile(`(?i)\bHUSKY\s*=\s*0\b`), name: "HUSKY=0", category: categoryHookBypass},
+	{regex: regexp.MustCompile(`(?i)\bHUSKY_SKIP_HOOKS\s*=`), name: "HUSKY_SKIP_HOOKS", category: categoryHookBypass},
+	{regex: regexp.MustCompile(`(?i)\bPRE_COMMIT_ALLOW_NO_CONFIG\s*=`), name: "PRE_COMMIT_ALLOW_NO_CONFIG", category: categoryHookBypass},
 
 	// Git flags that skip hooks (use .* after git to handle global flags like -C)
-	{regex: regexp.MustCompile(`(?i)\bgit\s+.*\bcommit\s+.*--no-verify\b`), name: "git commit --no-verify"},
-	{regex: regexp.MustCompile(`(?i)\bgit\s+.*\bcommit\s+.*-n\b`), name: "git commit -n"},
-	{regex: regexp.MustCompile(`(?i)\bgit\s+.*\bpush\s+.*--no-verify\b`), name: "git push --no-verify"},
-	{regex: regexp.MustCompile(`(?i)\bgit\s+.*\bmerge\s+.*--no-verify\b`), name: "git merge --no-verify"},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+.*\bcommit\s+.*--no-verify\b`), name: "git commit --no-verify", category: categoryHookBypass},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+.*\bcommit\s+.*-n\b`), name: "git commit -n", category: categoryHookBypass},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+.*\bpush\s+.*--no-verify\b`), name: "git push --no-verify", category: categoryHookBypass},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+.*\bmerge\s+.*--no-verify\b`), name: "git merge --no-verify", category: categoryHookBypass},
 
 	// Git -c config overrides that bypass hooks or signing
-	{regex: regexp.MustCompile(`(?i)\bgit\s+.*-c\s+core\.hooksPath\s*=`), name: "git -c core.hooksPath (hook bypass via inline config)"},
-	{regex: regexp.MustCompile(`(?i)\bgit\s+.*--config-env\s*=\s*core\.hooksPath\s*=`), name: "git --config-env=core.hooksPath (hook bypass via config-env)"},
-	{regex: regexp.MustCompile(`(?i)\bgit\s+.*-c\s+commit\.gpgSign\s*=\s*(false|no|off|0)\b`), name: "git -c commit.gpgSign=false (signing bypass)"},
-	{regex: regexp.MustCompile(`(?i)\bgit\s+.*-c\s+tag\.gpgSign\s*=\s*(false|no|off|0)\b`), name: "git -c tag.gpgSign=false (signing bypass)"},
-	{regex: regexp.MustCompile(`(?i)\bgit\s+.*-c\s+gpg\.program\s*=`), name: "git -c gpg.program (GPG program override)"},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+.*-c\s+core\.hooksPath\s*=`), name: "git -c core.hooksPath (hook bypass via inline config)", category: categoryHookBypass},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+.*--config-env\s*=\s*core\.hooksPath\s*=`), name: "git --config-env=core.hooksPath (hook bypass via config-env)", category: categoryHookBypass},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+.*-c\s+commit\.gpgSign\s*=\s*(false|no|off|0)\b`), name: "git -c commit.gpgSign=false (signing bypass)", category: categoryHookBypass},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+.*-c\s+tag\.gpgSign\s*=\s*(false|no|off|0)\b`), name: "git -c tag.gpgSign=false (signing bypass)", category: categoryHookBypass},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+.*-c\s+gpg\.program\s*=`), name: "git -c gpg.program (GPG program override)", category: categoryHookBypass},
 
 	// Git environment variables that override config files
-	{regex: regexp.MustCompile(`(?i)\bGIT_CONFIG_GLOBAL\s*=`), name: "GIT_CONFIG_GLOBAL (global config override)"},
-	{regex: regexp.MustCompile(`(?i)\bGIT_CONFIG_NOSYSTEM\s*=`), name: "GIT_CONFIG_NOSYSTEM (system config bypass)"},
-	{regex: regexp.MustCompile(`(?i)\bGIT_CONFIG_SYSTEM\s*=`), name: "GIT_CONFIG_SYSTEM (system config override)"},
-	{regex: regexp.MustCompile(`(?i)\bGIT_DIR\s*=`), name: "GIT_DIR (git directory override)"},
+	{regex: regexp.MustCompile(`(?i)\bGIT_CONFIG_GLOBAL\s*=`), name: "GIT_CONFIG_GLOBAL (global config override)", category: categoryHookBypass},
+	{regex: regexp.MustCompile(`(?i)\bGIT_CONFIG_NOSYSTEM\s*=`), name: "GIT_CONFIG_NOSYSTEM (system config bypass)", category: categoryHookBypass},
+	{regex: regexp.MustCompile(`(?i)\bGIT_CONFIG_SYSTEM\s*=`), name: "GIT_CONFIG_SYSTEM (system config override)", category: categoryHookBypass},
+	{regex: regexp.MustCompile(`(?i)\bGIT_DIR\s*=`), name: "GIT_DIR (git directory override)", category: categoryHookBypass},
 }
 
 // gitCommandRegex detects any git command invocation and extracts the subcommand.
@@ -391,47 +439,59 @@ var allowedGitSubcommands = map[string]bool{
 // have specific subcommands/flags that modify state and should be blocked.
 var gitModifyingPatterns = []pattern{
 	// git remote - allow listing (git remote, git remote -v) but block modifications
-	{regex: regexp.MustCompile(`(?i)\bgit\s+remote\s+(add|remove|rm|rename|set-url|set-head|prune)\b`), name: "git remote modification (only listing allowed)"},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+remote\s+(add|remove|rm|rename|set-url|set-head|prune)\b`), name: "git remote modification (only listing allowed)", category: categoryGitModification},
 
 	// git tag - allow listing (git tag, git tag -l) but block create/delete
-	{regex: regexp.MustCompile(`(?i)\bgit\s+tag\s+(-d|--delete)\b`), name: "git tag delete"},
-	{regex: regexp.MustCompile(`(?i)\bgit\s+tag\s+(-a|--annotate|-s|--sign|-f|--force)\b`), name: "git tag create"},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+tag\s+(-d|--delete)\b`), name: "git tag delete", category: categoryGitModification},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+tag\s+(-a|--annotate|-s|--sign|-f|--force)\b`), name: "git tag create", category: categoryGitModification},
 	// git tag <name> (creating a tag - has a non-flag argument)
-	{regex: regexp.MustCompile(`(?i)\bgit\s+tag\s+[^-]\S*\s`), name: "git tag create"},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+tag\s+[^-]\S*\s`), name: "git tag create", category: categoryGitModification},
 
 	// git worktree - allow list/add but block remove/prune
-	{regex: regexp.MustCompile(`(?i)\bgit\s+worktree\s+(remove|prune)\b`), name: "git worktree modification (only list and add allowed)"},
+	{regex: regexp.MustCompile(`(?i)\bgit\s+worktree\s+(remove|prune)\b`), name: "git worktree modification (only list and add allowed)", category: categoryGitModification},
 
 	// git config - all modifications blocked
-	{regex: regexp.MustCompile(`(?i)\bgit\s+config\b`), name: "git config (user must modify config manually)"},
-}
-
-// blockResponse represents the JSON output Claude Code requires to deny a PreToolUse hook.
-type blockResponse struct {
-	HookSpecificOutput struct {
-		HookEventName            string `json:"hookEventName"`
-		PermissionDecision       string `json:"permissionDecision"`
-		PermissionDecisionReason string `json:"permissionDecisionReason"`
-	} `json:"hookSpecificOutput"`
+	{regex: regexp.MustCompile(`(?i)\bgit\s+config\b`), name: "git config (user must modify config manually)", category: categoryGitModification},
 }
 
 // block outputs the JSON deny response to stdout and a human-readable reason to stderr, then exits.
 func block(reason string) {
-	resp := blockResponse{}
-	resp.HookSpecificOutput.HookEventName = "PreToolUse"
-	resp.HookSpecificOutput.PermissionDecision = "deny"
-	resp.HookSpecificOutput.PermissionDecisionReason = reason
-	_ = json.NewEncoder(os.Stdout).Encode(resp)
+	_ = writeResponse(os.Stdout, decisionDeny, reason)
 	fmt.Fprintln(os.Stderr, reason)
 	os.Exit(2)
 }
 
+// confirm outputs the JSON ask response so Claude Code prompts the user, then
+// exits 0 so the prompt, not the hook, decides.
+func confirm(reason string) {
+	_ = writeResponse(os.Stdout, decisionAsk, reason)
+	os.Exit(0)
+}
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "bypass" {
+		os.Exit(runBypass(os.Args[2:], os.Stdout, os.Stderr))
+	}
+
 	var input hookInput
 	if err := json.NewDecoder(os.Stdin).Decode(&input); err != nil {
 		block(fmt.Sprintf("BLOCKED: failed to parse hook input: %v\n\nBlocking by default when input cannot be parsed.", err))
 	}
 
+	cwd := input.Cwd
+	if cwd == "" {
+		cwd, _ = os.Getwd()
+	}
+
+	// File tools only matter here for the bypass key and token directory
+	if input.ToolName != "" && input.ToolName != "Bash" {
+		in := input.ToolInput
+		if v := fileToolVerdict(input.ToolName, in.FilePath, in.Path, in.Pattern, cwd, defaultBypassPaths()); v.decision == decisionDeny {
+			block(v.reason)
+		}
+		os.Exit(0)
+	}
+
 	cmd := input.ToolInput.Command
 	if cmd == "" {
 		cmd = input.Command // fallback for flat format
@@ -440,49 +500,113 @@ func main() {
 		os.Exit(0)
 	}
 
-	// Check for destructive commands (specific blacklist with clear error messages)
-	for _, p := range destructivePatterns {
-		if p.regex.MatchString(cmd) {
+	cfg := loadHookConfig(cwd)
+	v := applyBypass(evaluate(cmd, cfg), cmd, cwd, cfg, defaultBypassPaths(), time.Now())
+	switch v.decision {
+	case decisionDeny:
+		block(v.reason)
+	case decisionAsk:
+		confirm(v.reason)
+	}
+	os.Exit(0)
+}
+
+// evaluate checks cmd against every pattern group. Any block-severity match
+// denies the command. Confirm-severity matches are collected instead, and the
+// first one asks the user once nothing blocks. A confirm match lifts the git
+// subcommand whitelist only for the git invocation it matched itself (a
+// demoted "git stash pop"), never for a git command elsewhere in cmd.
+func evaluate(cmd string, cfg hookConfig) verdict {
+	var ask *verdict
+	confirmedAt := map[int]bool{} // start offsets of confirm matches in cmd
+	match := func(patterns []pattern, reason func(pattern) string) *verdict {
+		for _, p := range patterns {
+			if !p.regex.MatchString(cmd) {
+				continue
+			}
+			// Skip patterns the bypass token being applied covers
+			if cfg.lifts(p) {
+				continue
+			}
 			// Skip if exclude pattern matches (e.g., git rm --cached is allowed)
 			if p.exclude != nil && p.exclude.MatchString(cmd) {
 				continue
 			}
-			block(fmt.Sprintf("BLOCKED: %s — %s is blocked because it can cause data loss. Ask the user to run it manually.", p.name, cmd))
+			// Skip force pushes the project allows (unprotected remote/branch)
+			if p.forcePush && cfg.allowsForcePush(cmd) {
+				continue
+			}
+			// Skip disk writes to devices the user whitelisted, leaving a trail
+			if p.diskDevice && cfg.allowsDevices(cmd) {
+				auditAllowedDevice(p, cmd)
+				continue
+			}
+			switch cfg.severityOf(p) {
+			case severityOff:
+				continue
+			case severityConfirm:
+				confirmedAt[p.regex.FindStringIndex(cmd)[0]] = true
+				if ask == nil {
+					ask = &verdict{decisionAsk, fmt.Sprintf("CONFIRM [%s]: %s — %s can cause data loss. Confirm with the user before running it.", p.category, p.name, cmd)}
+				}
+				continue
+			}
+			return &verdict{decisionDeny, reason(p)}
 		}
+		return nil
 	}
 
-	// Check for hook bypass attempts
-	for _, p := range hookBypassPatterns {
-		if p.regex.MatchString(cmd) {
-			// Skip if exclude pattern matches
-			if p.exclude != nil && p.exclude.MatchString(cmd) {
-				continue
-			}
-			block(fmt.Sprintf("BLOCKED: %s — Skipping pre-commit hooks is not allowed. Fix the underlying issues or ask the user to run the commit manually.", p.name))
+	// Check for destructive commands (specific blacklist with clear error messages)
+	destructiveReason := func(p pattern) string {
+		return fmt.Sprintf("BLOCKED [%s]: %s — %s is blocked because it can cause data loss. Ask the user to run it manually.", p.category, p.name, cmd)
+	}
+	if v := match(destructivePatterns, destructiveReason); v != nil {
+		return *v
+	}
+	if isPowerShell(cmd) {
+		if v := match(powershellPatterns, destructiveReason); v != nil {
+			return *v
 		}
 	}
+	if v := match(packageManagerPatterns, destructiveReason); v != nil {
+		return *v
+	}
+
+	// Only the user may mint bypass tokens or read their signing key
+	if p, ok := bypassAttempt(cmd); ok {
+		return verdict{decisionDeny, fmt.Sprintf("BLOCKED [%s]: %s — Bypass tokens must be created by the user in their own terminal.", p.category, p.name)}
+	}
+
+	// Check for hook bypass attempts
+	if v := match(hookBypassPatterns, func(p pattern) string {
+		return fmt.Sprintf("BLOCKED [%s]: %s — Skipping pre-commit hooks is not allowed. Fix the underlying issues or ask the user to run the commit manually.", p.category, p.name)
+	}); v != nil {
+		return *v
+	}
 
 	// Git whitelist check: if the command contains a git invocation,
 	// verify the subcommand is in the allowed list. This catches any
 	// plumbing commands or obscure subcommands not in the blacklist above.
-	if matches := gitCommandRegex.FindStringSubmatch(cmd); matches != nil {
-		subcommand := strings.ToLower(matches[1])
-
-		// Check if the subcommand is whitelisted
-		if !allowedGitSubcommands[subcommand] {
-			block(fmt.Sprintf("BLOCKED: git %s is not in the allowed git commands. Ask the user to run it manually.", subcommand))
-		}
+	if loc := gitCommandRegex.FindStringSubmatchIndex(cmd); loc != nil {
+		subcommand := strings.ToLower(cmd[loc[2]:loc[3]])
 
 		// Even for whitelisted subcommands, check for modifying patterns
-		for _, p := range gitModifyingPatterns {
-			if p.regex.MatchString(cmd) {
-				if p.exclude != nil && p.exclude.MatchString(cmd) {
-					continue
-				}
-				block(fmt.Sprintf("BLOCKED: %s — This git modification is not allowed. Ask the user to run it manually.", p.name))
-			}
+		modifying := match(gitModifyingPatterns, func(p pattern) string {
+			return fmt.Sprintf("BLOCKED [%s]: %s — This git modification is not allowed. Ask the user to run it manually.", p.category, p.name)
+		})
+
+		// Check if the subcommand is whitelisted, unless a confirm pattern
+		// matched this very git invocation
+		if !allowedGitSubcommands[subcommand] && !confirmedAt[loc[0]] && !cfg.liftsGitSubcommand(subcommand) {
+			return verdict{decisionDeny, fmt.Sprintf("BLOCKED [%s]: git %s is not in the allowed git commands. Ask the user to run it manually.", categoryGitUnlisted, subcommand)}
+		}
+		if modifying != nil {
+			return *modifying
 		}
 	}
 
-	os.Exit(0)
+	if ask != nil {
+		return *ask
+	}
+	return verdict{decision: decisionAllow}
 }
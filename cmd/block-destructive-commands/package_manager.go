@@ -0,0 +1,25 @@
+package main
+
+import "regexp"
+
+// categoryPackageManager groups package-manager operations that wipe local
+// state: caches, stores, node_modules and lockfiles. Nothing is lost that a
+// reinstall can't bring back, but a reinstall can be slow, offline-hostile,
+// or resolve different versions, so these ask the user instead of blocking.
+const categoryPackageManager = "package-manager"
+
+// packageManagerPatterns are the lower-risk category: every pattern ships as
+// severityConfirm, and unlike the hard-block patterns they can be switched
+// off entirely with "package-manager": "off" in the severity config.
+var packageManagerPatterns = []pattern{
+	// Cache and store pruning
+	{regex: regexp.MustCompile(`(?i)\bnpm\s+cache\s+(clean|clear|rm)\b`), name: "npm cache clean", category: categoryPackageManager, severity: severityConfirm},
+	{regex: regexp.MustCompile(`(?i)\byarn\s+cache\s+clean\b`), name: "yarn cache clean", category: categoryPackageManager, severity: severityConfirm},
+	{regex: regexp.MustCompile(`(?i)\bpnpm\s+store\s+prune\b`), name: "pnpm store prune", category: categoryPackageManager, severity: severityConfirm},
+	{regex: regexp.MustCompile(`(?i)\bbun\s+pm\s+cache\s+rm\b`), name: "bun pm cache rm", category: categoryPackageManager, severity: severityConfirm},
+
+	// Installed dependencies and lockfiles
+	{regex: regexp.MustCompile(`(?i)\brm\s+.*-[a-zA-Z]*r[a-zA-Z]*\s+.*\bnode_modules\b`), name: "rm -r node_modules", category: categoryPackageManager, severity: severityConfirm},
+	{regex: regexp.MustCompile(`(?i)\brimraf\s+.*\bnode_modules\b`), name: "rimraf node_modules", category: categoryPackageManager, severity: severityConfirm},
+	{regex: regexp.MustCompile(`(?i)\b(rm|rimraf)\s+.*\b(package-lock\.json|pnpm-lock\.yaml|yarn\.lock|bun\.lockb?)\b`), name: "lockfile deletion", category: categoryPackageManager, severity: severityConfirm},
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPackageManagerPatterns(t *testing.T) {
+	tests := []struct {
+		name     string
+		command  string
+		decision string
+		reason   string
+	}{
+		{"npm cache clean --force", "npm cache clean --force", decisionAsk, "CONFIRM [package-manager]: npm cache clean"},
+		{"npm cache clear", "npm cache clear", decisionAsk, "npm cache clean"},
+		{"yarn cache clean", "yarn cache clean", decisionAsk, "yarn cache clean"},
+		{"pnpm store prune", "pnpm store prune", decisionAsk, "pnpm store prune"},
+		{"bun pm cache rm", "bun pm cache rm", decisionAsk, "bun pm cache rm"},
+		{"rm -rf node_modules", "rm -rf node_modules", decisionAsk, "CONFIRM [package-manager]: rm -r node_modules"},
+		{"rm -rf node_modules and reinstall", "rm -rf node_modules && npm install", decisionAsk, "rm -r node_modules"},
+		{"rm -fr nested node_modules", "rm -fr apps/web/node_modules", decisionAsk, "rm -r node_modules"},
+		{"rimraf node_modules", "npx rimraf node_modules", decisionAsk, "rimraf node_modules"},
+		{"lockfile deletion", "rm pnpm-lock.yaml && pnpm install", decisionAsk, "lockfile deletion"},
+
+		// Hard-block patterns still win over a package-manager confirm
+		{"home wipe stays blocked", "rm -rf ~/node_modules", decisionDeny, "BLOCKED [filesystem]"},
+
+		// Everyday package-manager use is untouched
+		{"npm install", "npm install", decisionAllow, ""},
+		{"npm cache verify", "npm cache verify", decisionAllow, ""},
+		{"pnpm store status", "pnpm store status", decisionAllow, ""},
+		{"ls node_modules", "ls node_modules/.bin", decisionAllow, ""},
+		{"rm a single file", "rm node_modules/.cache/eslint", decisionAllow, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := evaluate(tt.command, hookConfig{})
+			if got.decision != tt.decision {
+				t.Fatalf("decision = %s (%s), want %s", got.decision, got.reason, tt.decision)
+			}
+			if !strings.Contains(got.reason, tt.reason) {
+				t.Errorf("reason = %q, want it to contain %q", got.reason, tt.reason)
+			}
+		})
+	}
+}
+
+func TestPackageManagerSeverityConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		severity map[string]string
+		command  string
+		decision string
+	}{
+		{"category off", map[string]string{categoryPackageManager: severityOff}, "npm cache clean --force", decisionAllow},
+		{"category block", map[string]string{categoryPackageManager: severityBlock}, "pnpm store prune", decisionDeny},
+		{"name wins over category", map[string]string{categoryPackageManager: severityOff, "npm cache clean": severityBlock}, "npm cache clean --force", decisionDeny},
+		{"off leaves other names in the category on", map[string]string{"npm cache clean": severityOff}, "yarn cache clean", decisionAsk},
+		{"off cannot silence a block pattern", map[string]string{"git reset": severityOff, categoryHistoryRewrite: severityOff}, "git reset --hard", decisionDeny},
+		{"category confirm for a block category", map[string]string{categoryDiscardChanges: severityConfirm}, "git clean -fd", decisionAsk},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := evaluate(tt.command, hookConfig{Severity: tt.severity})
+			if got.decision != tt.decision {
+				t.Errorf("evaluate(%q) = %s (%s), want %s", tt.command, got.decision, got.reason, tt.decision)
+			}
+		})
+	}
+}
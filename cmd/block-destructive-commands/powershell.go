@@ -0,0 +1,58 @@
+package main
+
+import "regexp"
+
+// powershellHintRegex decides whether a command is PowerShell: an explicit
+// powershell/pwsh invocation, $env: access, Verb-Noun cmdlet syntax, or a
+// PascalCase parameter such as -Recurse. The POSIX patterns never see these
+// commands' PowerShell meaning (rm -Recurse, del, ri), so powershellPatterns
+// only run when this matches.
+var powershellHintRegex = regexp.MustCompile(`(?i)\b(?:powershell|pwsh)(?:\.exe)?\b|\$env:|` +
+	`\b(?:Get|Set|New|Remove|Clear|Format|Stop|Start|Restart|Invoke|Copy|Move|Rename|Initialize|Reset|Out|Write|Select|Where|ForEach)-[A-Za-z]+\b|` +
+	`\s-(?:Recurse|Force|Path|LiteralPath|Confirm)\b`)
+
+// isPowerShell reports whether cmd looks like a PowerShell command.
+func isPowerShell(cmd string) bool {
+	return powershellHintRegex.MatchString(cmd)
+}
+
+const (
+	// psRemove matches Remove-Item and its built-in aliases.
+	psRemove = `\b(?:Remove-Item|rm|del|erase|ri|rd|rmdir)\b`
+
+	// psRecurse matches -Recurse and its accepted abbreviations (-r, -rec);
+	// callers make sure whitespace precedes it.
+	psRecurse = `-r(?:e(?:c(?:u(?:r(?:s(?:e)?)?)?)?)?)?\b`
+
+	// psCriticalPath matches a drive root, the home directory, or a Windows
+	// system directory as a whole argument.
+	psCriticalPath = `\s["']?(?:[A-Za-z]:[\\/]?\*?|~[\\/]?|\$HOME|\$env:(?:USERPROFILE|SystemRoot|windir|ProgramFiles)|[A-Za-z]:[\\/](?:Windows|Program Files(?: \(x86\))?|Users|ProgramData))["']?(?:\s|$)`
+
+	// psCurrentDir matches "." or "*" as the last argument.
+	psCurrentDir = `\s["']?(?:\.|\*|\.[\\/]\*)["']?\s*$`
+)
+
+// powershellPatterns are the PowerShell counterparts of the POSIX filesystem,
+// disk and system patterns, checked only when isPowerShell matches. Git and
+// database commands read the same in every shell and stay with the main set.
+var powershellPatterns = []pattern{
+	// === Repository Destruction ===
+	{regex: regexp.MustCompile(`(?i)` + psRemove + `.*(?:^|[\s"'\\/])\.git(?:[\s"'\\/]|$)`), name: "Remove-Item .git (repository deletion)", category: categoryRepository},
+
+	// === Filesystem Destruction ===
+	{regex: regexp.MustCompile(`(?i)` + psRemove + `.*\s` + psRecurse + `.*` + psCriticalPath + `|` + psRemove + `.*` + psCriticalPath + `(?:.*\s)?` + psRecurse), name: "Remove-Item -Recurse on a drive root, home or system directory", category: categoryFilesystem},
+	{regex: regexp.MustCompile(`(?i)` + psRemove + `.*\s` + psRecurse + `.*` + psCurrentDir), name: "Remove-Item -Recurse . (current directory wipe)", category: categoryFilesystem},
+	{regex: regexp.MustCompile(`(?i)\b(?:Clear-Content|clc)\b`), name: "Clear-Content (empties files)", category: categoryFilesystem},
+	{regex: regexp.MustCompile(`(?i)\bClear-RecycleBin\b`), name: "Clear-RecycleBin", category: categoryFilesystem},
+
+	// === Disk/Partition Destruction ===
+	{regex: regexp.MustCompile(`(?i)\bFormat-Volume\b`), name: "Format-Volume (filesystem format)", category: categoryDisk},
+	{regex: regexp.MustCompile(`(?i)\bClear-Disk\b`), name: "Clear-Disk (disk wipe)", category: categoryDisk},
+	{regex: regexp.MustCompile(`(?i)\bInitialize-Disk\b`), name: "Initialize-Disk (partition table reset)", category: categoryDisk},
+	{regex: regexp.MustCompile(`(?i)\bRemove-Partition\b`), name: "Remove-Partition", category: categoryDisk},
+
+	// === System Commands ===
+	{regex: regexp.MustCompile(`(?i)\b(?:Stop-Computer|Restart-Computer)\b`), name: "Stop-Computer/Restart-Computer", category: categorySystem},
+	{regex: regexp.MustCompile(`(?i)\b(?:Get-Process|gps|ps)\s*\|\s*(?:Stop-Process|spps|kill)\b`), name: "Get-Process | Stop-Process (kill all processes)", category: categorySystem},
+	{regex: regexp.MustCompile(`(?i)\b(?:Remove-Item|Remove-ItemProperty|rm|del|ri|rp)\b.*\b(?:HKLM|HKCU|HKCR|HKU|HKCC):`), name: "registry key removal", category: categorySystem},
+}
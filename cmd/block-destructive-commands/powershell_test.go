@@ -0,0 +1,93 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIsPowerShell(t *testing.T) {
+	tests := []struct {
+		command string
+		want    bool
+	}{
+		{"Remove-Item -Recurse -Force .git", true},
+		{"rm -Recurse -Force build", true},
+		{`pwsh -Command "ri -r .git"`, true},
+		{"powershell.exe -c del x", true},
+		{"echo $env:PATH", true},
+		{"rm -rf build", false},
+		{"git push --force origin feature", false},
+		{"ls -la", false},
+	}
+	for _, tt := range tests {
+		if got := isPowerShell(tt.command); got != tt.want {
+			t.Errorf("isPowerShell(%q) = %v, want %v", tt.command, got, tt.want)
+		}
+	}
+}
+
+func TestPowerShellPatterns(t *testing.T) {
+	tests := []struct {
+		name     string
+		command  string
+		blocked  bool
+		category string
+	}{
+		// === Repository destruction ===
+		{"Remove-Item .git", "Remove-Item -Recurse -Force .git", true, categoryRepository},
+		{"Remove-Item .git path first", `Remove-Item .\.git -Recurse -Force`, true, categoryRepository},
+		{"rm alias .git", "rm -Recurse -Force .git", true, categoryRepository},
+		{"pwsh ri alias .git", `pwsh -Command "ri -r -fo .git"`, true, categoryRepository},
+		{"del .git in powershell", `powershell -c "del -Recurse C:\repo\.git"`, true, categoryRepository},
+
+		// === Filesystem destruction ===
+		{"drive root", `Remove-Item -Recurse -Force C:\`, true, categoryFilesystem},
+		{"drive root wildcard", `Remove-Item C:\* -Recurse`, true, categoryFilesystem},
+		{"home directory", "Remove-Item -Recurse -Force ~", true, categoryFilesystem},
+		{"userprofile", "Remove-Item -Recurse $env:USERPROFILE", true, categoryFilesystem},
+		{"windows dir", `Remove-Item -Recurse -Force "C:\Windows"`, true, categoryFilesystem},
+		{"current directory", "Remove-Item -Recurse -Force *", true, categoryFilesystem},
+		{"Clear-Content", "Clear-Content app.log", true, categoryFilesystem},
+		{"Clear-RecycleBin", "Clear-RecycleBin -Force", true, categoryFilesystem},
+
+		// === Disk ===
+		{"Format-Volume", "Format-Volume -DriveLetter D", true, categoryDisk},
+		{"Clear-Disk", "Clear-Disk -Number 1 -RemoveData", true, categoryDisk},
+		{"Initialize-Disk", "Initialize-Disk -Number 2", true, categoryDisk},
+		{"Remove-Partition", "Remove-Partition -DriveLetter E", true, categoryDisk},
+
+		// === System ===
+		{"Stop-Computer", "Stop-Computer -Force", true, categorySystem},
+		{"Restart-Computer", "Restart-Computer", true, categorySystem},
+		{"kill all processes", "Get-Process | Stop-Process -Force", true, categorySystem},
+		{"registry", `Remove-Item -Path HKLM:\SOFTWARE\Vendor -Recurse`, true, categorySystem},
+
+		// === Allowed ===
+		{"remove build dir", "Remove-Item -Recurse -Force build", false, ""},
+		{"remove node_modules", `Remove-Item -Recurse -Force .\node_modules`, false, ""},
+		{"remove gitignore", "Remove-Item .gitignore", false, ""},
+		{"list files", "Get-ChildItem -Recurse", false, ""},
+		{"stop one process", "Stop-Process -Name node", false, ""},
+		{"posix rm build", "rm -rf build", false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := evaluate(tt.command, hookConfig{})
+			if blocked := got.decision == decisionDeny; blocked != tt.blocked {
+				t.Fatalf("command %q: blocked=%v (%s), want %v", tt.command, blocked, got.reason, tt.blocked)
+			}
+			if tt.blocked && !strings.HasPrefix(got.reason, "BLOCKED ["+tt.category+"]: ") {
+				t.Errorf("reason = %q, want category %s", got.reason, tt.category)
+			}
+		})
+	}
+}
+
+func TestPowerShellPatternsHaveCategories(t *testing.T) {
+	for _, p := range powershellPatterns {
+		if p.category == "" {
+			t.Errorf("pattern %q has no category", p.name)
+		}
+	}
+}
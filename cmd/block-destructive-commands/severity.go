@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// Pattern severities. A block match denies the command; a confirm match asks
+// Claude Code to prompt the user instead; an off pattern is skipped. Only
+// patterns that ship as confirm can be turned off.
+const (
+	severityBlock   = "block"
+	severityConfirm = "confirm"
+	severityOff     = "off"
+)
+
+// Permission decisions in the PreToolUse hook output.
+const (
+	decisionAllow = "allow"
+	decisionDeny  = "deny"
+	decisionAsk   = "ask"
+)
+
+// hookConfig is the blockDestructiveCommandsConfig block of .pre-commit.json.
+type hookConfig struct {
+	forcePushPolicy
+	// Severity maps pattern names (as shown in the block message, e.g.
+	// "git stash (bare command)") or categories (e.g. "package-manager") to
+	// "block", "confirm" or "off", overriding the pattern's built-in
+	// severity. A pattern name wins over its category. Other values, and
+	// "off" for built-in block patterns, are ignored.
+	Severity map[string]string `json:"severity,omitempty"`
+	// AllowedDevices are disk devices (e.g. "/dev/disk4") that dd and shell
+	// redirects may write to, for flashing SD cards and USB images. Each
+	// allowed command is logged to stderr.
+	AllowedDevices []string `json:"allowedDevices,omitempty"`
+
+	// bypass is the pattern text of a bypass token being applied: patterns
+	// that match it, and the git subcommand it names, are skipped.
+	bypass string
+}
+
+// lifts reports whether the bypass token being applied covers p.
+func (c hookConfig) lifts(p pattern) bool {
+	return c.bypass != "" && p.regex.MatchString(c.bypass)
+}
+
+// liftsGitSubcommand reports whether the bypass token being applied names
+// the git subcommand, e.g. "git update-ref" for update-ref.
+func (c hookConfig) liftsGitSubcommand(subcommand string) bool {
+	m := gitCommandRegex.FindStringSubmatch(c.bypass)
+	return m != nil && strings.EqualFold(m[1], subcommand)
+}
+
+// severityOf returns the effective severity of p: the override configured
+// for its name or category, else the pattern's own, else block.
+func (c hookConfig) severityOf(p pattern) string {
+	for _, key := range []string{p.name, p.category} {
+		switch s := c.Severity[key]; s {
+		case severityBlock, severityConfirm:
+			return s
+		case severityOff:
+			if p.severity == severityConfirm {
+				return s
+			}
+		}
+	}
+	if p.severity == severityConfirm {
+		return severityConfirm
+	}
+	return severityBlock
+}
+
+// verdict is the outcome of checking one command.
+type verdict struct {
+	decision string // decisionAllow, decisionDeny or decisionAsk
+	reason   string
+}
+
+// hookResponse is the JSON output Claude Code reads from a PreToolUse hook.
+type hookResponse struct {
+	HookSpecificOutput struct {
+		HookEventName            string `json:"hookEventName"`
+		PermissionDecision       string `json:"permissionDecision"`
+		PermissionDecisionReason string `json:"permissionDecisionReason"`
+	} `json:"hookSpecificOutput"`
+}
+
+// writeResponse writes the PreToolUse decision JSON to w.
+func writeResponse(w io.Writer, decision, reason string) error {
+	resp := hookResponse{}
+	resp.HookSpecificOutput.HookEventName = "PreToolUse"
+	resp.HookSpecificOutput.PermissionDecision = decision
+	resp.HookSpecificOutput.PermissionDecisionReason = reason
+	return json.NewEncoder(w).Encode(resp)
+}
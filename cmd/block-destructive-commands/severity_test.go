@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEvaluateDefaultsToBlock(t *testing.T) {
+	tests := []struct {
+		command  string
+		decision string
+	}{
+		{"git status", decisionAllow},
+		{"git stash", decisionDeny},
+		{"git reset --hard HEAD", decisionDeny},
+		{"git commit --no-verify -m x", decisionDeny},
+		{"git gc", decisionDeny},
+		{"git remote add origin x", decisionDeny},
+	}
+	for _, tt := range tests {
+		if got := evaluate(tt.command, hookConfig{}); got.decision != tt.decision {
+			t.Errorf("evaluate(%q) = %s (%s), want %s", tt.command, got.decision, got.reason, tt.decision)
+		}
+	}
+}
+
+func TestEvaluateConfirmSeverity(t *testing.T) {
+	cfg := hookConfig{Severity: map[string]string{
+		"git stash (bare command)": severityConfirm,
+		"git stash subcommands":    severityConfirm,
+		"git rebase":               "sometimes", // invalid values are ignored
+	}}
+
+	tests := []struct {
+		name     string
+		command  string
+		decision string
+		reason   string
+	}{
+		{"demoted pattern asks", "git stash", decisionAsk, "CONFIRM [git-discard-changes]: git stash (bare command)"},
+		{"confirm lifts the git whitelist", "git stash pop", decisionAsk, "git stash subcommands"},
+		{"confirm elsewhere keeps the git whitelist", "npm cache clean --force && git update-ref refs/heads/main HEAD~5", decisionDeny, "BLOCKED [git-unlisted-subcommand]: git update-ref"},
+		{"block elsewhere in the command wins", "git stash && git reset --hard", decisionDeny, "BLOCKED [git-history-rewrite]: git reset"},
+		{"invalid severity keeps block", "git rebase main", decisionDeny, "BLOCKED [git-history-rewrite]: git rebase"},
+		{"unrelated commands unaffected", "git log", decisionAllow, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := evaluate(tt.command, cfg)
+			if got.decision != tt.decision {
+				t.Fatalf("decision = %s (%s), want %s", got.decision, got.reason, tt.decision)
+			}
+			if !strings.Contains(got.reason, tt.reason) {
+				t.Errorf("reason = %q, want it to contain %q", got.reason, tt.reason)
+			}
+		})
+	}
+}
+
+func TestSeverityOfPromotesBuiltInConfirm(t *testing.T) {
+	p := pattern{name: "example", severity: severityConfirm}
+	if got := (hookConfig{}).severityOf(p); got != severityConfirm {
+		t.Errorf("built-in severity = %s, want confirm", got)
+	}
+	cfg := hookConfig{Severity: map[string]string{"example": severityBlock}}
+	if got := cfg.severityOf(p); got != severityBlock {
+		t.Errorf("promoted severity = %s, want block", got)
+	}
+}
+
+func TestWriteResponseAsk(t *testing.T) {
+	v := evaluate("git stash", hookConfig{Severity: map[string]string{"git stash (bare command)": severityConfirm}})
+
+	var buf bytes.Buffer
+	if err := writeResponse(&buf, v.decision, v.reason); err != nil {
+		t.Fatal(err)
+	}
+
+	var resp map[string]map[string]string
+	if err := json.Unmarshal(buf.Bytes(), &resp); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, buf.String())
+	}
+	out := resp["hookSpecificOutput"]
+	if out["hookEventName"] != "PreToolUse" {
+		t.Errorf("hookEventName = %q, want PreToolUse", out["hookEventName"])
+	}
+	if out["permissionDecision"] != "ask" {
+		t.Errorf("permissionDecision = %q, want ask", out["permissionDecision"])
+	}
+	if !strings.HasPrefix(out["permissionDecisionReason"], "CONFIRM [") {
+		t.Errorf("permissionDecisionReason = %q, want a CONFIRM reason", out["permissionDecisionReason"])
+	}
+}
+
+func TestLoadHookConfigSeverity(t *testing.T) {
+	root := t.TempDir()
+	config := `{
+  "blockDestructiveCommandsConfig": {
+    "protectedRemotes": ["origin"],
+    "severity": { "git stash (bare command)": "confirm" }
+  }
+}`
+	if err := os.WriteFile(filepath.Join(root, preCommitConfigFile), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := loadHookConfig(root)
+	if cfg.Severity["git stash (bare command)"] != severityConfirm {
+		t.Errorf("Severity = %v, want git stash demoted to confirm", cfg.Severity)
+	}
+	if len(cfg.ProtectedRemotes) != 1 {
+		t.Errorf("ProtectedRemotes = %v, want the force-push policy loaded alongside", cfg.ProtectedRemotes)
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/milehighideas/claude-hooks/internal/jsonc"
+)
+
+// convexGenConfigNames are the config files convex-gen reads, in its order.
+var convexGenConfigNames = []string{".convex-gen.json", "convex-gen.json"}
+
+// convexGenConfig is the part of .convex-gen.json that says where generated
+// files are written.
+type convexGenConfig struct {
+	DataLayer struct {
+		Path     string `json:"path"`
+		HooksDir string `json:"hooksDir"`
+		APIDir   string `json:"apiDir"`
+		TypesDir string `json:"typesDir"`
+	} `json:"dataLayer"`
+}
+
+// protectedPathsFor returns the generated directories to protect for a
+// project, read from the nearest convex-gen config walking up from cwd.
+// Unset fields take convex-gen's defaults; without a readable config the
+// hardcoded protectedPaths are used.
+func protectedPathsFor(cwd string) []string {
+	path := findConvexGenConfig(cwd)
+	if path == "" {
+		return protectedPaths
+	}
+
+	var cfg convexGenConfig
+	if err := jsonc.Unmarshal(path, &cfg); err != nil {
+		return protectedPaths
+	}
+
+	dl := cfg.DataLayer
+	base := orDefault(dl.Path, "packages/data-layer/src")
+	dirs := []string{
+		orDefault(dl.HooksDir, "generated-hooks"),
+		orDefault(dl.APIDir, "generated-api"),
+		orDefault(dl.TypesDir, "generated-types"),
+	}
+
+	paths := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		paths = append(paths, cleanConfigPath(filepath.Join(base, dir)))
+	}
+	return paths
+}
+
+// findConvexGenConfig walks up from dir to the repository root looking for a
+// convex-gen config file. Returns "" when there is none.
+func findConvexGenConfig(dir string) string {
+	if dir == "" {
+		dir, _ = os.Getwd()
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		for _, name := range convexGenConfigNames {
+			candidate := filepath.Join(dir, name)
+			if _, err := os.Stat(candidate); err == nil {
+				return candidate
+			}
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return ""
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// cleanConfigPath normalizes a repo-relative config path for substring
+// matching against file paths and commands.
+func cleanConfigPath(path string) string {
+	return strings.TrimPrefix(filepath.ToSlash(filepath.Clean(path)), "./")
+}
+
+func orDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// setupConvexGenProject creates a temp repository root, optionally with a
+// .convex-gen.json holding config.
+func setupConvexGenProject(t *testing.T, config string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if config != "" {
+		if err := os.WriteFile(filepath.Join(dir, ".convex-gen.json"), []byte(config), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestProtectedPathsFor(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		want   []string
+	}{
+		{
+			name:   "no config uses defaults",
+			config: "",
+			want:   protectedPaths,
+		},
+		{
+			name:   "relocated data layer",
+			config: `{"dataLayer": {"path": "apps/web/src/data"}}`,
+			want: []string{
+				"apps/web/src/data/generated-hooks",
+				"apps/web/src/data/generated-api",
+				"apps/web/src/data/generated-types",
+			},
+		},
+		{
+			name:   "custom subdirectories",
+			config: `{"dataLayer": {"path": "./lib/", "hooksDir": "hooks-gen", "apiDir": "api-gen", "typesDir": "types-gen"}}`,
+			want:   []string{"lib/hooks-gen", "lib/api-gen", "lib/types-gen"},
+		},
+		{
+			name:   "invalid config uses defaults",
+			config: `{"dataLayer": `,
+			want:   protectedPaths,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := setupConvexGenProject(t, tt.config)
+			got := protectedPathsFor(filepath.Join(dir, "apps", "web"))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("protectedPathsFor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProcessHookRelocatedDataLayer(t *testing.T) {
+	dir := setupConvexGenProject(t, `{"dataLayer": {"path": "apps/web/src/data"}}`)
+
+	tests := []struct {
+		name     string
+		toolName string
+		input    map[string]interface{}
+		decision string
+	}{
+		{
+			name:     "Edit relocated generated hook",
+			toolName: "Edit",
+			input:    map[string]interface{}{"file_path": filepath.Join(dir, "apps/web/src/data/generated-hooks/useEvents.ts")},
+			decision: "block",
+		},
+		{
+			name:     "rm relocated generated types",
+			toolName: "Bash",
+			input:    map[string]interface{}{"command": "rm -rf apps/web/src/data/generated-types/"},
+			decision: "block",
+		},
+		{
+			name:     "old default location is no longer generated",
+			toolName: "Write",
+			input:    map[string]interface{}{"file_path": filepath.Join(dir, "packages/data-layer/src/generated-hooks/index.ts")},
+			decision: "approve",
+		},
+		{
+			name:     "hand-written file next to generated dirs",
+			toolName: "Edit",
+			input:    map[string]interface{}{"file_path": filepath.Join(dir, "apps/web/src/data/hooks/useCustom.ts")},
+			decision: "approve",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := processHook(&HookInput{ToolName: tt.toolName, ToolInput: tt.input, Cwd: dir})
+			if output.Decision != tt.decision {
+				t.Errorf("expected decision %q, got %q (reason: %s)", tt.decision, output.Decision, output.Reason)
+			}
+		})
+	}
+}
@@ -5,12 +5,14 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 )
 
 type HookInput struct {
 	ToolName  string                 `json:"tool_name"`
 	ToolInput map[string]interface{} `json:"tool_input"`
+	Cwd       string                 `json:"cwd"`
 }
 
 type HookOutput struct {
@@ -20,6 +22,8 @@ type HookOutput struct {
 
 // Protected paths that should not be modified directly.
 // These are auto-generated directories managed by tooling (e.g. convex-gen).
+// They are the defaults for projects without a .convex-gen.json; see
+// protectedPathsFor.
 var protectedPaths = []string{
 	"packages/data-layer/src/generated-hooks",
 	"packages/data-layer/src/generated-api",
@@ -78,19 +82,20 @@ func writeHookOutput(w io.Writer, output *HookOutput) error {
 func processHook(input *HookInput) *HookOutput {
 	switch input.ToolName {
 	case "Edit", "Write":
-		return checkFilePath(getFilePath(input.ToolInput))
+		return checkFilePath(getFilePath(input.ToolInput), protectedPathsFor(input.Cwd))
 	case "Bash":
-		return checkBashCommand(getCommand(input.ToolInput))
+		return checkBashCommand(getCommand(input.ToolInput), protectedPathsFor(input.Cwd))
 	default:
 		return approve()
 	}
 }
 
-func checkFilePath(filePath string) *HookOutput {
+func checkFilePath(filePath string, protectedPaths []string) *HookOutput {
 	if filePath == "" {
 		return approve()
 	}
 
+	filePath = filepath.ToSlash(filePath)
 	for _, protected := range protectedPaths {
 		if strings.Contains(filePath, protected) {
 			return block(protected)
@@ -100,11 +105,15 @@ func checkFilePath(filePath string) *HookOutput {
 	return approve()
 }
 
-func checkBashCommand(command string) *HookOutput {
+func checkBashCommand(command string, protectedPaths []string) *HookOutput {
 	if command == "" {
 		return approve()
 	}
 
+	if isRegenCommand(command) {
+		return approve()
+	}
+
 	for _, protected := range protectedPaths {
 		if !strings.Contains(command, protected) {
 			continue
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// regenScriptsEnvVar lists extra package.json scripts, comma-separated, that
+// run convex-gen (e.g. "generate,codegen").
+const regenScriptsEnvVar = "CLAUDE_HOOKS_REGEN_SCRIPTS"
+
+// defaultRegenScripts are the package.json scripts always treated as
+// regeneration.
+var defaultRegenScripts = []string{"convex-gen"}
+
+// shellControlChars split or redirect a command. A regeneration command must
+// contain none of them, so `convex-gen && echo x > generated-hooks/a.ts` is
+// not let through.
+const shellControlChars = ";&|<>`\n"
+
+// isRegenCommand reports whether command does nothing but run convex-gen:
+// the binary itself (by name or path), a package runner (npx, bunx,
+// pnpm exec, yarn) invoking it, or a configured package.json script.
+// Regeneration writes to the generated directories by design, so it is
+// approved even when its arguments name them.
+func isRegenCommand(command string) bool {
+	if strings.ContainsAny(command, shellControlChars) || strings.Contains(command, "$(") {
+		return false
+	}
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return false
+	}
+
+	if isConvexGenBinary(fields[0]) {
+		return true
+	}
+
+	rest := fields[1:]
+	switch fields[0] {
+	case "npx", "bunx":
+		return len(rest) > 0 && isConvexGenBinary(rest[0])
+	case "npm", "bun":
+		return len(rest) > 1 && (rest[0] == "run" || rest[0] == "run-script") && isRegenScript(rest[1])
+	case "pnpm", "yarn":
+		if len(rest) > 0 && (rest[0] == "run" || rest[0] == "exec") {
+			rest = rest[1:]
+		}
+		return len(rest) > 0 && (isConvexGenBinary(rest[0]) || isRegenScript(rest[0]))
+	}
+	return false
+}
+
+// isConvexGenBinary matches "convex-gen" or a path ending in it.
+func isConvexGenBinary(word string) bool {
+	return filepath.Base(word) == "convex-gen"
+}
+
+// isRegenScript reports whether name is a default or configured regeneration
+// script.
+func isRegenScript(name string) bool {
+	for _, script := range regenScripts() {
+		if name == script {
+			return true
+		}
+	}
+	return false
+}
+
+// regenScripts returns defaultRegenScripts plus those in
+// CLAUDE_HOOKS_REGEN_SCRIPTS.
+func regenScripts() []string {
+	scripts := append([]string{}, defaultRegenScripts...)
+	for _, s := range strings.Split(os.Getenv(regenScriptsEnvVar), ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			scripts = append(scripts, s)
+		}
+	}
+	return scripts
+}
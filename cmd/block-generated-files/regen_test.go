@@ -0,0 +1,85 @@
+package main
+
+import "testing"
+
+func TestIsRegenCommand(t *testing.T) {
+	tests := []struct {
+		command string
+		want    bool
+	}{
+		{"convex-gen", true},
+		{"convex-gen --watch", true},
+		{"./bin/convex-gen", true},
+		{"~/go/bin/convex-gen --config .convex-gen.json", true},
+		{"npx convex-gen", true},
+		{"bunx convex-gen", true},
+		{"pnpm exec convex-gen", true},
+		{"pnpm convex-gen", true},
+		{"npm run convex-gen", true},
+		{"yarn run convex-gen", true},
+		{"npm run generate", false},
+		{"npm run build", false},
+		{"convex-gen > packages/data-layer/src/generated-hooks/x.ts", false},
+		{"convex-gen && rm -rf packages/data-layer/src/generated-hooks", false},
+		{"convex-gen; echo x", false},
+		{"echo convex-gen", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			if got := isRegenCommand(tt.command); got != tt.want {
+				t.Errorf("isRegenCommand(%q) = %v, want %v", tt.command, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsRegenCommandConfiguredScripts(t *testing.T) {
+	t.Setenv(regenScriptsEnvVar, "generate, codegen")
+
+	for _, command := range []string{"npm run generate", "pnpm codegen", "bun run codegen"} {
+		if !isRegenCommand(command) {
+			t.Errorf("isRegenCommand(%q) = false, want true", command)
+		}
+	}
+	if isRegenCommand("npm run build") {
+		t.Error("unconfigured script should not count as regeneration")
+	}
+}
+
+func TestRegenVersusManualRedirect(t *testing.T) {
+	tests := []struct {
+		name     string
+		command  string
+		decision string
+	}{
+		{
+			name:     "regeneration naming the output dir",
+			command:  "convex-gen --out packages/data-layer/src/generated-hooks",
+			decision: "approve",
+		},
+		{
+			name:     "manual redirect into generated dir",
+			command:  "echo 'export {}' > packages/data-layer/src/generated-hooks/x.ts",
+			decision: "block",
+		},
+		{
+			name:     "regeneration chained with a manual write",
+			command:  "convex-gen && echo x > packages/data-layer/src/generated-hooks/x.ts",
+			decision: "block",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := processHook(&HookInput{
+				ToolName:  "Bash",
+				ToolInput: map[string]interface{}{"command": tt.command},
+			})
+			if output.Decision != tt.decision {
+				t.Errorf("expected decision %q, got %q", tt.decision, output.Decision)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// auditEnvVar enables the block audit log when set to "1".
+const auditEnvVar = "CLAUDE_HOOKS_AUDIT"
+
+// auditEntry is one line of ~/.claude/logs/infra-blocks.jsonl.
+type auditEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Tool      string    `json:"tool"`
+	FilePath  string    `json:"file_path"`
+	Reason    string    `json:"reason"`
+	Command   string    `json:"command,omitempty"`
+}
+
+// auditLogPath returns where blocked attempts are recorded.
+func auditLogPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".claude", "logs", "infra-blocks.jsonl"), nil
+}
+
+// auditBlock appends a block decision to the audit log when
+// CLAUDE_HOOKS_AUDIT=1. It is best-effort: any failure is ignored so logging
+// never changes whether the hook blocks. command is empty for edit tools.
+func auditBlock(tool, filePath, reason, command string) {
+	if os.Getenv(auditEnvVar) != "1" {
+		return
+	}
+
+	path, err := auditLogPath()
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+
+	line, err := json.Marshal(auditEntry{
+		Timestamp: time.Now().UTC(),
+		Tool:      tool,
+		FilePath:  filePath,
+		Reason:    reason,
+		Command:   command,
+	})
+	if err != nil {
+		return
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	_, _ = f.Write(append(line, '\n'))
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAuditBlock(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(auditEnvVar, "1")
+	logPath := filepath.Join(home, ".claude", "logs", "infra-blocks.jsonl")
+
+	allow := HookInput{
+		ToolName:  "Bash",
+		ToolInput: map[string]interface{}{"command": "echo x > /tmp/notes.txt"},
+		Cwd:       "/tmp",
+	}
+	if code := runHookWithInput(t, allow); code != 0 {
+		t.Fatalf("expected allow, got exit %d", code)
+	}
+	if _, err := os.Stat(logPath); !os.IsNotExist(err) {
+		t.Fatalf("allowed command should not write the audit log (stat err %v)", err)
+	}
+
+	block := HookInput{
+		ToolName:  "Bash",
+		ToolInput: map[string]interface{}{"command": "echo x > ~/.claude/settings.json"},
+		Cwd:       "/tmp",
+	}
+	if code := runHookWithInput(t, block); code != 2 {
+		t.Fatalf("expected block, got exit %d", code)
+	}
+	edit := HookInput{
+		ToolName:  "Write",
+		ToolInput: map[string]interface{}{"file_path": "~/.claude/CLAUDE.md"},
+		Cwd:       "/tmp",
+	}
+	if code := runHookWithInput(t, edit); code != 2 {
+		t.Fatalf("expected block, got exit %d", code)
+	}
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("reading audit log: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 audit lines, got %d: %s", len(lines), data)
+	}
+
+	var bash, write auditEntry
+	if err := json.Unmarshal([]byte(lines[0]), &bash); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &write); err != nil {
+		t.Fatal(err)
+	}
+	if bash.Tool != "Bash" || bash.FilePath != "~/.claude/settings.json" || bash.Command == "" || bash.Reason == "" || bash.Timestamp.IsZero() {
+		t.Errorf("unexpected Bash entry: %+v", bash)
+	}
+	if write.Tool != "Write" || write.FilePath != "~/.claude/CLAUDE.md" || write.Command != "" {
+		t.Errorf("unexpected Write entry: %+v", write)
+	}
+	if strings.Contains(lines[1], `"command"`) {
+		t.Errorf("edit entry should omit command: %s", lines[1])
+	}
+}
+
+func TestAuditBlockDisabled(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(auditEnvVar, "")
+
+	auditBlock("Bash", "~/.claude/settings.json", "protected", "echo x > ~/.claude/settings.json")
+
+	if _, err := os.Stat(filepath.Join(home, ".claude", "logs")); !os.IsNotExist(err) {
+		t.Errorf("audit log should not be written unless %s=1", auditEnvVar)
+	}
+}
@@ -27,6 +27,7 @@ var protectedPaths = []string{
 var projectProtectedPatterns = []*regexp.Regexp{
 	regexp.MustCompile(`\.claude-hooks-config\.sh$`),
 	regexp.MustCompile(`\.claude-hooks-ignore$`),
+	regexp.MustCompile(`\.claude-hooks-protect\.json$`),
 	regexp.MustCompile(`\.claude/hooks/.*\.py$`),
 	regexp.MustCompile(`\.claude/hooks/.*\.sh$`),
 	regexp.MustCompile(`\.claude/hooks/.*\.js$`),
@@ -54,6 +55,12 @@ func main() {
 		os.Exit(0)
 	}
 
+	cwd := input.Cwd
+	if cwd == "" {
+		cwd, _ = os.Getwd()
+	}
+	loadProtectConfig(cwd, os.Stderr)
+
 	// Handle different tool types
 	switch input.ToolName {
 	case "Bash":
@@ -83,6 +90,7 @@ func handleBashTool(input HookInput) {
 
 	for _, filePath := range filePaths {
 		if isProtected, reason := isProtectedFile(filePath, cwd); isProtected {
+			auditBlock(input.ToolName, filePath, reason, command)
 			blockBashEdit(command, filePath, reason)
 		}
 	}
@@ -109,6 +117,7 @@ func handleEditTool(input HookInput) {
 	}
 
 	if isProtected, reason := isProtectedFile(filePath, cwd); isProtected {
+		auditBlock(input.ToolName, filePath, reason, "")
 		blockFileEdit(filePath, reason)
 	}
 
@@ -119,8 +128,12 @@ func extractFilePathsFromCommand(command string) []string {
 	var filePaths []string
 	seen := make(map[string]bool)
 
+	// Resolve VAR=value assignments so `F=...; echo x > $F` is caught
+	command = expandShellVariables(command)
+
 	// Helper to add unique paths
 	addPath := func(path string) {
+		path = strings.Trim(path, `"'`)
 		if path != "" && !seen[path] {
 			filePaths = append(filePaths, path)
 			seen[path] = true
@@ -204,6 +217,11 @@ func isProtectedFile(filePath, cwd string) (bool, string) {
 		}
 	}
 
+	// Check patterns from .claude-hooks-protect.json
+	if pattern, ok := matchConfigGlobs(normalizedPath); ok {
+		return true, fmt.Sprintf("Files matching %s are protected by %s", pattern, protectConfigFile)
+	}
+
 	return false, ""
 }
 
@@ -277,7 +295,7 @@ Protected file categories:
 - Hook scripts (~/.claude/hooks/*.py, *.sh, *.js)
 - Global instructions (~/.claude/CLAUDE.md)
 - Settings (~/.claude/settings.json)
-- Project hook configuration (.claude-hooks-config.sh, .claude-hooks-ignore)
+- Project hook configuration (.claude-hooks-config.sh, .claude-hooks-ignore, .claude-hooks-protect.json)
 
 This protection ensures agents cannot circumvent quality controls.
 `, command, filePath, reason)
@@ -303,7 +321,7 @@ Protected file categories:
 - Hook scripts (~/.claude/hooks/*.py, *.sh, *.js)
 - Hook utilities (ast_utils.py, srp_validators.py, etc.)
 - Global instructions (~/.claude/CLAUDE.md)
-- Project hook configuration (.claude-hooks-config.sh, .claude-hooks-ignore)
+- Project hook configuration (.claude-hooks-config.sh, .claude-hooks-ignore, .claude-hooks-protect.json)
 
 This protection ensures agents cannot circumvent quality controls or
 modify their own behavior without user approval.
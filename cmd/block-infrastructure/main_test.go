@@ -74,6 +74,26 @@ func TestExtractFilePathsFromCommand(t *testing.T) {
 			command:  "ls -la | grep test",
 			expected: []string{},
 		},
+		{
+			name:     "variable assigned then redirected to",
+			command:  "F=~/.claude/settings.json; echo x > $F",
+			expected: []string{"~/.claude/settings.json"},
+		},
+		{
+			name:     "quoted braced variable",
+			command:  `F="$HOME/.claude/CLAUDE.md" && cat notes.md >> "${F}"`,
+			expected: []string{"~/.claude/CLAUDE.md"},
+		},
+		{
+			name:     "variable built from another variable",
+			command:  "D=~/.claude/hooks; export F=$D/test.py; cp evil.py $F",
+			expected: []string{"~/.claude/hooks/test.py"},
+		},
+		{
+			name:     "unresolvable variable kept raw",
+			command:  "F=$(mktemp); echo x > $F",
+			expected: []string{"$F"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -228,6 +248,17 @@ func TestHandleBashTool(t *testing.T) {
 			},
 			expectBlock: true,
 		},
+		{
+			name: "block redirect through variable",
+			input: HookInput{
+				ToolName: "Bash",
+				ToolInput: map[string]interface{}{
+					"command": "F=~/.claude/settings.json; echo x > $F",
+				},
+				Cwd: "/tmp",
+			},
+			expectBlock: true,
+		},
 		{
 			name: "allow regular file edit",
 			input: HookInput{
@@ -380,7 +411,8 @@ func runHookWithInput(t *testing.T, input HookInput) int {
 		}
 
 		for _, filePath := range filePaths {
-			if isProtected, _ := isProtectedFile(filePath, cwd); isProtected {
+			if isProtected, reason := isProtectedFile(filePath, cwd); isProtected {
+				auditBlock(input.ToolName, filePath, reason, command)
 				return 2
 			}
 		}
@@ -404,7 +436,8 @@ func runHookWithInput(t *testing.T, input HookInput) int {
 			return 0
 		}
 
-		if isProtected, _ := isProtectedFile(filePath, cwd); isProtected {
+		if isProtected, reason := isProtectedFile(filePath, cwd); isProtected {
+			auditBlock(input.ToolName, filePath, reason, "")
 			return 2
 		}
 		return 0
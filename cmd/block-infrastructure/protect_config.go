@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/milehighideas/claude-hooks/internal/glob"
+	"github.com/milehighideas/claude-hooks/internal/jsonc"
+)
+
+// protectConfigFile lets a project protect more files from agent edits. It is
+// found by walking up from cwd to the repository root.
+const protectConfigFile = ".claude-hooks-protect.json"
+
+// protectConfig is the parsed .claude-hooks-protect.json:
+//
+//	{"patterns": ["infra/**", ".github/workflows/**"], "paths": ["~/.aws/"]}
+type protectConfig struct {
+	// Patterns are globs matched against paths relative to the config's
+	// directory. ** spans directories; a trailing / protects a whole directory.
+	Patterns []string `json:"patterns"`
+	// Paths are home-relative or absolute paths, checked like protectedPaths.
+	Paths []string `json:"paths"`
+}
+
+// protectedGlob is a compiled Patterns entry.
+type protectedGlob struct {
+	pattern string
+	root    string // project root the pattern is relative to
+	re      *regexp.Regexp
+}
+
+// configProtectedGlobs holds the project's extra patterns once
+// loadProtectConfig has run.
+var configProtectedGlobs []protectedGlob
+
+// loadProtectConfig merges the nearest .claude-hooks-protect.json into the
+// built-in protectedPaths and configProtectedGlobs. A missing file changes
+// nothing; an unreadable or malformed one is logged to stderr and adds no
+// protection, leaving the built-in defaults in force.
+func loadProtectConfig(cwd string, stderr io.Writer) {
+	path := findProtectConfig(cwd)
+	if path == "" {
+		return
+	}
+
+	var cfg protectConfig
+	if err := jsonc.Unmarshal(path, &cfg); err != nil {
+		fmt.Fprintf(stderr, "block-infrastructure: ignoring %s: %v\n", path, err)
+		return
+	}
+
+	root := filepath.Dir(path)
+	for _, pattern := range cfg.Patterns {
+		if pattern = strings.TrimSpace(pattern); pattern == "" {
+			continue
+		}
+		configProtectedGlobs = append(configProtectedGlobs, protectedGlob{
+			pattern: pattern,
+			root:    root,
+			re:      compileProtectGlob(pattern),
+		})
+	}
+	for _, p := range cfg.Paths {
+		if p = strings.TrimSpace(p); p != "" {
+			protectedPaths = append(protectedPaths, p)
+		}
+	}
+}
+
+// findProtectConfig walks up from dir looking for protectConfigFile, stopping
+// at the directory that holds .git. Returns "" when there is none.
+func findProtectConfig(dir string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		candidate := filepath.Join(dir, protectConfigFile)
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return ""
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// compileProtectGlob turns a project-relative glob into an anchored regexp
+// (see glob.ToRegex). A trailing "/" protects everything below.
+func compileProtectGlob(pattern string) *regexp.Regexp {
+	pattern = strings.TrimPrefix(filepath.ToSlash(pattern), "./")
+	pattern = strings.TrimPrefix(pattern, "/")
+	if strings.HasSuffix(pattern, "/") {
+		pattern += "**"
+	}
+	return glob.MustCompile(pattern)
+}
+
+// matchConfigGlobs reports the configured pattern protecting normalizedPath,
+// if any.
+func matchConfigGlobs(normalizedPath string) (string, bool) {
+	for _, g := range configProtectedGlobs {
+		root, err := normalizePath(g.root)
+		if err != nil {
+			continue
+		}
+		rel, err := filepath.Rel(root, normalizedPath)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, "../") {
+			continue
+		}
+		if g.re.MatchString(filepath.ToSlash(rel)) {
+			return g.pattern, true
+		}
+	}
+	return "", false
+}
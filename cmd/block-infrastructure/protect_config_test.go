@@ -0,0 +1,151 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setupProtectProject creates a temp repository with the given
+// .claude-hooks-protect.json content (none when empty) and resets the merged
+// protection state when the test ends.
+func setupProtectProject(t *testing.T, config string) string {
+	t.Helper()
+
+	savedPaths := protectedPaths
+	t.Cleanup(func() {
+		protectedPaths = savedPaths
+		configProtectedGlobs = nil
+	})
+
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if config != "" {
+		if err := os.WriteFile(filepath.Join(dir, protectConfigFile), []byte(config), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestProtectConfigPatterns(t *testing.T) {
+	dir := setupProtectProject(t, `{
+		// Terraform lives here
+		"patterns": ["infra/**", "*.lock"]
+	}`)
+
+	var stderr bytes.Buffer
+	loadProtectConfig(filepath.Join(dir, "src"), &stderr)
+	if stderr.Len() != 0 {
+		t.Errorf("unexpected stderr: %s", stderr.String())
+	}
+
+	tests := []struct {
+		path      string
+		protected bool
+	}{
+		{"infra/main.tf", true},
+		{"infra/modules/vpc/main.tf", true},
+		{"yarn.lock", true},
+		{"src/app.ts", false},
+		{"src/infra/notes.md", false},
+		{"packages/yarn.lock", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			isProtected, reason := isProtectedFile(filepath.Join(dir, tt.path), dir)
+			if isProtected != tt.protected {
+				t.Errorf("isProtectedFile(%q) = %v, want %v", tt.path, isProtected, tt.protected)
+			}
+			if isProtected && !strings.Contains(reason, protectConfigFile) {
+				t.Errorf("reason %q does not mention %s", reason, protectConfigFile)
+			}
+		})
+	}
+}
+
+func TestProtectConfigBlocksHook(t *testing.T) {
+	dir := setupProtectProject(t, `{"patterns": ["infra/**"]}`)
+	loadProtectConfig(dir, &bytes.Buffer{})
+
+	edit := HookInput{
+		ToolName:  "Edit",
+		ToolInput: map[string]interface{}{"file_path": filepath.Join(dir, "infra", "main.tf")},
+		Cwd:       dir,
+	}
+	if code := runHookWithInput(t, edit); code != 2 {
+		t.Errorf("Edit of infra/main.tf exited %d, want 2", code)
+	}
+
+	bash := HookInput{
+		ToolName:  "Bash",
+		ToolInput: map[string]interface{}{"command": "echo x > infra/main.tf"},
+		Cwd:       dir,
+	}
+	oldWd, _ := os.Getwd()
+	defer func() { _ = os.Chdir(oldWd) }()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	if code := runHookWithInput(t, bash); code != 2 {
+		t.Errorf("redirect into infra/main.tf exited %d, want 2", code)
+	}
+
+	edit.ToolInput = map[string]interface{}{"file_path": filepath.Join(dir, "src", "app.ts")}
+	if code := runHookWithInput(t, edit); code != 0 {
+		t.Errorf("Edit of src/app.ts exited %d, want 0", code)
+	}
+}
+
+func TestProtectConfigPaths(t *testing.T) {
+	extra := t.TempDir()
+	dir := setupProtectProject(t, `{"paths": ["`+extra+`/"]}`)
+	loadProtectConfig(dir, &bytes.Buffer{})
+
+	if isProtected, _ := isProtectedFile(filepath.Join(extra, "secrets.env"), dir); !isProtected {
+		t.Error("expected file under configured path to be protected")
+	}
+}
+
+func TestProtectConfigInvalid(t *testing.T) {
+	dir := setupProtectProject(t, `{"patterns": ["infra/**"`)
+	builtin := len(protectedPaths)
+
+	var stderr bytes.Buffer
+	loadProtectConfig(dir, &stderr)
+
+	if !strings.Contains(stderr.String(), protectConfigFile) {
+		t.Errorf("expected stderr to report the bad config, got %q", stderr.String())
+	}
+	if isProtected, _ := isProtectedFile(filepath.Join(dir, "infra", "main.tf"), dir); isProtected {
+		t.Error("invalid config should add no protection")
+	}
+	if len(protectedPaths) != builtin {
+		t.Errorf("built-in protectedPaths changed: %d -> %d", builtin, len(protectedPaths))
+	}
+	if isProtected, _ := isProtectedFile("~/.claude/CLAUDE.md", dir); !isProtected {
+		t.Error("built-in protection should still apply")
+	}
+}
+
+func TestProtectConfigMissing(t *testing.T) {
+	dir := setupProtectProject(t, "")
+
+	var stderr bytes.Buffer
+	loadProtectConfig(dir, &stderr)
+	if stderr.Len() != 0 || len(configProtectedGlobs) != 0 {
+		t.Errorf("missing config should be silent and add nothing (stderr %q)", stderr.String())
+	}
+}
+
+func TestProtectConfigFileIsProtected(t *testing.T) {
+	dir := setupProtectProject(t, "")
+	if isProtected, _ := isProtectedFile(filepath.Join(dir, protectConfigFile), dir); !isProtected {
+		t.Errorf("%s itself should be protected", protectConfigFile)
+	}
+}
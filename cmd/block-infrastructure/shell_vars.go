@@ -0,0 +1,94 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// NAME=value at the start of a command, after a separator, or after export.
+	// The value is a quoted string or a run of non-separator characters.
+	shellAssignPattern = regexp.MustCompile(`(?:^|[;&|\n(]|\bexport)\s*([A-Za-z_][A-Za-z0-9_]*)=("[^"]*"|'[^']*'|[^\s;&|]*)`)
+
+	// $NAME or ${NAME}
+	shellVarRefPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}|\$([A-Za-z_][A-Za-z0-9_]*)`)
+)
+
+// expandShellVariables substitutes variables assigned earlier in the same
+// command string, so `F=~/.claude/settings.json; echo x > $F` is checked as
+// `echo x > ~/.claude/settings.json`. $HOME expands to ~. This is not a shell:
+// values built from command substitution, and variables never assigned in
+// the command, are left as the raw $NAME token.
+func expandShellVariables(command string) string {
+	assigns := shellAssignPattern.FindAllStringSubmatchIndex(command, -1)
+	if len(assigns) == 0 && !strings.Contains(command, "$HOME") && !strings.Contains(command, "${HOME}") {
+		return command
+	}
+
+	vars := map[string]string{"HOME": "~"}
+	applied := 0
+	// applyAssignments records every assignment that ends at or before pos,
+	// so a reference only sees values assigned before it.
+	applyAssignments := func(pos int) {
+		for applied < len(assigns) && assigns[applied][1] <= pos {
+			a := assigns[applied]
+			name := command[a[2]:a[3]]
+			if value, ok := assignedValue(command[a[4]:a[5]], vars); ok {
+				vars[name] = value
+			} else {
+				delete(vars, name)
+			}
+			applied++
+		}
+	}
+
+	var sb strings.Builder
+	last := 0
+	for _, ref := range shellVarRefPattern.FindAllStringSubmatchIndex(command, -1) {
+		applyAssignments(ref[0])
+		name := refName(command, ref)
+		value, ok := vars[name]
+		if !ok {
+			continue
+		}
+		sb.WriteString(command[last:ref[0]])
+		sb.WriteString(value)
+		last = ref[1]
+	}
+	sb.WriteString(command[last:])
+	return sb.String()
+}
+
+// assignedValue resolves the right-hand side of an assignment. Single quotes
+// are literal; double-quoted and bare values have known variables expanded.
+// It fails for values the hook can't know, like $(...) or backticks.
+func assignedValue(raw string, vars map[string]string) (string, bool) {
+	if len(raw) >= 2 && raw[0] == '\'' && raw[len(raw)-1] == '\'' {
+		return raw[1 : len(raw)-1], true
+	}
+	if len(raw) >= 2 && raw[0] == '"' && raw[len(raw)-1] == '"' {
+		raw = raw[1 : len(raw)-1]
+	}
+	if strings.Contains(raw, "$(") || strings.Contains(raw, "`") {
+		return "", false
+	}
+
+	resolved := true
+	value := shellVarRefPattern.ReplaceAllStringFunc(raw, func(ref string) string {
+		m := shellVarRefPattern.FindStringSubmatchIndex(ref)
+		if v, ok := vars[refName(ref, m)]; ok {
+			return v
+		}
+		resolved = false
+		return ref
+	})
+	return value, resolved
+}
+
+// refName returns the variable name of a shellVarRefPattern match.
+func refName(s string, m []int) string {
+	if m[2] >= 0 {
+		return s[m[2]:m[3]]
+	}
+	return s[m[4]:m[5]]
+}
@@ -7,6 +7,8 @@ import (
 	"os"
 	"regexp"
 	"strings"
+
+	"github.com/milehighideas/claude-hooks/internal/filewrite"
 )
 
 // HookInput represents the JSON input from stdin
@@ -77,24 +79,33 @@ func writeHookOutput(w io.Writer, output *HookOutput) error {
 }
 
 func processHook(input *HookInput) *HookOutput {
-	// Only check Edit and Write tools
-	if input.ToolName != "Edit" && input.ToolName != "Write" {
+	// Only check file writes: Write, Edit, MultiEdit, and Bash heredocs/redirects
+	var text, filePath string
+	if filewrite.IsWriteTool(input.ToolName) {
+		text = getTextToCheck(input.ToolInput)
+		filePath = getFilePath(input.ToolInput)
+	} else if writes := filewrite.FromMap(input.ToolName, input.ToolInput); len(writes) > 0 {
+		text = writes[0].Content
+		filePath = writes[0].Path
+	}
+	if text == "" {
 		return &HookOutput{Decision: "approve"}
 	}
 
-	// Get the text to check
-	text := getTextToCheck(input.ToolInput)
-	if text == "" {
+	// Files outside .claude-hooks-lint-scope.json aren't linted, so their
+	// suppressions aren't workarounds
+	if !inLintScope(filePath) {
 		return &HookOutput{Decision: "approve"}
 	}
 
-	// Get file path to check for Convex context
-	filePath := getFilePath(input.ToolInput)
+	// Check for Convex context
 	isConvexFile := isInConvexDirectory(filePath)
 
-	// Check for underscore prefix workarounds
-	if output := checkUnderscorePrefixes(text, isConvexFile); output != nil {
-		return output
+	// Check for underscore prefix workarounds. Borderline matches only
+	// warn, so the remaining checks still get a chance to block.
+	underscore := checkUnderscorePrefixes(text, isConvexFile)
+	if underscore != nil && underscore.Decision == "block" {
+		return underscore
 	}
 
 	// Check for eslint-disable comments
@@ -122,6 +133,10 @@ func processHook(input *HookInput) *HookOutput {
 		return output
 	}
 
+	if underscore != nil {
+		return underscore
+	}
+
 	return &HookOutput{Decision: "approve"}
 }
 
@@ -136,6 +151,11 @@ func getTextToCheck(toolInput map[string]interface{}) string {
 		return content
 	}
 
+	// Every edit's new_string (for MultiEdit tool)
+	if writes := filewrite.FromMap("MultiEdit", toolInput); len(writes) > 0 {
+		return writes[0].NewText()
+	}
+
 	return ""
 }
 
@@ -157,60 +177,22 @@ func isInConvexDirectory(filePath string) bool {
 // Convex system fields that start with underscore (allowed in convex directories)
 var convexSystemFields = regexp.MustCompile(`_id|_creationTime`)
 
-func checkUnderscorePrefixes(text string, isConvexFile bool) *HookOutput {
-	patterns := []Pattern{
-		{
-			Regex:   regexp.MustCompile(`as\s+_\w+`),
-			Message: "aliasing with underscore prefix",
-		},
-		{
-			Regex:   regexp.MustCompile(`:\s*_\w+`),
-			Message: "destructuring with underscore prefix",
-		},
-		{
-			Regex:   regexp.MustCompile(`type\s+\w+\s+as\s+_`),
-			Message: "type alias with underscore prefix",
-		},
-	}
-
-	for _, p := range patterns {
-		if match := p.Regex.FindString(text); match != "" {
-			// In Convex files, allow Convex system fields like _id and _creationTime
-			if isConvexFile && convexSystemFields.MatchString(match) {
-				continue
-			}
-
-			return &HookOutput{
-				Decision: "block",
-				Reason: fmt.Sprintf(`BLOCKED: Underscore prefix workaround detected
-
-Found: %s
-
-Do not prefix unused imports/variables with underscore to silence lint errors.
-Instead, REMOVE the unused import or variable entirely.
-
-If you need the import for type-only usage, use 'import type { ... }' syntax.`, match),
-			}
-		}
-	}
-
-	return nil
-}
-
 func checkESLintDisable(text string) *HookOutput {
+	comments := scanSource(text).comments
+
 	patterns := []Pattern{
 		{
-			Regex:   regexp.MustCompile(`//\s*eslint-disable`),
+			Regex:   regexp.MustCompile(`^//\s*eslint-disable`),
 			Message: "inline eslint-disable comment",
 		},
 		{
-			Regex:   regexp.MustCompile(`/\*\s*eslint-disable`),
+			Regex:   regexp.MustCompile(`^/\*\s*eslint-disable`),
 			Message: "block eslint-disable comment",
 		},
 	}
 
 	for _, p := range patterns {
-		if match := p.Regex.FindString(text); match != "" {
+		if match := findCommentDirective(comments, p.Regex); match != "" {
 			return &HookOutput{
 				Decision: "block",
 				Reason: fmt.Sprintf(`BLOCKED: ESLint suppression comment detected
@@ -229,19 +211,21 @@ Instead, fix the underlying issue:
 }
 
 func checkOxlintDisable(text string) *HookOutput {
+	comments := scanSource(text).comments
+
 	patterns := []Pattern{
 		{
-			Regex:   regexp.MustCompile(`//\s*oxlint-disable`),
+			Regex:   regexp.MustCompile(`^//\s*oxlint-disable`),
 			Message: "inline oxlint-disable comment",
 		},
 		{
-			Regex:   regexp.MustCompile(`/\*\s*oxlint-disable`),
+			Regex:   regexp.MustCompile(`^/\*\s*oxlint-disable`),
 			Message: "block oxlint-disable comment",
 		},
 	}
 
 	for _, p := range patterns {
-		if match := p.Regex.FindString(text); match != "" {
+		if match := findCommentDirective(comments, p.Regex); match != "" {
 			return &HookOutput{
 				Decision: "block",
 				Reason: fmt.Sprintf(`BLOCKED: oxlint suppression comment detected
@@ -376,23 +360,25 @@ Either:
 }
 
 func checkTSIgnore(text string) *HookOutput {
+	comments := scanSource(text).comments
+
 	patterns := []Pattern{
 		{
-			Regex:   regexp.MustCompile(`//\s*@ts-ignore`),
+			Regex:   regexp.MustCompile(`^//\s*@ts-ignore`),
 			Message: "ts-ignore comment",
 		},
 		{
-			Regex:   regexp.MustCompile(`//\s*@ts-expect-error`),
+			Regex:   regexp.MustCompile(`^//\s*@ts-expect-error`),
 			Message: "ts-expect-error comment",
 		},
 		{
-			Regex:   regexp.MustCompile(`//\s*@ts-nocheck`),
+			Regex:   regexp.MustCompile(`^//\s*@ts-nocheck`),
 			Message: "ts-nocheck comment",
 		},
 	}
 
 	for _, p := range patterns {
-		if match := p.Regex.FindString(text); match != "" {
+		if match := findCommentDirective(comments, p.Regex); match != "" {
 			return &HookOutput{
 				Decision: "approve",
 				Reason: fmt.Sprintf(`WARNING: TypeScript suppression comment detected
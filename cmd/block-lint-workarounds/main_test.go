@@ -182,25 +182,25 @@ func TestCheckUnderscorePrefixes_ConvexExceptions(t *testing.T) {
 	}{
 		{
 			name:         "allows convex id field in convex file",
-			text:         fmt.Sprintf("Key%s %sid", colon, underscore),
+			text:         fmt.Sprintf("const { Key%s %sid } = doc;", colon, underscore),
 			isConvexFile: true,
 			shouldBlock:  false,
 		},
 		{
 			name:         "allows convex creationTime field in convex file",
-			text:         fmt.Sprintf("Key%s %screationTime", colon, underscore),
+			text:         fmt.Sprintf("const { Key%s %screationTime } = doc;", colon, underscore),
 			isConvexFile: true,
 			shouldBlock:  false,
 		},
 		{
 			name:         "blocks convex id field in non-convex file",
-			text:         fmt.Sprintf("Key%s %sid", colon, underscore),
+			text:         fmt.Sprintf("const { Key%s %sid } = doc;", colon, underscore),
 			isConvexFile: false,
 			shouldBlock:  true,
 		},
 		{
 			name:         "blocks convex creationTime field in non-convex file",
-			text:         fmt.Sprintf("Key%s %screationTime", colon, underscore),
+			text:         fmt.Sprintf("const { Key%s %screationTime } = doc;", colon, underscore),
 			isConvexFile: false,
 			shouldBlock:  true,
 		},
@@ -981,3 +981,58 @@ func TestIsTestFilePath(t *testing.T) {
 		})
 	}
 }
+
+func TestIntegration_MultiEditAndBashWrites(t *testing.T) {
+	disable := fmt.Sprintf("/* %s%s */", "eslint", "-disable")
+	tests := []struct {
+		name           string
+		input          *HookInput
+		expectDecision string
+	}{
+		{
+			name: "blocks eslint-disable in a MultiEdit edit",
+			input: &HookInput{
+				ToolName: "MultiEdit",
+				ToolInput: map[string]interface{}{
+					"file_path": "/some/file.ts",
+					"edits": []interface{}{
+						map[string]interface{}{"old_string": "a", "new_string": "const a = 1;"},
+						map[string]interface{}{"old_string": "b", "new_string": disable + "\nconst b = 2;"},
+					},
+				},
+			},
+			expectDecision: "block",
+		},
+		{
+			name: "approves clean MultiEdit",
+			input: &HookInput{
+				ToolName: "MultiEdit",
+				ToolInput: map[string]interface{}{
+					"file_path": "/some/file.ts",
+					"edits": []interface{}{
+						map[string]interface{}{"old_string": "a", "new_string": "const a = 1;"},
+					},
+				},
+			},
+			expectDecision: "approve",
+		},
+		{
+			name: "blocks eslint-disable in a Bash heredoc",
+			input: &HookInput{
+				ToolName: "Bash",
+				ToolInput: map[string]interface{}{
+					"command": "cat > /some/file.ts << 'EOF'\n" + disable + "\nconst x = 1;\nEOF",
+				},
+			},
+			expectDecision: "block",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if output := processHook(tt.input); output.Decision != tt.expectDecision {
+				t.Errorf("expected decision %s, got %s", tt.expectDecision, output.Decision)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/milehighideas/claude-hooks/internal/glob"
+	"github.com/milehighideas/claude-hooks/internal/jsonc"
+)
+
+// lintScopeFile limits the hook to part of a project. It is found by walking
+// up from the edited file to the repository root.
+const lintScopeFile = ".claude-hooks-lint-scope.json"
+
+// lintScope is the parsed .claude-hooks-lint-scope.json:
+//
+//	{"include": ["src/**", "apps/*/src/**"], "exclude": ["**/vendor/**", "examples/**"]}
+//
+// Globs are relative to the file's directory. An empty include list means
+// every file; exclude always wins.
+type lintScope struct {
+	Include []string `json:"include"`
+	Exclude []string `json:"exclude"`
+}
+
+// inLintScope reports whether the workaround checks apply to filePath. Without
+// a scope file, or with one that can't be parsed, every file is in scope.
+func inLintScope(filePath string) bool {
+	if filePath == "" {
+		return true
+	}
+	abs, err := filepath.Abs(filePath)
+	if err != nil {
+		return true
+	}
+	configPath := findLintScope(filepath.Dir(abs))
+	if configPath == "" {
+		return true
+	}
+
+	var scope lintScope
+	if err := jsonc.Unmarshal(configPath, &scope); err != nil {
+		fmt.Fprintf(os.Stderr, "block-lint-workarounds: ignoring %s: %v\n", configPath, err)
+		return true
+	}
+
+	rel, err := filepath.Rel(filepath.Dir(configPath), abs)
+	if err != nil {
+		return true
+	}
+	rel = filepath.ToSlash(rel)
+
+	if glob.MatchAny(rel, scope.Exclude) {
+		return false
+	}
+	return len(scope.Include) == 0 || glob.MatchAny(rel, scope.Include)
+}
+
+// findLintScope walks up from dir looking for lintScopeFile, stopping at the
+// directory that holds .git. Returns "" when there is none.
+func findLintScope(dir string) string {
+	for {
+		candidate := filepath.Join(dir, lintScopeFile)
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return ""
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// setupLintScope creates a temp repository root with the given
+// .claude-hooks-lint-scope.json content (none when empty).
+func setupLintScope(t *testing.T, config string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if config != "" {
+		if err := os.WriteFile(filepath.Join(dir, lintScopeFile), []byte(config), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestInLintScope(t *testing.T) {
+	dir := setupLintScope(t, `{
+		"include": ["src/**", "apps/*/src/**"],
+		"exclude": ["**/vendor/**", "src/examples/**"]
+	}`)
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"src/index.ts", true},
+		{"src/lib/deep/util.ts", true},
+		{"apps/web/src/page.tsx", true},
+		{"src/vendor/lodash.js", false},
+		{"src/examples/demo.ts", false},
+		{"scripts/build.ts", false},
+		{"apps/web/next.config.js", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := inLintScope(filepath.Join(dir, tt.path)); got != tt.want {
+				t.Errorf("inLintScope(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInLintScope_Defaults(t *testing.T) {
+	t.Run("no config", func(t *testing.T) {
+		dir := setupLintScope(t, "")
+		if !inLintScope(filepath.Join(dir, "vendor", "lib.js")) {
+			t.Error("expected every file in scope without a config")
+		}
+	})
+	t.Run("exclude only", func(t *testing.T) {
+		dir := setupLintScope(t, `{"exclude": ["vendor/**"]}`)
+		if !inLintScope(filepath.Join(dir, "src", "app.ts")) {
+			t.Error("expected files outside exclude to be in scope")
+		}
+		if inLintScope(filepath.Join(dir, "vendor", "lib.js")) {
+			t.Error("expected excluded file to be out of scope")
+		}
+	})
+	t.Run("malformed config", func(t *testing.T) {
+		dir := setupLintScope(t, `{"exclude": [`)
+		if !inLintScope(filepath.Join(dir, "vendor", "lib.js")) {
+			t.Error("expected a malformed config to leave every file in scope")
+		}
+	})
+}
+
+func TestProcessHook_LintScope(t *testing.T) {
+	dir := setupLintScope(t, `{"exclude": ["vendor/**", "examples/**"]}`)
+	content := "// eslint-disable-next-line no-console\nconsole.log(x);\n"
+
+	tests := []struct {
+		name     string
+		path     string
+		decision string
+	}{
+		{"excluded vendored file", "vendor/lib/index.js", "approve"},
+		{"excluded example", "examples/basic.ts", "approve"},
+		{"file in scope", "src/app.ts", "block"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := processHook(&HookInput{
+				ToolName: "Write",
+				ToolInput: map[string]interface{}{
+					"file_path": filepath.Join(dir, tt.path),
+					"content":   content,
+				},
+			})
+			if output.Decision != tt.decision {
+				t.Errorf("expected %s, got %s (reason: %s)", tt.decision, output.Decision, output.Reason)
+			}
+		})
+	}
+}
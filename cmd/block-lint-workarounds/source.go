@@ -0,0 +1,219 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+// sourceText is JS/TS source split into code and comments, so workaround
+// patterns are only matched where they would take effect.
+type sourceText struct {
+	// code is the source with every comment and string literal (including
+	// template literal text, but not ${} expressions) replaced by spaces.
+	// Newlines and offsets are preserved.
+	code string
+	// comments holds each comment with its // or /* */ delimiters.
+	comments []string
+}
+
+// scanSource splits text into code and comments. It is a lexer-level
+// approximation. A "/" starts a regex literal where an operand is expected
+// (see regexAllowed). A quote right after an identifier character (the
+// apostrophe in JSX text like Don't) or with no closing partner on its line
+// is left as code, so it can't hide a comment that follows it.
+func scanSource(text string) sourceText {
+	code := []byte(text)
+	var comments []string
+	blank := func(from, to int) {
+		for k := from; k < to; k++ {
+			if code[k] != '\n' {
+				code[k] = ' '
+			}
+		}
+	}
+
+	// templates holds, for each ${ currently open, the brace depth at which
+	// its closing } returns to the template literal's text.
+	var templates []int
+	depth := 0
+
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case strings.HasPrefix(text[i:], "//"):
+			end := strings.IndexByte(text[i:], '\n')
+			if end == -1 {
+				end = len(text)
+			} else {
+				end += i
+			}
+			comments = append(comments, text[i:end])
+			blank(i, end)
+			i = end
+		case strings.HasPrefix(text[i:], "/*"):
+			end := strings.Index(text[i+2:], "*/")
+			if end == -1 {
+				end = len(text)
+			} else {
+				end += i + 4
+			}
+			comments = append(comments, text[i:end])
+			blank(i, end)
+			i = end
+		case c == '/' && regexAllowed(code[:i]):
+			end, closed := regexEnd(text, i)
+			if !closed {
+				i++
+				continue
+			}
+			blank(i, end)
+			i = end
+		case c == '\'' || c == '"':
+			end, closed := quotedEnd(text, i)
+			if !closed || (i > 0 && isIdentByte(text[i-1])) {
+				i++
+				continue
+			}
+			blank(i, end)
+			i = end
+		case c == '`':
+			end, interpolation := templateEnd(text, i+1)
+			blank(i, end)
+			if interpolation {
+				templates = append(templates, depth)
+				depth++
+			}
+			i = end
+		case c == '{':
+			depth++
+			i++
+		case c == '}':
+			depth--
+			i++
+			if n := len(templates); n > 0 && depth == templates[n-1] {
+				// Back in the template literal's text after ${...}
+				templates = templates[:n-1]
+				end, interpolation := templateEnd(text, i)
+				blank(i-1, end)
+				if interpolation {
+					templates = append(templates, depth)
+					depth++
+				}
+				i = end
+			}
+		default:
+			i++
+		}
+	}
+
+	return sourceText{code: string(code), comments: comments}
+}
+
+// quotedEnd returns the index just past the '- or "-quoted string starting
+// at text[start]. closed is false when the line or text ends first.
+func quotedEnd(text string, start int) (end int, closed bool) {
+	quote := text[start]
+	for i := start + 1; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case quote:
+			return i + 1, true
+		case '\n':
+			return i, false
+		}
+	}
+	return len(text), false
+}
+
+// regexKeywords may directly precede a regex literal.
+var regexKeywords = []string{"return", "typeof", "case", "do", "else", "in", "of", "new", "delete", "void", "throw", "yield", "await"}
+
+// regexAllowed reports whether a "/" after code (comments and strings
+// already blanked) starts a regex literal rather than a division: at the
+// start of the text, after an operator or opening punctuation, or after a
+// keyword. "<" and ">" are excluded so JSX closing tags stay code.
+func regexAllowed(code []byte) bool {
+	end := len(code)
+	for end > 0 && (code[end-1] == ' ' || code[end-1] == '\t' || code[end-1] == '\n' || code[end-1] == '\r') {
+		end--
+	}
+	if end == 0 {
+		return true
+	}
+	if strings.IndexByte("(,=:[!&|?{};+-*%~^", code[end-1]) >= 0 {
+		return true
+	}
+	start := end
+	for start > 0 && isIdentByte(code[start-1]) {
+		start--
+	}
+	word := string(code[start:end])
+	for _, kw := range regexKeywords {
+		if word == kw {
+			return true
+		}
+	}
+	return false
+}
+
+// regexEnd returns the index just past the regex literal starting at
+// text[start], including its flags. closed is false when the line or text
+// ends first.
+func regexEnd(text string, start int) (end int, closed bool) {
+	inClass := false
+	for i := start + 1; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '[':
+			inClass = true
+		case ']':
+			inClass = false
+		case '\n':
+			return i, false
+		case '/':
+			if inClass {
+				continue
+			}
+			for i++; i < len(text) && isIdentByte(text[i]); i++ {
+			}
+			return i, true
+		}
+	}
+	return len(text), false
+}
+
+// isIdentByte reports whether b can be part of a JS identifier (ASCII only).
+func isIdentByte(b byte) bool {
+	return b == '_' || b == '$' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9'
+}
+
+// templateEnd scans template literal text from start. It returns the index
+// just past the closing backtick, or just past a "${" with interpolation
+// set, in which case an expression follows.
+func templateEnd(text string, start int) (end int, interpolation bool) {
+	for i := start; i < len(text); i++ {
+		switch {
+		case text[i] == '\\':
+			i++
+		case text[i] == '`':
+			return i + 1, false
+		case strings.HasPrefix(text[i:], "${"):
+			return i + 2, true
+		}
+	}
+	return len(text), false
+}
+
+// findCommentDirective returns the first comment that re matches. Patterns
+// are anchored at the comment's start, so a directive mentioned inside a
+// longer comment (documentation, commented-out examples) is not a match.
+func findCommentDirective(comments []string, re *regexp.Regexp) string {
+	for _, comment := range comments {
+		if match := re.FindString(comment); match != "" {
+			return match
+		}
+	}
+	return ""
+}
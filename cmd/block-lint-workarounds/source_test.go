@@ -0,0 +1,140 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Workaround markers are assembled at runtime so this file doesn't trip the
+// hook when edited.
+var (
+	underscoreAlias = "as " + "_unused"
+	underscoreKey   = ": " + "_unused"
+	eslintDisable   = "eslint" + "-disable"
+	tsIgnore        = "@" + "ts-ignore"
+)
+
+func TestScanSource(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		code     string
+		comments []string
+	}{
+		{
+			name:     "line and block comments",
+			text:     "a; // one\nb; /* two */ c;",
+			code:     "a;       \nb;           c;",
+			comments: []string{"// one", "/* two */"},
+		},
+		{
+			name: "string literals",
+			text: `x = 'it\'s'; y = "// no";`,
+			code: `x =        ; y =        ;`,
+		},
+		{
+			name: "template literal keeps interpolation code",
+			text: "s = `a ${f({k: 1})} b`;",
+			code: "s =      f({k: 1})    ;",
+		},
+		{
+			name:     "comment markers inside strings are not comments",
+			text:     `u = "http://x"; /* real */`,
+			code:     `u =           ;           `,
+			comments: []string{"/* real */"},
+		},
+		{
+			name: "unterminated quote is left as code",
+			text: "<p>Don't</p>\nconst a = 1;",
+			code: "<p>Don't</p>\nconst a = 1;",
+		},
+		{
+			name:     "quote inside a regex literal",
+			text:     "const re = /'/g; // it's fine",
+			code:     "const re =     ;             ",
+			comments: []string{"// it's fine"},
+		},
+		{
+			name:     "apostrophe after a word does not open a string",
+			text:     "<p>Don't</p>; // it's fine",
+			code:     "<p>Don't</p>;             ",
+			comments: []string{"// it's fine"},
+		},
+		{
+			name:     "division is not a regex",
+			text:     "x = (a) / b; y = c / 'd'; // n/m",
+			code:     "x = (a) / b; y = c /    ;       ",
+			comments: []string{"// n/m"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := scanSource(tt.text)
+			if got.code != tt.code {
+				t.Errorf("code = %q, want %q", got.code, tt.code)
+			}
+			if !reflect.DeepEqual(got.comments, tt.comments) {
+				t.Errorf("comments = %q, want %q", got.comments, tt.comments)
+			}
+		})
+	}
+}
+
+func TestCheckUnderscorePrefixes_IgnoresStringsAndComments(t *testing.T) {
+	tests := []struct {
+		name        string
+		text        string
+		shouldBlock bool
+	}{
+		{"inside a string", `const msg = "do not write { a` + underscoreKey + ` }";`, false},
+		{"inside a template literal", "const msg = `import { X " + underscoreAlias + " }`;", false},
+		{"inside a line comment", "// never write { a" + underscoreKey + " }\nconst a = 1;", false},
+		{"inside a JSDoc block", "/**\n * Bad: import { X " + underscoreAlias + " }\n */\nexport {}", false},
+		{"in real code", "const { a" + underscoreKey + " } = obj;", true},
+		{"in template interpolation", "const s = `${(({ a" + underscoreKey + " }) => 1)(o)}`;", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := checkUnderscorePrefixes(tt.text, false)
+			if blocked := output != nil && output.Decision == "block"; blocked != tt.shouldBlock {
+				t.Errorf("blocked = %v, want %v", blocked, tt.shouldBlock)
+			}
+		})
+	}
+}
+
+func TestCommentDirectives_IgnoreMentions(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		check func(string) *HookOutput
+		hit   bool
+	}{
+		{"eslint directive in a string", `const rule = "// ` + eslintDisable + `";`, checkESLintDisable, false},
+		{"eslint directive mentioned in a comment", "// avoid `// " + eslintDisable + "` here", checkESLintDisable, false},
+		{"eslint directive as trailing comment", "foo(); // " + eslintDisable + "-line", checkESLintDisable, true},
+		{"eslint directive after an apostrophe in JSX text", "const a = <p>Don't</p>; // " + eslintDisable + "-line", checkESLintDisable, true},
+		{"eslint directive after a quote in a regex", "const re = /'/; // " + eslintDisable + "-line no-console", checkESLintDisable, true},
+		{"eslint directive with an apostrophe after a regex quote", "const r = /'/; foo(); // " + eslintDisable + "-line -- it's fine", checkESLintDisable, true},
+		{"eslint directive with an apostrophe after JSX text", "const a = <p>Don't</p>; // " + eslintDisable + "-line -- it's fine", checkESLintDisable, true},
+		{"ts-ignore in commented-out example", "/*\n  // " + tsIgnore + "\n  bad();\n*/\nok();", checkTSIgnore, false},
+		{"ts-ignore in a string", `const hint = "// ` + tsIgnore + `";`, checkTSIgnore, false},
+		{"ts-ignore directive", "// " + tsIgnore + "\nbad();", checkTSIgnore, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := tt.check(tt.text)
+			if hit := output != nil; hit != tt.hit {
+				reason := ""
+				if output != nil {
+					reason = strings.SplitN(output.Reason, "\n", 2)[0]
+				}
+				t.Errorf("hit = %v, want %v (%s)", hit, tt.hit, reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// import { X as _X } / export { X as _X } / import * as _ns
+	underscoreImportAliasRe = regexp.MustCompile(`\b(?:import|export)\s+(?:type\s+)?(?:\w+\s*,\s*)?\{[^}]*?\bas\s+_\w+|\bimport\s+(?:type\s+)?\*\s+as\s+_\w+`)
+
+	// { type X as _X } inside an import list
+	underscoreTypeAliasRe = regexp.MustCompile(`type\s+\w+\s+as\s+_\w*`)
+
+	// key: _name — a destructuring rename, object value or type annotation
+	underscoreColonRe = regexp.MustCompile(`\b(\w+)\s*:\s*(_\w+)`)
+
+	// expr as _Type outside an import or export list
+	underscoreCastRe = regexp.MustCompile(`\bas\s+_\w+`)
+
+	// const|let|var right before a destructuring pattern's {
+	declKeywordRe = regexp.MustCompile(`\b(?:const|let|var)$`)
+
+	// A key right before a nested pattern's {
+	nestedKeyRe = regexp.MustCompile(`\w\s*:$`)
+
+	// What follows a destructuring pattern's }: "= value", or the end of a
+	// parameter list and then => or a function body
+	destructureTailRe = regexp.MustCompile(`^\s*(?:=[^=>]|(?::[^,)=]*)?(?:,[^)]*)?\)\s*(?::[^={]*)?(?:=>|\{))`)
+)
+
+// checkUnderscorePrefixes looks for underscore renames in code only; string
+// literals and comments, such as docs describing the anti-pattern, are
+// skipped.
+//
+// Clear lint-silencing renames are blocked: an `as _name` alias in an import
+// or export list, a destructuring rename (`const { data: _data } = ...`), and
+// `foo: _foo` anywhere. Other `: _name` and `as _Name` uses may be a real
+// underscored identifier (`{ key: _privateVar }`, `x: _Foo`), so they are
+// approved with a warning.
+func checkUnderscorePrefixes(text string, isConvexFile bool) *HookOutput {
+	code := scanSource(text).code
+
+	// In Convex files, allow Convex system fields like _id and _creationTime
+	allowed := func(match string) bool {
+		return isConvexFile && convexSystemFields.MatchString(match)
+	}
+
+	var warning string
+	for _, re := range []*regexp.Regexp{underscoreImportAliasRe, underscoreTypeAliasRe} {
+		for _, match := range re.FindAllString(code, -1) {
+			if !allowed(match) {
+				return underscoreBlock(match)
+			}
+		}
+	}
+
+	for _, m := range underscoreColonRe.FindAllStringSubmatchIndex(code, -1) {
+		match := code[m[0]:m[1]]
+		if allowed(match) {
+			continue
+		}
+		key, renamed := code[m[2]:m[3]], code[m[4]:m[5]]
+		if renamed[1:] == key || inDestructuringPattern(code, m[0]) {
+			return underscoreBlock(match)
+		}
+		if warning == "" {
+			warning = match
+		}
+	}
+
+	if warning == "" {
+		for _, match := range underscoreCastRe.FindAllString(code, -1) {
+			if !allowed(match) {
+				warning = match
+				break
+			}
+		}
+	}
+
+	if warning != "" {
+		return &HookOutput{
+			Decision: "approve",
+			Reason: fmt.Sprintf(`WARNING: Possible underscore prefix workaround
+
+Found: %s
+
+If this renames an unused variable to silence a lint error, remove the
+variable instead. Identifiers that are genuinely underscored (private
+values, types named _Foo) are fine.`, warning),
+		}
+	}
+
+	return nil
+}
+
+func underscoreBlock(match string) *HookOutput {
+	return &HookOutput{
+		Decision: "block",
+		Reason: fmt.Sprintf(`BLOCKED: Underscore prefix workaround detected
+
+Found: %s
+
+Do not prefix unused imports/variables with underscore to silence lint errors.
+Instead, REMOVE the unused import or variable entirely.
+
+If you need the import for type-only usage, use 'import type { ... }' syntax.`, match),
+	}
+}
+
+// inDestructuringPattern reports whether pos sits directly inside a { }
+// that is a destructuring pattern: declared with const/let/var, assigned
+// to, or a function parameter.
+func inDestructuringPattern(code string, pos int) bool {
+	open := enclosingBrace(code, pos)
+	if open < 0 {
+		return false
+	}
+	before := strings.TrimRight(code[:open], " \t\r\n")
+	if declKeywordRe.MatchString(before) {
+		return true
+	}
+	// { outer: { inner: _x } } is destructuring when the outer pattern is
+	if nestedKeyRe.MatchString(before) {
+		return inDestructuringPattern(code, open)
+	}
+	closing := matchingBrace(code, open)
+	return closing >= 0 && destructureTailRe.MatchString(code[closing+1:])
+}
+
+// enclosingBrace returns the index of the innermost unclosed { before pos,
+// or -1.
+func enclosingBrace(code string, pos int) int {
+	depth := 0
+	for i := pos - 1; i >= 0; i-- {
+		switch code[i] {
+		case '}':
+			depth++
+		case '{':
+			if depth == 0 {
+				return i
+			}
+			depth--
+		}
+	}
+	return -1
+}
+
+// matchingBrace returns the index of the } closing the { at open, or -1.
+func matchingBrace(code string, open int) int {
+	depth := 0
+	for i := open; i < len(code); i++ {
+		switch code[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
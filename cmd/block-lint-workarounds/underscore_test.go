@@ -0,0 +1,120 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckUnderscorePrefixes_Context(t *testing.T) {
+	// Build test strings dynamically to avoid triggering the hook on this file
+	u := "_"
+
+	tests := []struct {
+		name     string
+		text     string
+		decision string // "block", "approve" (warning) or "" (nil)
+	}{
+		{
+			name:     "same-name rename",
+			text:     "const opts = { foo: " + u + "foo };",
+			decision: "block",
+		},
+		{
+			name:     "destructuring assignment",
+			text:     "({ data: " + u + "data2 } = result);",
+			decision: "block",
+		},
+		{
+			name:     "arrow function parameter destructuring",
+			text:     "const f = ({ a: " + u + "b }) => 1;",
+			decision: "block",
+		},
+		{
+			name:     "function parameter destructuring with type",
+			text:     "function f({ a: " + u + "b }: Props) {}",
+			decision: "block",
+		},
+		{
+			name:     "nested destructuring",
+			text:     "const { outer: { inner: " + u + "inner2 } } = obj;",
+			decision: "block",
+		},
+		{
+			name:     "namespace import alias",
+			text:     "import * as " + u + "ns from './ns';",
+			decision: "block",
+		},
+		{
+			name:     "export alias",
+			text:     "export { helper as " + u + "helper } from './helper';",
+			decision: "block",
+		},
+		{
+			name:     "object literal with underscored value",
+			text:     "const config = { key: " + u + "privateVar };",
+			decision: "approve",
+		},
+		{
+			name:     "function call argument",
+			text:     "register({ handler: " + u + "handler2 });",
+			decision: "approve",
+		},
+		{
+			name:     "type annotation",
+			text:     "let x: " + u + "Foo = make();",
+			decision: "approve",
+		},
+		{
+			name:     "type cast",
+			text:     "const y = value as " + u + "Foo;",
+			decision: "approve",
+		},
+		{
+			name:     "underscored identifier without rename",
+			text:     "const " + u + "cache = new Map();",
+			decision: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := checkUnderscorePrefixes(tt.text, false)
+			if tt.decision == "" {
+				if output != nil {
+					t.Fatalf("expected nil, got %s: %s", output.Decision, output.Reason)
+				}
+				return
+			}
+			if output == nil {
+				t.Fatalf("expected %s, got nil", tt.decision)
+			}
+			if output.Decision != tt.decision {
+				t.Errorf("expected %s, got %s: %s", tt.decision, output.Decision, output.Reason)
+			}
+			if tt.decision == "approve" && !strings.Contains(output.Reason, "WARNING") {
+				t.Errorf("expected a warning reason, got %q", output.Reason)
+			}
+		})
+	}
+}
+
+func TestProcessHook_UnderscoreWarningDoesNotHideBlocks(t *testing.T) {
+	warn := "const config = { key: " + "_" + "privateVar };\n"
+	disable := "// eslint-" + "disable-next-line no-console\n"
+
+	output := processHook(&HookInput{
+		ToolName:  "Write",
+		ToolInput: map[string]interface{}{"file_path": "/project/src/app.ts", "content": warn + disable},
+	})
+	if output.Decision != "block" {
+		t.Fatalf("expected block, got %s: %s", output.Decision, output.Reason)
+	}
+
+	output = processHook(&HookInput{
+		ToolName:  "Write",
+		ToolInput: map[string]interface{}{"file_path": "/project/src/app.ts", "content": warn},
+	})
+	if output.Decision != "approve" || !strings.Contains(output.Reason, "WARNING") {
+		t.Fatalf("expected approve with warning, got %s: %s", output.Decision, output.Reason)
+	}
+}
@@ -10,6 +10,15 @@
 //  2. Flipping any "features.*" boolean from true to false. Same logic at
 //     the feature level — if a check is annoying, disable it.
 //
+//  3. Setting a "checkSeverity" entry to "warning" or "off", or from
+//     "warning" to "off". That makes a check non-blocking or disables it
+//     just as a features flag would.
+//
+//  4. Any change to "blockDestructiveCommandsConfig". block-destructive-
+//     commands reads its allowed disk devices, unprotected force-push
+//     targets and pattern severities from it, so an agent could otherwise
+//     allowlist the command it is about to run.
+//
 // Additions to excludePaths that are legitimate (e.g. a genuinely generated
 // directory) should be made by a human editing the file directly. There is
 // no sentinel or bypass — agents learn sentinels.
@@ -24,6 +33,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 
 	"github.com/milehighideas/claude-hooks/internal/jsonc"
@@ -119,6 +129,14 @@ func evaluate(in *hookInput, readFile readFileFn) *hookOutput {
 		return blockFeatureToggle(disabled)
 	}
 
+	if weakened := weakenedSeverities(oldJSON, newJSON); len(weakened) > 0 {
+		return blockSeverityChange(weakened)
+	}
+
+	if !reflect.DeepEqual(destructiveCommandsConfig(oldJSON), destructiveCommandsConfig(newJSON)) {
+		return blockDestructiveConfigChange()
+	}
+
 	return approve()
 }
 
@@ -225,6 +243,51 @@ func collectFeatures(node interface{}) map[string]bool {
 	return out
 }
 
+// severityRank orders checkSeverity values from strictest to weakest. An
+// unset or unknown value counts as "error".
+var severityRank = map[string]int{"error": 0, "warning": 1, "off": 2}
+
+// weakenedSeverities returns "check: severity" for each checkSeverity entry
+// that is weaker in new than in old.
+func weakenedSeverities(oldJSON, newJSON interface{}) []string {
+	oldSeverity := collectSeverities(oldJSON)
+	var weakened []string
+	for name, severity := range collectSeverities(newJSON) {
+		if severityRank[severity] > severityRank[oldSeverity[name]] {
+			weakened = append(weakened, name+": "+severity)
+		}
+	}
+	return sortedUnique(weakened)
+}
+
+func collectSeverities(node interface{}) map[string]string {
+	out := map[string]string{}
+	walk(node, func(key string, value interface{}) {
+		if key != "checkSeverity" {
+			return
+		}
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return
+		}
+		for k, v := range obj {
+			if s, ok := v.(string); ok {
+				out[k] = s
+			}
+		}
+	})
+	return out
+}
+
+// destructiveCommandsConfig returns the top-level
+// blockDestructiveCommandsConfig block, or nil.
+func destructiveCommandsConfig(node interface{}) interface{} {
+	if obj, ok := node.(map[string]interface{}); ok {
+		return obj["blockDestructiveCommandsConfig"]
+	}
+	return nil
+}
+
 // walk visits every (key, value) pair in the JSON tree, descending into
 // objects and arrays. Root values are visited with key "".
 func walk(node interface{}, visit func(key string, value interface{})) {
@@ -294,3 +357,28 @@ the underlying issue. If a feature should genuinely be turned off, a
 human must make that change directly.`, strings.Join(disabled, ", ")),
 	}
 }
+
+func blockSeverityChange(weakened []string) *hookOutput {
+	return &hookOutput{
+		Decision: "block",
+		Reason: fmt.Sprintf(`BLOCKED: weakening check severity in .pre-commit.json
+
+Changed: %s
+
+A checkSeverity of "warning" lets a failing check through and "off" turns
+it off entirely, the same escape hatch as flipping features.* to false. If
+a check is failing, fix the underlying issue. If its severity should
+genuinely change, a human must make that change directly.`, strings.Join(weakened, ", ")),
+	}
+}
+
+func blockDestructiveConfigChange() *hookOutput {
+	return &hookOutput{
+		Decision: "block",
+		Reason: `BLOCKED: editing blockDestructiveCommandsConfig in .pre-commit.json
+
+block-destructive-commands reads its allowed disk devices, force-push
+targets and pattern severities from this block, so changing it can let a
+destructive command through. A human must make that change directly.`,
+	}
+}
@@ -149,6 +149,61 @@ func TestEvaluate_AllowsFeatureFlippedTrue(t *testing.T) {
 	}
 }
 
+func TestEvaluate_BlocksWeakenedCheckSeverity(t *testing.T) {
+	original := `{
+  "features": { "missingTestsCheck": true, "lint": true },
+  "checkSeverity": { "lint": "warning" }
+}`
+	for _, after := range []string{
+		`{ "features": { "missingTestsCheck": true, "lint": true }, "checkSeverity": { "lint": "warning", "missingTestsCheck": "off" } }`,
+		`{ "features": { "missingTestsCheck": true, "lint": true }, "checkSeverity": { "lint": "warning", "missingTestsCheck": "warning" } }`,
+		`{ "features": { "missingTestsCheck": true, "lint": true }, "checkSeverity": { "lint": "off" } }`,
+	} {
+		out := evaluate(writeInput("/repo/.pre-commit.json", after), mockRead(original))
+		if out.Decision != "block" {
+			t.Errorf("expected block for %s, got %+v", after, out)
+		}
+	}
+}
+
+func TestEvaluate_AllowsStrengthenedCheckSeverity(t *testing.T) {
+	original := `{ "checkSeverity": { "lint": "off", "typecheck": "warning" } }`
+	after := `{ "checkSeverity": { "lint": "warning", "missingTestsCheck": "error" } }`
+	out := evaluate(writeInput("/repo/.pre-commit.json", after), mockRead(original))
+	if out.Decision != "approve" {
+		t.Fatalf("expected approve for stricter severities, got %+v", out)
+	}
+}
+
+func TestEvaluate_BlocksDestructiveCommandsConfigChange(t *testing.T) {
+	original := `{
+  "blockDestructiveCommandsConfig": { "protectedBranches": ["main"] }
+}`
+	for _, after := range []string{
+		`{ "blockDestructiveCommandsConfig": { "protectedBranches": ["main"], "allowedDevices": ["/dev/sda"] } }`,
+		`{ "blockDestructiveCommandsConfig": { "protectedBranches": [] } }`,
+		`{ "blockDestructiveCommandsConfig": { "protectedBranches": ["main"], "severity": { "package-manager": "off" } } }`,
+		`{}`,
+	} {
+		out := evaluate(writeInput("/repo/.pre-commit.json", after), mockRead(original))
+		if out.Decision != "block" {
+			t.Errorf("expected block for %s, got %+v", after, out)
+		}
+	}
+
+	// A new .pre-commit.json nearer the working directory is read first.
+	created := `{ "blockDestructiveCommandsConfig": { "allowedDevices": ["/dev/sda"] } }`
+	if out := evaluate(writeInput("/repo/apps/web/.pre-commit.json", created), mockRead("")); out.Decision != "block" {
+		t.Errorf("expected block for a new file with the block, got %+v", out)
+	}
+
+	// Unrelated edits elsewhere in the file still pass.
+	after := `{ "features": { "lint": true }, "blockDestructiveCommandsConfig": { "protectedBranches": ["main"] } }`
+	if out := evaluate(writeInput("/repo/.pre-commit.json", after), mockRead(original)); out.Decision != "approve" {
+		t.Errorf("expected approve for an unrelated edit, got %+v", out)
+	}
+}
+
 func TestEvaluate_AllowsUnrelatedChanges(t *testing.T) {
 	original := `{
   "features": { "missingTestsCheck": true },
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// fragmentTimestampRe matches the timestamp prefix of a fragment filename.
+var fragmentTimestampRe = regexp.MustCompile(`^(\d{8}-\d{6})-`)
+
+// latestFragment returns the path and timestamp of the most recently created
+// fragment in dir, judged by the timestamp in its filename. Fragments from
+// the same second are ordered by modification time. Files without a
+// timestamp prefix are not fragments changelog-add created and are ignored.
+func latestFragment(dir string) (string, string, bool) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", "", false
+	}
+	var latest, latestStamp string
+	var latestMod time.Time
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".txt") {
+			continue
+		}
+		m := fragmentTimestampRe.FindStringSubmatch(entry.Name())
+		if m == nil {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		if latest == "" || m[1] > latestStamp || (m[1] == latestStamp && info.ModTime().After(latestMod)) {
+			latest, latestStamp, latestMod = filepath.Join(dir, entry.Name()), m[1], info.ModTime()
+		}
+	}
+	return latest, latestStamp, latest != ""
+}
+
+// amendFragment replaces the most recent fragment in the .changelog/ that
+// entryText resolves to: its content becomes entryText and it is renamed to
+// match the new type, scope and description, keeping its timestamp so it
+// stays in place when compiled. It returns the fragment's old and new paths
+// relative to projectRoot, which are equal when the name didn't change.
+func amendFragment(entryText string, appPath string, projectRoot string) (string, string, error) {
+	commitType, scope, description, err := parseConventionalCommit(entryText)
+	if err != nil {
+		return "", "", err
+	}
+
+	changelogDir := fragmentDir(projectRoot, appPath)
+	oldPath, timestamp, ok := latestFragment(changelogDir)
+	if !ok {
+		return "", "", fmt.Errorf("no changelog fragment to amend in %s", relToRoot(projectRoot, changelogDir))
+	}
+
+	newPath := filepath.Join(changelogDir, fragmentFilename(timestamp, commitType, scope, description))
+	if newPath != oldPath {
+		newPath = freeFragmentPath(changelogDir, filepath.Base(newPath))
+	}
+
+	content := strings.TrimSpace(entryText) + "\n"
+	if err := os.WriteFile(newPath, []byte(content), 0644); err != nil {
+		return "", "", fmt.Errorf("failed to write fragment: %w", err)
+	}
+	if newPath != oldPath {
+		if err := os.Remove(oldPath); err != nil {
+			return "", "", fmt.Errorf("failed to remove old fragment: %w", err)
+		}
+	}
+
+	return relToRoot(projectRoot, oldPath), relToRoot(projectRoot, newPath), nil
+}
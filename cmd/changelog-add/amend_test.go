@@ -0,0 +1,123 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeFragment writes a fragment file named name into dir.
+func writeFragment(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAmendFragmentRewritesLatest(t *testing.T) {
+	tmpDir := t.TempDir()
+	dir := filepath.Join(tmpDir, "apps/web/.changelog")
+	writeFragment(t, dir, "20250128-154530-feat-web-add-search.txt", "feat(web): add search\n")
+	writeFragment(t, dir, "20250128-154532-fix-web-resolve-navgation-bug.txt", "fix(web): resolve navgation bug\n")
+	writeFragment(t, dir, "notes.txt", "not a fragment\n")
+	writeFragment(t, dir, ".gitkeep", "")
+
+	oldPath, newPath, err := amendFragment("fix(web): resolve navigation bug\n\n- back button works again\n", "apps/web", tmpDir)
+	if err != nil {
+		t.Fatalf("amendFragment() error = %v", err)
+	}
+	if want := "apps/web/.changelog/20250128-154532-fix-web-resolve-navgation-bug.txt"; oldPath != filepath.FromSlash(want) {
+		t.Errorf("oldPath = %q, want %q", oldPath, want)
+	}
+	if want := "apps/web/.changelog/20250128-154532-fix-web-resolve-navigation-bug.txt"; newPath != filepath.FromSlash(want) {
+		t.Errorf("newPath = %q, want %q (timestamp kept, slug updated)", newPath, want)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, newPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "fix(web): resolve navigation bug\n\n- back button works again\n"; string(content) != want {
+		t.Errorf("content = %q, want %q", content, want)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, oldPath)); !os.IsNotExist(err) {
+		t.Error("old fragment still exists after rename")
+	}
+
+	// Older fragments and non-fragment files are untouched.
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 4 {
+		t.Errorf(".changelog has %d entries, want 4", len(entries))
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "20250128-154530-feat-web-add-search.txt")); string(data) != "feat(web): add search\n" {
+		t.Errorf("older fragment changed: %q", data)
+	}
+}
+
+func TestAmendFragmentTypeChangeAndSameName(t *testing.T) {
+	tmpDir := t.TempDir()
+	dir := filepath.Join(tmpDir, ".changelog")
+	writeFragment(t, dir, "20250128-154532-feat-update-dependencies.txt", "feat: update dependencies\n")
+
+	_, newPath, err := amendFragment("chore: update dependencies", "", tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(".changelog", "20250128-154532-chore-update-dependencies.txt"); newPath != want {
+		t.Errorf("newPath = %q, want %q", newPath, want)
+	}
+
+	// Amending again with the same header only rewrites the content.
+	oldPath, newPath, err := amendFragment("chore: update dependencies\n\n- react 19", "", tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if oldPath != newPath {
+		t.Errorf("same header renamed %q to %q", oldPath, newPath)
+	}
+	if data, _ := os.ReadFile(filepath.Join(tmpDir, newPath)); !strings.Contains(string(data), "- react 19") {
+		t.Errorf("content = %q, want the amended body", data)
+	}
+}
+
+func TestAmendFragmentNoFragment(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, root string)
+	}{
+		{"missing .changelog", func(t *testing.T, root string) {}},
+		{"only .gitkeep", func(t *testing.T, root string) {
+			writeFragment(t, filepath.Join(root, ".changelog"), ".gitkeep", "")
+		}},
+		{"fragments in another app only", func(t *testing.T, root string) {
+			writeFragment(t, filepath.Join(root, "apps/web/.changelog"), "20250128-154532-feat-web-add-search.txt", "feat(web): add search\n")
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			tt.setup(t, tmpDir)
+
+			_, _, err := amendFragment("fix: resolve bug", "", tmpDir)
+			if err == nil || !strings.Contains(err.Error(), "no changelog fragment to amend in .changelog") {
+				t.Errorf("err = %v, want a no-fragment error naming .changelog", err)
+			}
+		})
+	}
+}
+
+func TestAmendFragmentInvalidEntry(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFragment(t, filepath.Join(tmpDir, ".changelog"), "20250128-154532-fix-resolve-bug.txt", "fix: resolve bug\n")
+
+	if _, _, err := amendFragment("resolve bug", "", tmpDir); err == nil {
+		t.Error("want an error for an entry without a type")
+	}
+	if data, _ := os.ReadFile(filepath.Join(tmpDir, ".changelog", "20250128-154532-fix-resolve-bug.txt")); string(data) != "fix: resolve bug\n" {
+		t.Errorf("fragment changed by a rejected amend: %q", data)
+	}
+}
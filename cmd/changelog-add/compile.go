@@ -0,0 +1,236 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// typeSections maps conventional-commit types to CHANGELOG.md section
+// headings, in the order sections are rendered.
+var typeSections = []struct {
+	Type    string
+	Heading string
+}{
+	{"feat", "Features"},
+	{"fix", "Bug Fixes"},
+	{"perf", "Performance"},
+	{"refactor", "Refactoring"},
+	{"revert", "Reverts"},
+	{"docs", "Documentation"},
+	{"test", "Tests"},
+	{"build", "Build"},
+	{"ci", "CI"},
+	{"style", "Style"},
+	{"chore", "Chores"},
+}
+
+// fragment is one parsed .changelog/*.txt file.
+type fragment struct {
+	Path        string
+	Type        string
+	Scope       string
+	Description string
+	Body        []string // lines after the header, blank edges trimmed
+}
+
+// compileTarget pairs a .changelog/ directory with the CHANGELOG.md its
+// fragments compile into.
+type compileTarget struct {
+	ChangelogDir  string
+	ChangelogFile string
+}
+
+// compileTargets returns the root target plus, in per-app and required
+// modes, one target per changelog-enabled app, sorted by app name.
+func compileTargets(config *PreCommitConfig, projectRoot string) []compileTarget {
+	targets := []compileTarget{{
+		ChangelogDir:  filepath.Join(projectRoot, ".changelog"),
+		ChangelogFile: filepath.Join(projectRoot, "CHANGELOG.md"),
+	}}
+	if config == nil || config.Changelog.Mode == "global" {
+		return targets
+	}
+
+	apps := getChangelogApps(config)
+	names := make([]string, 0, len(apps))
+	for name := range apps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		appRoot := filepath.Join(projectRoot, apps[name].Path)
+		targets = append(targets, compileTarget{
+			ChangelogDir:  filepath.Join(appRoot, ".changelog"),
+			ChangelogFile: filepath.Join(appRoot, "CHANGELOG.md"),
+		})
+	}
+	return targets
+}
+
+// readFragments parses every .txt fragment in dir, ordered by filename (the
+// timestamp prefix makes that chronological). A missing dir has no
+// fragments; an unparseable fragment is an error so nothing is lost.
+func readFragments(dir string) ([]fragment, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var fragments []fragment
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".txt") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		text := strings.TrimSpace(strings.ReplaceAll(string(data), "\r\n", "\n"))
+		commitType, scope, description, err := parseConventionalCommit(text)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+
+		var body []string
+		if idx := strings.IndexByte(text, '\n'); idx >= 0 {
+			body = strings.Split(strings.Trim(text[idx+1:], "\n"), "\n")
+		}
+		fragments = append(fragments, fragment{
+			Path:        path,
+			Type:        commitType,
+			Scope:       scope,
+			Description: description,
+			Body:        body,
+		})
+	}
+	sort.Slice(fragments, func(i, j int) bool { return fragments[i].Path < fragments[j].Path })
+	return fragments, nil
+}
+
+// renderSection renders fragments as a Markdown release section: a version
+// heading, then one subsection per type in typeSections order. Entries keep
+// their chronological order within a subsection.
+func renderSection(version, date string, fragments []fragment) string {
+	byType := make(map[string][]fragment)
+	for _, f := range fragments {
+		byType[f.Type] = append(byType[f.Type], f)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## %s (%s)\n", version, date)
+	for _, section := range typeSections {
+		entries := byType[section.Type]
+		if len(entries) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "\n### %s\n\n", section.Heading)
+		for _, f := range entries {
+			if f.Scope != "" {
+				fmt.Fprintf(&sb, "- **%s:** %s\n", f.Scope, f.Description)
+			} else {
+				fmt.Fprintf(&sb, "- %s\n", f.Description)
+			}
+			for _, line := range f.Body {
+				if strings.TrimSpace(line) == "" {
+					continue
+				}
+				fmt.Fprintf(&sb, "  %s\n", line)
+			}
+		}
+	}
+	return sb.String()
+}
+
+// prependSection inserts section into an existing CHANGELOG.md body, after a
+// leading "# " title (and its intro paragraph lines up to the first "## "),
+// or at the top when there is none. An empty existing file gets a title.
+func prependSection(existing, section string) string {
+	if strings.TrimSpace(existing) == "" {
+		return "# Changelog\n\n" + section
+	}
+	if !strings.HasPrefix(existing, "# ") {
+		return section + "\n" + existing
+	}
+	if idx := strings.Index(existing, "\n## "); idx >= 0 {
+		return existing[:idx+1] + section + "\n" + existing[idx+1:]
+	}
+	return strings.TrimRight(existing, "\n") + "\n\n" + section
+}
+
+// writeChangelog prepends section to file and deletes the consumed
+// fragments.
+func writeChangelog(file, section string, fragments []fragment) error {
+	existing, err := os.ReadFile(file)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.WriteFile(file, []byte(prependSection(string(existing), section)), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", file, err)
+	}
+	for _, f := range fragments {
+		if err := os.Remove(f.Path); err != nil {
+			return fmt.Errorf("failed to remove fragment %s: %w", f.Path, err)
+		}
+	}
+	return nil
+}
+
+// runCompile implements `changelog-add compile`. Every target's fragments
+// are parsed before anything is written, so one malformed fragment aborts
+// the whole run instead of leaving some changelogs compiled.
+func runCompile(args []string, config *PreCommitConfig, projectRoot string) error {
+	fs := flag.NewFlagSet("compile", flag.ContinueOnError)
+	version := fs.String("version", "Unreleased", "Version heading for the compiled section")
+	dryRun := fs.Bool("dry-run", false, "Print the rendered sections without writing or deleting anything")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	targets := compileTargets(config, projectRoot)
+	targetFragments := make([][]fragment, len(targets))
+	for i, target := range targets {
+		fragments, err := readFragments(target.ChangelogDir)
+		if err != nil {
+			return err
+		}
+		targetFragments[i] = fragments
+	}
+
+	date := time.Now().Format("2006-01-02")
+	compiled := 0
+	for i, target := range targets {
+		fragments := targetFragments[i]
+		if len(fragments) == 0 {
+			continue
+		}
+		compiled++
+
+		section := renderSection(*version, date, fragments)
+		rel, err := filepath.Rel(projectRoot, target.ChangelogFile)
+		if err != nil {
+			rel = target.ChangelogFile
+		}
+		if *dryRun {
+			fmt.Printf("==> %s (dry run)\n\n%s\n", rel, section)
+			continue
+		}
+		if err := writeChangelog(target.ChangelogFile, section, fragments); err != nil {
+			return err
+		}
+		fmt.Printf("Compiled %d fragment(s) into %s\n", len(fragments), rel)
+	}
+
+	if compiled == 0 {
+		fmt.Println("No changelog fragments to compile")
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeFragments creates .changelog/ fragments under dir, keyed by filename.
+func writeFragments(t *testing.T, dir string, fragments map[string]string) {
+	t.Helper()
+	changelogDir := filepath.Join(dir, ".changelog")
+	if err := os.MkdirAll(changelogDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(changelogDir, ".gitkeep"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range fragments {
+		if err := os.WriteFile(filepath.Join(changelogDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestReadFragmentsOrderAndBody(t *testing.T) {
+	dir := t.TempDir()
+	writeFragments(t, dir, map[string]string{
+		"20250102-090000-fix-web-resolve-bug.txt": "fix(web): resolve bug\n",
+		"20250101-090000-feat-web-add-search.txt": "feat(web): add search\n\n- fuzzy matching\n- keyboard shortcut\n",
+		"notes.md": "not a fragment",
+	})
+
+	fragments, err := readFragments(filepath.Join(dir, ".changelog"))
+	if err != nil {
+		t.Fatalf("readFragments() error = %v", err)
+	}
+	if len(fragments) != 2 {
+		t.Fatalf("got %d fragments, want 2", len(fragments))
+	}
+	first := fragments[0]
+	if first.Type != "feat" || first.Scope != "web" || first.Description != "add search" {
+		t.Errorf("first fragment = %+v, want the older feat(web) entry", first)
+	}
+	if got := strings.Join(first.Body, "|"); got != "- fuzzy matching|- keyboard shortcut" {
+		t.Errorf("body = %q", got)
+	}
+	if fragments[1].Type != "fix" {
+		t.Errorf("second fragment type = %q, want fix", fragments[1].Type)
+	}
+
+	if fragments, err := readFragments(filepath.Join(dir, "missing")); err != nil || fragments != nil {
+		t.Errorf("missing dir = (%v, %v), want (nil, nil)", fragments, err)
+	}
+}
+
+func TestRenderSectionGroupsAndOrders(t *testing.T) {
+	fragments := []fragment{
+		{Type: "chore", Description: "bump deps"},
+		{Type: "fix", Scope: "web", Description: "resolve bug"},
+		{Type: "feat", Scope: "web", Description: "add search", Body: []string{"", "- fuzzy matching"}},
+		{Type: "fix", Description: "handle empty input"},
+		{Type: "feat", Scope: "api", Description: "add endpoint"},
+	}
+
+	got := renderSection("1.2.0", "2025-01-28", fragments)
+	want := `## 1.2.0 (2025-01-28)
+
+### Features
+
+- **web:** add search
+  - fuzzy matching
+- **api:** add endpoint
+
+### Bug Fixes
+
+- **web:** resolve bug
+- handle empty input
+
+### Chores
+
+- bump deps
+`
+	if got != want {
+		t.Errorf("renderSection() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestPrependSection(t *testing.T) {
+	section := "## 1.1.0 (2025-02-01)\n\n### Features\n\n- new\n"
+	tests := []struct {
+		name     string
+		existing string
+		want     string
+	}{
+		{
+			name:     "new file gets a title",
+			existing: "",
+			want:     "# Changelog\n\n" + section,
+		},
+		{
+			name:     "inserted above the previous release",
+			existing: "# Changelog\n\nAll notable changes.\n\n## 1.0.0 (2025-01-01)\n\n- old\n",
+			want:     "# Changelog\n\nAll notable changes.\n\n" + section + "\n## 1.0.0 (2025-01-01)\n\n- old\n",
+		},
+		{
+			name:     "title without releases",
+			existing: "# Changelog\n",
+			want:     "# Changelog\n\n" + section,
+		},
+		{
+			name:     "no title",
+			existing: "## 1.0.0\n\n- old\n",
+			want:     section + "\n## 1.0.0\n\n- old\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := prependSection(tt.existing, section); got != tt.want {
+				t.Errorf("prependSection() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompileTargets(t *testing.T) {
+	config := &PreCommitConfig{
+		Apps: map[string]AppConfig{
+			"web":    {Path: "apps/web"},
+			"native": {Path: "apps/native"},
+		},
+		Changelog: ChangelogConfig{Mode: "global"},
+	}
+	if got := compileTargets(config, "/repo"); len(got) != 1 || got[0].ChangelogFile != "/repo/CHANGELOG.md" {
+		t.Errorf("global mode targets = %+v, want root only", got)
+	}
+
+	config.Changelog.Mode = "per-app"
+	got := compileTargets(config, "/repo")
+	wantFiles := []string{"/repo/CHANGELOG.md", "/repo/apps/native/CHANGELOG.md", "/repo/apps/web/CHANGELOG.md"}
+	if len(got) != len(wantFiles) {
+		t.Fatalf("per-app targets = %+v", got)
+	}
+	for i, want := range wantFiles {
+		if got[i].ChangelogFile != want {
+			t.Errorf("target %d = %s, want %s", i, got[i].ChangelogFile, want)
+		}
+	}
+}
+
+func TestRunCompile(t *testing.T) {
+	root := t.TempDir()
+	config := &PreCommitConfig{
+		Apps:      map[string]AppConfig{"web": {Path: "apps/web"}},
+		Changelog: ChangelogConfig{Mode: "per-app"},
+	}
+	writeFragments(t, root, map[string]string{
+		"20250101-090000-chore-update-ci.txt": "chore: update CI\n",
+	})
+	webRoot := filepath.Join(root, "apps/web")
+	writeFragments(t, webRoot, map[string]string{
+		"20250101-090000-feat-web-add-search.txt": "feat(web): add search\n",
+	})
+
+	if err := runCompile([]string{"--version", "2.0.0", "--dry-run"}, config, root); err != nil {
+		t.Fatalf("dry run error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "CHANGELOG.md")); !os.IsNotExist(err) {
+		t.Error("dry run must not write CHANGELOG.md")
+	}
+
+	if err := runCompile([]string{"--version", "2.0.0"}, config, root); err != nil {
+		t.Fatalf("runCompile() error = %v", err)
+	}
+	rootLog, _ := os.ReadFile(filepath.Join(root, "CHANGELOG.md"))
+	if !strings.Contains(string(rootLog), "## 2.0.0 (") || !strings.Contains(string(rootLog), "- update CI") {
+		t.Errorf("root CHANGELOG.md = %q", rootLog)
+	}
+	if strings.Contains(string(rootLog), "add search") {
+		t.Error("app fragments must compile into the app's CHANGELOG.md, not the root one")
+	}
+	webLog, _ := os.ReadFile(filepath.Join(webRoot, "CHANGELOG.md"))
+	if !strings.Contains(string(webLog), "- **web:** add search") {
+		t.Errorf("web CHANGELOG.md = %q", webLog)
+	}
+
+	for _, dir := range []string{root, webRoot} {
+		entries, _ := os.ReadDir(filepath.Join(dir, ".changelog"))
+		if len(entries) != 1 || entries[0].Name() != ".gitkeep" {
+			t.Errorf("%s/.changelog should only keep .gitkeep, has %v", dir, entries)
+		}
+	}
+}
+
+func TestRunCompileInvalidFragmentWritesNothing(t *testing.T) {
+	root := t.TempDir()
+	config := &PreCommitConfig{
+		Apps:      map[string]AppConfig{"web": {Path: "apps/web"}},
+		Changelog: ChangelogConfig{Mode: "per-app"},
+	}
+	writeFragments(t, root, map[string]string{"20250101-090000-good.txt": "feat: good\n"})
+	writeFragments(t, filepath.Join(root, "apps/web"), map[string]string{"20250101-090000-bad.txt": "not conventional\n"})
+
+	if err := runCompile(nil, config, root); err == nil {
+		t.Fatal("expected an error for a malformed fragment")
+	}
+	if _, err := os.Stat(filepath.Join(root, "CHANGELOG.md")); !os.IsNotExist(err) {
+		t.Error("no changelog may be written when any fragment is malformed")
+	}
+	if _, err := os.Stat(filepath.Join(root, ".changelog", "20250101-090000-good.txt")); err != nil {
+		t.Error("fragments must survive a failed compile")
+	}
+}
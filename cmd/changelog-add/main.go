@@ -3,29 +3,19 @@ package main
 import (
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
-	"regexp"
 	"sort"
 	"strings"
 	"time"
 
+	"github.com/milehighideas/claude-hooks/internal/changelog"
 	"github.com/milehighideas/claude-hooks/internal/jsonc"
 )
 
-var validTypes = map[string]bool{
-	"feat":     true,
-	"fix":      true,
-	"chore":    true,
-	"docs":     true,
-	"test":     true,
-	"style":    true,
-	"refactor": true,
-	"perf":     true,
-	"build":    true,
-	"ci":       true,
-	"revert":   true,
-}
+// validTypes are the commit types a changelog entry may use.
+var validTypes = changelog.ValidTypes
 
 // AppConfig represents an app configuration from .pre-commit.json
 type AppConfig struct {
@@ -42,6 +32,10 @@ type ChangelogConfig struct {
 	Mode string `json:"mode"`
 	// Apps lists which apps have changelog support (optional, defaults to all apps)
 	Apps []string `json:"apps"`
+	// StrictScope makes an unmatched scope an error in per-app mode instead
+	// of falling back to the root .changelog/. Unscoped entries still go to
+	// the root.
+	StrictScope bool `json:"strictScope"`
 }
 
 // PreCommitConfig represents the .pre-commit.json structure
@@ -50,31 +44,16 @@ type PreCommitConfig struct {
 	Changelog ChangelogConfig      `json:"changelog"`
 }
 
-// parseConventionalCommit parses a conventional commit message.
+// headerLine returns the first non-blank line of a changelog entry.
+func headerLine(entry string) string {
+	return changelog.HeaderLine(entry)
+}
+
+// parseConventionalCommit parses an entry with the shared internal/changelog
+// parser, so pre-commit validates staged fragments the same way.
 // Returns (type, scope, description, error)
 func parseConventionalCommit(entry string) (string, string, string, error) {
-	// Pattern: type(scope): description or type: description
-	pattern := regexp.MustCompile(`(?i)^([a-z]+)(?:\(([^)]+)\))?: (.+)$`)
-	match := pattern.FindStringSubmatch(strings.TrimSpace(entry))
-
-	if match == nil {
-		return "", "", "", fmt.Errorf("invalid format. Expected: 'type(scope): description' or 'type: description'")
-	}
-
-	commitType := strings.ToLower(match[1])
-	scope := match[2] // May be empty
-	description := strings.TrimSpace(match[3])
-
-	if !validTypes[commitType] {
-		types := make([]string, 0, len(validTypes))
-		for t := range validTypes {
-			types = append(types, t)
-		}
-		sort.Strings(types)
-		return "", "", "", fmt.Errorf("invalid type '%s'. Valid types: %s", commitType, strings.Join(types, ", "))
-	}
-
-	return commitType, scope, description, nil
+	return changelog.ParseConventionalCommit(entry)
 }
 
 // sanitizeFilename converts text to a safe filename slug.
@@ -196,78 +175,172 @@ func resolveApp(apps map[string]AppConfig, scope string, explicitApp string) (st
 	return "", AppConfig{}, nil
 }
 
-// createFragment creates a new changelog fragment file.
-func createFragment(entryText string, appName string, appPath string, projectRoot string) (string, error) {
-	commitType, scope, description, err := parseConventionalCommit(entryText)
+// resolvePerApp routes an entry in per-app mode. A scope that matches no app
+// falls back to the root (empty name) unless strictScope is set, in which
+// case it is an error. Unscoped entries always go to the root.
+func resolvePerApp(apps map[string]AppConfig, scope string, explicitApp string, strictScope bool) (string, AppConfig, error) {
+	name, app, err := resolveApp(apps, scope, explicitApp)
+	if err != nil || name != "" || scope == "" || !strictScope {
+		return name, app, err
+	}
+	return "", AppConfig{}, fmt.Errorf("scope '%s' doesn't match any configured app (strictScope is enabled)", scope)
+}
+
+// findDuplicateFragment returns the path of a fragment in changelogDir whose
+// trimmed content equals content, ignoring its timestamped filename.
+func findDuplicateFragment(changelogDir, content string) (string, bool) {
+	entries, err := os.ReadDir(changelogDir)
 	if err != nil {
-		return "", fmt.Errorf("%w\n\nExamples:\n  feat(native): add login functionality\n  fix(web): resolve navigation bug\n  chore(backend): update dependencies", err)
+		return "", false
+	}
+	want := strings.TrimSpace(strings.ReplaceAll(content, "\r\n", "\n"))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".txt") {
+			continue
+		}
+		path := filepath.Join(changelogDir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if strings.TrimSpace(strings.ReplaceAll(string(data), "\r\n", "\n")) == want {
+			return path, true
+		}
 	}
+	return "", false
+}
 
-	// Determine changelog directory
-	var changelogDir string
-	if appPath != "" {
-		changelogDir = filepath.Join(projectRoot, appPath, ".changelog")
-	} else {
-		changelogDir = filepath.Join(projectRoot, ".changelog")
+// createFragment creates a new changelog fragment file. When the target
+// .changelog/ already holds a fragment with the same entry, nothing is
+// written and that fragment's path is returned with existing set, unless
+// force is true.
+func createFragment(entryText string, appName string, appPath string, projectRoot string, force bool) (string, bool, error) {
+	commitType, scope, description, err := parseConventionalCommit(entryText)
+	if err != nil {
+		return "", false, fmt.Errorf("%w\n\nExamples:\n  feat(native): add login functionality\n  fix(web): resolve navigation bug\n  chore(backend): update dependencies", err)
 	}
 
+	changelogDir := fragmentDir(projectRoot, appPath)
+
 	// Create .changelog directory if it doesn't exist
 	if err := os.MkdirAll(changelogDir, 0755); err != nil {
-		return "", fmt.Errorf("failed to create .changelog directory: %w", err)
+		return "", false, fmt.Errorf("failed to create .changelog directory: %w", err)
 	}
 
 	// Create .gitkeep
 	gitkeepPath := filepath.Join(changelogDir, ".gitkeep")
 	if _, err := os.Stat(gitkeepPath); os.IsNotExist(err) {
 		if err := os.WriteFile(gitkeepPath, []byte{}, 0644); err != nil {
-			return "", fmt.Errorf("failed to create .gitkeep: %w", err)
+			return "", false, fmt.Errorf("failed to create .gitkeep: %w", err)
+		}
+	}
+
+	content := strings.TrimSpace(entryText) + "\n"
+	if !force {
+		if dup, ok := findDuplicateFragment(changelogDir, content); ok {
+			return relToRoot(projectRoot, dup), true, nil
 		}
 	}
 
 	// Generate timestamp-based filename
-	timestamp := time.Now().Format("20060102-150405")
+	timestamp := time.Now().Format(fragmentTimestampLayout)
+	// A forced duplicate within the same second would reuse the filename.
+	fragmentPath := freeFragmentPath(changelogDir, fragmentFilename(timestamp, commitType, scope, description))
+
+	if err := os.WriteFile(fragmentPath, []byte(content), 0644); err != nil {
+		return "", false, fmt.Errorf("failed to write fragment: %w", err)
+	}
 
+	return relToRoot(projectRoot, fragmentPath), false, nil
+}
+
+// fragmentTimestampLayout is the time layout that prefixes fragment names.
+const fragmentTimestampLayout = "20060102-150405"
+
+// fragmentDir returns the .changelog/ directory of the app at appPath, or
+// the root one when appPath is empty.
+func fragmentDir(projectRoot, appPath string) string {
+	if appPath != "" {
+		return filepath.Join(projectRoot, appPath, ".changelog")
+	}
+	return filepath.Join(projectRoot, ".changelog")
+}
+
+// fragmentFilename builds a fragment's name from its timestamp and the
+// parsed entry header.
+func fragmentFilename(timestamp, commitType, scope, description string) string {
 	descSlug := sanitizeFilename(description, 50)
 	if descSlug == "" {
 		descSlug = "entry"
 	}
-
-	var filename string
 	if scope != "" {
 		scopeSlug := sanitizeFilename(scope, 20)
-		filename = fmt.Sprintf("%s-%s-%s-%s.txt", timestamp, commitType, scopeSlug, descSlug)
-	} else {
-		filename = fmt.Sprintf("%s-%s-%s.txt", timestamp, commitType, descSlug)
+		return fmt.Sprintf("%s-%s-%s-%s.txt", timestamp, commitType, scopeSlug, descSlug)
 	}
+	return fmt.Sprintf("%s-%s-%s.txt", timestamp, commitType, descSlug)
+}
 
-	fragmentPath := filepath.Join(changelogDir, filename)
+// freeFragmentPath returns the path of filename in dir, numbered -2, -3, ...
+// when a file by that name already exists.
+func freeFragmentPath(dir, filename string) string {
+	fragmentPath := filepath.Join(dir, filename)
+	for n := 2; ; n++ {
+		if _, err := os.Stat(fragmentPath); os.IsNotExist(err) {
+			return fragmentPath
+		}
+		fragmentPath = filepath.Join(dir, fmt.Sprintf("%s-%d.txt", strings.TrimSuffix(filename, ".txt"), n))
+	}
+}
 
-	content := strings.TrimSpace(entryText) + "\n"
-	if err := os.WriteFile(fragmentPath, []byte(content), 0644); err != nil {
-		return "", fmt.Errorf("failed to write fragment: %w", err)
+// relToRoot returns p relative to projectRoot for display, or p itself.
+func relToRoot(projectRoot, p string) string {
+	if rel, err := filepath.Rel(projectRoot, p); err == nil {
+		return rel
 	}
+	return p
+}
 
-	if rel, err := filepath.Rel(projectRoot, fragmentPath); err == nil {
-		return rel, nil
+// readEntry returns the changelog entry: all of stdin when useStdin is set or
+// the positional argument is "-", otherwise the positional argument itself.
+func readEntry(args []string, useStdin bool, stdin io.Reader) (string, error) {
+	if !useStdin && (len(args) == 0 || args[0] != "-") {
+		if len(args) == 0 {
+			return "", nil
+		}
+		return strings.TrimSpace(args[0]), nil
 	}
-	return fragmentPath, nil
+	data, err := io.ReadAll(stdin)
+	if err != nil {
+		return "", fmt.Errorf("failed to read entry from stdin: %w", err)
+	}
+	return strings.TrimSpace(strings.ReplaceAll(string(data), "\r\n", "\n")), nil
 }
 
 func printUsage(apps map[string]AppConfig, mode string) {
 	fmt.Fprintln(os.Stderr, "Usage: changelog-add [--app <app>] 'type(scope): description'")
+	fmt.Fprintln(os.Stderr, "       changelog-add [--app <app>] --stdin < entry.txt")
+	fmt.Fprintln(os.Stderr, "       changelog-add [--app <app>] --amend 'type(scope): corrected description'")
+	fmt.Fprintln(os.Stderr, "       changelog-add compile [--version <version>] [--dry-run]")
 	fmt.Fprintln(os.Stderr, "")
 	fmt.Fprintln(os.Stderr, "Creates a changelog fragment in the appropriate .changelog/ directory.")
 	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprintln(os.Stderr, "The compile command groups all fragments by type, prepends them to CHANGELOG.md")
+	fmt.Fprintln(os.Stderr, "under a version heading, and deletes the consumed fragments.")
+	fmt.Fprintln(os.Stderr, "")
 	fmt.Fprintln(os.Stderr, "Changelog modes (configured in .pre-commit.json):")
 	fmt.Fprintln(os.Stderr, "  global    - All changelogs go to root .changelog/")
 	fmt.Fprintln(os.Stderr, "  per-app   - Changelogs routed by scope (e.g., feat(native): ... → apps/native/.changelog/)")
 	fmt.Fprintln(os.Stderr, "  required  - Scope must match an app, error otherwise")
+	fmt.Fprintln(os.Stderr, "Set \"strictScope\": true to make an unmatched scope an error in per-app mode.")
 	fmt.Fprintf(os.Stderr, "\nCurrent mode: %s\n", mode)
 	fmt.Fprintln(os.Stderr, "")
 	fmt.Fprintln(os.Stderr, "Options:")
 	fmt.Fprintln(os.Stderr, "  --app <name>  Explicitly specify the app (overrides scope detection)")
 	fmt.Fprintln(os.Stderr, "  --global      Create fragment in root .changelog/ (overrides config mode)")
 	fmt.Fprintln(os.Stderr, "  --list        List available apps")
+	fmt.Fprintln(os.Stderr, "  --stdin       Read a multi-line entry from stdin (same as passing '-')")
+	fmt.Fprintln(os.Stderr, "  --force       Create the fragment even if an identical entry already exists")
+	fmt.Fprintln(os.Stderr, "  --amend       Rewrite and rename the most recent fragment instead of creating one")
 	fmt.Fprintln(os.Stderr, "")
 	fmt.Fprintln(os.Stderr, "Valid types: feat, fix, chore, docs, test, style, refactor, perf, build, ci, revert")
 	fmt.Fprintln(os.Stderr, "")
@@ -276,6 +349,8 @@ func printUsage(apps map[string]AppConfig, mode string) {
 	fmt.Fprintln(os.Stderr, "  changelog-add 'fix(web): resolve navigation bug'")
 	fmt.Fprintln(os.Stderr, "  changelog-add --app backend 'chore: update dependencies'")
 	fmt.Fprintln(os.Stderr, "  changelog-add --global 'chore: update CI workflows'")
+	fmt.Fprintln(os.Stderr, "  changelog-add compile --version 1.4.0")
+	fmt.Fprintln(os.Stderr, "  printf 'feat(web): add search\\n\\n- fuzzy matching\\n' | changelog-add -")
 
 	if len(apps) > 0 {
 		fmt.Fprintln(os.Stderr, "")
@@ -295,6 +370,9 @@ func main() {
 	appFlag := flag.String("app", "", "Explicitly specify the app")
 	globalFlag := flag.Bool("global", false, "Create fragment in root .changelog/")
 	listFlag := flag.Bool("list", false, "List available apps")
+	stdinFlag := flag.Bool("stdin", false, "Read the entry from stdin")
+	forceFlag := flag.Bool("force", false, "Create the fragment even if an identical one exists")
+	amendFlag := flag.Bool("amend", false, "Replace the most recent fragment instead of creating one")
 	helpFlag := flag.Bool("help", false, "Show help")
 	flag.BoolVar(helpFlag, "h", false, "Show help")
 
@@ -318,6 +396,14 @@ func main() {
 		os.Exit(0)
 	}
 
+	if args := flag.Args(); len(args) > 0 && args[0] == "compile" {
+		if err := runCompile(args[1:], config, projectRoot); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	if *listFlag {
 		if len(apps) == 0 {
 			fmt.Println("No apps configured in .pre-commit.json")
@@ -337,14 +423,18 @@ func main() {
 	}
 
 	args := flag.Args()
-	if len(args) < 1 {
+	if len(args) < 1 && !*stdinFlag {
 		fmt.Fprintln(os.Stderr, "Error: No changelog entry provided")
 		fmt.Fprintln(os.Stderr, "")
 		printUsage(apps, mode)
 		os.Exit(1)
 	}
 
-	entryText := strings.TrimSpace(args[0])
+	entryText, err := readEntry(args, *stdinFlag, os.Stdin)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
 	if entryText == "" {
 		fmt.Fprintln(os.Stderr, "Error: Changelog entry cannot be empty")
 		os.Exit(1)
@@ -379,7 +469,7 @@ func main() {
 		case "per-app":
 			// Route by scope, fall back to root if no match
 			if apps != nil {
-				resolvedName, resolvedApp, err := resolveApp(apps, scope, *appFlag)
+				resolvedName, resolvedApp, err := resolvePerApp(apps, scope, *appFlag, config.Changelog.StrictScope)
 				if err != nil {
 					printAppError(err, apps)
 					os.Exit(1)
@@ -427,14 +517,36 @@ func main() {
 		}
 	}
 
-	fragmentPath, err := createFragment(entryText, appName, appPath, projectRoot)
+	if *amendFlag {
+		oldPath, newPath, err := amendFragment(entryText, appPath, projectRoot)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Amended changelog fragment: %s\n", newPath)
+		if oldPath != newPath {
+			fmt.Printf("   Renamed from: %s\n", oldPath)
+		}
+		fmt.Printf("   Entry: %s\n", headerLine(entryText))
+		if appName != "" {
+			fmt.Printf("   App: %s\n", appName)
+		}
+		os.Exit(0)
+	}
+
+	fragmentPath, existing, err := createFragment(entryText, appName, appPath, projectRoot, *forceFlag)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
+	if existing {
+		fmt.Printf("Changelog fragment already exists: %s\n", fragmentPath)
+		fmt.Println("   Skipped creating a duplicate (use --force to create it anyway)")
+		os.Exit(0)
+	}
 
 	fmt.Printf("Created changelog fragment: %s\n", fragmentPath)
-	fmt.Printf("   Entry: %s\n", entryText)
+	fmt.Printf("   Entry: %s\n", headerLine(entryText))
 	if appName != "" {
 		fmt.Printf("   App: %s\n", appName)
 	}
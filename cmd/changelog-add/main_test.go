@@ -175,6 +175,45 @@ func TestParseConventionalCommit(t *testing.T) {
 	}
 }
 
+func TestParseConventionalCommitMultiLine(t *testing.T) {
+	entry := "feat(web): add search\n\n- fuzzy matching\n- keyboard shortcut"
+	gotType, gotScope, gotDesc, err := parseConventionalCommit(entry)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotType != "feat" || gotScope != "web" || gotDesc != "add search" {
+		t.Errorf("got (%q, %q, %q), want (feat, web, add search)", gotType, gotScope, gotDesc)
+	}
+}
+
+func TestReadEntry(t *testing.T) {
+	const body = "feat(web): add search\r\n\r\n- fuzzy matching\r\n- keyboard shortcut\r\n"
+	const want = "feat(web): add search\n\n- fuzzy matching\n- keyboard shortcut"
+
+	tests := []struct {
+		name     string
+		args     []string
+		useStdin bool
+		want     string
+	}{
+		{name: "positional argument", args: []string{"  fix: bug  "}, want: "fix: bug"},
+		{name: "dash reads stdin", args: []string{"-"}, want: want},
+		{name: "stdin flag", useStdin: true, want: want},
+		{name: "no input", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readEntry(tt.args, tt.useStdin, strings.NewReader(body))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("readEntry() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestSanitizeFilename(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -597,11 +636,25 @@ func TestCreateFragment(t *testing.T) {
 			wantFilePrefix: "-fix-web-resolve-bug.txt",
 			wantContent:    "fix(web): resolve bug\n",
 		},
+		{
+			name:           "multi-line entry keeps the body",
+			entry:          "feat(web): add search\n\n- fuzzy matching\n- keyboard shortcut\n",
+			appName:        "web",
+			appPath:        "apps/web",
+			wantDirSuffix:  "apps/web/.changelog",
+			wantFilePrefix: "-feat-web-add-search.txt",
+			wantContent:    "feat(web): add search\n\n- fuzzy matching\n- keyboard shortcut\n",
+		},
 		{
 			name:    "invalid entry format",
 			entry:   "invalid entry",
 			wantErr: true,
 		},
+		{
+			name:    "invalid header with valid body line",
+			entry:   "added search\nfeat(web): add search",
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -615,7 +668,7 @@ func TestCreateFragment(t *testing.T) {
 				}
 			}
 
-			fragmentPath, err := createFragment(tt.entry, tt.appName, tt.appPath, tmpDir)
+			fragmentPath, _, err := createFragment(tt.entry, tt.appName, tt.appPath, tmpDir, false)
 
 			if tt.wantErr {
 				if err == nil {
@@ -661,6 +714,58 @@ func TestCreateFragment(t *testing.T) {
 	}
 }
 
+func TestCreateFragmentSkipsDuplicate(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	first, existing, err := createFragment("feat(web): add search", "web", "apps/web", tmpDir, false)
+	if err != nil || existing {
+		t.Fatalf("first createFragment() = (%q, %v, %v)", first, existing, err)
+	}
+
+	// Same entry with different surrounding whitespace is still a duplicate.
+	again, existing, err := createFragment("  feat(web): add search\n", "web", "apps/web", tmpDir, false)
+	if err != nil {
+		t.Fatalf("duplicate createFragment() error = %v", err)
+	}
+	if !existing || again != first {
+		t.Errorf("duplicate = (%q, %v), want (%q, true)", again, existing, first)
+	}
+
+	// A different entry, or the same entry for another app, is not a duplicate.
+	if _, existing, _ := createFragment("feat(web): add filters", "web", "apps/web", tmpDir, false); existing {
+		t.Error("different entry reported as a duplicate")
+	}
+	if _, existing, _ := createFragment("feat(web): add search", "", "", tmpDir, false); existing {
+		t.Error("entry in another .changelog/ reported as a duplicate")
+	}
+
+	entries, _ := os.ReadDir(filepath.Join(tmpDir, "apps/web/.changelog"))
+	if len(entries) != 3 { // .gitkeep + two distinct fragments
+		t.Errorf("apps/web/.changelog has %d entries, want 3", len(entries))
+	}
+}
+
+func TestCreateFragmentForceBypassesDedup(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	first, _, err := createFragment("fix: resolve bug", "", "", tmpDir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	forced, existing, err := createFragment("fix: resolve bug", "", "", tmpDir, true)
+	if err != nil {
+		t.Fatalf("forced createFragment() error = %v", err)
+	}
+	if existing || forced == first {
+		t.Errorf("forced = (%q, %v), want a new fragment distinct from %q", forced, existing, first)
+	}
+
+	entries, _ := os.ReadDir(filepath.Join(tmpDir, ".changelog"))
+	if len(entries) != 3 { // .gitkeep + both fragments
+		t.Errorf(".changelog has %d entries, want 3", len(entries))
+	}
+}
+
 func TestFindProjectRoot(t *testing.T) {
 	// Create temp directory structure
 	tmpDir := t.TempDir()
@@ -760,6 +865,7 @@ func TestChangelogModes(t *testing.T) {
 		scope       string
 		explicitApp string
 		globalFlag  bool
+		strictScope bool
 		wantAppName string
 		wantAppPath string
 		wantErr     bool
@@ -802,6 +908,29 @@ func TestChangelogModes(t *testing.T) {
 			wantAppName: "",
 			wantAppPath: "",
 		},
+		{
+			name:        "per-app strictScope - matching scope",
+			mode:        "per-app",
+			scope:       "native",
+			strictScope: true,
+			wantAppName: "native",
+			wantAppPath: "apps/native",
+		},
+		{
+			name:        "per-app strictScope - unknown scope errors",
+			mode:        "per-app",
+			scope:       "moblie",
+			strictScope: true,
+			wantErr:     true,
+		},
+		{
+			name:        "per-app strictScope - no scope falls back to root",
+			mode:        "per-app",
+			scope:       "",
+			strictScope: true,
+			wantAppName: "",
+			wantAppPath: "",
+		},
 		{
 			name:        "per-app mode - explicit app",
 			mode:        "per-app",
@@ -877,7 +1006,7 @@ func TestChangelogModes(t *testing.T) {
 					appPath = ""
 
 				case "per-app":
-					resolvedName, resolvedApp, resolveErr := resolveApp(apps, tt.scope, tt.explicitApp)
+					resolvedName, resolvedApp, resolveErr := resolvePerApp(apps, tt.scope, tt.explicitApp, tt.strictScope)
 					if resolveErr != nil {
 						err = resolveErr
 					} else {
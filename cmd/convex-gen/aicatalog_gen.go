@@ -85,11 +85,11 @@ func (g *AICatalogGenerator) Generate(functions []ConvexFunction) error {
 	sort.Slice(entries, func(i, j int) bool { return entries[i].fnPath < entries[j].fnPath })
 
 	content := g.render(entries)
-	if err := os.WriteFile(filepath.Join(g.outputDir, "catalog.ts"), []byte(content), 0644); err != nil {
+	if err := writeIfChanged(filepath.Join(g.outputDir, "catalog.ts"), []byte(content)); err != nil {
 		return fmt.Errorf("failed to write catalog.ts: %w", err)
 	}
-	return os.WriteFile(filepath.Join(g.outputDir, "index.ts"),
-		[]byte("/** Auto-generated. DO NOT EDIT. Run 'convex-gen'. */\nexport * from './catalog';\n"), 0644)
+	return writeIfChanged(filepath.Join(g.outputDir, "index.ts"),
+		[]byte("/** Auto-generated. DO NOT EDIT. Run 'convex-gen'. */\nexport * from './catalog';\n"))
 }
 
 func (g *AICatalogGenerator) render(entries []catalogEntry) string {
@@ -29,8 +29,9 @@ func (g *APIGenerator) Generate(functions []ConvexFunction) error {
 		return fmt.Errorf("failed to create directory %s: %w", g.outputDir, err)
 	}
 
-	// Clean existing files
-	if err := cleanDirectory(g.outputDir); err != nil {
+	// Clean existing files (incremental mode removes only stale ones at the end)
+	cleaner, err := newOutputCleaner(g.config.DataLayer.Incremental, g.outputDir)
+	if err != nil {
 		return err
 	}
 
@@ -51,7 +52,7 @@ func (g *APIGenerator) Generate(functions []ConvexFunction) error {
 
 			content := g.generateGroupedAPIFileContent(topNamespace, funcs)
 
-			if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+			if err := writeIfChanged(filePath, []byte(content)); err != nil {
 				return fmt.Errorf("failed to write %s: %w", filePath, err)
 			}
 
@@ -70,7 +71,7 @@ func (g *APIGenerator) Generate(functions []ConvexFunction) error {
 
 			content := g.generateAPIFileContent(namespace, funcs)
 
-			if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+			if err := writeIfChanged(filePath, []byte(content)); err != nil {
 				return fmt.Errorf("failed to write %s: %w", filePath, err)
 			}
 
@@ -91,7 +92,7 @@ func (g *APIGenerator) Generate(functions []ConvexFunction) error {
 
 				content := g.generateGroupedAPIFileContent(topNamespace, funcs)
 
-				if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+				if err := writeIfChanged(filePath, []byte(content)); err != nil {
 					return fmt.Errorf("failed to write %s: %w", filePath, err)
 				}
 
@@ -105,16 +106,22 @@ func (g *APIGenerator) Generate(functions []ConvexFunction) error {
 	files = uniqueStrings(files)
 
 	if g.config.DataLayer.ExportAPI {
-		return g.generateAPIIndexFile(files)
+		if err := g.generateAPIIndexFile(files); err != nil {
+			return err
+		}
+	} else if err := generateIndexFile(g.outputDir, files); err != nil {
+		return err
 	}
-	return generateIndexFile(g.outputDir, files)
+
+	// Record what was written so the next run cleans up only these files
+	return cleaner.finish(g.outputDir, files)
 }
 
 // generateAPIIndexFile creates index.ts with an api re-export at the top
 func (g *APIGenerator) generateAPIIndexFile(files []string) error {
 	if len(files) == 0 {
 		content := "// No files generated\nexport {};\n"
-		return os.WriteFile(filepath.Join(g.outputDir, "index.ts"), []byte(content), 0644)
+		return writeIfChanged(filepath.Join(g.outputDir, "index.ts"), []byte(content))
 	}
 
 	var sb strings.Builder
@@ -127,7 +134,7 @@ func (g *APIGenerator) generateAPIIndexFile(files []string) error {
 		fmt.Fprintf(&sb, "export * from './%s';\n", file)
 	}
 
-	return os.WriteFile(filepath.Join(g.outputDir, "index.ts"), []byte(sb.String()), 0644)
+	return writeIfChanged(filepath.Join(g.outputDir, "index.ts"), []byte(sb.String()))
 }
 
 // getUniqueExportName returns a unique export name for a function, prefixing with sub-namespace if needed
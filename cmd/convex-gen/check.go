@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// staleFile is one difference between fresh generation and the committed
+// output: a file whose content changed, one generation would add, or a
+// previously generated file it would remove.
+type staleFile struct {
+	path   string
+	reason string // "changed", "missing" or "removed"
+}
+
+// checkGenerated runs the full pipeline for config into a temporary output
+// root inside tempParent and compares the result with the committed output,
+// which is never written to. tempParent should be inside the project so
+// Prettier resolves the project's config for the files it formats.
+func checkGenerated(config *Config, tempParent string) ([]staleFile, error) {
+	root, err := os.MkdirTemp(tempParent, ".convex-gen-check-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp output root: %w", err)
+	}
+	defer os.RemoveAll(root)
+
+	checkConfig := *config
+	checkConfig.outputRoot = root
+	checkConfig.Cache.Validators = "" // leave the on-disk cache alone too
+	if _, err := generate(&checkConfig, io.Discard); err != nil {
+		return nil, err
+	}
+
+	// Generated files land under outputPath of these committed locations;
+	// the Convex path holds the Terraform emitter's files.
+	var targets []string
+	for _, dir := range []string{
+		config.GetHooksOutputDir(),
+		config.GetAPIOutputDir(),
+		config.GetTypesOutputDir(),
+		config.GetMetadataOutputDir(),
+		config.GetAICatalogOutputDir(),
+		config.OpenAPI.OutputDir,
+		config.Convex.Path,
+	} {
+		targets = append(targets, filepath.Clean(dir))
+	}
+	// Longest first, so a file maps back through its innermost target
+	sort.Slice(targets, func(i, j int) bool { return len(targets[i]) > len(targets[j]) })
+
+	seen := make(map[string]bool)
+	var stale []staleFile
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		committed := committedPath(&checkConfig, targets, path)
+		if committed == "" || seen[committed] {
+			return nil
+		}
+		seen[committed] = true
+
+		fresh, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		existing, err := os.ReadFile(committed)
+		switch {
+		case os.IsNotExist(err):
+			stale = append(stale, staleFile{committed, "missing"})
+		case err != nil:
+			return err
+		case !bytes.Equal(fresh, existing):
+			stale = append(stale, staleFile{committed, "changed"})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Files a previous run generated that this one doesn't would be removed
+	if config.Generators.Hooks {
+		hooksDir := config.GetHooksOutputDir()
+		for _, dir := range []string{hooksDir, filepath.Join(hooksDir, "queries"), filepath.Join(hooksDir, "mutations"), filepath.Join(hooksDir, "actions")} {
+			stale = append(stale, removedFiles(&checkConfig, dir, seen)...)
+		}
+	}
+	if config.Generators.API {
+		stale = append(stale, removedFiles(&checkConfig, config.GetAPIOutputDir(), seen)...)
+	}
+
+	sort.Slice(stale, func(i, j int) bool { return stale[i].path < stale[j].path })
+	return stale, nil
+}
+
+// committedPath maps a file generated under checkConfig's output root back
+// to its committed location, or "" if it belongs to no target.
+func committedPath(checkConfig *Config, targets []string, generated string) string {
+	for _, target := range targets {
+		relocated := checkConfig.outputPath(target)
+		if rel, err := filepath.Rel(relocated, generated); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return filepath.Join(target, rel)
+		}
+	}
+	return ""
+}
+
+// removedFiles returns the files a previous run generated in dir that the
+// check run didn't, which regeneration would delete.
+func removedFiles(checkConfig *Config, dir string, generated map[string]bool) []staleFile {
+	previous, err := previousGeneratedFiles(dir)
+	if err != nil {
+		return nil
+	}
+	var stale []staleFile
+	for _, name := range previous {
+		path := filepath.Join(filepath.Clean(dir), name)
+		if generated[path] {
+			continue
+		}
+		if _, err := os.Stat(path); err == nil {
+			stale = append(stale, staleFile{path, "removed"})
+		}
+	}
+	return stale
+}
+
+// runCheck implements --check: it fails, listing the stale files, when the
+// committed generated output doesn't match what convex-gen would produce.
+func runCheck(cliTypedReturns bool) error {
+	config, err := loadRunConfig(cliTypedReturns)
+	if err != nil {
+		return err
+	}
+	stale, err := checkGenerated(config, ".")
+	if err != nil {
+		return err
+	}
+	if len(stale) == 0 {
+		fmt.Println("convex-gen: generated output is up to date")
+		return nil
+	}
+	fmt.Printf("convex-gen: %d generated file(s) are stale:\n", len(stale))
+	for _, f := range stale {
+		fmt.Printf("  %s (%s)\n", f.path, f.reason)
+	}
+	return fmt.Errorf("generated output is stale; run convex-gen and commit the result")
+}
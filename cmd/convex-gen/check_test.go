@@ -0,0 +1,107 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// checkFixtureConfig materializes a small project, generates its committed
+// output, and returns its config.
+func checkFixtureConfig(t *testing.T) *Config {
+	t.Helper()
+	f := fixture{
+		name:          "checkapp",
+		convexPath:    "packages/backend/convex",
+		dataLayerPath: "packages/data-layer/src",
+		fileStructure: "grouped",
+		functionFiles: map[string]string{
+			"issues.ts": `import { query, mutation } from './_generated/server';
+import { v } from 'convex/values';
+
+export const getIssue = query({
+  args: { id: v.id('issues') },
+  handler: async (ctx, args) => {
+    return await ctx.db.get(args.id);
+  },
+});
+`,
+			"users.ts": `import { query } from './_generated/server';
+import { v } from 'convex/values';
+
+export const getUser = query({
+  args: { id: v.id('users') },
+  handler: async (ctx, args) => {
+    return await ctx.db.get(args.id);
+  },
+});
+`,
+		},
+	}
+	cfg := f.build(t, t.TempDir())
+	cfg.Generators.Types = false
+	if _, err := generate(cfg, io.Discard); err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	return cfg
+}
+
+func TestCheckGenerated_UpToDate(t *testing.T) {
+	cfg := checkFixtureConfig(t)
+
+	stale, err := checkGenerated(cfg, t.TempDir())
+	if err != nil {
+		t.Fatalf("checkGenerated: %v", err)
+	}
+	if len(stale) != 0 {
+		t.Errorf("freshly generated output reported stale: %v", stale)
+	}
+}
+
+func TestCheckGenerated_ReportsStaleFiles(t *testing.T) {
+	cfg := checkFixtureConfig(t)
+	hooksDir := filepath.Join(cfg.GetHooksOutputDir(), "queries")
+	changed := filepath.Join(hooksDir, "useIssues.ts")
+	missing := filepath.Join(cfg.GetAPIOutputDir(), "issues.ts")
+	removed := filepath.Join(hooksDir, "useUsers.ts")
+
+	writeTestFile(t, changed, "// DO NOT EDIT - hand-edited\n")
+	if err := os.Remove(missing); err != nil {
+		t.Fatal(err)
+	}
+	// Dropping the users functions leaves their committed hooks behind
+	if err := os.Remove(filepath.Join(cfg.Convex.Path, "users.ts")); err != nil {
+		t.Fatal(err)
+	}
+	before, err := os.ReadFile(changed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stale, err := checkGenerated(cfg, t.TempDir())
+	if err != nil {
+		t.Fatalf("checkGenerated: %v", err)
+	}
+	got := make(map[string]string)
+	for _, f := range stale {
+		got[f.path] = f.reason
+	}
+	want := map[string]string{changed: "changed", missing: "missing", removed: "removed"}
+	for path, reason := range want {
+		if got[path] != reason {
+			t.Errorf("%s reported as %q, want %q (all: %v)", path, got[path], reason, stale)
+		}
+	}
+
+	// The committed tree is left exactly as it was
+	if after, _ := os.ReadFile(changed); string(after) != string(before) {
+		t.Errorf("check rewrote %s", changed)
+	}
+	if fileExists(missing) {
+		t.Errorf("check recreated %s", missing)
+	}
+	if !fileExists(removed) {
+		t.Errorf("check removed %s", removed)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 // Config represents the .convex-gen.json configuration
@@ -18,6 +19,18 @@ type Config struct {
 	AI         AIConfig         `json:"ai"`         // AI tool catalog generator policy (opt-in)
 	OpenAPI    OpenAPIConfig    `json:"openapi"`    // OpenAPI spec generator policy (opt-in)
 	Terraform  TerraformConfig  `json:"terraform"`  // Terraform/public-API emitter policy (opt-in)
+	Cache      CacheConfig      `json:"cache"`      // On-disk caches reused across runs (opt-in)
+
+	// outputRoot, when set, relocates every generated file under it: --check
+	// generates into a temporary root to compare with the committed output.
+	outputRoot string
+}
+
+// CacheConfig controls caches persisted between runs (opt-in). The validator
+// cache is keyed by a hash of every validator file, so it is rebuilt whenever
+// any of them changes.
+type CacheConfig struct {
+	Validators string `json:"validators"` // file to persist the validator cache in, e.g. "node_modules/.cache/convex-gen/validators.json"; empty disables
 }
 
 // TerraformConfig controls the Terraform/public-API emitter (opt-in). It points
@@ -65,10 +78,21 @@ type DataLayerConfig struct {
 	TypesDir      string `json:"typesDir"`      // e.g., "generated-types"
 	MetadataDir   string `json:"metadataDir"`   // e.g., "generated-schema"
 	FileStructure string `json:"fileStructure"` // "grouped", "split", or "both"
-	HookNaming    string `json:"hookNaming"`    // "flat" (no sub-namespace), "qualified" (always sub-namespace), or "auto" (sub-namespace only on collision)
+	HookNaming    string `json:"hookNaming"`    // "flat" (no sub-namespace), "qualified" (always sub-namespace), "auto" (sub-namespace only on collision), or a template such as "use{Func}"
 	ExportAPI     bool   `json:"exportApi"`     // Re-export { api } from the generated-api index
 	TypedReturns  bool   `json:"typedReturns"`  // When true, emit typed `FunctionReturnType<typeof api.x.y> | undefined` on shouldSkip query hooks instead of `as any`
 	TypedArgs     bool   `json:"typedArgs"`     // When true, emit typed `ReactMutation<typeof api.x.y>` / `ReactAction<...>` annotations on mutation/action hooks so caller args are type-checked. Defaults to false (untyped) for backwards compatibility.
+	HookStyle     string `json:"hookStyle"`     // "convex-react" (default): useQuery/useMutation/useAction from convex/react; "tanstack": TanStack Query wrappers via @convex-dev/react-query
+	// HookCollisions decides what the root hooks index.ts does when a query,
+	// mutation or action hook share a generated name: "suffix" (default)
+	// re-exports them as <name>Query / <name>Mutation / <name>Action, "error"
+	// fails generation and lists the colliding names.
+	HookCollisions string `json:"hookCollisions"`
+	// Incremental keeps the previous run's hooks and API files in place and
+	// rewrites only those whose content changed, removing the ones no longer
+	// generated afterwards. Unchanged files keep their mtimes, so a one-function
+	// change shows up as a one-file diff. Defaults to false (clean and rewrite).
+	Incremental bool `json:"incremental"`
 
 	// RequireAuthGatedShouldSkip: when true, a query hook whose backend handler
 	// calls one of AuthHelperNames gets a REQUIRED `shouldSkip: boolean` param
@@ -90,6 +114,18 @@ type DataLayerConfig struct {
 	AuthHelperNames []string `json:"authHelperNames"`
 }
 
+// Hook styles for DataLayerConfig.HookStyle
+const (
+	HookStyleConvexReact = "convex-react" // useQuery/useMutation/useAction from convex/react
+	HookStyleTanStack    = "tanstack"     // TanStack Query via @convex-dev/react-query
+)
+
+// Cross-category collision handling for DataLayerConfig.HookCollisions
+const (
+	HookCollisionsSuffix = "suffix" // re-export colliding hooks with a category suffix
+	HookCollisionsError  = "error"  // fail generation on any collision
+)
+
 // ImportsConfig configures how generated code imports dependencies
 type ImportsConfig struct {
 	Style     string `json:"style"`     // "package" (recommended) or "relative"
@@ -112,6 +148,7 @@ type GeneratorsConfig struct {
 type SkipConfig struct {
 	Directories []string `json:"directories"` // Directory names to skip
 	Patterns    []string `json:"patterns"`    // Regex patterns for files to skip
+	Functions   []string `json:"functions"`   // "namespace/functionName" entries or regexes for functions to skip
 }
 
 // LoadConfig loads configuration from .convex-gen.json
@@ -196,6 +233,12 @@ func applyConfigDefaults(config *Config) {
 	if config.DataLayer.HookNaming == "" {
 		config.DataLayer.HookNaming = "flat" // default to flat for backward compatibility
 	}
+	if config.DataLayer.HookStyle == "" {
+		config.DataLayer.HookStyle = HookStyleConvexReact
+	}
+	if config.DataLayer.HookCollisions == "" {
+		config.DataLayer.HookCollisions = HookCollisionsSuffix
+	}
 	if len(config.DataLayer.AuthHelperNames) == 0 {
 		config.DataLayer.AuthHelperNames = []string{"getAuthenticatedUser", "getAuthenticatedUserForActions"}
 	}
@@ -294,37 +337,64 @@ func validateConfig(config *Config) error {
 		return fmt.Errorf("convex.structure must be 'nested' or 'flat', got: %s", config.Convex.Structure)
 	}
 
+	if config.DataLayer.HookStyle != HookStyleConvexReact && config.DataLayer.HookStyle != HookStyleTanStack {
+		return fmt.Errorf("dataLayer.hookStyle must be '%s' or '%s', got: %s", HookStyleConvexReact, HookStyleTanStack, config.DataLayer.HookStyle)
+	}
+
+	if config.DataLayer.HookCollisions != HookCollisionsSuffix && config.DataLayer.HookCollisions != HookCollisionsError {
+		return fmt.Errorf("dataLayer.hookCollisions must be '%s' or '%s', got: %s", HookCollisionsSuffix, HookCollisionsError, config.DataLayer.HookCollisions)
+	}
+
+	if isHookNameTemplate(config.DataLayer.HookNaming) {
+		if err := validateHookNameTemplate(config.DataLayer.HookNaming); err != nil {
+			return err
+		}
+	}
+
+	if _, err := compileFunctionSkips(config.Skip.Functions); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 // GetHooksOutputDir returns the full path for generated hooks
 func (c *Config) GetHooksOutputDir() string {
-	return filepath.Join(c.DataLayer.Path, c.DataLayer.HooksDir)
+	return c.outputPath(filepath.Join(c.DataLayer.Path, c.DataLayer.HooksDir))
 }
 
 // GetAPIOutputDir returns the full path for generated API wrappers
 func (c *Config) GetAPIOutputDir() string {
-	return filepath.Join(c.DataLayer.Path, c.DataLayer.APIDir)
+	return c.outputPath(filepath.Join(c.DataLayer.Path, c.DataLayer.APIDir))
 }
 
 // GetTypesOutputDir returns the full path for generated types
 func (c *Config) GetTypesOutputDir() string {
-	return filepath.Join(c.DataLayer.Path, c.DataLayer.TypesDir)
+	return c.outputPath(filepath.Join(c.DataLayer.Path, c.DataLayer.TypesDir))
 }
 
 // GetMetadataOutputDir returns the full path for generated schema metadata
 func (c *Config) GetMetadataOutputDir() string {
-	return filepath.Join(c.DataLayer.Path, c.DataLayer.MetadataDir)
+	return c.outputPath(filepath.Join(c.DataLayer.Path, c.DataLayer.MetadataDir))
 }
 
 // GetAICatalogOutputDir returns the full path for the generated AI tool catalog.
 func (c *Config) GetAICatalogOutputDir() string {
-	return filepath.Join(c.DataLayer.Path, c.AI.OutputDir)
+	return c.outputPath(filepath.Join(c.DataLayer.Path, c.AI.OutputDir))
 }
 
 // GetOpenAPISpecPath returns the full path for the generated OpenAPI spec file.
 func (c *Config) GetOpenAPISpecPath() string {
-	return filepath.Join(c.OpenAPI.OutputDir, c.OpenAPI.FileName)
+	return c.outputPath(filepath.Join(c.OpenAPI.OutputDir, c.OpenAPI.FileName))
+}
+
+// outputPath returns where a file or directory generated at path is written:
+// path itself, or its place under outputRoot when one is set.
+func (c *Config) outputPath(path string) string {
+	if c.outputRoot == "" {
+		return path
+	}
+	return filepath.Join(c.outputRoot, strings.TrimPrefix(path, filepath.VolumeName(path)))
 }
 
 // GetTerraformConfigPath returns the path to the Terraform curation overlay.
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// debugEnabled turns on debugf output. Set by --debug, or CONVEX_GEN_DEBUG=1
+// for runs where the flag can't be passed (e.g. a package.json script).
+var debugEnabled = os.Getenv("CONVEX_GEN_DEBUG") == "1"
+
+// debugOut is where debugf writes; stderr keeps stdout clean for the normal
+// progress output.
+var debugOut io.Writer = os.Stderr
+
+// debugf logs a parse decision when debugging is enabled. It never affects
+// generated output.
+func debugf(format string, args ...any) {
+	if !debugEnabled {
+		return
+	}
+	fmt.Fprintf(debugOut, "[debug] "+format+"\n", args...)
+}
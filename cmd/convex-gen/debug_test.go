@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// withDebug enables debugf for the duration of a test and returns its output.
+func withDebug(t *testing.T, enabled bool) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	prevEnabled, prevOut := debugEnabled, debugOut
+	debugEnabled, debugOut = enabled, &buf
+	t.Cleanup(func() { debugEnabled, debugOut = prevEnabled, prevOut })
+	return &buf
+}
+
+func TestDebugfLogsValidatorResolution(t *testing.T) {
+	buf := withDebug(t, true)
+
+	p := NewParser(&Config{})
+	p.validatorCache["Projects.getProjectValidator"] = `v.object({ projectId: v.id("projects") })`
+	p.parseArgs("{ args: Projects.getProjectValidator,\n handler: async () => {} }")
+	p.parseArgs("{ args: Missing.someValidator,\n handler: async () => {} }")
+
+	out := buf.String()
+	for _, want := range []string{
+		"[debug] args reference Projects.getProjectValidator resolved from validator cache",
+		"[debug] args reference Missing.someValidator not found in validator cache",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("debug output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestDebugfSilentByDefault(t *testing.T) {
+	buf := withDebug(t, false)
+
+	p := NewParser(&Config{})
+	p.parseArgs("{ args: Missing.someValidator,\n handler: async () => {} }")
+	debugf("should not appear")
+
+	if buf.Len() != 0 {
+		t.Errorf("debug output with debugging off = %q, want none", buf.String())
+	}
+}
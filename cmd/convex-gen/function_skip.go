@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// functionSkipper decides which parsed functions skip.functions excludes.
+// Each entry matches a "namespace/functionName" key either exactly or as a
+// regex anchored to the whole key.
+type functionSkipper struct {
+	exact    map[string]bool
+	patterns []*regexp.Regexp
+}
+
+// compileFunctionSkips builds a functionSkipper from skip.functions.
+func compileFunctionSkips(entries []string) (*functionSkipper, error) {
+	s := &functionSkipper{exact: make(map[string]bool)}
+	for _, entry := range entries {
+		if entry == "" {
+			continue
+		}
+		s.exact[entry] = true
+		re, err := regexp.Compile("^(?:" + entry + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("skip.functions: invalid pattern %q: %w", entry, err)
+		}
+		s.patterns = append(s.patterns, re)
+	}
+	return s, nil
+}
+
+// matches reports whether the function name in namespace is excluded.
+func (s *functionSkipper) matches(namespace, name string) bool {
+	if s == nil {
+		return false
+	}
+	key := namespace + "/" + name
+	if s.exact[key] {
+		return true
+	}
+	for _, re := range s.patterns {
+		if re.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// skipFunction reports whether skip.functions excludes the function, and
+// counts it towards SkippedFunctions when it does.
+func (p *Parser) skipFunction(file ConvexFile, name string) bool {
+	if !p.functionSkips.matches(file.Namespace, name) {
+		return false
+	}
+	debugf("%s: skipping %s/%s (skip.functions)", file.Path, file.Namespace, name)
+	p.skippedFunctions++
+	return true
+}
+
+// SkippedFunctions returns how many functions skip.functions has excluded so
+// far.
+func (p *Parser) SkippedFunctions() int {
+	return p.skippedFunctions
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func parsedNames(t *testing.T, p *Parser, file ConvexFile) []string {
+	t.Helper()
+	functions, err := p.ParseConvexFile(file)
+	if err != nil {
+		t.Fatalf("ParseConvexFile: %v", err)
+	}
+	var names []string
+	for _, fn := range functions {
+		names = append(names, fn.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestParseConvexFile_SkipFunctions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "eventQueries.ts")
+	content := `import { query } from "./_generated/server";
+
+export const getEvent = query({ args: {}, handler: async () => null });
+export const listEvents = query({ args: {}, handler: async () => [] });
+export const legacyListEvents = query({ args: {}, handler: async () => [] });
+export const legacyGetEvent = query({ args: {}, handler: async () => null });
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	file := ConvexFile{Path: path, Namespace: "events/eventQueries", FileName: "eventQueries"}
+
+	tests := []struct {
+		name  string
+		skip  []string
+		want  []string
+		count int
+	}{
+		{
+			name:  "exact name",
+			skip:  []string{"events/eventQueries/getEvent"},
+			want:  []string{"legacyGetEvent", "legacyListEvents", "listEvents"},
+			count: 1,
+		},
+		{
+			name:  "regex",
+			skip:  []string{`events/.*/legacy\w+`},
+			want:  []string{"getEvent", "listEvents"},
+			count: 2,
+		},
+		{
+			name:  "regex is anchored to the whole key",
+			skip:  []string{"legacy.*"},
+			want:  []string{"getEvent", "legacyGetEvent", "legacyListEvents", "listEvents"},
+			count: 0,
+		},
+		{
+			name:  "other namespace",
+			skip:  []string{"users/userQueries/getEvent"},
+			want:  []string{"getEvent", "legacyGetEvent", "legacyListEvents", "listEvents"},
+			count: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewParser(&Config{Skip: SkipConfig{Functions: tt.skip}})
+			got := parsedNames(t, p, file)
+			if len(got) != len(tt.want) {
+				t.Fatalf("parsed %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("parsed %v, want %v", got, tt.want)
+					break
+				}
+			}
+			if p.SkippedFunctions() != tt.count {
+				t.Errorf("SkippedFunctions() = %d, want %d", p.SkippedFunctions(), tt.count)
+			}
+		})
+	}
+}
+
+func TestParseFluentConvexFile_SkipFunctions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "admin.ts")
+	content := `export const listUsers = adminQuery.input({}).handler(async () => []).public();
+export const deprecatedPurge = adminMutation.input({}).handler(async () => null).public();
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	file := ConvexFile{Path: path, Namespace: "admin", FileName: "admin"}
+
+	cfg := &Config{
+		Convex: ConvexConfig{FluentConvex: true},
+		Skip:   SkipConfig{Functions: []string{`admin/deprecated.*`}},
+	}
+	p := NewParser(cfg)
+	got := parsedNames(t, p, file)
+	if len(got) != 1 || got[0] != "listUsers" {
+		t.Errorf("parsed %v, want [listUsers]", got)
+	}
+	if p.SkippedFunctions() != 1 {
+		t.Errorf("SkippedFunctions() = %d, want 1", p.SkippedFunctions())
+	}
+}
+
+func TestCompileFunctionSkips_InvalidPattern(t *testing.T) {
+	if _, err := compileFunctionSkips([]string{"events/("}); err == nil {
+		t.Error("want an error for an invalid skip.functions pattern")
+	}
+}
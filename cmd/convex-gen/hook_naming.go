@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Placeholders a dataLayer.hookNaming template can use. {SubNamespace} is
+// empty for functions that live directly in the top-level namespace.
+const (
+	hookNameNamespace    = "{Namespace}"
+	hookNameSubNamespace = "{SubNamespace}"
+	hookNameFunc         = "{Func}"
+)
+
+// hookNameLiteral matches what a template may contain besides placeholders.
+var hookNameLiteral = regexp.MustCompile(`^[A-Za-z0-9_$]*$`)
+
+// isHookNameTemplate reports whether hookNaming is a template such as
+// "use{Func}" rather than one of the flat/qualified/auto modes.
+func isHookNameTemplate(hookNaming string) bool {
+	return strings.Contains(hookNaming, "{")
+}
+
+// validateHookNameTemplate rejects templates that don't start with "use",
+// lack {Func}, use an unknown placeholder, or can't form an identifier.
+func validateHookNameTemplate(tmpl string) error {
+	if !strings.HasPrefix(tmpl, "use") {
+		return fmt.Errorf("dataLayer.hookNaming template must start with 'use', got: %s", tmpl)
+	}
+	if !strings.Contains(tmpl, hookNameFunc) {
+		return fmt.Errorf("dataLayer.hookNaming template must contain %s, got: %s", hookNameFunc, tmpl)
+	}
+	rest := strings.NewReplacer(hookNameNamespace, "", hookNameSubNamespace, "", hookNameFunc, "").Replace(tmpl)
+	if strings.ContainsAny(rest, "{}") {
+		return fmt.Errorf("dataLayer.hookNaming template has an unknown placeholder (want %s, %s or %s), got: %s",
+			hookNameNamespace, hookNameSubNamespace, hookNameFunc, tmpl)
+	}
+	if !hookNameLiteral.MatchString(rest) {
+		return fmt.Errorf("dataLayer.hookNaming template must produce a valid identifier, got: %s", tmpl)
+	}
+	return nil
+}
+
+// hookSubNamespace returns the camel-cased sub-namespace used in hook names,
+// or "" when fn sits directly in topNamespace.
+func hookSubNamespace(topNamespace string, fn ConvexFunction) string {
+	subNs := getSubNamespace(fn.Namespace)
+	if subNs == "" || subNs == topNamespace {
+		return ""
+	}
+	return capitalize(toCamelCase(subNs))
+}
+
+// qualifiedHookName is the always-unique name: use + namespace +
+// sub-namespace + function.
+func qualifiedHookName(topNamespace string, fn ConvexFunction) string {
+	return "use" + capitalize(topNamespace) + hookSubNamespace(topNamespace, fn) + capitalize(fn.Name)
+}
+
+// hookNameFromTemplate fills tmpl's placeholders for fn.
+func hookNameFromTemplate(tmpl, topNamespace string, fn ConvexFunction) string {
+	return strings.NewReplacer(
+		hookNameNamespace, capitalize(topNamespace),
+		hookNameSubNamespace, hookSubNamespace(topNamespace, fn),
+		hookNameFunc, capitalize(fn.Name),
+	).Replace(tmpl)
+}
+
+// resolveHookNames names every function in one category (queries, mutations
+// or actions) with the hookNaming template. All of a category's hooks are
+// re-exported from one index.ts, so a name the template gives to more than
+// one function falls back to qualifiedHookName for each of them. A no-op
+// for the flat/qualified/auto modes.
+func (g *HooksGenerator) resolveHookNames(byNamespace map[string][]ConvexFunction) {
+	tmpl := g.config.DataLayer.HookNaming
+	if !isHookNameTemplate(tmpl) {
+		return
+	}
+
+	count := make(map[string]int)
+	for topNamespace, funcs := range byNamespace {
+		for _, fn := range funcs {
+			count[hookNameFromTemplate(tmpl, topNamespace, fn)]++
+		}
+	}
+
+	g.hookNames = make(map[string]string)
+	for topNamespace, funcs := range byNamespace {
+		for _, fn := range funcs {
+			name := hookNameFromTemplate(tmpl, topNamespace, fn)
+			if count[name] > 1 {
+				name = qualifiedHookName(topNamespace, fn)
+			}
+			g.hookNames[toApiPath(fn.Namespace, fn.Name)] = name
+		}
+	}
+}
+
+// templateHookName returns fn's hook name under the hookNaming template, as
+// resolved for its category by resolveHookNames.
+func (g *HooksGenerator) templateHookName(topNamespace string, fn ConvexFunction) string {
+	if name, ok := g.hookNames[toApiPath(fn.Namespace, fn.Name)]; ok {
+		return name
+	}
+	return hookNameFromTemplate(g.config.DataLayer.HookNaming, topNamespace, fn)
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// hookNamingFunctions has a function in the top-level namespace, a name
+// shared by two sub-namespaces, and a name shared by two top-level
+// namespaces, so each template hits at least one collision.
+func hookNamingFunctions() []ConvexFunction {
+	return []ConvexFunction{
+		{Name: "getStats", Type: FunctionTypeQuery, Namespace: "events"},
+		{Name: "getEvent", Type: FunctionTypeQuery, Namespace: "events/eventQueries",
+			Args: []ArgInfo{{Name: "eventId", Type: "string", IsID: true, TableName: "events"}}},
+		{Name: "list", Type: FunctionTypeQuery, Namespace: "events/eventQueries"},
+		{Name: "list", Type: FunctionTypeQuery, Namespace: "events/adminQueries"},
+		{Name: "getStats", Type: FunctionTypeQuery, Namespace: "users/userQueries"},
+	}
+}
+
+// renderHookNaming renders the grouped and split files of every namespace
+// under one hookNaming template into one document.
+func renderHookNaming(tmpl string) string {
+	cfg := &Config{
+		DataLayer: DataLayerConfig{HookStyle: HookStyleConvexReact, HookNaming: tmpl},
+		Imports:   ImportsConfig{API: "@acme/backend/api", DataModel: "@acme/backend/dataModel"},
+	}
+	gen := NewHooksGenerator(cfg)
+
+	byNamespace := make(map[string][]ConvexFunction)
+	for _, fn := range hookNamingFunctions() {
+		top := getTopLevelNamespace(fn.Namespace)
+		byNamespace[top] = append(byNamespace[top], fn)
+	}
+	gen.resolveHookNames(byNamespace)
+
+	var sb strings.Builder
+	for _, top := range []string{"events", "users"} {
+		funcs := byNamespace[top]
+		sb.WriteString("// ===== grouped " + top + " =====\n")
+		sb.WriteString(gen.generateGroupedHookFileContent(top, funcs, "query"))
+		for _, ns := range uniqueNamespaces(funcs) {
+			sb.WriteString("// ===== split " + ns + " =====\n")
+			var subFuncs []ConvexFunction
+			for _, fn := range funcs {
+				if fn.Namespace == ns {
+					subFuncs = append(subFuncs, fn)
+				}
+			}
+			sb.WriteString(gen.generateSplitHookFileContent(top, ns, subFuncs, "query"))
+		}
+	}
+	return sb.String()
+}
+
+// uniqueNamespaces returns the namespaces of funcs in first-seen order.
+func uniqueNamespaces(funcs []ConvexFunction) []string {
+	var out []string
+	seen := make(map[string]bool)
+	for _, fn := range funcs {
+		if !seen[fn.Namespace] {
+			seen[fn.Namespace] = true
+			out = append(out, fn.Namespace)
+		}
+	}
+	return out
+}
+
+// TestHookNamingGolden compares the generated hooks for each hookNaming
+// template against testdata/hooknaming/<name>.golden. Run `go test -run
+// TestHookNamingGolden -update` to regenerate after an intentional change.
+func TestHookNamingGolden(t *testing.T) {
+	templates := map[string]string{
+		"namespace-func":    "use{Namespace}{Func}",
+		"func":              "use{Func}",
+		"subnamespace-func": "use{SubNamespace}{Func}",
+	}
+	for name, tmpl := range templates {
+		t.Run(name, func(t *testing.T) {
+			got := renderHookNaming(tmpl)
+			golden := filepath.Join("testdata", "hooknaming", name+".golden")
+
+			if *updateGolden {
+				if err := os.MkdirAll(filepath.Dir(golden), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(golden, []byte(got), 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("read golden (run with -update to create): %v", err)
+			}
+			if got != string(want) {
+				t.Errorf("%s output differs from %s (run with -update if intended)\n--- got ---\n%s", tmpl, golden, got)
+			}
+		})
+	}
+}
+
+func TestHookNameFromTemplate(t *testing.T) {
+	fn := ConvexFunction{Name: "list", Namespace: "events/adminQueries"}
+	top := ConvexFunction{Name: "getStats", Namespace: "events"}
+	tests := []struct {
+		tmpl string
+		fn   ConvexFunction
+		want string
+	}{
+		{"use{Namespace}{Func}", fn, "useEventsList"},
+		{"use{Func}", fn, "useList"},
+		{"use{SubNamespace}{Func}", fn, "useAdminQueriesList"},
+		{"use{Namespace}{SubNamespace}{Func}", fn, "useEventsAdminQueriesList"},
+		{"use{SubNamespace}{Func}", top, "useGetStats"},
+		{"use{Func}Hook", fn, "useListHook"},
+	}
+	for _, tt := range tests {
+		if got := hookNameFromTemplate(tt.tmpl, "events", tt.fn); got != tt.want {
+			t.Errorf("hookNameFromTemplate(%q, %s) = %q, want %q", tt.tmpl, tt.fn.Namespace, got, tt.want)
+		}
+	}
+}
+
+func TestValidateHookNameTemplate(t *testing.T) {
+	tests := []struct {
+		tmpl    string
+		wantErr string
+	}{
+		{"use{Func}", ""},
+		{"use{Namespace}{SubNamespace}{Func}", ""},
+		{"get{Func}", "must start with 'use'"},
+		{"use{Namespace}", "must contain {Func}"},
+		{"use{Table}{Func}", "unknown placeholder"},
+		{"use-{Func}", "valid identifier"},
+	}
+	for _, tt := range tests {
+		err := validateHookNameTemplate(tt.tmpl)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("validateHookNameTemplate(%q) = %v, want nil", tt.tmpl, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("validateHookNameTemplate(%q) = %v, want error containing %q", tt.tmpl, err, tt.wantErr)
+		}
+	}
+}
@@ -15,6 +15,9 @@ type HooksGenerator struct {
 	queriesDir   string
 	mutationsDir string
 	actionsDir   string
+	// hookNames maps each function's API path to its hook name when
+	// hookNaming is a template; set per category by resolveHookNames.
+	hookNames map[string]string
 }
 
 // NewHooksGenerator creates a hooks generator
@@ -44,11 +47,10 @@ func (g *HooksGenerator) Generate(functions []ConvexFunction) error {
 		}
 	}
 
-	// Clean existing files
-	for _, dir := range []string{g.queriesDir, g.mutationsDir, g.actionsDir} {
-		if err := cleanDirectory(dir); err != nil {
-			return err
-		}
+	// Clean existing files (incremental mode removes only stale ones at the end)
+	cleaner, err := newOutputCleaner(g.config.DataLayer.Incremental, g.outputDir, g.queriesDir, g.mutationsDir, g.actionsDir)
+	if err != nil {
+		return err
 	}
 
 	// Group functions by type and TOP-LEVEL namespace
@@ -71,19 +73,19 @@ func (g *HooksGenerator) Generate(functions []ConvexFunction) error {
 	}
 
 	// Generate query hooks
-	queryFiles, err := g.generateHookFiles(queries, g.queriesDir, "query")
+	queryFiles, queryHooks, err := g.generateHookFiles(queries, g.queriesDir, "query")
 	if err != nil {
 		return err
 	}
 
 	// Generate mutation hooks
-	mutationFiles, err := g.generateHookFiles(mutations, g.mutationsDir, "mutation")
+	mutationFiles, mutationHooks, err := g.generateHookFiles(mutations, g.mutationsDir, "mutation")
 	if err != nil {
 		return err
 	}
 
 	// Generate action hooks
-	actionFiles, err := g.generateHookFiles(actions, g.actionsDir, "action")
+	actionFiles, actionHooks, err := g.generateHookFiles(actions, g.actionsDir, "action")
 	if err != nil {
 		return err
 	}
@@ -99,7 +101,27 @@ func (g *HooksGenerator) Generate(functions []ConvexFunction) error {
 		return err
 	}
 
-	return nil
+	// Record what was written so the next run cleans up only these files
+	if err := cleaner.finish(g.queriesDir, queryFiles); err != nil {
+		return err
+	}
+	if err := cleaner.finish(g.mutationsDir, mutationFiles); err != nil {
+		return err
+	}
+	if err := cleaner.finish(g.actionsDir, actionFiles); err != nil {
+		return err
+	}
+
+	// Root barrel re-exporting all three categories
+	categories := []hookCategory{
+		{dir: "queries", suffix: "Query", hooks: queryHooks},
+		{dir: "mutations", suffix: "Mutation", hooks: mutationHooks},
+		{dir: "actions", suffix: "Action", hooks: actionHooks},
+	}
+	if err := generateRootIndexFile(g.outputDir, categories, g.config.DataLayer.HookCollisions); err != nil {
+		return err
+	}
+	return cleaner.finish(g.outputDir, nil)
 }
 
 // getTopLevelNamespace extracts the top-level namespace from a full namespace path
@@ -117,10 +139,12 @@ func getSubNamespace(namespace string) string {
 	return ""
 }
 
-// generateHookFiles creates hook files based on fileStructure config
-func (g *HooksGenerator) generateHookFiles(byNamespace map[string][]ConvexFunction, outputDir string, funcType string) ([]string, error) {
+// generateHookFiles creates hook files based on fileStructure config. It
+// returns the file names written and the hook names they export.
+func (g *HooksGenerator) generateHookFiles(byNamespace map[string][]ConvexFunction, outputDir string, funcType string) ([]string, []string, error) {
 	fileStructure := g.config.DataLayer.FileStructure
-	var files []string
+	var files, hooks []string
+	g.resolveHookNames(byNamespace)
 
 	// Generate grouped files (one per top-level namespace)
 	if fileStructure == "grouped" || fileStructure == "both" {
@@ -130,11 +154,12 @@ func (g *HooksGenerator) generateHookFiles(byNamespace map[string][]ConvexFuncti
 
 			content := g.generateGroupedHookFileContent(topNamespace, funcs, funcType)
 
-			if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
-				return nil, fmt.Errorf("failed to write %s: %w", filePath, err)
+			if err := writeIfChanged(filePath, []byte(content)); err != nil {
+				return nil, nil, fmt.Errorf("failed to write %s: %w", filePath, err)
 			}
 
 			files = append(files, fileName)
+			hooks = append(hooks, exportedHookNames(content)...)
 		}
 	}
 
@@ -153,11 +178,12 @@ func (g *HooksGenerator) generateHookFiles(byNamespace map[string][]ConvexFuncti
 
 				content := g.generateSplitHookFileContent(topNamespace, fullNamespace, subFuncs, funcType)
 
-				if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
-					return nil, fmt.Errorf("failed to write %s: %w", filePath, err)
+				if err := writeIfChanged(filePath, []byte(content)); err != nil {
+					return nil, nil, fmt.Errorf("failed to write %s: %w", filePath, err)
 				}
 
 				files = append(files, fileName)
+				hooks = append(hooks, exportedHookNames(content)...)
 			}
 		}
 	}
@@ -165,7 +191,8 @@ func (g *HooksGenerator) generateHookFiles(byNamespace map[string][]ConvexFuncti
 	sort.Strings(files)
 	// Remove duplicates (in case both modes generate same file name)
 	files = uniqueStrings(files)
-	return files, nil
+	sort.Strings(hooks)
+	return files, uniqueStrings(hooks), nil
 }
 
 // uniqueStrings removes duplicates from a sorted slice
@@ -182,6 +209,40 @@ func uniqueStrings(s []string) []string {
 	return result
 }
 
+// isTanStack reports whether hooks are rendered as TanStack Query wrappers
+// (dataLayer.hookStyle "tanstack") instead of convex/react hooks.
+func (g *HooksGenerator) isTanStack() bool {
+	return g.config.DataLayer.HookStyle == HookStyleTanStack
+}
+
+// tanstackHookImports returns the import block for a "tanstack" hook file.
+// Paginated queries keep convex/react's usePaginatedQuery, which
+// @convex-dev/react-query has no equivalent for. quote is the string quote
+// the calling file style uses.
+func tanstackHookImports(funcType string, needsPagination, needsRegularQuery bool, quote string) string {
+	var sb strings.Builder
+	importFrom := func(names, module string) {
+		fmt.Fprintf(&sb, "import { %s } from %s%s%s;\n", names, quote, module, quote)
+	}
+	switch funcType {
+	case "query":
+		if needsRegularQuery || !needsPagination {
+			importFrom("useQuery", "@tanstack/react-query")
+			importFrom("convexQuery", "@convex-dev/react-query")
+		}
+		if needsPagination {
+			importFrom("usePaginatedQuery", "convex/react")
+		}
+	case "mutation":
+		importFrom("useMutation", "@tanstack/react-query")
+		importFrom("useConvexMutation", "@convex-dev/react-query")
+	case "action":
+		importFrom("useMutation", "@tanstack/react-query")
+		importFrom("useConvexAction", "@convex-dev/react-query")
+	}
+	return sb.String()
+}
+
 // generateSplitHookFileContent creates content for a single sub-namespace file
 func (g *HooksGenerator) generateSplitHookFileContent(topNamespace, fullNamespace string, funcs []ConvexFunction, funcType string) string {
 	var sb strings.Builder
@@ -222,19 +283,23 @@ func (g *HooksGenerator) generateSplitHookFileContent(topNamespace, fullNamespac
 	}
 
 	// Imports
-	switch funcType {
-	case "query":
-		if needsPagination && needsRegularQuery {
-			sb.WriteString("import { useQuery, usePaginatedQuery } from 'convex/react';\n")
-		} else if needsPagination {
-			sb.WriteString("import { usePaginatedQuery } from 'convex/react';\n")
-		} else {
-			sb.WriteString("import { useQuery } from 'convex/react';\n")
+	if g.isTanStack() {
+		sb.WriteString(tanstackHookImports(funcType, needsPagination, needsRegularQuery, "'"))
+	} else {
+		switch funcType {
+		case "query":
+			if needsPagination && needsRegularQuery {
+				sb.WriteString("import { useQuery, usePaginatedQuery } from 'convex/react';\n")
+			} else if needsPagination {
+				sb.WriteString("import { usePaginatedQuery } from 'convex/react';\n")
+			} else {
+				sb.WriteString("import { useQuery } from 'convex/react';\n")
+			}
+		case "mutation":
+			sb.WriteString("import { useMutation } from 'convex/react';\n")
+		case "action":
+			sb.WriteString("import { useAction } from 'convex/react';\n")
 		}
-	case "mutation":
-		sb.WriteString("import { useMutation } from 'convex/react';\n")
-	case "action":
-		sb.WriteString("import { useAction } from 'convex/react';\n")
 	}
 
 	fmt.Fprintf(&sb, "import { api } from '%s';\n", g.config.Imports.API)
@@ -245,13 +310,13 @@ func (g *HooksGenerator) generateSplitHookFileContent(topNamespace, fullNamespac
 	if needsFunctionArgs {
 		sb.WriteString("import type { FunctionArgs } from 'convex/server';\n")
 	}
-	if g.config.DataLayer.TypedReturns && funcType == "query" && needsRegularQuery {
+	if g.config.DataLayer.TypedReturns && funcType == "query" && needsRegularQuery && !g.isTanStack() {
 		sb.WriteString("import type { FunctionReturnType } from 'convex/server';\n")
 	}
-	if g.config.DataLayer.TypedArgs && funcType == "mutation" {
+	if g.config.DataLayer.TypedArgs && funcType == "mutation" && !g.isTanStack() {
 		sb.WriteString("import type { ReactMutation } from 'convex/react';\n")
 	}
-	if g.config.DataLayer.TypedArgs && funcType == "action" {
+	if g.config.DataLayer.TypedArgs && funcType == "action" && !g.isTanStack() {
 		sb.WriteString("import type { ReactAction } from 'convex/react';\n")
 	}
 
@@ -350,19 +415,23 @@ func (g *HooksGenerator) generateGroupedHookFileContent(topNamespace string, fun
 	}
 
 	// Imports - use configured import paths
-	switch funcType {
-	case "query":
-		if needsPagination && needsRegularQuery {
-			sb.WriteString("import { useQuery, usePaginatedQuery } from \"convex/react\";\n")
-		} else if needsPagination {
-			sb.WriteString("import { usePaginatedQuery } from \"convex/react\";\n")
-		} else {
-			sb.WriteString("import { useQuery } from \"convex/react\";\n")
+	if g.isTanStack() {
+		sb.WriteString(tanstackHookImports(funcType, needsPagination, needsRegularQuery, "\""))
+	} else {
+		switch funcType {
+		case "query":
+			if needsPagination && needsRegularQuery {
+				sb.WriteString("import { useQuery, usePaginatedQuery } from \"convex/react\";\n")
+			} else if needsPagination {
+				sb.WriteString("import { usePaginatedQuery } from \"convex/react\";\n")
+			} else {
+				sb.WriteString("import { useQuery } from \"convex/react\";\n")
+			}
+		case "mutation":
+			sb.WriteString("import { useMutation } from \"convex/react\";\n")
+		case "action":
+			sb.WriteString("import { useAction } from \"convex/react\";\n")
 		}
-	case "mutation":
-		sb.WriteString("import { useMutation } from \"convex/react\";\n")
-	case "action":
-		sb.WriteString("import { useAction } from \"convex/react\";\n")
 	}
 
 	// API import - use configured path
@@ -374,13 +443,13 @@ func (g *HooksGenerator) generateGroupedHookFileContent(topNamespace string, fun
 	if needsFunctionArgs {
 		sb.WriteString("import type { FunctionArgs } from \"convex/server\";\n")
 	}
-	if g.config.DataLayer.TypedReturns && funcType == "query" && needsRegularQuery {
+	if g.config.DataLayer.TypedReturns && funcType == "query" && needsRegularQuery && !g.isTanStack() {
 		sb.WriteString("import type { FunctionReturnType } from \"convex/server\";\n")
 	}
-	if g.config.DataLayer.TypedArgs && funcType == "mutation" {
+	if g.config.DataLayer.TypedArgs && funcType == "mutation" && !g.isTanStack() {
 		sb.WriteString("import type { ReactMutation } from \"convex/react\";\n")
 	}
-	if g.config.DataLayer.TypedArgs && funcType == "action" {
+	if g.config.DataLayer.TypedArgs && funcType == "action" && !g.isTanStack() {
 		sb.WriteString("import type { ReactAction } from \"convex/react\";\n")
 	}
 
@@ -424,27 +493,20 @@ func (g *HooksGenerator) generateHook(topNamespace string, fn ConvexFunction, co
 
 	// Determine hook name based on naming strategy
 	var hookName string
-	switch hookNaming {
-	case "qualified":
+	switch {
+	case isHookNameTemplate(hookNaming):
+		// Template, falling back to the qualified name on collision
+		hookName = g.templateHookName(topNamespace, fn)
+	case hookNaming == "qualified":
 		// Always include sub-namespace
-		subNs := getSubNamespace(fn.Namespace)
-		if subNs != "" && subNs != topNamespace {
-			hookName = "use" + capitalize(topNamespace) + capitalize(toCamelCase(subNs)) + capitalize(fn.Name)
-		} else {
-			hookName = baseName
-		}
-	case "flat":
+		hookName = qualifiedHookName(topNamespace, fn)
+	case hookNaming == "flat":
 		// Never include sub-namespace
 		hookName = baseName
 	default: // "auto"
 		// Include sub-namespace only on collision
 		if collisions[baseName] {
-			subNs := getSubNamespace(fn.Namespace)
-			if subNs != "" && subNs != topNamespace {
-				hookName = "use" + capitalize(topNamespace) + capitalize(toCamelCase(subNs)) + capitalize(fn.Name)
-			} else {
-				hookName = baseName
-			}
+			hookName = qualifiedHookName(topNamespace, fn)
 		} else {
 			hookName = baseName
 		}
@@ -533,6 +595,7 @@ func (g *HooksGenerator) generateHook(topNamespace string, fn ConvexFunction, co
 			sb.WriteString(" * @param options - Pagination options (optional)\n")
 		}
 	}
+	writeReturnsDoc(&sb, fn)
 	sb.WriteString(" */\n")
 
 	// Function signature
@@ -554,17 +617,34 @@ func (g *HooksGenerator) generateHook(topNamespace string, fn ConvexFunction, co
 	return sb.String(), hookName
 }
 
+// writeReturnsDoc adds a @returns line to a hook's JSDoc when the parser
+// knows the function's result type, from its returns validator or inferred
+// from the handler, after a blank line unless it follows @param lines.
+// Paginated queries are left out: their hooks return pages, not the
+// handler's result.
+func writeReturnsDoc(sb *strings.Builder, fn ConvexFunction) {
+	if fn.ReturnType == "" || fn.IsPaginated {
+		return
+	}
+	doc := sb.String()[strings.LastIndex(sb.String(), "/**"):]
+	if !strings.Contains(doc, "@param") {
+		sb.WriteString(" *\n")
+	}
+	fmt.Fprintf(sb, " * @returns %s result: %s\n", capitalize(string(fn.Type)), fn.ReturnType)
+	if fn.ReturnsUntyped {
+		sb.WriteString(" * TODO: type the result; its returns validator is too complex for convex-gen\n")
+	}
+}
+
 // generateSplitHook creates a hook for split files - always includes sub-namespace in name
 func (g *HooksGenerator) generateSplitHook(topNamespace string, fn ConvexFunction) string {
 	var sb strings.Builder
 
-	// For split files, always include sub-namespace to ensure unique names across files
-	subNs := getSubNamespace(fn.Namespace)
-	var hookName string
-	if subNs != "" && subNs != topNamespace {
-		hookName = "use" + capitalize(topNamespace) + capitalize(toCamelCase(subNs)) + capitalize(fn.Name)
-	} else {
-		hookName = "use" + capitalize(topNamespace) + capitalize(fn.Name)
+	// For split files, always include sub-namespace to ensure unique names
+	// across files, unless a hookNaming template decides the name
+	hookName := qualifiedHookName(topNamespace, fn)
+	if isHookNameTemplate(g.config.DataLayer.HookNaming) {
+		hookName = g.templateHookName(topNamespace, fn)
 	}
 	apiPath := toApiPath(fn.Namespace, fn.Name)
 
@@ -650,6 +730,7 @@ func (g *HooksGenerator) generateSplitHook(topNamespace string, fn ConvexFunctio
 			sb.WriteString(" * @param options - Pagination options (optional)\n")
 		}
 	}
+	writeReturnsDoc(&sb, fn)
 	sb.WriteString(" */\n")
 
 	// Function signature
@@ -793,11 +874,12 @@ func (g *HooksGenerator) getTypeScriptType(arg ArgInfo) string {
 //   - the function is not a query (mutation/action arg typing is handled by
 //     generateArgsAnnotation under the separate typedArgs flag)
 //   - the query is paginated (the existing emit already preserves return types via usePaginatedQuery's generic)
+//   - hookStyle is "tanstack" (TanStack's useQuery result carries its own types)
 //
 // Otherwise returns ": FunctionReturnType<typeof <apiPath>> | undefined". The trailing
 // `| undefined` matches useQuery's runtime contract — undefined while loading or when args === "skip".
 func (g *HooksGenerator) generateReturnAnnotation(fn ConvexFunction, apiPath string) string {
-	if !g.config.DataLayer.TypedReturns {
+	if !g.config.DataLayer.TypedReturns || g.isTanStack() {
 		return ""
 	}
 	if fn.Type != FunctionTypeQuery {
@@ -823,8 +905,9 @@ func (g *HooksGenerator) generateReturnAnnotation(fn ConvexFunction, apiPath str
 // Returns "" (no annotation, the historical behavior) when:
 //   - typedArgs is disabled in config (this is the default — backwards compatible)
 //   - the function is a query (queries are covered by typedReturns instead)
+//   - hookStyle is "tanstack" (useMutation infers mutationFn's argument types)
 func (g *HooksGenerator) generateArgsAnnotation(fn ConvexFunction, apiPath string) string {
-	if !g.config.DataLayer.TypedArgs {
+	if !g.config.DataLayer.TypedArgs || g.isTanStack() {
 		return ""
 	}
 	switch fn.Type {
@@ -850,6 +933,8 @@ func (g *HooksGenerator) generateHookBodyV2(fn ConvexFunction, apiPath string) s
 				sb.WriteString("    args ?? \"skip\",\n")
 				sb.WriteString("    { initialNumItems: options?.initialNumItems || 20 }\n")
 				sb.WriteString("  );\n")
+			} else if g.isTanStack() {
+				fmt.Fprintf(&sb, "  return useQuery({ ...convexQuery(%s, args ?? \"skip\") });\n", apiPath)
 			} else {
 				fmt.Fprintf(&sb, "  return useQuery(%s, args ?? \"skip\");\n", apiPath)
 			}
@@ -869,6 +954,12 @@ func (g *HooksGenerator) generateHookBodyV2(fn ConvexFunction, apiPath string) s
 			sb.WriteString("  );\n")
 		} else {
 			argsExpr, needsAsAnyCast := g.generateArgsWithSpreadInline(fn)
+			if g.isTanStack() {
+				// convexQuery accepts "skip" like convex/react's useQuery, so the
+				// args expression is shared; TanStack's result needs no cast.
+				fmt.Fprintf(&sb, "  return useQuery({ ...convexQuery(%s, %s) });\n", apiPath, argsExpr)
+				break
+			}
 			// When typed returns are enabled, the function signature carries
 			// `FunctionReturnType<typeof api.x.y> | undefined`, so the historical
 			// `as any` return cast (used to swallow TS2589 deep-instantiation noise
@@ -881,10 +972,18 @@ func (g *HooksGenerator) generateHookBodyV2(fn ConvexFunction, apiPath string) s
 		}
 
 	case FunctionTypeMutation:
-		fmt.Fprintf(&sb, "  return useMutation(%s);\n", apiPath)
+		if g.isTanStack() {
+			fmt.Fprintf(&sb, "  return useMutation({ mutationFn: useConvexMutation(%s) });\n", apiPath)
+		} else {
+			fmt.Fprintf(&sb, "  return useMutation(%s);\n", apiPath)
+		}
 
 	case FunctionTypeAction:
-		fmt.Fprintf(&sb, "  return useAction(%s);\n", apiPath)
+		if g.isTanStack() {
+			fmt.Fprintf(&sb, "  return useMutation({ mutationFn: useConvexAction(%s) });\n", apiPath)
+		} else {
+			fmt.Fprintf(&sb, "  return useAction(%s);\n", apiPath)
+		}
 	}
 
 	return sb.String()
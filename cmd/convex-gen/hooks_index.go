@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// hookExportRe matches the hook declarations a generated hook file exports
+var hookExportRe = regexp.MustCompile(`(?m)^export function (\w+)`)
+
+// hookCategory is one hooks subdirectory as seen by the root index.ts.
+type hookCategory struct {
+	dir    string   // subdirectory, e.g. "queries"
+	suffix string   // appended to colliding names, e.g. "Query"
+	hooks  []string // hook names the subdirectory exports, sorted
+}
+
+// exportedHookNames returns the hook names declared in generated file content.
+func exportedHookNames(content string) []string {
+	var names []string
+	for _, m := range hookExportRe.FindAllStringSubmatch(content, -1) {
+		names = append(names, m[1])
+	}
+	return names
+}
+
+// findHookCollisions returns every hook name exported by more than one
+// category, mapped to those categories' directories.
+func findHookCollisions(categories []hookCategory) map[string][]string {
+	owners := make(map[string][]string)
+	for _, c := range categories {
+		for _, name := range c.hooks {
+			owners[name] = append(owners[name], c.dir)
+		}
+	}
+	collisions := make(map[string][]string)
+	for name, dirs := range owners {
+		if len(dirs) > 1 {
+			collisions[name] = dirs
+		}
+	}
+	return collisions
+}
+
+// formatHookCollisions lists collisions one per line, sorted by name:
+// "  useEventsGet (queries, mutations)".
+func formatHookCollisions(collisions map[string][]string) string {
+	names := make([]string, 0, len(collisions))
+	for name := range collisions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&sb, "\n  %s (%s)", name, strings.Join(collisions[name], ", "))
+	}
+	return sb.String()
+}
+
+// generateRootIndexFile writes the index.ts barrel at the root of the hooks
+// output dir, re-exporting queries, mutations and actions. `export *` of two
+// modules sharing a name is ambiguous, so a category with a colliding hook is
+// re-exported name by name instead, with the collisions renamed by category
+// suffix. With mode "error" any collision fails generation.
+func generateRootIndexFile(dir string, categories []hookCategory, mode string) error {
+	collisions := findHookCollisions(categories)
+	if len(collisions) > 0 && mode == HookCollisionsError {
+		return fmt.Errorf("hook names collide across categories (set dataLayer.hookCollisions to %q to rename them):%s",
+			HookCollisionsSuffix, formatHookCollisions(collisions))
+	}
+
+	exported := make(map[string]bool)
+	for _, c := range categories {
+		for _, name := range c.hooks {
+			exported[name] = true
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("/**\n")
+	sb.WriteString(" * AUTO-GENERATED INDEX - DO NOT EDIT\n")
+	sb.WriteString(" */\n\n")
+
+	for _, c := range categories {
+		renamed := false
+		for _, name := range c.hooks {
+			if collisions[name] != nil {
+				renamed = true
+				break
+			}
+		}
+		if !renamed {
+			fmt.Fprintf(&sb, "export * from './%s';\n", c.dir)
+			continue
+		}
+
+		sb.WriteString("export {\n")
+		for _, name := range c.hooks {
+			if collisions[name] == nil {
+				fmt.Fprintf(&sb, "  %s,\n", name)
+				continue
+			}
+			alias := name + c.suffix
+			if exported[alias] {
+				return fmt.Errorf("cannot rename colliding hook %s in %s: %s is already exported", name, c.dir, alias)
+			}
+			fmt.Fprintf(&sb, "  %s as %s,\n", name, alias)
+		}
+		fmt.Fprintf(&sb, "} from './%s';\n", c.dir)
+	}
+
+	return writeIfChanged(filepath.Join(dir, "index.ts"), []byte(sb.String()))
+}
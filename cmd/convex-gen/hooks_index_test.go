@@ -0,0 +1,113 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// collidingFunctions yields useEventsGet from both a query and a mutation.
+func collidingFunctions() []ConvexFunction {
+	return []ConvexFunction{
+		{Name: "get", Type: FunctionTypeQuery, Namespace: "events/eventQueries"},
+		{Name: "list", Type: FunctionTypeQuery, Namespace: "events/eventQueries"},
+		{Name: "get", Type: FunctionTypeMutation, Namespace: "events/eventMutations"},
+		{Name: "sync", Type: FunctionTypeAction, Namespace: "events/eventActions"},
+	}
+}
+
+func readRootIndex(t *testing.T, gen *HooksGenerator) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(gen.outputDir, "index.ts"))
+	if err != nil {
+		t.Fatalf("root index.ts not written: %v", err)
+	}
+	return string(data)
+}
+
+func TestHooksGenerator_RootIndexWithoutCollisions(t *testing.T) {
+	gen := NewHooksGenerator(manifestTestConfig(t.TempDir()))
+
+	funcs := []ConvexFunction{
+		{Name: "get", Type: FunctionTypeQuery, Namespace: "events/eventQueries"},
+		{Name: "create", Type: FunctionTypeMutation, Namespace: "events/eventMutations"},
+	}
+	if err := gen.Generate(funcs); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	index := readRootIndex(t, gen)
+	for _, want := range []string{
+		"export * from './queries';",
+		"export * from './mutations';",
+		"export * from './actions';",
+	} {
+		if !strings.Contains(index, want) {
+			t.Errorf("root index missing %q:\n%s", want, index)
+		}
+	}
+
+	manifest, err := os.ReadFile(filepath.Join(gen.outputDir, manifestFileName))
+	if err != nil {
+		t.Fatalf("root manifest not written: %v", err)
+	}
+	if !strings.Contains(string(manifest), `"index.ts"`) {
+		t.Errorf("root manifest does not track index.ts:\n%s", manifest)
+	}
+}
+
+func TestHooksGenerator_RootIndexSuffixesCollisions(t *testing.T) {
+	cfg := manifestTestConfig(t.TempDir())
+	cfg.DataLayer.HookCollisions = HookCollisionsSuffix
+	gen := NewHooksGenerator(cfg)
+
+	if err := gen.Generate(collidingFunctions()); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	index := readRootIndex(t, gen)
+	for _, want := range []string{
+		"  useEventsGet as useEventsGetQuery,\n  useEventsList,\n} from './queries';",
+		"  useEventsGet as useEventsGetMutation,\n} from './mutations';",
+		"export * from './actions';",
+	} {
+		if !strings.Contains(index, want) {
+			t.Errorf("root index missing %q:\n%s", want, index)
+		}
+	}
+	if strings.Contains(index, "export * from './queries'") || strings.Contains(index, "export * from './mutations'") {
+		t.Errorf("colliding categories must not be star-exported:\n%s", index)
+	}
+}
+
+func TestHooksGenerator_RootIndexErrorsOnCollisions(t *testing.T) {
+	cfg := manifestTestConfig(t.TempDir())
+	cfg.DataLayer.HookCollisions = HookCollisionsError
+	gen := NewHooksGenerator(cfg)
+
+	err := gen.Generate(collidingFunctions())
+	if err == nil {
+		t.Fatal("Generate: want an error for colliding hook names")
+	}
+	if !strings.Contains(err.Error(), "useEventsGet (queries, mutations)") {
+		t.Errorf("error should list the colliding name and categories, got: %v", err)
+	}
+	if strings.Contains(err.Error(), "useEventsList") {
+		t.Errorf("error lists a non-colliding hook: %v", err)
+	}
+	if fileExists(filepath.Join(gen.outputDir, "index.ts")) {
+		t.Error("root index.ts written despite the collision error")
+	}
+}
+
+func TestGenerateRootIndexFile_SuffixTakenFails(t *testing.T) {
+	categories := []hookCategory{
+		{dir: "queries", suffix: "Query", hooks: []string{"useEventsGet", "useEventsGetQuery"}},
+		{dir: "mutations", suffix: "Mutation", hooks: []string{"useEventsGet"}},
+	}
+	err := generateRootIndexFile(t.TempDir(), categories, HookCollisionsSuffix)
+	if err == nil || !strings.Contains(err.Error(), "useEventsGetQuery is already exported") {
+		t.Errorf("generateRootIndexFile() error = %v, want suffix conflict", err)
+	}
+}
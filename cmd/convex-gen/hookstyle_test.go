@@ -0,0 +1,102 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite golden files in testdata/")
+
+// hookStyleFunctions covers every body template: a query keyed on a required
+// ID, a no-args shouldSkip query, a paginated query, a FunctionArgs query, a
+// mutation and an action.
+func hookStyleFunctions() []ConvexFunction {
+	return []ConvexFunction{
+		{Name: "getEvent", Type: FunctionTypeQuery, Namespace: "events/eventQueries",
+			Args: []ArgInfo{{Name: "eventId", Type: "string", IsID: true, TableName: "events"}}},
+		{Name: "listUpcoming", Type: FunctionTypeQuery, Namespace: "events/eventQueries",
+			Args: []ArgInfo{{Name: "limit", Type: "number", Optional: true}}},
+		{Name: "listPaged", Type: FunctionTypeQuery, Namespace: "events/eventQueries", IsPaginated: true},
+		{Name: "search", Type: FunctionTypeQuery, Namespace: "events/eventQueries", UseFunctionArgs: true},
+		{Name: "createEvent", Type: FunctionTypeMutation, Namespace: "events/eventMutations"},
+		{Name: "syncCalendar", Type: FunctionTypeAction, Namespace: "events/eventActions"},
+	}
+}
+
+// renderHookStyle renders grouped and split files for every function type
+// into one document, so a single golden file covers a whole style.
+func renderHookStyle(style string) string {
+	cfg := &Config{
+		DataLayer: DataLayerConfig{HookStyle: style, HookNaming: "flat"},
+		Imports:   ImportsConfig{API: "@acme/backend/api", DataModel: "@acme/backend/dataModel"},
+	}
+	gen := NewHooksGenerator(cfg)
+	fns := hookStyleFunctions()
+
+	var sb strings.Builder
+	for _, funcType := range []string{"query", "mutation", "action"} {
+		typed := filterByType(fns, FunctionType(funcType))
+		sb.WriteString("// ===== grouped " + funcType + " =====\n")
+		sb.WriteString(gen.generateGroupedHookFileContent("events", typed, funcType))
+		sb.WriteString("// ===== split " + funcType + " =====\n")
+		sb.WriteString(gen.generateSplitHookFileContent("events", typed[0].Namespace, typed, funcType))
+	}
+	return sb.String()
+}
+
+// TestHookStyleGolden compares the generated hooks for each hookStyle against
+// testdata/hookstyle/<style>.golden. Run `go test -run TestHookStyleGolden
+// -update` to regenerate after an intentional template change.
+func TestHookStyleGolden(t *testing.T) {
+	for _, style := range []string{HookStyleConvexReact, HookStyleTanStack} {
+		t.Run(style, func(t *testing.T) {
+			got := renderHookStyle(style)
+			golden := filepath.Join("testdata", "hookstyle", style+".golden")
+
+			if *updateGolden {
+				if err := os.MkdirAll(filepath.Dir(golden), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(golden, []byte(got), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("read golden (run with -update to create): %v", err)
+			}
+			if got != string(want) {
+				t.Errorf("%s output differs from %s (run with -update if intended)\n--- got ---\n%s", style, golden, got)
+			}
+		})
+	}
+}
+
+// TestHookStyleTanStackKeepsConvexReactOut guards the import switch: a
+// tanstack file must not pull the plain convex/react hooks (or their typed
+// annotations) even when typedReturns/typedArgs are on.
+func TestHookStyleTanStackKeepsConvexReactOut(t *testing.T) {
+	cfg := &Config{
+		DataLayer: DataLayerConfig{HookStyle: HookStyleTanStack, HookNaming: "flat", TypedReturns: true, TypedArgs: true},
+		Imports:   ImportsConfig{API: "@acme/backend/api", DataModel: "@acme/backend/dataModel"},
+	}
+	gen := NewHooksGenerator(cfg)
+	fns := hookStyleFunctions()
+
+	for _, funcType := range []string{"query", "mutation", "action"} {
+		typed := filterByType(fns, FunctionType(funcType))
+		if funcType == "query" {
+			typed = typed[:1] // no paginated query: convex/react must not be imported at all
+		}
+		content := gen.generateGroupedHookFileContent("events", typed, funcType)
+		for _, banned := range []string{`from "convex/react"`, "FunctionReturnType", "ReactMutation", "ReactAction"} {
+			if strings.Contains(content, banned) {
+				t.Errorf("%s tanstack output contains %q:\n%s", funcType, banned, content)
+			}
+		}
+	}
+}
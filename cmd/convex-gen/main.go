@@ -3,14 +3,31 @@ package main
 import (
 	"flag"
 	"fmt"
+	"io"
 	"os"
 )
 
 func main() {
 	typedReturns := flag.Bool("typed-returns", false, "Emit typed `FunctionReturnType<typeof api.x.y> | undefined` returns on shouldSkip query hooks instead of `as any`. When true, overrides .convex-gen.json `dataLayer.typedReturns`. Default off (existing behavior).")
+	watchMode := flag.Bool("watch", false, "Keep running and regenerate whenever files under the Convex path change.")
+	debug := flag.Bool("debug", debugEnabled, "Log parse decisions (files, functions, validator resolution) to stderr. Also enabled by env CONVEX_GEN_DEBUG=1.")
+	checkMode := flag.Bool("check", false, "Generate into a temporary directory and exit non-zero, listing the stale files, if the committed output differs. Writes nothing. For CI.")
 	flag.Parse()
+	debugEnabled = *debug
 
-	if err := run(*typedReturns); err != nil {
+	if *checkMode && *watchMode {
+		fmt.Fprintln(os.Stderr, "Error: --check cannot be combined with --watch")
+		os.Exit(1)
+	}
+
+	runFn := run
+	if *watchMode {
+		runFn = watch
+	}
+	if *checkMode {
+		runFn = runCheck
+	}
+	if err := runFn(*typedReturns); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
@@ -20,17 +37,9 @@ func run(cliTypedReturns bool) error {
 	fmt.Println("convex-gen - Convex Data Layer Generator")
 	fmt.Println()
 
-	// Load configuration
-	config, err := LoadConfig()
+	config, err := loadRunConfig(cliTypedReturns)
 	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
-	}
-
-	// CLI flag is a one-way override: when true, force typed returns regardless of config.
-	// When false (default), config wins — preserving existing behavior unless `.convex-gen.json`
-	// opts in via `dataLayer.typedReturns: true`.
-	if cliTypedReturns {
-		config.DataLayer.TypedReturns = true
+		return err
 	}
 
 	fmt.Printf("Organization: %s\n", config.Org)
@@ -41,61 +50,98 @@ func run(cliTypedReturns bool) error {
 	}
 	fmt.Println()
 
+	if _, err := generate(config, os.Stdout); err != nil {
+		return err
+	}
+
+	fmt.Println("Generation complete!")
+
+	return nil
+}
+
+// loadRunConfig loads .convex-gen.json and applies CLI overrides.
+func loadRunConfig(cliTypedReturns bool) (*Config, error) {
+	config, err := LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// CLI flag is a one-way override: when true, force typed returns regardless of config.
+	// When false (default), config wins — preserving existing behavior unless `.convex-gen.json`
+	// opts in via `dataLayer.typedReturns: true`.
+	if cliTypedReturns {
+		config.DataLayer.TypedReturns = true
+	}
+	return config, nil
+}
+
+// generate runs the scan/parse/generate pipeline for config, writing progress
+// to w, and returns the number of hooks generated (0 when the hooks generator
+// is off). Both the one-shot run and --watch cycles call it.
+func generate(config *Config, w io.Writer) (int, error) {
 	// Create scanner
 	scanner, err := NewScanner(config)
 	if err != nil {
-		return fmt.Errorf("failed to create scanner: %w", err)
+		return 0, fmt.Errorf("failed to create scanner: %w", err)
 	}
 
 	// Create parser
 	parser := NewParser(config)
 
 	// Build validator cache for resolving referenced validators
-	fmt.Println("Building validator cache...")
+	fmt.Fprintln(w, "Building validator cache...")
 	if err := parser.BuildValidatorCache(config.Convex.Path); err != nil {
-		fmt.Printf("Warning: failed to build validator cache: %v\n", err)
+		fmt.Fprintf(w, "Warning: failed to build validator cache: %v\n", err)
 	}
-	fmt.Printf("Cached %d validators\n", len(parser.validatorCache))
-	fmt.Println()
+	fmt.Fprintf(w, "Cached %d validators\n", len(parser.validatorCache))
+	fmt.Fprintln(w)
 
 	// Scan and parse Convex functions
 	var allFunctions []ConvexFunction
 	if config.Generators.Hooks || config.Generators.API || config.Generators.AICatalog {
-		fmt.Println("Scanning Convex functions...")
+		fmt.Fprintln(w, "Scanning Convex functions...")
 
 		files, err := scanner.ScanConvexDirectory()
 		if err != nil {
-			return fmt.Errorf("failed to scan convex directory: %w", err)
+			return 0, fmt.Errorf("failed to scan convex directory: %w", err)
 		}
 
-		fmt.Printf("Found %d Convex files\n", len(files))
+		fmt.Fprintf(w, "Found %d Convex files\n", len(files))
+
+		// Validators imported from outside model/ (sibling files, shared modules)
+		if n := parser.BuildImportedValidatorCache(files); n > 0 {
+			fmt.Fprintf(w, "Cached %d imported validators\n", n)
+		}
 
 		for _, file := range files {
 			functions, err := parser.ParseConvexFile(file)
 			if err != nil {
-				fmt.Printf("Warning: failed to parse %s: %v\n", file.Path, err)
+				fmt.Fprintf(w, "Warning: failed to parse %s: %v\n", file.Path, err)
 				continue
 			}
 			allFunctions = append(allFunctions, functions...)
 		}
 
-		fmt.Printf("Parsed %d functions\n", len(allFunctions))
-		fmt.Println()
+		fmt.Fprintf(w, "Parsed %d functions\n", len(allFunctions))
+		if n := parser.SkippedFunctions(); n > 0 {
+			fmt.Fprintf(w, "Skipped %d functions (skip.functions)\n", n)
+		}
+		fmt.Fprintln(w)
 	}
 
 	// Scan and parse schema
 	var allTables []TableInfo
 	var schemaFiles []SchemaFile
 	if config.Generators.Types || config.Generators.Metadata {
-		fmt.Println("Scanning schema files...")
+		fmt.Fprintln(w, "Scanning schema files...")
 
 		var err error
 		schemaFiles, err = scanner.ScanSchemaFiles()
 		if err != nil {
-			return fmt.Errorf("failed to scan schema files: %w", err)
+			return 0, fmt.Errorf("failed to scan schema files: %w", err)
 		}
 
-		fmt.Printf("Found %d schema files\n", len(schemaFiles))
+		fmt.Fprintf(w, "Found %d schema files\n", len(schemaFiles))
 
 		// Check if we have a main schema file (with defineSchema)
 		// If so, only use tables from that file
@@ -104,7 +150,7 @@ func run(cliTypedReturns bool) error {
 			if file.Domain == "main" {
 				tables, err := parser.ParseSchemaFile(file)
 				if err != nil {
-					fmt.Printf("Warning: failed to parse main schema %s: %v\n", file.Path, err)
+					fmt.Fprintf(w, "Warning: failed to parse main schema %s: %v\n", file.Path, err)
 					continue
 				}
 				if len(tables) > 0 {
@@ -120,7 +166,7 @@ func run(cliTypedReturns bool) error {
 			for _, file := range schemaFiles {
 				tables, err := parser.ParseSchemaFile(file)
 				if err != nil {
-					fmt.Printf("Warning: failed to parse schema %s: %v\n", file.Path, err)
+					fmt.Fprintf(w, "Warning: failed to parse schema %s: %v\n", file.Path, err)
 					continue
 				}
 				allTables = append(allTables, tables...)
@@ -138,8 +184,8 @@ func run(cliTypedReturns bool) error {
 		}
 		allTables = uniqueTables
 
-		fmt.Printf("Parsed %d tables\n", len(allTables))
-		fmt.Println()
+		fmt.Fprintf(w, "Parsed %d tables\n", len(allTables))
+		fmt.Fprintln(w)
 	}
 
 	// Count by type
@@ -157,45 +203,46 @@ func run(cliTypedReturns bool) error {
 
 	// Generate hooks
 	if config.Generators.Hooks {
-		fmt.Println("Generating hooks...")
+		fmt.Fprintln(w, "Generating hooks...")
 		hooksGen := NewHooksGenerator(config)
 		if err := hooksGen.Generate(allFunctions); err != nil {
-			return fmt.Errorf("failed to generate hooks: %w", err)
+			return 0, fmt.Errorf("failed to generate hooks: %w", err)
 		}
-		fmt.Printf("  %d query hooks\n", queryCount)
-		fmt.Printf("  %d mutation hooks\n", mutationCount)
-		fmt.Printf("  %d action hooks\n", actionCount)
-		fmt.Printf("  Output: %s\n", config.GetHooksOutputDir())
-		fmt.Println()
+		fmt.Fprintf(w, "  %d query hooks\n", queryCount)
+		fmt.Fprintf(w, "  %d mutation hooks\n", mutationCount)
+		fmt.Fprintf(w, "  %d action hooks\n", actionCount)
+		fmt.Fprintf(w, "  Output: %s\n", config.GetHooksOutputDir())
+		fmt.Fprintln(w)
 	}
 
 	// Generate API wrappers
 	if config.Generators.API {
-		fmt.Println("Generating API wrappers...")
+		fmt.Fprintln(w, "Generating API wrappers...")
 		apiGen := NewAPIGenerator(config)
 		if err := apiGen.Generate(allFunctions); err != nil {
-			return fmt.Errorf("failed to generate API wrappers: %w", err)
+			return 0, fmt.Errorf("failed to generate API wrappers: %w", err)
 		}
-		fmt.Printf("  Output: %s\n", config.GetAPIOutputDir())
-		fmt.Println()
+		fmt.Fprintf(w, "  Output: %s\n", config.GetAPIOutputDir())
+		fmt.Fprintln(w)
 	}
 
 	// Generate types
 	if config.Generators.Types {
-		fmt.Println("Generating types...")
+		fmt.Fprintln(w, "Generating types...")
+		parser.EnrichTablesWithFields(schemaFiles, allTables)
 		typesGen := NewTypesGenerator(config)
 		if err := typesGen.Generate(allTables); err != nil {
-			return fmt.Errorf("failed to generate types: %w", err)
+			return 0, fmt.Errorf("failed to generate types: %w", err)
 		}
-		fmt.Printf("  %d table types\n", len(allTables))
-		fmt.Printf("  %d ID types\n", len(allTables))
-		fmt.Printf("  Output: %s\n", config.GetTypesOutputDir())
-		fmt.Println()
+		fmt.Fprintf(w, "  %d table types\n", len(allTables))
+		fmt.Fprintf(w, "  %d ID types\n", len(allTables))
+		fmt.Fprintf(w, "  Output: %s\n", config.GetTypesOutputDir())
+		fmt.Fprintln(w)
 	}
 
 	// Generate schema metadata
 	if config.Generators.Metadata {
-		fmt.Println("Enriching tables with field metadata...")
+		fmt.Fprintln(w, "Enriching tables with field metadata...")
 		parser.EnrichTablesWithFields(schemaFiles, allTables)
 
 		fieldsFound := 0
@@ -204,48 +251,48 @@ func run(cliTypedReturns bool) error {
 				fieldsFound++
 			}
 		}
-		fmt.Printf("  %d/%d tables with field definitions\n", fieldsFound, len(allTables))
-		fmt.Println()
+		fmt.Fprintf(w, "  %d/%d tables with field definitions\n", fieldsFound, len(allTables))
+		fmt.Fprintln(w)
 
-		fmt.Println("Generating schema metadata...")
+		fmt.Fprintln(w, "Generating schema metadata...")
 		metadataGen := NewMetadataGenerator(config)
 		if err := metadataGen.Generate(allTables); err != nil {
-			return fmt.Errorf("failed to generate metadata: %w", err)
+			return 0, fmt.Errorf("failed to generate metadata: %w", err)
 		}
-		fmt.Printf("  %d tables with field metadata\n", len(allTables))
-		fmt.Printf("  Output: %s\n", config.GetMetadataOutputDir())
-		fmt.Println()
+		fmt.Fprintf(w, "  %d tables with field metadata\n", len(allTables))
+		fmt.Fprintf(w, "  Output: %s\n", config.GetMetadataOutputDir())
+		fmt.Fprintln(w)
 	}
 
 	// Generate Terraform/public-API surface (opt-in). Resolves the curated
 	// resources from convex-terraform-gen.json against the parsed schema and
 	// emits <res>Api.ts, <res>Routes.ts, and the tfplugingen-openapi config.
 	if config.Generators.Terraform {
-		fmt.Println("Generating Terraform/public-API surface...")
+		fmt.Fprintln(w, "Generating Terraform/public-API surface...")
 		parser.EnrichTablesWithFields(schemaFiles, allTables)
 		tfGen := NewTerraformGenerator(config)
 		if err := tfGen.Generate(allTables); err != nil {
-			return fmt.Errorf("failed to generate terraform surface: %w", err)
+			return 0, fmt.Errorf("failed to generate terraform surface: %w", err)
 		}
-		fmt.Println("  Output: curated *Api.ts + *Routes.ts + generator_config.yml")
-		fmt.Println()
+		fmt.Fprintln(w, "  Output: curated *Api.ts + *Routes.ts + generator_config.yml")
+		fmt.Fprintln(w)
 	}
 
 	// Generate AI tool catalog
 	if config.Generators.AICatalog {
-		fmt.Println("Generating AI tool catalog...")
+		fmt.Fprintln(w, "Generating AI tool catalog...")
 		aiGen := NewAICatalogGenerator(config)
 		if err := aiGen.Generate(allFunctions); err != nil {
-			return fmt.Errorf("failed to generate AI catalog: %w", err)
+			return 0, fmt.Errorf("failed to generate AI catalog: %w", err)
 		}
-		fmt.Printf("  Output: %s\n", config.GetAICatalogOutputDir())
-		fmt.Println()
+		fmt.Fprintf(w, "  Output: %s\n", config.GetAICatalogOutputDir())
+		fmt.Fprintln(w)
 	}
 
 	// Generate OpenAPI spec (opt-in). Self-contained: scans the Convex tree for
 	// `*Api.ts` modules itself, independent of the public-function scan above.
 	if config.Generators.OpenAPI {
-		fmt.Println("Generating OpenAPI spec...")
+		fmt.Fprintln(w, "Generating OpenAPI spec...")
 		openapiGen := NewOpenAPIGenerator(config)
 		// Align OpenAPI URL segments with the Terraform overlay's canonical `path`
 		// (snake_case) so the spec matches the generated routes and the
@@ -263,14 +310,12 @@ func run(cliTypedReturns bool) error {
 		}
 		resources, err := openapiGen.Generate()
 		if err != nil {
-			return fmt.Errorf("failed to generate OpenAPI spec: %w", err)
+			return 0, fmt.Errorf("failed to generate OpenAPI spec: %w", err)
 		}
-		fmt.Printf("  %d resource(s)\n", resources)
-		fmt.Printf("  Output: %s\n", config.GetOpenAPISpecPath())
-		fmt.Println()
+		fmt.Fprintf(w, "  %d resource(s)\n", resources)
+		fmt.Fprintf(w, "  Output: %s\n", config.GetOpenAPISpecPath())
+		fmt.Fprintln(w)
 	}
 
-	fmt.Println("Generation complete!")
-
-	return nil
+	return queryCount + mutationCount + actionCount, nil
 }
@@ -0,0 +1,277 @@
+package main
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// manifestTestConfig returns a grouped-output config writing under tmpDir.
+func manifestTestConfig(tmpDir string) *Config {
+	return &Config{
+		DataLayer: DataLayerConfig{
+			Path:          tmpDir,
+			HooksDir:      "generated-hooks",
+			APIDir:        "generated-api",
+			FileStructure: "grouped",
+			HookNaming:    "flat",
+		},
+	}
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// TestHooksGenerator_KeepsStrayFilesOnRegenerate guards against cleanup
+// destroying hand-written files that share the output directory: only files
+// listed in the previous run's manifest are removed.
+func TestHooksGenerator_KeepsStrayFilesOnRegenerate(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := manifestTestConfig(tmpDir)
+	gen := NewHooksGenerator(cfg)
+
+	first := []ConvexFunction{{Name: "getEvent", Type: FunctionTypeQuery, Namespace: "events/eventQueries"}}
+	if err := gen.Generate(first); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if !fileExists(filepath.Join(gen.queriesDir, "useEvents.ts")) {
+		t.Fatal("useEvents.ts not generated")
+	}
+	if !fileExists(filepath.Join(gen.queriesDir, manifestFileName)) {
+		t.Fatal("manifest not written")
+	}
+
+	stray := filepath.Join(gen.queriesDir, "customHelpers.ts")
+	writeTestFile(t, stray, "export const helper = () => 1;\n")
+
+	// The events namespace disappears; its file must go, the stray must stay.
+	second := []ConvexFunction{{Name: "getUser", Type: FunctionTypeQuery, Namespace: "users/userQueries"}}
+	if err := gen.Generate(second); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if fileExists(filepath.Join(gen.queriesDir, "useEvents.ts")) {
+		t.Error("stale useEvents.ts from the previous manifest was not removed")
+	}
+	if !fileExists(filepath.Join(gen.queriesDir, "useUsers.ts")) {
+		t.Error("useUsers.ts not generated")
+	}
+	if !fileExists(stray) {
+		t.Error("hand-written customHelpers.ts was deleted on regenerate")
+	}
+}
+
+func TestAPIGenerator_KeepsStrayFilesOnRegenerate(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := manifestTestConfig(tmpDir)
+	gen := NewAPIGenerator(cfg)
+
+	if err := gen.Generate([]ConvexFunction{{Name: "getEvent", Type: FunctionTypeQuery, Namespace: "events/eventQueries"}}); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	stray := filepath.Join(gen.outputDir, "client.ts")
+	writeTestFile(t, stray, "export const client = {};\n")
+
+	if err := gen.Generate([]ConvexFunction{{Name: "getUser", Type: FunctionTypeQuery, Namespace: "users/userQueries"}}); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if fileExists(filepath.Join(gen.outputDir, "events.ts")) {
+		t.Error("stale events.ts was not removed")
+	}
+	if !fileExists(stray) {
+		t.Error("hand-written client.ts was deleted on regenerate")
+	}
+}
+
+// TestCleanDirectory_WithoutManifest covers output from before manifests
+// existed: generated files are recognized by their header, everything else
+// is left alone.
+func TestCleanDirectory_WithoutManifest(t *testing.T) {
+	dir := t.TempDir()
+	generated := filepath.Join(dir, "useEvents.ts")
+	writeTestFile(t, generated, "/**\n * AUTO-GENERATED QUERY HOOKS - DO NOT EDIT\n */\n")
+	handWritten := filepath.Join(dir, "helpers.ts")
+	writeTestFile(t, handWritten, "export const x = 1;\n")
+
+	if err := cleanDirectory(dir); err != nil {
+		t.Fatalf("cleanDirectory: %v", err)
+	}
+	if fileExists(generated) {
+		t.Error("generated file without manifest was not removed")
+	}
+	if !fileExists(handWritten) {
+		t.Error("hand-written file was removed")
+	}
+}
+
+func TestCleanDirectory_IgnoresEscapingManifestEntries(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "out")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	outside := filepath.Join(root, "source.ts")
+	writeTestFile(t, outside, "export {};\n")
+	writeTestFile(t, filepath.Join(dir, manifestFileName), `{"files": ["../source.ts", "index.ts"]}`)
+
+	if err := cleanDirectory(dir); err != nil {
+		t.Fatalf("cleanDirectory: %v", err)
+	}
+	if !fileExists(outside) {
+		t.Error("manifest entry escaped the output directory")
+	}
+}
+
+// backdateTree sets the mtime of every file under root to a fixed past time
+// and returns it, so a later rewrite is detectable regardless of clock
+// granularity.
+func backdateTree(t *testing.T, root string) time.Time {
+	t.Helper()
+	past := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		return os.Chtimes(path, past, past)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return past
+}
+
+// rewrittenFiles returns the files under root modified after since.
+func rewrittenFiles(t *testing.T, root string, since time.Time) []string {
+	t.Helper()
+	var rewritten []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if info.ModTime().After(since) {
+			rel, _ := filepath.Rel(root, path)
+			rewritten = append(rewritten, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rewritten
+}
+
+func generateHooksAndAPI(t *testing.T, cfg *Config, functions []ConvexFunction) {
+	t.Helper()
+	if err := NewHooksGenerator(cfg).Generate(functions); err != nil {
+		t.Fatalf("hooks Generate: %v", err)
+	}
+	if err := NewAPIGenerator(cfg).Generate(functions); err != nil {
+		t.Fatalf("API Generate: %v", err)
+	}
+}
+
+func TestIncremental_NoChangesRewritesZeroFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := manifestTestConfig(tmpDir)
+	cfg.DataLayer.Incremental = true
+	functions := []ConvexFunction{
+		{Name: "getEvent", Type: FunctionTypeQuery, Namespace: "events/eventQueries"},
+		{Name: "createEvent", Type: FunctionTypeMutation, Namespace: "events/eventMutations"},
+		{Name: "getUser", Type: FunctionTypeQuery, Namespace: "users/userQueries"},
+	}
+
+	generateHooksAndAPI(t, cfg, functions)
+	since := backdateTree(t, tmpDir)
+	generateHooksAndAPI(t, cfg, functions)
+
+	if rewritten := rewrittenFiles(t, tmpDir, since); len(rewritten) != 0 {
+		t.Errorf("regenerating unchanged sources rewrote %v, want none", rewritten)
+	}
+}
+
+func TestIncremental_RewritesOnlyChangedNamespace(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := manifestTestConfig(tmpDir)
+	cfg.DataLayer.Incremental = true
+	gen := NewHooksGenerator(cfg)
+
+	first := []ConvexFunction{
+		{Name: "getEvent", Type: FunctionTypeQuery, Namespace: "events/eventQueries"},
+		{Name: "getUser", Type: FunctionTypeQuery, Namespace: "users/userQueries"},
+		{Name: "getVenue", Type: FunctionTypeQuery, Namespace: "venues/venueQueries"},
+	}
+	if err := gen.Generate(first); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	stray := filepath.Join(gen.queriesDir, "customHelpers.ts")
+	writeTestFile(t, stray, "export const helper = () => 1;\n")
+	since := backdateTree(t, tmpDir)
+
+	// events gains a function and venues disappears; users is untouched.
+	second := []ConvexFunction{
+		{Name: "getEvent", Type: FunctionTypeQuery, Namespace: "events/eventQueries"},
+		{Name: "listEvents", Type: FunctionTypeQuery, Namespace: "events/eventQueries"},
+		{Name: "getUser", Type: FunctionTypeQuery, Namespace: "users/userQueries"},
+	}
+	if err := gen.Generate(second); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	rewritten := strings.Join(rewrittenFiles(t, tmpDir, since), ",")
+	if !strings.Contains(rewritten, "queries/useEvents.ts") {
+		t.Errorf("changed useEvents.ts was not rewritten (rewritten: %s)", rewritten)
+	}
+	if strings.Contains(rewritten, "useUsers.ts") {
+		t.Errorf("unchanged useUsers.ts was rewritten (rewritten: %s)", rewritten)
+	}
+	if fileExists(filepath.Join(gen.queriesDir, "useVenues.ts")) {
+		t.Error("stale useVenues.ts was not removed")
+	}
+	if !fileExists(stray) {
+		t.Error("hand-written customHelpers.ts was deleted on regenerate")
+	}
+}
+
+// TestIncremental_WithoutManifest upgrades output from before manifests
+// existed: stale generated files go, hand-written ones stay.
+func TestIncremental_WithoutManifest(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := manifestTestConfig(tmpDir)
+	cfg.DataLayer.Incremental = true
+	gen := NewAPIGenerator(cfg)
+	if err := os.MkdirAll(gen.outputDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	legacy := filepath.Join(gen.outputDir, "venues.ts")
+	writeTestFile(t, legacy, "/**\n * AUTO-GENERATED API - DO NOT EDIT\n */\n")
+	handWritten := filepath.Join(gen.outputDir, "client.ts")
+	writeTestFile(t, handWritten, "export const client = {};\n")
+
+	if err := gen.Generate([]ConvexFunction{{Name: "getEvent", Type: FunctionTypeQuery, Namespace: "events/eventQueries"}}); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if fileExists(legacy) {
+		t.Error("stale legacy venues.ts was not removed")
+	}
+	if !fileExists(handWritten) {
+		t.Error("hand-written client.ts was removed")
+	}
+	if !fileExists(filepath.Join(gen.outputDir, "events.ts")) {
+		t.Error("events.ts not generated")
+	}
+}
@@ -32,7 +32,7 @@ func (g *MetadataGenerator) Generate(tables []TableInfo) error {
 	content := g.generateMetadataContent(tables)
 
 	filePath := filepath.Join(g.outputDir, "schemaMetadata.ts")
-	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+	if err := writeIfChanged(filePath, []byte(content)); err != nil {
 		return fmt.Errorf("failed to write %s: %w", filePath, err)
 	}
 
@@ -148,5 +148,5 @@ func (g *MetadataGenerator) generateMetadataIndexFile() error {
 
 export * from './schemaMetadata';
 `
-	return os.WriteFile(filepath.Join(g.outputDir, "index.ts"), []byte(content), 0644)
+	return writeIfChanged(filepath.Join(g.outputDir, "index.ts"), []byte(content))
 }
@@ -30,6 +30,14 @@ type ConvexFunction struct {
 	// `shouldSkip` must be required when DataLayer.RequireAuthGatedShouldSkip
 	// is enabled.
 	RequiresAuth bool
+	// ReturnType is what the handler resolves to (e.g. `Doc<"events"> | null`),
+	// from its return-type annotation or a best-effort look at its return
+	// statements; "" when unknown. See inferReturnType.
+	ReturnType string
+	// ReturnsUntyped is true when a mutation or action has a `returns:`
+	// validator too complex to render; ReturnType is then "any" and the hook's
+	// JSDoc carries a TODO. See functionReturnType.
+	ReturnsUntyped bool
 }
 
 // ArgInfo represents a function argument
@@ -52,6 +60,10 @@ type FieldInfo struct {
 	IsArray   bool     // Is v.array(...)
 	ArrayType string   // For arrays, the inner type
 	Literals  []string // For v.union(v.literal(...)) enums
+	// LiteralUnion is set when every union member is a string v.literal(...),
+	// so the field can be emitted as a named enum type. Mixed unions such as
+	// v.union(v.literal("a"), v.null()) still populate Literals but not this.
+	LiteralUnion bool
 	// Nested holds the inner object fields when this field is a nested object
 	// (Type=="object") or an array-of-objects (ArrayType=="object"), so the
 	// OpenAPI surface can emit the inner `properties` instead of a bare object.
@@ -69,15 +81,19 @@ type TableInfo struct {
 
 // Parser extracts information from TypeScript files
 type Parser struct {
-	config         *Config
-	validatorCache map[string]string // Maps validator reference to its definition
+	config           *Config
+	validatorCache   map[string]string // Maps validator reference to its definition
+	functionSkips    *functionSkipper  // skip.functions; invalid patterns are reported by validateConfig
+	skippedFunctions int               // Functions excluded by skip.functions
 }
 
 // NewParser creates a new parser
 func NewParser(config *Config) *Parser {
+	functionSkips, _ := compileFunctionSkips(config.Skip.Functions)
 	return &Parser{
 		config:         config,
 		validatorCache: make(map[string]string),
+		functionSkips:  functionSkips,
 	}
 }
 
@@ -99,31 +115,57 @@ func (p *Parser) BuildValidatorCache(convexPath string) error {
 		return err
 	}
 
+	var files []string
 	for _, entry := range entries {
 		if entry.IsDir() && entry.Name() == "model" {
 			modelPath := convexPath + "/model"
-			p.scanValidatorDir(modelPath, validatorDefRe)
+			files = append(files, findValidatorFiles(modelPath)...)
 		}
 	}
 
+	// Reuse the persisted cache when no validator file has changed
+	cachePath := p.config.Cache.Validators
+	var hash string
+	if cachePath != "" {
+		hash = hashValidatorFiles(files)
+		if cached, ok := loadValidatorCache(cachePath, hash); ok {
+			debugf("validator cache: reused %d validators from %s", len(cached), cachePath)
+			p.validatorCache = cached
+			return nil
+		}
+		debugf("validator cache: %s missing or stale, rebuilding", cachePath)
+	}
+
+	for _, file := range files {
+		p.parseValidatorFile(file, validatorDefRe)
+	}
+	debugf("validator cache: %d validators from %d files", len(p.validatorCache), len(files))
+
+	if cachePath != "" {
+		// Best-effort: a cache that can't be written just means a rebuild next run
+		_ = saveValidatorCache(cachePath, hash, p.validatorCache)
+	}
+
 	return nil
 }
 
-// scanValidatorDir recursively scans a directory for validator definitions
-func (p *Parser) scanValidatorDir(dirPath string, validatorDefRe *regexp.Regexp) {
+// findValidatorFiles recursively collects validator files under a directory
+func findValidatorFiles(dirPath string) []string {
 	entries, err := os.ReadDir(dirPath)
 	if err != nil {
-		return
+		return nil
 	}
 
+	var files []string
 	for _, entry := range entries {
 		fullPath := dirPath + "/" + entry.Name()
 		if entry.IsDir() {
-			p.scanValidatorDir(fullPath, validatorDefRe)
+			files = append(files, findValidatorFiles(fullPath)...)
 		} else if strings.HasSuffix(entry.Name(), "validators.ts") || strings.HasSuffix(entry.Name(), "validator.ts") {
-			p.parseValidatorFile(fullPath, validatorDefRe)
+			files = append(files, fullPath)
 		}
 	}
+	return files
 }
 
 // parseValidatorFile extracts validator definitions from a file
@@ -153,6 +195,8 @@ func (p *Parser) parseValidatorFile(filePath string, validatorDefRe *regexp.Rege
 		validatorName := match[1]
 		validatorDef := match[2]
 
+		debugf("validator %s (namespace %q) from %s", validatorName, namespace, filePath)
+
 		// Store with full reference (e.g., "Issues.getIssueValidator")
 		if namespace != "" {
 			fullRef := namespace + "." + validatorName
@@ -174,16 +218,12 @@ var (
 	// Match re-export pattern: export { func1, func2 } from './path'
 	reExportRe = regexp.MustCompile(`export\s*\{([^}]+)\}\s*from\s*['"]([^'"]+)['"]`)
 
-	// Match args: { ... } in the function config
-	argsBlockRe = regexp.MustCompile(`args:\s*\{([^}]+)\}`)
+	// Match the opening of an inline args: { ... } block in the function config
+	argsBlockStartRe = regexp.MustCompile(`args:\s*\{`)
 
 	// Match args: SomeReference (e.g., args: Issues.getIssueValidator or args: getIssueValidator)
 	argsRefRe = regexp.MustCompile(`args:\s*(\w+(?:\.\w+)?),?\s*(?:\n|handler)`)
 
-	// Match individual arg: argName: v.something(...) OR argName: variableReference
-	// The second pattern captures validator references that don't start with v.
-	argRe = regexp.MustCompile(`(\w+):\s*(v\.[^,}]+|[a-zA-Z_]\w*)`)
-
 	// Match v.id("tableName")
 	idRe = regexp.MustCompile(`v\.id\(["'](\w+)["']\)`)
 
@@ -213,8 +253,8 @@ var (
 	// Match: export const NAME = IDENTIFIER (for fluent chain detection)
 	fluentExportRe = regexp.MustCompile(`export\s+const\s+(\w+)\s*=\s*(\w+)`)
 
-	// Match .input({ ... }) — for inline arg extraction
-	inputBlockRe = regexp.MustCompile(`\.input\(\s*\{([^}]+)\}`)
+	// Match the opening of .input({ ... }) — for inline arg extraction
+	inputBlockStartRe = regexp.MustCompile(`\.input\(\s*\{`)
 
 	// Match .input(validatorRef) — for referenced validators
 	inputRefRe = regexp.MustCompile(`\.input\(\s*(\w+(?:\.\w+)?)\s*\)`)
@@ -237,7 +277,17 @@ var (
 // matches. Used by both the standard and fluent-convex parsing paths so they
 // stay in sync — previously each path had its own copy of the v.object regex
 // and the fluent path was missing the plain-literal fallback entirely.
+//
+// Complete definitions (such as those cached from imported files) are cut at
+// the matching brace so nested objects survive; the regexes remain as the
+// fallback for definitions validatorDefRe already truncated.
 func extractValidatorArgsBlock(validatorDef string) string {
+	def := strings.TrimSpace(validatorDef)
+	if strings.HasPrefix(def, "v.object") || strings.HasPrefix(def, "{") {
+		if body := extractOuterBraceBody(def); body != "" {
+			return body
+		}
+	}
 	if m := validatorObjectInnerRe.FindStringSubmatch(validatorDef); m != nil {
 		return m[1]
 	}
@@ -282,18 +332,26 @@ func (p *Parser) ParseConvexFile(file ConvexFile) ([]ConvexFunction, error) {
 
 		// Skip internal functions
 		if internalFunctionRe.MatchString(fullMatch) {
+			debugf("%s: skipping internal function", file.Path)
 			continue
 		}
 
 		funcName := text[match[2]:match[3]]
 		funcType := FunctionType(text[match[4]:match[5]])
 
+		if p.skipFunction(file, funcName) {
+			continue
+		}
+
 		// Extract the function body (find matching parenthesis)
 		startIdx := match[1]
 		funcBody := extractFunctionBody(text[startIdx:])
 
 		// Parse arguments
 		args, isPaginated, useFunctionArgs := p.parseArgs(funcBody)
+		debugf("%s: %s %s.%s: %d args, paginated=%v, FunctionArgs=%v",
+			file.Path, funcType, file.Namespace, funcName, len(args), isPaginated, useFunctionArgs)
+		returnType, returnsUntyped := functionReturnType(funcType, returnsValidator(funcBody), funcBody, args)
 
 		functions = append(functions, ConvexFunction{
 			Name:            funcName,
@@ -304,6 +362,8 @@ func (p *Parser) ParseConvexFile(file ConvexFile) ([]ConvexFunction, error) {
 			IsPaginated:     isPaginated,
 			UseFunctionArgs: useFunctionArgs,
 			RequiresAuth:    funcType == FunctionTypeQuery && functionRequiresAuth(funcBody, p.config.DataLayer.AuthHelperNames),
+			ReturnType:      returnType,
+			ReturnsUntyped:  returnsUntyped,
 		})
 	}
 
@@ -313,6 +373,7 @@ func (p *Parser) ParseConvexFile(file ConvexFile) ([]ConvexFunction, error) {
 	// namespace so the generated API path matches the Convex API.
 	if len(functions) == 0 && reExportRe.MatchString(text) {
 		functions = p.parseReExports(file, text)
+		debugf("%s: resolved %d functions through re-exports", file.Path, len(functions))
 	}
 
 	return functions, nil
@@ -366,11 +427,19 @@ func (p *Parser) parseFluentConvexFile(file ConvexFile) ([]ConvexFunction, error
 
 		// Skip internal functions — only generate hooks for public ones
 		if isInternal {
+			debugf("%s: skipping internal function %s", file.Path, funcName)
+			continue
+		}
+
+		if p.skipFunction(file, funcName) {
 			continue
 		}
 
 		// Parse arguments from .input({...}) or .input(validatorRef)
 		args, isPaginated, useFunctionArgs := p.parseFluentArgs(chainText)
+		debugf("%s: %s %s.%s (fluent, root %s): %d args, paginated=%v, FunctionArgs=%v",
+			file.Path, funcType, file.Namespace, funcName, chainRoot, len(args), isPaginated, useFunctionArgs)
+		returnType, returnsUntyped := functionReturnType(FunctionType(funcType), fluentReturnsValidator(chainText), chainText, args)
 
 		functions = append(functions, ConvexFunction{
 			Name:            funcName,
@@ -381,6 +450,8 @@ func (p *Parser) parseFluentConvexFile(file ConvexFile) ([]ConvexFunction, error
 			IsPaginated:     isPaginated,
 			UseFunctionArgs: useFunctionArgs,
 			RequiresAuth:    FunctionType(funcType) == FunctionTypeQuery && functionRequiresAuth(chainText, p.config.DataLayer.AuthHelperNames),
+			ReturnType:      returnType,
+			ReturnsUntyped:  returnsUntyped,
 		})
 	}
 
@@ -459,8 +530,8 @@ func (p *Parser) parseFluentArgs(chainText string) ([]ArgInfo, bool, bool) {
 	var argsBlock string
 
 	// Try inline .input({ ... })
-	if inputMatch := inputBlockRe.FindStringSubmatch(chainText); inputMatch != nil {
-		argsBlock = inputMatch[1]
+	if loc := inputBlockStartRe.FindStringIndex(chainText); loc != nil {
+		argsBlock = extractOuterBraceBody(chainText[loc[1]-1:])
 	} else if refMatch := inputRefRe.FindStringSubmatch(chainText); refMatch != nil {
 		// Try .input(validatorRef)
 		validatorRef := strings.TrimSpace(refMatch[1])
@@ -477,7 +548,10 @@ func (p *Parser) parseFluentArgs(chainText string) ([]ArgInfo, bool, bool) {
 		}
 
 		if found {
+			debugf("input reference %s resolved from validator cache", validatorRef)
 			argsBlock = extractValidatorArgsBlock(validatorDef)
+		} else {
+			debugf("input reference %s not found in validator cache", validatorRef)
 		}
 	}
 
@@ -500,28 +574,34 @@ func (p *Parser) parseArgsBlock(argsBlock string) ([]ArgInfo, bool, bool) {
 		isPaginated = true
 	}
 
-	argMatches := argRe.FindAllStringSubmatch(argsBlock, -1)
-	for _, match := range argMatches {
-		argName := match[1]
-		argValidator := strings.TrimSpace(match[2])
+	for _, entry := range splitTopLevel(argsBlock, ',') {
+		entry = strings.TrimSpace(entry)
+		if strings.HasPrefix(entry, "...") {
+			// Spread of another validator's fields: the args can't be listed.
+			useFunctionArgs = true
+			continue
+		}
+		colonIdx := indexTopLevel(entry, ':')
+		if colonIdx == -1 {
+			continue
+		}
+		argName := strings.TrimSpace(entry[:colonIdx])
+		argValidator := strings.TrimSpace(entry[colonIdx+1:])
 
-		if argName == "paginationOpts" {
+		if argName == "paginationOpts" || !isValidIdentifier(argName) {
 			continue
 		}
 
 		arg := p.parseArgValidator(argName, argValidator)
 
 		if arg.Type == "unknown" {
+			debugf("arg %s: cannot type %q, falling back to FunctionArgs", argName, argValidator)
 			useFunctionArgs = true
 		}
 
 		args = append(args, arg)
 	}
 
-	if strings.Contains(argsBlock, "v.object(") || strings.Contains(argsBlock, "v.union(") {
-		useFunctionArgs = true
-	}
-
 	return args, isPaginated, useFunctionArgs
 }
 
@@ -553,6 +633,7 @@ func (p *Parser) parseReExports(file ConvexFile, text string) []ConvexFunction {
 		// Resolve the source path relative to the current file
 		sourceFilePath := p.resolveImportPath(file.Path, sourcePath)
 		if sourceFilePath == "" {
+			debugf("%s: re-export source %s did not resolve to a file", file.Path, sourcePath)
 			continue
 		}
 
@@ -571,7 +652,7 @@ func (p *Parser) parseReExports(file ConvexFile, text string) []ConvexFunction {
 			funcType := FunctionType(sourceText[fm[4]:fm[5]])
 
 			// Only include functions that are re-exported
-			if !exportedSet[funcName] {
+			if !exportedSet[funcName] || p.skipFunction(file, funcName) {
 				continue
 			}
 
@@ -579,6 +660,7 @@ func (p *Parser) parseReExports(file ConvexFile, text string) []ConvexFunction {
 			startIdx := fm[1]
 			funcBody := extractFunctionBody(sourceText[startIdx:])
 			args, isPaginated, useFunctionArgs := p.parseArgs(funcBody)
+			returnType, returnsUntyped := functionReturnType(funcType, returnsValidator(funcBody), funcBody, args)
 
 			// Use the re-exporting file's namespace, not the source file's
 			functions = append(functions, ConvexFunction{
@@ -590,6 +672,8 @@ func (p *Parser) parseReExports(file ConvexFile, text string) []ConvexFunction {
 				IsPaginated:     isPaginated,
 				UseFunctionArgs: useFunctionArgs,
 				RequiresAuth:    funcType == FunctionTypeQuery && functionRequiresAuth(funcBody, p.config.DataLayer.AuthHelperNames),
+				ReturnType:      returnType,
+				ReturnsUntyped:  returnsUntyped,
 			})
 		}
 	}
@@ -693,11 +777,10 @@ func (p *Parser) parseArgs(funcBody string) ([]ArgInfo, bool, bool) {
 	}
 
 	// First try to find inline args block: args: { ... }
-	argsMatch := argsBlockRe.FindStringSubmatch(funcBody)
 	var argsBlock string
 
-	if argsMatch != nil {
-		argsBlock = argsMatch[1]
+	if loc := argsBlockStartRe.FindStringIndex(funcBody); loc != nil {
+		argsBlock = extractOuterBraceBody(funcBody[loc[1]-1:])
 	} else if refMatch := argsRefRe.FindStringSubmatch(funcBody); refMatch != nil {
 		// Try to find referenced validator: args: SomeValidator or args: Module.validatorName
 		validatorRef := strings.TrimSpace(refMatch[1])
@@ -715,7 +798,10 @@ func (p *Parser) parseArgs(funcBody string) ([]ArgInfo, bool, bool) {
 		}
 
 		if found {
+			debugf("args reference %s resolved from validator cache", validatorRef)
 			argsBlock = extractValidatorArgsBlock(validatorDef)
+		} else {
+			debugf("args reference %s not found in validator cache", validatorRef)
 		}
 	}
 
@@ -723,32 +809,7 @@ func (p *Parser) parseArgs(funcBody string) ([]ArgInfo, bool, bool) {
 		return args, isPaginated, useFunctionArgs
 	}
 
-	// Parse each argument
-	argMatches := argRe.FindAllStringSubmatch(argsBlock, -1)
-	for _, match := range argMatches {
-		argName := match[1]
-		argValidator := strings.TrimSpace(match[2])
-
-		// Skip pagination opts
-		if argName == "paginationOpts" {
-			continue
-		}
-
-		arg := p.parseArgValidator(argName, argValidator)
-
-		// Check if we need FunctionArgs
-		if arg.Type == "unknown" {
-			useFunctionArgs = true
-		}
-
-		args = append(args, arg)
-	}
-
-	// If args block contains complex patterns, use FunctionArgs
-	if strings.Contains(argsBlock, "v.object(") || strings.Contains(argsBlock, "v.union(") {
-		useFunctionArgs = true
-	}
-
+	args, _, useFunctionArgs = p.parseArgsBlock(argsBlock)
 	return args, isPaginated, useFunctionArgs
 }
 
@@ -760,10 +821,24 @@ func (p *Parser) parseArgValidator(name, validator string) ArgInfo {
 	}
 
 	// Check for optional patterns first
-	if strings.HasPrefix(validator, "v.optional(") {
+	inner := validator
+	if optInner, ok := validatorCallArgs("v.optional", validator); ok {
+		arg.Optional = true
+		inner = strings.TrimSpace(optInner)
+	} else if strings.HasPrefix(validator, "v.optional(") {
 		arg.Optional = true
 	}
 
+	// Unions, objects, records and literals go straight to the recursive
+	// renderer: the ID regexes below are unanchored and would otherwise
+	// match a v.id(...) nested inside them.
+	if isCompoundValidator(inner) {
+		if ts, ok := validatorTSType(inner); ok {
+			arg.Type = ts
+		}
+		return arg
+	}
+
 	// Check v.optional(v.array(v.id("table")))
 	if match := optionalArrayIdRe.FindStringSubmatch(validator); match != nil {
 		arg.Type = "Id<\"" + match[1] + "\">[]"
@@ -820,9 +895,30 @@ func (p *Parser) parseArgValidator(name, validator string) ArgInfo {
 		return arg
 	}
 
+	// Anything else the recursive renderer can express (v.null(), v.int64(),
+	// arrays of other validators, ...). Left as "unknown" otherwise, which
+	// makes the hook fall back to FunctionArgs.
+	if ts, ok := validatorTSType(inner); ok {
+		arg.Type = ts
+	}
+
 	return arg
 }
 
+// isCompoundValidator reports whether validator is a union, object, record or
+// literal, or an array of one of those.
+func isCompoundValidator(validator string) bool {
+	for _, name := range []string{"v.union", "v.object", "v.record", "v.literal"} {
+		if _, ok := validatorCallArgs(name, validator); ok {
+			return true
+		}
+	}
+	if elem, ok := validatorCallArgs("v.array", validator); ok {
+		return isCompoundValidator(strings.TrimSpace(elem))
+	}
+	return false
+}
+
 // ParseSchemaFile extracts table definitions from a schema file
 func (p *Parser) ParseSchemaFile(file SchemaFile) ([]TableInfo, error) {
 	content, err := os.ReadFile(file.Path)
@@ -1318,6 +1414,26 @@ func parseObjectBody(validator string) []FieldInfo {
 	return parseTableFields(inner)
 }
 
+// literalMemberRe matches a union member that is exactly one string literal.
+var literalMemberRe = regexp.MustCompile(`^v\.literal\(\s*(?:"[^"]*"|'[^']*')\s*\)$`)
+
+// isLiteralUnion reports whether every member of a v.union(...) argument list
+// is a string v.literal(...).
+func isLiteralUnion(inner string) bool {
+	members := 0
+	for _, m := range splitFieldEntries(inner) {
+		m = strings.TrimSpace(m)
+		if m == "" {
+			continue
+		}
+		if !literalMemberRe.MatchString(m) {
+			return false
+		}
+		members++
+	}
+	return members > 0
+}
+
 // classifyValidator determines the type information for a validator expression.
 func classifyValidator(name, validator string) FieldInfo {
 	field := FieldInfo{Name: name, Type: "any"}
@@ -1419,6 +1535,7 @@ func classifyValidator(name, validator string) FieldInfo {
 			for _, m := range litMatches {
 				field.Literals = append(field.Literals, m[1])
 			}
+			field.LiteralUnion = isLiteralUnion(inner)
 		}
 		// Check if it's a union of IDs
 		idMatches := idRe.FindAllStringSubmatch(inner, -1)
@@ -288,3 +288,142 @@ const eventAttendees = defineTable({
 		t.Errorf("eventAttendees fields: got %v, want %v", got, want)
 	}
 }
+
+// TestExtractAllTableFields_LiteralUnion checks that a status-enum field is
+// captured as a literal union, while mixed unions keep their literals but are
+// not treated as enums.
+func TestExtractAllTableFields_LiteralUnion(t *testing.T) {
+	text := `
+const events = defineTable({
+  title: v.string(),
+  status: v.union(v.literal("draft"), v.literal("published"), v.literal("archived")),
+  visibility: v.optional(v.union(v.literal('public'), v.literal('private'))),
+  cancelReason: v.union(v.literal("weather"), v.null()),
+});
+`
+	p := NewParser(&Config{})
+	fields := p.extractAllTableFields(text)["events"]
+	byName := make(map[string]FieldInfo)
+	for _, f := range fields {
+		byName[f.Name] = f
+	}
+
+	status := byName["status"]
+	if !status.LiteralUnion || !reflect.DeepEqual(status.Literals, []string{"draft", "published", "archived"}) {
+		t.Errorf("status: got LiteralUnion=%v Literals=%v", status.LiteralUnion, status.Literals)
+	}
+	visibility := byName["visibility"]
+	if !visibility.LiteralUnion || !visibility.Optional || !reflect.DeepEqual(visibility.Literals, []string{"public", "private"}) {
+		t.Errorf("visibility: got %+v", visibility)
+	}
+	if byName["cancelReason"].LiteralUnion {
+		t.Error("cancelReason: a union with v.null() must not be a literal union")
+	}
+	if byName["title"].LiteralUnion {
+		t.Error("title: plain string must not be a literal union")
+	}
+}
+
+func TestParseArgValidator_UnionOfLiterals(t *testing.T) {
+	p := NewParser(&Config{})
+	tests := []struct {
+		validator    string
+		wantType     string
+		wantOptional bool
+	}{
+		{`v.union(v.literal("draft"), v.literal("published"))`, `"draft" | "published"`, false},
+		{`v.optional(v.union(v.literal('a'), v.literal('b')))`, `"a" | "b"`, true},
+		{`v.union(v.literal(1), v.literal(true), v.null())`, `1 | true | null`, false},
+		{`v.union(v.id("users"), v.id("teams"))`, `Id<"users"> | Id<"teams">`, false},
+		{`v.array(v.union(v.literal("x"), v.literal("y")))`, `("x" | "y")[]`, false},
+		{`v.literal("only, one")`, `"only, one"`, false},
+	}
+	for _, tt := range tests {
+		arg := p.parseArgValidator("status", tt.validator)
+		if arg.Type != tt.wantType || arg.Optional != tt.wantOptional {
+			t.Errorf("parseArgValidator(%s) = {Type: %s, Optional: %v}, want {Type: %s, Optional: %v}",
+				tt.validator, arg.Type, arg.Optional, tt.wantType, tt.wantOptional)
+		}
+		if arg.IsID {
+			t.Errorf("parseArgValidator(%s): a union must not be treated as a single ID", tt.validator)
+		}
+	}
+}
+
+func TestParseArgValidator_NestedObject(t *testing.T) {
+	p := NewParser(&Config{})
+	tests := []struct {
+		validator string
+		wantType  string
+	}{
+		{
+			`v.object({ userId: v.id("users"), limit: v.optional(v.number()) })`,
+			`{ userId: Id<"users">; limit?: number }`,
+		},
+		{
+			`v.object({
+        range: v.object({ start: v.number(), end: v.number() }),
+        tags: v.array(v.string()),
+      })`,
+			`{ range: { start: number; end: number }; tags: string[] }`,
+		},
+		{
+			`v.array(v.object({ kind: v.union(v.literal("a"), v.literal("b")) }))`,
+			`{ kind: "a" | "b" }[]`,
+		},
+		{`v.record(v.string(), v.number())`, `Record<string, number>`},
+	}
+	for _, tt := range tests {
+		if arg := p.parseArgValidator("filter", tt.validator); arg.Type != tt.wantType {
+			t.Errorf("parseArgValidator(%s).Type = %s, want %s", tt.validator, arg.Type, tt.wantType)
+		}
+	}
+
+	// Anything the renderer can't express exactly stays unknown so the hook
+	// falls back to FunctionArgs.
+	for _, validator := range []string{
+		`v.object({ profile: profileValidator })`,
+		`v.union(v.literal("a"), someHelper())`,
+		`v.object({ ...baseFields, extra: v.string() })`,
+	} {
+		if arg := p.parseArgValidator("filter", validator); arg.Type != "unknown" {
+			t.Errorf("parseArgValidator(%s).Type = %s, want unknown", validator, arg.Type)
+		}
+	}
+}
+
+// TestParseArgs_NestedBlock checks that nested v.object args are read as one
+// argument (the old regex split the block at the first `}` and at every
+// comma) and that a fully typed block no longer forces FunctionArgs.
+func TestParseArgs_NestedBlock(t *testing.T) {
+	p := NewParser(&Config{})
+	body := `{
+  args: {
+    communityId: v.id("communities"),
+    filter: v.object({ status: v.union(v.literal("open"), v.literal("closed")), from: v.number() }),
+    limit: v.optional(v.number()),
+  },
+  handler: async (ctx, args) => {},
+}`
+	args, _, useFunctionArgs := p.parseArgs(body)
+	if useFunctionArgs {
+		t.Error("useFunctionArgs = true, want false for a fully parseable block")
+	}
+	var got []string
+	for _, a := range args {
+		got = append(got, a.Name+": "+a.Type)
+	}
+	want := []string{
+		`communityId: Id<"communities">`,
+		`filter: { status: "open" | "closed"; from: number }`,
+		`limit: number`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("args = %q, want %q", got, want)
+	}
+
+	_, _, useFunctionArgs = p.parseArgs(`{ args: { profile: profileValidator }, handler: async () => {} }`)
+	if !useFunctionArgs {
+		t.Error("useFunctionArgs = false, want true for an unresolvable validator reference")
+	}
+}
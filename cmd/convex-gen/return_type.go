@@ -0,0 +1,169 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// handlerParamsRe finds the opening paren of a handler's parameter list in
+	// its three spellings: `handler: async (ctx, args)`, `async handler(ctx,
+	// args)` and fluent-convex's `.handler(async (ctx, args)`.
+	handlerParamsRe = regexp.MustCompile(`\bhandler\s*(?::\s*(?:async\s*)?|\(\s*(?:async\s*)?)?\(`)
+
+	returnStmtRe = regexp.MustCompile(`\breturn\b`)
+
+	// Return expressions whose type follows from the ctx.db call alone.
+	returnInsertRe = regexp.MustCompile(`^return\s+(?:await\s+)?ctx\.db\s*\.insert\(\s*["'](\w+)["']`)
+	returnQueryRe  = regexp.MustCompile(`^return\s+(?:await\s+)?ctx\.db\s*\.query\(\s*["'](\w+)["']\s*\)[^;]*?\.(collect|take|first|unique)\(`)
+	returnGetRe    = regexp.MustCompile(`^return\s+(?:await\s+)?ctx\.db\s*\.get\(\s*args\.(\w+)\s*\)`)
+
+	// fluentReturnsRe finds fluent-convex's .returns( call in a builder chain.
+	fluentReturnsRe = regexp.MustCompile(`\.returns\s*\(`)
+)
+
+// returnsValidator returns the `returns:` validator of a function config
+// object such as `{ args: {...}, returns: v.id("events"), handler: ... }`,
+// or "" when the config has none. Only top-level keys count, so a `returns`
+// field inside args or the handler is ignored.
+func returnsValidator(funcBody string) string {
+	for _, entry := range splitTopLevel(extractOuterBraceBody(funcBody), ',') {
+		key, value, ok := strings.Cut(entry, ":")
+		if !ok {
+			continue
+		}
+		if strings.TrimSpace(key) == "returns" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// fluentReturnsValidator returns the validator passed to .returns(...) in a
+// fluent-convex chain, or "".
+func fluentReturnsValidator(chainText string) string {
+	loc := fluentReturnsRe.FindStringIndex(chainText)
+	if loc == nil {
+		return ""
+	}
+	rest := chainText[loc[1]:]
+	inner := extractFunctionBody(rest)
+	if len(inner) == len(rest) {
+		return ""
+	}
+	return strings.TrimSpace(inner)
+}
+
+// functionReturnType decides a function's ReturnType. A mutation or action
+// that declares a returns validator gets the validator's type, which wins
+// over anything inferred from the handler; untyped is true when the
+// validator is too complex to render (a reference, a helper call) and the
+// type falls back to "any". Everything else is inferred from the handler.
+func functionReturnType(fnType FunctionType, validator, funcBody string, args []ArgInfo) (returnType string, untyped bool) {
+	if validator != "" && (fnType == FunctionTypeMutation || fnType == FunctionTypeAction) {
+		if ts, ok := validatorTSType(validator); ok {
+			return ts, false
+		}
+		return "any", true
+	}
+	return inferReturnType(funcBody, args), false
+}
+
+// inferReturnType makes a best-effort guess at what a function's handler
+// resolves to, for the generated hook's @returns doc. An explicit return-type
+// annotation on the handler wins, with any Promise<> unwrapped. Otherwise
+// every return statement must be a recognizable ctx.db call (insert, get by
+// an ID arg, or a query ending in collect/take/first/unique) and all must
+// agree. Returns "" when the type can't be inferred.
+func inferReturnType(funcBody string, args []ArgInfo) string {
+	loc := handlerParamsRe.FindStringIndex(funcBody)
+	if loc == nil {
+		return ""
+	}
+	rest := funcBody[loc[1]:]
+	params := extractFunctionBody(rest)
+	if len(params) == len(rest) {
+		return ""
+	}
+	rest = strings.TrimLeft(rest[len(params)+1:], " \t\r\n")
+
+	if strings.HasPrefix(rest, ":") {
+		return unwrapPromise(strings.TrimSpace(handlerReturnAnnotation(rest[1:])))
+	}
+	return inferFromReturns(rest, args)
+}
+
+// handlerReturnAnnotation returns the type annotation at the start of text,
+// which ends at the arrow of an arrow function or the body of a method.
+func handlerReturnAnnotation(text string) string {
+	depth := 0
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '=':
+			if i+1 < len(text) && text[i+1] == '>' {
+				if depth == 0 {
+					return text[:i]
+				}
+				i++ // a function type's arrow inside the annotation
+			}
+		case '<', '(', '[':
+			depth++
+		case '>', ')', ']':
+			depth--
+		case '{':
+			if depth == 0 && strings.TrimSpace(text[:i]) != "" {
+				return text[:i]
+			}
+			depth++
+		case '}':
+			depth--
+		}
+	}
+	return ""
+}
+
+// unwrapPromise returns T for Promise<T>, and anything else unchanged.
+func unwrapPromise(typ string) string {
+	if strings.HasPrefix(typ, "Promise<") && strings.HasSuffix(typ, ">") {
+		return strings.TrimSpace(typ[len("Promise<") : len(typ)-1])
+	}
+	return typ
+}
+
+// inferFromReturns infers the type of a handler body from its return
+// statements, or returns "" unless each one maps to the same type.
+func inferFromReturns(body string, args []ArgInfo) string {
+	idTables := make(map[string]string)
+	for _, arg := range args {
+		if arg.IsID && !arg.IsArrayID {
+			idTables[arg.Name] = arg.TableName
+		}
+	}
+
+	inferred := ""
+	for _, loc := range returnStmtRe.FindAllStringIndex(body, -1) {
+		typ := returnExpressionType(body[loc[0]:], idTables)
+		if typ == "" || (inferred != "" && typ != inferred) {
+			return ""
+		}
+		inferred = typ
+	}
+	return inferred
+}
+
+// returnExpressionType maps one return statement to its type, or "".
+func returnExpressionType(stmt string, idTables map[string]string) string {
+	if m := returnInsertRe.FindStringSubmatch(stmt); m != nil {
+		return `Id<"` + m[1] + `">`
+	}
+	if m := returnQueryRe.FindStringSubmatch(stmt); m != nil {
+		if m[2] == "collect" || m[2] == "take" {
+			return `Doc<"` + m[1] + `">[]`
+		}
+		return `Doc<"` + m[1] + `"> | null`
+	}
+	if m := returnGetRe.FindStringSubmatch(stmt); m != nil && idTables[m[1]] != "" {
+		return `Doc<"` + idTables[m[1]] + `"> | null`
+	}
+	return ""
+}
@@ -56,14 +56,14 @@ func (g *TerraformGenerator) Generate(tables []TableInfo) error {
 		resolved = append(resolved, r)
 
 		// Write the curated Api.ts at the overlay's module path.
-		apiPath := filepath.Join(g.config.Convex.Path, rs.Module)
+		apiPath := g.config.outputPath(filepath.Join(g.config.Convex.Path, rs.Module))
 		if err := writeGeneratedFile(apiPath, EmitApiTS(r)); err != nil {
 			return err
 		}
 
 		// Write the wire types to <domain>/types/<lcSingular>Api.types.ts so the
 		// type exports live under types/ (SRP gate).
-		typesPath := filepath.Join(g.config.Convex.Path, apiTypesFileRelPath(r))
+		typesPath := g.config.outputPath(filepath.Join(g.config.Convex.Path, apiTypesFileRelPath(r)))
 		if err := writeGeneratedFile(typesPath, EmitApiTypesTS(r)); err != nil {
 			return err
 		}
@@ -73,7 +73,7 @@ func (g *TerraformGenerator) Generate(tables []TableInfo) error {
 		// override disambiguates two resources sharing one table, the file is keyed
 		// on the singular symbol base (<lcSingular>Routes.ts) so the two routes
 		// files do not collide on the shared table name.
-		routesPath := filepath.Join(g.config.Convex.Path, "api", routesFileBasename(r))
+		routesPath := g.config.outputPath(filepath.Join(g.config.Convex.Path, "api", routesFileBasename(r)))
 		if err := writeGeneratedFile(routesPath, EmitRoutesTS(r)); err != nil {
 			return err
 		}
@@ -89,7 +89,7 @@ func (g *TerraformGenerator) Generate(tables []TableInfo) error {
 
 	// generator_config.yml for the HashiCorp codegen tools, written beside the
 	// OpenAPI spec output so the provider repo can consume both together.
-	cfgYML := filepath.Join(g.config.OpenAPI.OutputDir, "generator_config.yml")
+	cfgYML := g.config.outputPath(filepath.Join(g.config.OpenAPI.OutputDir, "generator_config.yml"))
 	if err := writeGeneratedFile(cfgYML, EmitGeneratorConfig(resolved)); err != nil {
 		return err
 	}
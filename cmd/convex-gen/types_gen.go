@@ -32,7 +32,7 @@ func (g *TypesGenerator) Generate(tables []TableInfo) error {
 	content := g.generateTypesContent(tables)
 
 	filePath := filepath.Join(g.outputDir, "convex.ts")
-	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+	if err := writeIfChanged(filePath, []byte(content)); err != nil {
 		return fmt.Errorf("failed to write %s: %w", filePath, err)
 	}
 
@@ -63,6 +63,7 @@ func (g *TypesGenerator) generateTypesContent(tables []TableInfo) string {
 	sb.WriteString(" * - Use Doc<\"tableName\"> for document types\n")
 	sb.WriteString(" * - Use Id<\"tableName\"> for ID types\n")
 	sb.WriteString(" * - Use derived types for specific fields\n")
+	sb.WriteString(" * - Use <Table><Field> types for literal-union (enum) fields\n")
 	sb.WriteString(" */\n\n")
 
 	// Imports
@@ -70,6 +71,25 @@ func (g *TypesGenerator) generateTypesContent(tables []TableInfo) string {
 	sb.WriteString("// Re-export Doc and Id types so they can be imported from this file\n")
 	sb.WriteString("export type { Doc, Id };\n\n")
 
+	enums := collectFieldEnums(tables)
+
+	// Field enum types section
+	if len(enums) > 0 {
+		sb.WriteString("// ============================================================================\n")
+		sb.WriteString("// FIELD ENUM TYPES\n")
+		sb.WriteString("// ============================================================================\n\n")
+
+		for _, e := range enums {
+			quoted := make([]string, len(e.Values))
+			for i, v := range e.Values {
+				quoted[i] = fmt.Sprintf("%q", v)
+			}
+			fmt.Fprintf(&sb, "/** Values of %s.%s */\n", e.Table, e.Field)
+			fmt.Fprintf(&sb, "export const %sValues = [%s] as const;\n", e.TypeName, strings.Join(quoted, ", "))
+			fmt.Fprintf(&sb, "export type %s = (typeof %sValues)[number];\n\n", e.TypeName, e.TypeName)
+		}
+	}
+
 	// Table document types section
 	sb.WriteString("// ============================================================================\n")
 	sb.WriteString("// TABLE DOCUMENT TYPES\n")
@@ -77,7 +97,29 @@ func (g *TypesGenerator) generateTypesContent(tables []TableInfo) string {
 
 	for _, table := range tables {
 		fmt.Fprintf(&sb, "/** %s table */\n", table.Name)
-		fmt.Fprintf(&sb, "export type %s = Doc<\"%s\">;\n\n", table.TypeName, table.Name)
+		var tableEnums []fieldEnum
+		for _, e := range enums {
+			if e.Table == table.Name {
+				tableEnums = append(tableEnums, e)
+			}
+		}
+		if len(tableEnums) == 0 {
+			fmt.Fprintf(&sb, "export type %s = Doc<\"%s\">;\n\n", table.TypeName, table.Name)
+			continue
+		}
+		omitted := make([]string, len(tableEnums))
+		for i, e := range tableEnums {
+			omitted[i] = fmt.Sprintf("\"%s\"", e.Field)
+		}
+		fmt.Fprintf(&sb, "export type %s = Omit<Doc<\"%s\">, %s> & {\n", table.TypeName, table.Name, strings.Join(omitted, " | "))
+		for _, e := range tableEnums {
+			optional := ""
+			if e.Optional {
+				optional = "?"
+			}
+			fmt.Fprintf(&sb, "  %s%s: %s;\n", e.Field, optional, e.TypeName)
+		}
+		sb.WriteString("};\n\n")
 	}
 
 	// Table ID types section
@@ -132,6 +174,49 @@ func (g *TypesGenerator) generateTypesContent(tables []TableInfo) string {
 	return sb.String()
 }
 
+// fieldEnum is a named type generated for a table field whose validator is a
+// union of string literals.
+type fieldEnum struct {
+	Table    string   // Table name in schema
+	Field    string   // Field name
+	TypeName string   // Exported TS type name, e.g. EventsStatus
+	Optional bool     // Field is wrapped in v.optional()
+	Values   []string // Literal values in declaration order
+}
+
+// collectFieldEnums returns one fieldEnum per literal-union field across
+// tables, in table then field order. Names are <TableType><Field>; a name that
+// would shadow another generated type gets an "Enum" suffix.
+func collectFieldEnums(tables []TableInfo) []fieldEnum {
+	taken := map[string]bool{"Doc": true, "Id": true, "TableName": true, "EntityType": true}
+	for _, table := range tables {
+		taken[table.TypeName] = true
+		taken[table.TypeName+"Id"] = true
+	}
+
+	var enums []fieldEnum
+	for _, table := range tables {
+		for _, field := range table.Fields {
+			if !field.LiteralUnion || len(field.Literals) == 0 {
+				continue
+			}
+			name := table.TypeName + toPascalCase(field.Name)
+			if taken[name] {
+				name += "Enum"
+			}
+			taken[name] = true
+			enums = append(enums, fieldEnum{
+				Table:    table.Name,
+				Field:    field.Name,
+				TypeName: name,
+				Optional: field.Optional,
+				Values:   field.Literals,
+			})
+		}
+	}
+	return enums
+}
+
 // generateTypesIndexFile creates index.ts barrel export for types
 func (g *TypesGenerator) generateTypesIndexFile() error {
 	content := `/**
@@ -144,7 +229,7 @@ func (g *TypesGenerator) generateTypesIndexFile() error {
 
 export * from './convex';
 `
-	return os.WriteFile(filepath.Join(g.outputDir, "index.ts"), []byte(content), 0644)
+	return writeIfChanged(filepath.Join(g.outputDir, "index.ts"), []byte(content))
 }
 
 // toSingular converts a plural table name to singular form
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateTypesContent_FieldEnums(t *testing.T) {
+	tables := []TableInfo{
+		{
+			Name:     "events",
+			TypeName: "Events",
+			Fields: []FieldInfo{
+				{Name: "title", Type: "string"},
+				{Name: "status", Type: "union", LiteralUnion: true, Literals: []string{"draft", "published"}},
+				{Name: "visibility", Type: "union", Optional: true, LiteralUnion: true, Literals: []string{"public", "private"}},
+				{Name: "cancelReason", Type: "union", Literals: []string{"weather"}},
+			},
+		},
+		{Name: "users", TypeName: "Users", Fields: []FieldInfo{{Name: "name", Type: "string"}}},
+	}
+
+	g := &TypesGenerator{config: &Config{Imports: ImportsConfig{DataModel: "@org/backend/dataModel"}}}
+	got := g.generateTypesContent(tables)
+
+	for _, want := range []string{
+		"export const EventsStatusValues = [\"draft\", \"published\"] as const;\n",
+		"export type EventsStatus = (typeof EventsStatusValues)[number];\n",
+		"export type EventsVisibility = (typeof EventsVisibilityValues)[number];\n",
+		"export type Events = Omit<Doc<\"events\">, \"status\" | \"visibility\"> & {\n  status: EventsStatus;\n  visibility?: EventsVisibility;\n};\n",
+		"export type Users = Doc<\"users\">;\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("types output missing %q\n\ngot:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "EventsCancelReason") {
+		t.Error("mixed union cancelReason must not get an enum type")
+	}
+}
+
+func TestCollectFieldEnums_AvoidsNameCollisions(t *testing.T) {
+	tables := []TableInfo{
+		{Name: "users", TypeName: "Users", Fields: []FieldInfo{
+			{Name: "role", LiteralUnion: true, Literals: []string{"admin", "member"}},
+		}},
+		{Name: "usersRole", TypeName: "UsersRole"},
+	}
+	enums := collectFieldEnums(tables)
+	if len(enums) != 1 || enums[0].TypeName != "UsersRoleEnum" {
+		t.Errorf("enums = %+v, want a single UsersRoleEnum", enums)
+	}
+}
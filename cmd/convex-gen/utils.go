@@ -1,10 +1,12 @@
 package main
 
 import (
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 )
 
@@ -15,25 +17,182 @@ func jsonMarshalString(s string) (string, error) {
 	return string(b), err
 }
 
-// cleanDirectory removes all .ts files from a directory
+// manifestFileName lists the files convex-gen wrote to an output directory,
+// so the next run removes exactly those and never a hand-written file that
+// happens to share the folder.
+const manifestFileName = ".convex-gen-manifest.json"
+
+// generatedManifest is the on-disk form of manifestFileName.
+type generatedManifest struct {
+	Files []string `json:"files"` // file names relative to the directory, e.g. "useEvents.ts"
+}
+
+// cleanDirectory removes the files a previous run generated in dir, as listed
+// in its manifest. Output from before manifests existed has none; then only
+// .ts files carrying the generated "DO NOT EDIT" header are removed.
 func cleanDirectory(dir string) error {
+	previous, err := previousGeneratedFiles(dir)
+	if err != nil {
+		return err
+	}
+	return removeFiles(dir, previous, nil)
+}
+
+// previousGeneratedFiles returns the names of the files a previous run
+// generated in dir: its manifest entries, or for output from before manifests
+// existed, the .ts files carrying the generated "DO NOT EDIT" header.
+func previousGeneratedFiles(dir string) ([]string, error) {
+	data, err := os.ReadFile(filepath.Join(dir, manifestFileName))
+	if os.IsNotExist(err) {
+		return legacyGeneratedFiles(dir)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest generatedManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %w", filepath.Join(dir, manifestFileName), err)
+	}
+	var files []string
+	for _, name := range manifest.Files {
+		// Never follow a manifest entry out of dir
+		if name == "" || name != filepath.Base(name) || name == "." || name == ".." {
+			continue
+		}
+		files = append(files, name)
+	}
+	return files, nil
+}
+
+// removeFiles removes the named files from dir, except those in keep.
+func removeFiles(dir string, names []string, keep map[string]bool) error {
+	for _, name := range names {
+		if keep[name] {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, name)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// legacyGeneratedFiles lists the generated .ts files in a directory written
+// without a manifest, identified by the "DO NOT EDIT" marker in their header.
+func legacyGeneratedFiles(dir string) ([]string, error) {
 	entries, err := os.ReadDir(dir)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return nil
+			return nil, nil
 		}
-		return err
+		return nil, err
 	}
 
+	var files []string
 	for _, entry := range entries {
-		if strings.HasSuffix(entry.Name(), ".ts") {
-			if err := os.Remove(filepath.Join(dir, entry.Name())); err != nil {
-				return err
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".ts") {
+			continue
+		}
+		if isGeneratedFile(filepath.Join(dir, entry.Name())) {
+			files = append(files, entry.Name())
+		}
+	}
+
+	return files, nil
+}
+
+// isGeneratedFile reports whether path starts with a header convex-gen writes.
+func isGeneratedFile(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	head := make([]byte, 1024)
+	n, _ := f.Read(head)
+	text := string(head[:n])
+	return strings.Contains(text, "DO NOT EDIT") || strings.HasPrefix(text, "// No files generated")
+}
+
+// manifestEntries returns the manifest file list for files (names without
+// the .ts extension): each as a .ts file plus index.ts, sorted.
+func manifestEntries(files []string) []string {
+	entries := make([]string, 0, len(files)+1)
+	for _, file := range files {
+		entries = append(entries, file+".ts")
+	}
+	entries = append(entries, "index.ts")
+	sort.Strings(entries)
+	return uniqueStrings(entries)
+}
+
+// writeManifest records the generated files in dir: each entry of files (a
+// name without the .ts extension) plus index.ts. The hooks root passes no
+// files, so its manifest tracks just the root index.ts.
+func writeManifest(dir string, files []string) error {
+	data, err := json.MarshalIndent(generatedManifest{Files: manifestEntries(files)}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeIfChanged(filepath.Join(dir, manifestFileName), append(data, '\n'))
+}
+
+// outputCleaner removes a previous run's generated files from output
+// directories. By default every one goes before regenerating. In incremental
+// mode the rest of the run writes over the old files instead, and finish
+// removes only those the run no longer produces, so unchanged files keep
+// their mtimes.
+type outputCleaner struct {
+	incremental bool
+	previous    map[string][]string // dir -> files generated there by the previous run
+}
+
+// newOutputCleaner prepares dirs for regeneration: it cleans them, or in
+// incremental mode records what they hold for finish.
+func newOutputCleaner(incremental bool, dirs ...string) (*outputCleaner, error) {
+	c := &outputCleaner{incremental: incremental, previous: make(map[string][]string)}
+	for _, dir := range dirs {
+		if !incremental {
+			if err := cleanDirectory(dir); err != nil {
+				return nil, err
 			}
+			continue
 		}
+		previous, err := previousGeneratedFiles(dir)
+		if err != nil {
+			return nil, err
+		}
+		c.previous[dir] = previous
 	}
+	return c, nil
+}
 
-	return nil
+// finish writes dir's manifest for files and, in incremental mode, removes
+// the previously generated files that are not among them.
+func (c *outputCleaner) finish(dir string, files []string) error {
+	if err := writeManifest(dir, files); err != nil {
+		return err
+	}
+	if !c.incremental {
+		return nil
+	}
+	keep := map[string]bool{manifestFileName: true}
+	for _, name := range manifestEntries(files) {
+		keep[name] = true
+	}
+	return removeFiles(dir, c.previous[dir], keep)
+}
+
+// writeIfChanged writes content to path unless the file already holds
+// content with the same hash, so regenerating unchanged output leaves the
+// file and its mtime alone.
+func writeIfChanged(path string, content []byte) error {
+	if existing, err := os.ReadFile(path); err == nil && sha256.Sum256(existing) == sha256.Sum256(content) {
+		return nil
+	}
+	return os.WriteFile(path, content, 0644)
 }
 
 // generateIndexFile creates index.ts barrel export
@@ -41,7 +200,7 @@ func generateIndexFile(dir string, files []string) error {
 	if len(files) == 0 {
 		// Create empty index
 		content := "// No files generated\nexport {};\n"
-		return os.WriteFile(filepath.Join(dir, "index.ts"), []byte(content), 0644)
+		return writeIfChanged(filepath.Join(dir, "index.ts"), []byte(content))
 	}
 
 	var sb strings.Builder
@@ -53,7 +212,7 @@ func generateIndexFile(dir string, files []string) error {
 		fmt.Fprintf(&sb, "export * from './%s';\n", file)
 	}
 
-	return os.WriteFile(filepath.Join(dir, "index.ts"), []byte(sb.String()), 0644)
+	return writeIfChanged(filepath.Join(dir, "index.ts"), []byte(sb.String()))
 }
 
 // toSnakeCase converts camelCase/PascalCase to snake_case (forSale → for_sale).
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// validatorCacheVersion is mixed into the cache key. Bump it whenever the
+// validator extraction logic changes so stale caches are rebuilt.
+const validatorCacheVersion = "1"
+
+// validatorCacheFile is the on-disk form of Parser.validatorCache.
+type validatorCacheFile struct {
+	Hash       string            `json:"hash"`       // hashValidatorFiles of the inputs it was built from
+	Validators map[string]string `json:"validators"` // validator reference -> definition
+}
+
+// hashValidatorFiles returns a key covering the path and content of every
+// validator file, so adding, removing, renaming or editing any of them
+// invalidates the cache.
+func hashValidatorFiles(files []string) string {
+	h := sha256.New()
+	h.Write([]byte("convex-gen-validators-v" + validatorCacheVersion + "\x00"))
+	for _, file := range files {
+		h.Write([]byte(file + "\x00"))
+		content, err := os.ReadFile(file)
+		if err != nil {
+			h.Write([]byte("<unreadable>"))
+		}
+		h.Write(content)
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// loadValidatorCache returns the cached validators at path if they were built
+// from inputs matching hash.
+func loadValidatorCache(path, hash string) (map[string]string, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var cache validatorCacheFile
+	if err := json.Unmarshal(data, &cache); err != nil || cache.Hash != hash || cache.Validators == nil {
+		return nil, false
+	}
+	return cache.Validators, true
+}
+
+// saveValidatorCache writes validators to path, keyed by hash. The file is
+// written to a temp name and renamed so a concurrent run never reads a
+// partial cache.
+func saveValidatorCache(path, hash string, validators map[string]string) error {
+	data, err := json.Marshal(validatorCacheFile{Hash: hash, Validators: validators})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestBuildValidatorCache_PersistedCache verifies the on-disk cache is reused
+// while validator files are unchanged and rebuilt once any of them changes.
+func TestBuildValidatorCache_PersistedCache(t *testing.T) {
+	tmpDir := t.TempDir()
+	modelDir := filepath.Join(tmpDir, "model", "issues")
+	if err := os.MkdirAll(modelDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	validatorsFile := filepath.Join(modelDir, "validators.ts")
+	writeValidators := func(field string) {
+		t.Helper()
+		content := "export const getIssueValidator = v.object({\n  " + field + ": v.string(),\n});\n"
+		if err := os.WriteFile(validatorsFile, []byte(content), 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	writeValidators("id")
+
+	cachePath := filepath.Join(tmpDir, ".cache", "validators.json")
+	build := func() map[string]string {
+		t.Helper()
+		parser := NewParser(&Config{Cache: CacheConfig{Validators: cachePath}})
+		if err := parser.BuildValidatorCache(tmpDir); err != nil {
+			t.Fatalf("BuildValidatorCache: %v", err)
+		}
+		return parser.validatorCache
+	}
+
+	first := build()
+	if _, ok := first["Issues.getIssueValidator"]; !ok {
+		t.Fatalf("validator missing from first build: %v", first)
+	}
+	if _, err := os.Stat(cachePath); err != nil {
+		t.Fatalf("cache file not written: %v", err)
+	}
+
+	// Mark the persisted entry so a reuse is distinguishable from a rebuild.
+	data, err := os.ReadFile(cachePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cache validatorCacheFile
+	if err := json.Unmarshal(data, &cache); err != nil {
+		t.Fatal(err)
+	}
+	cache.Validators["Issues.getIssueValidator"] = "from-cache"
+	data, _ = json.Marshal(cache)
+	if err := os.WriteFile(cachePath, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := build()["Issues.getIssueValidator"]; got != "from-cache" {
+		t.Errorf("unchanged inputs: got %q, want the persisted cache to be reused", got)
+	}
+
+	writeValidators("issueId")
+	got := build()["Issues.getIssueValidator"]
+	if got == "from-cache" || got == "" {
+		t.Fatalf("changed inputs: got %q, want a rebuilt definition", got)
+	}
+	if want := "issueId"; !strings.Contains(got, want) {
+		t.Errorf("rebuilt definition %q does not mention %q", got, want)
+	}
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"os"
+	"regexp"
+	"strings"
+)
+
+var (
+	// Match: import { a, b as c } from './path' (import type { ... } is skipped)
+	namedImportRe = regexp.MustCompile(`import\s*\{([^}]+)\}\s*from\s*['"]([^'"]+)['"]`)
+
+	// Match: import * as Ns from './path'
+	namespaceImportRe = regexp.MustCompile(`import\s*\*\s*as\s+(\w+)\s+from\s*['"]([^'"]+)['"]`)
+
+	// Match: export * from './path'
+	starReExportRe = regexp.MustCompile(`export\s*\*\s*from\s*['"]([^'"]+)['"]`)
+
+	// Match the start of any exported const, capturing its name
+	exportConstRe = regexp.MustCompile(`export\s+const\s+(\w+)\s*(?::[^=]+)?=\s*`)
+)
+
+// BuildImportedValidatorCache follows the relative imports of each Convex file
+// and caches the validators they bring in, so `args: someValidator` resolves
+// even when the validator lives outside model/. Named imports are cached under
+// their local name; `import * as Ns` imports under "Ns.name". Entries already
+// in the cache (from model/ validator files) are never overwritten. Returns the
+// number of validators added.
+func (p *Parser) BuildImportedValidatorCache(files []ConvexFile) int {
+	added := 0
+	add := func(key, def string) {
+		if _, exists := p.validatorCache[key]; exists {
+			return
+		}
+		p.validatorCache[key] = def
+		added++
+	}
+
+	for _, file := range files {
+		content, err := os.ReadFile(file.Path)
+		if err != nil {
+			continue
+		}
+		text := stripComments(string(content))
+
+		for _, m := range namedImportRe.FindAllStringSubmatch(text, -1) {
+			source := p.resolveImportPath(file.Path, m[2])
+			if source == "" {
+				continue
+			}
+			for _, spec := range parseImportSpecifiers(m[1]) {
+				if def, ok := p.findExportedValidator(source, spec.imported, map[string]bool{}); ok {
+					debugf("%s: imported validator %s resolved from %s", file.Path, spec.local, source)
+					add(spec.local, def)
+				}
+			}
+		}
+
+		for _, m := range namespaceImportRe.FindAllStringSubmatch(text, -1) {
+			source := p.resolveImportPath(file.Path, m[2])
+			if source == "" {
+				continue
+			}
+			for name, def := range p.exportedValidators(source) {
+				debugf("%s: imported validator %s.%s resolved from %s", file.Path, m[1], name, source)
+				add(m[1]+"."+name, def)
+			}
+		}
+	}
+	return added
+}
+
+// importSpecifier is one `imported as local` entry of a named import or
+// re-export list.
+type importSpecifier struct {
+	imported string
+	local    string
+}
+
+// parseImportSpecifiers splits the inside of `{ a, b as c, type D }`.
+// Type-only specifiers are dropped since they can't be validators.
+func parseImportSpecifiers(list string) []importSpecifier {
+	var specs []importSpecifier
+	for _, part := range strings.Split(list, ",") {
+		fields := strings.Fields(part)
+		if len(fields) > 0 && fields[0] == "type" {
+			continue
+		}
+		switch {
+		case len(fields) == 1 && isValidIdentifier(fields[0]):
+			specs = append(specs, importSpecifier{imported: fields[0], local: fields[0]})
+		case len(fields) == 3 && fields[1] == "as" && isValidIdentifier(fields[0]) && isValidIdentifier(fields[2]):
+			specs = append(specs, importSpecifier{imported: fields[0], local: fields[2]})
+		}
+	}
+	return specs
+}
+
+// findExportedValidator looks for `export const name = v.object(...)` (or a
+// plain object literal) in path, following the file's own re-exports and
+// imports when the name is only passed through. visited guards against
+// import cycles.
+func (p *Parser) findExportedValidator(path, name string, visited map[string]bool) (string, bool) {
+	if visited[path] {
+		return "", false
+	}
+	visited[path] = true
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+	text := stripComments(string(content))
+
+	if def, ok := exportedValidators(text)[name]; ok {
+		return def, true
+	}
+
+	// `export { x } from './y'`, `export { y as x } from './y'`, or
+	// `import { x } from './y'` followed by a local re-export
+	for _, re := range []*regexp.Regexp{reExportRe, namedImportRe} {
+		for _, m := range re.FindAllStringSubmatch(text, -1) {
+			for _, spec := range parseImportSpecifiers(m[1]) {
+				if spec.local != name {
+					continue
+				}
+				if next := p.resolveImportPath(path, m[2]); next != "" {
+					if def, ok := p.findExportedValidator(next, spec.imported, visited); ok {
+						return def, true
+					}
+				}
+			}
+		}
+	}
+
+	for _, m := range starReExportRe.FindAllStringSubmatch(text, -1) {
+		if next := p.resolveImportPath(path, m[1]); next != "" {
+			if def, ok := p.findExportedValidator(next, name, visited); ok {
+				return def, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// exportedValidators returns the validators exported directly by the file at
+// path, keyed by name.
+func (p *Parser) exportedValidators(path string) map[string]string {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	return exportedValidators(stripComments(string(content)))
+}
+
+// exportedValidators finds every `export const X = v.object({...})` or
+// `export const X = {...}` in text. Unlike validatorDefRe, the definition is
+// cut at the matching close paren/brace, so nested objects are kept whole.
+func exportedValidators(text string) map[string]string {
+	defs := make(map[string]string)
+	for _, m := range exportConstRe.FindAllStringSubmatchIndex(text, -1) {
+		name := text[m[2]:m[3]]
+		rest := text[m[1]:]
+
+		switch {
+		case strings.HasPrefix(rest, "v.object"):
+			open := strings.Index(rest, "(")
+			if open == -1 {
+				continue
+			}
+			if end := findMatchingCloseParen(rest, open+1); end != -1 {
+				defs[name] = rest[:end+1]
+			}
+		case strings.HasPrefix(rest, "{"):
+			if body := extractOuterBraceBody(rest); body != "" {
+				defs[name] = "{" + body + "}"
+			}
+		}
+	}
+	return defs
+}
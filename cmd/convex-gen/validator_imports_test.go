@@ -0,0 +1,125 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestBuildImportedValidatorCache_SiblingFile(t *testing.T) {
+	root := t.TempDir()
+	write := func(rel, content string) string {
+		t.Helper()
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	write("events/args.ts", `import { v } from "convex/values";
+
+// Shared by the events queries; lives next to them, not under model/.
+export const listEventsArgs = v.object({
+  communityId: v.id("communities"),
+  range: v.object({ start: v.number(), end: v.number() }),
+  limit: v.optional(v.number()),
+});
+`)
+	write("shared/index.ts", `export * from "./filters";
+`)
+	write("shared/filters.ts", `import { v } from "convex/values";
+export const statusFilterArgs = { status: v.string() };
+`)
+	events := write("events/queries.ts", `import { query } from "../_generated/server";
+import { listEventsArgs } from "./args";
+import { statusFilterArgs as filterArgs } from "../shared";
+
+export const listEvents = query({
+  args: listEventsArgs,
+  handler: async (ctx, args) => [],
+});
+
+export const filterEvents = query({
+  args: filterArgs,
+  handler: async (ctx, args) => [],
+});
+`)
+
+	p := NewParser(&Config{})
+	if n := p.BuildImportedValidatorCache([]ConvexFile{{Path: events, Namespace: "events", FileName: "queries"}}); n != 2 {
+		t.Fatalf("BuildImportedValidatorCache() added %d validators, want 2 (cache: %v)", n, p.validatorCache)
+	}
+
+	fns, err := p.ParseConvexFile(ConvexFile{Path: events, Namespace: "events", FileName: "queries"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string][]string)
+	for _, fn := range fns {
+		if fn.UseFunctionArgs {
+			t.Errorf("%s fell back to FunctionArgs", fn.Name)
+		}
+		for _, a := range fn.Args {
+			got[fn.Name] = append(got[fn.Name], a.Name)
+		}
+	}
+	want := map[string][]string{
+		"listEvents":   {"communityId", "range", "limit"},
+		"filterEvents": {"status"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("resolved args = %v, want %v", got, want)
+	}
+}
+
+func TestBuildImportedValidatorCache_ImportCycle(t *testing.T) {
+	root := t.TempDir()
+	a := filepath.Join(root, "a.ts")
+	b := filepath.Join(root, "b.ts")
+	// a and b re-export from each other and neither defines missingArgs.
+	if err := os.WriteFile(a, []byte("export { missingArgs } from './b';\nexport const aArgs = { id: v.string() };\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(b, []byte("export { missingArgs } from './a';\nexport * from './a';\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	fn := filepath.Join(root, "fn.ts")
+	if err := os.WriteFile(fn, []byte("import { missingArgs, aArgs } from './b';\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	p := NewParser(&Config{})
+	p.BuildImportedValidatorCache([]ConvexFile{{Path: fn}})
+
+	if _, ok := p.validatorCache["missingArgs"]; ok {
+		t.Error("missingArgs should not resolve")
+	}
+	if def := p.validatorCache["aArgs"]; def != "{ id: v.string() }" {
+		t.Errorf("aArgs = %q, want it resolved through export * from './a'", def)
+	}
+}
+
+func TestBuildImportedValidatorCache_KeepsModelEntries(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "args.ts"), []byte("export const sharedArgs = { fromImport: v.string() };\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	fn := filepath.Join(root, "fn.ts")
+	if err := os.WriteFile(fn, []byte("import { sharedArgs } from './args';\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	p := NewParser(&Config{})
+	p.validatorCache["sharedArgs"] = "{ fromModel: v.string() }"
+	if n := p.BuildImportedValidatorCache([]ConvexFile{{Path: fn}}); n != 0 {
+		t.Errorf("added %d, want 0", n)
+	}
+	if p.validatorCache["sharedArgs"] != "{ fromModel: v.string() }" {
+		t.Errorf("model entry overwritten: %q", p.validatorCache["sharedArgs"])
+	}
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// Match an entire v.id("tableName") expression (anchored, unlike idRe)
+	exactIdRe = regexp.MustCompile(`^v\.id\(\s*["'](\w+)["']\s*\)$`)
+
+	// Match a number, bigint or boolean literal accepted by v.literal()
+	plainLiteralRe = regexp.MustCompile(`^(?:-?\d+(?:\.\d+)?n?|true|false)$`)
+
+	// Match an object key: identifier or quoted string
+	objectKeyRe = regexp.MustCompile(`^(?:[A-Za-z_$][\w$]*|"[^"]*"|'[^']*')$`)
+)
+
+// validatorScalarTypes maps argument-less Convex validators to TypeScript types.
+var validatorScalarTypes = map[string]string{
+	"v.string()":  "string",
+	"v.number()":  "number",
+	"v.float64()": "number",
+	"v.int64()":   "bigint",
+	"v.bigint()":  "bigint",
+	"v.boolean()": "boolean",
+	"v.null()":    "null",
+	"v.any()":     "any",
+	"v.bytes()":   "ArrayBuffer",
+}
+
+// validatorTSType renders a Convex validator expression as a TypeScript type,
+// recursing through v.union, v.object, v.array, v.record and v.optional.
+// It returns false for anything it cannot express exactly (validator
+// references, helper calls, unbalanced input) so callers can fall back to
+// FunctionArgs.
+func validatorTSType(validator string) (string, bool) {
+	ts, _, ok := renderValidator(validator)
+	return ts, ok
+}
+
+// renderValidator does the work for validatorTSType. isUnion reports whether
+// the rendered type is a top-level union, which needs parentheses before [].
+func renderValidator(validator string) (ts string, isUnion bool, ok bool) {
+	validator = strings.TrimSpace(validator)
+
+	if ts, found := validatorScalarTypes[validator]; found {
+		return ts, false, true
+	}
+
+	if m := exactIdRe.FindStringSubmatch(validator); m != nil {
+		return fmt.Sprintf("Id<\"%s\">", m[1]), false, true
+	}
+
+	if inner, found := validatorCallArgs("v.literal", validator); found {
+		lit, ok := renderLiteral(inner)
+		return lit, false, ok
+	}
+
+	if inner, found := validatorCallArgs("v.optional", validator); found {
+		ts, _, ok := renderValidator(inner)
+		if !ok {
+			return "", false, false
+		}
+		return ts + " | undefined", true, true
+	}
+
+	if inner, found := validatorCallArgs("v.array", validator); found {
+		elem, union, ok := renderValidator(inner)
+		if !ok {
+			return "", false, false
+		}
+		if union {
+			elem = "(" + elem + ")"
+		}
+		return elem + "[]", false, true
+	}
+
+	if inner, found := validatorCallArgs("v.union", validator); found {
+		var members []string
+		seen := make(map[string]bool)
+		for _, m := range splitTopLevel(inner, ',') {
+			if strings.TrimSpace(m) == "" {
+				continue
+			}
+			ts, _, ok := renderValidator(m)
+			if !ok {
+				return "", false, false
+			}
+			if !seen[ts] {
+				seen[ts] = true
+				members = append(members, ts)
+			}
+		}
+		if len(members) == 0 {
+			return "", false, false
+		}
+		return strings.Join(members, " | "), len(members) > 1, true
+	}
+
+	if inner, found := validatorCallArgs("v.object", validator); found {
+		obj, ok := renderObjectValidator(inner)
+		return obj, false, ok
+	}
+
+	if inner, found := validatorCallArgs("v.record", validator); found {
+		parts := splitTopLevel(inner, ',')
+		if len(parts) != 2 {
+			return "", false, false
+		}
+		key, _, okKey := renderValidator(parts[0])
+		value, _, okValue := renderValidator(parts[1])
+		if !okKey || !okValue {
+			return "", false, false
+		}
+		return fmt.Sprintf("Record<%s, %s>", key, value), false, true
+	}
+
+	return "", false, false
+}
+
+// renderLiteral renders the argument of v.literal(...) as a TS literal type.
+func renderLiteral(inner string) (string, bool) {
+	inner = strings.TrimSpace(inner)
+	if len(inner) >= 2 && (inner[0] == '"' || inner[0] == '\'') && inner[len(inner)-1] == inner[0] {
+		return strconv.Quote(inner[1 : len(inner)-1]), true
+	}
+	if plainLiteralRe.MatchString(inner) {
+		return inner, true
+	}
+	return "", false
+}
+
+// renderObjectValidator renders the `{ ... }` argument of v.object(...) as an
+// inline object type. v.optional fields become optional properties.
+func renderObjectValidator(inner string) (string, bool) {
+	inner = strings.TrimSpace(inner)
+	if !strings.HasPrefix(inner, "{") || !strings.HasSuffix(inner, "}") {
+		return "", false
+	}
+	body := inner[1 : len(inner)-1]
+
+	var props []string
+	for _, entry := range splitTopLevel(body, ',') {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		colon := indexTopLevel(entry, ':')
+		if colon == -1 {
+			return "", false
+		}
+		key := strings.TrimSpace(entry[:colon])
+		value := strings.TrimSpace(entry[colon+1:])
+		if !objectKeyRe.MatchString(key) {
+			return "", false
+		}
+
+		optional := ""
+		if optInner, found := validatorCallArgs("v.optional", value); found {
+			optional = "?"
+			value = optInner
+		}
+		ts, _, ok := renderValidator(value)
+		if !ok {
+			return "", false
+		}
+		props = append(props, fmt.Sprintf("%s%s: %s", key, optional, ts))
+	}
+
+	if len(props) == 0 {
+		return "{}", true
+	}
+	return "{ " + strings.Join(props, "; ") + " }", true
+}
+
+// validatorCallArgs returns the argument text of `name(...)` when validator is
+// exactly that call, with nothing after the closing paren.
+func validatorCallArgs(name, validator string) (string, bool) {
+	if !strings.HasPrefix(validator, name) {
+		return "", false
+	}
+	rest := strings.TrimSpace(validator[len(name):])
+	if !strings.HasPrefix(rest, "(") || !strings.HasSuffix(rest, ")") {
+		return "", false
+	}
+	if indexTopLevel(rest[1:], ')') != len(rest)-2 {
+		return "", false
+	}
+	return rest[1 : len(rest)-1], true
+}
+
+// splitTopLevel splits s on sep where it appears outside brackets and string
+// literals.
+func splitTopLevel(s string, sep byte) []string {
+	var parts []string
+	for {
+		i := indexTopLevel(s, sep)
+		if i == -1 {
+			return append(parts, s)
+		}
+		parts = append(parts, s[:i])
+		s = s[i+1:]
+	}
+}
+
+// indexTopLevel returns the index of the first sep in s that is outside
+// brackets and string literals, or -1. A closing bracket that would take the
+// depth below zero counts as top level, so `a)` finds the `)`.
+func indexTopLevel(s string, sep byte) int {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if quote != 0 {
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		if depth == 0 && c == sep {
+			return i
+		}
+		switch c {
+		case '"', '\'', '`':
+			quote = c
+		case '{', '(', '[':
+			depth++
+		case '}', ')', ']':
+			depth--
+		}
+	}
+	return -1
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	// watchPollInterval is how often --watch re-stats the Convex tree.
+	watchPollInterval = 300 * time.Millisecond
+	// watchQuietPeriod is how long the tree must stay unchanged before a
+	// regeneration runs, so a save-all or branch switch triggers one cycle.
+	watchQuietPeriod = 500 * time.Millisecond
+)
+
+// fileStamp is the part of a file's metadata that signals an edit.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// snapshotTree records the stamp of every .ts/.tsx file under roots,
+// skipping dot directories, node_modules and the configured skip
+// directories (which include Convex's own _generated output).
+func snapshotTree(roots []string, skipDirs []string) map[string]fileStamp {
+	skip := map[string]bool{"node_modules": true, "_generated": true}
+	for _, dir := range skipDirs {
+		skip[dir] = true
+	}
+
+	snap := make(map[string]fileStamp)
+	for _, root := range roots {
+		_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			if d.IsDir() {
+				if path != root && (skip[d.Name()] || strings.HasPrefix(d.Name(), ".")) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !strings.HasSuffix(path, ".ts") && !strings.HasSuffix(path, ".tsx") {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			snap[path] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+			return nil
+		})
+	}
+	return snap
+}
+
+// snapshotChanged reports whether any file was added, removed or modified
+// between two snapshots.
+func snapshotChanged(prev, cur map[string]fileStamp) bool {
+	if len(prev) != len(cur) {
+		return true
+	}
+	for path, stamp := range cur {
+		old, ok := prev[path]
+		if !ok || !old.modTime.Equal(stamp.modTime) || old.size != stamp.size {
+			return true
+		}
+	}
+	return false
+}
+
+// debouncer coalesces bursts of change events into a single regeneration
+// that fires once no new event has arrived for the quiet period.
+type debouncer struct {
+	quiet   time.Duration
+	pending bool
+	last    time.Time
+}
+
+// touch records a change event at now, restarting the quiet period.
+func (d *debouncer) touch(now time.Time) {
+	d.pending = true
+	d.last = now
+}
+
+// ready reports whether a pending change has been quiet long enough to act
+// on. It returns true at most once per burst.
+func (d *debouncer) ready(now time.Time) bool {
+	if !d.pending || now.Sub(d.last) < d.quiet {
+		return false
+	}
+	d.pending = false
+	return true
+}
+
+// watch implements --watch: it polls the Convex tree and re-runs the full
+// pipeline after each burst of changes until interrupted. Config is reloaded
+// every cycle so .convex-gen.json edits apply without a restart.
+func watch(cliTypedReturns bool) error {
+	config, err := loadRunConfig(cliTypedReturns)
+	if err != nil {
+		return err
+	}
+
+	roots := []string{config.Convex.Path}
+	if !strings.HasPrefix(config.Convex.SchemaPath, config.Convex.Path+string(filepath.Separator)) {
+		roots = append(roots, config.Convex.SchemaPath)
+	}
+
+	regenerate := func() {
+		stamp := time.Now().Format("15:04:05")
+		config, err := loadRunConfig(cliTypedReturns)
+		if err == nil {
+			var hooks int
+			if hooks, err = generate(config, io.Discard); err == nil {
+				fmt.Printf("[%s] regenerated %d hooks\n", stamp, hooks)
+				return
+			}
+		}
+		fmt.Fprintf(os.Stderr, "[%s] generation failed: %v\n", stamp, err)
+	}
+
+	fmt.Printf("Watching %s for changes (Ctrl+C to stop)\n", strings.Join(roots, ", "))
+	regenerate()
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigs)
+
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+
+	prev := snapshotTree(roots, config.Skip.Directories)
+	d := &debouncer{quiet: watchQuietPeriod}
+	for {
+		select {
+		case <-sigs:
+			fmt.Println("Stopped watching")
+			return nil
+		case now := <-ticker.C:
+			cur := snapshotTree(roots, config.Skip.Directories)
+			if snapshotChanged(prev, cur) {
+				d.touch(now)
+			}
+			prev = cur
+			if d.ready(now) {
+				regenerate()
+			}
+		}
+	}
+}
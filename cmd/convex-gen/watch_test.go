@@ -0,0 +1,82 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDebouncerCoalescesBursts(t *testing.T) {
+	d := &debouncer{quiet: 500 * time.Millisecond}
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(ms int) time.Time { return t0.Add(time.Duration(ms) * time.Millisecond) }
+
+	if d.ready(at(0)) {
+		t.Fatal("ready() with no pending change")
+	}
+
+	// A burst of saves 200ms apart keeps pushing the deadline out.
+	d.touch(at(0))
+	d.touch(at(200))
+	d.touch(at(400))
+	if d.ready(at(800)) {
+		t.Error("ready() fired before the burst went quiet")
+	}
+	if !d.ready(at(900)) {
+		t.Error("ready() did not fire after the quiet period")
+	}
+	if d.ready(at(2000)) {
+		t.Error("ready() fired twice for one burst")
+	}
+
+	// A later change starts a new cycle.
+	d.touch(at(3000))
+	if !d.ready(at(3500)) {
+		t.Error("ready() did not fire for the next burst")
+	}
+}
+
+func TestSnapshotChanged(t *testing.T) {
+	root := t.TempDir()
+	write := func(rel, content string) {
+		t.Helper()
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("users/queries.ts", "export const a = 1;")
+	write("_generated/api.ts", "generated")
+	write("node_modules/pkg/index.ts", "dep")
+	write("README.md", "docs")
+
+	snap := func() map[string]fileStamp { return snapshotTree([]string{root}, []string{".turbo"}) }
+	base := snap()
+	if len(base) != 1 {
+		t.Fatalf("snapshot = %v, want only users/queries.ts", base)
+	}
+	if snapshotChanged(base, snap()) {
+		t.Error("unchanged tree reported as changed")
+	}
+
+	// Output under skipped directories must not retrigger a cycle.
+	write("_generated/api.ts", "regenerated")
+	if snapshotChanged(base, snap()) {
+		t.Error("_generated write reported as a change")
+	}
+
+	write("users/queries.ts", "export const a = 12;")
+	if !snapshotChanged(base, snap()) {
+		t.Error("modified file not detected")
+	}
+
+	base = snap()
+	write("users/mutations.ts", "export const b = 1;")
+	if !snapshotChanged(base, snap()) {
+		t.Error("new file not detected")
+	}
+}
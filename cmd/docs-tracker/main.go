@@ -11,8 +11,10 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/milehighideas/claude-hooks/internal/jsonc"
+	"github.com/milehighideas/claude-hooks/internal/session"
 )
 
 // Mode represents the operation mode of the hook
@@ -29,6 +31,11 @@ const (
 // per project.
 const preCommitConfigFile = ".pre-commit.json"
 
+// docsConfigFile is a standalone alternative for projects that don't use
+// .pre-commit.json. It holds the docsTrackerConfig block by itself, and its
+// presence enables the hook. When both files exist it wins.
+const docsConfigFile = ".claude-docs.json"
+
 // defaultConvexBackendDir is the conventional Convex backend path used when
 // `convex` is enabled and no explicit `backendDir` is provided.
 const defaultConvexBackendDir = "packages/backend"
@@ -51,7 +58,33 @@ type HookInput struct {
 
 // SessionData represents the stored session data
 type SessionData struct {
-	DocsRead []string `json:"docs_read"`
+	DocsRead []DocRead `json:"docs_read"`
+	// ReadAt records when a Read of each doc was last seen, either by
+	// enforce mode (PreToolUse) or track mode (PostToolUse).
+	ReadAt map[string]time.Time `json:"read_at,omitempty"`
+}
+
+// DocRead is a doc track mode saw read, and when.
+type DocRead struct {
+	Doc    string    `json:"doc"`
+	ReadAt time.Time `json:"read_at"`
+}
+
+// UnmarshalJSON also accepts the bare doc path that older session files
+// stored. Such entries have a zero ReadAt and never go stale.
+func (d *DocRead) UnmarshalJSON(data []byte) error {
+	var doc string
+	if err := json.Unmarshal(data, &doc); err == nil {
+		*d = DocRead{Doc: doc}
+		return nil
+	}
+	type plain DocRead
+	var p plain
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	*d = DocRead(p)
+	return nil
 }
 
 // Project represents a docs-tracker-enabled project with resolved mappings.
@@ -89,6 +122,21 @@ type Config struct {
 	// ExcludePaths skips enforcement on files whose project-relative path
 	// contains any of these substrings. Exclusions always win over AppPaths.
 	ExcludePaths []string `json:"excludePaths,omitempty"`
+
+	// ReadCooldownMs is how long after a Read of a doc is requested that
+	// edits needing it are allowed, even if track mode hasn't recorded the
+	// read yet (a Read and an Edit in the same tool batch). Default 5000;
+	// 0 disables the grace window.
+	ReadCooldownMs *int `json:"readCooldownMs,omitempty"`
+
+	// ReadTTLMinutes expires a recorded read after this many minutes, so the
+	// doc must be read again. Unset or 0 means reads last the session. A
+	// doc modified after it was read always needs a re-read.
+	ReadTTLMinutes *int `json:"readTtlMinutes,omitempty"`
+
+	// SkipPatterns replaces the default skipPatterns: path fragments whose
+	// files never need docs read first.
+	SkipPatterns []string `json:"skipPatterns,omitempty"`
 }
 
 // CustomMapping is an explicit directory-to-docs rule. Pattern is the
@@ -152,6 +200,29 @@ func (c *Config) isAutoDiscover() bool {
 	return *c.AutoDiscover
 }
 
+// defaultReadCooldown is the grace window used when ReadCooldownMs is unset.
+const defaultReadCooldown = 5 * time.Second
+
+// readCooldown returns the effective ReadCooldownMs as a duration.
+func (c *Config) readCooldown() time.Duration {
+	if c == nil || c.ReadCooldownMs == nil {
+		return defaultReadCooldown
+	}
+	if *c.ReadCooldownMs <= 0 {
+		return 0
+	}
+	return time.Duration(*c.ReadCooldownMs) * time.Millisecond
+}
+
+// readTTL returns the effective ReadTTLMinutes as a duration; 0 means reads
+// don't expire.
+func (c *Config) readTTL() time.Duration {
+	if c == nil || c.ReadTTLMinutes == nil || *c.ReadTTLMinutes <= 0 {
+		return 0
+	}
+	return time.Duration(*c.ReadTTLMinutes) * time.Minute
+}
+
 // effectiveDocFileNames returns DocFileNames with a CLAUDE.md default.
 func (c *Config) effectiveDocFileNames() []string {
 	if c == nil || len(c.DocFileNames) == 0 {
@@ -160,9 +231,18 @@ func (c *Config) effectiveDocFileNames() []string {
 	return c.DocFileNames
 }
 
+// effectiveSkipPatterns returns SkipPatterns, defaulting to skipPatterns.
+func (c *Config) effectiveSkipPatterns() []string {
+	if c == nil || len(c.SkipPatterns) == 0 {
+		return skipPatterns
+	}
+	return c.SkipPatterns
+}
+
 // skipPatterns are path fragments that bypass the docs-read requirement.
 // Applies to tests, generated code, declaration files, etc. Docs themselves
-// are skipped dynamically per-project in enforce.
+// are skipped dynamically per-project in enforce. A project can replace them
+// with docsTrackerConfig.skipPatterns.
 var skipPatterns = []string{
 	"__tests__/",
 	".test.ts",
@@ -189,17 +269,20 @@ type sessionFileProvider func(sessionID string) string
 
 // defaultSessionFileProvider returns the default session file path
 func defaultSessionFileProvider(sessionID string) string {
-	homeDir, err := os.UserHomeDir()
+	dir, err := session.Dir()
 	if err != nil {
 		// Fallback to current directory
-		homeDir = "."
+		dir = filepath.Join(".", ".claude", "sessions")
 	}
-	return filepath.Join(homeDir, ".claude", "sessions", sessionID+"-docs.json")
+	return filepath.Join(dir, sessionID+"-docs.json")
 }
 
 // globalSessionFileProvider is the current session file provider
 var globalSessionFileProvider = defaultSessionFileProvider
 
+// now is replaced in tests to control read timestamps.
+var now = time.Now
+
 func main() {
 	mode := flag.String("mode", "", "Operation mode: enforce or track")
 	flag.Parse()
@@ -247,6 +330,13 @@ func enforceWithProvider(input io.Reader, stderr io.Writer, provider sessionFile
 		return nil
 	}
 
+	// A Read is noted so an edit later in the same tool batch isn't blocked
+	// before track mode gets to record it.
+	if hookInput.ToolName == "Read" {
+		notePendingRead(hookInput, provider)
+		return nil
+	}
+
 	// Only check Edit and Write tools
 	if hookInput.ToolName != "Edit" && hookInput.ToolName != "Write" {
 		return nil
@@ -257,17 +347,17 @@ func enforceWithProvider(input io.Reader, stderr io.Writer, provider sessionFile
 		return nil
 	}
 
-	// Skip certain files (tests, generated, etc.)
-	if !shouldCheckFile(filePath) {
-		return nil
-	}
-
 	// Find the opt-in project root; without one the hook is a no-op
 	project := findProject(filePath)
 	if project == nil || len(project.Mappings) == 0 {
 		return nil
 	}
 
+	// Skip certain files (tests, generated, etc.)
+	if !shouldCheckFile(filePath, project.Config.effectiveSkipPatterns()) {
+		return nil
+	}
+
 	// Compute the file's path relative to the project root
 	relPath, ok := relativeToProject(project.Root, filePath)
 	if !ok {
@@ -295,17 +385,24 @@ func enforceWithProvider(input io.Reader, stderr io.Writer, provider sessionFile
 	}
 
 	// Figure out which docs have been read this session.
-	docsRead, err := loadDocsReadWithProvider(hookInput.SessionID, provider)
+	sess, err := loadSessionWithProvider(hookInput.SessionID, provider)
 	if err != nil {
 		// If we can't load session data, allow operation
 		return nil
 	}
 
+	cooldown := project.Config.readCooldown()
+	ttl := project.Config.readTTL()
 	var missing []string
 	for _, doc := range required.Docs {
-		if !contains(docsRead, doc) {
-			missing = append(missing, doc)
+		stale := sess.staleReason(project.Root, doc, ttl)
+		if stale == "" || readRecently(sess, project.Root, doc, cooldown) {
+			continue
 		}
+		if stale != notReadYet {
+			doc += " (" + stale + ")"
+		}
+		missing = append(missing, doc)
 	}
 	if len(missing) == 0 {
 		return nil
@@ -360,14 +457,98 @@ func trackWithProvider(input io.Reader, provider sessionFileProvider) error {
 		return nil
 	}
 
-	docsRead, err := loadDocsReadWithProvider(hookInput.SessionID, provider)
+	sess, err := loadSessionWithProvider(hookInput.SessionID, provider)
 	if err != nil {
-		docsRead = []string{}
+		sess = &SessionData{}
 	}
-	if !contains(docsRead, relPath) {
-		docsRead = append(docsRead, relPath)
+	sess.recordRead(relPath)
+	sess.markRead(relPath)
+	return saveSessionWithProvider(hookInput.SessionID, sess, provider)
+}
+
+// notePendingRead stamps ReadAt for a registered doc when enforce mode sees
+// its Read. Claude Code can run an Edit's PreToolUse before the Read's
+// PostToolUse lands, so without this a doc read in the same batch would
+// still look unread. Failures are ignored: the edit then falls back to the
+// normal docs_read check.
+func notePendingRead(hookInput *HookInput, provider sessionFileProvider) {
+	filePath, ok := hookInput.ToolInput["file_path"].(string)
+	if !ok || filePath == "" {
+		return
 	}
-	return saveDocsReadWithProvider(hookInput.SessionID, docsRead, provider)
+
+	project := findProject(filePath)
+	if project == nil || len(project.Mappings) == 0 || project.Config.readCooldown() == 0 {
+		return
+	}
+
+	relPath, ok := relativeToProject(project.Root, filePath)
+	if !ok || !isRegisteredDoc(relPath, project.Mappings) {
+		return
+	}
+
+	sess, err := loadSessionWithProvider(hookInput.SessionID, provider)
+	if err != nil {
+		return
+	}
+	sess.markRead(relPath)
+	_ = saveSessionWithProvider(hookInput.SessionID, sess, provider)
+}
+
+// readRecently reports whether doc exists and its Read was seen within
+// cooldown, covering a read that track mode hasn't recorded yet.
+func readRecently(sess *SessionData, projectRoot, doc string, cooldown time.Duration) bool {
+	at, ok := sess.ReadAt[doc]
+	if !ok || cooldown == 0 || now().Sub(at) > cooldown {
+		return false
+	}
+	_, err := os.Stat(filepath.Join(projectRoot, filepath.FromSlash(doc)))
+	return err == nil
+}
+
+// notReadYet is staleReason's result for a doc with no recorded read.
+const notReadYet = "not read"
+
+// staleReason reports why doc's recorded read doesn't count: notReadYet,
+// the doc changed on disk after the read, or the read is older than ttl.
+// It returns "" for a valid read. Entries from older session files carry
+// no time and are always valid.
+func (s *SessionData) staleReason(projectRoot, doc string, ttl time.Duration) string {
+	for _, r := range s.DocsRead {
+		if r.Doc != doc {
+			continue
+		}
+		if r.ReadAt.IsZero() {
+			return ""
+		}
+		if info, err := os.Stat(filepath.Join(projectRoot, filepath.FromSlash(doc))); err == nil && info.ModTime().After(r.ReadAt) {
+			return "changed since you read it"
+		}
+		if ttl > 0 && now().Sub(r.ReadAt) > ttl {
+			return fmt.Sprintf("read more than %d minutes ago", int(ttl.Minutes()))
+		}
+		return ""
+	}
+	return notReadYet
+}
+
+// recordRead adds doc to DocsRead, or refreshes its time if already there.
+func (s *SessionData) recordRead(doc string) {
+	for i := range s.DocsRead {
+		if s.DocsRead[i].Doc == doc {
+			s.DocsRead[i].ReadAt = now()
+			return
+		}
+	}
+	s.DocsRead = append(s.DocsRead, DocRead{Doc: doc, ReadAt: now()})
+}
+
+// markRead stamps ReadAt for doc with the current time.
+func (s *SessionData) markRead(doc string) {
+	if s.ReadAt == nil {
+		s.ReadAt = make(map[string]time.Time)
+	}
+	s.ReadAt[doc] = now()
 }
 
 // parseInput parses JSON input from stdin
@@ -380,9 +561,10 @@ func parseInput(input io.Reader) (*HookInput, error) {
 	return &hookInput, nil
 }
 
-// shouldCheckFile determines if this file should require doc reading.
-func shouldCheckFile(filePath string) bool {
-	for _, pattern := range skipPatterns {
+// shouldCheckFile determines if this file should require doc reading, given
+// the project's skip patterns.
+func shouldCheckFile(filePath string, patterns []string) bool {
+	for _, pattern := range patterns {
 		if strings.Contains(filePath, pattern) {
 			return false
 		}
@@ -413,8 +595,8 @@ func isRegisteredDoc(relPath string, mappings []DocMapping) bool {
 	return false
 }
 
-// findProject walks up from filePath looking for the opt-in marker
-// (`.pre-commit.json`) and resolves its mappings. Returns nil when no project
+// findProject walks up from filePath looking for an opt-in marker
+// (`.claude-docs.json` or `.pre-commit.json`) and resolves its mappings. Returns nil when no project
 // is found, the config is unreadable, or features.docsTracker is off.
 func findProject(filePath string) *Project {
 	root := findProjectRoot(filePath)
@@ -434,7 +616,8 @@ func findProject(filePath string) *Project {
 }
 
 // findProjectRoot walks up from filePath's directory until it finds a
-// `.pre-commit.json` marker. Returns "" if none is found.
+// `.claude-docs.json` or `.pre-commit.json` marker. Returns "" if none is
+// found.
 func findProjectRoot(filePath string) string {
 	abs, err := filepath.Abs(filePath)
 	if err != nil {
@@ -442,8 +625,10 @@ func findProjectRoot(filePath string) string {
 	}
 	dir := filepath.Dir(abs)
 	for {
-		if _, err := os.Stat(filepath.Join(dir, preCommitConfigFile)); err == nil {
-			return dir
+		for _, marker := range []string{docsConfigFile, preCommitConfigFile} {
+			if _, err := os.Stat(filepath.Join(dir, marker)); err == nil {
+				return dir
+			}
 		}
 		parent := filepath.Dir(dir)
 		if parent == dir {
@@ -453,14 +638,23 @@ func findProjectRoot(filePath string) string {
 	}
 }
 
-// loadConfig reads and parses the `.pre-commit.json` file at root, returning
-// the docsTrackerConfig block and whether features.docsTracker is enabled.
+// loadConfig reads the project config at root. A `.claude-docs.json` is the
+// whole config and always enables the hook; otherwise `.pre-commit.json` is
+// parsed for the docsTrackerConfig block and features.docsTracker.
 // The returned *Config is always non-nil so callers can chain into
 // buildMappings without a nil check; errors flow through the third return.
 // JSONC comments are stripped before parsing so the file can be annotated.
 func loadConfig(root string) (*Config, bool, error) {
-	path := filepath.Join(root, preCommitConfigFile)
 	cfg := &Config{}
+	docsPath := filepath.Join(root, docsConfigFile)
+	if _, err := os.Stat(docsPath); err == nil {
+		if err := jsonc.Unmarshal(docsPath, cfg); err != nil {
+			return &Config{}, false, fmt.Errorf("parsing %s: %w", docsPath, err)
+		}
+		return cfg, true, nil
+	}
+
+	path := filepath.Join(root, preCommitConfigFile)
 	data, err := jsonc.ReadFile(path)
 	if err != nil {
 		return cfg, false, err
@@ -710,14 +904,15 @@ func relativeToProject(projectRoot, filePath string) (string, bool) {
 	return rel, true
 }
 
-// loadDocsReadWithProvider loads the set of docs read this session using a custom provider
-func loadDocsReadWithProvider(sessionID string, provider sessionFileProvider) ([]string, error) {
+// loadSessionWithProvider loads this session's data using a custom provider.
+// A missing session file yields empty data.
+func loadSessionWithProvider(sessionID string, provider sessionFileProvider) (*SessionData, error) {
 	sessionFile := provider(sessionID)
 
 	data, err := os.ReadFile(sessionFile)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return []string{}, nil
+			return &SessionData{DocsRead: []DocRead{}}, nil
 		}
 		return nil, fmt.Errorf("reading session file: %w", err)
 	}
@@ -727,11 +922,13 @@ func loadDocsReadWithProvider(sessionID string, provider sessionFileProvider) ([
 		return nil, fmt.Errorf("parsing session file: %w", err)
 	}
 
-	return sessionData.DocsRead, nil
+	return &sessionData, nil
 }
 
-// saveDocsReadWithProvider saves docs read to session file using a custom provider
-func saveDocsReadWithProvider(sessionID string, docs []string, provider sessionFileProvider) error {
+// saveSessionWithProvider saves session data using a custom provider. The
+// file is written to a temp file and renamed into place so a concurrent
+// enforce never reads it half-written.
+func saveSessionWithProvider(sessionID string, sess *SessionData, provider sessionFileProvider) error {
 	sessionFile := provider(sessionID)
 
 	// Ensure sessions directory exists
@@ -740,13 +937,26 @@ func saveDocsReadWithProvider(sessionID string, docs []string, provider sessionF
 		return fmt.Errorf("creating sessions directory: %w", err)
 	}
 
-	sessionData := SessionData{DocsRead: docs}
-	data, err := json.Marshal(sessionData)
+	data, err := json.Marshal(sess)
 	if err != nil {
 		return fmt.Errorf("marshaling session data: %w", err)
 	}
 
-	if err := os.WriteFile(sessionFile, data, 0644); err != nil {
+	tmp, err := os.CreateTemp(sessionsDir, filepath.Base(sessionFile)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("writing session file: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("writing session file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("writing session file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), sessionFile); err != nil {
+		os.Remove(tmp.Name())
 		return fmt.Errorf("writing session file: %w", err)
 	}
 
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 // projectFixture describes a temp project layout for tests.
@@ -21,6 +22,8 @@ type projectFixture struct {
 	// .pre-commit.json. Use for tests that need to exercise the top-level
 	// schema (feature flag off, missing features block, malformed JSON).
 	rawPreCommit string
+	// docsConfig, if non-empty, is written verbatim as .claude-docs.json.
+	docsConfig string
 	// docs creates files (by relative path) with placeholder content.
 	docs []string
 	// extraFiles creates empty files (by relative path) for layout purposes.
@@ -41,6 +44,11 @@ func setupProject(t *testing.T, fx projectFixture) string {
 			t.Fatalf("write config: %v", err)
 		}
 	}
+	if fx.docsConfig != "" {
+		if err := os.WriteFile(filepath.Join(root, ".claude-docs.json"), []byte(fx.docsConfig), 0644); err != nil {
+			t.Fatalf("write docs config: %v", err)
+		}
+	}
 	for _, doc := range fx.docs {
 		full := filepath.Join(root, filepath.FromSlash(doc))
 		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
@@ -69,14 +77,19 @@ func sessionProvider(dir string) sessionFileProvider {
 	}
 }
 
-// seedSession writes docsRead into the session file for sessionID.
+// seedSession writes docsRead into the session file for sessionID, each read
+// at the current time.
 func seedSession(t *testing.T, provider sessionFileProvider, sessionID string, docsRead []string) {
 	t.Helper()
 	sessionFile := provider(sessionID)
 	if err := os.MkdirAll(filepath.Dir(sessionFile), 0755); err != nil {
 		t.Fatalf("mkdir session dir: %v", err)
 	}
-	data, _ := json.Marshal(SessionData{DocsRead: docsRead})
+	session := SessionData{}
+	for _, doc := range docsRead {
+		session.recordRead(doc)
+	}
+	data, _ := json.Marshal(session)
 	if err := os.WriteFile(sessionFile, data, 0644); err != nil {
 		t.Fatalf("write session file: %v", err)
 	}
@@ -84,9 +97,15 @@ func seedSession(t *testing.T, provider sessionFileProvider, sessionID string, d
 
 // runEnforce runs enforceWithProvider for a given file_path/session.
 func runEnforce(t *testing.T, provider sessionFileProvider, sessionID, filePath string) (string, error) {
+	t.Helper()
+	return runEnforceTool(t, provider, "Edit", sessionID, filePath)
+}
+
+// runEnforceTool runs enforceWithProvider for an arbitrary tool name.
+func runEnforceTool(t *testing.T, provider sessionFileProvider, toolName, sessionID, filePath string) (string, error) {
 	t.Helper()
 	input := HookInput{
-		ToolName:  "Edit",
+		ToolName:  toolName,
 		ToolInput: map[string]interface{}{"file_path": filePath},
 		SessionID: sessionID,
 	}
@@ -381,6 +400,39 @@ func TestEnforce_CustomMapping_NormalizesSlashes(t *testing.T) {
 	}
 }
 
+func TestEnforce_CustomMapping_RequiresEveryDoc(t *testing.T) {
+	// An area governed by both an architecture doc and a conventions doc
+	// stays gated until both are read; the message lists only the unread one.
+	root := setupProject(t, projectFixture{
+		config: `{
+			"autoDiscover": false,
+			"mappings": [
+				{ "pattern": "apps/web/", "docs": ["docs/architecture.md", "docs/conventions.md"] }
+			]
+		}`,
+		docs: []string{"docs/architecture.md", "docs/conventions.md"},
+	})
+	provider := sessionProvider(t.TempDir())
+	target := filepath.Join(root, "apps", "web", "page.tsx")
+	seedSession(t, provider, "s", []string{"docs/architecture.md"})
+
+	stderr, err := runEnforce(t, provider, "s", target)
+	if exitErr, ok := err.(*ExitError); !ok || exitErr.Code != 2 {
+		t.Fatalf("expected block with one doc unread, got %v", err)
+	}
+	if !strings.Contains(stderr, "docs/conventions.md") {
+		t.Errorf("expected unread doc listed: %s", stderr)
+	}
+	if strings.Contains(stderr, "docs/architecture.md") {
+		t.Errorf("should not list the doc already read: %s", stderr)
+	}
+
+	seedSession(t, provider, "s", []string{"docs/architecture.md", "docs/conventions.md"})
+	if _, err := runEnforce(t, provider, "s", target); err != nil {
+		t.Fatalf("expected allow once both docs are read, got %v", err)
+	}
+}
+
 func TestEnforce_CustomMapping_SkipsEmptyEntries(t *testing.T) {
 	// A mapping with no pattern or no docs is silently dropped.
 	root := setupProject(t, projectFixture{
@@ -733,6 +785,86 @@ func TestEnforce_ConvexPreset_MergesWithClaudeMdAutoDiscovery(t *testing.T) {
 	}
 }
 
+// ---------------------------------------------------------------------------
+// Standalone .claude-docs.json
+// ---------------------------------------------------------------------------
+
+func TestEnforce_DocsConfig_CustomMappingBlocks(t *testing.T) {
+	// No .pre-commit.json: .claude-docs.json alone opts the project in.
+	root := setupProject(t, projectFixture{
+		docsConfig: `{
+			// backend edits need the API guide
+			"autoDiscover": false,
+			"mappings": [
+				{ "pattern": "services/api/", "docs": ["docs/api-guide.md"], "name": "API service" }
+			]
+		}`,
+		docs: []string{"docs/api-guide.md"},
+	})
+	provider := sessionProvider(t.TempDir())
+	target := filepath.Join(root, "services", "api", "handler.go")
+
+	stderr, err := runEnforce(t, provider, "s", target)
+	exitErr, ok := err.(*ExitError)
+	if !ok || exitErr.Code != 2 {
+		t.Fatalf("expected block, got %v", err)
+	}
+	if !strings.Contains(stderr, "docs/api-guide.md") || !strings.Contains(stderr, "API service") {
+		t.Errorf("expected stderr to name the mapping and doc, got %q", stderr)
+	}
+
+	seedSession(t, provider, "s", []string{"docs/api-guide.md"})
+	if _, err := runEnforce(t, provider, "s", target); err != nil {
+		t.Fatalf("expected allow after reading, got %v", err)
+	}
+}
+
+func TestEnforce_DocsConfig_OverridesPreCommit(t *testing.T) {
+	// .pre-commit.json's block would gate apps/web/ via auto-discovery; the
+	// standalone file replaces it entirely.
+	root := setupProject(t, projectFixture{
+		config:     `{}`,
+		docsConfig: `{"autoDiscover": false, "mappings": [{"pattern": "lib/", "docs": ["docs/lib.md"]}]}`,
+		docs:       []string{"apps/web/CLAUDE.md", "docs/lib.md"},
+	})
+	provider := sessionProvider(t.TempDir())
+
+	if _, err := runEnforce(t, provider, "s", filepath.Join(root, "apps", "web", "page.tsx")); err != nil {
+		t.Errorf("expected apps/web to be ungated, got %v", err)
+	}
+	if _, err := runEnforce(t, provider, "s", filepath.Join(root, "lib", "util.ts")); err == nil {
+		t.Error("expected lib/ to be gated by .claude-docs.json")
+	}
+}
+
+func TestEnforce_DocsConfig_MalformedIsNoOp(t *testing.T) {
+	root := setupProject(t, projectFixture{
+		docsConfig: `{"mappings": [`,
+		docs:       []string{"packages/backend/CLAUDE.md"},
+	})
+	provider := sessionProvider(t.TempDir())
+
+	if _, err := runEnforce(t, provider, "s", filepath.Join(root, "packages", "backend", "foo.ts")); err != nil {
+		t.Errorf("expected allow with malformed config, got %v", err)
+	}
+}
+
+func TestEnforce_DocsConfig_SkipPatterns(t *testing.T) {
+	root := setupProject(t, projectFixture{
+		docsConfig: `{"skipPatterns": ["/fixtures/"]}`,
+		docs:       []string{"packages/backend/CLAUDE.md"},
+	})
+	provider := sessionProvider(t.TempDir())
+
+	if _, err := runEnforce(t, provider, "s", filepath.Join(root, "packages", "backend", "fixtures", "user.ts")); err != nil {
+		t.Errorf("expected configured skip pattern to allow, got %v", err)
+	}
+	// Replacing the defaults means test files are gated again.
+	if _, err := runEnforce(t, provider, "s", filepath.Join(root, "packages", "backend", "foo.test.ts")); err == nil {
+		t.Error("expected foo.test.ts to be gated once skipPatterns replaces the defaults")
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Skip patterns and edge cases
 // ---------------------------------------------------------------------------
@@ -885,9 +1017,247 @@ func TestTrack_DoesNotDuplicate(t *testing.T) {
 	if err := trackWithProvider(bytes.NewReader(data), provider); err != nil {
 		t.Fatalf("track: %v", err)
 	}
-	loaded, _ := loadDocsReadWithProvider("s", provider)
-	if len(loaded) != 1 {
-		t.Errorf("expected 1 entry, got %d: %v", len(loaded), loaded)
+	loaded, _ := loadSessionWithProvider("s", provider)
+	if len(loaded.DocsRead) != 1 {
+		t.Errorf("expected 1 entry, got %d: %v", len(loaded.DocsRead), loaded.DocsRead)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Read cooldown (Read and Edit in the same tool batch)
+// ---------------------------------------------------------------------------
+
+// setNow pins the package clock for the duration of a test.
+func setNow(t *testing.T, at time.Time) {
+	t.Helper()
+	orig := now
+	now = func() time.Time { return at }
+	t.Cleanup(func() { now = orig })
+}
+
+func TestEnforce_ReadInSameBatch_AllowsBeforeTrack(t *testing.T) {
+	// Claude Code ordering for a batched Read + Edit: Read PreToolUse,
+	// Edit PreToolUse, and only then Read PostToolUse (track).
+	root := setupProject(t, projectFixture{
+		config: `{}`,
+		docs:   []string{"packages/backend/CLAUDE.md"},
+	})
+	provider := sessionProvider(t.TempDir())
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	setNow(t, start)
+
+	doc := filepath.Join(root, "packages", "backend", "CLAUDE.md")
+	if _, err := runEnforceTool(t, provider, "Read", "s", doc); err != nil {
+		t.Fatalf("enforce on Read: %v", err)
+	}
+
+	setNow(t, start.Add(300*time.Millisecond))
+	if _, err := runEnforce(t, provider, "s", filepath.Join(root, "packages", "backend", "foo.ts")); err != nil {
+		t.Fatalf("expected allow for a doc read in the same batch, got %v", err)
+	}
+}
+
+func TestEnforce_ReadCooldownExpired_Blocks(t *testing.T) {
+	root := setupProject(t, projectFixture{
+		config: `{ "readCooldownMs": 1000 }`,
+		docs:   []string{"packages/backend/CLAUDE.md"},
+	})
+	provider := sessionProvider(t.TempDir())
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	setNow(t, start)
+
+	doc := filepath.Join(root, "packages", "backend", "CLAUDE.md")
+	if _, err := runEnforceTool(t, provider, "Read", "s", doc); err != nil {
+		t.Fatalf("enforce on Read: %v", err)
+	}
+
+	// The Read never completed (no track), and the window has passed.
+	setNow(t, start.Add(2*time.Second))
+	_, err := runEnforce(t, provider, "s", filepath.Join(root, "packages", "backend", "foo.ts"))
+	if exitErr, ok := err.(*ExitError); !ok || exitErr.Code != 2 {
+		t.Fatalf("expected block after the cooldown, got %v", err)
+	}
+}
+
+func TestEnforce_ReadCooldownDisabled_Blocks(t *testing.T) {
+	root := setupProject(t, projectFixture{
+		config: `{ "readCooldownMs": 0 }`,
+		docs:   []string{"packages/backend/CLAUDE.md"},
+	})
+	provider := sessionProvider(t.TempDir())
+
+	doc := filepath.Join(root, "packages", "backend", "CLAUDE.md")
+	if _, err := runEnforceTool(t, provider, "Read", "s", doc); err != nil {
+		t.Fatalf("enforce on Read: %v", err)
+	}
+	_, err := runEnforce(t, provider, "s", filepath.Join(root, "packages", "backend", "foo.ts"))
+	if exitErr, ok := err.(*ExitError); !ok || exitErr.Code != 2 {
+		t.Fatalf("expected block with the cooldown disabled, got %v", err)
+	}
+}
+
+func TestEnforce_ReadCooldown_RequiresDocOnDisk(t *testing.T) {
+	// A Read of a doc that doesn't exist will fail, so it must not count.
+	root := setupProject(t, projectFixture{
+		config: `{
+			"autoDiscover": false,
+			"mappings": [
+				{ "pattern": "apps/web/", "docs": ["docs/frontend.md"] }
+			]
+		}`,
+	})
+	provider := sessionProvider(t.TempDir())
+
+	if _, err := runEnforceTool(t, provider, "Read", "s", filepath.Join(root, "docs", "frontend.md")); err != nil {
+		t.Fatalf("enforce on Read: %v", err)
+	}
+	_, err := runEnforce(t, provider, "s", filepath.Join(root, "apps", "web", "foo.tsx"))
+	if exitErr, ok := err.(*ExitError); !ok || exitErr.Code != 2 {
+		t.Fatalf("expected block for a missing doc, got %v", err)
+	}
+}
+
+func TestTrack_PreservesPendingReads(t *testing.T) {
+	// Tracking one doc must not drop the pending stamp of another doc read
+	// in the same batch.
+	root := setupProject(t, projectFixture{
+		config: `{
+			"autoDiscover": false,
+			"mappings": [
+				{ "pattern": "apps/web/", "docs": ["docs/a.md", "docs/b.md"] }
+			]
+		}`,
+		docs: []string{"docs/a.md", "docs/b.md"},
+	})
+	provider := sessionProvider(t.TempDir())
+
+	for _, doc := range []string{"a.md", "b.md"} {
+		if _, err := runEnforceTool(t, provider, "Read", "s", filepath.Join(root, "docs", doc)); err != nil {
+			t.Fatalf("enforce on Read %s: %v", doc, err)
+		}
+	}
+	input := HookInput{
+		ToolName:  "Read",
+		ToolInput: map[string]interface{}{"file_path": filepath.Join(root, "docs", "a.md")},
+		SessionID: "s",
+	}
+	data, _ := json.Marshal(input)
+	if err := trackWithProvider(bytes.NewReader(data), provider); err != nil {
+		t.Fatalf("track: %v", err)
+	}
+
+	if _, err := runEnforce(t, provider, "s", filepath.Join(root, "apps", "web", "foo.tsx")); err != nil {
+		t.Fatalf("expected allow with a tracked and a pending read, got %v", err)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Read expiry
+// ---------------------------------------------------------------------------
+
+// trackRead runs track mode for a Read of doc (project-relative).
+func trackRead(t *testing.T, provider sessionFileProvider, root, doc string) {
+	t.Helper()
+	input := HookInput{
+		ToolName:  "Read",
+		ToolInput: map[string]interface{}{"file_path": filepath.Join(root, filepath.FromSlash(doc))},
+		SessionID: "s",
+	}
+	data, _ := json.Marshal(input)
+	if err := trackWithProvider(bytes.NewReader(data), provider); err != nil {
+		t.Fatalf("track %s: %v", doc, err)
+	}
+}
+
+func TestEnforce_DocModifiedAfterRead_Blocks(t *testing.T) {
+	root := setupProject(t, projectFixture{
+		config: `{ "readCooldownMs": 0 }`,
+		docs:   []string{"packages/backend/CLAUDE.md"},
+	})
+	provider := sessionProvider(t.TempDir())
+	doc := filepath.Join(root, "packages", "backend", "CLAUDE.md")
+	target := filepath.Join(root, "packages", "backend", "foo.ts")
+
+	readAt := time.Now()
+	setNow(t, readAt)
+	trackRead(t, provider, root, "packages/backend/CLAUDE.md")
+	if _, err := runEnforce(t, provider, "s", target); err != nil {
+		t.Fatalf("expected allow right after reading, got %v", err)
+	}
+
+	// Someone updates the doc after it was read.
+	later := readAt.Add(time.Minute)
+	if err := os.Chtimes(doc, later, later); err != nil {
+		t.Fatal(err)
+	}
+	stderr, err := runEnforce(t, provider, "s", target)
+	if exitErr, ok := err.(*ExitError); !ok || exitErr.Code != 2 {
+		t.Fatalf("expected block once the doc changed, got %v", err)
+	}
+	if !strings.Contains(stderr, "packages/backend/CLAUDE.md (changed since you read it)") {
+		t.Errorf("expected stderr to explain the stale read, got %q", stderr)
+	}
+
+	// Reading it again clears the gate.
+	setNow(t, later.Add(time.Second))
+	trackRead(t, provider, root, "packages/backend/CLAUDE.md")
+	if _, err := runEnforce(t, provider, "s", target); err != nil {
+		t.Fatalf("expected allow after re-reading, got %v", err)
+	}
+}
+
+func TestEnforce_ReadTTLExpired_Blocks(t *testing.T) {
+	root := setupProject(t, projectFixture{
+		config: `{ "readCooldownMs": 0, "readTtlMinutes": 30 }`,
+		docs:   []string{"packages/backend/CLAUDE.md"},
+	})
+	provider := sessionProvider(t.TempDir())
+	target := filepath.Join(root, "packages", "backend", "foo.ts")
+
+	start := time.Now()
+	setNow(t, start)
+	trackRead(t, provider, root, "packages/backend/CLAUDE.md")
+
+	setNow(t, start.Add(29*time.Minute))
+	if _, err := runEnforce(t, provider, "s", target); err != nil {
+		t.Fatalf("expected allow within the TTL, got %v", err)
+	}
+
+	setNow(t, start.Add(31*time.Minute))
+	stderr, err := runEnforce(t, provider, "s", target)
+	if exitErr, ok := err.(*ExitError); !ok || exitErr.Code != 2 {
+		t.Fatalf("expected block after the TTL, got %v", err)
+	}
+	if !strings.Contains(stderr, "read more than 30 minutes ago") {
+		t.Errorf("expected stderr to explain the expiry, got %q", stderr)
+	}
+}
+
+func TestSession_LegacyStringFormat(t *testing.T) {
+	root := setupProject(t, projectFixture{
+		config: `{ "readCooldownMs": 0, "readTtlMinutes": 1 }`,
+		docs:   []string{"packages/backend/CLAUDE.md"},
+	})
+	provider := sessionProvider(t.TempDir())
+	sessionFile := provider("s")
+	if err := os.MkdirAll(filepath.Dir(sessionFile), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(sessionFile, []byte(`{"docs_read":["packages/backend/CLAUDE.md"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := loadSessionWithProvider("s", provider)
+	if err != nil {
+		t.Fatalf("load legacy session: %v", err)
+	}
+	if len(loaded.DocsRead) != 1 || loaded.DocsRead[0].Doc != "packages/backend/CLAUDE.md" || !loaded.DocsRead[0].ReadAt.IsZero() {
+		t.Fatalf("unexpected legacy entries: %+v", loaded.DocsRead)
+	}
+
+	// Untimed reads from older sessions stay valid.
+	if _, err := runEnforce(t, provider, "s", filepath.Join(root, "packages", "backend", "foo.ts")); err != nil {
+		t.Errorf("expected allow for a legacy read, got %v", err)
 	}
 }
 
@@ -912,7 +1282,7 @@ func TestShouldCheckFile(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := shouldCheckFile(tt.path); got != tt.expect {
+			if got := shouldCheckFile(tt.path, skipPatterns); got != tt.expect {
 				t.Errorf("shouldCheckFile(%q) = %v, want %v", tt.path, got, tt.expect)
 			}
 		})
@@ -1079,21 +1449,25 @@ func TestContains(t *testing.T) {
 func TestSessionPersistence(t *testing.T) {
 	provider := sessionProvider(t.TempDir())
 	sessionID := "persist-test"
-	docs := []string{"packages/backend/CLAUDE.md", "apps/mobile/components/CLAUDE.md"}
+	readAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	docs := []DocRead{
+		{Doc: "packages/backend/CLAUDE.md", ReadAt: readAt},
+		{Doc: "apps/mobile/components/CLAUDE.md", ReadAt: readAt},
+	}
 
-	if err := saveDocsReadWithProvider(sessionID, docs, provider); err != nil {
+	if err := saveSessionWithProvider(sessionID, &SessionData{DocsRead: docs}, provider); err != nil {
 		t.Fatalf("save failed: %v", err)
 	}
-	loaded, err := loadDocsReadWithProvider(sessionID, provider)
+	loaded, err := loadSessionWithProvider(sessionID, provider)
 	if err != nil {
 		t.Fatalf("load failed: %v", err)
 	}
-	if len(loaded) != len(docs) {
-		t.Errorf("expected %d docs, got %d", len(docs), len(loaded))
+	if len(loaded.DocsRead) != len(docs) {
+		t.Errorf("expected %d docs, got %d", len(docs), len(loaded.DocsRead))
 	}
-	for _, d := range docs {
-		if !contains(loaded, d) {
-			t.Errorf("missing doc %q in %v", d, loaded)
+	for i, d := range docs {
+		if i >= len(loaded.DocsRead) || loaded.DocsRead[i].Doc != d.Doc || !loaded.DocsRead[i].ReadAt.Equal(d.ReadAt) {
+			t.Errorf("missing doc %+v in %v", d, loaded.DocsRead)
 		}
 	}
 }
@@ -4,14 +4,18 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/milehighideas/claude-hooks/internal/jsonc"
+	"github.com/milehighideas/claude-hooks/internal/pkgmanager"
+	"github.com/milehighideas/claude-hooks/internal/session"
 )
 
 const (
@@ -31,11 +35,9 @@ func escapeJestPattern(path string) string {
 	return result
 }
 
-// sessionData holds tracked files for a session
-type sessionData struct {
-	SourceFiles []string `json:"source_files"`
-	TestFiles   []string `json:"test_files"`
-}
+// sessionData holds tracked files for a session, as written by
+// track-edited-files
+type sessionData = session.Data
 
 // hookInput represents the JSON input from Claude
 type hookInput struct {
@@ -63,46 +65,20 @@ func isGitCommit(command string) bool {
 
 // loadSessionData loads session tracking data
 func loadSessionData(sessionID string) sessionData {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return sessionData{SourceFiles: []string{}, TestFiles: []string{}}
-	}
-
-	sessionFile := filepath.Join(homeDir, ".claude", "sessions", sessionID+".json")
-	data, err := os.ReadFile(sessionFile)
+	path, err := session.Path(sessionID)
 	if err != nil {
 		return sessionData{SourceFiles: []string{}, TestFiles: []string{}}
 	}
-
-	var sd sessionData
-	if err := json.Unmarshal(data, &sd); err != nil {
-		return sessionData{SourceFiles: []string{}, TestFiles: []string{}}
-	}
-
-	if sd.SourceFiles == nil {
-		sd.SourceFiles = []string{}
-	}
-	if sd.TestFiles == nil {
-		sd.TestFiles = []string{}
-	}
-
-	return sd
+	return *session.Load(path)
 }
 
 // saveSessionData persists session tracking data
 func saveSessionData(sessionID string, sd sessionData) error {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return err
-	}
-
-	sessionFile := filepath.Join(homeDir, ".claude", "sessions", sessionID+".json")
-	data, err := json.MarshalIndent(sd, "", "  ")
+	path, err := session.Path(sessionID)
 	if err != nil {
 		return err
 	}
-
-	return os.WriteFile(sessionFile, data, 0644)
+	return session.Save(path, &sd)
 }
 
 // cleanStaleEntries removes entries for files that no longer exist on disk
@@ -122,10 +98,11 @@ func cleanStaleEntries(sd sessionData) sessionData {
 		}
 	}
 
-	return sessionData{
-		SourceFiles: cleanedSources,
-		TestFiles:   cleanedTests,
-	}
+	// Keep the rest of the record (edited files, per-file stats) intact
+	cleaned := sd
+	cleaned.SourceFiles = cleanedSources
+	cleaned.TestFiles = cleanedTests
+	return cleaned
 }
 
 // getGitStagedFiles returns absolute paths of files staged for commit
@@ -339,8 +316,63 @@ func checkVitestSetup(projectRoot string) bool {
 	return true
 }
 
-// runTests runs tests for the given test files
-func runTests(testFiles []string, projectType, projectRoot string) (bool, string) {
+// isStreamEnabled reports whether CLAUDE_HOOKS_STREAM asks for test output to
+// be streamed live while the tests run.
+func isStreamEnabled() bool {
+	val := os.Getenv("CLAUDE_HOOKS_STREAM")
+	return val == "true" || val == "1"
+}
+
+const (
+	// testTimeoutEnvVar sets how many seconds a test run may take
+	testTimeoutEnvVar = "CLAUDE_HOOKS_TEST_TIMEOUT"
+
+	defaultTestTimeout = 120 * time.Second
+)
+
+// testTimeout reads the limit from CLAUDE_HOOKS_TEST_TIMEOUT, falling back to
+// the default when it is unset or not a positive whole number.
+func testTimeout() time.Duration {
+	seconds, err := strconv.Atoi(os.Getenv(testTimeoutEnvVar))
+	if err != nil || seconds <= 0 {
+		return defaultTestTimeout
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// timeoutMessage names the suite that hung: its project type and the test
+// files it was running.
+func timeoutMessage(timeout time.Duration, projectType string, testFiles []string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Tests timed out after %s (%s project)\n", timeout, projectType)
+	sb.WriteString("Test files:\n")
+	for _, f := range testFiles {
+		fmt.Fprintf(&sb, "  - %s\n", f)
+	}
+	fmt.Fprintf(&sb, "Set %s (seconds) to change the limit.\n", testTimeoutEnvVar)
+	return sb.String()
+}
+
+// streamOutput receives live test output when streaming is enabled; tests
+// swap it for a buffer.
+var streamOutput io.Writer = os.Stderr
+
+// lookPath is the indirection point for tests; production code resolves
+// binaries with exec.LookPath.
+var lookPath = exec.LookPath
+
+// resolvePackageManager returns the path of the package manager binary, or an
+// error naming the manager and where it was chosen when it is not on PATH.
+func resolvePackageManager(pm pkgmanager.Resolution) (string, error) {
+	path, err := lookPath(pm.Name)
+	if err != nil {
+		return "", fmt.Errorf("package manager %q (from %s) is not installed or not on PATH", pm.Name, pm.Describe("enforceTestsOnCommitConfig.packageManager in "+preCommitConfigFile))
+	}
+	return path, nil
+}
+
+// runTests runs tests for the given test files with packageManager.
+func runTests(testFiles []string, projectType, projectRoot, packageManager string) (bool, string) {
 	if len(testFiles) == 0 {
 		return true, ""
 	}
@@ -377,7 +409,7 @@ func runTests(testFiles []string, projectType, projectRoot string) (bool, string
 	case "backend":
 		args := []string{"run", "test:run", "--"}
 		args = append(args, relativePaths...)
-		cmd = exec.Command("npm", args...)
+		cmd = exec.Command(packageManager, args...)
 	case "mobile":
 		args := []string{"run", "test", "--", "--watchAll=false", "--no-watchman"}
 		// Escape regex special characters for Jest pattern matching
@@ -385,36 +417,54 @@ func runTests(testFiles []string, projectType, projectRoot string) (bool, string
 		for _, p := range relativePaths {
 			args = append(args, escapeJestPattern(p))
 		}
-		cmd = exec.Command("npm", args...)
+		cmd = exec.Command(packageManager, args...)
 	case "web", "portal":
 		args := []string{"run", "test:run", "--"}
 		args = append(args, relativePaths...)
-		cmd = exec.Command("npm", args...)
+		cmd = exec.Command(packageManager, args...)
 	default:
 		return false, fmt.Sprintf("Unknown project type: %s", projectType)
 	}
 
 	cmd.Dir = projectRoot
+	setProcessGroup(cmd)
+	// A runner that escaped the process group could hold the output pipes
+	// open after the kill; stop waiting for them shortly after.
+	cmd.WaitDelay = 2 * time.Second
 
 	// Set timeout
+	timeout := testTimeout()
 	done := make(chan struct{})
 	var output []byte
 	var cmdErr error
 
 	go func() {
-		output, cmdErr = cmd.CombinedOutput()
+		if isStreamEnabled() {
+			// Stream live while keeping a copy for the summary below.
+			var buf bytes.Buffer
+			w := io.MultiWriter(streamOutput, &buf)
+			cmd.Stdout = w
+			cmd.Stderr = w
+			cmdErr = cmd.Run()
+			output = buf.Bytes()
+		} else {
+			output, cmdErr = cmd.CombinedOutput()
+		}
 		close(done)
 	}()
 
 	select {
 	case <-done:
 		// Command completed
-	case <-time.After(120 * time.Second):
-		// Timeout
-		_ = cmd.Process.Kill()
-		return false, "Tests timed out after 120 seconds"
+	case <-time.After(timeout):
+		// Kill the whole group so no test runner is left orphaned
+		killProcessGroup(cmd)
+		<-done
+		return false, timeoutMessage(timeout, projectType, relativePaths)
 	}
 
+	// Only the stored summary is truncated; a streamed run already showed
+	// everything.
 	outputStr := string(output)
 	if len(outputStr) > 3000 {
 		outputStr = outputStr[len(outputStr)-3000:]
@@ -756,6 +806,53 @@ type enforceConfig struct {
 	// ExcludePaths skips staged files whose project-relative path contains
 	// any of these substrings. Exclusions always win over AppPaths.
 	ExcludePaths []string `json:"excludePaths,omitempty"`
+	// PackageManager runs the test scripts when package.json's
+	// packageManager field and lockfiles don't decide. Empty = npm.
+	PackageManager string `json:"packageManager,omitempty"`
+	// AllowDirectoryTests lets a directory-level test (see
+	// DirectoryTestNames) cover every source file in its directory that has
+	// no co-located test of its own. Default false: strict 1:1 tests.
+	AllowDirectoryTests bool `json:"allowDirectoryTests,omitempty"`
+	// DirectoryTestNames are the file names treated as directory-level
+	// tests; "__dir__" stands for the directory's own name. Empty =
+	// defaultDirectoryTestNames.
+	DirectoryTestNames []string `json:"directoryTestNames,omitempty"`
+}
+
+// defaultDirectoryTestNames are the directory-level test names used when
+// allowDirectoryTests is set without directoryTestNames.
+var defaultDirectoryTestNames = []string{"index.test.ts", "index.test.tsx", "__dir__.test.ts", "__dir__.test.tsx"}
+
+// directoryTestFor returns the directory-level test that covers sourcePath,
+// or "" when directory tests are off or none of the configured names is
+// found in its directory. found reports whether a candidate test exists.
+func (c enforceConfig) directoryTestFor(sourcePath string, found func(string) bool) string {
+	if !c.AllowDirectoryTests {
+		return ""
+	}
+	names := c.DirectoryTestNames
+	if len(names) == 0 {
+		names = defaultDirectoryTestNames
+	}
+	dir := filepath.Dir(sourcePath)
+	for _, name := range names {
+		candidate := filepath.Join(dir, strings.ReplaceAll(name, "__dir__", filepath.Base(dir)))
+		if candidate != sourcePath && found(candidate) {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// packageManager resolves the package manager for the project at root:
+// package.json's packageManager field, then a lockfile, then the configured
+// one, defaulting to npm.
+func (c enforceConfig) packageManager(root string) pkgmanager.Resolution {
+	r := pkgmanager.Resolve(root, c.PackageManager)
+	if r.Name == "" {
+		r.Name = "npm"
+	}
+	return r
 }
 
 // rootConfig is the minimal view of .pre-commit.json this hook decodes — just
@@ -892,14 +989,14 @@ func main() {
 	}
 
 	// 2. Load session data
-	session := loadSessionData(sessionID)
+	tracked := loadSessionData(sessionID)
 
 	// 3. Clean stale entries (self-healing: removes renamed/deleted files)
-	cleanedSession := cleanStaleEntries(session)
+	cleanedSession := cleanStaleEntries(tracked)
 
 	// 4. Save cleaned session data if anything changed
-	if len(cleanedSession.SourceFiles) != len(session.SourceFiles) ||
-		len(cleanedSession.TestFiles) != len(session.TestFiles) {
+	if len(cleanedSession.SourceFiles) != len(tracked.SourceFiles) ||
+		len(cleanedSession.TestFiles) != len(tracked.TestFiles) {
 		_ = saveSessionData(sessionID, cleanedSession)
 	}
 
@@ -968,23 +1065,29 @@ func main() {
 		// 1. Test file is in session's test_files list
 		// 2. Test file is being staged in this commit
 		// 3. Test file exists on disk
-		testExists := testFilesEdited[expectedTest]
-		if !testExists {
+		// 4. With allowDirectoryTests, a directory-level test is found
+		//    the same ways
+		testFound := func(testPath string) bool {
+			if testFilesEdited[testPath] {
+				return true
+			}
 			// Check if any edited test file contains the expected test path
 			for tf := range testFilesEdited {
-				if strings.Contains(tf, expectedTest) {
-					testExists = true
-					break
+				if strings.Contains(tf, testPath) {
+					return true
 				}
 			}
+			return stagedFilesSet[testPath] || checkTestExists(testPath, cwd)
 		}
+
+		testPath := expectedTest
+		testExists := testFound(expectedTest)
 		if !testExists {
-			// Check if test file is being staged in this commit
-			testExists = stagedFilesSet[expectedTest]
-		}
-		if !testExists {
-			// Check if test file exists on disk
-			testExists = checkTestExists(expectedTest, cwd)
+			if dirTest := enforceCfg.directoryTestFor(sourceFile, testFound); dirTest != "" {
+				fmt.Fprintf(os.Stderr, "ℹ️  %s covered by directory test %s\n", sourceFile, dirTest)
+				testPath = dirTest
+				testExists = true
+			}
 		}
 
 		if !testExists {
@@ -994,14 +1097,17 @@ func main() {
 			}{sourceFile, expectedTest})
 		} else {
 			// Find the actual test path
-			actualTestPath := expectedTest
+			actualTestPath := testPath
 			for tf := range testFilesEdited {
-				if strings.Contains(tf, expectedTest) {
+				if strings.Contains(tf, testPath) {
 					actualTestPath = tf
 					break
 				}
 			}
-			testsToRun[projectType] = append(testsToRun[projectType], actualTestPath)
+			// A directory test covering several files only runs once
+			if !containsFile(testsToRun[projectType], actualTestPath) {
+				testsToRun[projectType] = append(testsToRun[projectType], actualTestPath)
+			}
 		}
 	}
 
@@ -1014,10 +1120,28 @@ func main() {
 			msg += fmt.Sprintf("    Expected: %s\n\n", mt.expected)
 		}
 		msg += "Create the test files before committing.\n"
+		if enforceCfg.AllowDirectoryTests {
+			names := enforceCfg.DirectoryTestNames
+			if len(names) == 0 {
+				names = defaultDirectoryTestNames
+			}
+			msg += fmt.Sprintf("A directory-level test (%s) also covers every file in its directory.\n", strings.Join(names, ", "))
+		}
 		fmt.Fprint(os.Stderr, msg)
 		os.Exit(exitBlock)
 	}
 
+	// Resolve the package manager once, before any test run, so a missing
+	// binary is reported by name rather than as a failing test.
+	pm := enforceCfg.packageManager(preCommitRoot)
+	packageManager := pm.Name
+	if len(testsToRun["backend"])+len(testsToRun["mobile"])+len(testsToRun["web"])+len(testsToRun["portal"]) > 0 {
+		if _, err := resolvePackageManager(pm); err != nil {
+			fmt.Fprintf(os.Stderr, "\n❌ COMMIT BLOCKED - %v\n", err)
+			os.Exit(exitBlock)
+		}
+	}
+
 	// Run tests for each project type
 	allPassed := true
 	var testOutput strings.Builder
@@ -1076,7 +1200,7 @@ func main() {
 			}
 		}
 
-		passed, output := runTests(testList, projectType, projectRoot)
+		passed, output := runTests(testList, projectType, projectRoot, packageManager)
 		testOutput.WriteString(output)
 
 		if !passed {
@@ -2,9 +2,15 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/milehighideas/claude-hooks/internal/pkgmanager"
 )
 
 func TestIsGitCommit(t *testing.T) {
@@ -532,6 +538,49 @@ func TestCleanStaleEntries(t *testing.T) {
 	}
 }
 
+func TestSessionDataKeepsEditStats(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+
+	source := filepath.Join(tmpDir, "cart.ts")
+	_ = os.WriteFile(source, []byte("// cart"), 0644)
+	deleted := filepath.Join(tmpDir, "gone.ts")
+
+	sessionsDir := filepath.Join(tmpDir, ".claude", "sessions")
+	if err := os.MkdirAll(sessionsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := fmt.Sprintf(`{
+  "source_files": [%q, %q],
+  "test_files": [],
+  "edited_files": [%q, %q],
+  "files": {%q: {"first_seen": "2026-10-16T09:00:00Z", "edits": 3}}
+}`, source, deleted, source, deleted, source)
+	if err := os.WriteFile(filepath.Join(sessionsDir, "rich.json"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	sd := loadSessionData("rich")
+	if stats, ok := sd.Stats(source); !ok || stats.Edits != 3 {
+		t.Errorf("Stats(%q) = %+v, %v; want 3 edits", source, stats, ok)
+	}
+
+	// Cleaning stale entries and saving must not drop the rest of the record
+	if err := saveSessionData("rich", cleanStaleEntries(sd)); err != nil {
+		t.Fatal(err)
+	}
+	reloaded := loadSessionData("rich")
+	if len(reloaded.SourceFiles) != 1 || reloaded.SourceFiles[0] != source {
+		t.Errorf("SourceFiles = %v, want only %s", reloaded.SourceFiles, source)
+	}
+	if len(reloaded.EditedFiles) != 2 {
+		t.Errorf("EditedFiles = %v, want both files kept", reloaded.EditedFiles)
+	}
+	if stats, _ := reloaded.Stats(source); stats.Edits != 3 {
+		t.Errorf("edit count lost on save: %+v", stats)
+	}
+}
+
 func TestIntersectFiles(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -756,6 +805,62 @@ func stringSlicesEqual(a, b []string) bool {
 	return true
 }
 
+func TestDirectoryTestFor(t *testing.T) {
+	tmpDir := t.TempDir()
+	hooksDir := filepath.Join(tmpDir, "src", "hooks")
+	utilsDir := filepath.Join(tmpDir, "src", "utils")
+	plainDir := filepath.Join(tmpDir, "src", "plain")
+	for _, dir := range []string{hooksDir, utilsDir, plainDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, f := range []string{
+		filepath.Join(hooksDir, "index.test.ts"),
+		filepath.Join(utilsDir, "utils.test.ts"),
+	} {
+		if err := os.WriteFile(f, []byte("// test"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	found := func(path string) bool { return checkTestExists(path, tmpDir) }
+
+	on := enforceConfig{AllowDirectoryTests: true}
+	cases := []struct {
+		name   string
+		cfg    enforceConfig
+		source string
+		want   string
+	}{
+		{"off by default", enforceConfig{}, filepath.Join(hooksDir, "useAuth.ts"), ""},
+		{"index test covers first file", on, filepath.Join(hooksDir, "useAuth.ts"), filepath.Join(hooksDir, "index.test.ts")},
+		{"index test covers second file", on, filepath.Join(hooksDir, "useTheme.tsx"), filepath.Join(hooksDir, "index.test.ts")},
+		{"__dir__ names the directory", on, filepath.Join(utilsDir, "format.ts"), filepath.Join(utilsDir, "utils.test.ts")},
+		{"no directory test", on, filepath.Join(plainDir, "a.ts"), ""},
+		{"configured names replace defaults", enforceConfig{AllowDirectoryTests: true, DirectoryTestNames: []string{"__dir__.test.ts"}}, filepath.Join(hooksDir, "useAuth.ts"), ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.cfg.directoryTestFor(tc.source, found); got != tc.want {
+				t.Errorf("directoryTestFor(%q) = %q, want %q", tc.source, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLoadProjectConfigDirectoryTests(t *testing.T) {
+	root := t.TempDir()
+	raw := `{"features":{"enforceTestsOnCommit":true},"enforceTestsOnCommitConfig":{"allowDirectoryTests":true,"directoryTestNames":["all.test.ts"]}}`
+	if err := os.WriteFile(filepath.Join(root, ".pre-commit.json"), []byte(raw), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, _ := loadProjectConfig(root)
+	if !cfg.AllowDirectoryTests || !stringSlicesEqual(cfg.DirectoryTestNames, []string{"all.test.ts"}) {
+		t.Errorf("directory test settings not loaded: %+v", cfg)
+	}
+}
+
 func TestIsFileInScope(t *testing.T) {
 	cases := []struct {
 		name string
@@ -785,3 +890,112 @@ func TestIsFileInScope(t *testing.T) {
 		})
 	}
 }
+
+func TestResolvePackageManager(t *testing.T) {
+	origLookPath := lookPath
+	t.Cleanup(func() { lookPath = origLookPath })
+
+	lookPath = func(name string) (string, error) {
+		if name == "pnpm" {
+			return "/usr/bin/pnpm", nil
+		}
+		return "", errors.New("executable file not found in $PATH")
+	}
+
+	path, err := resolvePackageManager(pkgmanager.Resolution{Name: "pnpm", Source: pkgmanager.SourceConfig})
+	if err != nil || path != "/usr/bin/pnpm" {
+		t.Fatalf("resolvePackageManager(pnpm) = %q, %v", path, err)
+	}
+
+	_, err = resolvePackageManager(pkgmanager.Resolution{Name: "yarn", Source: pkgmanager.SourceConfig})
+	if err == nil {
+		t.Fatal("expected an error for a missing package manager")
+	}
+	for _, want := range []string{`"yarn"`, "packageManager", preCommitConfigFile, "not installed"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %s", err, want)
+		}
+	}
+}
+
+func TestEnforceConfigPackageManager(t *testing.T) {
+	// A .git marker keeps resolution from walking above the temp dir.
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := (enforceConfig{}).packageManager(root); got.Name != "npm" {
+		t.Errorf("default packageManager = %q, want npm", got.Name)
+	}
+	if got := (enforceConfig{PackageManager: "pnpm"}).packageManager(root); got.Name != "pnpm" {
+		t.Errorf("packageManager = %q, want pnpm", got.Name)
+	}
+
+	// A lockfile beats the config; package.json's field beats both.
+	if err := os.WriteFile(filepath.Join(root, "yarn.lock"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := (enforceConfig{PackageManager: "pnpm"}).packageManager(root); got.Name != "yarn" {
+		t.Errorf("packageManager with yarn.lock = %q, want yarn", got.Name)
+	}
+	if err := os.WriteFile(filepath.Join(root, "package.json"), []byte(`{"packageManager": "bun@1.1.0"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got := (enforceConfig{PackageManager: "pnpm"}).packageManager(root)
+	if got.Name != "bun" || got.Source != pkgmanager.SourcePackageJSON {
+		t.Errorf("packageManager with field = %+v, want bun from package.json", got)
+	}
+}
+
+func TestRunTestsStreamsOutput(t *testing.T) {
+	dir := t.TempDir()
+	fakePM := filepath.Join(dir, "fakepm")
+	script := "#!/bin/sh\necho \"running $*\"\necho boom >&2\nexit 1\n"
+	if err := os.WriteFile(fakePM, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	origOutput := streamOutput
+	t.Cleanup(func() { streamOutput = origOutput })
+
+	for _, stream := range []bool{false, true} {
+		var live strings.Builder
+		streamOutput = &live
+		if stream {
+			t.Setenv("CLAUDE_HOOKS_STREAM", "1")
+		} else {
+			t.Setenv("CLAUDE_HOOKS_STREAM", "")
+		}
+
+		passed, output := runTests([]string{"a.test.ts"}, "backend", dir, fakePM)
+		if passed {
+			t.Errorf("stream=%v: expected failure", stream)
+		}
+		if !strings.Contains(output, "running run test:run -- a.test.ts") || !strings.Contains(output, "boom") {
+			t.Errorf("stream=%v: summary missing output: %q", stream, output)
+		}
+		if stream != (live.Len() > 0) {
+			t.Errorf("stream=%v: live output = %q", stream, live.String())
+		}
+	}
+}
+
+func TestTestTimeout(t *testing.T) {
+	tests := []struct {
+		env  string
+		want time.Duration
+	}{
+		{"", defaultTestTimeout},
+		{"300", 300 * time.Second},
+		{"0", defaultTestTimeout},
+		{"-5", defaultTestTimeout},
+		{"2m", defaultTestTimeout},
+	}
+	for _, tt := range tests {
+		t.Setenv(testTimeoutEnvVar, tt.env)
+		if got := testTimeout(); got != tt.want {
+			t.Errorf("%s=%q: testTimeout() = %s, want %s", testTimeoutEnvVar, tt.env, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+//go:build !windows
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestRunTestsTimeoutKillsProcessGroup(t *testing.T) {
+	dir := t.TempDir()
+	pidFile := filepath.Join(dir, "child.pid")
+	// The runner starts a child (like a test worker) and then hangs itself.
+	fakePM := filepath.Join(dir, "fakepm")
+	script := "#!/bin/sh\nsleep 60 &\necho $! > " + pidFile + "\nsleep 60\n"
+	if err := os.WriteFile(fakePM, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(testTimeoutEnvVar, "1")
+	t.Setenv("CLAUDE_HOOKS_STREAM", "")
+
+	start := time.Now()
+	passed, output := runTests([]string{"convex/a.test.ts", "convex/b.test.ts"}, "backend", dir, fakePM)
+	if passed {
+		t.Fatal("expected the hung run to fail")
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("runTests returned after %s, want shortly after the 1s timeout", elapsed)
+	}
+	for _, want := range []string{"timed out after 1s", "backend", "convex/a.test.ts", "convex/b.test.ts", testTimeoutEnvVar} {
+		if !strings.Contains(output, want) {
+			t.Errorf("timeout message missing %q:\n%s", want, output)
+		}
+	}
+
+	data, err := os.ReadFile(pidFile)
+	if err != nil {
+		t.Fatalf("fake runner did not record its child: %v", err)
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The child is reaped by init once orphaned; give that a moment.
+	deadline := time.Now().Add(5 * time.Second)
+	for syscall.Kill(pid, 0) == nil {
+		if time.Now().After(deadline) {
+			_ = syscall.Kill(pid, syscall.SIGKILL)
+			t.Fatalf("child process %d outlived the timeout", pid)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
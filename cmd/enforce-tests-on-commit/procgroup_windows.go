@@ -0,0 +1,23 @@
+//go:build windows
+
+package main
+
+import (
+	"os/exec"
+	"strconv"
+)
+
+// setProcessGroup is a no-op on Windows; killProcessGroup walks the process
+// tree instead.
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// killProcessGroup kills cmd and every process it started.
+func killProcessGroup(cmd *exec.Cmd) {
+	if cmd.Process == nil {
+		return
+	}
+	kill := exec.Command("taskkill", "/T", "/F", "/PID", strconv.Itoa(cmd.Process.Pid))
+	if err := kill.Run(); err != nil {
+		_ = cmd.Process.Kill()
+	}
+}
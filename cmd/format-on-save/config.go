@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/milehighideas/claude-hooks/internal/jsonc"
+)
+
+// formatConfigFile maps file extensions to formatter commands. The nearest
+// one walking up from the edited file applies.
+const formatConfigFile = ".claude-hooks-format.json"
+
+// fileArg is replaced with the edited file's path in formatter args.
+const fileArg = "{file}"
+
+// formatConfig is the parsed .claude-hooks-format.json:
+//
+//	{"formatters": {".go": {"command": "gofmt", "args": ["-w", "{file}"]}}}
+type formatConfig struct {
+	Formatters map[string]*formatter `json:"formatters"`
+
+	dir string // directory holding the config file
+}
+
+// formatter is a command run on a saved file. Args may contain {file}; when
+// none does, the path is appended as the last argument.
+type formatter struct {
+	Command string   `json:"command"`
+	Args    []string `json:"args"`
+
+	dir   string // working directory, so relative commands resolve from the config
+	batch bool   // takes every file in one run (Prettier)
+}
+
+// loadFormatConfig finds the nearest .claude-hooks-format.json at or above
+// dir. Returns nil, nil when there is none.
+func loadFormatConfig(dir string) (*formatConfig, error) {
+	for {
+		path := filepath.Join(dir, formatConfigFile)
+		if _, err := os.Stat(path); err == nil {
+			var cfg formatConfig
+			if err := jsonc.Unmarshal(path, &cfg); err != nil {
+				return nil, fmt.Errorf("parsing %s: %w", path, err)
+			}
+			cfg.dir = dir
+			return &cfg, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// formatterFor returns the configured formatter for filePath's extension, or
+// nil. Keys match case-insensitively, with or without the leading dot.
+func (c *formatConfig) formatterFor(filePath string) *formatter {
+	if c == nil {
+		return nil
+	}
+	ext := strings.ToLower(filepath.Ext(filePath))
+	if ext == "" {
+		return nil
+	}
+	for key, f := range c.Formatters {
+		key = strings.ToLower(key)
+		if !strings.HasPrefix(key, ".") {
+			key = "." + key
+		}
+		if key == ext && f != nil && f.Command != "" {
+			resolved := *f
+			resolved.dir = c.dir
+			return &resolved
+		}
+	}
+	return nil
+}
+
+// args expands {file} in the formatter's args. An argument that is exactly
+// {file} becomes every path; one that embeds it is repeated per path.
+func (f *formatter) args(filePaths ...string) []string {
+	var args []string
+	substituted := false
+	for _, a := range f.Args {
+		switch {
+		case a == fileArg:
+			substituted = true
+			args = append(args, filePaths...)
+		case strings.Contains(a, fileArg):
+			substituted = true
+			for _, p := range filePaths {
+				args = append(args, strings.ReplaceAll(a, fileArg, p))
+			}
+		default:
+			args = append(args, a)
+		}
+	}
+	if !substituted {
+		args = append(args, filePaths...)
+	}
+	return args
+}
+
+// run executes the formatter once on filePaths, passing its stderr through.
+func (f *formatter) run(filePaths []string, stderr io.Writer) error {
+	command := f.Command
+	if f.dir != "" && strings.ContainsRune(command, '/') && !filepath.IsAbs(command) {
+		command = filepath.Join(f.dir, command)
+	}
+	cmd := exec.Command(command, f.args(filePaths...)...)
+	cmd.Dir = f.dir
+	cmd.Stderr = stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s failed: %w", filepath.Base(f.Command), err)
+	}
+
+	return nil
+}
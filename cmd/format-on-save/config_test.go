@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string, mode os.FileMode) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), mode); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestResolveFormatter(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, formatConfigFile), `{
+		// comments are allowed
+		"formatters": {
+			".go": {"command": "gofmt", "args": ["-w", "{file}"]},
+			"py": {"command": "black", "args": ["--quiet"]},
+			".RS": {"command": "rustfmt"},
+			".md": {"command": "mdformat"}
+		}
+	}`, 0644)
+	prettier := filepath.Join(root, "node_modules", ".bin", "prettier")
+	writeFile(t, prettier, "#!/bin/sh\n", 0755)
+
+	cfg, err := loadFormatConfig(filepath.Join(root, "src", "pkg"))
+	if err != nil {
+		t.Fatalf("loadFormatConfig() error = %v", err)
+	}
+
+	tests := []struct {
+		file        string
+		wantCommand string
+		wantArgs    []string
+	}{
+		{"src/pkg/main.go", "gofmt", []string{"-w", "FILE"}},
+		{"src/tool.py", "black", []string{"--quiet", "FILE"}},
+		{"src/lib.rs", "rustfmt", []string{"FILE"}},
+		{"README.md", "mdformat", []string{"FILE"}}, // config beats the Prettier default
+		{"src/app.tsx", prettier, []string{"--write", "FILE"}},
+		{"src/config.yaml", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			path := filepath.Join(root, tt.file)
+			f := resolveFormatter(cfg, path)
+			if tt.wantCommand == "" {
+				if f != nil {
+					t.Fatalf("resolveFormatter() = %+v, want nil", f)
+				}
+				return
+			}
+			if f == nil {
+				t.Fatalf("resolveFormatter() = nil, want %s", tt.wantCommand)
+			}
+			var want []string
+			for _, a := range tt.wantArgs {
+				want = append(want, strings.ReplaceAll(a, "FILE", path))
+			}
+			if f.Command != tt.wantCommand || !reflect.DeepEqual(f.args(path), want) {
+				t.Errorf("resolveFormatter() = %s %v, want %s %v", f.Command, f.args(path), tt.wantCommand, want)
+			}
+		})
+	}
+}
+
+func TestLoadFormatConfigMissing(t *testing.T) {
+	dir := t.TempDir()
+	cfg, err := loadFormatConfig(dir)
+	if err != nil || cfg != nil {
+		t.Fatalf("loadFormatConfig() = %v, %v; want nil, nil", cfg, err)
+	}
+	if f := cfg.formatterFor(filepath.Join(dir, "main.go")); f != nil {
+		t.Errorf("formatterFor() on a nil config = %+v", f)
+	}
+}
+
+func TestRunConfiguredFormatter(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "bin", "fmt"), "#!/bin/sh\necho formatted > \"$1\"\n", 0755)
+	writeFile(t, filepath.Join(root, formatConfigFile), `{
+		"formatters": {
+			".go": {"command": "./bin/fmt"},
+			".py": {"command": "false"}
+		}
+	}`, 0644)
+	goFile := filepath.Join(root, "main.go")
+	writeFile(t, goFile, "package main\n", 0644)
+
+	var stderr bytes.Buffer
+	input := `{"tool_name":"Write","tool_input":{"file_path":"` + goFile + `"}}`
+	if err := run(strings.NewReader(input), &stderr); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	if got, _ := os.ReadFile(goFile); string(got) != "formatted\n" {
+		t.Errorf("formatter did not run from the config dir: %q", got)
+	}
+
+	// A failing formatter is reported, never fatal (main always exits 0).
+	pyFile := filepath.Join(root, "tool.py")
+	input = `{"tool_name":"Write","tool_input":{"file_path":"` + pyFile + `"}}`
+	if err := run(strings.NewReader(input), &stderr); err == nil || !strings.Contains(err.Error(), "false failed") {
+		t.Errorf("run() error = %v, want a formatter failure", err)
+	}
+}
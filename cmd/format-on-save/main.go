@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -34,22 +35,52 @@ func main() {
 	os.Exit(0)
 }
 
+// run formats the file(s) named in the hook input. Files going to the same
+// Prettier binary are formatted in one invocation to avoid paying its cold
+// start per file; configured formatters run once per file.
 func run(stdin io.Reader, stderr io.Writer) error {
 	input, err := readInput(stdin)
 	if err != nil {
 		return err
 	}
 
-	filePath := getFilePath(input.ToolInput)
-	if filePath == "" {
-		return nil
-	}
+	configs := make(map[string]*formatConfig)
+	batches := make(map[string][]string)
+	var batchOrder []*formatter
+	var errs []error
+	for _, filePath := range getFilePaths(input.ToolInput) {
+		dir := filepath.Dir(filePath)
+		cfg, seen := configs[dir]
+		if !seen {
+			if cfg, err = loadFormatConfig(dir); err != nil {
+				// A broken config shouldn't stop the Prettier defaults.
+				fmt.Fprintf(stderr, "format-on-save: %v\n", err)
+			}
+			configs[dir] = cfg
+		}
 
-	if !shouldFormat(filePath) {
-		return nil
+		f := resolveFormatter(cfg, filePath)
+		if f == nil {
+			continue
+		}
+		if f.batch {
+			if _, ok := batches[f.Command]; !ok {
+				batchOrder = append(batchOrder, f)
+			}
+			batches[f.Command] = append(batches[f.Command], filePath)
+			continue
+		}
+		if err := f.run([]string{filePath}, stderr); err != nil {
+			errs = append(errs, err)
+		}
 	}
 
-	return runPrettier(filePath, stderr)
+	for _, f := range batchOrder {
+		if err := f.run(batches[f.Command], stderr); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
 }
 
 func readInput(r io.Reader) (*HookInput, error) {
@@ -66,11 +97,22 @@ func readInput(r io.Reader) (*HookInput, error) {
 	return &input, nil
 }
 
-func getFilePath(toolInput map[string]interface{}) string {
-	if fp, ok := toolInput["file_path"].(string); ok {
-		return fp
+// getFilePaths returns the tool input's file_paths array when present (a
+// multi-file edit), else its single file_path.
+func getFilePaths(toolInput map[string]interface{}) []string {
+	if list, ok := toolInput["file_paths"].([]interface{}); ok && len(list) > 0 {
+		var paths []string
+		for _, item := range list {
+			if fp, ok := item.(string); ok && fp != "" {
+				paths = append(paths, fp)
+			}
+		}
+		return paths
 	}
-	return ""
+	if fp, ok := toolInput["file_path"].(string); ok && fp != "" {
+		return []string{fp}
+	}
+	return nil
 }
 
 func shouldFormat(filePath string) bool {
@@ -78,15 +120,31 @@ func shouldFormat(filePath string) bool {
 	return formatExtensions[ext]
 }
 
-// runPrettier finds and runs prettier on the file.
-// Checks for a local project install first, then falls back to a global one.
-func runPrettier(filePath string, stderr io.Writer) error {
+// resolveFormatter picks the formatter for filePath: the config entry for its
+// extension, else Prettier for the extensions it handles unless
+// .prettierignore excludes the file. Returns nil when there is nothing to run.
+func resolveFormatter(cfg *formatConfig, filePath string) *formatter {
+	if f := cfg.formatterFor(filePath); f != nil {
+		return f
+	}
+	if !shouldFormat(filePath) || prettierIgnored(filePath) {
+		return nil
+	}
+	if prettier := findPrettier(filePath); prettier != "" {
+		return &formatter{Command: prettier, Args: []string{"--write", fileArg}, batch: true}
+	}
+	return nil
+}
+
+// findPrettier returns the nearest local prettier, walking up from the file,
+// then a global one, or "" when there is none.
+func findPrettier(filePath string) string {
 	// Walk up from the file to find the nearest node_modules/.bin/prettier.
 	dir := filepath.Dir(filePath)
 	for {
 		candidate := filepath.Join(dir, "node_modules", ".bin", "prettier")
 		if _, err := os.Stat(candidate); err == nil {
-			return execPrettier(candidate, filePath, stderr)
+			return candidate
 		}
 		parent := filepath.Dir(dir)
 		if parent == dir {
@@ -97,19 +155,8 @@ func runPrettier(filePath string, stderr io.Writer) error {
 
 	// Fall back to global prettier.
 	if path, err := exec.LookPath("prettier"); err == nil {
-		return execPrettier(path, filePath, stderr)
+		return path
 	}
 
-	return nil // No prettier found — silently skip.
-}
-
-func execPrettier(prettierPath, filePath string, stderr io.Writer) error {
-	cmd := exec.Command(prettierPath, "--write", filePath)
-	cmd.Stderr = stderr
-
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("prettier failed: %w", err)
-	}
-
-	return nil
+	return "" // No prettier found — silently skip.
 }
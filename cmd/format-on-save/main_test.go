@@ -1,6 +1,12 @@
 package main
 
 import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -38,3 +44,56 @@ func TestShouldFormat(t *testing.T) {
 		})
 	}
 }
+
+func TestGetFilePaths(t *testing.T) {
+	tests := []struct {
+		name      string
+		toolInput map[string]interface{}
+		expected  []string
+	}{
+		{"single path", map[string]interface{}{"file_path": "/p/a.ts"}, []string{"/p/a.ts"}},
+		{"multiple paths", map[string]interface{}{"file_paths": []interface{}{"/p/a.ts", "/p/b.go"}}, []string{"/p/a.ts", "/p/b.go"}},
+		{"paths win over path", map[string]interface{}{"file_path": "/p/a.ts", "file_paths": []interface{}{"/p/b.ts"}}, []string{"/p/b.ts"}},
+		{"empty paths fall back", map[string]interface{}{"file_path": "/p/a.ts", "file_paths": []interface{}{}}, []string{"/p/a.ts"}},
+		{"non-string entries skipped", map[string]interface{}{"file_paths": []interface{}{"/p/a.ts", 3, ""}}, []string{"/p/a.ts"}},
+		{"neither", map[string]interface{}{}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getFilePaths(tt.toolInput); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("getFilePaths() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRunBatchesPrettier(t *testing.T) {
+	root := t.TempDir()
+	log := filepath.Join(root, "prettier.log")
+	writeFile(t, filepath.Join(root, "node_modules", ".bin", "prettier"), "#!/bin/sh\necho \"$@\" >> "+log+"\n", 0755)
+
+	a := filepath.Join(root, "src", "a.ts")
+	b := filepath.Join(root, "src", "b.tsx")
+	goFile := filepath.Join(root, "main.go")
+	md := filepath.Join(root, "README.md")
+	input, _ := json.Marshal(HookInput{
+		ToolName:  "MultiEdit",
+		ToolInput: map[string]interface{}{"file_paths": []string{a, goFile, b, md}},
+	})
+
+	var stderr bytes.Buffer
+	if err := run(bytes.NewReader(input), &stderr); err != nil {
+		t.Fatalf("run() error = %v (stderr: %s)", err, stderr.String())
+	}
+
+	data, err := os.ReadFile(log)
+	if err != nil {
+		t.Fatalf("prettier never ran: %v", err)
+	}
+	calls := strings.Split(strings.TrimSpace(string(data)), "\n")
+	want := strings.Join([]string{"--write", a, b, md}, " ")
+	if len(calls) != 1 || calls[0] != want {
+		t.Errorf("prettier calls = %q, want one call %q", calls, want)
+	}
+}
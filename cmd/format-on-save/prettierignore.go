@@ -0,0 +1,172 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// prettierIgnoreFile lists paths Prettier skips, in gitignore syntax.
+const prettierIgnoreFile = ".prettierignore"
+
+// ignoreRule is one compiled .prettierignore line.
+type ignoreRule struct {
+	re      *regexp.Regexp
+	negate  bool // "!pattern" re-includes a path
+	dirOnly bool // "pattern/" only matches directories
+}
+
+// ignoreMatcher holds the rules of an ignore file, relative to its directory.
+type ignoreMatcher struct {
+	dir   string
+	rules []ignoreRule
+}
+
+// prettierIgnored reports whether the nearest .prettierignore walking up
+// from filePath excludes it. Without one nothing is ignored.
+func prettierIgnored(filePath string) bool {
+	m := findIgnoreMatcher(filepath.Dir(filePath), prettierIgnoreFile)
+	return m != nil && m.ignores(filePath)
+}
+
+// findIgnoreMatcher loads the nearest file named name at or above dir.
+func findIgnoreMatcher(dir, name string) *ignoreMatcher {
+	for {
+		if m, err := loadIgnoreMatcher(filepath.Join(dir, name)); err == nil {
+			return m
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil
+		}
+		dir = parent
+	}
+}
+
+// loadIgnoreMatcher parses an ignore file. Blank lines and # comments are
+// skipped; invalid patterns are dropped.
+func loadIgnoreMatcher(path string) (*ignoreMatcher, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	m := &ignoreMatcher{dir: filepath.Dir(path)}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if rule, ok := parseIgnoreRule(scanner.Text()); ok {
+			m.rules = append(m.rules, rule)
+		}
+	}
+	return m, scanner.Err()
+}
+
+// parseIgnoreRule compiles one gitignore-style line. A pattern containing a
+// slash (other than a trailing one) is anchored to the ignore file's
+// directory; otherwise it matches at any depth.
+func parseIgnoreRule(line string) (ignoreRule, bool) {
+	line = strings.TrimRight(line, " \t\r")
+	if line == "" || strings.HasPrefix(line, "#") {
+		return ignoreRule{}, false
+	}
+
+	var rule ignoreRule
+	if negated, ok := strings.CutPrefix(line, "!"); ok {
+		rule.negate = true
+		line = negated
+	}
+	line = strings.TrimPrefix(line, `\`) // "\#file" and "\!file" are literal
+	if trimmed, ok := strings.CutSuffix(line, "/"); ok {
+		rule.dirOnly = true
+		line = trimmed
+	}
+
+	anchored := strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+	if line == "" {
+		return ignoreRule{}, false
+	}
+
+	prefix := "^"
+	if !anchored {
+		prefix = "^(?:.*/)?"
+	}
+	re, err := regexp.Compile(prefix + globToRegexp(line) + "$")
+	if err != nil {
+		return ignoreRule{}, false
+	}
+	rule.re = re
+	return rule, true
+}
+
+// globToRegexp translates gitignore glob syntax: ** spans directories, *
+// and ? stay within one path segment, and [...] classes pass through.
+func globToRegexp(glob string) string {
+	var sb strings.Builder
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; {
+		case strings.HasPrefix(glob[i:], "**/"):
+			sb.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			sb.WriteString(".*")
+			i++
+		case c == '*':
+			sb.WriteString("[^/]*")
+		case c == '?':
+			sb.WriteString("[^/]")
+		case c == '[':
+			if end := strings.IndexByte(glob[i:], ']'); end > 0 {
+				class := glob[i+1 : i+end]
+				if negated, ok := strings.CutPrefix(class, "!"); ok {
+					class = "^" + negated
+				}
+				sb.WriteString("[" + class + "]")
+				i += end
+			} else {
+				sb.WriteString(`\[`)
+			}
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return sb.String()
+}
+
+// ignores reports whether filePath is excluded. As in gitignore, the last
+// matching rule wins, and a file under an excluded directory stays excluded
+// even if a later "!pattern" names the file.
+func (m *ignoreMatcher) ignores(filePath string) bool {
+	rel, err := filepath.Rel(m.dir, filePath)
+	if err != nil {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+	if rel == ".." || strings.HasPrefix(rel, "../") {
+		return false
+	}
+
+	segments := strings.Split(rel, "/")
+	for i := 1; i <= len(segments); i++ {
+		if m.match(strings.Join(segments[:i], "/"), i < len(segments)) {
+			return true
+		}
+	}
+	return false
+}
+
+// match applies the rules to one path; directory-only rules need isDir.
+func (m *ignoreMatcher) match(path string, isDir bool) bool {
+	ignored := false
+	for _, rule := range m.rules {
+		if (!rule.dirOnly || isDir) && rule.re.MatchString(path) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPrettierIgnored(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, prettierIgnoreFile), `# generated output
+dist/
+/coverage
+**/__generated__/**
+*.min.js
+legacy/*.ts
+!legacy/keep.ts
+!dist/keep.js
+`, 0644)
+
+	tests := []struct {
+		file    string
+		ignored bool
+	}{
+		{"dist/index.js", true},
+		{"packages/ui/dist/index.js", true},
+		{"dist/keep.js", true}, // can't re-include inside an excluded dir
+		{"coverage/lcov.json", true},
+		{"packages/ui/coverage/lcov.json", false}, // leading slash anchors to the root
+		{"src/__generated__/api.ts", true},
+		{"src/vendor/jquery.min.js", true},
+		{"legacy/old.ts", true},
+		{"legacy/keep.ts", false},
+		{"legacy/nested/new.ts", false}, // * stays within one segment
+		{"src/app.tsx", false},
+		{"distance.ts", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			if got := prettierIgnored(filepath.Join(root, filepath.FromSlash(tt.file))); got != tt.ignored {
+				t.Errorf("prettierIgnored(%s) = %v, want %v", tt.file, got, tt.ignored)
+			}
+		})
+	}
+}
+
+func TestResolveFormatterSkipsPrettierIgnored(t *testing.T) {
+	root := t.TempDir()
+	prettier := filepath.Join(root, "node_modules", ".bin", "prettier")
+	writeFile(t, prettier, "#!/bin/sh\n", 0755)
+	generated := filepath.Join(root, "src", "generated", "api.ts")
+	source := filepath.Join(root, "src", "app.ts")
+
+	// No .prettierignore: both files are formatted as before.
+	for _, f := range []string{generated, source} {
+		if resolveFormatter(nil, f) == nil {
+			t.Fatalf("resolveFormatter(%s) = nil without a .prettierignore", f)
+		}
+	}
+
+	writeFile(t, filepath.Join(root, prettierIgnoreFile), "src/generated/\n", 0644)
+	if f := resolveFormatter(nil, generated); f != nil {
+		t.Errorf("resolveFormatter(%s) = %+v, want nil for an ignored file", generated, f)
+	}
+	if resolveFormatter(nil, source) == nil {
+		t.Errorf("resolveFormatter(%s) = nil, want prettier", source)
+	}
+
+	// A configured formatter is not Prettier, so .prettierignore doesn't apply.
+	cfg := &formatConfig{Formatters: map[string]*formatter{".ts": {Command: "deno", Args: []string{"fmt"}}}}
+	if f := resolveFormatter(cfg, generated); f == nil || f.Command != "deno" {
+		t.Errorf("resolveFormatter() with config = %+v, want deno", f)
+	}
+}
+
+func TestPrettierIgnoreNearest(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, prettierIgnoreFile), "*.md\n", 0644)
+	writeFile(t, filepath.Join(root, "docs", prettierIgnoreFile), "drafts/\n", 0644)
+
+	// The nearest file applies, relative to its own directory.
+	if !prettierIgnored(filepath.Join(root, "docs", "drafts", "a.ts")) {
+		t.Error("docs/drafts/a.ts should be ignored by docs/.prettierignore")
+	}
+	if prettierIgnored(filepath.Join(root, "docs", "guide.md")) {
+		t.Error("docs/guide.md: only the nearest .prettierignore applies")
+	}
+	if !prettierIgnored(filepath.Join(root, "README.md")) {
+		t.Error("README.md should be ignored by the root .prettierignore")
+	}
+
+	if err := os.Remove(filepath.Join(root, prettierIgnoreFile)); err != nil {
+		t.Fatal(err)
+	}
+	if prettierIgnored(filepath.Join(root, "README.md")) {
+		t.Error("README.md ignored with no .prettierignore")
+	}
+}
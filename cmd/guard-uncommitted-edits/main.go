@@ -0,0 +1,229 @@
+// guard-uncommitted-edits is a Claude Code PreToolUse hook that warns before
+// an Edit, Write, or MultiEdit touches a file with uncommitted changes this
+// session did not make — typically a human's in-progress work.
+//
+// Attribution uses the session files track-edited-files writes to
+// ~/.claude/sessions/<session_id>.json: a dirty file listed there was changed
+// by this session and is edited without comment.
+//
+// Exit codes:
+//   - 0: Allow the edit (a warning may be printed to stderr)
+//   - 2: Block the edit (strict mode only; prints reason to stderr)
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/milehighideas/claude-hooks/internal/filewrite"
+	"github.com/milehighideas/claude-hooks/internal/jsonc"
+	"github.com/milehighideas/claude-hooks/internal/session"
+)
+
+// preCommitConfigFile is the shared project config; this hook reads only its
+// guardUncommittedEditsConfig block.
+const preCommitConfigFile = ".pre-commit.json"
+
+// hookInput represents the JSON structure from Claude Code's PreToolUse hook.
+type hookInput struct {
+	SessionID string              `json:"session_id"`
+	ToolName  string              `json:"tool_name"`
+	ToolInput filewrite.ToolInput `json:"tool_input"`
+	Cwd       string              `json:"cwd"`
+}
+
+// guardConfig is the guardUncommittedEditsConfig block of .pre-commit.json.
+type guardConfig struct {
+	// Strict blocks the edit (exit 2) instead of warning.
+	Strict bool `json:"strict,omitempty"`
+}
+
+// sessionData is the session file written by track-edited-files.
+type sessionData = session.Data
+
+// gitStatus returns the two-letter porcelain status of file, or "" when the
+// file is clean, missing, or outside a git repository.
+func gitStatus(file string) string {
+	dir := filepath.Dir(file)
+	if _, err := os.Stat(dir); err != nil {
+		return ""
+	}
+	cmd := exec.Command("git", "status", "--porcelain=v1", "--untracked-files=all", "--", filepath.Base(file))
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	line, _, _ := strings.Cut(string(output), "\n")
+	if len(line) < 2 {
+		return ""
+	}
+	return line[:2]
+}
+
+// sessionsDir returns ~/.claude/sessions, or "" when the home directory is
+// unknown.
+func sessionsDir() string {
+	dir, err := session.Dir()
+	if err != nil {
+		return ""
+	}
+	return dir
+}
+
+// containsPath reports whether paths lists file, comparing cleaned paths.
+func containsPath(paths []string, file string) bool {
+	file = filepath.Clean(file)
+	for _, p := range paths {
+		if p != "" && filepath.Clean(p) == file {
+			return true
+		}
+	}
+	return false
+}
+
+// otherSessions returns the IDs of sessions other than sessionID whose
+// tracked files include file, sorted.
+func otherSessions(dir, sessionID, file string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var ids []string
+	for _, entry := range entries {
+		name := entry.Name()
+		// <id>-docs.json files belong to docs-tracker.
+		if entry.IsDir() || !strings.HasSuffix(name, ".json") || strings.HasSuffix(name, "-docs.json") {
+			continue
+		}
+		id := strings.TrimSuffix(name, ".json")
+		if id == sessionID {
+			continue
+		}
+		if containsPath(session.Load(filepath.Join(dir, name)).Paths(), file) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// findPreCommitRoot walks up from dir looking for .pre-commit.json. Returns
+// "" when none is found.
+func findPreCommitRoot(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(abs, preCommitConfigFile)); err == nil {
+			return abs
+		}
+		parent := filepath.Dir(abs)
+		if parent == abs {
+			return ""
+		}
+		abs = parent
+	}
+}
+
+// loadConfig reads guardUncommittedEditsConfig from the nearest
+// .pre-commit.json above dir. Missing or malformed config yields the zero
+// value, which warns without blocking.
+func loadConfig(dir string) guardConfig {
+	root := findPreCommitRoot(dir)
+	if root == "" {
+		return guardConfig{}
+	}
+	data, err := jsonc.ReadFile(filepath.Join(root, preCommitConfigFile))
+	if err != nil || len(bytes.TrimSpace(data)) == 0 {
+		return guardConfig{}
+	}
+	var rc struct {
+		GuardUncommittedEditsConfig guardConfig `json:"guardUncommittedEditsConfig"`
+	}
+	if err := json.Unmarshal(data, &rc); err != nil {
+		return guardConfig{}
+	}
+	return rc.GuardUncommittedEditsConfig
+}
+
+// describeStatus turns a porcelain status code into a short phrase.
+func describeStatus(code string) string {
+	switch {
+	case code == "??":
+		return "untracked"
+	case strings.Contains(code, "U") || code == "AA" || code == "DD":
+		return "unmerged"
+	case code[0] != ' ' && code[1] != ' ':
+		return "staged and unstaged changes"
+	case code[0] != ' ':
+		return "staged changes"
+	default:
+		return "unstaged changes"
+	}
+}
+
+// check returns the warning for an edit of file by sessionID, or "" when the
+// edit is safe: the file is clean, or its changes are this session's own.
+func check(sessionID, file, sessDir string) string {
+	status := gitStatus(file)
+	if status == "" {
+		return ""
+	}
+	if sessDir != "" && sessionID != "" {
+		own := session.Load(filepath.Join(sessDir, sessionID+".json"))
+		if containsPath(own.Paths(), file) {
+			return ""
+		}
+	}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "%s has uncommitted changes (%s) that this session did not make.", file, describeStatus(status))
+	if sessDir != "" {
+		if others := otherSessions(sessDir, sessionID, file); len(others) > 0 {
+			fmt.Fprintf(&msg, " It was edited by session(s): %s.", strings.Join(others, ", "))
+		}
+	}
+	msg.WriteString(" They may be someone's in-progress work — check `git diff` before overwriting, or ask the user to commit or stash them first.")
+	return msg.String()
+}
+
+// run evaluates one hook invocation and returns the exit code.
+func run(stdin io.Reader, stderr io.Writer) int {
+	var input hookInput
+	if err := json.NewDecoder(stdin).Decode(&input); err != nil {
+		return 0
+	}
+	if !filewrite.IsWriteTool(input.ToolName) || input.ToolInput.FilePath == "" {
+		return 0
+	}
+
+	file := input.ToolInput.FilePath
+	if !filepath.IsAbs(file) && input.Cwd != "" {
+		file = filepath.Join(input.Cwd, file)
+	}
+
+	warning := check(input.SessionID, file, sessionsDir())
+	if warning == "" {
+		return 0
+	}
+
+	if loadConfig(filepath.Dir(file)).Strict {
+		fmt.Fprintf(stderr, "BLOCKED: %s\n", warning)
+		return 2
+	}
+	fmt.Fprintf(stderr, "⚠️  %s\n", warning)
+	return 0
+}
+
+func main() {
+	os.Exit(run(os.Stdin, os.Stderr))
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setupRepo creates a git repo with committed files clean.ts and dirty.ts,
+// then modifies dirty.ts without committing. HOME points at a temp dir so
+// session files stay isolated.
+func setupRepo(t *testing.T) (repo, sessDir string) {
+	t.Helper()
+	repo = t.TempDir()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	sessDir = filepath.Join(home, ".claude", "sessions")
+	if err := os.MkdirAll(sessDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "-q")
+	git("config", "user.email", "test@example.com")
+	git("config", "user.name", "test")
+	for _, name := range []string{"clean.ts", "dirty.ts"} {
+		if err := os.WriteFile(filepath.Join(repo, name), []byte("export {};\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	git("add", ".")
+	git("commit", "-q", "-m", "init")
+	if err := os.WriteFile(filepath.Join(repo, "dirty.ts"), []byte("export const wip = 1;\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return repo, sessDir
+}
+
+func writeSession(t *testing.T, sessDir, id string, data sessionData) {
+	t.Helper()
+	content, err := json.Marshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(sessDir, id+".json"), content, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func runHook(t *testing.T, toolName, file string) (int, string) {
+	t.Helper()
+	input, err := json.Marshal(map[string]any{
+		"session_id": "current",
+		"tool_name":  toolName,
+		"tool_input": map[string]any{"file_path": file},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stderr bytes.Buffer
+	code := run(bytes.NewReader(input), &stderr)
+	return code, stderr.String()
+}
+
+func TestDirtyFileNotInSessionWarns(t *testing.T) {
+	repo, sessDir := setupRepo(t)
+	writeSession(t, sessDir, "current", sessionData{EditedFiles: []string{filepath.Join(repo, "clean.ts")}})
+	writeSession(t, sessDir, "other", sessionData{SourceFiles: []string{filepath.Join(repo, "dirty.ts")}})
+
+	code, stderr := runHook(t, "Edit", filepath.Join(repo, "dirty.ts"))
+	if code != 0 {
+		t.Errorf("exit code = %d, want 0 (warn only by default)", code)
+	}
+	if !strings.Contains(stderr, "dirty.ts has uncommitted changes (unstaged changes)") {
+		t.Errorf("stderr = %q, want an uncommitted-changes warning", stderr)
+	}
+	if !strings.Contains(stderr, "session(s): other") {
+		t.Errorf("stderr = %q, want the other session named", stderr)
+	}
+}
+
+func TestDirtyFileNotInSessionBlocksInStrictMode(t *testing.T) {
+	repo, _ := setupRepo(t)
+	config := `{
+  // block instead of warn
+  "guardUncommittedEditsConfig": {"strict": true}
+}`
+	if err := os.WriteFile(filepath.Join(repo, preCommitConfigFile), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	code, stderr := runHook(t, "Write", filepath.Join(repo, "dirty.ts"))
+	if code != 2 {
+		t.Errorf("exit code = %d, want 2 in strict mode", code)
+	}
+	if !strings.HasPrefix(stderr, "BLOCKED: ") {
+		t.Errorf("stderr = %q, want a BLOCKED reason", stderr)
+	}
+}
+
+func TestSilentCases(t *testing.T) {
+	repo, sessDir := setupRepo(t)
+	writeSession(t, sessDir, "current", sessionData{EditedFiles: []string{filepath.Join(repo, "dirty.ts")}})
+
+	tests := []struct {
+		name string
+		tool string
+		file string
+	}{
+		{"dirty file edited by this session", "Edit", filepath.Join(repo, "dirty.ts")},
+		{"clean file", "MultiEdit", filepath.Join(repo, "clean.ts")},
+		{"new file", "Write", filepath.Join(repo, "src", "new.ts")},
+		{"non-write tool", "Read", filepath.Join(repo, "other.ts")},
+		{"outside a git repo", "Edit", filepath.Join(t.TempDir(), "x.ts")},
+	}
+	// An untracked file appears dirty; it must be attributed like any other.
+	if err := os.WriteFile(filepath.Join(repo, "other.ts"), []byte("x\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, stderr := runHook(t, tt.tool, tt.file)
+			if code != 0 || stderr != "" {
+				t.Errorf("got (%d, %q), want (0, \"\")", code, stderr)
+			}
+		})
+	}
+
+	code, stderr := runHook(t, "Edit", filepath.Join(repo, "other.ts"))
+	if code != 0 || !strings.Contains(stderr, "(untracked)") {
+		t.Errorf("untracked file = (%d, %q), want an untracked warning", code, stderr)
+	}
+}
+
+func TestDescribeStatus(t *testing.T) {
+	for code, want := range map[string]string{
+		"??": "untracked",
+		" M": "unstaged changes",
+		"M ": "staged changes",
+		"MM": "staged and unstaged changes",
+		"A ": "staged changes",
+		"UU": "unmerged",
+	} {
+		if got := describeStatus(code); got != want {
+			t.Errorf("describeStatus(%q) = %q, want %q", code, got, want)
+		}
+	}
+}
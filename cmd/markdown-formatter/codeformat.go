@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os/exec"
+	"strings"
+)
+
+// codeFormatter is an external command that formats code read from stdin
+// and writes the result to stdout.
+type codeFormatter struct {
+	command string
+	args    []string
+}
+
+// codeFormatters maps fence languages to the formatter --format-code runs on
+// their bodies. Prettier picks its parser from the --stdin-filepath extension.
+var codeFormatters = map[string]codeFormatter{
+	"go":         {"gofmt", nil},
+	"typescript": {"prettier", []string{"--stdin-filepath", "snippet.ts"}},
+	"ts":         {"prettier", []string{"--stdin-filepath", "snippet.ts"}},
+	"tsx":        {"prettier", []string{"--stdin-filepath", "snippet.tsx"}},
+	"javascript": {"prettier", []string{"--stdin-filepath", "snippet.js"}},
+	"js":         {"prettier", []string{"--stdin-filepath", "snippet.js"}},
+	"jsx":        {"prettier", []string{"--stdin-filepath", "snippet.jsx"}},
+	"json":       {"prettier", []string{"--stdin-filepath", "snippet.json"}},
+}
+
+// errEmptyFormatterOutput reports a formatter that succeeded without output.
+var errEmptyFormatterOutput = errors.New("formatter produced no output")
+
+// format runs the formatter on code. It fails when the command is not
+// installed, exits non-zero or prints nothing for non-empty input.
+func (f codeFormatter) format(code string) (string, error) {
+	path, err := exec.LookPath(f.command)
+	if err != nil {
+		return "", err
+	}
+	var stdout bytes.Buffer
+	cmd := exec.Command(path, f.args...)
+	cmd.Stdin = strings.NewReader(code + "\n")
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		return "", err
+	}
+	out := strings.TrimRight(stdout.String(), "\n")
+	if out == "" {
+		return "", errEmptyFormatterOutput
+	}
+	return out, nil
+}
+
+// formatFenceBodies pipes each fence body through the formatter for the
+// fence's language and splices the result back into lines. A fence whose
+// formatter is missing or fails is left exactly as it was. fences must be
+// the ones parseCodeFences found in lines.
+func formatFenceBodies(lines []string, fences []codeFence) []string {
+	// Work from the last fence back so earlier line indices stay valid when
+	// a body grows or shrinks.
+	for i := len(fences) - 1; i >= 0; i-- {
+		fence := fences[i]
+		fields := strings.Fields(fence.lang)
+		if len(fields) == 0 || strings.TrimSpace(fence.body) == "" {
+			continue
+		}
+		formatter, ok := codeFormatters[strings.ToLower(fields[0])]
+		if !ok {
+			continue
+		}
+
+		body, ok := dedentFenceBody(lines[fence.startLine+1:fence.endLine], fence.indent)
+		if !ok {
+			continue
+		}
+		formatted, err := formatter.format(body)
+		if err != nil || formatted == body {
+			continue
+		}
+
+		var replaced []string
+		for _, line := range strings.Split(formatted, "\n") {
+			if line != "" {
+				line = fence.indent + line
+			}
+			replaced = append(replaced, line)
+		}
+
+		out := make([]string, 0, len(lines)-(fence.endLine-fence.startLine-1)+len(replaced))
+		out = append(out, lines[:fence.startLine+1]...)
+		out = append(out, replaced...)
+		out = append(out, lines[fence.endLine:]...)
+		lines = out
+	}
+	return lines
+}
+
+// dedentFenceBody strips the fence indent from each body line. It reports
+// false when a non-blank line lacks the indent, since re-indenting the
+// formatted code would then change it.
+func dedentFenceBody(body []string, indent string) (string, bool) {
+	dedented := make([]string, len(body))
+	for i, line := range body {
+		switch {
+		case strings.TrimSpace(line) == "":
+			dedented[i] = ""
+		case strings.HasPrefix(line, indent):
+			dedented[i] = strings.TrimPrefix(line, indent)
+		default:
+			return "", false
+		}
+	}
+	return strings.Join(dedented, "\n"), true
+}
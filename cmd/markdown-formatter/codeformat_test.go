@@ -0,0 +1,70 @@
+package main
+
+import (
+	"os/exec"
+	"testing"
+)
+
+func TestFormatCodeFences(t *testing.T) {
+	if _, err := exec.LookPath("gofmt"); err != nil {
+		t.Skip("gofmt not installed")
+	}
+	opts := defaultFormatOptions()
+	opts.formatCode = true
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "misformatted go fence",
+			input:    "# Example\n\n```go\npackage main\nfunc main( ) {\nx:=1\n_ = x}\n```\n",
+			expected: "# Example\n\n```go\npackage main\n\nfunc main() {\n\tx := 1\n\t_ = x\n}\n```\n",
+		},
+		{
+			name:     "untagged go fence is detected then formatted",
+			input:    "```\npackage main\nfunc main( ) {}\n```\n",
+			expected: "```go\npackage main\n\nfunc main() {}\n```\n",
+		},
+		{
+			name:     "indented fence keeps its indent",
+			input:    "  ```go\n  package main\n  func main( ) {}\n  ```\n",
+			expected: "  ```go\n  package main\n\n  func main() {}\n  ```\n",
+		},
+		{
+			name:     "invalid go is left unchanged",
+			input:    "```go\nfunc main( {\n```\n",
+			expected: "```go\nfunc main( {\n```\n",
+		},
+		{
+			name:     "language without formatter is left unchanged",
+			input:    "```python\ndef  f( ):\n    pass\n```\n",
+			expected: "```python\ndef  f( ):\n    pass\n```\n",
+		},
+		{
+			name:     "later fences survive a resized body",
+			input:    "```go\npackage main\nfunc main( ) {}\n```\n\n```go\npackage b\nvar x  =  1\n```\n",
+			expected: "```go\npackage main\n\nfunc main() {}\n```\n\n```go\npackage b\n\nvar x = 1\n```\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := formatMarkdown(tt.input, opts)
+			if result != tt.expected {
+				t.Errorf("formatMarkdown() mismatch\ngot:\n%q\nwant:\n%q", result, tt.expected)
+			}
+			if again := formatMarkdown(result, opts); again != result {
+				t.Errorf("formatMarkdown() is not idempotent\nfirst:\n%q\nsecond:\n%q", result, again)
+			}
+		})
+	}
+}
+
+func TestFormatCodeOffByDefault(t *testing.T) {
+	input := "```go\npackage main\nfunc main( ) {}\n```\n"
+	if got := formatMarkdown(input, defaultFormatOptions()); got != input {
+		t.Errorf("fence body changed without formatCode: %q", got)
+	}
+}
@@ -4,7 +4,9 @@ package main
 
 import (
 	"encoding/json"
+	"flag"
 	"fmt"
+	"io"
 	"os"
 	"regexp"
 	"strings"
@@ -31,17 +33,19 @@ var (
 	jsFunctionPattern = regexp.MustCompile(`\b(function\s+\w+\s*\(|const\s+\w+\s*=)`)
 	jsArrowPattern    = regexp.MustCompile(`=>|console\.(log|error)`)
 
-	// TypeScript-specific: interface, type, generic syntax
-	tsInterfacePattern = regexp.MustCompile(`\b(interface|type)\s+\w+`)
-	tsGenericPattern   = regexp.MustCompile(`<\w+>`)
+	// TypeScript-specific: interface, type, generic syntax, type annotations
+	tsInterfacePattern  = regexp.MustCompile(`\b(interface|type)\s+\w+`)
+	tsGenericPattern    = regexp.MustCompile(`<\w+>`)
+	tsAnnotationPattern = regexp.MustCompile(`:\s*(string|number|boolean)\b`)
 
 	// Bash: shebang or control structures
 	bashShebangPattern = regexp.MustCompile(`(?m)^#!.*\b(bash|sh)\b`)
 	bashControlPattern = regexp.MustCompile(`\b(if|then|fi|for|in|do|done)\b`)
 	bashCommandPattern = regexp.MustCompile(`\b(echo|export|source|chmod|mkdir|cd|ls|grep|sed|awk)\b`)
 
-	// SQL: common SQL keywords
-	sqlPattern = regexp.MustCompile(`(?i)\b(SELECT|INSERT|UPDATE|DELETE|CREATE|ALTER|DROP)\s+`)
+	// SQL: common SQL keywords and the clauses that follow them
+	sqlPattern       = regexp.MustCompile(`(?i)\b(SELECT|INSERT|UPDATE|DELETE|CREATE|ALTER|DROP)\s+`)
+	sqlClausePattern = regexp.MustCompile(`(?i)\b(FROM|INTO|TABLE|WHERE|VALUES)\b`)
 
 	// Go: package, func, import with quotes
 	goPackagePattern = regexp.MustCompile(`(?m)^package\s+\w+`)
@@ -73,7 +77,76 @@ var (
 	excessiveBlanksPattern = regexp.MustCompile(`\n{3,}`)
 )
 
+// languageSignal is one weighted piece of evidence for a language.
+type languageSignal struct {
+	lang    string
+	pattern *regexp.Regexp
+	weight  int
+}
+
+// languageSignals lists the evidence detectLanguage tallies. Declarations no
+// other language has (a Go package clause, a shebang) weigh the most; words
+// and punctuation shared across languages weigh the least. The "typescript"
+// and "jsx" signals only cover what those dialects add to JavaScript.
+var languageSignals = []languageSignal{
+	{"go", goPackagePattern, 5},
+	{"go", goFuncPattern, 3},
+	{"go", goImportPattern, 3},
+
+	{"rust", rustFnPattern, 4},
+	{"rust", rustImplPattern, 4},
+	{"rust", rustLetPattern, 3},
+
+	{"python", pythonDefPattern, 4},
+	{"python", pythonImportPattern, 3},
+
+	{"typescript", tsInterfacePattern, 2},
+	{"typescript", tsAnnotationPattern, 2},
+	{"typescript", tsGenericPattern, 1},
+
+	{"jsx", jsxPattern, 3},
+
+	{"javascript", jsFunctionPattern, 3},
+	{"javascript", jsArrowPattern, 2},
+
+	{"bash", bashShebangPattern, 5},
+	{"bash", bashCommandPattern, 2},
+	{"bash", bashControlPattern, 1},
+
+	{"sql", sqlPattern, 2},
+	{"sql", sqlClausePattern, 1},
+
+	{"html", htmlPattern, 2},
+
+	{"css", cssPattern, 2},
+	{"css", cssPropPattern, 2},
+
+	{"yaml", yamlPattern, 2},
+	{"yaml", yamlListPattern, 2},
+
+	{"toml", tomlSectionPattern, 3},
+	{"toml", tomlKeyPattern, 2},
+}
+
+// languagePriority breaks ties between equal scores, most specific first.
+var languagePriority = []string{
+	"go", "rust", "python", "tsx", "typescript", "jsx", "javascript",
+	"bash", "sql", "html", "css", "yaml", "toml",
+}
+
+const (
+	// minLanguageScore is the score a language needs before detectLanguage
+	// reports it; below that the code is labelled "text".
+	minLanguageScore = 3
+
+	// maxSignalHits caps how many matches of one pattern count, so a word
+	// repeated through a snippet can't outweigh stronger evidence.
+	maxSignalHits = 2
+)
+
 // detectLanguage attempts to identify the programming language of code content.
+// Valid JSON is taken as is; otherwise the language with the highest signal
+// score wins, provided it reaches minLanguageScore.
 func detectLanguage(code string) string {
 	s := strings.TrimSpace(code)
 
@@ -85,70 +158,37 @@ func detectLanguage(code string) string {
 		}
 	}
 
-	// Go detection (before other C-like languages)
-	if goPackagePattern.MatchString(s) || goFuncPattern.MatchString(s) || goImportPattern.MatchString(s) {
-		return "go"
-	}
-
-	// Rust detection
-	if rustFnPattern.MatchString(s) || rustImplPattern.MatchString(s) || rustLetPattern.MatchString(s) {
-		return "rust"
-	}
-
-	// Python detection
-	if pythonDefPattern.MatchString(s) || pythonImportPattern.MatchString(s) {
-		return "python"
-	}
-
-	// TypeScript detection (before JavaScript)
-	if tsInterfacePattern.MatchString(s) || (tsGenericPattern.MatchString(s) && jsFunctionPattern.MatchString(s)) {
-		return "typescript"
-	}
-
-	// JSX/TSX detection
-	if jsxPattern.MatchString(s) {
-		if tsInterfacePattern.MatchString(s) || tsGenericPattern.MatchString(s) {
-			return "tsx"
+	scores := languageScores(s)
+	best, bestScore := "text", minLanguageScore-1
+	for _, lang := range languagePriority {
+		if scores[lang] > bestScore {
+			best, bestScore = lang, scores[lang]
 		}
-		return "jsx"
-	}
-
-	// JavaScript detection
-	if jsFunctionPattern.MatchString(s) || jsArrowPattern.MatchString(s) {
-		return "javascript"
-	}
-
-	// Bash detection
-	if bashShebangPattern.MatchString(s) || bashControlPattern.MatchString(s) || bashCommandPattern.MatchString(s) {
-		return "bash"
-	}
-
-	// SQL detection
-	if sqlPattern.MatchString(s) {
-		return "sql"
-	}
-
-	// HTML detection
-	if htmlPattern.MatchString(s) {
-		return "html"
 	}
+	return best
+}
 
-	// CSS detection
-	if cssPattern.MatchString(s) && cssPropPattern.MatchString(s) {
-		return "css"
+// languageScores tallies the weighted signal matches in s per language.
+func languageScores(s string) map[string]int {
+	scores := make(map[string]int)
+	for _, sig := range languageSignals {
+		hits := len(sig.pattern.FindAllStringIndex(s, maxSignalHits))
+		scores[sig.lang] += hits * sig.weight
 	}
 
-	// YAML detection
-	if yamlPattern.MatchString(s) && yamlListPattern.MatchString(s) {
-		return "yaml"
+	// TypeScript and JSX are JavaScript dialects: once their own signals
+	// show up, the JavaScript evidence counts toward them too.
+	js, ts, jsx := scores["javascript"], scores["typescript"], scores["jsx"]
+	if ts > 0 {
+		scores["typescript"] = ts + js
 	}
-
-	// TOML detection
-	if tomlSectionPattern.MatchString(s) && tomlKeyPattern.MatchString(s) {
-		return "toml"
+	if jsx > 0 {
+		scores["jsx"] = jsx + js
+		if ts > 0 {
+			scores["tsx"] = jsx + ts + js
+		}
 	}
-
-	return "text"
+	return scores
 }
 
 // codeFence represents a parsed code fence block.
@@ -203,70 +243,148 @@ func parseCodeFences(lines []string) []codeFence {
 	return fences
 }
 
-// formatMarkdown formats markdown content by adding language tags and fixing blank lines.
-func formatMarkdown(content string) string {
+// formatMarkdown formats markdown content: it tags untagged code fences,
+// formats their bodies with the language's formatter, normalizes list
+// markers and the spacing around headings and fences, and collapses
+// excessive blank lines, as enabled in opts. The dominant line
+// ending (LF or CRLF) is kept, and a final newline is only written when the
+// original ended with one.
+func formatMarkdown(content string, opts formatOptions) string {
+	crlf := usesCRLF(content)
+	hadFinalNewline := strings.HasSuffix(content, "\n")
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+
 	lines := strings.Split(content, "\n")
 	fences := parseCodeFences(lines)
 
-	// Process fences in reverse order to preserve line numbers
-	for i := len(fences) - 1; i >= 0; i-- {
-		fence := fences[i]
-		if !fence.hasLang {
-			lang := detectLanguage(fence.body)
-			// Update the opening fence line
-			lines[fence.startLine] = fence.indent + "```" + lang
+	if opts.tagFences {
+		for i, fence := range fences {
+			if !fence.hasLang {
+				lang := detectLanguage(fence.body)
+				// Update the opening fence line
+				lines[fence.startLine] = fence.indent + "```" + lang
+				fences[i].lang = lang
+			}
 		}
 	}
 
+	if opts.formatCode {
+		lines = formatFenceBodies(lines, fences)
+		fences = parseCodeFences(lines)
+	}
+
+	lines = normalizeBlocks(lines, fences, opts)
 	result := strings.Join(lines, "\n")
 
 	// Fix excessive blank lines (3+ newlines -> 2 newlines)
-	result = excessiveBlanksPattern.ReplaceAllString(result, "\n\n")
+	if opts.collapseBlanks {
+		result = excessiveBlanksPattern.ReplaceAllString(result, "\n\n")
+	}
+
+	// End with a single newline, unless the original had none
+	result = strings.TrimRight(result, "\n")
+	if hadFinalNewline {
+		result += "\n"
+	}
 
-	// Ensure file ends with single newline
-	return strings.TrimRight(result, "\n") + "\n"
+	if crlf {
+		result = strings.ReplaceAll(result, "\n", "\r\n")
+	}
+	return result
+}
+
+// usesCRLF reports whether CRLF is the dominant line ending in content.
+func usesCRLF(content string) bool {
+	crlf := strings.Count(content, "\r\n")
+	return crlf > 0 && crlf >= strings.Count(content, "\n")-crlf
 }
 
 func main() {
-	var input toolInput
-	if err := json.NewDecoder(os.Stdin).Decode(&input); err != nil {
-		// Allow if we can't parse
-		os.Exit(0)
-	}
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
 
-	filePath := input.ToolInput.FilePath
-	if filePath == "" {
-		os.Exit(0)
+// run formats the markdown files named in args, or the file from the hook
+// JSON on stdin when there are none, and returns the exit code. With
+// --check nothing is written: files that would change are listed and the
+// exit code is 1.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("markdown-formatter", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	check := fs.Bool("check", false, "List files that need formatting and exit 1 instead of rewriting them")
+	opts := defaultFormatOptions()
+	fs.BoolVar(&opts.tagFences, "tag-fences", opts.tagFences, "Add detected language tags to untagged code fences")
+	fs.BoolVar(&opts.collapseBlanks, "collapse-blank-lines", opts.collapseBlanks, "Collapse runs of blank lines to one")
+	fs.StringVar(&opts.listMarker, "list-marker", opts.listMarker, "Unordered list marker (-, * or +); empty keeps existing markers")
+	fs.BoolVar(&opts.blockSpacing, "block-spacing", opts.blockSpacing, "Keep one blank line around headings and fenced blocks")
+	fs.BoolVar(&opts.formatCode, "format-code", opts.formatCode, "Format go, ts and json fence bodies with gofmt or prettier")
+	if err := fs.Parse(args); err != nil {
+		return 1
 	}
-
-	// Only process markdown files
-	if !strings.HasSuffix(filePath, ".md") && !strings.HasSuffix(filePath, ".mdx") {
-		os.Exit(0)
+	if err := validateListMarker(opts.listMarker); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
 	}
 
-	// Check if file exists
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		os.Exit(0)
-	}
+	paths := fs.Args()
+	if len(paths) == 0 {
+		var input toolInput
+		if err := json.NewDecoder(stdin).Decode(&input); err != nil {
+			// Allow if we can't parse
+			return 0
+		}
 
-	// Read file content
-	content, err := os.ReadFile(filePath)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error reading file: %v\n", err)
-		os.Exit(1)
+		filePath := input.ToolInput.FilePath
+		if filePath == "" || !isMarkdownFile(filePath) {
+			return 0
+		}
+
+		// Check if file exists
+		if _, err := os.Stat(filePath); os.IsNotExist(err) {
+			return 0
+		}
+		paths = []string{filePath}
 	}
 
-	// Format the markdown
-	formatted := formatMarkdown(string(content))
+	unformatted := 0
+	for _, filePath := range paths {
+		if !isMarkdownFile(filePath) {
+			continue
+		}
+
+		// Read file content
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error reading file: %v\n", err)
+			return 1
+		}
+
+		// Format the markdown
+		formatted := formatMarkdown(string(content), opts)
+		if formatted == string(content) {
+			continue
+		}
+
+		if *check {
+			fmt.Fprintf(stdout, "Needs formatting: %s\n", filePath)
+			unformatted++
+			continue
+		}
 
-	// Only write if content changed
-	if formatted != string(content) {
 		if err := os.WriteFile(filePath, []byte(formatted), 0644); err != nil {
-			fmt.Fprintf(os.Stderr, "Error writing file: %v\n", err)
-			os.Exit(1)
+			fmt.Fprintf(stderr, "Error writing file: %v\n", err)
+			return 1
 		}
-		fmt.Printf("Fixed markdown formatting in %s\n", filePath)
+		fmt.Fprintf(stdout, "Fixed markdown formatting in %s\n", filePath)
+	}
+
+	if unformatted > 0 {
+		fmt.Fprintf(stderr, "%d markdown file(s) need formatting; run markdown-formatter without --check to fix\n", unformatted)
+		return 1
 	}
+	return 0
+}
 
-	os.Exit(0)
+// isMarkdownFile reports whether path is a .md or .mdx file.
+func isMarkdownFile(path string) bool {
+	return strings.HasSuffix(path, ".md") || strings.HasSuffix(path, ".mdx")
 }
@@ -1,6 +1,10 @@
 package main
 
 import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -71,9 +75,17 @@ func TestDetectLanguage(t *testing.T) {
 		// TOML
 		{"toml config", "[package]\nname = \"test\"\nversion = \"1.0\"", "toml"},
 
+		// Overlapping signals, settled by score
+		{"bash using type", "if type node >/dev/null 2>&1; then\n  echo \"node found\"\nfi", "bash"},
+		{"yaml without lists", "name: api\nservices:\n  web:\n    image: nginx\n    restart: always", "yaml"},
+		{"toml keys without section", "name = \"app\"\nversion = \"0.1.0\"\nedition = \"2021\"", "toml"},
+		{"javascript let", "let count = 0;\nconst inc = () => count++;", "javascript"},
+		{"tsx interface and component", "interface Props { name: string }\nconst App = () => <Button label=\"x\" />;", "tsx"},
+
 		// Fallback
 		{"plain text", "This is just some plain text.", "text"},
 		{"unknown", "some random content here", "text"},
+		{"prose with shell words", "Run the tests in order, then do the release for each package.", "text"},
 	}
 
 	for _, tt := range tests {
@@ -132,24 +144,52 @@ func TestFormatMarkdown(t *testing.T) {
 			input:    "   ```\n   SELECT * FROM users\n   ```\n",
 			expected: "   ```sql\n   SELECT * FROM users\n   ```\n",
 		},
+		{
+			name:     "empty file",
+			input:    "",
+			expected: "",
+		},
 		{
 			name:     "no changes needed",
 			input:    "# Title\n\nSome text.\n",
 			expected: "# Title\n\nSome text.\n",
 		},
 		{
-			name:     "ensure trailing newline",
-			input:    "# Title",
+			name:     "collapse trailing newlines",
+			input:    "# Title\n\n\n",
 			expected: "# Title\n",
 		},
+		{
+			name:     "keep missing trailing newline",
+			input:    "# Title\n\n\n\nText",
+			expected: "# Title\n\nText",
+		},
+		{
+			name:     "keep crlf line endings",
+			input:    "# Title\r\n\r\n\r\n\r\n```\r\ndef hello():\r\n    pass\r\n```\r\n",
+			expected: "# Title\r\n\r\n```python\r\ndef hello():\r\n    pass\r\n```\r\n",
+		},
+		{
+			name:     "crlf without trailing newline",
+			input:    "# Title\r\nText",
+			expected: "# Title\r\n\r\nText",
+		},
+		{
+			name:     "mostly lf stays lf",
+			input:    "# Title\n\nOne\nTwo\r\n",
+			expected: "# Title\n\nOne\nTwo\n",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := formatMarkdown(tt.input)
+			result := formatMarkdown(tt.input, defaultFormatOptions())
 			if result != tt.expected {
 				t.Errorf("formatMarkdown() mismatch\ngot:\n%q\nwant:\n%q", result, tt.expected)
 			}
+			if again := formatMarkdown(result, defaultFormatOptions()); again != result {
+				t.Errorf("formatMarkdown() is not idempotent\nfirst:\n%q\nsecond:\n%q", result, again)
+			}
 		})
 	}
 }
@@ -191,6 +231,57 @@ More text.
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		formatMarkdown(content)
+		formatMarkdown(content, defaultFormatOptions())
+	}
+}
+
+func TestRunCheck(t *testing.T) {
+	dir := t.TempDir()
+	unformatted := filepath.Join(dir, "README.md")
+	original := "# Title\n\n\n\nText\n"
+	if err := os.WriteFile(unformatted, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+	formatted := filepath.Join(dir, "GUIDE.md")
+	if err := os.WriteFile(formatted, []byte("# Guide\n\nText\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"--check", unformatted, formatted}, strings.NewReader(""), &stdout, &stderr); code != 1 {
+		t.Fatalf("run(--check) = %d, want 1 (stderr: %s)", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), unformatted) {
+		t.Errorf("output should name %s, got: %s", unformatted, stdout.String())
+	}
+	if strings.Contains(stdout.String(), formatted) {
+		t.Errorf("output names the already formatted %s: %s", formatted, stdout.String())
+	}
+	if got, _ := os.ReadFile(unformatted); string(got) != original {
+		t.Errorf("--check modified the file: %q", got)
+	}
+
+	stdout.Reset()
+	if code := run([]string{"--check", formatted}, strings.NewReader(""), &stdout, &stderr); code != 0 {
+		t.Errorf("run(--check) on a formatted file = %d, want 0", code)
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("unexpected output for a formatted file: %s", stdout.String())
+	}
+}
+
+func TestRunHookWritesInPlace(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notes.md")
+	if err := os.WriteFile(path, []byte("# Notes\n\n\n\nText"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	stdin := strings.NewReader(`{"tool":"Write","tool_input":{"file_path":"` + path + `"}}`)
+	var stdout, stderr bytes.Buffer
+	if code := run(nil, stdin, &stdout, &stderr); code != 0 {
+		t.Fatalf("run() = %d, want 0 (stderr: %s)", code, stderr.String())
+	}
+	if got, _ := os.ReadFile(path); string(got) != "# Notes\n\nText" {
+		t.Errorf("hook did not format in place: %q", got)
 	}
 }
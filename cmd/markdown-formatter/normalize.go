@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// ATX heading: up to 3 spaces of indent, 1-6 #'s, then space or end of line
+	headingPattern = regexp.MustCompile(`^ {0,3}#{1,6}(\s|$)`)
+
+	// Unordered list item: indent, marker, at least one space
+	listItemPattern = regexp.MustCompile(`^(\s*)[-*+](\s+)`)
+
+	// Thematic break (* * *, ---, ___), which looks like a list item
+	thematicBreakPattern = regexp.MustCompile(`^ {0,3}([-*_])(\s*([-*_])){2,}\s*$`)
+)
+
+// formatOptions selects the transformations formatMarkdown applies.
+type formatOptions struct {
+	tagFences      bool   // add detected language tags to untagged fences
+	collapseBlanks bool   // collapse runs of blank lines to one
+	listMarker     string // unordered list marker to use; "" leaves markers alone
+	blockSpacing   bool   // one blank line around headings and fenced blocks
+	formatCode     bool   // run fence bodies through their language's formatter
+}
+
+// defaultFormatOptions enables every transformation with "-" list markers,
+// except formatCode, which spawns external formatters and is opt-in.
+func defaultFormatOptions() formatOptions {
+	return formatOptions{
+		tagFences:      true,
+		collapseBlanks: true,
+		listMarker:     "-",
+		blockSpacing:   true,
+	}
+}
+
+// validateListMarker checks a --list-marker value.
+func validateListMarker(marker string) error {
+	switch marker {
+	case "", "-", "*", "+":
+		return nil
+	}
+	return fmt.Errorf("invalid --list-marker %q (must be -, * or +, or empty to keep markers)", marker)
+}
+
+// normalizeBlocks rewrites list markers and the blank lines around headings
+// and top-level fences. Lines inside fences and YAML front matter are kept
+// verbatim; fences are the ones parseCodeFences found in lines.
+func normalizeBlocks(lines []string, fences []codeFence, opts formatOptions) []string {
+	protected := make([]bool, len(lines))
+	fenceStart := make(map[int]bool)
+	fenceEnd := make(map[int]bool)
+	for _, f := range fences {
+		for i := f.startLine; i <= f.endLine; i++ {
+			protected[i] = true
+		}
+		// Indented fences usually belong to a list item, where adding blank
+		// lines would turn a tight list loose.
+		if f.indent == "" {
+			fenceStart[f.startLine] = true
+			fenceEnd[f.endLine] = true
+		}
+	}
+	if end := frontMatterEnd(lines); end > 0 {
+		for i := 0; i <= end; i++ {
+			protected[i] = true
+		}
+	}
+
+	var out []string
+	pendingBlank := false
+	for i, line := range lines {
+		if !protected[i] && opts.listMarker != "" && !thematicBreakPattern.MatchString(line) {
+			if m := listItemPattern.FindStringSubmatchIndex(line); m != nil {
+				line = line[:m[3]] + opts.listMarker + line[m[3]+1:]
+			}
+		}
+
+		if !opts.blockSpacing {
+			out = append(out, line)
+			continue
+		}
+
+		heading := !protected[i] && headingPattern.MatchString(line)
+		blank := !protected[i] && strings.TrimSpace(line) == ""
+
+		if pendingBlank {
+			if blank {
+				continue
+			}
+			out = append(out, "")
+			pendingBlank = false
+		}
+
+		if heading || fenceStart[i] {
+			for len(out) > 0 && strings.TrimSpace(out[len(out)-1]) == "" {
+				out = out[:len(out)-1]
+			}
+			if len(out) > 0 {
+				out = append(out, "")
+			}
+		}
+
+		out = append(out, line)
+		if heading || fenceEnd[i] {
+			pendingBlank = true
+		}
+	}
+	return out
+}
+
+// frontMatterEnd returns the index of the line closing a leading "---" YAML
+// front matter block, or -1 when there is none.
+func frontMatterEnd(lines []string) int {
+	if len(lines) == 0 || strings.TrimRight(lines[0], " \t") != "---" {
+		return -1
+	}
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimRight(lines[i], " \t") == "---" {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeListMarkers(t *testing.T) {
+	tests := []struct {
+		name     string
+		marker   string
+		input    string
+		expected string
+	}{
+		{
+			name:     "mixed markers to dash",
+			marker:   "-",
+			input:    "* one\n+ two\n- three\n  * nested\n",
+			expected: "- one\n- two\n- three\n  - nested\n",
+		},
+		{
+			name:     "mixed markers to star",
+			marker:   "*",
+			input:    "- one\n+ two\n",
+			expected: "* one\n* two\n",
+		},
+		{
+			name:     "thematic breaks and emphasis untouched",
+			marker:   "-",
+			input:    "* * *\n\n*emphasis* here\n\n***\n",
+			expected: "* * *\n\n*emphasis* here\n\n***\n",
+		},
+		{
+			name:     "fence content untouched",
+			marker:   "-",
+			input:    "* item\n\n```yaml\n* not a list: here\n+ also: kept\n```\n",
+			expected: "- item\n\n```yaml\n* not a list: here\n+ also: kept\n```\n",
+		},
+		{
+			name:     "empty marker keeps markers",
+			marker:   "",
+			input:    "* one\n+ two\n",
+			expected: "* one\n+ two\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := defaultFormatOptions()
+			opts.listMarker = tt.marker
+			if got := formatMarkdown(tt.input, opts); got != tt.expected {
+				t.Errorf("formatMarkdown() mismatch\ngot:\n%q\nwant:\n%q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNormalizeBlockSpacing(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "tight headings",
+			input:    "# Title\nIntro\n## Section\nBody\n",
+			expected: "# Title\n\nIntro\n\n## Section\n\nBody\n",
+		},
+		{
+			name:     "tight fence",
+			input:    "Text\n```go\npackage main\n```\nMore\n",
+			expected: "Text\n\n```go\npackage main\n```\n\nMore\n",
+		},
+		{
+			name:     "heading followed by fence",
+			input:    "## Usage\n```bash\necho hi\n```\n",
+			expected: "## Usage\n\n```bash\necho hi\n```\n",
+		},
+		{
+			name:     "extra blank lines around a heading",
+			input:    "Intro\n\n\n## Section\n\n\nBody\n",
+			expected: "Intro\n\n## Section\n\nBody\n",
+		},
+		{
+			name:     "comment in fence is not a heading",
+			input:    "```bash\n# install\nnpm i\n```\n",
+			expected: "```bash\n# install\nnpm i\n```\n",
+		},
+		{
+			name:     "front matter untouched",
+			input:    "---\ntitle: Doc\n# yaml comment\n---\n# Title\nText\n",
+			expected: "---\ntitle: Doc\n# yaml comment\n---\n\n# Title\n\nText\n",
+		},
+		{
+			name:     "indented fence in list stays tight",
+			input:    "- step\n  ```bash\n  echo hi\n  ```\n- next\n",
+			expected: "- step\n  ```bash\n  echo hi\n  ```\n- next\n",
+		},
+		{
+			name:     "hashtag is not a heading",
+			input:    "Text\n#tag\n",
+			expected: "Text\n#tag\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatMarkdown(tt.input, defaultFormatOptions()); got != tt.expected {
+				t.Errorf("formatMarkdown() mismatch\ngot:\n%q\nwant:\n%q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatOptionsToggles(t *testing.T) {
+	input := "# Title\n* item\n```\npackage main\n```\n"
+
+	fencesOnly := formatOptions{tagFences: true}
+	if got, want := formatMarkdown(input, fencesOnly), "# Title\n* item\n```go\npackage main\n```\n"; got != want {
+		t.Errorf("fence tagging only: got %q, want %q", got, want)
+	}
+
+	noTagging := defaultFormatOptions()
+	noTagging.tagFences = false
+	if got, want := formatMarkdown(input, noTagging), "# Title\n\n- item\n\n```\npackage main\n```\n"; got != want {
+		t.Errorf("without fence tagging: got %q, want %q", got, want)
+	}
+}
+
+func TestRunFormatFlags(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notes.md")
+	if err := os.WriteFile(path, []byte("# Notes\n* item\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"--list-marker=+", "--block-spacing=false", path}, strings.NewReader(""), &stdout, &stderr); code != 0 {
+		t.Fatalf("run() = %d, want 0 (stderr: %s)", code, stderr.String())
+	}
+	if got, _ := os.ReadFile(path); string(got) != "# Notes\n+ item\n" {
+		t.Errorf("flags not applied: %q", got)
+	}
+
+	if code := run([]string{"--list-marker=x", path}, strings.NewReader(""), &stdout, &stderr); code != 1 {
+		t.Errorf("invalid --list-marker: run() = %d, want 1", code)
+	}
+}
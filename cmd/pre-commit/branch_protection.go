@@ -4,30 +4,94 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
+
+	"github.com/milehighideas/claude-hooks/internal/glob"
+	"github.com/milehighideas/claude-hooks/internal/glyph"
 )
 
+// skipBranchProtectionEnv is the emergency override for branch protection.
+// Every use on a protected branch is logged.
+const skipBranchProtectionEnv = "SKIP_BRANCH_PROTECTION"
+
+// bypassLogName is the file in the git directory recording emergency bypasses.
+const bypassLogName = "pre-commit-bypass.log"
+
 // checkBranchProtection checks if the current branch is in the protected branches list.
-// If SKIP_BRANCH_PROTECTION env var is set, the check is bypassed.
+// A commit whose staged files all match an allowOnProtected glob passes with a
+// note. If SKIP_BRANCH_PROTECTION env var is set, the check is bypassed and the
+// bypass is logged.
 // Returns an error if the current branch matches any protected branch pattern.
-func checkBranchProtection(protectedBranches []string) error {
-	if os.Getenv("SKIP_BRANCH_PROTECTION") != "" {
+func checkBranchProtection(protectedBranches, allowOnProtected, stagedFiles []string) error {
+	branch := branchOverride
+	if branch == "" {
+		cmd := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD")
+		output, err := cmd.Output()
+		if err != nil {
+			return nil // Ignore errors, might not be in a git repo
+		}
+		branch = strings.TrimSpace(string(output))
+	}
+	pattern := "^(" + strings.Join(protectedBranches, "|") + ")$"
+	matched, _ := regexp.MatchString(pattern, branch)
+	if !matched || len(protectedBranches) == 0 {
 		return nil
 	}
 
-	cmd := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD")
-	output, err := cmd.Output()
-	if err != nil {
-		return nil // Ignore errors, might not be in a git repo
+	if os.Getenv(skipBranchProtectionEnv) != "" {
+		logBranchProtectionBypass(branch, len(stagedFiles))
+		return nil
 	}
 
-	branch := strings.TrimSpace(string(output))
-	pattern := "^(" + strings.Join(protectedBranches, "|") + ")$"
-	matched, _ := regexp.MatchString(pattern, branch)
-	if matched {
-		return fmt.Errorf("direct commits to the %s branch are not allowed. Please choose a new branch name", branch)
+	if allFilesAllowed(allowOnProtected, stagedFiles) {
+		fmt.Printf(glyph.Info+"  Committing to protected branch %s: all %d staged files match allowOnProtected\n\n", branch, len(stagedFiles))
+		return nil
+	}
+
+	return fmt.Errorf("direct commits to the %s branch are not allowed. Please choose a new branch name", branch)
+}
+
+// allFilesAllowed reports whether every staged file matches one of the
+// allowOnProtected globs. An empty commit or an empty allow list never does.
+func allFilesAllowed(globs, stagedFiles []string) bool {
+	if len(globs) == 0 || len(stagedFiles) == 0 {
+		return false
+	}
+	patterns := make([]*regexp.Regexp, len(globs))
+	for i, g := range globs {
+		patterns[i] = glob.MustCompile(g)
+	}
+	for _, f := range stagedFiles {
+		if !matchesAny(patterns, filepath.ToSlash(f)) {
+			return false
+		}
+	}
+	return true
+}
+
+// logBranchProtectionBypass warns about an emergency bypass and appends it to
+// pre-commit-bypass.log in the git directory, so bypasses stay auditable.
+// The common git directory is used so runs from a linked worktree (such as
+// --verify-staged's) log to the same file.
+// Logging is best-effort and never fails the commit.
+func logBranchProtectionBypass(branch string, stagedCount int) {
+	fmt.Fprintf(os.Stderr, glyph.Warn+"  Branch protection on %s bypassed by %s\n", branch, skipBranchProtectionEnv)
+
+	output, err := exec.Command("git", "rev-parse", "--git-common-dir").Output()
+	if err != nil {
+		return
 	}
+	user, _ := exec.Command("git", "config", "user.email").Output()
 
-	return nil
+	path := filepath.Join(strings.TrimSpace(string(output)), bypassLogName)
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return
+	}
+	defer func() { _ = f.Close() }()
+	_, _ = fmt.Fprintf(f, "%s branch=%s user=%s files=%d\n",
+		time.Now().UTC().Format(time.RFC3339), branch, strings.TrimSpace(string(user)), stagedCount)
 }
@@ -116,7 +116,7 @@ func TestCheckBranchProtection(t *testing.T) {
 				_ = os.Unsetenv("SKIP_BRANCH_PROTECTION")
 			}
 
-			err := checkBranchProtection(tt.protectedBranches)
+			err := checkBranchProtection(tt.protectedBranches, nil, []string{"test.txt"})
 
 			if tt.wantErr {
 				if err == nil {
@@ -147,3 +147,94 @@ func runGitCommand(dir string, args ...string) error {
 	)
 	return cmd.Run()
 }
+
+// chdirToBranchRepo creates a git repo with one commit, changes into it for
+// the rest of the test and returns its current branch.
+func chdirToBranchRepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := runGitCommand(dir, "init"); err != nil {
+		t.Fatalf("Failed to init git repo: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "test.txt"), []byte("test"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := runGitCommand(dir, "add", "."); err != nil {
+		t.Fatal(err)
+	}
+	if err := runGitCommand(dir, "commit", "-m", "Initial commit"); err != nil {
+		t.Fatal(err)
+	}
+
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(originalDir) })
+
+	cmd := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD")
+	output, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("Failed to get current branch: %v", err)
+	}
+	return strings.TrimSpace(string(output))
+}
+
+func TestCheckBranchProtection_AllowOnProtected(t *testing.T) {
+	branch := chdirToBranchRepo(t)
+	t.Setenv(skipBranchProtectionEnv, "")
+	allow := []string{"docs/**", "**/*.md", ".changelog/*.txt"}
+
+	tests := []struct {
+		name    string
+		allow   []string
+		staged  []string
+		wantErr bool
+	}{
+		{"all files allowed", allow, []string{"docs/hotfix.md", "README.md", ".changelog/20260101-fix.txt"}, false},
+		{"nested allowed file", allow, []string{"apps/web/CHANGES.md"}, false},
+		{"one file not allowed", allow, []string{"docs/hotfix.md", "src/main.ts"}, true},
+		{"star stays in one segment", allow, []string{".changelog/old/entry.txt"}, true},
+		{"no allow list", nil, []string{"docs/hotfix.md"}, true},
+		{"nothing staged", allow, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkBranchProtection([]string{branch}, tt.allow, tt.staged)
+			if tt.wantErr && err == nil {
+				t.Error("expected protected branch error, got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestCheckBranchProtection_BypassIsLogged(t *testing.T) {
+	branch := chdirToBranchRepo(t)
+	logPath := filepath.Join(".git", bypassLogName)
+
+	t.Setenv(skipBranchProtectionEnv, "1")
+	if err := checkBranchProtection([]string{"some-other-branch"}, nil, []string{"src/main.ts"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := os.Stat(logPath); !os.IsNotExist(err) {
+		t.Fatal("bypass on an unprotected branch should not be logged")
+	}
+
+	if err := checkBranchProtection([]string{branch}, nil, []string{"src/main.ts", "src/app.ts"}); err != nil {
+		t.Fatalf("bypass should pass, got: %v", err)
+	}
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("bypass log not written: %v", err)
+	}
+	if line := string(data); !strings.Contains(line, "branch="+branch) || !strings.Contains(line, "files=2") {
+		t.Errorf("bypass log = %q, want branch and file count", line)
+	}
+}
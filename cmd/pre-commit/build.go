@@ -5,8 +5,25 @@ import (
 	"strings"
 )
 
-// checkBuild runs build for configured apps
-func checkBuild(config BuildConfig, apps map[string]AppConfig) error {
+// buildCommandRunner is the indirection point for tests. Production code
+// invokes runCommandCapturedInDir; tests overwrite this to record the
+// command each app runs.
+var buildCommandRunner = runCommandCapturedInDir
+
+// buildScriptArgs returns the package-manager args that run the "build"
+// script. bun and npm need an explicit `run` (`bun build` is the bundler).
+func buildScriptArgs(packageManager string) []string {
+	switch packageManager {
+	case "bun", "npm":
+		return []string{"run", "build"}
+	default:
+		return []string{"build"}
+	}
+}
+
+// checkBuild runs build for configured apps using each app's package manager
+// (AppConfig.PackageManager, falling back to the global one).
+func checkBuild(config BuildConfig, apps map[string]AppConfig, packageManager string) error {
 	if len(config.Apps) == 0 {
 		return nil
 	}
@@ -22,7 +39,8 @@ func checkBuild(config BuildConfig, apps map[string]AppConfig) error {
 		if !compactMode() {
 			fmt.Printf("Building %s...\n", appName)
 		}
-		out, err := runCommandCapturedInDir(appConfig.Path, "pnpm", "build")
+		pm := appConfig.packageManagerFor(packageManager)
+		out, err := buildCommandRunner(appConfig.Path, pm, buildScriptArgs(pm)...)
 		if !compactMode() && out != "" {
 			fmt.Print(out)
 		}
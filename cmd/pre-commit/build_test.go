@@ -184,3 +184,40 @@ func checkBuildWithRunner(config BuildConfig, apps map[string]AppConfig, runner
 
 	return nil
 }
+
+func TestCheckBuild_PerAppPackageManager(t *testing.T) {
+	type call struct {
+		dir  string
+		name string
+		args []string
+	}
+	var calls []call
+	orig := buildCommandRunner
+	buildCommandRunner = func(dir, name string, args ...string) (string, error) {
+		calls = append(calls, call{dir, name, args})
+		return "", nil
+	}
+	t.Cleanup(func() { buildCommandRunner = orig })
+
+	apps := map[string]AppConfig{
+		"web":    {Path: "apps/web"},
+		"mobile": {Path: "apps/mobile", PackageManager: "bun"},
+	}
+	if err := checkBuild(BuildConfig{Apps: []string{"web", "mobile"}}, apps, "pnpm"); err != nil {
+		t.Fatalf("checkBuild() error = %v", err)
+	}
+
+	want := []call{
+		{"apps/web", "pnpm", []string{"build"}},
+		{"apps/mobile", "bun", []string{"run", "build"}},
+	}
+	if len(calls) != len(want) {
+		t.Fatalf("got %d calls, want %d: %+v", len(calls), len(want), calls)
+	}
+	for i, w := range want {
+		got := calls[i]
+		if got.dir != w.dir || got.name != w.name || strings.Join(got.args, " ") != strings.Join(w.args, " ") {
+			t.Errorf("call %d = %+v, want %+v", i, got, w)
+		}
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+
+	"github.com/milehighideas/claude-hooks/internal/glyph"
 )
 
 // BundleCheckConfig configures the Metro bundle-only check. Each app listed
@@ -70,11 +72,11 @@ func runBundleCheck(config BundleCheckConfig, apps map[string]AppConfig, package
 		}
 
 		wg.Add(1)
-		go func(idx int, name string, path string) {
+		go func(idx int, name string, path string, pm string) {
 			defer wg.Done()
 			out, err := bundleScriptRunner(path, pm, script)
 			results[idx] = result{app: name, output: out, err: err}
-		}(i, appName, appCfg.Path)
+		}(i, appName, appCfg.Path, appCfg.packageManagerFor(pm))
 	}
 
 	wg.Wait()
@@ -96,7 +98,7 @@ func runBundleCheck(config BundleCheckConfig, apps map[string]AppConfig, package
 			continue
 		}
 		if !compactMode() {
-			fmt.Printf("   ✓ %s passed bundle check\n", r.app)
+			fmt.Printf("   "+glyph.Tick+" %s passed bundle check\n", r.app)
 		}
 	}
 
@@ -109,7 +111,7 @@ func runBundleCheck(config BundleCheckConfig, apps map[string]AppConfig, package
 
 	if !compactMode() {
 		for _, r := range failed {
-			fmt.Printf("\n   ❌ %s bundle check failed\n", r.app)
+			fmt.Printf("\n   "+glyph.Fail+" %s bundle check failed\n", r.app)
 			if r.output != "" {
 				fmt.Println(strings.TrimRight(r.output, "\n"))
 			}
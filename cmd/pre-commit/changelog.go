@@ -6,8 +6,15 @@ import (
 	"os"
 	"regexp"
 	"strings"
+
+	"github.com/milehighideas/claude-hooks/internal/changelog"
+	"github.com/milehighideas/claude-hooks/internal/glyph"
 )
 
+// changelogFragmentReader returns a fragment's staged content (or its content
+// on disk in standalone mode). Tests overwrite it.
+var changelogFragmentReader = defaultGitShow
+
 // checkChangelog verifies that a changelog fragment is staged when required.
 // It checks the SKIP_CHANGELOG_CHECK env var, applies exclude patterns,
 // and looks for changelog files based on the configured mode.
@@ -16,6 +23,10 @@ func checkChangelog(stagedFiles []string, excludePatterns []string, config Chang
 		return nil
 	}
 
+	if err := validateChangelogFragments(stagedFiles, config, apps); err != nil {
+		return err
+	}
+
 	// Filter out excluded files
 	var relevantFiles []string
 	for _, file := range stagedFiles {
@@ -163,7 +174,7 @@ func checkPerAppChangelog(stagedFiles, relevantFiles []string, apps map[string]A
 		fmt.Println("You're making changes that require changelog entries for:")
 		for _, app := range missingApps {
 			appConfig := apps[app]
-			fmt.Printf("   • %s (%s/.changelog/)\n", app, appConfig.Path)
+			fmt.Printf("   "+glyph.Bullet+" %s (%s/.changelog/)\n", app, appConfig.Path)
 		}
 		fmt.Println()
 		fmt.Println("Add entries using:")
@@ -249,3 +260,89 @@ func checkAnyAppChangelogStrict(stagedFiles []string, apps map[string]AppConfig)
 	fmt.Println()
 	return fmt.Errorf("changelog fragment required for shared changes")
 }
+
+// validateChangelogFragments checks every staged fragment the way
+// changelog-add reads it when compiling: the header must parse as a
+// conventional commit. A scoped fragment outside an app's .changelog/ must
+// name a configured app in required mode, and in per-app mode with
+// strictScope; in required mode every such fragment needs a scope. Fragments
+// that can't be read (e.g. deleted) are skipped.
+func validateChangelogFragments(stagedFiles []string, config ChangelogConfig, apps map[string]AppConfig) error {
+	changelogApps := getChangelogApps(config, apps)
+	perApp := config.Mode == "per-app" || config.Mode == "required"
+	strictScope := config.Mode == "required" || (config.Mode == "per-app" && config.StrictScope)
+
+	var problems []string
+	for _, file := range stagedFiles {
+		inApp, ok := changelogFragmentLocation(file, config.GlobalDir, changelogApps)
+		if !ok {
+			continue
+		}
+		content, err := changelogFragmentReader(file)
+		if err != nil {
+			continue
+		}
+		_, scope, _, err := changelog.ParseConventionalCommit(strings.ReplaceAll(string(content), "\r\n", "\n"))
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", file, err))
+			continue
+		}
+		if !perApp || inApp {
+			continue
+		}
+		switch {
+		case scope != "" && strictScope && !changelogScopeMatchesApp(scope, changelogApps):
+			problems = append(problems, fmt.Sprintf("%s: scope '%s' doesn't match any configured app", file, scope))
+		case scope == "" && config.Mode == "required":
+			problems = append(problems, fmt.Sprintf("%s: mode 'required' needs a scope naming a configured app", file))
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	fmt.Println("================================")
+	fmt.Println("  MALFORMED CHANGELOG FRAGMENT")
+	fmt.Println("================================")
+	fmt.Println()
+	for _, problem := range problems {
+		fmt.Printf("   "+glyph.Bullet+" %s\n", problem)
+	}
+	fmt.Println()
+	fmt.Println("Fragments must use Conventional Commits format, e.g.:")
+	fmt.Println("   feat(web): add login functionality")
+	fmt.Println("Recreate them with changelog-add, or to skip this check temporarily, use:")
+	fmt.Println("   SKIP_CHANGELOG_CHECK=1 git commit")
+	fmt.Println()
+	return fmt.Errorf("%d malformed changelog fragment(s)", len(problems))
+}
+
+// changelogFragmentLocation reports whether file is a changelog fragment: a
+// .txt file in the global changelog dir or in a changelog app's .changelog/.
+// inApp is true for the latter.
+func changelogFragmentLocation(file, globalDir string, apps map[string]AppConfig) (inApp, ok bool) {
+	if !strings.HasSuffix(file, ".txt") {
+		return false, false
+	}
+	for _, app := range apps {
+		// "/" not filepath.Join — git paths are always forward-slash (see checkPerAppChangelog).
+		if strings.HasPrefix(file, app.Path+"/.changelog/") {
+			return true, true
+		}
+	}
+	if globalDir == "" {
+		globalDir = ".changelog"
+	}
+	return false, strings.HasPrefix(file, globalDir+"/")
+}
+
+// changelogScopeMatchesApp reports whether scope names one of apps, compared
+// case-insensitively as changelog-add routes entries.
+func changelogScopeMatchesApp(scope string, apps map[string]AppConfig) bool {
+	for name := range apps {
+		if strings.EqualFold(name, scope) {
+			return true
+		}
+	}
+	return false
+}
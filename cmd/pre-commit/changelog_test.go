@@ -2,6 +2,7 @@ package main
 
 import (
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -324,3 +325,116 @@ func TestGetChangelogApps(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckChangelogFragmentFormat(t *testing.T) {
+	apps := map[string]AppConfig{
+		"native": {Path: "apps/native"},
+		"web":    {Path: "apps/web"},
+	}
+	fragments := map[string]string{
+		".changelog/good.txt":             "feat: add search\n",
+		".changelog/web.txt":              "fix(web): resolve navigation bug\n",
+		".changelog/unknown-scope.txt":    "fix(admin): resolve navigation bug\n",
+		".changelog/no-colon.txt":         "added search to the header\n",
+		".changelog/bad-type.txt":         "feature: add search\n",
+		"apps/native/.changelog/auth.txt": "feat(auth): add login\n",
+	}
+	orig := changelogFragmentReader
+	changelogFragmentReader = func(file string) ([]byte, error) {
+		content, ok := fragments[file]
+		if !ok {
+			return nil, os.ErrNotExist
+		}
+		return []byte(content), nil
+	}
+	defer func() { changelogFragmentReader = orig }()
+	_ = os.Unsetenv("SKIP_CHANGELOG_CHECK")
+
+	tests := []struct {
+		name        string
+		stagedFiles []string
+		config      ChangelogConfig
+		wantErr     bool
+	}{
+		{
+			name:        "global: well-formed fragment passes",
+			stagedFiles: []string{"src/app.ts", ".changelog/good.txt"},
+			config:      ChangelogConfig{Mode: "global", GlobalDir: ".changelog"},
+		},
+		{
+			name:        "global: fragment without a type fails",
+			stagedFiles: []string{"src/app.ts", ".changelog/no-colon.txt"},
+			config:      ChangelogConfig{Mode: "global", GlobalDir: ".changelog"},
+			wantErr:     true,
+		},
+		{
+			name:        "global: unknown type fails even when all other files are excluded",
+			stagedFiles: []string{"README.md", ".changelog/bad-type.txt"},
+			config:      ChangelogConfig{Mode: "global", GlobalDir: ".changelog"},
+			wantErr:     true,
+		},
+		{
+			name:        "global: any scope is accepted",
+			stagedFiles: []string{"src/app.ts", ".changelog/unknown-scope.txt"},
+			config:      ChangelogConfig{Mode: "global", GlobalDir: ".changelog"},
+		},
+		{
+			name:        "unreadable fragment is skipped",
+			stagedFiles: []string{"src/app.ts", ".changelog/deleted.txt"},
+			config:      ChangelogConfig{Mode: "global", GlobalDir: ".changelog"},
+		},
+		{
+			name:        "per-app: root fragment scoped to an app passes",
+			stagedFiles: []string{"packages/shared/utils.ts", ".changelog/web.txt"},
+			config:      ChangelogConfig{Mode: "per-app"},
+		},
+		{
+			name:        "per-app: root fragment scoped to an unknown app passes, as changelog-add files it",
+			stagedFiles: []string{"packages/shared/utils.ts", ".changelog/unknown-scope.txt"},
+			config:      ChangelogConfig{Mode: "per-app"},
+		},
+		{
+			name:        "per-app strictScope: root fragment scoped to an unknown app fails",
+			stagedFiles: []string{"packages/shared/utils.ts", ".changelog/unknown-scope.txt"},
+			config:      ChangelogConfig{Mode: "per-app", StrictScope: true},
+			wantErr:     true,
+		},
+		{
+			name:        "required: root fragment scoped to an unknown app fails",
+			stagedFiles: []string{"apps/native/src/app.ts", "apps/native/.changelog/auth.txt", ".changelog/unknown-scope.txt"},
+			config:      ChangelogConfig{Mode: "required"},
+			wantErr:     true,
+		},
+		{
+			name:        "per-app: unscoped root fragment passes",
+			stagedFiles: []string{"packages/shared/utils.ts", ".changelog/good.txt"},
+			config:      ChangelogConfig{Mode: "per-app"},
+		},
+		{
+			name:        "required: fragment in an app's .changelog keeps any scope",
+			stagedFiles: []string{"apps/native/src/app.ts", "apps/native/.changelog/auth.txt"},
+			config:      ChangelogConfig{Mode: "required"},
+		},
+		{
+			name:        "required: unscoped root fragment fails",
+			stagedFiles: []string{"apps/native/src/app.ts", "apps/native/.changelog/auth.txt", ".changelog/good.txt"},
+			config:      ChangelogConfig{Mode: "required"},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkChangelog(tt.stagedFiles, []string{`\.md$`, `^\.changelog/`}, tt.config, apps)
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "malformed changelog fragment") {
+					t.Errorf("checkChangelog() error = %v, want malformed changelog fragment", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("checkChangelog() unexpected error: %v", err)
+			}
+		})
+	}
+}
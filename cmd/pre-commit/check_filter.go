@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/milehighideas/claude-hooks/internal/glyph"
+)
+
+// unfilteredFeatures are Features keys --only and --except don't accept:
+// settings that tune another check rather than enable one, and branch
+// protection, whose only bypass is the logged SKIP_BRANCH_PROTECTION.
+var unfilteredFeatures = map[string]bool{
+	"fullLintOnCommit":  true,
+	"fullSRPOnCommit":   true,
+	"srpStrictOnStaged": true,
+	"branchProtection":  true,
+}
+
+// filterableChecks returns the check names --only and --except accept: the
+// features keys that turn a check on, sorted.
+func filterableChecks() []string {
+	var names []string
+	for _, key := range jsonKeys(reflect.TypeOf(Features{})) {
+		if !unfilteredFeatures[key] {
+			names = append(names, key)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// parseCheckList splits a comma-separated --only/--except value.
+func parseCheckList(value string) []string {
+	var names []string
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// applyCheckFilter turns off the enabled checks that only leaves out or
+// except names; an empty only keeps every check. It never enables a check
+// the config leaves off. Unknown names are an error, with a suggestion for
+// near misses.
+func applyCheckFilter(features *Features, only, except []string) error {
+	known := filterableChecks()
+	var problems []string
+	for _, list := range []struct {
+		flag  string
+		names []string
+	}{{"--only", only}, {"--except", except}} {
+		for _, name := range list.names {
+			if slices.Contains(known, name) {
+				continue
+			}
+			problem := fmt.Sprintf("%s %q", list.flag, name)
+			if s := suggestKey(name, known); s != "" {
+				problem += fmt.Sprintf(" (did you mean %q?)", s)
+			}
+			problems = append(problems, problem)
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("unknown check name(s):\n  %s\nRun with --list to see available checks", strings.Join(problems, "\n  "))
+	}
+
+	fieldByKey := featureFields(features)
+	for _, name := range only {
+		if !fieldByKey[name].Bool() {
+			fmt.Printf(glyph.Info+"  --only %s: not enabled in .pre-commit.json, skipping\n", name)
+		}
+	}
+	for _, name := range known {
+		if (len(only) > 0 && !slices.Contains(only, name)) || slices.Contains(except, name) {
+			fieldByKey[name].SetBool(false)
+		}
+	}
+	return nil
+}
+
+// filterChecks applies the --only and --except flags to config.
+func filterChecks(config *Config) error {
+	if onlyChecks == "" && exceptChecks == "" {
+		return nil
+	}
+	if checkName != "" {
+		return fmt.Errorf("--check cannot be combined with --only or --except")
+	}
+	return applyCheckFilter(&config.Features, parseCheckList(onlyChecks), parseCheckList(exceptChecks))
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestApplyCheckFilter(t *testing.T) {
+	enabled := func() Features {
+		return Features{Lint: true, Typecheck: true, Tests: true, SRP: true, ConsoleCheck: true, BranchProtection: true, FullLintOnCommit: true}
+	}
+	tests := []struct {
+		name   string
+		only   []string
+		except []string
+		want   Features
+	}{
+		{
+			name: "no filter keeps every check",
+			want: enabled(),
+		},
+		{
+			name: "only keeps the named checks",
+			only: []string{"srp", "consoleCheck"},
+			want: Features{SRP: true, ConsoleCheck: true, BranchProtection: true, FullLintOnCommit: true},
+		},
+		{
+			name:   "except drops the named checks",
+			except: []string{"tests"},
+			want:   Features{Lint: true, Typecheck: true, SRP: true, ConsoleCheck: true, BranchProtection: true, FullLintOnCommit: true},
+		},
+		{
+			name:   "except applies after only",
+			only:   []string{"lint", "typecheck", "srp"},
+			except: []string{"typecheck"},
+			want:   Features{Lint: true, SRP: true, BranchProtection: true, FullLintOnCommit: true},
+		},
+		{
+			name: "only never enables a disabled check",
+			only: []string{"srp", "mockCheck"},
+			want: Features{SRP: true, BranchProtection: true, FullLintOnCommit: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := enabled()
+			if err := applyCheckFilter(&got, tt.only, tt.except); err != nil {
+				t.Fatalf("applyCheckFilter: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("features = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyCheckFilter_InvalidNames(t *testing.T) {
+	tests := []struct {
+		name        string
+		only        []string
+		except      []string
+		errContains []string
+	}{
+		{
+			name:        "typo in only",
+			only:        []string{"srpp"},
+			errContains: []string{`--only "srpp" (did you mean "srp"?)`},
+		},
+		{
+			name:        "typo in except",
+			except:      []string{"test"},
+			errContains: []string{`--except "test" (did you mean "tests"?)`},
+		},
+		{
+			name:        "every unknown name reported",
+			only:        []string{"lint", "zzzzzz"},
+			except:      []string{"consolCheck"},
+			errContains: []string{`--only "zzzzzz"`, `--except "consolCheck" (did you mean "consoleCheck"?)`},
+		},
+		{
+			name:        "modifiers are not checks",
+			only:        []string{"fullLintOnCommit"},
+			errContains: []string{`--only "fullLintOnCommit"`},
+		},
+		{
+			name:        "branch protection cannot be filtered out",
+			except:      []string{"branchProtection"},
+			errContains: []string{`--except "branchProtection"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			features := Features{Lint: true, SRP: true}
+			err := applyCheckFilter(&features, tt.only, tt.except)
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			for _, want := range tt.errContains {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error = %q, want it to contain %q", err, want)
+				}
+			}
+			if !features.Lint || !features.SRP {
+				t.Errorf("features changed despite the error: %+v", features)
+			}
+		})
+	}
+}
+
+func TestParseCheckList(t *testing.T) {
+	got := parseCheckList(" srp, consoleCheck,,lint ")
+	want := []string{"srp", "consoleCheck", "lint"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("parseCheckList = %q, want %q", got, want)
+	}
+	if got := parseCheckList(""); len(got) != 0 {
+		t.Errorf("parseCheckList(\"\") = %q, want none", got)
+	}
+}
+
+func TestFilterChecks_RejectsCheckFlag(t *testing.T) {
+	origCheck, origOnly := checkName, onlyChecks
+	defer func() { checkName, onlyChecks = origCheck, origOnly }()
+	checkName, onlyChecks = "srp", "lint"
+
+	err := filterChecks(&Config{})
+	if err == nil || !strings.Contains(err.Error(), "--check cannot be combined") {
+		t.Errorf("err = %v, want --check conflict", err)
+	}
+}
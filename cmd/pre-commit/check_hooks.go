@@ -0,0 +1,96 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/milehighideas/claude-hooks/internal/glyph"
+)
+
+// CheckHookConfig configures a user command run around the checks: preCheck
+// runs before any check, postCheck only after every check has passed.
+type CheckHookConfig struct {
+	// Command is the executable to run (e.g., "./scripts/notify.sh").
+	// Empty disables the hook.
+	Command string `json:"command"`
+	// Args are the arguments passed to Command.
+	Args []string `json:"args"`
+	// FailOnError makes a failing postCheck fail the commit. A failing
+	// preCheck always aborts, so this field is ignored there. Default: false
+	// (postCheck failures only warn).
+	FailOnError bool `json:"failOnError"`
+}
+
+// checkHookRunner is the indirection point for tests. Production code
+// invokes runCheckHookCommand; tests overwrite this to record invocations.
+var checkHookRunner = runCheckHookCommand
+
+// runCheckHookCommand runs a hook command in dir with the configured env
+// merged over the current environment, streaming its output to the terminal.
+func runCheckHookCommand(dir string, env map[string]string, name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	if errors.Is(cmd.Err, exec.ErrDot) {
+		cmd.Err = nil
+	}
+	cmd.Dir = dir
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if len(env) > 0 {
+		cmd.Env = os.Environ()
+		for k, v := range env {
+			cmd.Env = append(cmd.Env, k+"="+v)
+		}
+	}
+	return cmd.Run()
+}
+
+// hookRootDir returns the directory hook commands run from: the git repo
+// root, or the working directory outside a repo.
+func hookRootDir() string {
+	if root := getRepoToplevel(); root != "unknown" {
+		return root
+	}
+	dir, _ := os.Getwd()
+	return dir
+}
+
+// runPreCheck runs config.PreCheck. Any failure aborts the commit.
+func runPreCheck(config *Config) error {
+	hook := config.PreCheck
+	if hook.Command == "" {
+		return nil
+	}
+	printStart("Pre-check")
+	if err := checkHookRunner(hookRootDir(), config.Env, hook.Command, hook.Args...); err != nil {
+		printStatus("Pre-check", false, "")
+		return fmt.Errorf("preCheck %q failed: %w", hook.Command, err)
+	}
+	printStatus("Pre-check", true, "")
+	return nil
+}
+
+// runPostCheck runs config.PostCheck after all checks have passed. Failures
+// only warn unless postCheck.failOnError is set.
+func runPostCheck(config *Config) error {
+	hook := config.PostCheck
+	if hook.Command == "" {
+		return nil
+	}
+	printStart("Post-check")
+	err := checkHookRunner(hookRootDir(), config.Env, hook.Command, hook.Args...)
+	if err == nil {
+		printStatus("Post-check", true, "")
+		return nil
+	}
+	if hook.FailOnError {
+		printStatus("Post-check", false, "")
+		return fmt.Errorf("postCheck %q failed: %w", hook.Command, err)
+	}
+	printWarningStatus("Post-check", "failed")
+	if !compactMode() {
+		fmt.Printf("  "+glyph.Warn+"  postCheck %q failed (non-blocking): %v\n", hook.Command, err)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type hookCall struct {
+	dir  string
+	env  map[string]string
+	name string
+	args []string
+}
+
+// withMockCheckHookRunner swaps checkHookRunner for the duration of the test,
+// recording every invocation and returning err from each.
+func withMockCheckHookRunner(t *testing.T, err error) *[]hookCall {
+	t.Helper()
+	var calls []hookCall
+	orig := checkHookRunner
+	checkHookRunner = func(dir string, env map[string]string, name string, args ...string) error {
+		calls = append(calls, hookCall{dir, env, name, args})
+		return err
+	}
+	t.Cleanup(func() { checkHookRunner = orig })
+	return &calls
+}
+
+func TestRunPreCheck(t *testing.T) {
+	t.Run("unset is a no-op", func(t *testing.T) {
+		calls := withMockCheckHookRunner(t, nil)
+		if err := runPreCheck(&Config{}); err != nil {
+			t.Fatalf("runPreCheck() error = %v", err)
+		}
+		if len(*calls) != 0 {
+			t.Errorf("expected no invocation, got %+v", *calls)
+		}
+	})
+
+	t.Run("runs command from repo root with env", func(t *testing.T) {
+		calls := withMockCheckHookRunner(t, nil)
+		config := &Config{
+			Env:      map[string]string{"FOO": "bar"},
+			PreCheck: CheckHookConfig{Command: "./scripts/warm.sh", Args: []string{"--fast"}},
+		}
+		if err := runPreCheck(config); err != nil {
+			t.Fatalf("runPreCheck() error = %v", err)
+		}
+		if len(*calls) != 1 {
+			t.Fatalf("expected 1 invocation, got %d", len(*calls))
+		}
+		c := (*calls)[0]
+		if c.name != "./scripts/warm.sh" || strings.Join(c.args, " ") != "--fast" {
+			t.Errorf("invoked %s %v", c.name, c.args)
+		}
+		if c.dir != hookRootDir() {
+			t.Errorf("dir = %q, want %q", c.dir, hookRootDir())
+		}
+		if c.env["FOO"] != "bar" {
+			t.Errorf("env not passed through: %v", c.env)
+		}
+	})
+
+	t.Run("failure aborts", func(t *testing.T) {
+		withMockCheckHookRunner(t, errors.New("exit status 1"))
+		err := runPreCheck(&Config{PreCheck: CheckHookConfig{Command: "false"}})
+		if err == nil || !strings.Contains(err.Error(), "preCheck") {
+			t.Errorf("expected preCheck error, got %v", err)
+		}
+	})
+
+	t.Run("failOnError is ignored: preCheck always aborts", func(t *testing.T) {
+		withMockCheckHookRunner(t, errors.New("exit status 1"))
+		err := runPreCheck(&Config{PreCheck: CheckHookConfig{Command: "false", FailOnError: false}})
+		if err == nil {
+			t.Error("expected preCheck failure to abort regardless of failOnError")
+		}
+	})
+}
+
+func TestRunPostCheck(t *testing.T) {
+	t.Run("unset is a no-op", func(t *testing.T) {
+		calls := withMockCheckHookRunner(t, nil)
+		if err := runPostCheck(&Config{}); err != nil {
+			t.Fatalf("runPostCheck() error = %v", err)
+		}
+		if len(*calls) != 0 {
+			t.Errorf("expected no invocation, got %+v", *calls)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		calls := withMockCheckHookRunner(t, nil)
+		if err := runPostCheck(&Config{PostCheck: CheckHookConfig{Command: "notify"}}); err != nil {
+			t.Fatalf("runPostCheck() error = %v", err)
+		}
+		if len(*calls) != 1 || (*calls)[0].name != "notify" {
+			t.Errorf("expected notify to run once, got %+v", *calls)
+		}
+	})
+
+	t.Run("failure warns by default", func(t *testing.T) {
+		withMockCheckHookRunner(t, errors.New("exit status 1"))
+		if err := runPostCheck(&Config{PostCheck: CheckHookConfig{Command: "notify"}}); err != nil {
+			t.Errorf("postCheck failure should not fail the commit, got %v", err)
+		}
+	})
+
+	t.Run("failure fails when failOnError", func(t *testing.T) {
+		withMockCheckHookRunner(t, errors.New("exit status 1"))
+		err := runPostCheck(&Config{PostCheck: CheckHookConfig{Command: "notify", FailOnError: true}})
+		if err == nil || !strings.Contains(err.Error(), "postCheck") {
+			t.Errorf("expected postCheck error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Check severities for checkSeverity. A failing "error" check blocks the
+// commit, a failing "warning" check is reported without blocking, and an
+// "off" check doesn't run.
+const (
+	severityError   = "error"
+	severityWarning = "warning"
+	severityOff     = "off"
+)
+
+// SeverityOf returns the severity of the named check: its checkSeverity entry
+// if it has one, otherwise warning for checks listed in warningChecks or
+// nonBlockingChecks and custom checks with warning set, and error for the
+// rest.
+func (c *Config) SeverityOf(name string) string {
+	if s, ok := c.CheckSeverity[name]; ok {
+		return s
+	}
+	if slices.Contains(c.WarningChecks, name) || slices.Contains(nonBlockingChecks, name) {
+		return severityWarning
+	}
+	if check, ok := c.customCheck(name); ok && check.Warning {
+		return severityWarning
+	}
+	return severityError
+}
+
+// severityCheckNames returns the check keys checkSeverity accepts, sorted.
+func severityCheckNames() []string {
+	names := filterableChecks()
+	for key := range checkKeyToDisplay {
+		if !slices.Contains(names, key) {
+			names = append(names, key)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// applyCheckSeverity validates config.CheckSeverity and turns off the
+// features of checks set to "off", overriding the features flags.
+func applyCheckSeverity(config *Config) error {
+	if len(config.CheckSeverity) == 0 {
+		return nil
+	}
+	known := append(severityCheckNames(), config.customCheckNames()...)
+	names := make([]string, 0, len(config.CheckSeverity))
+	for name := range config.CheckSeverity {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var problems []string
+	for _, name := range names {
+		severity := config.CheckSeverity[name]
+		if !slices.Contains(known, name) {
+			problem := fmt.Sprintf("checkSeverity: unknown check %q", name)
+			if s := suggestKey(name, known); s != "" {
+				problem += fmt.Sprintf(" (did you mean %q?)", s)
+			}
+			problems = append(problems, problem)
+			continue
+		}
+		switch severity {
+		case severityError, severityWarning:
+		case severityOff:
+			if field, ok := featureFields(&config.Features)[name]; ok {
+				field.SetBool(false)
+			}
+		default:
+			problems = append(problems, fmt.Sprintf("checkSeverity.%s: unknown severity %q (want error, warning or off)", name, severity))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid .pre-commit.json:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+// checkResults collects failed checks by severity. Checks run concurrently,
+// so collect is safe to call from several goroutines.
+type checkResults struct {
+	config     *Config
+	mu         sync.Mutex
+	errors     []string
+	warnings   []string
+	failedHelp []string // "check: help" for blocking failures with checkHelp set
+}
+
+// collect records err, if any, as a warning or an error according to the
+// check's severity. Failures of checks that are off are dropped.
+func (r *checkResults) collect(checkName string, err error) {
+	if err == nil {
+		return
+	}
+	severity := r.config.SeverityOf(checkName)
+	if severity == severityOff {
+		return
+	}
+	msg := r.config.FailureMessage(checkName, err)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if severity == severityWarning {
+		r.warnings = append(r.warnings, msg)
+		return
+	}
+	r.errors = append(r.errors, msg)
+	if help := r.config.CheckHelpFor(checkName); help != "" {
+		r.failedHelp = append(r.failedHelp, fmt.Sprintf("%s: %s", checkName, help))
+	}
+}
+
+// featureFields maps each Features json key to its settable field.
+func featureFields(features *Features) map[string]reflect.Value {
+	v := reflect.ValueOf(features).Elem()
+	fields := make(map[string]reflect.Value, v.NumField())
+	for i := 0; i < v.NumField(); i++ {
+		name, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("json"), ",")
+		fields[name] = v.Field(i)
+	}
+	return fields
+}
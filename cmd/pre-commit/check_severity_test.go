@@ -0,0 +1,93 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheckResults_RoutesBySeverity(t *testing.T) {
+	config := &Config{
+		WarningChecks: []string{"lint", "maestroValidation"},
+		CheckSeverity: map[string]string{
+			"lint":            severityError,   // ramped up from warningChecks
+			"typecheck":       severityWarning, // ramped down
+			"tests":           severityOff,
+			"stubSourceCheck": severityError, // overrides nonBlockingChecks
+		},
+		CheckHelp: map[string]string{"lint": "see docs/lint.md"},
+	}
+	results := &checkResults{config: config}
+	for _, name := range []string{"lint", "typecheck", "tests", "stubSourceCheck", "maestroValidation", "changelog"} {
+		results.collect(name, errors.New("failed"))
+	}
+	results.collect("consoleCheck", nil)
+
+	wantErrors := []string{"lint", "stubSourceCheck", "changelog"}
+	wantWarnings := []string{"typecheck", "maestroValidation"}
+	if got := checkNames(results.errors); strings.Join(got, ",") != strings.Join(wantErrors, ",") {
+		t.Errorf("errors = %v, want %v", got, wantErrors)
+	}
+	if got := checkNames(results.warnings); strings.Join(got, ",") != strings.Join(wantWarnings, ",") {
+		t.Errorf("warnings = %v, want %v", got, wantWarnings)
+	}
+	if len(results.failedHelp) != 1 || results.failedHelp[0] != "lint: see docs/lint.md" {
+		t.Errorf("failedHelp = %v, want the lint help", results.failedHelp)
+	}
+}
+
+// checkNames returns the check name each failure message starts with.
+func checkNames(msgs []string) []string {
+	var names []string
+	for _, msg := range msgs {
+		name, _, _ := strings.Cut(msg, ":")
+		names = append(names, name)
+	}
+	return names
+}
+
+func TestApplyCheckSeverity_OffDisablesFeature(t *testing.T) {
+	config := &Config{
+		Features:      Features{Lint: true, Typecheck: true, Changelog: true},
+		CheckSeverity: map[string]string{"typecheck": severityOff, "changelog": severityWarning},
+	}
+	if err := applyCheckSeverity(config); err != nil {
+		t.Fatalf("applyCheckSeverity() error = %v", err)
+	}
+	if config.Features.Typecheck {
+		t.Error("typecheck: off should disable the feature")
+	}
+	if !config.Features.Lint || !config.Features.Changelog {
+		t.Errorf("features = %+v, want lint and changelog still enabled", config.Features)
+	}
+}
+
+func TestApplyCheckSeverity_RejectsUnknownEntries(t *testing.T) {
+	config := &Config{CheckSeverity: map[string]string{
+		"typechek": severityWarning,
+		"lint":     "blocking",
+	}}
+	err := applyCheckSeverity(config)
+	if err == nil {
+		t.Fatal("applyCheckSeverity() error = nil, want unknown check and severity reported")
+	}
+	for _, want := range []string{`unknown check "typechek" (did you mean "typecheck"?)`, `checkSeverity.lint: unknown severity "blocking"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error = %q, want it to contain %q", err, want)
+		}
+	}
+}
+
+func TestRegisterWarningChecks_FollowsCheckSeverity(t *testing.T) {
+	t.Cleanup(func() { warningDisplayNames = map[string]bool{} })
+	registerWarningChecks(&Config{
+		WarningChecks: []string{"lint"},
+		CheckSeverity: map[string]string{"lint": severityError, "typecheck": severityWarning},
+	})
+	if warningDisplayNames["Lint"] {
+		t.Error("lint is an error check, want it rendered as a failure")
+	}
+	if !warningDisplayNames["Typecheck"] || !warningDisplayNames["Stub sources"] {
+		t.Errorf("warningDisplayNames = %v, want Typecheck and Stub sources", warningDisplayNames)
+	}
+}
@@ -5,9 +5,12 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path"
 	"sort"
 	"strings"
 	"sync"
+
+	"github.com/milehighideas/claude-hooks/internal/glyph"
 )
 
 // AppCheckResult holds the result of checking a single app for a single phase
@@ -45,16 +48,37 @@ type phaseJob struct {
 // collectJobs builds the per-app job list for a phase. A job is included when
 // fullLintOnCommit is set, when a shared path changed, or when the app has its
 // own staged files. Jobs inherit each app's skip flags via the skipped field so
-// the runner can still emit a "skipped" status line in the report.
+// the runner can still emit a "skipped" status line in the report. An app
+// whose glob filter expanded to several packages gets one job per package,
+// named "<app>-<package dir>", with Path and Filter narrowed to it.
 func collectJobs(apps map[string]AppConfig, appFiles map[string][]string, sharedChanged, fullLintOnCommit bool, appSkipped func(AppConfig) bool) []phaseJob {
 	var jobs []phaseJob
-	for appName, appConfig := range apps {
-		files := appFiles[appName]
-
+	add := func(name string, config AppConfig, files []string) {
 		if fullLintOnCommit {
-			jobs = append(jobs, phaseJob{name: appName, config: appConfig, files: files, full: true, skipped: appSkipped(appConfig)})
+			jobs = append(jobs, phaseJob{name: name, config: config, files: files, full: true, skipped: appSkipped(config)})
 		} else if sharedChanged || len(files) > 0 {
-			jobs = append(jobs, phaseJob{name: appName, config: appConfig, files: files, full: sharedChanged, skipped: appSkipped(appConfig)})
+			jobs = append(jobs, phaseJob{name: name, config: config, files: files, full: sharedChanged, skipped: appSkipped(config)})
+		}
+	}
+
+	for appName, appConfig := range apps {
+		files := appFiles[appName]
+		if len(appConfig.packages) == 0 {
+			add(appName, appConfig, files)
+			continue
+		}
+		for _, pkg := range appConfig.packages {
+			pkgConfig := appConfig
+			pkgConfig.Path = pkg.Dir
+			pkgConfig.Filter = pkg.Name
+			pkgConfig.packages = nil
+			var pkgFiles []string
+			for _, f := range files {
+				if strings.HasPrefix(f, pkg.Dir+"/") {
+					pkgFiles = append(pkgFiles, f)
+				}
+			}
+			add(appName+"-"+path.Base(pkg.Dir), pkgConfig, pkgFiles)
 		}
 	}
 	return jobs
@@ -129,20 +153,27 @@ func runLintTo(w io.Writer, apps map[string]AppConfig, appFiles map[string][]str
 			var errs int
 
 			if j.skipped {
-				fmt.Fprintf(&output, "   ⏩ %s lint skipped (skipLint: true)\n", j.name)
+				fmt.Fprintf(&output, "   "+glyph.Skip+" %s lint skipped (skipLint: true)\n", j.name)
 				printStatus(appCheck, true, "skipped")
 			} else if j.full {
-				fmt.Fprintf(&output, "🔍 Running full lint for %s...\n", j.name)
-				lintOutput, lintErr := runFilteredLintBuffered(j.name, j.config.Path, lintFilter)
-				output.WriteString(lintOutput)
-				if lintErr != nil {
-					fmt.Fprintf(&output, "   ❌ %s lint failed\n", j.name)
-					errs = extractErrorCount(lintErr)
-					err = lintErr
-					printStatus(appCheck, false, fmt.Sprintf("%d errors", errs))
+				cacheKey, cached := phaseCache.lookup("lint", j, lintFilter)
+				if cached {
+					fmt.Fprintf(&output, "   "+glyph.Tick+" %s passed lint (cached, no inputs changed)\n", j.name)
+					printStatus(appCheck, true, "cached")
 				} else {
-					fmt.Fprintf(&output, "   ✓ %s passed lint\n", j.name)
-					printStatus(appCheck, true, "")
+					fmt.Fprintf(&output, glyph.Search+" Running full lint for %s...\n", j.name)
+					lintOutput, lintErr := runFilteredLintBuffered(j.name, j.config.Path, lintFilter)
+					output.WriteString(lintOutput)
+					if lintErr != nil {
+						fmt.Fprintf(&output, "   "+glyph.Fail+" %s lint failed\n", j.name)
+						errs = extractErrorCount(lintErr)
+						err = lintErr
+						printStatus(appCheck, false, fmt.Sprintf("%d errors", errs))
+					} else {
+						fmt.Fprintf(&output, "   "+glyph.Tick+" %s passed lint\n", j.name)
+						printStatus(appCheck, true, "")
+						phaseCache.store("lint", cacheKey)
+					}
 				}
 			} else {
 				// Incremental: lint-staged already ran on the staged files.
@@ -152,7 +183,7 @@ func runLintTo(w io.Writer, apps map[string]AppConfig, appFiles map[string][]str
 					fmt.Fprintf(&output, "   No lintable files in %s\n", j.name)
 					printStatus(appCheck, true, "no files")
 				} else {
-					fmt.Fprintf(&output, "   ✓ %s lint handled by lint-staged (%d files)\n", j.name, len(lintFiles))
+					fmt.Fprintf(&output, "   "+glyph.Tick+" %s lint handled by lint-staged (%d files)\n", j.name, len(lintFiles))
 					printStatus(appCheck, true, fmt.Sprintf("%d files via lint-staged", len(lintFiles)))
 				}
 			}
@@ -246,20 +277,24 @@ func runTypecheckTo(w io.Writer, apps map[string]AppConfig, appFiles map[string]
 			effectiveFilter := GetTypecheckFilter(typecheckFilter, j.config.TypecheckFilter)
 
 			if j.skipped {
-				fmt.Fprintf(&output, "   ⏩ %s typecheck skipped (skipTypecheck: true)\n", j.name)
+				fmt.Fprintf(&output, "   "+glyph.Skip+" %s typecheck skipped (skipTypecheck: true)\n", j.name)
 				printStatus(appCheck, true, "skipped")
+			} else if cacheKey, cached := phaseCache.lookup("typecheck", j, effectiveFilter); cached {
+				fmt.Fprintf(&output, "   "+glyph.Tick+" %s passed typecheck (cached, no inputs changed)\n", j.name)
+				printStatus(appCheck, true, "cached")
 			} else if j.full {
-				fmt.Fprintf(&output, "🔍 Running full typecheck for %s...\n", j.name)
-				tcOutput, tcErr := runFilteredTypecheckBuffered(j.name, j.config.Path, j.config.Filter, packageManager, effectiveFilter, j.config.NodeMemoryMB)
+				fmt.Fprintf(&output, glyph.Search+" Running full typecheck for %s...\n", j.name)
+				tcOutput, tcErr := runFilteredTypecheckBuffered(j.name, j.config.Path, j.config.Filter, j.config.packageManagerFor(packageManager), effectiveFilter, j.config.NodeMemoryMB)
 				output.WriteString(tcOutput)
 				if tcErr != nil {
-					fmt.Fprintf(&output, "   ❌ %s typecheck failed\n", j.name)
+					fmt.Fprintf(&output, "   "+glyph.Fail+" %s typecheck failed\n", j.name)
 					errs = extractErrorCount(tcErr)
 					err = tcErr
 					printStatus(appCheck, false, fmt.Sprintf("%d errors", errs))
 				} else {
-					fmt.Fprintf(&output, "   ✓ %s passed typecheck\n", j.name)
+					fmt.Fprintf(&output, "   "+glyph.Tick+" %s passed typecheck\n", j.name)
 					printStatus(appCheck, true, "")
+					phaseCache.store("typecheck", cacheKey)
 				}
 			} else {
 				// Incremental typecheck on changed files only.
@@ -268,17 +303,18 @@ func runTypecheckTo(w io.Writer, apps map[string]AppConfig, appFiles map[string]
 					fmt.Fprintf(&output, "   No typecheckable files in %s\n", j.name)
 					printStatus(appCheck, true, "no files")
 				} else {
-					fmt.Fprintf(&output, "🔍 Running incremental typecheck for %s (%d files)...\n", j.name, len(lintFiles))
+					fmt.Fprintf(&output, glyph.Search+" Running incremental typecheck for %s (%d files)...\n", j.name, len(lintFiles))
 					tcOutput, tcErr := runIncrementalTypecheckBuffered(j.config.Path, lintFiles, effectiveFilter)
 					output.WriteString(tcOutput)
 					if tcErr != nil {
-						fmt.Fprintf(&output, "   ❌ %s incremental typecheck failed\n", j.name)
+						fmt.Fprintf(&output, "   "+glyph.Fail+" %s incremental typecheck failed\n", j.name)
 						errs = extractErrorCount(tcErr)
 						err = tcErr
 						printStatus(appCheck, false, fmt.Sprintf("%d errors", errs))
 					} else {
-						fmt.Fprintf(&output, "   ✓ %s passed incremental typecheck\n", j.name)
+						fmt.Fprintf(&output, "   "+glyph.Tick+" %s passed incremental typecheck\n", j.name)
 						printStatus(appCheck, true, fmt.Sprintf("%d files", len(lintFiles)))
+						phaseCache.store("typecheck", cacheKey)
 					}
 				}
 			}
@@ -7,18 +7,22 @@ import (
 	"os"
 	"strings"
 
+	"github.com/milehighideas/claude-hooks/internal/glyph"
 	"github.com/milehighideas/claude-hooks/internal/jsonc"
+	"github.com/milehighideas/claude-hooks/internal/pkgmanager"
 )
 
 // Config represents the .pre-commit.json configuration
 type Config struct {
-	PackageManager                string                        `json:"packageManager"` // Global package manager: "pnpm" (default), "bun", "npm", "yarn"
+	SchemaVersion                 int                           `json:"schemaVersion"`  // When set, unknown keys are rejected (see validateConfigKeys)
+	PackageManager                string                        `json:"packageManager"` // Global package manager when package.json and lockfiles don't decide: "pnpm" (default), "bun", "npm", "yarn"
 	Env                           map[string]string             `json:"env"`            // Global environment variables for all commands
 	Apps                          map[string]AppConfig          `json:"apps"`
 	SharedPaths                   []string                      `json:"sharedPaths"`
 	ReportDir                     string                        `json:"reportDir"` // Directory to write analysis reports
 	Features                      Features                      `json:"features"`
 	ProtectedBranches             []string                      `json:"protectedBranches"`
+	AllowOnProtected              []string                      `json:"allowOnProtected"` // Globs of files that may be committed on a protected branch
 	ChangelogExclude              []string                      `json:"changelogExclude"`
 	ChangelogConfig               ChangelogConfig               `json:"changelog"`
 	ConsoleAllowed                []string                      `json:"consoleAllowed"`
@@ -39,6 +43,7 @@ type Config struct {
 	SRPConfig                     SRPConfig                     `json:"srpConfig"`
 	SRPNativeConfig               SRPNativeConfig               `json:"srpNativeConfig"`
 	DataLayerAllowed              []string                      `json:"dataLayerAllowed"`
+	EnvAccessCheckConfig          EnvAccessCheckConfig          `json:"envAccessCheckConfig"`
 	NextImageCheck                NextImageCheckConfig          `json:"nextImageCheck"`
 	NextLinkCheck                 NextLinkCheckConfig           `json:"nextLinkCheck"`
 	MaestroValidation             MaestroValidationConfig       `json:"maestroValidation"`
@@ -46,7 +51,16 @@ type Config struct {
 	MissingTestsCheckConfig       MissingTestsCheckConfig       `json:"missingTestsCheckConfig"`
 	TestSubstanceCheckConfig      TestSubstanceCheckConfig      `json:"testSubstanceCheckConfig"`
 	RedundantCreatedAtCheckConfig RedundantCreatedAtCheckConfig `json:"redundantCreatedAtCheckConfig"`
+	FileHeaderCheckConfig         FileHeaderCheckConfig         `json:"fileHeaderCheckConfig"`
+	StubSourceCheckConfig         StubSourceCheckConfig         `json:"stubSourceCheckConfig"`
+	MaxFilesCheckConfig           MaxFilesCheckConfig           `json:"maxFilesCheckConfig"`
+	ConflictMarkersConfig         ConflictMarkersConfig         `json:"conflictMarkersConfig"`
 	WarningChecks                 []string                      `json:"warningChecks"` // Checks listed here run but don't block commits
+	CheckSeverity                 map[string]string             `json:"checkSeverity"` // Check key -> "error", "warning" or "off"; overrides warningChecks and features
+	CheckHelp                     map[string]string             `json:"checkHelp"`     // Check key -> help message or URL shown when that check fails
+	PreCheck                      CheckHookConfig               `json:"preCheck"`      // Command run before any check; failure aborts
+	PostCheck                     CheckHookConfig               `json:"postCheck"`     // Command run after all checks pass; failure warns unless failOnError
+	CustomChecks                  []CustomCheckConfig           `json:"customChecks"`  // Team-defined commands run after the built-in checks
 }
 
 // RedundantCreatedAtCheckConfig configures the Convex schema `createdAt`
@@ -82,6 +96,37 @@ type RedundantCreatedAtCheckConfig struct {
 	ExcludePaths []string `json:"excludePaths"`
 }
 
+// EnvAccessCheckConfig configures the envAccessCheck feature, which keeps
+// environment-variable reads inside a central config module.
+type EnvAccessCheckConfig struct {
+	// AllowedPaths lists path substrings where env access is permitted.
+	// Default: ["src/config/", ".test.", ".spec.", "__tests__/", "__mocks__/"].
+	// Setting it replaces the defaults, so list test paths too if needed.
+	AllowedPaths []string `json:"allowedPaths"`
+	// Accessors lists the expressions treated as env access.
+	// Default: ["process.env", "import.meta.env"].
+	Accessors []string `json:"accessors"`
+}
+
+// FileHeaderCheckConfig configures the required license/copyright header on
+// newly added source files. Only files with git status "A" are checked, so
+// adopting the check never flags existing files.
+type FileHeaderCheckConfig struct {
+	// Header is the literal text new files must start with, comment markers
+	// included (e.g. "// Copyright {{year}} Acme Inc."). {{year}} matches any
+	// four-digit year and is filled with the current year by --fix.
+	Header string `json:"header"`
+	// HeaderFile is a project-relative path to read the header from when
+	// Header is empty (e.g. "scripts/license-header.txt").
+	HeaderFile string `json:"headerFile"`
+	// Extensions scopes the check. Default: [".ts", ".tsx", ".js", ".jsx", ".go"].
+	Extensions []string `json:"extensions"`
+	// ExcludePaths skips files whose path contains any of these substrings.
+	// Generated files (_generated/, .gen., "Code generated" markers, .d.ts)
+	// and config files (dotfiles, *.config.*) are always exempt.
+	ExcludePaths []string `json:"excludePaths"`
+}
+
 // ConvexCheckConfig configures the convexCheck feature: it runs the
 // @milehighideas/oxlint-plugin-convex rules on staged Convex files and blocks
 // on the rules listed in ErrorRules. Thresholds (maxLines/maxFunctions/
@@ -203,14 +248,65 @@ type StubTestCheckConfig struct {
 	ExcludePaths []string `json:"excludePaths"`
 }
 
+// StubSourceCheckConfig configures the stub-source detector: staged source
+// files that have a co-located test but whose implementation is still a
+// placeholder (a thrown "not implemented", or every function body empty).
+// Heuristic, so the check only ever warns.
+type StubSourceCheckConfig struct {
+	// Patterns are regexes matched against each staged source file with
+	// comments stripped. A match marks the file as a stub and the matched
+	// text is reported. Empty uses the default "throw new Error('not
+	// implemented' / 'todo')" pattern. The empty-bodies rule always applies.
+	Patterns []string `json:"patterns"`
+	// AppPaths restricts the check to files whose project-relative path
+	// contains at least one of these substrings. Empty = whole project.
+	AppPaths []string `json:"appPaths"`
+	// ExcludePaths skips files whose project-relative path contains any of
+	// these substrings. Exclusions always win over AppPaths.
+	ExcludePaths []string `json:"excludePaths"`
+}
+
+// MaxFilesCheckConfig configures the staged-file-count guard that catches
+// accidental mass-adds (a stray `git add .`).
+type MaxFilesCheckConfig struct {
+	// MaxFiles is the largest number of staged files a commit may contain.
+	// Zero or negative uses the default of 200. Intentional large commits
+	// bypass the limit with --allow-large-commit or
+	// PRE_COMMIT_ALLOW_LARGE_COMMIT=1.
+	MaxFiles int `json:"maxFiles"`
+}
+
+// ConflictMarkersConfig configures the conflictMarkers check.
+type ConflictMarkersConfig struct {
+	// AllowedPaths skips staged files whose path contains any of these
+	// substrings, for files that legitimately hold marker lines such as docs
+	// about resolving git conflicts.
+	AllowedPaths []string `json:"allowedPaths"`
+}
+
+// nonBlockingChecks are heuristic checks that warn instead of block, whether
+// or not they are listed in warningChecks, unless checkSeverity says otherwise.
+var nonBlockingChecks = []string{"stubSourceCheck"}
+
 // IsWarningCheck returns true if the named check should warn instead of block.
 func (c *Config) IsWarningCheck(name string) bool {
-	for _, w := range c.WarningChecks {
-		if w == name {
-			return true
-		}
+	return c.SeverityOf(name) == severityWarning
+}
+
+// FailureMessage formats a failed check for the end-of-run summary. When
+// checkHelp has an entry for the check, it is appended on its own line so
+// teams can point developers at their own remediation docs.
+func (c *Config) FailureMessage(name string, err error) string {
+	msg := fmt.Sprintf("%s: %v", name, err)
+	if help := c.CheckHelpFor(name); help != "" {
+		msg += "\n     " + glyph.Arrow + " " + help
 	}
-	return false
+	return msg
+}
+
+// CheckHelpFor returns the configured help text for a check, or "".
+func (c *Config) CheckHelpFor(name string) string {
+	return strings.TrimSpace(c.CheckHelp[name])
 }
 
 // Features represents which pre-commit features are enabled
@@ -258,6 +354,7 @@ type Features struct {
 	TestQuality             bool `json:"testQuality"`
 	NativeBuild             bool `json:"nativeBuild"`
 	DataLayerCheck          bool `json:"dataLayerCheck"`
+	EnvAccessCheck          bool `json:"envAccessCheck"`
 	MaestroValidation       bool `json:"maestroValidation"`
 	StubTestCheck           bool `json:"stubTestCheck"`
 	MissingTestsCheck       bool `json:"missingTestsCheck"`
@@ -265,12 +362,28 @@ type Features struct {
 	ConvexCheck             bool `json:"convexCheck"`
 	TiersGen                bool `json:"tiersGen"`
 	LinguiExtract           bool `json:"linguiExtract"`
+	// FileHeaderCheck requires newly added files to start with the header in
+	// fileHeaderCheckConfig. Run with --fix to insert it and re-stage.
+	FileHeaderCheck bool `json:"fileHeaderCheck"`
 	// TestSubstanceCheck runs substance gates against test files for staged
 	// source files: LOC ratio, UI-interaction requirement, branch-proportional
 	// it() count, plus tautological-assertion detection. Catches "minimal
 	// tests" that satisfy the missingTestsCheck file-existence gate but don't
 	// actually exercise the source. Configured via testSubstanceCheckConfig.
 	TestSubstanceCheck bool `json:"testSubstanceCheck"`
+	// StubSourceCheck warns about staged source files that have a test but
+	// whose implementation is a stub (throws "not implemented", or every
+	// function body is empty). Never blocks. Configured via
+	// stubSourceCheckConfig.
+	StubSourceCheck bool `json:"stubSourceCheck"`
+	// MaxFilesCheck blocks commits that stage more files than
+	// maxFilesCheckConfig.maxFiles. Runs as a hard gate before any other
+	// check.
+	MaxFilesCheck bool `json:"maxFilesCheck"`
+	// ConflictMarkers fails commits whose staged text files still contain
+	// <<<<<<< / ======= / >>>>>>> merge-conflict markers at the start of a
+	// line. Configured via conflictMarkersConfig.
+	ConflictMarkers bool `json:"conflictMarkers"`
 	// NextImageCheck verifies every public-relative asset reference resolves to
 	// a real file under the app's public/ dir (next build does not). Static.
 	NextImageCheck bool `json:"nextImageCheck"`
@@ -284,12 +397,45 @@ type Features struct {
 type AppConfig struct {
 	Path            string           `json:"path"`
 	Filter          string           `json:"filter"`
+	PackageManager  string           `json:"packageManager,omitempty"` // Per-app override of the global packageManager
 	TestCommand     string           `json:"testCommand,omitempty"`
 	TestArgs        []string         `json:"testArgs,omitempty"`        // Extra args passed to the test runner after "--" (e.g., ["--watchman=false"])
 	NodeMemoryMB    int              `json:"nodeMemoryMB,omitempty"`    // Memory limit for Node.js (e.g., 8192 for 8GB)
 	TypecheckFilter *TypecheckFilter `json:"typecheckFilter,omitempty"` // Per-app override for typecheck settings
 	SkipLint        bool             `json:"skipLint,omitempty"`        // Skip lint for this app (typecheck still runs)
 	SkipTypecheck   bool             `json:"skipTypecheck,omitempty"`   // Skip typecheck for this app (lint still runs)
+
+	// packages holds the workspace packages a glob Filter expanded to (set by
+	// expandAppFilters); empty for a plain filter.
+	packages []workspacePackage
+}
+
+// packageManagerFor returns the package manager for this app's commands: its
+// own override when set, else the global one, else "pnpm".
+func (a AppConfig) packageManagerFor(global string) string {
+	if a.PackageManager != "" {
+		return a.PackageManager
+	}
+	if global != "" {
+		return global
+	}
+	return "pnpm"
+}
+
+// resolveAppPackageManagers resolves the package manager of each app without
+// an explicit packageManager from the app's own directory, so an app with its
+// own package.json "packageManager" field or lockfile runs with that manager
+// rather than the repo root's. global applies when nothing decides.
+func resolveAppPackageManagers(apps map[string]AppConfig, global string) {
+	for name, app := range apps {
+		if app.PackageManager != "" {
+			continue
+		}
+		if r := pkgmanager.Resolve(app.Path, global); r.Name != "" {
+			app.PackageManager = r.Name
+			apps[name] = app
+		}
+	}
 }
 
 // TypecheckFilter configures which TypeScript errors to filter out
@@ -385,6 +531,19 @@ type TestConfig struct {
 	// Listing a test here is a contract: it MUST pass on a clean isolated
 	// run. The quarantine bypasses gate failures, not real bugs.
 	FlakyTestFiles []string `json:"flakyTestFiles,omitempty"`
+	// Parallelism is how many apps' test suites run at once. Each suite's
+	// output is captured and printed as one block when it finishes. Default:
+	// 1 (one app at a time), since test runners already spread their own
+	// workers across cores.
+	Parallelism int `json:"parallelism,omitempty"`
+}
+
+// parallelism returns the number of app test suites to run at once.
+func (c TestConfig) parallelism() int {
+	if c.Parallelism < 1 {
+		return 1
+	}
+	return c.Parallelism
 }
 
 // AppTestOverride configures test behavior for a specific app
@@ -440,6 +599,10 @@ type ChangelogConfig struct {
 	GlobalDir string `json:"globalDir,omitempty"`
 	// Apps: list of app names that have changelog support (optional, defaults to all apps)
 	Apps []string `json:"apps,omitempty"`
+	// StrictScope: in per-app mode, reject a root fragment whose scope names no
+	// configured app. Off by default, matching changelog-add, which files such
+	// fragments under the root with a warning. Required mode always rejects them.
+	StrictScope bool `json:"strictScope,omitempty"`
 }
 
 // SRPConfig configures Single Responsibility Principle checking
@@ -457,6 +620,18 @@ type SRPConfig struct {
 	// If empty/unset, defaults to ["useState", "useReducer", "useContext"] for
 	// backwards compatibility.
 	ScreenHooks []string `json:"screenHooks"`
+	// AllowedConvexReactImports lists the convex/react names that may be
+	// imported outside the data layer. Defaults to ["Preloaded",
+	// "usePreloadedQuery"] when unset.
+	AllowedConvexReactImports []string `json:"allowedConvexReactImports"`
+	// AllowedDataModelTypes lists the _generated/dataModel names that may be
+	// imported outside the data layer. Defaults to ["Id", "Doc"] when unset.
+	AllowedDataModelTypes []string `json:"allowedDataModelTypes"`
+	// ConvexImportExemptPaths lists path substrings whose files may import
+	// Convex directly. Defaults to ["/data-layer/", "/backend/", "/convex/",
+	// "/scripts/", "/providers/"] when unset. _layout.tsx files are always
+	// exempt.
+	ConvexImportExemptPaths []string `json:"convexImportExemptPaths"`
 	// EnabledRules specifies which SRP rules to run. If empty/unset, all 6
 	// existing rules run (backwards compatible). The "testRequired" rule is
 	// always opt-in — it only runs when explicitly listed here.
@@ -692,6 +867,10 @@ func (c SRPConfig) resolvedTestRequired() []TestRequiredConfig {
 	return result
 }
 
+// configSource supplies run() with the config. The daemon swaps it for a
+// cached copy that reloads when the config's inputs change.
+var configSource = loadConfig
+
 // loadConfig loads configuration from .pre-commit.json (supports JSONC comments)
 func loadConfig() (*Config, error) {
 	configPath := ".pre-commit.json"
@@ -699,17 +878,34 @@ func loadConfig() (*Config, error) {
 	data, err := jsonc.ReadFile(configPath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return defaultConfig(), nil
+			config := defaultConfig()
+			config.PackageManager = pkgmanager.Resolve(".", "").Name
+			return config, nil
 		}
 		return nil, err
 	}
 
+	if err := validateConfigKeys(data); err != nil {
+		return nil, err
+	}
+
 	var config Config
 	if err := json.Unmarshal(data, &config); err != nil {
 		return nil, err
 	}
 
+	// package.json's packageManager field, then the lockfile, take
+	// precedence over the configured packageManager
+	config.PackageManager = pkgmanager.Resolve(".", config.PackageManager).Name
+	resolveAppPackageManagers(config.Apps, config.PackageManager)
 	applyDefaults(&config)
+	expandAppFilters(config.Apps, ".")
+	if err := validateCustomChecks(&config); err != nil {
+		return nil, err
+	}
+	if err := applyCheckSeverity(&config); err != nil {
+		return nil, err
+	}
 
 	return &config, nil
 }
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// currentSchemaVersion is the newest .pre-commit.json schemaVersion this
+// build understands.
+const currentSchemaVersion = 1
+
+// siblingHookKeys are the top-level blocks other hooks read from the same
+// .pre-commit.json. pre-commit ignores them, but they aren't unknown.
+var siblingHookKeys = []string{
+	"blockDestructiveCommandsConfig", // block-destructive-commands
+	"docsTrackerConfig",              // docs-tracker
+	"enforceTestsOnCommitConfig",     // enforce-tests-on-commit
+	"guardUncommittedEditsConfig",    // guard-uncommitted-edits
+	"testFilesConfig",                // validate-test-files
+}
+
+// validateConfigKeys rejects unknown keys at the top level of the config and
+// inside "features", where a typo silently turns a check off. Validation is
+// opt-in: configs without a schemaVersion key load leniently as before.
+func validateConfigKeys(data []byte) error {
+	var top map[string]json.RawMessage
+	if err := json.Unmarshal(data, &top); err != nil {
+		return err
+	}
+	rawVersion, ok := top["schemaVersion"]
+	if !ok {
+		return nil
+	}
+
+	var version int
+	if err := json.Unmarshal(rawVersion, &version); err != nil || version < 1 {
+		return fmt.Errorf("schemaVersion must be a positive integer, got %s", rawVersion)
+	}
+	if version > currentSchemaVersion {
+		return fmt.Errorf("schemaVersion %d is newer than this pre-commit supports (%d); update the pre-commit binary", version, currentSchemaVersion)
+	}
+
+	problems := unknownKeys(top, append(jsonKeys(reflect.TypeOf(Config{})), siblingHookKeys...), "")
+	if rawFeatures, ok := top["features"]; ok {
+		var features map[string]json.RawMessage
+		if err := json.Unmarshal(rawFeatures, &features); err == nil {
+			problems = append(problems, unknownKeys(features, jsonKeys(reflect.TypeOf(Features{})), "features.")...)
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("unknown key(s) in .pre-commit.json:\n  %s", strings.Join(problems, "\n  "))
+}
+
+// unknownKeys returns one problem line per key of obj not in known, sorted,
+// with a "did you mean" suggestion when a known key is close. encoding/json
+// matches keys case-insensitively, so only a case-insensitive miss counts.
+func unknownKeys(obj map[string]json.RawMessage, known []string, prefix string) []string {
+	lower := make(map[string]bool, len(known))
+	for _, k := range known {
+		lower[strings.ToLower(k)] = true
+	}
+
+	var problems []string
+	for key := range obj {
+		if lower[strings.ToLower(key)] {
+			continue
+		}
+		problem := fmt.Sprintf("%q", prefix+key)
+		if s := suggestKey(key, known); s != "" {
+			problem += fmt.Sprintf(" (did you mean %q?)", prefix+s)
+		}
+		problems = append(problems, problem)
+	}
+	sort.Strings(problems)
+	return problems
+}
+
+// jsonKeys returns the JSON field names of struct type t.
+func jsonKeys(t reflect.Type) []string {
+	var keys []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		keys = append(keys, name)
+	}
+	return keys
+}
+
+// suggestKey returns the known key closest to key by edit distance, or ""
+// when none is within a third of key's length (and at least 2 edits).
+func suggestKey(key string, known []string) string {
+	limit := len(key) / 3
+	if limit < 2 {
+		limit = 2
+	}
+	best, bestDist := "", limit+1
+	for _, k := range known {
+		if d := editDistance(strings.ToLower(key), strings.ToLower(k)); d < bestDist {
+			best, bestDist = k, d
+		}
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateConfigKeys(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      string
+		wantErr     bool
+		errContains []string
+	}{
+		{
+			name:   "no schemaVersion loads leniently",
+			config: `{"features": {"frontendStructre": true}, "protectedBranch": ["main"]}`,
+		},
+		{
+			name:   "valid keys with schemaVersion",
+			config: `{"schemaVersion": 1, "features": {"frontendStructure": true}, "protectedBranches": ["main"]}`,
+		},
+		{
+			name:   "keys match case-insensitively like encoding/json",
+			config: `{"schemaVersion": 1, "Features": {"FrontendStructure": true}}`,
+		},
+		{
+			name: "other hooks' blocks alongside pre-commit's",
+			config: `{"schemaVersion": 1, "features": {"lint": true},
+				"blockDestructiveCommandsConfig": {"protectedBranches": ["main"]},
+				"docsTrackerConfig": {}, "enforceTestsOnCommitConfig": {},
+				"guardUncommittedEditsConfig": {}, "testFilesConfig": {}}`,
+		},
+		{
+			name:        "typo in another hook's block",
+			config:      `{"schemaVersion": 1, "docsTrackerConfg": {}}`,
+			wantErr:     true,
+			errContains: []string{`"docsTrackerConfg" (did you mean "docsTrackerConfig"?)`},
+		},
+		{
+			name:        "typo in feature key",
+			config:      `{"schemaVersion": 1, "features": {"frontendStructre": true}}`,
+			wantErr:     true,
+			errContains: []string{`"features.frontendStructre" (did you mean "features.frontendStructure"?)`},
+		},
+		{
+			name:        "typo in top-level key",
+			config:      `{"schemaVersion": 1, "protectedBranch": ["main"]}`,
+			wantErr:     true,
+			errContains: []string{`"protectedBranch" (did you mean "protectedBranches"?)`},
+		},
+		{
+			name:        "unrelated key has no suggestion",
+			config:      `{"schemaVersion": 1, "zzzzzzzz": true}`,
+			wantErr:     true,
+			errContains: []string{`"zzzzzzzz"`},
+		},
+		{
+			name:        "every unknown key reported",
+			config:      `{"schemaVersion": 1, "sharedPath": [], "features": {"lnt": true}}`,
+			wantErr:     true,
+			errContains: []string{`"sharedPath"`, `"features.lnt" (did you mean "features.lint"?)`},
+		},
+		{
+			name:        "newer schemaVersion rejected",
+			config:      `{"schemaVersion": 2}`,
+			wantErr:     true,
+			errContains: []string{"schemaVersion 2 is newer"},
+		},
+		{
+			name:        "non-integer schemaVersion rejected",
+			config:      `{"schemaVersion": "1"}`,
+			wantErr:     true,
+			errContains: []string{"positive integer"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateConfigKeys([]byte(tt.config))
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			for _, want := range tt.errContains {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error = %q, want it to contain %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadConfig_UnknownKeyWithSchemaVersion(t *testing.T) {
+	tempDir := t.TempDir()
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(origDir) }()
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatal(err)
+	}
+
+	config := `{
+  // typo: the check would silently never run
+  "schemaVersion": 1,
+  "features": { "frontendStructre": true }
+}`
+	if err := os.WriteFile(filepath.Join(tempDir, ".pre-commit.json"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = loadConfig()
+	if err == nil {
+		t.Fatal("expected loadConfig to reject the unknown key")
+	}
+	if !strings.Contains(err.Error(), `did you mean "features.frontendStructure"?`) {
+		t.Errorf("error = %q, want a suggestion", err)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"lint", "", 4},
+		{"lint", "lint", 0},
+		{"lnt", "lint", 1},
+		{"frontendStructre", "frontendStructure", 1},
+		{"kitten", "sitting", 3},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+// TestDocumentedConfigExampleValidates keeps the canonical example in
+// docs/pre-commit.md loadable: it declares a schemaVersion, so every key in
+// it must be one validateConfigKeys knows.
+func TestDocumentedConfigExampleValidates(t *testing.T) {
+	doc, err := os.ReadFile(filepath.Join("..", "..", "docs", "pre-commit.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, section, ok := strings.Cut(string(doc), "### Configuration File Structure")
+	if !ok {
+		t.Fatal("docs/pre-commit.md has no Configuration File Structure section")
+	}
+	_, block, ok := strings.Cut(section, "```json\n")
+	if !ok {
+		t.Fatal("Configuration File Structure section has no json example")
+	}
+	example, _, _ := strings.Cut(block, "```")
+	if !strings.Contains(example, `"schemaVersion"`) {
+		t.Fatal("documented example no longer declares schemaVersion")
+	}
+	if err := validateConfigKeys([]byte(example)); err != nil {
+		t.Errorf("documented config example fails validation: %v", err)
+	}
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -566,3 +568,82 @@ func TestStripJSONComments(t *testing.T) {
 		})
 	}
 }
+
+func TestAppConfig_packageManagerFor(t *testing.T) {
+	tests := []struct {
+		name   string
+		app    AppConfig
+		global string
+		want   string
+	}{
+		{"app override wins", AppConfig{PackageManager: "bun"}, "pnpm", "bun"},
+		{"falls back to global", AppConfig{}, "yarn", "yarn"},
+		{"defaults to pnpm", AppConfig{}, "", "pnpm"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.app.packageManagerFor(tt.global); got != tt.want {
+				t.Errorf("packageManagerFor(%q) = %q, want %q", tt.global, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveAppPackageManagers(t *testing.T) {
+	root := t.TempDir()
+	for _, f := range []string{".git/HEAD", "pnpm-lock.yaml", "apps/mobile/bun.lock", "apps/web/package.json", "apps/legacy/yarn.lock"} {
+		path := filepath.Join(root, f)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("{}"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	apps := map[string]AppConfig{
+		"mobile": {Path: filepath.Join(root, "apps/mobile")},
+		"web":    {Path: filepath.Join(root, "apps/web")},
+		"legacy": {Path: filepath.Join(root, "apps/legacy"), PackageManager: "npm"},
+	}
+	resolveAppPackageManagers(apps, "pnpm")
+
+	want := map[string]string{
+		"mobile": "bun",  // its own lockfile
+		"web":    "pnpm", // falls through to the root lockfile
+		"legacy": "npm",  // explicit override kept
+	}
+	for name, pm := range want {
+		if got := apps[name].PackageManager; got != pm {
+			t.Errorf("%s: PackageManager = %q, want %q", name, got, pm)
+		}
+	}
+}
+
+func TestFailureMessageAppendsCheckHelp(t *testing.T) {
+	var config Config
+	raw := `{
+		"checkHelp": {
+			"srp": "See https://wiki.example.com/eng/srp for how to split components",
+			"mockCheck": "   "
+		}
+	}`
+	if err := json.Unmarshal([]byte(raw), &config); err != nil {
+		t.Fatal(err)
+	}
+
+	checkErr := errors.New("3 file(s) violate SRP")
+
+	got := config.FailureMessage("srp", checkErr)
+	want := "srp: 3 file(s) violate SRP\n     → See https://wiki.example.com/eng/srp for how to split components"
+	if got != want {
+		t.Errorf("FailureMessage(srp) = %q, want %q", got, want)
+	}
+
+	// No entry, or a blank one, leaves the message unchanged.
+	for _, name := range []string{"consoleCheck", "mockCheck"} {
+		if got := config.FailureMessage(name, checkErr); got != name+": 3 file(s) violate SRP" {
+			t.Errorf("FailureMessage(%s) = %q, want no help suffix", name, got)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/milehighideas/claude-hooks/internal/glyph"
+)
+
+// conflictEdgeRe matches the opening and closing lines git writes around a
+// conflict: exactly seven < or > at the start of a line, then a space (and
+// the side's label) or the end of the line.
+var conflictEdgeRe = regexp.MustCompile(`^(<{7}|>{7})(?:[ \t]|$)`)
+
+// conflictSeparatorRe matches the ======= separator and the ||||||| base
+// marker of diff3-style conflicts. A line of seven = is also a Markdown
+// heading underline, so separators only count in a file that also has an
+// opening or closing marker.
+var conflictSeparatorRe = regexp.MustCompile(`^(={7}|\|{7})(?:[ \t]|$)`)
+
+// binarySniffLen is how much of a file is searched for a NUL byte to decide
+// it is binary, the same heuristic git uses.
+const binarySniffLen = 8000
+
+// ConflictMarkersChecker finds merge-conflict markers left in staged files.
+type ConflictMarkersChecker struct {
+	gitShowFunc func(file string) ([]byte, error)
+	allowed     []string
+}
+
+// ConflictMarkerViolation is one conflict-marker line in a staged file.
+type ConflictMarkerViolation struct {
+	File   string
+	Line   int
+	Marker string
+}
+
+// NewConflictMarkersChecker creates a ConflictMarkersChecker for cfg with
+// default git show behavior.
+func NewConflictMarkersChecker(cfg ConflictMarkersConfig) *ConflictMarkersChecker {
+	return &ConflictMarkersChecker{
+		gitShowFunc: defaultGitShow,
+		allowed:     cfg.AllowedPaths,
+	}
+}
+
+// isAllowedFile checks if a file matches any allowed path (substring match).
+func (c *ConflictMarkersChecker) isAllowedFile(file string) bool {
+	file = filepath.ToSlash(file)
+	for _, pattern := range c.allowed {
+		if pattern != "" && strings.Contains(file, filepath.ToSlash(pattern)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isBinary reports whether content looks binary: a NUL byte near the start.
+func isBinary(content []byte) bool {
+	if len(content) > binarySniffLen {
+		content = content[:binarySniffLen]
+	}
+	return bytes.IndexByte(content, 0) >= 0
+}
+
+// findConflictMarkers returns every conflict-marker line in content with its
+// 1-based line number. Separators are dropped when the file has no opening
+// or closing marker.
+func findConflictMarkers(content []byte) []ConflictMarkerViolation {
+	var found []ConflictMarkerViolation
+	hasEdge := false
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if m := conflictEdgeRe.FindStringSubmatch(line); m != nil {
+			hasEdge = true
+			found = append(found, ConflictMarkerViolation{Line: lineNum, Marker: m[1]})
+		} else if m := conflictSeparatorRe.FindStringSubmatch(line); m != nil {
+			found = append(found, ConflictMarkerViolation{Line: lineNum, Marker: m[1]})
+		}
+	}
+	if !hasEdge {
+		return nil
+	}
+	return found
+}
+
+// check scans the staged content of files, skipping allowed and binary
+// files and files that can't be read.
+func (c *ConflictMarkersChecker) check(files []string) []ConflictMarkerViolation {
+	var violations []ConflictMarkerViolation
+	for _, file := range files {
+		if c.isAllowedFile(file) {
+			continue
+		}
+		content, err := c.gitShowFunc(file)
+		if err != nil || isBinary(content) {
+			continue
+		}
+		for _, v := range findConflictMarkers(content) {
+			v.File = file
+			violations = append(violations, v)
+		}
+	}
+	return violations
+}
+
+// runConflictMarkersCheck is the pre-commit entry point for conflictMarkers.
+func runConflictMarkersCheck(cfg ConflictMarkersConfig, stagedFiles []string) error {
+	violations := NewConflictMarkersChecker(cfg).check(stagedFiles)
+
+	var body strings.Builder
+	var files []string
+	for _, v := range violations {
+		fmt.Fprintf(&body, "  %s:%d: %s\n", v.File, v.Line, v.Marker)
+		if len(files) == 0 || files[len(files)-1] != v.File {
+			files = append(files, v.File)
+		}
+	}
+	if reportDir != "" {
+		_ = writeRunReport("conflict-markers", "Conflict markers", body.String(), len(violations) > 0)
+	}
+
+	if len(violations) == 0 {
+		if compactMode() {
+			printStatus("Conflict markers", true, "")
+		} else {
+			fmt.Println(glyph.Pass + " No merge-conflict markers in staged files")
+			fmt.Println()
+		}
+		return nil
+	}
+
+	if compactMode() {
+		printStatus("Conflict markers", false, fmt.Sprintf("%d file(s)", len(files)))
+		printReportHint("conflict-markers/")
+	} else {
+		fmt.Printf(glyph.Fail+" Merge-conflict markers in %d staged file(s):\n\n", len(files))
+		fmt.Print(body.String())
+		fmt.Println()
+		fmt.Println(glyph.Hint + " Resolve the conflict, or add the file to conflictMarkersConfig.allowedPaths if the markers are intended")
+		fmt.Println()
+	}
+	return fmt.Errorf("merge-conflict markers in %d file(s): %s", len(files), strings.Join(files, ", "))
+}
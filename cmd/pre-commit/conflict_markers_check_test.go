@@ -0,0 +1,122 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFindConflictMarkers(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []ConflictMarkerViolation
+	}{
+		{
+			name: "real conflict",
+			content: "const a = 1;\n" +
+				"<<<<<<< HEAD\n" +
+				"const b = 2;\n" +
+				"=======\n" +
+				"const b = 3;\n" +
+				">>>>>>> feature/login\n",
+			want: []ConflictMarkerViolation{
+				{Line: 2, Marker: "<<<<<<<"},
+				{Line: 4, Marker: "======="},
+				{Line: 6, Marker: ">>>>>>>"},
+			},
+		},
+		{
+			name:    "diff3 base marker and CRLF",
+			content: "<<<<<<< ours\r\nx\r\n||||||| base\r\ny\r\n=======\r\nz\r\n>>>>>>> theirs\r\n",
+			want: []ConflictMarkerViolation{
+				{Line: 1, Marker: "<<<<<<<"},
+				{Line: 3, Marker: "|||||||"},
+				{Line: 5, Marker: "======="},
+				{Line: 7, Marker: ">>>>>>>"},
+			},
+		},
+		{
+			name:    "half-resolved conflict keeps the leftover marker",
+			content: "const b = 3;\n>>>>>>> main\n",
+			want:    []ConflictMarkerViolation{{Line: 2, Marker: ">>>>>>>"}},
+		},
+		{
+			name:    "markdown heading underline alone",
+			content: "Release\n=======\n\nNotes.\n",
+		},
+		{
+			name:    "indented or longer runs",
+			content: "  <<<<<<< HEAD\n<<<<<<<< eight\n// >>>>>>> quoted\n",
+		},
+		{
+			name:    "shift operators",
+			content: "const x = a <<<<<<< b;\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := findConflictMarkers([]byte(tt.content)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("findConflictMarkers() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConflictMarkersCheck(t *testing.T) {
+	staged := map[string]string{
+		"apps/web/src/login.ts": "export const a = 1;\n<<<<<<< HEAD\nconst b = 2;\n=======\nconst b = 3;\n>>>>>>> feature\n",
+		"apps/web/src/clean.ts": "export const a = 1;\n",
+		"docs/git/resolving-conflicts.md": "Git marks the conflict like this:\n\n" +
+			"<<<<<<< HEAD\nyour change\n=======\ntheir change\n>>>>>>> branch\n",
+		"assets/logo.png": "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\n>>>>>>> not text\n",
+	}
+	checker := NewConflictMarkersChecker(ConflictMarkersConfig{AllowedPaths: []string{"docs/git/"}})
+	checker.gitShowFunc = func(file string) ([]byte, error) {
+		content, ok := staged[file]
+		if !ok {
+			return nil, errors.New("not staged")
+		}
+		return []byte(content), nil
+	}
+
+	got := checker.check([]string{
+		"apps/web/src/clean.ts",
+		"apps/web/src/login.ts",
+		"docs/git/resolving-conflicts.md",
+		"assets/logo.png",
+		"apps/web/src/deleted.ts",
+	})
+	want := []ConflictMarkerViolation{
+		{File: "apps/web/src/login.ts", Line: 2, Marker: "<<<<<<<"},
+		{File: "apps/web/src/login.ts", Line: 4, Marker: "======="},
+		{File: "apps/web/src/login.ts", Line: 6, Marker: ">>>>>>>"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("check() = %+v, want %+v", got, want)
+	}
+
+	// Without the allowlist the doc is flagged too.
+	checker.allowed = nil
+	var files []string
+	for _, v := range checker.check([]string{"docs/git/resolving-conflicts.md"}) {
+		files = append(files, v.File)
+	}
+	if len(files) != 3 || !strings.HasSuffix(files[0], "resolving-conflicts.md") {
+		t.Errorf("unallowlisted doc violations = %v, want its 3 marker lines", files)
+	}
+}
+
+func TestIsBinary(t *testing.T) {
+	if isBinary([]byte("plain text\n")) {
+		t.Error("text reported as binary")
+	}
+	if !isBinary([]byte("GIF89a\x00\x01")) {
+		t.Error("NUL byte not reported as binary")
+	}
+	late := strings.Repeat("a", binarySniffLen) + "\x00"
+	if isBinary([]byte(late)) {
+		t.Error("NUL past the sniff window reported as binary")
+	}
+}
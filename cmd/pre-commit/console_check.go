@@ -9,6 +9,8 @@ import (
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/milehighideas/claude-hooks/internal/glyph"
 )
 
 // ConsoleChecker checks for console.* statements in staged files
@@ -37,7 +39,7 @@ func defaultGitShow(file string) ([]byte, error) {
 // Check checks for console.* statements in the given files
 // Returns an error if any violations are found
 func (c *ConsoleChecker) Check(appName string, files []string, allowedFiles []string) error {
-	fmt.Printf("🔍 Checking for console.* statements in %s app...\n", appName)
+	fmt.Printf(glyph.Search+" Checking for console.* statements in %s app...\n", appName)
 
 	var violations []string
 
@@ -61,18 +63,18 @@ func (c *ConsoleChecker) Check(appName string, files []string, allowedFiles []st
 		// Check for console.* statements
 		if c.hasConsoleStatements(output) {
 			violations = append(violations, file)
-			fmt.Printf("  ❌ %s\n", file)
+			fmt.Printf("  "+glyph.Fail+" %s\n", file)
 		}
 	}
 
 	if len(violations) > 0 {
-		fmt.Printf("\n❌ Found console.* statements in %d file(s)\n", len(violations))
-		fmt.Println("💡 Use a proper logger instead")
+		fmt.Printf("\n"+glyph.Fail+" Found console.* statements in %d file(s)\n", len(violations))
+		fmt.Println(glyph.Hint + " Use a proper logger instead")
 		fmt.Println()
 		return fmt.Errorf("console statements found")
 	}
 
-	fmt.Println("✅ No console.* statements found")
+	fmt.Println(glyph.Pass + " No console.* statements found")
 	return nil
 }
 
@@ -107,6 +109,11 @@ type ConsoleViolation struct {
 	File    string
 }
 
+// violation converts a console violation for the SARIF report.
+func (v ConsoleViolation) violation() Violation {
+	return Violation{RuleID: "consoleCheck", Level: "error", File: v.File, Message: "console.* statement; use a proper logger instead"}
+}
+
 // runConsoleCheck orchestrates console statement checking for all affected apps
 func runConsoleCheck(appFiles map[string][]string, allowedFiles []string) error {
 	var allViolations []ConsoleViolation
@@ -120,6 +127,9 @@ func runConsoleCheck(appFiles map[string][]string, allowedFiles []string) error
 			}
 			violations := checkConsoleStatementsWithViolations(appName, files, allowedFiles)
 			allViolations = append(allViolations, violations...)
+			for _, v := range violations {
+				recordViolations(v.violation())
+			}
 			if !compactMode() {
 				fmt.Println()
 			}
@@ -170,7 +180,7 @@ func checkConsoleStatementsWithViolations(appName string, files []string, allowe
 	checker := NewConsoleChecker()
 
 	if !compactMode() {
-		fmt.Printf("🔍 Checking for console.* statements in %s app...\n", appName)
+		fmt.Printf(glyph.Search+" Checking for console.* statements in %s app...\n", appName)
 	}
 
 	var violations []ConsoleViolation
@@ -191,17 +201,17 @@ func checkConsoleStatementsWithViolations(appName string, files []string, allowe
 		if checker.hasConsoleStatements(output) {
 			violations = append(violations, ConsoleViolation{AppName: appName, File: file})
 			if !compactMode() {
-				fmt.Printf("  ❌ %s\n", file)
+				fmt.Printf("  "+glyph.Fail+" %s\n", file)
 			}
 		}
 	}
 
 	if !compactMode() {
 		if len(violations) > 0 {
-			fmt.Printf("\n❌ Found console.* statements in %d file(s)\n", len(violations))
-			fmt.Println("💡 Use a proper logger instead")
+			fmt.Printf("\n"+glyph.Fail+" Found console.* statements in %d file(s)\n", len(violations))
+			fmt.Println(glyph.Hint + " Use a proper logger instead")
 		} else {
-			fmt.Println("✅ No console.* statements found")
+			fmt.Println(glyph.Pass + " No console.* statements found")
 		}
 	}
 
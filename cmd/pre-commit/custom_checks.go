@@ -0,0 +1,175 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Ways a custom check receives the staged file list.
+const (
+	filesViaStdin = "stdin"
+	filesViaArgs  = "args"
+	filesViaNone  = "none"
+)
+
+// CustomCheckConfig configures a team-defined check run after the built-in
+// checks. Its exit code decides pass or fail, and failures are reported like
+// any other check's: blocking, or a warning when Warning is set.
+type CustomCheckConfig struct {
+	// Name identifies the check in status lines, the failure summary,
+	// checkSeverity and checkHelp. Required and unique.
+	Name string `json:"name"`
+	// Command is the executable to run (e.g., "./scripts/check-i18n.sh").
+	Command string `json:"command"`
+	// Args are passed to Command before any staged files.
+	Args []string `json:"args"`
+	// Warning reports failures without blocking the commit. checkSeverity
+	// still takes precedence.
+	Warning bool `json:"warning"`
+	// FilesVia is how the staged files are passed: "stdin" (default), one
+	// path per line; "args", appended after Args; or "none".
+	FilesVia string `json:"filesVia"`
+	// Dir is the working directory, relative to the repo root. Default: the
+	// repo root.
+	Dir string `json:"dir"`
+	// Env is merged over the top-level env for this check only.
+	Env map[string]string `json:"env"`
+}
+
+// customCheckRunner is the indirection point for tests. Production code
+// invokes runCustomCheckCommand; tests overwrite this to record invocations.
+var customCheckRunner = runCustomCheckCommand
+
+// runCustomCheckCommand runs a custom check command in dir with env merged
+// over the current environment, feeding it stdin and returning its combined
+// output.
+func runCustomCheckCommand(dir string, env map[string]string, stdin io.Reader, name string, args ...string) ([]byte, error) {
+	cmd := exec.Command(name, args...)
+	if errors.Is(cmd.Err, exec.ErrDot) {
+		cmd.Err = nil
+	}
+	cmd.Dir = dir
+	cmd.Stdin = stdin
+	if len(env) > 0 {
+		cmd.Env = os.Environ()
+		for k, v := range env {
+			cmd.Env = append(cmd.Env, k+"="+v)
+		}
+	}
+	return cmd.CombinedOutput()
+}
+
+// customCheckNames returns the names of the configured custom checks.
+func (c *Config) customCheckNames() []string {
+	names := make([]string, 0, len(c.CustomChecks))
+	for _, check := range c.CustomChecks {
+		names = append(names, check.Name)
+	}
+	return names
+}
+
+// customCheck returns the custom check called name, if there is one.
+func (c *Config) customCheck(name string) (CustomCheckConfig, bool) {
+	for _, check := range c.CustomChecks {
+		if check.Name == name {
+			return check, true
+		}
+	}
+	return CustomCheckConfig{}, false
+}
+
+// validateCustomChecks rejects custom checks without a name or command,
+// with a name that is taken, or with an unknown filesVia.
+func validateCustomChecks(config *Config) error {
+	builtIn := severityCheckNames()
+	seen := make(map[string]bool, len(config.CustomChecks))
+	var problems []string
+	for i, check := range config.CustomChecks {
+		switch {
+		case check.Name == "":
+			problems = append(problems, fmt.Sprintf("customChecks[%d]: name is required", i))
+		case slices.Contains(builtIn, check.Name):
+			problems = append(problems, fmt.Sprintf("customChecks[%d]: name %q is a built-in check", i, check.Name))
+		case seen[check.Name]:
+			problems = append(problems, fmt.Sprintf("customChecks[%d]: duplicate name %q", i, check.Name))
+		}
+		seen[check.Name] = true
+		if check.Command == "" {
+			problems = append(problems, fmt.Sprintf("customChecks[%d]: command is required", i))
+		}
+		switch check.FilesVia {
+		case "", filesViaStdin, filesViaArgs, filesViaNone:
+		default:
+			problems = append(problems, fmt.Sprintf("customChecks[%d]: unknown filesVia %q (want stdin, args or none)", i, check.FilesVia))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid .pre-commit.json:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+// runCustomChecks runs each custom check in order on files, passing its
+// result to collect. Checks whose severity is "off" are skipped.
+func runCustomChecks(config *Config, files []string, collect func(string, error)) {
+	root := hookRootDir()
+	for _, check := range config.CustomChecks {
+		severity := config.SeverityOf(check.Name)
+		if severity == severityOff {
+			continue
+		}
+		printStart(check.Name)
+		err := runCustomCheck(config, check, root, files)
+		switch {
+		case err == nil:
+			printStatus(check.Name, true, "")
+		case severity == severityWarning:
+			printWarningStatus(check.Name, "failed")
+		default:
+			printStatus(check.Name, false, "")
+		}
+		collect(check.Name, err)
+	}
+}
+
+// runCustomCheck runs one custom check from root, returning an error with
+// the command's output when it exits non-zero.
+func runCustomCheck(config *Config, check CustomCheckConfig, root string, files []string) error {
+	dir := root
+	if check.Dir != "" {
+		dir = filepath.Join(root, check.Dir)
+	}
+
+	env := make(map[string]string, len(config.Env)+len(check.Env))
+	for k, v := range config.Env {
+		env[k] = v
+	}
+	for k, v := range check.Env {
+		env[k] = v
+	}
+
+	args := slices.Clone(check.Args)
+	var stdin io.Reader
+	switch check.FilesVia {
+	case filesViaArgs:
+		args = append(args, files...)
+	case filesViaNone:
+	default:
+		stdin = strings.NewReader(strings.Join(files, "\n") + "\n")
+	}
+
+	output, err := customCheckRunner(dir, env, stdin, check.Command, args...)
+	if err == nil {
+		return nil
+	}
+	if out := strings.TrimSpace(string(output)); out != "" {
+		return fmt.Errorf("%s failed: %w\n%s", check.Command, err, out)
+	}
+	return fmt.Errorf("%s failed: %w", check.Command, err)
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type customCheckCall struct {
+	dir   string
+	env   map[string]string
+	stdin string
+	name  string
+	args  []string
+}
+
+// withMockCustomCheckRunner swaps customCheckRunner for the duration of the
+// test, recording every invocation. fail lists the commands that exit
+// non-zero.
+func withMockCustomCheckRunner(t *testing.T, fail ...string) *[]customCheckCall {
+	t.Helper()
+	var calls []customCheckCall
+	orig := customCheckRunner
+	customCheckRunner = func(dir string, env map[string]string, stdin io.Reader, name string, args ...string) ([]byte, error) {
+		call := customCheckCall{dir: dir, env: env, name: name, args: args}
+		if stdin != nil {
+			data, _ := io.ReadAll(stdin)
+			call.stdin = string(data)
+		}
+		calls = append(calls, call)
+		for _, f := range fail {
+			if f == name {
+				return []byte("missing license header: a.ts\n"), errors.New("exit status 1")
+			}
+		}
+		return nil, nil
+	}
+	t.Cleanup(func() { customCheckRunner = orig })
+	return &calls
+}
+
+func TestRunCustomChecksRouting(t *testing.T) {
+	tests := []struct {
+		name         string
+		check        CustomCheckConfig
+		severity     map[string]string
+		fail         bool
+		wantErrors   int
+		wantWarnings int
+	}{
+		{"passing check does not block", CustomCheckConfig{Name: "license", Command: "./license.sh"}, nil, false, 0, 0},
+		{"failing check blocks", CustomCheckConfig{Name: "license", Command: "./license.sh"}, nil, true, 1, 0},
+		{"failing warning check warns", CustomCheckConfig{Name: "license", Command: "./license.sh", Warning: true}, nil, true, 0, 1},
+		{"checkSeverity overrides warning", CustomCheckConfig{Name: "license", Command: "./license.sh", Warning: true}, map[string]string{"license": "error"}, true, 1, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fail []string
+			if tt.fail {
+				fail = []string{tt.check.Command}
+			}
+			withMockCustomCheckRunner(t, fail...)
+			config := &Config{CustomChecks: []CustomCheckConfig{tt.check}, CheckSeverity: tt.severity}
+			results := &checkResults{config: config}
+
+			runCustomChecks(config, []string{"a.ts"}, results.collect)
+
+			if len(results.errors) != tt.wantErrors || len(results.warnings) != tt.wantWarnings {
+				t.Fatalf("errors = %v, warnings = %v; want %d errors, %d warnings", results.errors, results.warnings, tt.wantErrors, tt.wantWarnings)
+			}
+			for _, msg := range append(results.errors, results.warnings...) {
+				if !strings.HasPrefix(msg, "license: ") || !strings.Contains(msg, "missing license header") {
+					t.Errorf("failure message %q should name the check and include its output", msg)
+				}
+			}
+		})
+	}
+}
+
+func TestRunCustomChecksOffIsSkipped(t *testing.T) {
+	calls := withMockCustomCheckRunner(t, "./license.sh")
+	config := &Config{
+		CustomChecks:  []CustomCheckConfig{{Name: "license", Command: "./license.sh"}},
+		CheckSeverity: map[string]string{"license": "off"},
+	}
+	results := &checkResults{config: config}
+	runCustomChecks(config, []string{"a.ts"}, results.collect)
+	if len(*calls) != 0 || len(results.errors) != 0 {
+		t.Errorf("off check ran: calls = %+v, errors = %v", *calls, results.errors)
+	}
+}
+
+func TestRunCustomChecksInvocation(t *testing.T) {
+	calls := withMockCustomCheckRunner(t)
+	config := &Config{
+		Env: map[string]string{"SHARED": "1", "MODE": "global"},
+		CustomChecks: []CustomCheckConfig{
+			{Name: "viaStdin", Command: "./stdin.sh"},
+			{Name: "viaArgs", Command: "./args.sh", Args: []string{"--strict"}, FilesVia: "args"},
+			{Name: "noFiles", Command: "./none.sh", FilesVia: "none", Dir: "packages/web", Env: map[string]string{"MODE": "web"}},
+		},
+	}
+	results := &checkResults{config: config}
+	runCustomChecks(config, []string{"a.ts", "b.ts"}, results.collect)
+
+	if len(*calls) != 3 {
+		t.Fatalf("expected 3 invocations, got %d", len(*calls))
+	}
+	root := hookRootDir()
+	stdinCall, argsCall, noneCall := (*calls)[0], (*calls)[1], (*calls)[2]
+
+	if stdinCall.stdin != "a.ts\nb.ts\n" || len(stdinCall.args) != 0 {
+		t.Errorf("stdin check got stdin %q, args %v", stdinCall.stdin, stdinCall.args)
+	}
+	if strings.Join(argsCall.args, " ") != "--strict a.ts b.ts" || argsCall.stdin != "" {
+		t.Errorf("args check got args %v, stdin %q", argsCall.args, argsCall.stdin)
+	}
+	if len(noneCall.args) != 0 || noneCall.stdin != "" {
+		t.Errorf("none check got args %v, stdin %q", noneCall.args, noneCall.stdin)
+	}
+
+	if stdinCall.dir != root {
+		t.Errorf("default dir = %q, want repo root %q", stdinCall.dir, root)
+	}
+	if want := filepath.Join(root, "packages/web"); noneCall.dir != want {
+		t.Errorf("dir = %q, want %q", noneCall.dir, want)
+	}
+	if stdinCall.env["MODE"] != "global" || noneCall.env["MODE"] != "web" || noneCall.env["SHARED"] != "1" {
+		t.Errorf("env not merged: default %v, override %v", stdinCall.env, noneCall.env)
+	}
+}
+
+func TestRunCustomCheckCommandExitCode(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := runCustomCheckCommand(dir, nil, strings.NewReader("a.ts\n"), "sh", "-c", "grep -q a.ts"); err != nil {
+		t.Errorf("passing command returned %v", err)
+	}
+	out, err := runCustomCheckCommand(dir, map[string]string{"MSG": "bad header"}, nil, "sh", "-c", `echo "$MSG"; exit 3`)
+	if err == nil {
+		t.Fatal("failing command returned nil error")
+	}
+	if strings.TrimSpace(string(out)) != "bad header" {
+		t.Errorf("output = %q, want env-expanded message", out)
+	}
+}
+
+func TestValidateCustomChecks(t *testing.T) {
+	tests := []struct {
+		name    string
+		checks  []CustomCheckConfig
+		wantErr string
+	}{
+		{"valid", []CustomCheckConfig{{Name: "license", Command: "./license.sh", FilesVia: "args"}}, ""},
+		{"missing name", []CustomCheckConfig{{Command: "./license.sh"}}, "name is required"},
+		{"missing command", []CustomCheckConfig{{Name: "license"}}, "command is required"},
+		{"built-in name", []CustomCheckConfig{{Name: "srp", Command: "./srp.sh"}}, `"srp" is a built-in check`},
+		{"duplicate name", []CustomCheckConfig{{Name: "a", Command: "x"}, {Name: "a", Command: "y"}}, `duplicate name "a"`},
+		{"bad filesVia", []CustomCheckConfig{{Name: "a", Command: "x", FilesVia: "env"}}, `unknown filesVia "env"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCustomChecks(&Config{CustomChecks: tt.checks})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validateCustomChecks() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validateCustomChecks() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckSeverityAcceptsCustomChecks(t *testing.T) {
+	config := &Config{
+		CustomChecks:  []CustomCheckConfig{{Name: "license", Command: "./license.sh"}},
+		CheckSeverity: map[string]string{"license": "warning"},
+	}
+	if err := applyCheckSeverity(config); err != nil {
+		t.Fatalf("applyCheckSeverity() error = %v", err)
+	}
+	if !config.IsWarningCheck("license") {
+		t.Error("license should be a warning check")
+	}
+}
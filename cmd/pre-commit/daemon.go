@@ -0,0 +1,460 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/milehighideas/claude-hooks/internal/pkgmanager"
+)
+
+// The daemon protocol is newline-delimited JSON over a unix socket. The
+// client sends one daemonRequest; the daemon streams daemonMessages holding
+// the checks' output as it's printed, and ends with one whose Done is set.
+
+// daemonRequest asks the daemon to check the given staged files. Dir is the
+// client's working directory, which staged paths are relative to. Env and
+// Flags are the client's, and apply to this request only.
+type daemonRequest struct {
+	Dir   string            `json:"dir"`
+	Files []string          `json:"files"`
+	Env   map[string]string `json:"env,omitempty"`
+	Flags daemonFlags       `json:"flags"`
+}
+
+// daemonFlags are the command-line flags that shape one run of the checks.
+type daemonFlags struct {
+	Check        string `json:"check,omitempty"`
+	Only         string `json:"only,omitempty"`
+	Except       string `json:"except,omitempty"`
+	ReportDir    string `json:"reportDir,omitempty"`
+	Sarif        string `json:"sarif,omitempty"`
+	Fix          bool   `json:"fix,omitempty"`
+	AllowLarge   bool   `json:"allowLarge,omitempty"`
+	StrictStaged bool   `json:"strictStaged,omitempty"`
+	VerifyStaged bool   `json:"verifyStaged,omitempty"`
+	NoCache      bool   `json:"noCache,omitempty"`
+	NoLock       bool   `json:"noLock,omitempty"`
+	Verbose      bool   `json:"verbose,omitempty"`
+}
+
+// currentFlags returns the flags this process was run with.
+func currentFlags() daemonFlags {
+	return daemonFlags{
+		Check: checkName, Only: onlyChecks, Except: exceptChecks,
+		ReportDir: reportDir, Sarif: sarifPath,
+		Fix: fixFlag, AllowLarge: allowLarge, StrictStaged: strictStaged, VerifyStaged: verifyStaged,
+		NoCache: noCache, NoLock: noLock, Verbose: verboseFlag,
+	}
+}
+
+// set makes f the flags of the current run.
+func (f daemonFlags) set() {
+	checkName, onlyChecks, exceptChecks = f.Check, f.Only, f.Except
+	reportDir, sarifPath = f.ReportDir, f.Sarif
+	fixFlag, allowLarge, strictStaged, verifyStaged = f.Fix, f.AllowLarge, f.StrictStaged, f.VerifyStaged
+	noCache, noLock, verboseFlag = f.NoCache, f.NoLock, f.Verbose
+}
+
+// daemonEnvVars are the environment variables the checks read. The client
+// sends the ones it has set, since the daemon's environment is whatever it
+// was started with.
+var daemonEnvVars = []string{"SKIP_CHANGELOG_CHECK", skipBranchProtectionEnv, allowLargeCommitEnv}
+
+// clientEnv returns the daemonEnvVars set in this process.
+func clientEnv() map[string]string {
+	env := map[string]string{}
+	for _, name := range daemonEnvVars {
+		if value, ok := os.LookupEnv(name); ok {
+			env[name] = value
+		}
+	}
+	return env
+}
+
+// setEnv sets each of daemonEnvVars to its value in env, unsetting those env
+// lacks, and returns a func that restores the previous values.
+func setEnv(env map[string]string) (restore func()) {
+	prev := clientEnv()
+	apply := func(env map[string]string) {
+		for _, name := range daemonEnvVars {
+			if value, ok := env[name]; ok {
+				_ = os.Setenv(name, value)
+			} else {
+				_ = os.Unsetenv(name)
+			}
+		}
+	}
+	apply(env)
+	return func() { apply(prev) }
+}
+
+// requestMu serialises runs for client requests: the flags, environment,
+// stdout and the staged-file and config sources they swap are process-wide.
+var requestMu sync.Mutex
+
+// withRequestState runs fn with the request's flags and environment in
+// place of the daemon's, one request at a time, and restores the daemon's
+// afterwards.
+func withRequestState(req daemonRequest, fn func() error) error {
+	requestMu.Lock()
+	defer requestMu.Unlock()
+
+	own := currentFlags()
+	req.Flags.set()
+	defer own.set()
+	defer setEnv(req.Env)()
+
+	return fn()
+}
+
+// daemonMessage carries either a chunk of output or, when Done, the result.
+type daemonMessage struct {
+	Output   string `json:"output,omitempty"`
+	Done     bool   `json:"done,omitempty"`
+	ExitCode int    `json:"exitCode,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// errNoDaemon is returned by runClient when nothing listens on the socket.
+var errNoDaemon = errors.New("no pre-commit daemon listening")
+
+// configInputs are the repo-root files loadConfig reads, directly or through
+// package manager and workspace detection. configCache adds each app's
+// package manager inputs, and reloads the config when any of them changes.
+var configInputs = []string{
+	".pre-commit.json",
+	"package.json",
+	"pnpm-workspace.yaml",
+	"pnpm-lock.yaml",
+	"bun.lock",
+	"bun.lockb",
+	"yarn.lock",
+	"package-lock.json",
+}
+
+// daemonSocketPath returns the socket the daemon for the current git repo
+// listens on. Like the lock file, it lives in the temp dir, named by a hash
+// of the repo path.
+func daemonSocketPath() string {
+	hash := sha256.Sum256([]byte(getRepoToplevel()))
+	return filepath.Join(os.TempDir(), fmt.Sprintf("pre-commit-%x.sock", hash[:8]))
+}
+
+// fileStamp identifies one version of a file; the zero value means missing.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// stampFiles stamps each of names, taking relative ones from dir.
+func stampFiles(dir string, names []string) []fileStamp {
+	stamps := make([]fileStamp, len(names))
+	for i, name := range names {
+		if !filepath.IsAbs(name) {
+			name = filepath.Join(dir, name)
+		}
+		if info, err := os.Stat(name); err == nil {
+			stamps[i] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+		}
+	}
+	return stamps
+}
+
+// sameStamps reports whether two stampFiles results match.
+func sameStamps(a, b []fileStamp) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].modTime.Equal(b[i].modTime) || a[i].size != b[i].size {
+			return false
+		}
+	}
+	return true
+}
+
+// configCache keeps the daemon's config loaded between requests.
+type configCache struct {
+	dir    string
+	load   func() (*Config, error)
+	inputs []string // configInputs plus the loaded apps' (see loadedInputs)
+	stamps []fileStamp
+	config *Config
+}
+
+// loadedInputs returns configInputs plus the package.json and lockfiles
+// resolveAppPackageManagers read for each of config's apps.
+func loadedInputs(config *Config) []string {
+	inputs := append([]string(nil), configInputs...)
+	seen := map[string]bool{}
+	for _, app := range config.Apps {
+		for _, file := range pkgmanager.Inputs(app.Path) {
+			if !seen[file] {
+				seen[file] = true
+				inputs = append(inputs, file)
+			}
+		}
+	}
+	slices.Sort(inputs[len(configInputs):])
+	return inputs
+}
+
+// get returns the cached config, reloading it first when any of its inputs
+// changed since the last load. A failed load isn't cached, so fixing the
+// config fixes the next commit. Each call returns a copy, so one run's
+// --only/--except filtering doesn't leak into the next.
+func (c *configCache) get() (*Config, error) {
+	if c.inputs == nil {
+		c.inputs = configInputs
+	}
+	stamps := stampFiles(c.dir, c.inputs)
+	if c.config == nil || !sameStamps(stamps, c.stamps) {
+		config, err := c.load()
+		if err != nil {
+			return nil, err
+		}
+		// The apps may have changed, and with them the files to watch
+		if inputs := loadedInputs(config); !slices.Equal(inputs, c.inputs) {
+			c.inputs, stamps = inputs, stampFiles(c.dir, inputs)
+		}
+		c.config, c.stamps = config, stamps
+	}
+	config := *c.config
+	return &config, nil
+}
+
+// daemonServer answers client requests, each on its own goroutine; check
+// must serialise the runs itself (checkStagedFiles does, through
+// withRequestState).
+type daemonServer struct {
+	dir   string
+	check func(req daemonRequest, out io.Writer) error
+}
+
+// serve accepts connections until ln is closed.
+func (d *daemonServer) serve(ln net.Listener) error {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go d.handle(conn)
+	}
+}
+
+// handle answers one request.
+func (d *daemonServer) handle(conn net.Conn) {
+	defer conn.Close()
+	enc := json.NewEncoder(conn)
+
+	var req daemonRequest
+	if err := json.NewDecoder(conn).Decode(&req); err != nil {
+		_ = enc.Encode(daemonMessage{Done: true, ExitCode: 1, Error: fmt.Sprintf("bad request: %v", err)})
+		return
+	}
+	if !sameDir(req.Dir, d.dir) {
+		_ = enc.Encode(daemonMessage{Done: true, ExitCode: 1,
+			Error: fmt.Sprintf("daemon serves %s; run the client from there, not %s", d.dir, req.Dir)})
+		return
+	}
+
+	result := daemonMessage{Done: true}
+	if err := d.check(req, daemonOutput{enc}); err != nil {
+		result.ExitCode = 1
+		result.Error = err.Error()
+	}
+	_ = enc.Encode(result)
+}
+
+// daemonOutput streams writes to the client as output messages.
+type daemonOutput struct {
+	enc *json.Encoder
+}
+
+func (o daemonOutput) Write(p []byte) (int, error) {
+	if err := o.enc.Encode(daemonMessage{Output: string(p)}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// sameDir reports whether a and b are the same directory, resolving
+// symlinks such as macOS's /var -> /private/var.
+func sameDir(a, b string) bool {
+	if ra, err := filepath.EvalSymlinks(a); err == nil {
+		a = ra
+	}
+	if rb, err := filepath.EvalSymlinks(b); err == nil {
+		b = rb
+	}
+	return filepath.Clean(a) == filepath.Clean(b)
+}
+
+// redirectOutput points os.Stdout and os.Stderr, and so every fmt.Print and
+// child process of the checks, at out until restore is called.
+func redirectOutput(out io.Writer) (restore func(), err error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, stderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = w, w
+	done := make(chan struct{})
+	go func() {
+		_, _ = io.Copy(out, r)
+		close(done)
+	}()
+	return func() {
+		os.Stdout, os.Stderr = stdout, stderr
+		_ = w.Close()
+		<-done
+		_ = r.Close()
+	}, nil
+}
+
+// checkStagedFiles runs the checks for one client request: its files stand
+// in for `git diff --cached`, its flags and environment replace the
+// daemon's, the config comes from cache, and everything the checks print
+// goes to out. Report directories are created per request.
+func checkStagedFiles(cache *configCache, req daemonRequest, out io.Writer) error {
+	return withRequestState(req, func() error {
+		return runStagedChecks(cache, req.Files, out)
+	})
+}
+
+// runStagedChecks is checkStagedFiles once the request's state is in place.
+func runStagedChecks(cache *configCache, files []string, out io.Writer) error {
+	if !noLock {
+		lockFile, err := acquireLock()
+		if err != nil {
+			return errors.New("pre-commit already running — commit rejected")
+		}
+		defer releaseLock(lockFile)
+	}
+
+	restore, err := redirectOutput(out)
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	baseReportDir := reportDir
+	defer func() { reportDir = baseReportDir }()
+	if reportDir != "" {
+		reportDir = setupReportDir(reportDir)
+	}
+	violationLog.Lock()
+	violationLog.items = nil
+	violationLog.Unlock()
+
+	stagedFilesSource = func() ([]string, error) { return files, nil }
+	configSource = cache.get
+	defer func() { stagedFilesSource, configSource = getStagedFiles, loadConfig }()
+
+	return runOnce()
+}
+
+// listenDaemon listens on socketPath, replacing a stale socket left by a
+// daemon that died, but refusing to start next to one that still answers.
+func listenDaemon(socketPath string) (net.Listener, error) {
+	if conn, err := net.Dial("unix", socketPath); err == nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("a pre-commit daemon is already listening on %s", socketPath)
+	}
+	if err := os.Remove(socketPath); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	return net.Listen("unix", socketPath)
+}
+
+// runDaemon serves check requests for the repo in the working directory
+// until interrupted.
+func runDaemon(socketPath string) error {
+	if standalone {
+		return errors.New("--daemon cannot be combined with --standalone")
+	}
+	dir, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	ln, err := listenDaemon(socketPath)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(socketPath)
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-stop
+		_ = ln.Close()
+	}()
+
+	cache := &configCache{dir: dir, load: loadConfig}
+	d := &daemonServer{dir: dir, check: func(req daemonRequest, out io.Writer) error {
+		return checkStagedFiles(cache, req, out)
+	}}
+	fmt.Printf("pre-commit daemon serving %s on %s\n", dir, socketPath)
+	return d.serve(ln)
+}
+
+// runClient sends the staged files, with this process's flags and check
+// environment, to the daemon on socketPath, copies its output to stdout and
+// its error to stderr, and returns its exit code. It returns errNoDaemon
+// when nothing is listening.
+func runClient(socketPath string, stdout, stderr io.Writer) (int, error) {
+	conn, err := net.Dial("unix", socketPath)
+	if err != nil {
+		return 0, fmt.Errorf("%w on %s: %v", errNoDaemon, socketPath, err)
+	}
+	defer conn.Close()
+
+	files, err := getStagedFiles()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get staged files: %w", err)
+	}
+	dir, err := os.Getwd()
+	if err != nil {
+		return 0, err
+	}
+	req := daemonRequest{Dir: dir, Files: files, Env: clientEnv(), Flags: currentFlags()}
+	return daemonExchange(conn, req, stdout, stderr)
+}
+
+// daemonExchange sends req over conn, copies the streamed output to stdout
+// and returns the daemon's exit code, printing its error to stderr.
+func daemonExchange(conn net.Conn, req daemonRequest, stdout, stderr io.Writer) (int, error) {
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return 0, err
+	}
+	dec := json.NewDecoder(conn)
+	for {
+		var msg daemonMessage
+		if err := dec.Decode(&msg); err != nil {
+			return 0, fmt.Errorf("daemon closed the connection: %w", err)
+		}
+		if msg.Output != "" {
+			_, _ = io.WriteString(stdout, msg.Output)
+		}
+		if msg.Done {
+			if msg.Error != "" {
+				fmt.Fprintf(stderr, "Error: %s\n", msg.Error)
+			}
+			return msg.ExitCode, nil
+		}
+	}
+}
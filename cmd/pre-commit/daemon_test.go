@@ -0,0 +1,306 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// startTestDaemon serves check from a socket in a temp dir and returns the
+// socket path and the directory the daemon serves.
+func startTestDaemon(t *testing.T, check func(req daemonRequest, out io.Writer) error) (socket, dir string) {
+	t.Helper()
+	dir = t.TempDir()
+	socket = filepath.Join(dir, "d.sock")
+	ln, err := listenDaemon(socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := &daemonServer{dir: dir, check: check}
+	go func() { _ = d.serve(ln) }()
+	t.Cleanup(func() { _ = ln.Close() })
+	return socket, dir
+}
+
+func exchange(t *testing.T, socket string, req daemonRequest) (code int, stdout, stderr string) {
+	t.Helper()
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	var out, errOut bytes.Buffer
+	code, err = daemonExchange(conn, req, &out, &errOut)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return code, out.String(), errOut.String()
+}
+
+func TestDaemonRoundTrip(t *testing.T) {
+	socket, dir := startTestDaemon(t, func(req daemonRequest, out io.Writer) error {
+		for _, f := range req.Files {
+			fmt.Fprintf(out, "checked %s\n", f)
+		}
+		for _, f := range req.Files {
+			if strings.Contains(f, "bad") {
+				return fmt.Errorf("lint failed in %s", f)
+			}
+		}
+		return nil
+	})
+
+	tests := []struct {
+		name       string
+		files      []string
+		wantCode   int
+		wantStdout string
+		wantStderr string
+	}{
+		{"passing commit", []string{"apps/web/a.ts", "apps/web/b.ts"}, 0, "checked apps/web/a.ts\nchecked apps/web/b.ts\n", ""},
+		{"failing commit", []string{"apps/web/bad.ts"}, 1, "checked apps/web/bad.ts\n", "Error: lint failed in apps/web/bad.ts\n"},
+		{"nothing staged", nil, 0, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, stdout, stderr := exchange(t, socket, daemonRequest{Dir: dir, Files: tt.files})
+			if code != tt.wantCode || stdout != tt.wantStdout || stderr != tt.wantStderr {
+				t.Errorf("got (%d, %q, %q), want (%d, %q, %q)", code, stdout, stderr, tt.wantCode, tt.wantStdout, tt.wantStderr)
+			}
+		})
+	}
+}
+
+func TestDaemonRejectsOtherDirectory(t *testing.T) {
+	called := false
+	socket, _ := startTestDaemon(t, func(daemonRequest, io.Writer) error {
+		called = true
+		return nil
+	})
+
+	code, _, stderr := exchange(t, socket, daemonRequest{Dir: t.TempDir(), Files: []string{"a.ts"}})
+	if code != 1 || !strings.Contains(stderr, "daemon serves") {
+		t.Errorf("got (%d, %q), want exit 1 naming the served directory", code, stderr)
+	}
+	if called {
+		t.Error("checks ran for a request from another directory")
+	}
+}
+
+func TestDaemonStreamsCheckOutput(t *testing.T) {
+	socket, dir := startTestDaemon(t, func(_ daemonRequest, out io.Writer) error {
+		restore, err := redirectOutput(out)
+		if err != nil {
+			return err
+		}
+		defer restore()
+		fmt.Println("✅ Lint")
+		fmt.Fprintln(os.Stderr, "⚠️  SRP compliance")
+		return nil
+	})
+
+	code, stdout, _ := exchange(t, socket, daemonRequest{Dir: dir})
+	if code != 0 || stdout != "✅ Lint\n⚠️  SRP compliance\n" {
+		t.Errorf("got (%d, %q), want the checks' stdout and stderr", code, stdout)
+	}
+}
+
+func TestListenDaemon(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "d.sock")
+
+	// A stale socket file from a daemon that died is replaced.
+	if err := os.WriteFile(socket, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	ln, err := listenDaemon(socket)
+	if err != nil {
+		t.Fatalf("stale socket: %v", err)
+	}
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.Close()
+		}
+	}()
+
+	// A live daemon is left alone.
+	if _, err := listenDaemon(socket); err == nil || !strings.Contains(err.Error(), "already listening") {
+		t.Errorf("second daemon: err = %v, want already listening", err)
+	}
+}
+
+func TestRunClientWithoutDaemon(t *testing.T) {
+	_, err := runClient(filepath.Join(t.TempDir(), "none.sock"), io.Discard, io.Discard)
+	if !errors.Is(err, errNoDaemon) {
+		t.Errorf("err = %v, want errNoDaemon", err)
+	}
+}
+
+func TestConfigCacheReloadsOnChange(t *testing.T) {
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, ".pre-commit.json")
+	stamp := time.Now().Add(-time.Hour).Truncate(time.Second)
+	write := func(content string, mtime time.Time) {
+		t.Helper()
+		if err := os.WriteFile(configFile, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(configFile, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	loads := 0
+	cache := &configCache{dir: dir, load: func() (*Config, error) {
+		loads++
+		data, err := os.ReadFile(configFile)
+		if err != nil {
+			return nil, err
+		}
+		if strings.Contains(string(data), "broken") {
+			return nil, errors.New("invalid config")
+		}
+		return &Config{ReportDir: strings.TrimSpace(string(data))}, nil
+	}}
+
+	write("reports-a", stamp)
+	first, err := cache.get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	first.Features.Lint = true // a run's --only filtering
+	second, _ := cache.get()
+	if loads != 1 || second.ReportDir != "reports-a" {
+		t.Fatalf("unchanged config: loads = %d, ReportDir = %q; want 1 load of reports-a", loads, second.ReportDir)
+	}
+	if second.Features.Lint {
+		t.Error("a change to one run's config leaked into the next")
+	}
+
+	write("reports-b", stamp.Add(time.Second))
+	if got, _ := cache.get(); loads != 2 || got.ReportDir != "reports-b" {
+		t.Errorf("edited config: loads = %d, ReportDir = %q; want a reload of reports-b", loads, got.ReportDir)
+	}
+
+	// Another input (package manager detection) also invalidates.
+	if err := os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"packageManager":"bun@1.1.0"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _ = cache.get(); loads != 3 {
+		t.Errorf("new package.json: loads = %d, want 3", loads)
+	}
+
+	// A broken edit fails the run and isn't cached; fixing it reloads.
+	write("broken", stamp.Add(2*time.Second))
+	if _, err := cache.get(); err == nil {
+		t.Error("broken config: want an error")
+	}
+	write("reports-c", stamp.Add(3*time.Second))
+	if got, err := cache.get(); err != nil || got.ReportDir != "reports-c" {
+		t.Errorf("fixed config = %v, %v; want reports-c", got, err)
+	}
+}
+
+func TestConfigCacheWatchesAppPackageManager(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	app := filepath.Join(dir, "apps", "web")
+	if err := os.MkdirAll(app, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	loads := 0
+	cache := &configCache{dir: dir, load: func() (*Config, error) {
+		loads++
+		return &Config{Apps: map[string]AppConfig{"web": {Path: app}}}, nil
+	}}
+	if _, err := cache.get(); err != nil {
+		t.Fatal(err)
+	}
+	if _, _ = cache.get(); loads != 1 {
+		t.Fatalf("unchanged inputs: loads = %d, want 1", loads)
+	}
+
+	// The app's own lockfile changes which package manager it resolves to.
+	if err := os.WriteFile(filepath.Join(app, "bun.lock"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _ = cache.get(); loads != 2 {
+		t.Errorf("new app lockfile: loads = %d, want 2", loads)
+	}
+}
+
+func TestWithRequestStateAppliesClientFlagsAndEnv(t *testing.T) {
+	t.Setenv("SKIP_CHANGELOG_CHECK", "")
+	os.Unsetenv("SKIP_CHANGELOG_CHECK")
+	t.Setenv(skipBranchProtectionEnv, "daemon")
+	origFlags := currentFlags()
+	t.Cleanup(origFlags.set)
+	daemonOwn := daemonFlags{Only: "lint", ReportDir: "daemon-reports"}
+	daemonOwn.set()
+
+	req := daemonRequest{
+		Env:   map[string]string{"SKIP_CHANGELOG_CHECK": "1"},
+		Flags: daemonFlags{Except: "tests", Fix: true, AllowLarge: true},
+	}
+	err := withRequestState(req, func() error {
+		if got := currentFlags(); got != req.Flags {
+			t.Errorf("flags during request = %+v, want the client's %+v", got, req.Flags)
+		}
+		if got := os.Getenv("SKIP_CHANGELOG_CHECK"); got != "1" {
+			t.Errorf("SKIP_CHANGELOG_CHECK = %q, want the client's 1", got)
+		}
+		if _, ok := os.LookupEnv(skipBranchProtectionEnv); ok {
+			t.Errorf("%s still set from the daemon's environment", skipBranchProtectionEnv)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := currentFlags(); got != daemonOwn {
+		t.Errorf("flags after request = %+v, want the daemon's %+v", got, daemonOwn)
+	}
+	if _, ok := os.LookupEnv("SKIP_CHANGELOG_CHECK"); ok {
+		t.Error("SKIP_CHANGELOG_CHECK leaked into the daemon's environment")
+	}
+	if got := os.Getenv(skipBranchProtectionEnv); got != "daemon" {
+		t.Errorf("%s = %q after request, want the daemon's restored", skipBranchProtectionEnv, got)
+	}
+}
+
+func TestWithRequestStateSerialisesRequests(t *testing.T) {
+	origFlags := currentFlags()
+	t.Cleanup(origFlags.set)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(check string) {
+			defer wg.Done()
+			_ = withRequestState(daemonRequest{Flags: daemonFlags{Check: check}}, func() error {
+				time.Sleep(time.Millisecond)
+				if checkName != check {
+					t.Errorf("request for %s ran with --check %s", check, checkName)
+				}
+				return nil
+			})
+		}(fmt.Sprintf("check%d", i))
+	}
+	wg.Wait()
+}
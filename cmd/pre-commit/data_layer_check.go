@@ -7,6 +7,8 @@ import (
 	"regexp"
 	"strings"
 	"time"
+
+	"github.com/milehighideas/claude-hooks/internal/glyph"
 )
 
 // dataLayerPatterns matches forbidden direct Convex imports in frontend code.
@@ -32,7 +34,7 @@ func NewDataLayerChecker() *DataLayerChecker {
 // Check checks for direct Convex imports in the given files.
 // Returns an error if any violations are found.
 func (c *DataLayerChecker) Check(appName string, files []string, allowedFiles []string) error {
-	fmt.Printf("🔍 Checking for direct Convex imports in %s app...\n", appName)
+	fmt.Printf(glyph.Search+" Checking for direct Convex imports in %s app...\n", appName)
 
 	var violations []string
 
@@ -51,18 +53,18 @@ func (c *DataLayerChecker) Check(appName string, files []string, allowedFiles []
 
 		if c.hasDataLayerViolations(output) {
 			violations = append(violations, file)
-			fmt.Printf("  ❌ %s\n", file)
+			fmt.Printf("  "+glyph.Fail+" %s\n", file)
 		}
 	}
 
 	if len(violations) > 0 {
-		fmt.Printf("\n❌ Found direct Convex imports in %d file(s)\n", len(violations))
-		fmt.Println("💡 Use hooks from packages/data-layer instead")
+		fmt.Printf("\n"+glyph.Fail+" Found direct Convex imports in %d file(s)\n", len(violations))
+		fmt.Println(glyph.Hint + " Use hooks from packages/data-layer instead")
 		fmt.Println()
 		return fmt.Errorf("direct Convex imports found")
 	}
 
-	fmt.Println("✅ No direct Convex imports found")
+	fmt.Println(glyph.Pass + " No direct Convex imports found")
 	return nil
 }
 
@@ -101,6 +103,16 @@ type DataLayerViolation struct {
 	Patterns []string
 }
 
+// violation converts a data layer violation for the SARIF report.
+func (v DataLayerViolation) violation() Violation {
+	return Violation{
+		RuleID:  "dataLayerCheck",
+		Level:   "error",
+		File:    v.File,
+		Message: fmt.Sprintf("direct Convex import (matched %s); use hooks from packages/data-layer instead", strings.Join(v.Patterns, ", ")),
+	}
+}
+
 // runDataLayerCheck orchestrates data layer checking for all affected apps
 func runDataLayerCheck(appFiles map[string][]string, allowedFiles []string) error {
 	var allViolations []DataLayerViolation
@@ -114,6 +126,9 @@ func runDataLayerCheck(appFiles map[string][]string, allowedFiles []string) erro
 			}
 			violations := checkDataLayerWithViolations(appName, files, allowedFiles)
 			allViolations = append(allViolations, violations...)
+			for _, v := range violations {
+				recordViolations(v.violation())
+			}
 			if !compactMode() {
 				fmt.Println()
 			}
@@ -151,7 +166,7 @@ func checkDataLayerWithViolations(appName string, files []string, allowedFiles [
 	checker := NewDataLayerChecker()
 
 	if !compactMode() {
-		fmt.Printf("🔍 Checking for direct Convex imports in %s app...\n", appName)
+		fmt.Printf(glyph.Search+" Checking for direct Convex imports in %s app...\n", appName)
 	}
 
 	var violations []DataLayerViolation
@@ -179,17 +194,17 @@ func checkDataLayerWithViolations(appName string, files []string, allowedFiles [
 			}
 			violations = append(violations, DataLayerViolation{AppName: appName, File: file, Patterns: matched})
 			if !compactMode() {
-				fmt.Printf("  ❌ %s\n", file)
+				fmt.Printf("  "+glyph.Fail+" %s\n", file)
 			}
 		}
 	}
 
 	if !compactMode() {
 		if len(violations) > 0 {
-			fmt.Printf("\n❌ Found direct Convex imports in %d file(s)\n", len(violations))
-			fmt.Println("💡 Use hooks from packages/data-layer instead")
+			fmt.Printf("\n"+glyph.Fail+" Found direct Convex imports in %d file(s)\n", len(violations))
+			fmt.Println(glyph.Hint + " Use hooks from packages/data-layer instead")
 		} else {
-			fmt.Println("✅ No direct Convex imports found")
+			fmt.Println(glyph.Pass + " No direct Convex imports found")
 		}
 	}
 
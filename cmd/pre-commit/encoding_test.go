@@ -0,0 +1,28 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/milehighideas/claude-hooks/internal/glyph"
+)
+
+// TestSourceLiteralsAreValidUTF8 guards the emitted emoji: the bytes printed
+// are the bytes in the source, so every .go file here must be valid UTF-8
+// and free of double-encoded sequences.
+func TestSourceLiteralsAreValidUTF8(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := glyph.Validate(data); err != nil {
+			t.Errorf("%s: %v", f, err)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/milehighideas/claude-hooks/internal/glyph"
+)
+
+// defaultEnvAccessors are the environment accessors flagged when
+// envAccessCheckConfig.accessors is unset.
+var defaultEnvAccessors = []string{"process.env", "import.meta.env"}
+
+// defaultEnvAccessAllowed are the paths where env access is permitted when
+// envAccessCheckConfig.allowedPaths is unset: the config module and tests.
+var defaultEnvAccessAllowed = []string{"src/config/", ".test.", ".spec.", "__tests__/", "__mocks__/"}
+
+// EnvAccessChecker checks for direct environment-variable access outside the
+// config module
+type EnvAccessChecker struct {
+	gitShowFunc func(file string) ([]byte, error)
+	allowed     []string
+	patterns    []*regexp.Regexp
+}
+
+// EnvAccessViolation is a single env access on one line of a staged file
+type EnvAccessViolation struct {
+	AppName string
+	File    string
+	Line    int
+	Text    string
+}
+
+// NewEnvAccessChecker creates an EnvAccessChecker for cfg with default git
+// show behavior
+func NewEnvAccessChecker(cfg EnvAccessCheckConfig) *EnvAccessChecker {
+	allowed := cfg.AllowedPaths
+	if len(allowed) == 0 {
+		allowed = defaultEnvAccessAllowed
+	}
+	accessors := cfg.Accessors
+	if len(accessors) == 0 {
+		accessors = defaultEnvAccessors
+	}
+	return &EnvAccessChecker{
+		gitShowFunc: defaultGitShow,
+		allowed:     allowed,
+		patterns:    envAccessPatterns(accessors),
+	}
+}
+
+// envAccessPatterns compiles each accessor into a regexp that matches it as
+// a whole expression, so "process.env" does not match "myprocess.env",
+// "globals.process.env" or "process.environment". A spread ("...process.env")
+// still counts.
+func envAccessPatterns(accessors []string) []*regexp.Regexp {
+	patterns := make([]*regexp.Regexp, 0, len(accessors))
+	for _, a := range accessors {
+		if a = strings.TrimSpace(a); a == "" {
+			continue
+		}
+		patterns = append(patterns, regexp.MustCompile(`(?:^|[^\w$.]|\.\.\.)`+regexp.QuoteMeta(a)+`(?:[^\w$]|$)`))
+	}
+	return patterns
+}
+
+// isAllowedFile checks if a file matches any allowed path (substring match)
+func (c *EnvAccessChecker) isAllowedFile(file string) bool {
+	for _, pattern := range c.allowed {
+		if pattern != "" && strings.Contains(file, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// isCheckableFile returns true for TypeScript source files
+func (c *EnvAccessChecker) isCheckableFile(file string) bool {
+	if strings.HasSuffix(file, ".d.ts") {
+		return false
+	}
+	return strings.HasSuffix(file, ".ts") || strings.HasSuffix(file, ".tsx")
+}
+
+// findEnvAccess returns the 1-based line number and trimmed text of every
+// line in content that uses a configured accessor. Comment lines are skipped.
+func (c *EnvAccessChecker) findEnvAccess(content []byte) []EnvAccessViolation {
+	var found []EnvAccessViolation
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "//") || strings.HasPrefix(line, "*") || strings.HasPrefix(line, "/*") {
+			continue
+		}
+		for _, pattern := range c.patterns {
+			if pattern.MatchString(line) {
+				found = append(found, EnvAccessViolation{Line: lineNum, Text: line})
+				break
+			}
+		}
+	}
+	return found
+}
+
+// runEnvAccessCheck orchestrates env access checking for all affected apps
+func runEnvAccessCheck(appFiles map[string][]string, cfg EnvAccessCheckConfig) error {
+	checker := NewEnvAccessChecker(cfg)
+	var allViolations []EnvAccessViolation
+
+	for appName, files := range appFiles {
+		if len(files) > 0 {
+			if !compactMode() {
+				fmt.Println("================================")
+				fmt.Println("  ENV ACCESS CHECK")
+				fmt.Println("================================")
+			}
+			allViolations = append(allViolations, checkEnvAccessWithViolations(checker, appName, files)...)
+			if !compactMode() {
+				fmt.Println()
+			}
+		}
+	}
+
+	// Write report if reportDir is set
+	if reportDir != "" && len(allViolations) > 0 {
+		if err := writeEnvAccessCheckReport(allViolations, reportDir); err != nil {
+			fmt.Printf("   Warning: failed to write env access check report: %v\n", err)
+		}
+	} else if reportDir != "" {
+		// Always-write: leave a passing fullreport.txt on a clean run.
+		_ = writeRunReport("env-access-check", "Env access check", "", false)
+	}
+
+	if compactMode() {
+		if len(allViolations) > 0 {
+			printStatus("Env access check", false, fmt.Sprintf("%d usages", len(allViolations)))
+			printReportHint("env-access-check/")
+			return fmt.Errorf("direct env access violations found")
+		}
+		printStatus("Env access check", true, "")
+		return nil
+	}
+
+	if len(allViolations) > 0 {
+		return fmt.Errorf("direct env access violations found")
+	}
+	return nil
+}
+
+// checkEnvAccessWithViolations returns one violation per offending line
+func checkEnvAccessWithViolations(checker *EnvAccessChecker, appName string, files []string) []EnvAccessViolation {
+	if !compactMode() {
+		fmt.Printf(glyph.Search+" Checking for direct env access in %s app...\n", appName)
+	}
+
+	var violations []EnvAccessViolation
+
+	for _, file := range files {
+		if checker.isAllowedFile(file) {
+			continue
+		}
+		if !checker.isCheckableFile(file) {
+			continue
+		}
+
+		output, err := checker.gitShowFunc(file)
+		if err != nil {
+			continue
+		}
+
+		for _, v := range checker.findEnvAccess(output) {
+			v.AppName = appName
+			v.File = file
+			violations = append(violations, v)
+			if !compactMode() {
+				fmt.Printf("  "+glyph.Fail+" %s:%d: %s\n", file, v.Line, v.Text)
+			}
+		}
+	}
+
+	if !compactMode() {
+		if len(violations) > 0 {
+			fmt.Printf("\n"+glyph.Fail+" Found %d direct env access(es)\n", len(violations))
+			fmt.Println(glyph.Hint + " Read environment variables through the config module instead")
+		} else {
+			fmt.Println(glyph.Pass + " No direct env access found")
+		}
+	}
+
+	return violations
+}
+
+// writeEnvAccessCheckReport writes env access findings to per-app report files
+func writeEnvAccessCheckReport(violations []EnvAccessViolation, baseDir string) error {
+	envDir := filepath.Join(baseDir, "env-access-check")
+	if err := os.MkdirAll(envDir, 0755); err != nil {
+		return err
+	}
+
+	// Group by app
+	byApp := make(map[string][]EnvAccessViolation)
+	for _, v := range violations {
+		byApp[v.AppName] = append(byApp[v.AppName], v)
+	}
+
+	for app, appViolations := range byApp {
+		var lineList strings.Builder
+		for _, v := range appViolations {
+			fmt.Fprintf(&lineList, "  %s:%d: %s\n", v.File, v.Line, v.Text)
+		}
+
+		var sb strings.Builder
+		sb.WriteString(strings.Repeat("=", 80) + "\n")
+		fmt.Fprintf(&sb, "ENV ACCESS VIOLATIONS - %s\n", strings.ToUpper(app))
+		fmt.Fprintf(&sb, "Generated: %s\n", time.Now().Format("2006-01-02 15:04:05"))
+		sb.WriteString(strings.Repeat("=", 80) + "\n\n")
+
+		fmt.Fprintf(&sb, "Total violations: %d\n\n", len(appViolations))
+
+		sb.WriteString(strings.Repeat("-", 40) + "\n")
+		sb.WriteString("DIRECT ENV ACCESS OUTSIDE THE CONFIG MODULE\n")
+		sb.WriteString(strings.Repeat("-", 40) + "\n\n")
+		sb.WriteString(lineList.String())
+
+		findings := findingsDoc("ENV ACCESS CHECK", app, len(appViolations), lineList.String())
+		if err := writeDualReport(baseDir, "env-access-check", app, findings, sb.String()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestEnvAccessChecker(t *testing.T) {
+	contents := map[string]string{
+		"apps/web/src/api/client.ts":  "import { config } from '../config';\nconst url = process.env.API_URL;\n",
+		"apps/web/src/vite-env.ts":    "export const mode = import.meta.env.MODE;\n",
+		"apps/web/src/config/env.ts":  "export const apiUrl = process.env.API_URL;\n",
+		"apps/web/src/client.test.ts": "process.env.API_URL = 'http://localhost';\n",
+		"apps/web/src/clean.tsx":      "import { config } from './config';\nexport const A = () => config.apiUrl;\n",
+	}
+	gitShow := func(file string) ([]byte, error) {
+		content, ok := contents[file]
+		if !ok {
+			return nil, errors.New("not found")
+		}
+		return []byte(content), nil
+	}
+
+	tests := []struct {
+		name  string
+		cfg   EnvAccessCheckConfig
+		files []string
+		want  []EnvAccessViolation
+	}{
+		{
+			name:  "disallowed process.env reports file and line",
+			files: []string{"apps/web/src/api/client.ts"},
+			want: []EnvAccessViolation{
+				{AppName: "web", File: "apps/web/src/api/client.ts", Line: 2, Text: "const url = process.env.API_URL;"},
+			},
+		},
+		{
+			name:  "import.meta.env is flagged by default",
+			files: []string{"apps/web/src/vite-env.ts"},
+			want: []EnvAccessViolation{
+				{AppName: "web", File: "apps/web/src/vite-env.ts", Line: 1, Text: "export const mode = import.meta.env.MODE;"},
+			},
+		},
+		{
+			name:  "config module and tests are allowed by default",
+			files: []string{"apps/web/src/config/env.ts", "apps/web/src/client.test.ts", "apps/web/src/clean.tsx"},
+		},
+		{
+			name:  "configured allowed paths replace the defaults",
+			cfg:   EnvAccessCheckConfig{AllowedPaths: []string{"src/api/"}},
+			files: []string{"apps/web/src/api/client.ts", "apps/web/src/config/env.ts"},
+			want: []EnvAccessViolation{
+				{AppName: "web", File: "apps/web/src/config/env.ts", Line: 1, Text: "export const apiUrl = process.env.API_URL;"},
+			},
+		},
+		{
+			name:  "configured accessors narrow what is flagged",
+			cfg:   EnvAccessCheckConfig{Accessors: []string{"process.env"}},
+			files: []string{"apps/web/src/vite-env.ts"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewEnvAccessChecker(tt.cfg)
+			checker.gitShowFunc = gitShow
+			got := checkEnvAccessWithViolations(checker, "web", tt.files)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("violations = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindEnvAccess(t *testing.T) {
+	checker := NewEnvAccessChecker(EnvAccessCheckConfig{})
+	tests := []struct {
+		line string
+		want bool
+	}{
+		{"const url = process.env.API_URL;", true},
+		{"const key = process.env['API_KEY'];", true},
+		{"const { API_URL } = process.env;", true},
+		{"const env = { ...process.env, DEBUG: '1' };", true},
+		{"if (import.meta.env.DEV) {}", true},
+		{"// process.env.API_URL is read in config", false},
+		{" * Falls back to process.env when unset.", false},
+		{"const x = myprocess.env;", false},
+		{"const x = globals.process.env;", false},
+		{"const x = process.environment;", false},
+		{"const x = config.apiUrl;", false},
+	}
+	for _, tt := range tests {
+		got := len(checker.findEnvAccess([]byte(tt.line))) > 0
+		if got != tt.want {
+			t.Errorf("findEnvAccess(%q) = %v, want %v", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestEnvAccessIsCheckableFile(t *testing.T) {
+	checker := NewEnvAccessChecker(EnvAccessCheckConfig{})
+	for file, want := range map[string]bool{
+		"src/a.ts":       true,
+		"src/a.tsx":      true,
+		"src/env.d.ts":   false,
+		"src/a.js":       false,
+		"src/styles.css": false,
+	} {
+		if got := checker.isCheckableFile(file); got != want {
+			t.Errorf("isCheckableFile(%q) = %v, want %v", file, got, want)
+		}
+	}
+}
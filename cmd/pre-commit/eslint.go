@@ -7,8 +7,11 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
+
+	"github.com/milehighideas/claude-hooks/internal/glyph"
 )
 
 // lintError represents a parsed lint error (works for both ESLint and Oxlint)
@@ -22,6 +25,12 @@ type lintError struct {
 	fullText string
 }
 
+// violation converts a lint error for the SARIF report.
+func (e lintError) violation() Violation {
+	line, _ := strconv.Atoi(e.line)
+	return Violation{RuleID: "lint/" + e.rule, Level: e.severity, File: e.filePath, Line: line, Message: e.message}
+}
+
 // DefaultLintExcludePaths are the path patterns excluded by default (empty - no filtering unless configured)
 var DefaultLintExcludePaths = []string{}
 
@@ -307,14 +316,14 @@ func runConvexEslintBuffered(lf LintFilter) (string, error) {
 	appName := filepath.Base(pkgPath) + "-eslint"
 
 	var output strings.Builder
-	fmt.Fprintf(&output, "   → Running ESLint for %s...\n", pkgPath)
+	fmt.Fprintf(&output, "   "+glyph.Arrow+" Running ESLint for %s...\n", pkgPath)
 
 	lintOutput, err := runConvexEslint(pkgPath)
 	if err != nil {
 		// runConvexEslint only errors when eslint isn't installed (it ignores
 		// eslint's own exit code). A convex eslint config exists, so that's a
 		// setup error that must fail the commit — never a silent skip.
-		fmt.Fprintf(&output, "   ❌ %v\n", err)
+		fmt.Fprintf(&output, "   "+glyph.Fail+" %v\n", err)
 		return output.String(), err
 	}
 
@@ -337,6 +346,9 @@ func runConvexEslintBuffered(lf LintFilter) (string, error) {
 	if filteredCount > 0 {
 		fmt.Fprintf(&output, "   (filtered %d convex eslint errors)\n", filteredCount)
 	}
+	for _, e := range realErrors {
+		recordViolations(e.violation())
+	}
 
 	// Write report if reportDir is set
 	if reportDir != "" {
@@ -359,11 +371,11 @@ func runConvexEslintBuffered(lf LintFilter) (string, error) {
 			}
 			fmt.Fprintf(&output, "  %s:%s  %s  %s  %s\n", e.line, e.column, e.severity, e.message, e.rule)
 		}
-		fmt.Fprintf(&output, "   ❌ %s eslint: found %d finding(s)\n", pkgPath, len(realErrors))
+		fmt.Fprintf(&output, "   "+glyph.Fail+" %s eslint: found %d finding(s)\n", pkgPath, len(realErrors))
 		return output.String(), fmt.Errorf("found %d convex eslint finding(s)", len(realErrors))
 	}
 
-	fmt.Fprintf(&output, "   ✓ %s eslint passed\n", pkgPath)
+	fmt.Fprintf(&output, "   "+glyph.Tick+" %s eslint passed\n", pkgPath)
 	return output.String(), nil
 }
 
@@ -416,6 +428,9 @@ func runFilteredLintBuffered(appName, appPath string, lf LintFilter) (string, er
 	if filteredCount > 0 {
 		fmt.Fprintf(&output, "   (filtered %d lint errors)\n", filteredCount)
 	}
+	for _, e := range realErrors {
+		recordViolations(e.violation())
+	}
 
 	// Write report if reportDir is set
 	if reportDir != "" {
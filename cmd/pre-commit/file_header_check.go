@@ -0,0 +1,296 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/milehighideas/claude-hooks/internal/glyph"
+)
+
+// defaultFileHeaderExtensions is the scope when fileHeaderCheckConfig.extensions
+// is unset.
+var defaultFileHeaderExtensions = []string{".ts", ".tsx", ".js", ".jsx", ".go"}
+
+// fileHeaderYearToken in a header template matches any four-digit year when
+// checking and is replaced with the current year when inserting.
+const fileHeaderYearToken = "{{year}}"
+
+// generatedFileMarkers flag files produced by code generators. They are
+// exempt regardless of path because regenerating them would drop the header.
+var generatedFileMarkers = []string{"Code generated", "DO NOT EDIT", "@generated"}
+
+// generatedPathSegments are path substrings that mark generated or vendored
+// output.
+var generatedPathSegments = []string{"_generated/", "/generated/", ".generated.", ".gen.", "/node_modules/", "/dist/", "/build/"}
+
+// resolveFileHeader returns the configured header template: Header when set,
+// otherwise the contents of HeaderFile (relative to projectRoot). Trailing
+// newlines are dropped so both forms compare the same way.
+func resolveFileHeader(cfg FileHeaderCheckConfig, projectRoot string) (string, error) {
+	header := cfg.Header
+	if header == "" && cfg.HeaderFile != "" {
+		path := cfg.HeaderFile
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(projectRoot, path)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("reading headerFile: %w", err)
+		}
+		header = string(data)
+	}
+	header = strings.TrimRight(strings.ReplaceAll(header, "\r\n", "\n"), "\n")
+	if strings.TrimSpace(header) == "" {
+		return "", fmt.Errorf("fileHeaderCheckConfig needs a header or headerFile")
+	}
+	return header, nil
+}
+
+// fileHeaderPattern compiles header into a regexp anchored at the start of a
+// file. Trailing whitespace on each line is ignored and {{year}} matches any
+// four-digit year.
+func fileHeaderPattern(header string) *regexp.Regexp {
+	lines := strings.Split(header, "\n")
+	for i, line := range lines {
+		quoted := regexp.QuoteMeta(strings.TrimRight(line, " \t"))
+		lines[i] = strings.ReplaceAll(quoted, regexp.QuoteMeta(fileHeaderYearToken), `\d{4}`) + `[ \t]*`
+	}
+	return regexp.MustCompile(`\A` + strings.Join(lines, `\r?\n`) + `(\r?\n|\z)`)
+}
+
+// splitShebang separates a leading "#!" line from the rest of content. The
+// header goes after the shebang since the interpreter line must stay first.
+func splitShebang(content []byte) (shebang, rest []byte) {
+	if !bytes.HasPrefix(content, []byte("#!")) {
+		return nil, content
+	}
+	idx := bytes.IndexByte(content, '\n')
+	if idx < 0 {
+		return content, nil
+	}
+	return content[:idx+1], content[idx+1:]
+}
+
+// hasFileHeader reports whether content starts with the header, after an
+// optional shebang line.
+func hasFileHeader(content []byte, pattern *regexp.Regexp) bool {
+	_, rest := splitShebang(content)
+	return pattern.Match(rest)
+}
+
+// insertFileHeader returns content with header (and a blank separator line)
+// placed at the top, after any shebang.
+func insertFileHeader(content []byte, header string, year int) []byte {
+	shebang, rest := splitShebang(content)
+	text := strings.ReplaceAll(header, fileHeaderYearToken, strconv.Itoa(year))
+
+	var buf bytes.Buffer
+	buf.Write(shebang)
+	buf.WriteString(text)
+	buf.WriteString("\n")
+	if len(rest) > 0 {
+		buf.WriteString("\n")
+		buf.Write(rest)
+	}
+	return buf.Bytes()
+}
+
+// isFileHeaderCandidate reports whether file is in scope by extension and is
+// not a generated, config, or excluded file.
+func isFileHeaderCandidate(cfg FileHeaderCheckConfig, file string) bool {
+	extensions := cfg.Extensions
+	if len(extensions) == 0 {
+		extensions = defaultFileHeaderExtensions
+	}
+	inScope := false
+	for _, ext := range extensions {
+		if strings.HasSuffix(file, ext) {
+			inScope = true
+			break
+		}
+	}
+	if !inScope || strings.HasSuffix(file, ".d.ts") {
+		return false
+	}
+
+	slashed := "/" + filepath.ToSlash(file)
+	for _, seg := range generatedPathSegments {
+		if strings.Contains(slashed, seg) {
+			return false
+		}
+	}
+	for _, p := range cfg.ExcludePaths {
+		if p != "" && strings.Contains(file, p) {
+			return false
+		}
+	}
+
+	// Config files: dotfiles (.eslintrc.js, .prettierrc.cjs) and *.config.*
+	// (vite.config.ts, jest.config.js).
+	base := filepath.Base(file)
+	return !strings.HasPrefix(base, ".") && !strings.Contains(base, ".config.")
+}
+
+// isGeneratedContent reports whether the first lines of content carry a
+// code-generator marker.
+func isGeneratedContent(content []byte) bool {
+	lines := bytes.SplitN(content, []byte("\n"), 6)
+	if len(lines) > 5 {
+		lines = lines[:5]
+	}
+	for _, line := range lines {
+		for _, marker := range generatedFileMarkers {
+			if bytes.Contains(line, []byte(marker)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// collectMissingFileHeaders returns the files among added whose staged content
+// does not start with the header. added holds repo-relative paths of newly
+// added files; the result is sorted.
+func collectMissingFileHeaders(cfg FileHeaderCheckConfig, header string, added []string) []string {
+	pattern := fileHeaderPattern(header)
+	var missing []string
+	for _, file := range added {
+		if !isFileHeaderCandidate(cfg, file) {
+			continue
+		}
+		content, err := defaultGitShow(file)
+		if err != nil || isGeneratedContent(content) {
+			continue
+		}
+		if !hasFileHeader(content, pattern) {
+			missing = append(missing, file)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// fixFileHeader inserts header into file and re-stages it. Files whose
+// working copy differs from the staged copy are left alone: re-staging them
+// would sweep unstaged edits into the commit.
+func fixFileHeader(file, header string) error {
+	staged, err := defaultGitShow(file)
+	if err != nil {
+		return fmt.Errorf("reading staged %s: %w", file, err)
+	}
+	working, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", file, err)
+	}
+	if !bytes.Equal(staged, working) {
+		return fmt.Errorf("%s has unstaged changes; add the header manually", file)
+	}
+
+	info, err := os.Stat(file)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(file, insertFileHeader(staged, header, time.Now().Year()), info.Mode().Perm()); err != nil {
+		return fmt.Errorf("writing %s: %w", file, err)
+	}
+	if output, err := exec.Command("git", "add", "--", file).CombinedOutput(); err != nil {
+		return fmt.Errorf("re-staging %s: %v: %s", file, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// runFileHeaderCheck verifies that newly added staged files start with the
+// configured header. With fix set, missing headers are inserted and the
+// files re-staged; only files that could not be fixed are reported.
+func runFileHeaderCheck(cfg FileHeaderCheckConfig, projectRoot string, fix bool) error {
+	if !compactMode() {
+		fmt.Println("================================")
+		fmt.Println("  FILE HEADER CHECK")
+		fmt.Println("================================")
+	}
+
+	header, err := resolveFileHeader(cfg, projectRoot)
+	if err != nil {
+		printStatus("File headers", false, err.Error())
+		return err
+	}
+
+	addedSet, err := getNewlyAddedFiles()
+	if err != nil {
+		printStatus("File headers", false, "git diff failed")
+		return fmt.Errorf("getting newly added files: %w", err)
+	}
+	added := make([]string, 0, len(addedSet))
+	for f := range addedSet {
+		added = append(added, f)
+	}
+
+	missing := collectMissingFileHeaders(cfg, header, added)
+
+	var fixed []string
+	if fix {
+		var unfixed []string
+		for _, file := range missing {
+			if err := fixFileHeader(file, header); err != nil {
+				fmt.Printf("  "+glyph.Warn+"  %v\n", err)
+				unfixed = append(unfixed, file)
+				continue
+			}
+			fixed = append(fixed, file)
+		}
+		missing = unfixed
+	}
+
+	if !compactMode() {
+		for _, file := range fixed {
+			fmt.Printf("  "+glyph.Wrench+" %s (header added and re-staged)\n", file)
+		}
+	}
+
+	count := len(missing)
+	if reportDir != "" {
+		var body strings.Builder
+		for _, file := range missing {
+			fmt.Fprintf(&body, "  %s\n", file)
+		}
+		_ = writeRunReport("file-header", "File header check", body.String(), count > 0)
+	}
+
+	if count == 0 {
+		if compactMode() {
+			detail := ""
+			if len(fixed) > 0 {
+				detail = fmt.Sprintf("%d fixed", len(fixed))
+			}
+			printStatus("File headers", true, detail)
+		} else {
+			fmt.Println(glyph.Pass + " All new files have the required header")
+			fmt.Println()
+		}
+		return nil
+	}
+
+	if compactMode() {
+		printStatus("File headers", false, fmt.Sprintf("%d file(s)", count))
+		printReportHint("file-header/")
+	} else {
+		fmt.Printf(glyph.Fail+" %d new file(s) missing the required header:\n\n", count)
+		for _, file := range missing {
+			fmt.Printf("  "+glyph.Bullet+" %s\n", file)
+		}
+		fmt.Println()
+		if !fix {
+			fmt.Println(glyph.Hint + " Run pre-commit --check fileHeaderCheck --fix to insert it")
+		}
+		fmt.Println()
+	}
+	return fmt.Errorf("%d new file(s) missing the required header", count)
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testLicenseHeader = "// Copyright {{year}} Acme Inc.\n// SPDX-License-Identifier: MIT"
+
+// setupFileHeaderRepo creates a git repo with one commit, chdirs into it, and
+// stages files as newly added.
+func setupFileHeaderRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := runGitCommand(dir, "init", "-q"); err != nil {
+		t.Skipf("git not available: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("# repo\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := runGitCommand(dir, "add", "README.md"); err != nil {
+		t.Fatal(err)
+	}
+	if err := runGitCommand(dir, "commit", "-q", "-m", "init"); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := runGitCommand(dir, "add", name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(origDir) })
+	return dir
+}
+
+func TestHasFileHeader(t *testing.T) {
+	pattern := fileHeaderPattern(testLicenseHeader)
+	tests := []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{"present", "// Copyright 2024 Acme Inc.\n// SPDX-License-Identifier: MIT\n\nexport {};\n", true},
+		{"header only", "// Copyright 2024 Acme Inc.\n// SPDX-License-Identifier: MIT", true},
+		{"after shebang", "#!/usr/bin/env node\n// Copyright 2024 Acme Inc.\n// SPDX-License-Identifier: MIT\n", true},
+		{"trailing whitespace and CRLF", "// Copyright 2024 Acme Inc.  \r\n// SPDX-License-Identifier: MIT\r\n", true},
+		{"missing", "export const a = 1;\n", false},
+		{"not at top", "import x from 'x';\n// Copyright 2024 Acme Inc.\n// SPDX-License-Identifier: MIT\n", false},
+		{"partial", "// Copyright 2024 Acme Inc.\nexport {};\n", false},
+		{"bad year", "// Copyright 24 Acme Inc.\n// SPDX-License-Identifier: MIT\n", false},
+		{"extended last line", "// Copyright 2024 Acme Inc.\n// SPDX-License-Identifier: MIT-0\n", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasFileHeader([]byte(tt.content), pattern); got != tt.want {
+				t.Errorf("hasFileHeader() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInsertFileHeader(t *testing.T) {
+	got := string(insertFileHeader([]byte("#!/usr/bin/env node\nmain();\n"), testLicenseHeader, 2026))
+	want := "#!/usr/bin/env node\n// Copyright 2026 Acme Inc.\n// SPDX-License-Identifier: MIT\n\nmain();\n"
+	if got != want {
+		t.Errorf("insertFileHeader() = %q, want %q", got, want)
+	}
+	if !hasFileHeader([]byte(got), fileHeaderPattern(testLicenseHeader)) {
+		t.Error("inserted header should satisfy the check")
+	}
+}
+
+func TestIsFileHeaderCandidate(t *testing.T) {
+	cfg := FileHeaderCheckConfig{ExcludePaths: []string{"scripts/"}}
+	tests := map[string]bool{
+		"src/app.ts":                        true,
+		"cmd/tool/main.go":                  true,
+		"src/styles.css":                    false,
+		"src/types.d.ts":                    false,
+		"convex/_generated/api.ts":          false,
+		"src/schema.gen.ts":                 false,
+		"vite.config.ts":                    false,
+		".eslintrc.js":                      false,
+		"scripts/release.ts":                false,
+		"node_modules/pkg/index.js":         false,
+		"packages/ui/dist/index.js":         false,
+		"packages/ui/src/distance/index.ts": true,
+	}
+	for file, want := range tests {
+		if got := isFileHeaderCandidate(cfg, file); got != want {
+			t.Errorf("isFileHeaderCandidate(%q) = %v, want %v", file, got, want)
+		}
+	}
+
+	goOnly := FileHeaderCheckConfig{Extensions: []string{".go"}}
+	if isFileHeaderCandidate(goOnly, "src/app.ts") {
+		t.Error("extensions should narrow the scope")
+	}
+}
+
+func TestResolveFileHeader(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "header.txt"), []byte("// Licensed\n\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := resolveFileHeader(FileHeaderCheckConfig{HeaderFile: "header.txt"}, dir)
+	if err != nil || got != "// Licensed" {
+		t.Errorf("resolveFileHeader(headerFile) = %q, %v", got, err)
+	}
+	if _, err := resolveFileHeader(FileHeaderCheckConfig{}, dir); err == nil {
+		t.Error("expected an error when no header is configured")
+	}
+}
+
+func TestRunFileHeaderCheckMissing(t *testing.T) {
+	dir := setupFileHeaderRepo(t, map[string]string{
+		"src/a.ts":         "export const a = 1;\n",
+		"src/generated.ts": "// Code generated by tool. DO NOT EDIT.\nexport {};\n",
+	})
+	cfg := FileHeaderCheckConfig{Header: testLicenseHeader}
+
+	err := runFileHeaderCheck(cfg, dir, false)
+	if err == nil || !strings.Contains(err.Error(), "1 new file(s)") {
+		t.Fatalf("runFileHeaderCheck() = %v, want 1 missing header", err)
+	}
+	got := collectMissingFileHeaders(cfg, testLicenseHeader, []string{"src/a.ts", "src/generated.ts"})
+	if len(got) != 1 || got[0] != "src/a.ts" {
+		t.Errorf("collectMissingFileHeaders() = %v, want [src/a.ts]", got)
+	}
+}
+
+func TestRunFileHeaderCheckPresent(t *testing.T) {
+	dir := setupFileHeaderRepo(t, map[string]string{
+		"src/a.ts": "// Copyright 2024 Acme Inc.\n// SPDX-License-Identifier: MIT\n\nexport const a = 1;\n",
+		// Not in scope by extension.
+		"src/a.css": "body {}\n",
+	})
+	if err := runFileHeaderCheck(FileHeaderCheckConfig{Header: testLicenseHeader}, dir, false); err != nil {
+		t.Errorf("runFileHeaderCheck() = %v, want nil", err)
+	}
+}
+
+func TestRunFileHeaderCheckIgnoresModifiedFiles(t *testing.T) {
+	dir := setupFileHeaderRepo(t, nil)
+	// README.md is committed; modify and stage it under a checked extension.
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("changed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := runGitCommand(dir, "add", "README.md"); err != nil {
+		t.Fatal(err)
+	}
+	cfg := FileHeaderCheckConfig{Header: testLicenseHeader, Extensions: []string{".md"}}
+	if err := runFileHeaderCheck(cfg, dir, false); err != nil {
+		t.Errorf("modified files must not be checked: %v", err)
+	}
+}
+
+func TestRunFileHeaderCheckFix(t *testing.T) {
+	dir := setupFileHeaderRepo(t, map[string]string{
+		"src/a.ts": "export const a = 1;\n",
+		"src/b.ts": "export const b = 1;\n",
+	})
+	// An unstaged edit to b.ts must not be swept into the commit.
+	if err := os.WriteFile(filepath.Join(dir, "src/b.ts"), []byte("export const b = 2;\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := FileHeaderCheckConfig{Header: testLicenseHeader}
+
+	err := runFileHeaderCheck(cfg, dir, true)
+	if err == nil || !strings.Contains(err.Error(), "1 new file(s)") {
+		t.Fatalf("runFileHeaderCheck(fix) = %v, want only b.ts reported", err)
+	}
+
+	year := strconv.Itoa(time.Now().Year())
+	want := "// Copyright " + year + " Acme Inc.\n// SPDX-License-Identifier: MIT\n\nexport const a = 1;\n"
+	working, err := os.ReadFile(filepath.Join(dir, "src/a.ts"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(working) != want {
+		t.Errorf("working copy = %q, want %q", working, want)
+	}
+	staged, err := exec.Command("git", "show", ":src/a.ts").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(staged) != want {
+		t.Errorf("staged copy = %q, want the fixed file re-staged", staged)
+	}
+
+	unchanged, _ := os.ReadFile(filepath.Join(dir, "src/b.ts"))
+	if string(unchanged) != "export const b = 2;\n" {
+		t.Errorf("b.ts with unstaged changes was rewritten: %q", unchanged)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+
+	"github.com/milehighideas/claude-hooks/internal/glyph"
 )
 
 // runFrontendStructureCheck runs the frontend structure validation by shelling
@@ -78,11 +80,11 @@ func runFrontendStructureBinary() error {
 		return fmt.Errorf("frontend structure validation failed")
 	}
 	if skipped {
-		fmt.Println("✅ Frontend structure check skipped (validator not found)")
+		fmt.Println(glyph.Pass + " Frontend structure check skipped (validator not found)")
 		fmt.Println()
 		return nil
 	}
-	fmt.Println("✅ Frontend structure check passed")
+	fmt.Println(glyph.Pass + " Frontend structure check passed")
 	fmt.Println()
 	return nil
 }
@@ -9,6 +9,8 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+
+	"github.com/milehighideas/claude-hooks/internal/glyph"
 )
 
 const (
@@ -177,7 +179,7 @@ func runGoMissingTestsCheck(cfg GoMissingTestsCheckConfig, projectRoot string, s
 		if compactMode() {
 			printStatus("Go missing tests", false, err.Error())
 		} else {
-			fmt.Printf("❌ Go missing tests error: %v\n\n", err)
+			fmt.Printf(glyph.Fail+" Go missing tests error: %v\n\n", err)
 		}
 		return err
 	}
@@ -187,7 +189,7 @@ func runGoMissingTestsCheck(cfg GoMissingTestsCheckConfig, projectRoot string, s
 		if compactMode() {
 			printStatus("Go missing tests", true, "")
 		} else {
-			fmt.Println("✅ Go missing tests check passed")
+			fmt.Println(glyph.Pass + " Go missing tests check passed")
 			fmt.Println()
 		}
 		return nil
@@ -200,7 +202,7 @@ func runGoMissingTestsCheck(cfg GoMissingTestsCheckConfig, projectRoot string, s
 		if rerr != nil {
 			rel = d
 		}
-		fmt.Fprintf(&sb, "  • %s\n", filepath.ToSlash(rel))
+		fmt.Fprintf(&sb, "  "+glyph.Bullet+" %s\n", filepath.ToSlash(rel))
 	}
 	sb.WriteString("\nAdd at least one *_test.go to each package, or exclude the path via\n")
 	sb.WriteString("goMissingTestsCheckConfig.excludePaths.\n")
@@ -211,7 +213,7 @@ func runGoMissingTestsCheck(cfg GoMissingTestsCheckConfig, projectRoot string, s
 		printStatus("Go missing tests", false, fmt.Sprintf("%d package(s)", len(missing)))
 		printReportHint("go-missing-tests/")
 	} else {
-		fmt.Print("❌ " + sb.String())
+		fmt.Print(glyph.Fail + " " + sb.String())
 		fmt.Println()
 	}
 	return fmt.Errorf("found %d Go package(s) without tests", len(missing))
@@ -5,6 +5,8 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+
+	"github.com/milehighideas/claude-hooks/internal/glyph"
 )
 
 // goTestTarget is one `go test` invocation: a directory and a package pattern.
@@ -127,14 +129,14 @@ func runGoTests(cfg GoTestsConfig, projectRoot string, stagedFiles []string) err
 			printStatus("Go tests", false, fmt.Sprintf("%d target(s) failed", len(failures)))
 			printReportHint("go-tests/")
 		} else {
-			fmt.Printf("❌ go test failed:\n  %s\n", strings.Join(failures, "\n  "))
+			fmt.Printf(glyph.Fail+" go test failed:\n  %s\n", strings.Join(failures, "\n  "))
 		}
 		return fmt.Errorf("go test failed:\n  %s", strings.Join(failures, "\n  "))
 	}
 	if compactMode() {
 		printStatus("Go tests", true, "")
 	} else {
-		fmt.Println("✅ Go tests passed")
+		fmt.Println(glyph.Pass + " Go tests passed")
 		fmt.Println()
 	}
 	return nil
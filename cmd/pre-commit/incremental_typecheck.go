@@ -8,6 +8,8 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+
+	"github.com/milehighideas/claude-hooks/internal/glyph"
 )
 
 // IncrementalTypecheck handles incremental typechecking of specific files
@@ -90,7 +92,7 @@ func (it *IncrementalTypecheck) Run() error {
 		return fmt.Errorf("found %d typecheck error(s) in changed files", len(errors))
 	}
 
-	fmt.Printf("   ✅ Type check passed for %d file(s)\n", len(relativePaths))
+	fmt.Printf("   "+glyph.Pass+" Type check passed for %d file(s)\n", len(relativePaths))
 	return nil
 }
 
@@ -295,7 +297,7 @@ func (it *IncrementalTypecheck) RunBuffered() (string, error) {
 		return output.String(), fmt.Errorf("found %d typecheck error(s) in changed files", len(errors))
 	}
 
-	fmt.Fprintf(&output, "   ✅ Type check passed for %d file(s)\n", len(relativePaths))
+	fmt.Fprintf(&output, "   "+glyph.Pass+" Type check passed for %d file(s)\n", len(relativePaths))
 	return output.String(), nil
 }
 
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// rootConfigPatterns are the repo-root files every lint/typecheck run may
+// read: dependency manifests and lockfiles, shared tsconfig and linter
+// configs, and .pre-commit.json itself. Changing any of them invalidates
+// every cached result.
+var rootConfigPatterns = []string{
+	".pre-commit.json",
+	"package.json",
+	"pnpm-lock.yaml",
+	"pnpm-workspace.yaml",
+	"bun.lock",
+	"bun.lockb",
+	"yarn.lock",
+	"package-lock.json",
+	"tsconfig*.json",
+	".eslintrc*",
+	"eslint.config.*",
+	".oxlintrc*",
+}
+
+// resultCache remembers lint and typecheck runs that passed, keyed by a
+// hash of everything the run reads: the app's files, the workspace packages
+// it depends on, the shared paths, the root configs and the phase settings.
+// Failed runs are never cached, so a broken app is always re-checked.
+type resultCache struct {
+	dir         string
+	sharedPaths []string
+
+	workspaceOnce sync.Once
+	workspace     []workspacePackage
+}
+
+// phaseCache is the cache used by the lint and typecheck phases. nil (the
+// default, and with --no-cache) disables caching.
+var phaseCache *resultCache
+
+// newResultCache returns a cache for the repo at projectRoot, stored in its
+// common git directory so linked worktrees (--verify-staged) share it and
+// other users can't read or plant results. It returns nil, which disables
+// caching, when there is no git directory.
+func newResultCache(projectRoot string, sharedPaths []string) *resultCache {
+	out, err := gitIn(projectRoot, "rev-parse", "--git-common-dir")
+	if err != nil {
+		return nil
+	}
+	gitDir := strings.TrimSpace(string(out))
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(projectRoot, gitDir)
+	}
+	return &resultCache{
+		dir:         filepath.Join(gitDir, "pre-commit-cache"),
+		sharedPaths: sharedPaths,
+	}
+}
+
+// lookup hashes the inputs of job for phase and reports whether a run with
+// the same hash already passed. settings is the effective lint or typecheck
+// filter. The key is returned for store; it is empty when caching is off or
+// the inputs could not be listed, and then the run is never a hit.
+func (c *resultCache) lookup(phase string, job phaseJob, settings interface{}) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	key, err := c.key(phase, job, settings)
+	if err != nil {
+		return "", false
+	}
+	_, err = os.Stat(filepath.Join(c.dir, phase, key))
+	return key, err == nil
+}
+
+// store records that the run hashed to key passed. Errors are ignored; the
+// next run just misses.
+func (c *resultCache) store(phase, key string) {
+	if c == nil || key == "" {
+		return
+	}
+	dir := filepath.Join(c.dir, phase)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return
+	}
+	_ = os.WriteFile(filepath.Join(dir, key), nil, 0600)
+}
+
+// key hashes the phase settings, the job and the content of its input files.
+func (c *resultCache) key(phase string, job phaseJob, settings interface{}) (string, error) {
+	h := sha256.New()
+	settingsJSON, err := json.Marshal(settings)
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(h, "%s\x00%s\x00%s\x00%s\x00%t\x00%s\x00", phase, job.name, job.config.Path, job.config.Filter, job.full, settingsJSON)
+
+	// Incremental runs check only the staged files, so the same inputs with
+	// a different target list are a different run.
+	if !job.full {
+		targets := filterLintableFiles(job.files)
+		sort.Strings(targets)
+		fmt.Fprintf(h, "%s\x00", strings.Join(targets, "\x00"))
+	}
+
+	files, err := c.inputFiles(job.config.Path)
+	if err != nil {
+		return "", err
+	}
+	for _, f := range files {
+		fmt.Fprintf(h, "%s\x00", f)
+		if err := hashFile(h, f); err != nil {
+			// Deleted but still tracked: the path alone marks the state
+			fmt.Fprint(h, "\x01missing")
+		}
+		fmt.Fprint(h, "\x00")
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// inputFiles lists the files a run for the app at appPath can depend on:
+// tracked and untracked-but-not-ignored files under the app, the workspace
+// packages it depends on and the shared paths, plus the root configs.
+// Sorted and deduplicated.
+func (c *resultCache) inputFiles(appPath string) ([]string, error) {
+	// Shared paths are prefixes, not directories; a trailing * makes the
+	// pathspec match them the same way categorizeFiles does.
+	args := []string{"ls-files", "-co", "--exclude-standard", "-z", "--", appPath}
+	args = append(args, c.workspaceDeps(appPath)...)
+	for _, p := range c.sharedPaths {
+		args = append(args, p+"*")
+	}
+	out, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("listing cache inputs: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var files []string
+	add := func(f string) {
+		if f != "" && !seen[f] {
+			seen[f] = true
+			files = append(files, f)
+		}
+	}
+	for _, f := range bytes.Split(out, []byte{0}) {
+		add(string(f))
+	}
+	for _, pattern := range rootConfigPatterns {
+		matches, _ := filepath.Glob(pattern)
+		for _, m := range matches {
+			add(m)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// workspaceDeps returns the directories of the workspace packages the app
+// at appPath depends on, directly or through other workspace packages,
+// sorted. Workspaces are discovered once per cache.
+func (c *resultCache) workspaceDeps(appPath string) []string {
+	c.workspaceOnce.Do(func() {
+		c.workspace, _ = discoverWorkspacePackages(".")
+	})
+	dirByName := make(map[string]string, len(c.workspace))
+	for _, pkg := range c.workspace {
+		dirByName[pkg.Name] = pkg.Dir
+	}
+
+	appDir := path.Clean(filepath.ToSlash(appPath))
+	seen := map[string]bool{appDir: true}
+	var deps []string
+	queue := []string{appDir}
+	for len(queue) > 0 {
+		dir := queue[0]
+		queue = queue[1:]
+		for _, name := range readDependencyNames(filepath.FromSlash(dir)) {
+			dep, ok := dirByName[name]
+			if !ok || seen[dep] {
+				continue
+			}
+			seen[dep] = true
+			deps = append(deps, dep)
+			queue = append(queue, dep)
+		}
+	}
+	sort.Strings(deps)
+	return deps
+}
+
+// readDependencyNames returns the package names dir/package.json depends
+// on in any dependency section, or nil.
+func readDependencyNames(dir string) []string {
+	data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return nil
+	}
+	var pkg struct {
+		Dependencies         map[string]string `json:"dependencies"`
+		DevDependencies      map[string]string `json:"devDependencies"`
+		PeerDependencies     map[string]string `json:"peerDependencies"`
+		OptionalDependencies map[string]string `json:"optionalDependencies"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil
+	}
+	var names []string
+	for _, deps := range []map[string]string{pkg.Dependencies, pkg.DevDependencies, pkg.PeerDependencies, pkg.OptionalDependencies} {
+		for name := range deps {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func hashFile(w io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	_, err = io.Copy(w, f)
+	return err
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// cacheFixture creates a git repo with one app and a shared package,
+// changes into it and returns a cache stored in a temp dir.
+func cacheFixture(t *testing.T) *resultCache {
+	t.Helper()
+	chdirToBranchRepo(t)
+	for path, content := range map[string]string{
+		"tsconfig.json":                `{"compilerOptions":{"strict":true}}`,
+		"apps/web/tsconfig.json":       `{"extends":"../../tsconfig.json"}`,
+		"apps/web/src/index.ts":        "export const a = 1;\n",
+		"packages/shared/src/util.ts":  "export const u = 1;\n",
+		"apps/mobile/src/unrelated.ts": "export const m = 1;\n",
+	} {
+		writeCacheFile(t, path, content)
+	}
+	return &resultCache{dir: t.TempDir(), sharedPaths: []string{"packages/shared/"}}
+}
+
+func writeCacheFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func webJob() phaseJob {
+	return phaseJob{name: "web", config: AppConfig{Path: "apps/web", Filter: "web"}, full: true}
+}
+
+func TestResultCache_HitAndMiss(t *testing.T) {
+	c := cacheFixture(t)
+	filter := TypecheckFilter{ErrorCodes: []string{"TS2589"}}
+
+	key, hit := c.lookup("typecheck", webJob(), filter)
+	if hit || key == "" {
+		t.Fatalf("first lookup: key=%q hit=%v, want a miss with a key", key, hit)
+	}
+	c.store("typecheck", key)
+
+	if _, hit := c.lookup("typecheck", webJob(), filter); !hit {
+		t.Error("unchanged inputs should hit")
+	}
+	if _, hit := c.lookup("lint", webJob(), filter); hit {
+		t.Error("a pass in one phase should not hit in another")
+	}
+
+	// Files outside the app and the shared paths don't affect the result
+	writeCacheFile(t, "apps/mobile/src/unrelated.ts", "export const m = 2;\n")
+	if _, hit := c.lookup("typecheck", webJob(), filter); !hit {
+		t.Error("edit outside the app should still hit")
+	}
+
+	writeCacheFile(t, "apps/web/src/index.ts", "export const a = 2;\n")
+	if _, hit := c.lookup("typecheck", webJob(), filter); hit {
+		t.Error("edited app file should miss")
+	}
+}
+
+func TestResultCache_Invalidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(t *testing.T)
+		filter TypecheckFilter
+	}{
+		{"shared path edit", func(t *testing.T) {
+			writeCacheFile(t, "packages/shared/src/util.ts", "export const u = 2;\n")
+		}, TypecheckFilter{}},
+		{"new untracked app file", func(t *testing.T) {
+			writeCacheFile(t, "apps/web/src/new.ts", "export {};\n")
+		}, TypecheckFilter{}},
+		{"root tsconfig change", func(t *testing.T) {
+			writeCacheFile(t, "tsconfig.json", `{"compilerOptions":{"strict":false}}`)
+		}, TypecheckFilter{}},
+		{"app tsconfig change", func(t *testing.T) {
+			writeCacheFile(t, "apps/web/tsconfig.json", `{}`)
+		}, TypecheckFilter{}},
+		{"lockfile added", func(t *testing.T) {
+			writeCacheFile(t, "pnpm-lock.yaml", "lockfileVersion: '9.0'\n")
+		}, TypecheckFilter{}},
+		{"filter settings change", func(*testing.T) {}, TypecheckFilter{ErrorCodes: []string{"TS2742"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := cacheFixture(t)
+			key, _ := c.lookup("typecheck", webJob(), TypecheckFilter{})
+			c.store("typecheck", key)
+
+			tt.change(t)
+			if _, hit := c.lookup("typecheck", webJob(), tt.filter); hit {
+				t.Error("expected a miss after the change")
+			}
+		})
+	}
+}
+
+func TestResultCache_IncrementalTargets(t *testing.T) {
+	c := cacheFixture(t)
+	job := webJob()
+	job.full = false
+	job.files = []string{"apps/web/src/index.ts"}
+
+	key, _ := c.lookup("typecheck", job, TypecheckFilter{})
+	c.store("typecheck", key)
+
+	job.files = []string{"apps/web/src/index.ts", "apps/web/tsconfig.json"}
+	if _, hit := c.lookup("typecheck", job, TypecheckFilter{}); !hit {
+		t.Error("non-lintable extra target should not change the key")
+	}
+	job.files = []string{"apps/web/src/other.ts"}
+	if _, hit := c.lookup("typecheck", job, TypecheckFilter{}); hit {
+		t.Error("different target files should miss")
+	}
+}
+
+func TestResultCache_NilDisablesCaching(t *testing.T) {
+	var c *resultCache
+	key, hit := c.lookup("lint", webJob(), LintFilter{})
+	if key != "" || hit {
+		t.Errorf("nil cache lookup = (%q, %v), want (\"\", false)", key, hit)
+	}
+	c.store("lint", "abc") // must not panic
+}
+
+func TestRunTypecheckTo_CacheHitSkipsRun(t *testing.T) {
+	c := cacheFixture(t)
+	old := phaseCache
+	phaseCache = c
+	t.Cleanup(func() { phaseCache = old })
+
+	filter := TypecheckFilter{}
+	apps := map[string]AppConfig{"web": {Path: "apps/web", Filter: "web"}}
+	key, _ := c.lookup("typecheck", webJob(), GetTypecheckFilter(filter, nil))
+	c.store("typecheck", key)
+
+	var out bytes.Buffer
+	if err := runTypecheckTo(&out, apps, nil, false, filter, true, "pnpm"); err != nil {
+		t.Fatalf("cached typecheck failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "web passed typecheck (cached") {
+		t.Errorf("output = %q, want cached pass", out.String())
+	}
+}
+
+func TestResultCache_WorkspaceDependencies(t *testing.T) {
+	c := cacheFixture(t)
+	for path, content := range map[string]string{
+		"pnpm-workspace.yaml":           "packages:\n  - apps/*\n  - packages/*\n",
+		"apps/web/package.json":         `{"name":"web","dependencies":{"@acme/ui":"workspace:*","react":"19.0.0"}}`,
+		"apps/mobile/package.json":      `{"name":"mobile"}`,
+		"packages/ui/package.json":      `{"name":"@acme/ui","devDependencies":{"@acme/tokens":"workspace:*"}}`,
+		"packages/ui/src/button.ts":     "export const b = 1;\n",
+		"packages/tokens/package.json":  `{"name":"@acme/tokens"}`,
+		"packages/tokens/src/colors.ts": "export const c = 1;\n",
+		"packages/unused/package.json":  `{"name":"@acme/unused"}`,
+		"packages/unused/src/unused.ts": "export const u = 1;\n",
+	} {
+		writeCacheFile(t, path, content)
+	}
+	if got, want := c.workspaceDeps("apps/web"), []string{"packages/tokens", "packages/ui"}; strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("workspaceDeps(apps/web) = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		name string
+		file string
+		miss bool
+	}{
+		{"unrelated workspace package", "packages/unused/src/unused.ts", false},
+		{"direct dependency", "packages/ui/src/button.ts", true},
+		{"transitive dependency", "packages/tokens/src/colors.ts", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, _ := c.lookup("typecheck", webJob(), TypecheckFilter{})
+			c.store("typecheck", key)
+
+			writeCacheFile(t, tt.file, "export const changed = 2;\n")
+			if _, hit := c.lookup("typecheck", webJob(), TypecheckFilter{}); hit == tt.miss {
+				t.Errorf("hit = %v after editing %s, want %v", hit, tt.file, !tt.miss)
+			}
+		})
+	}
+}
+
+func TestNewResultCache_InGitDir(t *testing.T) {
+	chdirToBranchRepo(t)
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := newResultCache(dir, nil)
+	if c == nil {
+		t.Fatal("newResultCache() = nil in a git repo")
+	}
+	if want := filepath.Join(dir, ".git", "pre-commit-cache"); c.dir != want {
+		t.Errorf("dir = %q, want %q", c.dir, want)
+	}
+	c.store("lint", "abc")
+	for path, want := range map[string]os.FileMode{filepath.Join(c.dir, "lint"): 0700, filepath.Join(c.dir, "lint", "abc"): 0600} {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode().Perm() != want {
+			t.Errorf("%s mode = %o, want %o", path, info.Mode().Perm(), want)
+		}
+	}
+
+	if c := newResultCache(t.TempDir(), nil); c != nil {
+		t.Errorf("newResultCache() outside a repo = %+v, want nil", c)
+	}
+}
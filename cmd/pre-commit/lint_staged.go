@@ -1,6 +1,18 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/milehighideas/claude-hooks/internal/glyph"
+)
+
+// lintStagedDiffFile is where --report-dir mode keeps the diff of what
+// lint-staged rewrote, under <reportDir>/formatting/.
+const lintStagedDiffFile = "fixes.diff"
 
 // runLintStaged runs lint-staged for file formatting
 func runLintStaged(cfg LintStagedConfig) error {
@@ -22,20 +34,96 @@ func runLintStaged(cfg LintStagedConfig) error {
 	}
 	args := []string{"--no-stash"}
 
+	// lint-staged re-stages what it fixes; snapshot the index to diff after.
+	before, snapErr := indexTree()
+
 	if compactMode() {
 		// Capture output instead of piping to terminal
 		if _, err := runCommandCapturedWithEnv(cfg.Env, bin, args...); err != nil {
 			printStatus("Formatting", false, "lint-staged failed")
 			return fmt.Errorf("lint-staged failed: %w", err)
 		}
-		printStatus("Formatting", true, "")
+		var fixed []string
+		if snapErr == nil {
+			fixed = reportLintStagedFixes(before)
+		}
+		if len(fixed) == 0 {
+			printStatus("Formatting", true, "")
+			return nil
+		}
+		printStatus("Formatting", true, fmt.Sprintf("%d fixed", len(fixed)))
+		printReportHint("formatting/" + lintStagedDiffFile)
 		return nil
 	}
 
 	if err := runCommandWithEnv(cfg.Env, bin, args...); err != nil {
 		return fmt.Errorf("lint-staged failed: %w", err)
 	}
+	if snapErr == nil {
+		for _, file := range reportLintStagedFixes(before) {
+			fmt.Printf("  "+glyph.Wrench+" %s (reformatted and re-staged)\n", file)
+		}
+	}
 	fmt.Println("Formatting complete")
 	fmt.Println()
 	return nil
 }
+
+// indexTree writes the index as a tree object and returns its hash, a
+// snapshot of exactly what would be committed.
+func indexTree() (string, error) {
+	out, err := exec.Command("git", "write-tree").Output()
+	if err != nil {
+		return "", fmt.Errorf("git write-tree: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// changedBetween lists the files that differ between two tree snapshots.
+func changedBetween(before, after string) ([]string, error) {
+	out, err := exec.Command("git", "diff", "--name-only", before, after).Output()
+	if err != nil {
+		return nil, fmt.Errorf("git diff --name-only: %w", err)
+	}
+	var files []string
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
+
+// reportLintStagedFixes returns the files lint-staged changed in the index
+// since the before snapshot. In --report-dir mode it also writes their diff
+// to formatting/fixes.diff. Best-effort: git errors yield no files.
+func reportLintStagedFixes(before string) []string {
+	after, err := indexTree()
+	if err != nil || after == before {
+		return nil
+	}
+	fixed, err := changedBetween(before, after)
+	if err != nil || len(fixed) == 0 {
+		return nil
+	}
+	if reportDir != "" {
+		if err := writeLintStagedDiff(before, after); err != nil {
+			fmt.Printf("   Warning: failed to write formatting diff: %v\n", err)
+		}
+	}
+	return fixed
+}
+
+// writeLintStagedDiff writes the diff between two index snapshots to
+// <reportDir>/formatting/fixes.diff.
+func writeLintStagedDiff(before, after string) error {
+	diff, err := exec.Command("git", "diff", before, after).Output()
+	if err != nil {
+		return fmt.Errorf("git diff: %w", err)
+	}
+	dir := filepath.Join(reportDir, "formatting")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, lintStagedDiffFile), diff, 0644)
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReportLintStagedFixes(t *testing.T) {
+	chdirToBranchRepo(t)
+	dir, _ := os.Getwd()
+
+	writeAndStage := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := runGitCommand(dir, "add", name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeAndStage("a.ts", "const a = {x:1}\n")
+	writeAndStage("b.ts", "export const b = 1;\n")
+	before, err := indexTree()
+	if err != nil {
+		t.Fatalf("indexTree: %v", err)
+	}
+
+	origReportDir := reportDir
+	t.Cleanup(func() { reportDir = origReportDir })
+	reportDir = ""
+
+	// Nothing changed: no files reported.
+	if fixed := reportLintStagedFixes(before); len(fixed) != 0 {
+		t.Errorf("unchanged index: fixed = %v, want none", fixed)
+	}
+
+	// Simulated formatter run: rewrite a.ts and re-stage it, leave b.ts alone.
+	writeAndStage("a.ts", "const a = { x: 1 };\n")
+	reportDir = filepath.Join(t.TempDir(), "reports")
+
+	fixed := reportLintStagedFixes(before)
+	if want := []string{"a.ts"}; !reflect.DeepEqual(fixed, want) {
+		t.Errorf("fixed = %v, want %v", fixed, want)
+	}
+
+	diff, err := os.ReadFile(filepath.Join(reportDir, "formatting", lintStagedDiffFile))
+	if err != nil {
+		t.Fatalf("diff not written: %v", err)
+	}
+	for _, want := range []string{"-const a = {x:1}", "+const a = { x: 1 };"} {
+		if !strings.Contains(string(diff), want) {
+			t.Errorf("diff missing %q:\n%s", want, diff)
+		}
+	}
+	if strings.Contains(string(diff), "b.ts") {
+		t.Errorf("diff includes untouched b.ts:\n%s", diff)
+	}
+}
+
+func TestReportLintStagedFixes_UnstagedEditsIgnored(t *testing.T) {
+	chdirToBranchRepo(t)
+	dir, _ := os.Getwd()
+
+	before, err := indexTree()
+	if err != nil {
+		t.Fatalf("indexTree: %v", err)
+	}
+	// A worktree-only change is not part of the commit, so it isn't a fix.
+	if err := os.WriteFile(filepath.Join(dir, "test.txt"), []byte("edited"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if fixed := reportLintStagedFixes(before); len(fixed) != 0 {
+		t.Errorf("fixed = %v, want none", fixed)
+	}
+}
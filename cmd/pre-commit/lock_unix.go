@@ -8,6 +8,8 @@ import (
 	"path/filepath"
 	"strings"
 	"syscall"
+
+	"github.com/milehighideas/claude-hooks/internal/glyph"
 )
 
 // acquireLock tries to get an exclusive file lock keyed to the current repo.
@@ -62,7 +64,7 @@ func acquireGlobalLockBlocking(repoID string) (*os.File, error) {
 		if holder == "" {
 			holder = "another pre-commit run"
 		}
-		fmt.Fprintf(os.Stderr, "⏳ Waiting on global pre-commit lock (held by %s)...\n", holder)
+		fmt.Fprintf(os.Stderr, glyph.Wait+" Waiting on global pre-commit lock (held by %s)...\n", holder)
 		if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
 			_ = f.Close()
 			return nil, fmt.Errorf("global lock blocking wait failed: %w", err)
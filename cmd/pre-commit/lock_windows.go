@@ -9,6 +9,8 @@ import (
 	"strings"
 	"syscall"
 	"unsafe"
+
+	"github.com/milehighideas/claude-hooks/internal/glyph"
 )
 
 var (
@@ -99,7 +101,7 @@ func acquireGlobalLockBlocking(repoID string) (*os.File, error) {
 		if holder == "" {
 			holder = "another pre-commit run"
 		}
-		fmt.Fprintf(os.Stderr, "⏳ Waiting on global pre-commit lock (held by %s)...\n", holder)
+		fmt.Fprintf(os.Stderr, glyph.Wait+" Waiting on global pre-commit lock (held by %s)...\n", holder)
 		// Blocking wait — no FailImmediately flag.
 		ol2 := new(syscall.Overlapped)
 		r2, _, errno := lockFileEx.Call(
@@ -7,6 +7,8 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+
+	"github.com/milehighideas/claude-hooks/internal/glyph"
 )
 
 // MaestroValidation
@@ -626,7 +628,7 @@ func reportMaestroVerbose(
 	fmt.Printf("scanned %d literal and %d templated testIDs\n", len(literals), len(patterns))
 
 	if len(unresolved) == 0 {
-		fmt.Println("✅ all Maestro testIDs resolve to source")
+		fmt.Println(glyph.Pass + " all Maestro testIDs resolve to source")
 		return nil
 	}
 
@@ -661,11 +663,11 @@ func reportMaestroVerbose(
 		return entries[i].totalRef > entries[j].totalRef
 	})
 
-	fmt.Printf("\n❌ %d missing testID(s) across %d flow(s):\n\n", len(unresolved), len(entries))
+	fmt.Printf("\n"+glyph.Fail+" %d missing testID(s) across %d flow(s):\n\n", len(unresolved), len(entries))
 
 	for _, e := range entries {
 		name := strings.TrimPrefix(e.file, "./")
-		fmt.Printf("  ● %s  (%d ids, %d refs)\n", name, len(e.ids), e.totalRef)
+		fmt.Printf("  "+glyph.Dot+" %s  (%d ids, %d refs)\n", name, len(e.ids), e.totalRef)
 		limit := len(e.ids)
 		if limit > 6 {
 			limit = 6
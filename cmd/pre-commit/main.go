@@ -3,6 +3,7 @@ package main
 import (
 	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
@@ -12,30 +13,54 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/milehighideas/claude-hooks/internal/glyph"
 )
 
 // CLI flags
 var (
-	standalone  bool
-	targetPath  string
-	checkName   string
-	listChecks  bool
-	verboseFlag bool
-	configPath  string
-	reportDir   string
-	noLock      bool
-	globalLock  bool
+	standalone   bool
+	targetPath   string
+	checkName    string
+	onlyChecks   string
+	exceptChecks string
+	listChecks   bool
+	verboseFlag  bool
+	configPath   string
+	reportDir    string
+	noLock       bool
+	globalLock   bool
+	fixFlag      bool
+	checkConfig  bool
+	strictStaged bool
+	verifyStaged bool
+	allowLarge   bool
+	noCache      bool
+	sarifPath    string
+	daemonMode   bool
+	clientMode   bool
 )
 
 func init() {
 	flag.BoolVar(&standalone, "standalone", false, "Run without git context (check all files in path)")
 	flag.StringVar(&targetPath, "path", "", "Directory path to check (used with --standalone)")
 	flag.StringVar(&checkName, "check", "", "Run only a specific check (e.g., frontendStructure, srp, mockCheck)")
+	flag.StringVar(&onlyChecks, "only", "", "Run only these enabled checks, comma-separated (e.g., srp,consoleCheck)")
+	flag.StringVar(&exceptChecks, "except", "", "Skip these checks, comma-separated (e.g., tests)")
 	flag.BoolVar(&listChecks, "list", false, "List available checks")
 	flag.StringVar(&configPath, "config", "", "Path to .pre-commit.json config file (defaults to .pre-commit.json in target path)")
 	flag.StringVar(&reportDir, "report-dir", "", "Directory to write detailed lint/typecheck reports (creates lint/ and typecheck/ subdirs)")
 	flag.BoolVar(&noLock, "no-lock", false, "Skip exclusive lock (allow concurrent runs)")
 	flag.BoolVar(&globalLock, "global-lock", os.Getenv("PRE_COMMIT_GLOBAL_LOCK") == "1", "Serialize pre-commit runs across all repos via /tmp/pre-commit-global.lock (waits for previous run to finish). Also enabled by env PRE_COMMIT_GLOBAL_LOCK=1.")
+	flag.BoolVar(&fixFlag, "fix", false, "Auto-fix violations for checks that support it (fileHeaderCheck inserts missing headers and re-stages)")
+	flag.BoolVar(&strictStaged, "strict-staged", false, "Set unstaged changes aside while checks run so they see exactly the staged content (restored afterwards)")
+	flag.BoolVar(&verifyStaged, "verify-staged", false, "Run checks in a temporary git worktree holding only the staged content; only lint-staged and --fix run in the working tree, first")
+	flag.BoolVar(&allowLarge, "allow-large-commit", os.Getenv(allowLargeCommitEnv) == "1", "Let maxFilesCheck pass a commit over the staged-file limit. Also enabled by env PRE_COMMIT_ALLOW_LARGE_COMMIT=1.")
+	flag.StringVar(&sarifPath, "sarif", "", "Write lint, typecheck, SRP, native SRP, console and data-layer violations to this path as a SARIF 2.1.0 report (for GitHub code scanning)")
+	flag.BoolVar(&daemonMode, "daemon", false, "Serve checks for this repo on a unix socket, keeping config loaded between commits (use with --client)")
+	flag.BoolVar(&clientMode, "client", false, "Send the staged files to this repo's --daemon and print its results; runs the checks directly when no daemon is listening")
+	flag.BoolVar(&noCache, "no-cache", false, "Run lint and typecheck even when a previous run with the same inputs passed")
+	flag.BoolVar(&checkConfig, "check-config", false, "Validate .pre-commit.json (each app filter resolves to a workspace package) and exit")
 	flag.BoolVar(&verboseFlag, "verbose", false, "Print full per-app output even when reports are being written. Default: compact status lines when report-dir is set.")
 }
 
@@ -72,6 +97,7 @@ var checkKeyToDisplay = map[string]string{
 	"lintStaged":              "Formatting",
 	"consoleCheck":            "Console check",
 	"dataLayerCheck":          "Data layer check",
+	"envAccessCheck":          "Env access check",
 	"nextImageCheck":          "Next image refs",
 	"nextLinkCheck":           "Next link check",
 	"changelog":               "Changelog",
@@ -91,24 +117,34 @@ var checkKeyToDisplay = map[string]string{
 	"stubTestCheck":           "Stub tests",
 	"missingTestsCheck":       "Missing tests",
 	"testSubstanceCheck":      "Test substance",
+	"stubSourceCheck":         "Stub sources",
+	"maxFilesCheck":           "Max files",
 	"redundantCreatedAtCheck": "Redundant createdAt",
+	"fileHeaderCheck":         "File headers",
+	"conflictMarkers":         "Conflict markers",
 	"tiersGen":                "Tiers gen",
 	"linguiExtract":           "Lingui extract",
 	"tests":                   "Tests",
 }
 
 // warningDisplayNames holds the set of display names whose failures should
-// render as ⚠️ (warning) rather than ❌. Populated from config.WarningChecks
-// at the start of each run() via registerWarningChecks.
+// render as ⚠️ (warning) rather than ❌. Populated from the config's
+// warningChecks and checkSeverity at the start of each run() via
+// registerWarningChecks.
 var warningDisplayNames = map[string]bool{}
 
-// registerWarningChecks translates config.WarningChecks (a list of config
-// keys like "maestroValidation") into the display-name set consulted by
-// printStatus. Call once per run after config is loaded.
-func registerWarningChecks(keys []string) {
+// registerWarningChecks translates the checks config routes to warnings
+// (config keys like "maestroValidation") into the display-name set consulted
+// by printStatus. Call once per run after config is loaded.
+func registerWarningChecks(config *Config) {
 	warningDisplayNames = map[string]bool{}
-	for _, k := range keys {
-		if name, ok := checkKeyToDisplay[k]; ok {
+	for key, name := range checkKeyToDisplay {
+		if config.IsWarningCheck(key) {
+			warningDisplayNames[name] = true
+		}
+	}
+	for _, name := range config.customCheckNames() {
+		if config.IsWarningCheck(name) {
 			warningDisplayNames[name] = true
 		}
 	}
@@ -142,7 +178,7 @@ func printStartTo(w io.Writer, name string) {
 	if !compactMode() {
 		return
 	}
-	_, _ = fmt.Fprintf(w, "  ▶ %s %s\n", now.Format("15:04:05"), name)
+	_, _ = fmt.Fprintf(w, "  "+glyph.Start+" %s %s\n", now.Format("15:04:05"), name)
 }
 
 // consumeStart returns the start time recorded by printStart for name and
@@ -217,10 +253,10 @@ func printStatusTo(w io.Writer, name string, passed bool, detail string) {
 		printWarningStatusTo(w, name, detail)
 		return
 	}
-	icon := "✅"
+	icon := glyph.Pass
 	status := ""
 	if !passed {
-		icon = "❌"
+		icon = glyph.Fail
 	}
 	if detail != "" {
 		status = " (" + detail + ")"
@@ -243,7 +279,7 @@ func printWarningStatusTo(w io.Writer, name string, detail string) {
 	if detail != "" {
 		status = " (" + detail + ")"
 	}
-	_, _ = fmt.Fprintf(w, "  ⚠️  %s%s (warning)\n", name, status)
+	_, _ = fmt.Fprintf(w, "  "+glyph.Warn+"  %s%s (warning)\n", name, status)
 }
 
 // printReportHint prints a pointer to the report directory for a failed check.
@@ -253,7 +289,7 @@ func printReportHint(subdir string) {
 
 func printReportHintTo(w io.Writer, subdir string) {
 	if compactMode() {
-		_, _ = fmt.Fprintf(w, "     → %s/%s\n", reportDir, subdir)
+		_, _ = fmt.Fprintf(w, "     "+glyph.Arrow+" %s/%s\n", reportDir, subdir)
 	}
 }
 
@@ -265,6 +301,46 @@ func main() {
 		return
 	}
 
+	if checkConfig {
+		if err := runCheckConfig(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Checks may run from another directory (--verify-staged), so pin the
+	// SARIF path to where the hook was invoked.
+	if sarifPath != "" {
+		if abs, err := filepath.Abs(sarifPath); err == nil {
+			sarifPath = abs
+		}
+	}
+
+	if strictStaged && verifyStaged {
+		fmt.Fprintln(os.Stderr, "Error: --strict-staged and --verify-staged cannot be combined")
+		os.Exit(1)
+	}
+
+	if daemonMode {
+		if err := runDaemon(daemonSocketPath()); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if clientMode {
+		code, err := runClient(daemonSocketPath(), os.Stdout, os.Stderr)
+		if err == nil {
+			os.Exit(code)
+		}
+		if !errors.Is(err, errNoDaemon) {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprintln(os.Stderr, "pre-commit: no daemon listening; running checks directly")
+	}
+
 	// Optional system-wide blocking lock — serializes pre-commits across all repos
 	// on this machine. Lets two Claude sessions in different repos coexist without
 	// starving each other on test/typecheck CPU.
@@ -296,12 +372,39 @@ func main() {
 		reportDir = setupReportDir(reportDir)
 	}
 
-	if err := run(); err != nil {
+	if err := runOnce(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
 
+// runOnce runs the checks once, in the staged-content mode the flags ask
+// for, and writes the SARIF report when --sarif is set. main calls it once;
+// the daemon calls it per client request.
+func runOnce() error {
+	runChecks := run
+	if strictStaged && !standalone {
+		runChecks = func() error { return withStagedContent(run) }
+	}
+	if verifyStaged && !standalone {
+		runChecks = func() error {
+			if err := runStagedFixers(); err != nil {
+				return err
+			}
+			fixersApplied = true
+			defer func() { fixersApplied = false }()
+			return withStagedWorktree(run)
+		}
+	}
+	err := runChecks()
+	if sarifPath != "" {
+		if werr := writeSARIF(sarifPath); werr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to write SARIF report: %v\n", werr)
+		}
+	}
+	return err
+}
+
 // getRepoToplevel returns the absolute path of the current git repo, or
 // "unknown" if `git rev-parse` fails (e.g. running outside a repo).
 func getRepoToplevel() string {
@@ -353,12 +456,15 @@ func setupReportDir(baseDir string) string {
 		return baseDir
 	}
 
-	fmt.Printf("📁 Reports will be written to: %s\n\n", fullPath)
+	fmt.Printf(glyph.Folder+" Reports will be written to: %s\n\n", fullPath)
 	return fullPath
 }
 
 // getGitBranch returns the current git branch name
 func getGitBranch() string {
+	if branchOverride != "" {
+		return branchOverride
+	}
 	cmd := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD")
 	output, err := cmd.Output()
 	if err != nil {
@@ -389,8 +495,13 @@ func printAvailableChecks() {
 	fmt.Println("  stubTestCheck      - Ban placeholder expect(true).toBe(true) stub tests")
 	fmt.Println("  missingTestsCheck  - Ban source files without co-located .test.ts(x) (per-app scoped)")
 	fmt.Println("  testSubstanceCheck - LOC-ratio / interaction / branch / tautology gates against (source, test) pairs")
+	fmt.Println("  stubSourceCheck    - Warn when a tested source file is still a stub (never blocks)")
+	fmt.Println("  maxFilesCheck      - Block commits staging more than maxFilesCheckConfig.maxFiles files (--allow-large-commit overrides)")
 	fmt.Println("  redundantCreatedAtCheck - Ban createdAt fields inside Convex defineTable (use _creationTime)")
+	fmt.Println("  fileHeaderCheck    - Require a license/copyright header on newly added files (--fix inserts it)")
+	fmt.Println("  conflictMarkers    - Fail on <<<<<<< / ======= / >>>>>>> merge-conflict markers in staged text files")
 	fmt.Println("  dataLayerCheck     - Check for direct Convex imports (should use data-layer)")
+	fmt.Println("  envAccessCheck     - Check for process.env / import.meta.env outside the config module")
 	fmt.Println("  maestroValidation  - Validate Maestro flow id: selectors resolve to source testIDs")
 	fmt.Println("  nextImageCheck     - Verify Next.js public/ asset references resolve (static)")
 	fmt.Println("  nextLinkCheck      - Verify Next.js internal links resolve (static/crawl/both)")
@@ -406,14 +517,17 @@ func run() error {
 	fmt.Println()
 
 	// Load configuration
-	config, err := loadConfig()
+	config, err := configSource()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
+	if err := filterChecks(config); err != nil {
+		return err
+	}
 
 	// Register warning-only checks so printStatus downgrades their failures
 	// from ❌ to ⚠️ and matches the routing collectResult applies.
-	registerWarningChecks(config.WarningChecks)
+	registerWarningChecks(config)
 
 	// Set up report directory from config if not provided via flag
 	if reportDir == "" && config.ReportDir != "" {
@@ -421,7 +535,7 @@ func run() error {
 	}
 
 	// Get staged files
-	stagedFiles, err := getStagedFiles()
+	stagedFiles, err := stagedFilesSource()
 	if err != nil {
 		return fmt.Errorf("failed to get staged files: %w", err)
 	}
@@ -431,6 +545,11 @@ func run() error {
 		return nil
 	}
 
+	// Lint and typecheck skip apps whose inputs match a previous passing run
+	if !noCache {
+		phaseCache = newResultCache(getRepoToplevel(), config.SharedPaths)
+	}
+
 	// Categorize files by app
 	appFiles, sharedChanged := categorizeFiles(stagedFiles, config.Apps, config.SharedPaths)
 
@@ -442,13 +561,27 @@ func run() error {
 		return runSpecificCheck(checkName, config, stagedFiles)
 	}
 
+	// preCheck: user command that must succeed before any check runs.
+	if err := runPreCheck(config); err != nil {
+		return err
+	}
+
 	// =====================================================================
 	// PHASE 1 — Hard gates that must pass before any work runs.
 	// =====================================================================
 
 	// Branch protection
 	if config.Features.BranchProtection {
-		if err := checkBranchProtection(config.ProtectedBranches); err != nil {
+		if err := checkBranchProtection(config.ProtectedBranches, config.AllowOnProtected, stagedFiles); err != nil {
+			return err
+		}
+	}
+
+	// Staged file count: an accidental `git add .` should fail before any
+	// check spends time on hundreds of files.
+	if config.Features.MaxFilesCheck {
+		printStart("Max files")
+		if err := runMaxFilesCheck(config.MaxFilesCheckConfig, stagedFiles, allowLarge); err != nil {
 			return err
 		}
 	}
@@ -459,26 +592,36 @@ func run() error {
 	//   - Changelog: cheapest possible gate; if a fragment is missing the
 	//     commit is going to fail anyway, so bail before burning CPU on
 	//     lint/typecheck/tests.
+	//   - fileHeaderCheck: with --fix it rewrites and re-stages new files,
+	//     so it runs before the formatter and every reader.
 	//   - lint-staged: auto-formats staged files. Must run before any
 	//     read-based check or readers race against the formatter.
 	//   - tiersGen: regenerates a derived TypeScript file. Must run before
 	//     typecheck reads the regenerated output.
 	//
-	// All three fail fast on error.
+	// All of these fail fast on error.
 	// =====================================================================
 
 	if config.Features.Changelog {
 		printStart("Changelog")
 		if err := checkChangelog(stagedFiles, config.ChangelogExclude, config.ChangelogConfig, config.Apps); err != nil {
 			if compactMode() {
-				printStatus("Changelog", false, "missing fragments")
+				printStatus("Changelog", false, "missing or malformed fragments")
 			}
 			return err
 		}
 		printStatus("Changelog", true, "")
 	}
 
-	if config.Features.LintStaged {
+	if config.Features.FileHeaderCheck {
+		printStart("File headers")
+		projectRoot, _ := os.Getwd()
+		if err := runFileHeaderCheck(config.FileHeaderCheckConfig, projectRoot, fixFlag && !fixersApplied); err != nil {
+			return err
+		}
+	}
+
+	if config.Features.LintStaged && !fixersApplied {
 		printStart("Formatting")
 		if err := runLintStaged(config.LintStagedConfig); err != nil {
 			return err
@@ -511,29 +654,14 @@ func run() error {
 	//
 	// Each check runs in its own goroutine. Status lines emit live to
 	// stdout as each goroutine finishes — order is intentionally
-	// non-deterministic. Errors and warnings are aggregated under
-	// resultsMu and reported once after all goroutines drain.
+	// non-deterministic. Errors and warnings are aggregated by
+	// checkResults and reported once after all goroutines drain.
 	// =====================================================================
 
-	var allErrors []string
-	var allWarnings []string
-	var resultsMu sync.Mutex
+	results := &checkResults{config: config}
+	collectResult := results.collect
 	var asyncWg sync.WaitGroup
 
-	collectResult := func(checkName string, err error) {
-		if err == nil {
-			return
-		}
-		msg := fmt.Sprintf("%s: %v", checkName, err)
-		resultsMu.Lock()
-		defer resultsMu.Unlock()
-		if config.IsWarningCheck(checkName) {
-			allWarnings = append(allWarnings, msg)
-		} else {
-			allErrors = append(allErrors, msg)
-		}
-	}
-
 	// asyncCheck launches fn as a goroutine. printStart is called inside the
 	// goroutine so the start clock matches the moment work actually begins
 	// (not the dispatch order). The runner is expected to print its own
@@ -636,6 +764,12 @@ func run() error {
 		})
 	}
 
+	if config.Features.EnvAccessCheck {
+		asyncCheck("Env access check", "envAccessCheck", func() error {
+			return runEnvAccessCheck(appFiles, config.EnvAccessCheckConfig)
+		})
+	}
+
 	if config.Features.MaestroValidation {
 		asyncCheck("Maestro validation", "maestroValidation", func() error {
 			return runMaestroValidation(config.MaestroValidation)
@@ -770,6 +904,18 @@ func run() error {
 		})
 	}
 
+	if config.Features.StubSourceCheck {
+		asyncCheck("Stub sources", "stubSourceCheck", func() error {
+			return runStubSourceCheck(config.StubSourceCheckConfig, projectRoot, stagedAbs)
+		})
+	}
+
+	if config.Features.ConflictMarkers {
+		asyncCheck("Conflict markers", "conflictMarkers", func() error {
+			return runConflictMarkersCheck(config.ConflictMarkersConfig, stagedFiles)
+		})
+	}
+
 	if config.Features.RedundantCreatedAtCheck {
 		asyncCheck("Redundant createdAt", "redundantCreatedAtCheck", func() error {
 			return runRedundantCreatedAtCheck(config.RedundantCreatedAtCheckConfig, projectRoot, stagedAbs)
@@ -789,7 +935,7 @@ func run() error {
 				fmt.Println("  BUILD CHECK")
 				fmt.Println("================================")
 			}
-			err := checkBuild(config.Build, config.Apps)
+			err := checkBuild(config.Build, config.Apps, config.PackageManager)
 			if err != nil {
 				printStatus("Build check", false, "")
 			} else {
@@ -853,6 +999,11 @@ func run() error {
 	// the order checks finished.
 	asyncWg.Wait()
 
+	// customChecks: team-defined commands, run once the built-in checks
+	// are done and reported through the same severity routing.
+	runCustomChecks(config, stagedFiles, collectResult)
+	allErrors, allWarnings, failedHelp := results.errors, results.warnings, results.failedHelp
+
 	// Report warnings
 	if len(allWarnings) > 0 {
 		fmt.Println()
@@ -863,7 +1014,7 @@ func run() error {
 			fmt.Println("  WARNINGS (non-blocking)")
 			fmt.Println("================================")
 			for _, w := range allWarnings {
-				fmt.Printf("  ⚠️  %s\n", w)
+				fmt.Printf("  "+glyph.Warn+"  %s\n", w)
 			}
 			fmt.Println()
 		}
@@ -876,6 +1027,13 @@ func run() error {
 		fmt.Println("  PRE-COMMIT CHECKS FAILED")
 		fmt.Println("================================")
 		fmt.Println()
+		if len(failedHelp) > 0 {
+			fmt.Println("Help:")
+			for _, h := range failedHelp {
+				fmt.Printf("  "+glyph.Bullet+" %s\n", h)
+			}
+			fmt.Println()
+		}
 		if compactMode() {
 			suffix := ""
 			if len(allWarnings) > 0 {
@@ -892,6 +1050,11 @@ func run() error {
 		return fmt.Errorf("%d check(s) failed", len(allErrors))
 	}
 
+	// postCheck: user command run only once every check has passed.
+	if err := runPostCheck(config); err != nil {
+		return err
+	}
+
 	fmt.Println("================================")
 	fmt.Println("  ALL PRE-COMMIT CHECKS PASSED!")
 	fmt.Println("================================")
@@ -961,10 +1124,13 @@ func runStandalone() error {
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
+	if err := filterChecks(config); err != nil {
+		return err
+	}
 
 	// Register warning-only checks so printStatus downgrades their failures
 	// from ❌ to ⚠️ and matches the routing collectResult applies.
-	registerWarningChecks(config.WarningChecks)
+	registerWarningChecks(config)
 
 	// Set up report directory from config if not provided via flag
 	if reportDir == "" && config.ReportDir != "" {
@@ -1171,7 +1337,7 @@ func runSpecificCheck(name string, config *Config, files []string) error {
 	case "convexValidation":
 		return checkConvex(config.Convex)
 	case "buildCheck":
-		return checkBuild(config.Build, config.Apps)
+		return checkBuild(config.Build, config.Apps, config.PackageManager)
 	case "bundleCheck":
 		return runBundleCheck(config.BundleCheck, config.Apps, config.PackageManager)
 	case "vitestAssertions":
@@ -1219,6 +1385,11 @@ func runSpecificCheck(name string, config *Config, files []string) error {
 			}
 		}
 		return runTestSubstanceCheck(config.TestSubstanceCheckConfig, projectRoot, stagedAbs)
+	case "stubSourceCheck":
+		projectRoot, _ := os.Getwd()
+		return runStubSourceCheck(config.StubSourceCheckConfig, projectRoot, absStaged(files, projectRoot))
+	case "maxFilesCheck":
+		return runMaxFilesCheck(config.MaxFilesCheckConfig, files, allowLarge)
 	case "redundantCreatedAtCheck":
 		projectRoot, _ := os.Getwd()
 		stagedAbs := make([]string, 0, len(files))
@@ -1244,8 +1415,15 @@ func runSpecificCheck(name string, config *Config, files []string) error {
 	case "tiersGen":
 		projectRoot, _ := os.Getwd()
 		return checkTiersGen(projectRoot, files)
+	case "fileHeaderCheck":
+		projectRoot, _ := os.Getwd()
+		return runFileHeaderCheck(config.FileHeaderCheckConfig, projectRoot, fixFlag && !fixersApplied)
+	case "conflictMarkers":
+		return runConflictMarkersCheck(config.ConflictMarkersConfig, files)
 	case "dataLayerCheck":
 		return runDataLayerCheck(appFiles, config.DataLayerAllowed)
+	case "envAccessCheck":
+		return runEnvAccessCheck(appFiles, config.EnvAccessCheckConfig)
 	case "maestroValidation":
 		return runMaestroValidation(config.MaestroValidation)
 	case "nextImageCheck":
@@ -1263,20 +1441,8 @@ func runAllStandaloneChecks(config *Config, files []string) error {
 	appFiles, sharedChanged := categorizeFiles(files, config.Apps, config.SharedPaths)
 	printDetectionSummary(appFiles, sharedChanged)
 
-	var allErrors []string
-	var allWarnings []string
-
-	collectResult := func(checkName string, err error) {
-		if err == nil {
-			return
-		}
-		msg := fmt.Sprintf("%s: %v", checkName, err)
-		if config.IsWarningCheck(checkName) {
-			allWarnings = append(allWarnings, msg)
-		} else {
-			allErrors = append(allErrors, msg)
-		}
-	}
+	results := &checkResults{config: config}
+	collectResult := results.collect
 
 	// Frontend structure check
 	if config.Features.FrontendStructure {
@@ -1312,6 +1478,11 @@ func runAllStandaloneChecks(config *Config, files []string) error {
 		collectResult("dataLayerCheck", runDataLayerCheck(appFiles, config.DataLayerAllowed))
 	}
 
+	// Env access check
+	if config.Features.EnvAccessCheck {
+		collectResult("envAccessCheck", runEnvAccessCheck(appFiles, config.EnvAccessCheckConfig))
+	}
+
 	// Next.js public-asset reference check
 	if config.Features.NextImageCheck {
 		collectResult("nextImageCheck", runNextImageCheck(config.NextImageCheck, config.Apps))
@@ -1384,6 +1555,17 @@ func runAllStandaloneChecks(config *Config, files []string) error {
 		collectResult("testSubstanceCheck", runTestSubstanceCheck(config.TestSubstanceCheckConfig, projectRoot, stagedAbs))
 	}
 
+	// Stub source check (non-blocking)
+	if config.Features.StubSourceCheck {
+		projectRoot, _ := os.Getwd()
+		collectResult("stubSourceCheck", runStubSourceCheck(config.StubSourceCheckConfig, projectRoot, absStaged(files, projectRoot)))
+	}
+
+	// Conflict markers left over from a merge
+	if config.Features.ConflictMarkers {
+		collectResult("conflictMarkers", runConflictMarkersCheck(config.ConflictMarkersConfig, files))
+	}
+
 	// Redundant createdAt check — bans `createdAt:` inside Convex
 	// defineTable({...}) because Convex provides `_creationTime` for free.
 	if config.Features.RedundantCreatedAtCheck {
@@ -1432,6 +1614,10 @@ func runAllStandaloneChecks(config *Config, files []string) error {
 		}
 	}
 
+	// Custom checks run last, on the same file list
+	runCustomChecks(config, files, collectResult)
+	allErrors, allWarnings := results.errors, results.warnings
+
 	// Report warnings
 	if len(allWarnings) > 0 {
 		fmt.Println()
@@ -1439,7 +1625,7 @@ func runAllStandaloneChecks(config *Config, files []string) error {
 		fmt.Println("  WARNINGS (non-blocking)")
 		fmt.Println("================================")
 		for _, w := range allWarnings {
-			fmt.Printf("  ⚠️  %s\n", w)
+			fmt.Printf("  "+glyph.Warn+"  %s\n", w)
 		}
 	}
 
@@ -1451,7 +1637,7 @@ func runAllStandaloneChecks(config *Config, files []string) error {
 		fmt.Println()
 		fmt.Println("Errors found:")
 		for _, e := range allErrors {
-			fmt.Printf("  • %s\n", e)
+			fmt.Printf("  "+glyph.Bullet+" %s\n", e)
 		}
 		return fmt.Errorf("%d check(s) failed", len(allErrors))
 	}
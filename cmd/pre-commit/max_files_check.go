@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/milehighideas/claude-hooks/internal/glyph"
+)
+
+// defaultMaxStagedFiles is the maxFilesCheck limit when
+// maxFilesCheckConfig.maxFiles is unset.
+const defaultMaxStagedFiles = 200
+
+// allowLargeCommitEnv is the environment override for --allow-large-commit,
+// for commits made through git where flags can't be passed to the hook.
+const allowLargeCommitEnv = "PRE_COMMIT_ALLOW_LARGE_COMMIT"
+
+// maxStagedFiles returns the configured limit, falling back to the default.
+func maxStagedFiles(cfg MaxFilesCheckConfig) int {
+	if cfg.MaxFiles <= 0 {
+		return defaultMaxStagedFiles
+	}
+	return cfg.MaxFiles
+}
+
+// checkMaxFiles returns an error when staged exceeds the configured limit,
+// unless allow is set. A commit of exactly the limit passes.
+func checkMaxFiles(cfg MaxFilesCheckConfig, staged int, allow bool) error {
+	limit := maxStagedFiles(cfg)
+	if staged <= limit || allow {
+		return nil
+	}
+	return fmt.Errorf("%d files staged, over the limit of %d (maxFilesCheckConfig.maxFiles).\n"+
+		"If this is an accidental mass-add, unstage with `git reset` and add files selectively.\n"+
+		"If the large commit is intentional, rerun with --allow-large-commit or %s=1",
+		staged, limit, allowLargeCommitEnv)
+}
+
+// runMaxFilesCheck is the pre-commit entry point for maxFilesCheck.
+func runMaxFilesCheck(cfg MaxFilesCheckConfig, stagedFiles []string, allow bool) error {
+	limit := maxStagedFiles(cfg)
+	count := len(stagedFiles)
+	detail := fmt.Sprintf("%d/%d files", count, limit)
+
+	if err := checkMaxFiles(cfg, count, allow); err != nil {
+		if compactMode() {
+			printStatus("Max files", false, detail)
+		} else {
+			fmt.Printf(glyph.Fail+" %d files staged (limit %d)\n\n", count, limit)
+		}
+		return err
+	}
+
+	if count > limit {
+		detail += ", limit overridden"
+	}
+	if compactMode() {
+		printStatus("Max files", true, detail)
+	} else if count > limit {
+		fmt.Printf(glyph.Warn+"  %d files staged, over the limit of %d — allowed by --allow-large-commit\n\n", count, limit)
+	} else {
+		fmt.Printf(glyph.Pass+" %d files staged (limit %d)\n\n", count, limit)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckMaxFilesBoundary(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     MaxFilesCheckConfig
+		staged  int
+		allow   bool
+		wantErr bool
+	}{
+		{name: "under limit", cfg: MaxFilesCheckConfig{MaxFiles: 10}, staged: 9},
+		{name: "exactly at limit", cfg: MaxFilesCheckConfig{MaxFiles: 10}, staged: 10},
+		{name: "one over limit", cfg: MaxFilesCheckConfig{MaxFiles: 10}, staged: 11, wantErr: true},
+		{name: "over limit with override", cfg: MaxFilesCheckConfig{MaxFiles: 10}, staged: 11, allow: true},
+		{name: "default limit at boundary", staged: defaultMaxStagedFiles},
+		{name: "default limit exceeded", staged: defaultMaxStagedFiles + 1, wantErr: true},
+		{name: "negative limit uses default", cfg: MaxFilesCheckConfig{MaxFiles: -1}, staged: defaultMaxStagedFiles},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkMaxFiles(tt.cfg, tt.staged, tt.allow)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkMaxFiles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckMaxFilesMessage(t *testing.T) {
+	err := checkMaxFiles(MaxFilesCheckConfig{MaxFiles: 50}, 312, false)
+	if err == nil {
+		t.Fatal("checkMaxFiles() = nil, want error")
+	}
+	for _, want := range []string{"312 files staged", "limit of 50", "maxFilesCheckConfig.maxFiles", "--allow-large-commit", allowLargeCommitEnv + "=1"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q missing %q", err.Error(), want)
+		}
+	}
+}
+
+func TestRunMaxFilesCheck(t *testing.T) {
+	staged := []string{"a.ts", "b.ts", "c.ts"}
+	if err := runMaxFilesCheck(MaxFilesCheckConfig{MaxFiles: 3}, staged, false); err != nil {
+		t.Errorf("at limit: error = %v", err)
+	}
+	if err := runMaxFilesCheck(MaxFilesCheckConfig{MaxFiles: 2}, staged, false); err == nil {
+		t.Error("over limit: want error")
+	}
+	if err := runMaxFilesCheck(MaxFilesCheckConfig{MaxFiles: 2}, staged, true); err != nil {
+		t.Errorf("over limit with override: error = %v", err)
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/milehighideas/claude-hooks/internal/glyph"
 )
 
 const (
@@ -357,7 +359,7 @@ func runMissingTestsCheck(cfg MissingTestsCheckConfig, projectRoot string, stage
 		if compactMode() {
 			printStatus("Missing tests", false, err.Error())
 		} else {
-			fmt.Printf("❌ Missing tests check error: %v\n\n", err)
+			fmt.Printf(glyph.Fail+" Missing tests check error: %v\n\n", err)
 		}
 		return err
 	}
@@ -403,12 +405,12 @@ func runMissingTestsCheck(cfg MissingTestsCheckConfig, projectRoot string, stage
 	}
 
 	if count == 0 {
-		fmt.Println("✅ Missing tests check passed")
+		fmt.Println(glyph.Pass + " Missing tests check passed")
 		fmt.Println()
 		return nil
 	}
 
-	fmt.Printf("❌ Found %d source file(s) without tests:\n\n", count)
+	fmt.Printf(glyph.Fail+" Found %d source file(s) without tests:\n\n", count)
 	for _, m := range report.Missing {
 		relSrc, err := filepath.Rel(projectRoot, m.Source)
 		if err != nil {
@@ -418,7 +420,7 @@ func runMissingTestsCheck(cfg MissingTestsCheckConfig, projectRoot string, stage
 		if err != nil {
 			relExp = m.Expected
 		}
-		fmt.Printf("  • %s\n    Expected: %s\n\n", relSrc, relExp)
+		fmt.Printf("  "+glyph.Bullet+" %s\n    Expected: %s\n\n", relSrc, relExp)
 	}
 	fmt.Println("Create the test files or exclude these paths via")
 	fmt.Println("missingTestsCheckConfig.excludePaths.")
@@ -473,7 +475,7 @@ func writeMissingTestsReport(missing []missingTest, projectRoot, baseDir string)
 		var fileList strings.Builder
 		for _, e := range entries {
 			fmt.Fprintf(&fileList, "  %s\n", e.source)
-			fmt.Fprintf(&fileList, "    → expected: %s\n", e.expected)
+			fmt.Fprintf(&fileList, "    "+glyph.Arrow+" expected: %s\n", e.expected)
 		}
 		sb.WriteString(fileList.String())
 
@@ -8,6 +8,8 @@ import (
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/milehighideas/claude-hooks/internal/glyph"
 )
 
 // MockCheckConfig configures which modules should use __mocks__/ instead of inline jest.mock
@@ -37,17 +39,17 @@ func (c *MockChecker) Check(files []string, config MockCheckConfig) error {
 		fmt.Println("================================")
 		fmt.Println("  JEST MOCK CHECK")
 		fmt.Println("================================")
-		fmt.Println("🔍 Checking for inline jest.mock() that should use __mocks__/...")
+		fmt.Println(glyph.Search + " Checking for inline jest.mock() that should use __mocks__/...")
 	}
 
 	if len(config.ForbiddenMocks) == 0 {
 		if !compactMode() {
-			fmt.Println("⚠️  No forbiddenMocks configured, skipping")
+			fmt.Println(glyph.Warn + "  No forbiddenMocks configured, skipping")
 		}
 		return nil
 	}
 
-	var violations []Violation
+	var violations []MockViolation
 
 	for _, file := range files {
 		if !c.isTestFile(file) {
@@ -104,26 +106,26 @@ func (c *MockChecker) Check(files []string, config MockCheckConfig) error {
 
 	// Verbose output
 	if len(violations) > 0 {
-		fmt.Printf("\n❌ Found %d forbidden inline jest.mock() call(s):\n\n", len(violations))
+		fmt.Printf("\n"+glyph.Fail+" Found %d forbidden inline jest.mock() call(s):\n\n", len(violations))
 		for _, v := range violations {
 			fmt.Printf("  %s:\n", v.File)
 			fmt.Printf("    Line %d: jest.mock('%s', ...)\n", v.Line, v.Module)
 		}
 		fmt.Println()
-		fmt.Println("💡 These modules have __mocks__/ files and are auto-mocked via moduleNameMapper.")
+		fmt.Println(glyph.Hint + " These modules have __mocks__/ files and are auto-mocked via moduleNameMapper.")
 		fmt.Println("   Remove the inline jest.mock() and import from @/test-utils/mocks if you need")
 		fmt.Println("   to configure mock behavior.")
 		fmt.Println()
 		return fmt.Errorf("forbidden inline mocks found")
 	}
 
-	fmt.Println("✅ No forbidden inline jest.mock() calls found")
+	fmt.Println(glyph.Pass + " No forbidden inline jest.mock() calls found")
 	fmt.Println()
 	return nil
 }
 
-// Violation represents a forbidden mock found in a file
-type Violation struct {
+// MockViolation represents a forbidden mock found in a file
+type MockViolation struct {
 	File   string
 	Line   int
 	Module string
@@ -148,8 +150,8 @@ func (c *MockChecker) isAllowedFile(file string, allowedFiles []string) bool {
 }
 
 // findViolations looks for forbidden jest.mock() calls in file content
-func (c *MockChecker) findViolations(file string, content []byte, forbiddenMocks []string) []Violation {
-	var violations []Violation
+func (c *MockChecker) findViolations(file string, content []byte, forbiddenMocks []string) []MockViolation {
+	var violations []MockViolation
 	lines := strings.Split(string(content), "\n")
 
 	for lineNum, line := range lines {
@@ -163,7 +165,7 @@ func (c *MockChecker) findViolations(file string, content []byte, forbiddenMocks
 			for _, patternStr := range patterns {
 				pattern := regexp.MustCompile(patternStr)
 				if pattern.MatchString(line) {
-					violations = append(violations, Violation{
+					violations = append(violations, MockViolation{
 						File:   file,
 						Line:   lineNum + 1,
 						Module: module,
@@ -191,14 +193,14 @@ func getAppNameFromPath(filePath string) string {
 }
 
 // writeMockCheckReport writes mock check findings to per-app report files
-func writeMockCheckReport(violations []Violation, baseDir string) error {
+func writeMockCheckReport(violations []MockViolation, baseDir string) error {
 	mockDir := filepath.Join(baseDir, "mock-check")
 	if err := os.MkdirAll(mockDir, 0755); err != nil {
 		return err
 	}
 
 	// Group by app
-	byApp := make(map[string][]Violation)
+	byApp := make(map[string][]MockViolation)
 	for _, v := range violations {
 		app := getAppNameFromPath(v.File)
 		byApp[app] = append(byApp[app], v)
@@ -215,7 +217,7 @@ func writeMockCheckReport(violations []Violation, baseDir string) error {
 		fmt.Fprintf(&sb, "Total violations: %d\n\n", len(appViolations))
 
 		// Group by module within this app
-		byModule := make(map[string][]Violation)
+		byModule := make(map[string][]MockViolation)
 		for _, v := range appViolations {
 			byModule[v.Module] = append(byModule[v.Module], v)
 		}
@@ -236,7 +238,7 @@ func writeMockCheckReport(violations []Violation, baseDir string) error {
 		sb.WriteString(strings.Repeat("-", 40) + "\n\n")
 
 		// Group by file within this app
-		byFile := make(map[string][]Violation)
+		byFile := make(map[string][]MockViolation)
 		for _, v := range appViolations {
 			byFile[v.File] = append(byFile[v.File], v)
 		}
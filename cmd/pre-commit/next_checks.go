@@ -5,6 +5,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/milehighideas/claude-hooks/internal/glyph"
 	"github.com/milehighideas/claude-hooks/internal/nextchecks"
 )
 
@@ -80,13 +81,13 @@ func runNextCheck(
 		if err != nil {
 			runErr = err
 			if !compactMode() {
-				fmt.Printf("   ⚠️  %s: %v\n", r.name, err)
+				fmt.Printf("   "+glyph.Warn+"  %s: %v\n", r.name, err)
 			}
 			continue
 		}
 		if res.Skipped {
 			if !compactMode() {
-				fmt.Printf("   ⏩ %s skipped (%s)\n", r.name, res.Reason)
+				fmt.Printf("   "+glyph.Skip+" %s skipped (%s)\n", r.name, res.Reason)
 			}
 			continue
 		}
@@ -95,10 +96,10 @@ func runNextCheck(
 		}
 		if !compactMode() {
 			if len(res.Misses) == 0 {
-				fmt.Printf("   ✓ %s — %d %ss OK\n", r.name, res.Scanned, noun)
+				fmt.Printf("   "+glyph.Tick+" %s — %d %ss OK\n", r.name, res.Scanned, noun)
 			} else {
 				for _, m := range res.Misses {
-					fmt.Printf("   ❌ %s  %s  (in %s)\n", r.name, m.Ref, m.File)
+					fmt.Printf("   "+glyph.Fail+" %s  %s  (in %s)\n", r.name, m.Ref, m.File)
 				}
 			}
 		}
@@ -133,12 +134,12 @@ func runNextCheck(
 	}
 
 	if failed {
-		fmt.Printf("\n❌ Found %d unresolved %s(s)\n\n", len(misses), noun)
+		fmt.Printf("\n"+glyph.Fail+" Found %d unresolved %s(s)\n\n", len(misses), noun)
 		return fmt.Errorf("found %d unresolved %s(s)", len(misses), noun)
 	}
 	if runErr != nil {
 		return runErr
 	}
-	fmt.Printf("✅ All %ss resolve\n\n", noun)
+	fmt.Printf(glyph.Pass+" All %ss resolve\n\n", noun)
 	return nil
 }
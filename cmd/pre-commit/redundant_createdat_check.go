@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/milehighideas/claude-hooks/internal/glyph"
 	"github.com/milehighideas/claude-hooks/internal/schemachecks"
 )
 
@@ -158,7 +159,7 @@ func runRedundantCreatedAtCheck(
 		if compactMode() {
 			printStatus("Redundant createdAt", false, err.Error())
 		} else {
-			fmt.Printf("❌ Redundant createdAt check error: %v\n\n", err)
+			fmt.Printf(glyph.Fail+" Redundant createdAt check error: %v\n\n", err)
 		}
 		return err
 	}
@@ -187,18 +188,18 @@ func runRedundantCreatedAtCheck(
 	}
 
 	if count == 0 {
-		fmt.Println("✅ No redundant createdAt fields in Convex schema")
+		fmt.Println(glyph.Pass + " No redundant createdAt fields in Convex schema")
 		fmt.Println()
 		return nil
 	}
 
-	fmt.Printf("❌ Found %d schema file(s) with redundant createdAt in defineTable:\n\n", count)
+	fmt.Printf(glyph.Fail+" Found %d schema file(s) with redundant createdAt in defineTable:\n\n", count)
 	for _, p := range report.Violations {
 		rel, err := filepath.Rel(projectRoot, p)
 		if err != nil {
 			rel = p
 		}
-		fmt.Printf("  • %s\n", rel)
+		fmt.Printf("  "+glyph.Bullet+" %s\n", rel)
 	}
 	fmt.Println()
 	fmt.Println("Convex automatically maintains `_creationTime: number` on every row and")
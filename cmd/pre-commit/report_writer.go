@@ -7,6 +7,8 @@ import (
 	"regexp"
 	"strings"
 	"time"
+
+	"github.com/milehighideas/claude-hooks/internal/glyph"
 )
 
 // Report writers in this package emit up to two files per subject:
@@ -126,7 +128,7 @@ func runReportContent(title, app, output string, failed bool) (full, findings st
 		if failed {
 			fb.WriteString("(no output captured)\n")
 		} else {
-			fmt.Fprintf(&fb, "✅ %s passed\n", title)
+			fmt.Fprintf(&fb, glyph.Pass+" %s passed\n", title)
 		}
 	} else {
 		fb.WriteString(output)
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Violation is one finding in the check-independent shape the SARIF report
+// is built from. Checks convert their own violation types into it.
+type Violation struct {
+	RuleID  string // e.g. "lint/no-unused-vars", "typecheck/TS2322", "srp/multipleExports"
+	Level   string // "error", "warning" or "note"
+	File    string
+	Line    int // 1-based; 0 = file-level
+	Message string
+}
+
+// violationLog collects violations from every check of a run. Lint and
+// typecheck run their apps concurrently, so appends are locked.
+var violationLog struct {
+	sync.Mutex
+	items []Violation
+}
+
+// recordViolations adds vs to the run's violation log. It's a no-op unless
+// --sarif is set, so checks can call it unconditionally. Absolute paths are
+// made relative to the directory the checks run in, which under
+// --verify-staged is a temporary worktree rather than the repo.
+func recordViolations(vs ...Violation) {
+	if sarifPath == "" || len(vs) == 0 {
+		return
+	}
+	if wd, err := os.Getwd(); err == nil {
+		for i := range vs {
+			vs[i].File = sarifURI(vs[i].File, wd)
+		}
+	}
+	violationLog.Lock()
+	defer violationLog.Unlock()
+	violationLog.items = append(violationLog.items, vs...)
+}
+
+// recordedViolations returns a copy of the violations logged so far.
+func recordedViolations() []Violation {
+	violationLog.Lock()
+	defer violationLog.Unlock()
+	return append([]Violation(nil), violationLog.items...)
+}
+
+// SARIF 2.1.0 types — only the properties the report uses.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// sarifLevel maps a check severity onto a SARIF result level.
+func sarifLevel(level string) string {
+	switch level {
+	case "warning", "note":
+		return level
+	default:
+		return "error"
+	}
+}
+
+// sarifURI turns a violation's file into a forward-slashed URI relative to
+// root, which is how code scanning matches results to repository files.
+func sarifURI(file, root string) string {
+	if filepath.IsAbs(file) && root != "" {
+		if rel, err := filepath.Rel(root, file); err == nil && !strings.HasPrefix(rel, "..") {
+			file = rel
+		}
+	}
+	return filepath.ToSlash(file)
+}
+
+// buildSARIF builds a single-run SARIF log from violations, sorted by file,
+// line and rule so concurrent checks still produce a stable report. File
+// paths are made relative to root.
+func buildSARIF(violations []Violation, root string) sarifLog {
+	sorted := append([]Violation(nil), violations...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.RuleID < b.RuleID
+	})
+
+	var ruleIDs []string
+	seen := map[string]bool{}
+	for _, v := range sorted {
+		if !seen[v.RuleID] {
+			seen[v.RuleID] = true
+			ruleIDs = append(ruleIDs, v.RuleID)
+		}
+	}
+	sort.Strings(ruleIDs)
+	rules := make([]sarifRule, len(ruleIDs))
+	ruleIndex := make(map[string]int, len(ruleIDs))
+	for i, id := range ruleIDs {
+		rules[i] = sarifRule{ID: id}
+		ruleIndex[id] = i
+	}
+
+	results := make([]sarifResult, 0, len(sorted))
+	for _, v := range sorted {
+		r := sarifResult{
+			RuleID:    v.RuleID,
+			RuleIndex: ruleIndex[v.RuleID],
+			Level:     sarifLevel(v.Level),
+			Message:   sarifMessage{Text: v.Message},
+		}
+		if v.File != "" {
+			loc := sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: sarifURI(v.File, root)}}
+			if v.Line > 0 {
+				loc.Region = &sarifRegion{StartLine: v.Line}
+			}
+			r.Locations = []sarifLocation{{PhysicalLocation: loc}}
+		}
+		results = append(results, r)
+	}
+
+	return sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "pre-commit",
+				InformationURI: "https://github.com/milehighideas/claude-hooks",
+				Rules:          rules,
+			}},
+			Results: results,
+		}},
+	}
+}
+
+// writeSARIF writes the violations recorded during the run to path. It is
+// written even when nothing was found, so code scanning can close alerts
+// that a commit fixed.
+func writeSARIF(path string) error {
+	root, _ := os.Getwd()
+	data, err := json.MarshalIndent(buildSARIF(recordedViolations(), root), "", "  ")
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/milehighideas/claude-hooks/internal/srpnative"
+)
+
+// schemaNode is the slice of JSON Schema the SARIF fixture uses.
+type schemaNode struct {
+	Ref                  string                 `json:"$ref"`
+	Type                 string                 `json:"type"`
+	Required             []string               `json:"required"`
+	Properties           map[string]*schemaNode `json:"properties"`
+	AdditionalProperties *bool                  `json:"additionalProperties"`
+	Items                *schemaNode            `json:"items"`
+	Enum                 []any                  `json:"enum"`
+	Minimum              *float64               `json:"minimum"`
+	Definitions          map[string]*schemaNode `json:"definitions"`
+}
+
+func loadSARIFSchema(t *testing.T) *schemaNode {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "sarif-2.1.0-schema.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var root schemaNode
+	if err := json.Unmarshal(data, &root); err != nil {
+		t.Fatal(err)
+	}
+	return &root
+}
+
+// validateSchema returns one problem per place doc breaks node.
+func validateSchema(root, node *schemaNode, doc any, path string) []string {
+	if node.Ref != "" {
+		return validateSchema(root, root.Definitions[strings.TrimPrefix(node.Ref, "#/definitions/")], doc, path)
+	}
+	var problems []string
+	if len(node.Enum) > 0 {
+		found := false
+		for _, e := range node.Enum {
+			if e == doc {
+				found = true
+			}
+		}
+		if !found {
+			problems = append(problems, fmt.Sprintf("%s: %v not in %v", path, doc, node.Enum))
+		}
+	}
+	switch node.Type {
+	case "object":
+		obj, ok := doc.(map[string]any)
+		if !ok {
+			return append(problems, path+": want object")
+		}
+		for _, key := range node.Required {
+			if _, ok := obj[key]; !ok {
+				problems = append(problems, fmt.Sprintf("%s: missing required %q", path, key))
+			}
+		}
+		for key, value := range obj {
+			prop, ok := node.Properties[key]
+			if !ok {
+				if node.AdditionalProperties != nil && !*node.AdditionalProperties {
+					problems = append(problems, fmt.Sprintf("%s: unexpected property %q", path, key))
+				}
+				continue
+			}
+			problems = append(problems, validateSchema(root, prop, value, path+"."+key)...)
+		}
+	case "array":
+		arr, ok := doc.([]any)
+		if !ok {
+			return append(problems, path+": want array")
+		}
+		for i, item := range arr {
+			problems = append(problems, validateSchema(root, node.Items, item, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	case "string":
+		if _, ok := doc.(string); !ok {
+			problems = append(problems, path+": want string")
+		}
+	case "integer":
+		n, ok := doc.(float64)
+		if !ok || n != math.Trunc(n) {
+			return append(problems, path+": want integer")
+		}
+		if node.Minimum != nil && n < *node.Minimum {
+			problems = append(problems, fmt.Sprintf("%s: %v below minimum %v", path, n, *node.Minimum))
+		}
+	}
+	return problems
+}
+
+// sarifDoc marshals a SARIF log and decodes it generically for validation.
+func sarifDoc(t *testing.T, log sarifLog) any {
+	t.Helper()
+	data, err := json.Marshal(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func sampleViolations(root string) []Violation {
+	return []Violation{
+		lintError{filePath: filepath.Join(root, "apps/web/src/page.tsx"), line: "10", column: "5", severity: "warning", message: "'foo' is defined but never used", rule: "@typescript-eslint/no-unused-vars"}.violation(),
+		tsError{filePath: "apps/web/src/page.tsx", errorCode: "TS2322", fullText: "apps/web/src/page.tsx(3,7): error TS2322: Type 'string' is not assignable to type 'number'."}.violation(),
+		SRPViolation{File: "apps/web/src/screens/Home.tsx", Severity: "error", Message: "Screen has state", Suggestion: "Move state to a hook", RuleID: "stateInScreens", Line: 12}.violation(),
+		nativeSRPViolation(srpnative.Violation{File: "apps/ios/Sources/HomeView.swift", Severity: "error", Message: "File is 512 lines", Suggestion: "Split it", RuleID: "fileSize", Line: 1}),
+		ConsoleViolation{AppName: "web", File: "apps/web/src/debug.ts"}.violation(),
+		DataLayerViolation{AppName: "web", File: "apps/web/src/list.tsx", Patterns: []string{`from ["']convex/react["']`}}.violation(),
+	}
+}
+
+func TestSARIFMatchesSchema(t *testing.T) {
+	schema := loadSARIFSchema(t)
+	root := t.TempDir()
+
+	for name, violations := range map[string][]Violation{
+		"violations": sampleViolations(root),
+		"clean run":  nil,
+	} {
+		t.Run(name, func(t *testing.T) {
+			if problems := validateSchema(schema, schema, sarifDoc(t, buildSARIF(violations, root)), "$"); len(problems) > 0 {
+				t.Errorf("SARIF fails the schema:\n  %s", strings.Join(problems, "\n  "))
+			}
+		})
+	}
+}
+
+func TestSARIFSchemaRejectsMalformed(t *testing.T) {
+	schema := loadSARIFSchema(t)
+	log := buildSARIF([]Violation{{RuleID: "srp/fileSize", File: "a.ts", Line: 1, Message: "too big"}}, "")
+	log.Runs[0].Results[0].Level = "fatal"
+	log.Runs[0].Results[0].Locations[0].PhysicalLocation.Region.StartLine = 0
+
+	problems := validateSchema(schema, schema, sarifDoc(t, log), "$")
+	if len(problems) != 2 {
+		t.Errorf("problems = %q, want the bad level and startLine", problems)
+	}
+}
+
+func TestBuildSARIFResults(t *testing.T) {
+	root := t.TempDir()
+	log := buildSARIF(sampleViolations(root), root)
+	run := log.Runs[0]
+
+	if len(run.Results) != 6 {
+		t.Fatalf("got %d results, want 6", len(run.Results))
+	}
+	ids := make([]string, len(run.Tool.Driver.Rules))
+	for i, r := range run.Tool.Driver.Rules {
+		ids[i] = r.ID
+	}
+	if !sort.StringsAreSorted(ids) {
+		t.Errorf("rules not sorted: %v", ids)
+	}
+	for _, r := range run.Results {
+		if ids[r.RuleIndex] != r.RuleID {
+			t.Errorf("result %s has ruleIndex %d pointing at %s", r.RuleID, r.RuleIndex, ids[r.RuleIndex])
+		}
+	}
+
+	byRule := map[string]sarifResult{}
+	for _, r := range run.Results {
+		byRule[r.RuleID] = r
+	}
+	tests := []struct {
+		rule  string
+		level string
+		uri   string
+		line  int // 0 = no region
+	}{
+		{"lint/@typescript-eslint/no-unused-vars", "warning", "apps/web/src/page.tsx", 10},
+		{"typecheck/TS2322", "error", "apps/web/src/page.tsx", 3},
+		{"srp/stateInScreens", "error", "apps/web/src/screens/Home.tsx", 12},
+		{"srpNative/fileSize", "error", "apps/ios/Sources/HomeView.swift", 1},
+		{"consoleCheck", "error", "apps/web/src/debug.ts", 0},
+		{"dataLayerCheck", "error", "apps/web/src/list.tsx", 0},
+	}
+	for _, tt := range tests {
+		r, ok := byRule[tt.rule]
+		if !ok {
+			t.Errorf("no result for %s", tt.rule)
+			continue
+		}
+		if r.Level != tt.level {
+			t.Errorf("%s: level = %s, want %s", tt.rule, r.Level, tt.level)
+		}
+		loc := r.Locations[0].PhysicalLocation
+		if loc.ArtifactLocation.URI != tt.uri {
+			t.Errorf("%s: uri = %s, want %s", tt.rule, loc.ArtifactLocation.URI, tt.uri)
+		}
+		switch {
+		case tt.line == 0 && loc.Region != nil:
+			t.Errorf("%s: region = %+v, want none for a file-level violation", tt.rule, loc.Region)
+		case tt.line != 0 && (loc.Region == nil || loc.Region.StartLine != tt.line):
+			t.Errorf("%s: region = %+v, want startLine %d", tt.rule, loc.Region, tt.line)
+		}
+	}
+	if got := byRule["typecheck/TS2322"].Message.Text; got != "Type 'string' is not assignable to type 'number'." {
+		t.Errorf("typecheck message = %q", got)
+	}
+}
+
+func TestRecordViolationsOnlyWithSarifFlag(t *testing.T) {
+	defer func() { sarifPath = ""; violationLog.items = nil }()
+
+	recordViolations(Violation{RuleID: "consoleCheck", File: "a.ts"})
+	if got := recordedViolations(); len(got) != 0 {
+		t.Errorf("recorded %d violations without --sarif, want 0", len(got))
+	}
+
+	sarifPath = filepath.Join(t.TempDir(), "out", "pre-commit.sarif")
+	recordViolations(Violation{RuleID: "consoleCheck", File: "a.ts"})
+	if err := writeSARIF(sarifPath); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(sarifPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatal(err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 || len(log.Runs[0].Results) != 1 {
+		t.Errorf("written SARIF = %+v, want one run with one result", log)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/milehighideas/claude-hooks/internal/glyph"
 	"github.com/milehighideas/claude-hooks/internal/srp"
 )
 
@@ -19,6 +20,16 @@ type SRPViolation struct {
 	Message    string
 	Suggestion string
 	RuleID     string // e.g. "directConvexImports", "testRequired"
+	Line       int    // 1-based anchor line; 0 = file-level
+}
+
+// violation converts an SRP violation for the SARIF report.
+func (v SRPViolation) violation() Violation {
+	msg := v.Message
+	if v.Suggestion != "" {
+		msg += " " + glyph.Arrow + " " + v.Suggestion
+	}
+	return Violation{RuleID: "srp/" + v.RuleID, Level: v.Severity, File: v.File, Line: v.Line, Message: msg}
 }
 
 // SRPChecker validates Single Responsibility Principle compliance
@@ -80,6 +91,17 @@ func (c *SRPChecker) enabledRuleSet() map[string]bool {
 	return m
 }
 
+// detectorOptions builds the internal/srp options from srpConfig.
+func (c *SRPChecker) detectorOptions() srp.Options {
+	return srp.Options{
+		ScreenHooks:               c.config.resolvedScreenHooks(),
+		EnabledRules:              c.enabledRuleSet(),
+		AllowedConvexReactImports: srp.NameSet(c.config.AllowedConvexReactImports),
+		AllowedDataModelTypes:     srp.NameSet(c.config.AllowedDataModelTypes),
+		ConvexImportExemptPaths:   c.config.ConvexImportExemptPaths,
+	}
+}
+
 // CheckFiles validates SRP compliance for TypeScript files. Structural
 // detection is delegated to internal/srp (tree-sitter AST, shared with the
 // standalone validate-srp binary); this method owns file selection, severity
@@ -87,10 +109,7 @@ func (c *SRPChecker) enabledRuleSet() map[string]bool {
 func (c *SRPChecker) CheckFiles(files []string) ([]SRPViolation, error) {
 	var allViolations []SRPViolation
 
-	opts := srp.Options{
-		ScreenHooks:  c.config.resolvedScreenHooks(),
-		EnabledRules: c.enabledRuleSet(),
-	}
+	opts := c.detectorOptions()
 
 	for _, file := range files {
 		if !c.isTypeScriptFile(file) {
@@ -433,13 +452,13 @@ func writeSRPReport(errors, warnings []SRPViolation, baseDir string) error {
 		for file, violations := range allByFile {
 			fmt.Fprintf(&sb, "\n%s (%d issues)\n", file, len(violations))
 			for _, v := range violations {
-				prefix := "❌"
+				prefix := glyph.Fail
 				if v.Severity == "warning" {
-					prefix = "⚠️"
+					prefix = glyph.Warn
 				}
 				fmt.Fprintf(&sb, "  %s %s\n", prefix, v.Message)
 				if v.Suggestion != "" {
-					fmt.Fprintf(&sb, "     → %s\n", v.Suggestion)
+					fmt.Fprintf(&sb, "     "+glyph.Arrow+" %s\n", v.Suggestion)
 				}
 			}
 		}
@@ -455,9 +474,9 @@ func writeSRPReport(errors, warnings []SRPViolation, baseDir string) error {
 			for file, errs := range errByFile {
 				fmt.Fprintf(&findingsBody, "\n%s (%d errors)\n", file, len(errs))
 				for _, v := range errs {
-					fmt.Fprintf(&findingsBody, "  ❌ %s\n", v.Message)
+					fmt.Fprintf(&findingsBody, "  "+glyph.Fail+" %s\n", v.Message)
 					if v.Suggestion != "" {
-						fmt.Fprintf(&findingsBody, "     → %s\n", v.Suggestion)
+						fmt.Fprintf(&findingsBody, "     "+glyph.Arrow+" %s\n", v.Suggestion)
 					}
 				}
 			}
@@ -484,23 +503,23 @@ func runSRPCheckWithFilter(filterResult SRPFilterResult, config SRPConfig, fullM
 		// Print filter information if files were skipped
 		totalSkipped := filterResult.SkippedByAppPath + filterResult.SkippedByExclude
 		if totalSkipped > 0 || len(config.AppPaths) > 0 {
-			fmt.Printf("ℹ️  Checking SRP in: %v\n", config.AppPaths)
+			fmt.Printf(glyph.Info+"  Checking SRP in: %v\n", config.AppPaths)
 
 			if filterResult.SkippedByAppPath > 0 {
-				fmt.Printf("   • %d file(s) outside these paths were skipped:\n", filterResult.SkippedByAppPath)
+				fmt.Printf("   "+glyph.Bullet+" %d file(s) outside these paths were skipped:\n", filterResult.SkippedByAppPath)
 				for skippedPath, count := range filterResult.SkippedPaths {
 					fmt.Printf("     - %s (%d files)\n", skippedPath, count)
 				}
 			}
 
 			if filterResult.SkippedByExclude > 0 {
-				fmt.Printf("   • %d file(s) excluded by excludePaths:\n", filterResult.SkippedByExclude)
+				fmt.Printf("   "+glyph.Bullet+" %d file(s) excluded by excludePaths:\n", filterResult.SkippedByExclude)
 				for excludePath, count := range filterResult.ExcludeMatches {
 					fmt.Printf("     - %q matched %d file(s)\n", excludePath, count)
 				}
 			}
 
-			fmt.Printf("   • %d file(s) will be checked\n", len(filterResult.Files))
+			fmt.Printf("   "+glyph.Bullet+" %d file(s) will be checked\n", len(filterResult.Files))
 			fmt.Println()
 		}
 	}
@@ -509,7 +528,7 @@ func runSRPCheckWithFilter(filterResult SRPFilterResult, config SRPConfig, fullM
 		if compactMode() {
 			printStatus("SRP compliance", true, "no files")
 		} else {
-			fmt.Println("✅ SRP check passed (no files to check after filtering)")
+			fmt.Println(glyph.Pass + " SRP check passed (no files to check after filtering)")
 			fmt.Println()
 		}
 		return nil
@@ -518,7 +537,7 @@ func runSRPCheckWithFilter(filterResult SRPFilterResult, config SRPConfig, fullM
 	var checker *SRPChecker
 	if fullMode {
 		if !compactMode() {
-			fmt.Println("🔍 Running FULL SRP check (all files in configured paths)")
+			fmt.Println(glyph.Search + " Running FULL SRP check (all files in configured paths)")
 		}
 		checker = NewSRPCheckerFullMode(config)
 	} else {
@@ -533,6 +552,7 @@ func runSRPCheckWithFilter(filterResult SRPFilterResult, config SRPConfig, fullM
 
 	var errors, warnings []SRPViolation
 	for _, v := range violations {
+		recordViolations(v.violation())
 		if v.Severity == "error" {
 			errors = append(errors, v)
 		} else {
@@ -583,31 +603,31 @@ func runSRPCheckWithFilter(filterResult SRPFilterResult, config SRPConfig, fullM
 	// Verbose output: print individual violations
 	if !config.HideWarnings {
 		for _, v := range warnings {
-			fmt.Printf("⚠️  %s: %s\n", filepath.Base(v.File), v.Message)
+			fmt.Printf(glyph.Warn+"  %s: %s\n", filepath.Base(v.File), v.Message)
 			if v.Suggestion != "" {
-				fmt.Printf("   → %s\n", v.Suggestion)
+				fmt.Printf("   "+glyph.Arrow+" %s\n", v.Suggestion)
 			}
 		}
 	}
 
 	for _, v := range errors {
-		fmt.Printf("❌ %s: %s\n", v.File, v.Message)
+		fmt.Printf(glyph.Fail+" %s: %s\n", v.File, v.Message)
 		if v.Suggestion != "" {
 			fmt.Printf("   FIX: %s\n", v.Suggestion)
 		}
 	}
 
 	if len(errors) > 0 {
-		fmt.Printf("\n❌ Found %d SRP violation(s)\n", len(errors))
+		fmt.Printf("\n"+glyph.Fail+" Found %d SRP violation(s)\n", len(errors))
 		fmt.Println()
 		return fmt.Errorf("SRP violations found")
 	}
 
 	if len(warnings) > 0 && !config.HideWarnings {
-		fmt.Printf("\n⚠️  %d warning(s) - consider fixing\n", len(warnings))
+		fmt.Printf("\n"+glyph.Warn+"  %d warning(s) - consider fixing\n", len(warnings))
 	}
 
-	fmt.Println("✅ SRP check passed")
+	fmt.Println(glyph.Pass + " SRP check passed")
 	fmt.Println()
 	return nil
 }
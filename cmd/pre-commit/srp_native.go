@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/milehighideas/claude-hooks/internal/glyph"
 	"github.com/milehighideas/claude-hooks/internal/srpnative"
 )
 
@@ -155,6 +156,10 @@ func runSRPNativeCheck(files []string, cfg SRPNativeConfig) error {
 		violations = append(violations, srpnative.RunDetectors(a, file, opts)...)
 	}
 
+	for _, v := range violations {
+		recordViolations(nativeSRPViolation(v))
+	}
+
 	if reportDir != "" && len(violations) > 0 {
 		if err := writeSRPNativeReport(violations, reportDir); err != nil {
 			fmt.Printf("   Warning: failed to write SRP native report: %v\n", err)
@@ -188,19 +193,28 @@ func runSRPNativeCheck(files []string, cfg SRPNativeConfig) error {
 	}
 
 	for _, v := range violations {
-		fmt.Printf("❌ %s: %s\n", v.File, v.Message)
+		fmt.Printf(glyph.Fail+" %s: %s\n", v.File, v.Message)
 		if v.Suggestion != "" {
 			fmt.Printf("   FIX: %s\n", v.Suggestion)
 		}
 	}
 	if len(violations) > 0 {
-		fmt.Printf("\n❌ Found %d native SRP violation(s)\n\n", len(violations))
+		fmt.Printf("\n"+glyph.Fail+" Found %d native SRP violation(s)\n\n", len(violations))
 		return fmt.Errorf("native SRP violations found")
 	}
-	fmt.Println("✅ SRP native check passed")
+	fmt.Println(glyph.Pass + " SRP native check passed")
 	return nil
 }
 
+// nativeSRPViolation converts a native SRP violation for the SARIF report.
+func nativeSRPViolation(v srpnative.Violation) Violation {
+	msg := v.Message
+	if v.Suggestion != "" {
+		msg += " " + glyph.Arrow + " " + v.Suggestion
+	}
+	return Violation{RuleID: "srpNative/" + v.RuleID, Level: v.Severity, File: v.File, Line: v.Line, Message: msg}
+}
+
 // writeSRPNativeReport writes native SRP findings grouped by app, mirroring
 // writeSRPReport's layout under reportDir/srpNative/<app>.txt.
 func writeSRPNativeReport(violations []srpnative.Violation, baseDir string) error {
@@ -235,9 +249,9 @@ func writeSRPNativeReport(violations []srpnative.Violation, baseDir string) erro
 		for _, f := range files {
 			fmt.Fprintf(&sb, "\n%s (%d issues)\n", f, len(byFile[f]))
 			for _, v := range byFile[f] {
-				fmt.Fprintf(&sb, "  ❌ [%s] %s\n", v.RuleID, v.Message)
+				fmt.Fprintf(&sb, "  "+glyph.Fail+" [%s] %s\n", v.RuleID, v.Message)
 				if v.Suggestion != "" {
-					fmt.Fprintf(&sb, "     → %s\n", v.Suggestion)
+					fmt.Fprintf(&sb, "     "+glyph.Arrow+" %s\n", v.Suggestion)
 				}
 			}
 		}
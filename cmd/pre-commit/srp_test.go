@@ -20,9 +20,8 @@ func (c *SRPChecker) analyzeCode(code, filePath string) *srp.Analysis {
 }
 
 func (c *SRPChecker) validateSRPCompliance(a *srp.Analysis, filePath string) []SRPViolation {
-	opts := srp.Options{ScreenHooks: c.config.resolvedScreenHooks(), EnabledRules: c.enabledRuleSet()}
 	var out []SRPViolation
-	for _, v := range srp.RunDetectors(a, filePath, opts) {
+	for _, v := range srp.RunDetectors(a, filePath, c.detectorOptions()) {
 		out = append(out, c.resolveSeverity(SRPViolation(v)))
 	}
 	return out
@@ -1216,3 +1215,44 @@ func TestHasTestFile_TestDirs(t *testing.T) {
 		t.Error("expected hasTestFile=false for nonexistent.tsx")
 	}
 }
+
+func TestAllowedConvexImportsConfig(t *testing.T) {
+	code := `import { ConvexReactClient } from "convex/react";`
+	filePath := "apps/web/components/client.tsx"
+
+	tests := []struct {
+		name       string
+		config     SRPConfig
+		wantErrors int
+	}{
+		{name: "default allowlist flags ConvexReactClient", config: SRPConfig{}, wantErrors: 1},
+		{
+			name: "configured extra import is allowed",
+			config: SRPConfig{AllowedConvexReactImports: []string{
+				"Preloaded", "usePreloadedQuery", "ConvexReactClient",
+			}},
+			wantErrors: 0,
+		},
+		{
+			name:       "configured exempt path skips the file",
+			config:     SRPConfig{ConvexImportExemptPaths: []string{"/components/"}},
+			wantErrors: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := &SRPChecker{config: tt.config}
+			violations := checker.validateSRPCompliance(checker.analyzeCode(code, filePath), filePath)
+			got := 0
+			for _, v := range violations {
+				if v.RuleID == "directConvexImports" {
+					got++
+				}
+			}
+			if got != tt.wantErrors {
+				t.Errorf("got %d directConvexImports violations, want %d: %+v", got, tt.wantErrors, violations)
+			}
+		})
+	}
+}
@@ -6,6 +6,10 @@ import (
 	"strings"
 )
 
+// stagedFilesSource supplies run() with the staged files. The daemon swaps
+// it for the list a --client sent.
+var stagedFilesSource = getStagedFiles
+
 // getStagedFiles returns a list of staged files from git
 // It only returns files that are Added, Copied, Modified, or Renamed
 // Paths are relative to the current working directory
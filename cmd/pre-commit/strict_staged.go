@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/milehighideas/claude-hooks/internal/glyph"
+)
+
+// stagedStash holds the unstaged changes --strict-staged set aside while the
+// checks run. The changes live in a binary patch under the git directory
+// rather than the stash list, so a crash leaves them in a known file instead
+// of an anonymous stash entry.
+type stagedStash struct {
+	root  string // repo toplevel; git commands run here
+	patch string // saved patch; empty when the worktree matched the index
+	once  sync.Once
+	err   error
+}
+
+// gitIn runs git in dir and returns its stdout.
+func gitIn(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return out, fmt.Errorf("git %s: %v: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// stashUnstaged saves the worktree's unstaged changes to a patch and resets
+// tracked files to their staged content. Untracked files are left alone:
+// they are not part of the commit and checks read staged paths only.
+func stashUnstaged() (*stagedStash, error) {
+	out, err := gitIn(".", "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	root := strings.TrimSpace(string(out))
+	s := &stagedStash{root: root}
+
+	diff, err := gitIn(root, "diff", "--binary", "--no-color", "--no-ext-diff", "--ignore-submodules")
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(diff)) == 0 {
+		return s, nil
+	}
+
+	out, err = gitIn(root, "rev-parse", "--git-dir")
+	if err != nil {
+		return nil, err
+	}
+	gitDir := strings.TrimSpace(string(out))
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(root, gitDir)
+	}
+	s.patch = filepath.Join(gitDir, fmt.Sprintf("pre-commit-unstaged-%d.patch", time.Now().UnixNano()))
+	if err := os.WriteFile(s.patch, diff, 0600); err != nil {
+		return nil, fmt.Errorf("saving unstaged changes: %w", err)
+	}
+
+	if _, err := gitIn(root, "checkout", "--", "."); err != nil {
+		// Put back whatever the checkout managed to reset before giving up.
+		if restoreErr := s.restore(); restoreErr != nil {
+			return nil, fmt.Errorf("%v (and %v)", err, restoreErr)
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+// restore re-applies the saved patch. If the checks modified the same lines
+// (an auto-fixer, say), their worktree edits are rolled back and the patch is
+// applied again. When even that fails the patch file is kept and named in the
+// error so no work is lost. Safe to call more than once.
+func (s *stagedStash) restore() error {
+	s.once.Do(func() {
+		if s.patch == "" {
+			return
+		}
+		if _, err := gitIn(s.root, "apply", "--whitespace=nowarn", s.patch); err == nil {
+			_ = os.Remove(s.patch)
+			return
+		}
+
+		fmt.Fprintln(os.Stderr, glyph.Warn+"  Unstaged changes conflicted with edits made by the checks; rolling those edits back")
+		if _, err := gitIn(s.root, "checkout", "--", "."); err == nil {
+			if _, err := gitIn(s.root, "apply", "--whitespace=nowarn", s.patch); err == nil {
+				_ = os.Remove(s.patch)
+				return
+			}
+		}
+		s.err = fmt.Errorf("could not restore unstaged changes; they are saved in %s (re-apply with: git apply --3way %s)", s.patch, s.patch)
+	})
+	return s.err
+}
+
+// withStagedContent runs fn with unstaged changes set aside, so every check
+// sees exactly the content being committed. The changes are restored when fn
+// returns, fails, or panics, and on SIGINT/SIGTERM.
+func withStagedContent(fn func() error) (err error) {
+	stash, err := stashUnstaged()
+	if err != nil {
+		return fmt.Errorf("--strict-staged: %w", err)
+	}
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-sigs:
+			if restoreErr := stash.restore(); restoreErr != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", restoreErr)
+			}
+			os.Exit(130)
+		case <-done:
+		}
+	}()
+
+	defer func() {
+		signal.Stop(sigs)
+		close(done)
+		if restoreErr := stash.restore(); restoreErr != nil {
+			if err == nil {
+				err = restoreErr
+			} else {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", restoreErr)
+			}
+		}
+	}()
+
+	if stash.patch != "" && !compactMode() {
+		fmt.Println(glyph.Lock + " --strict-staged: unstaged changes set aside; checking staged content only")
+		fmt.Println()
+	}
+	return fn()
+}
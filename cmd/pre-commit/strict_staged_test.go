@@ -0,0 +1,113 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setupDivergentRepo creates a repo where app.ts has "staged" in the index and
+// "working" in the worktree, plus an untracked notes.txt, and chdirs into it.
+func setupDivergentRepo(t *testing.T) string {
+	t.Helper()
+	dir := setupFileHeaderRepo(t, map[string]string{"app.ts": "export const v = 'staged';\n"})
+	if err := os.WriteFile(filepath.Join(dir, "app.ts"), []byte("export const v = 'working';\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("untracked\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// assertRestored checks the worktree and index are back to their divergent
+// state and no patch file is left behind.
+func assertRestored(t *testing.T, dir string) {
+	t.Helper()
+	if got := readFile(t, filepath.Join(dir, "app.ts")); !strings.Contains(got, "working") {
+		t.Errorf("worktree app.ts = %q, want the unstaged 'working' content back", got)
+	}
+	staged, err := exec.Command("git", "show", ":app.ts").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(staged), "staged") {
+		t.Errorf("index app.ts = %q, want the staged content untouched", staged)
+	}
+	if got := readFile(t, filepath.Join(dir, "notes.txt")); got != "untracked\n" {
+		t.Errorf("untracked notes.txt = %q", got)
+	}
+	patches, _ := filepath.Glob(filepath.Join(dir, ".git", "pre-commit-unstaged-*.patch"))
+	if len(patches) != 0 {
+		t.Errorf("patch files left behind: %v", patches)
+	}
+}
+
+func TestWithStagedContentSeesStagedContent(t *testing.T) {
+	dir := setupDivergentRepo(t)
+
+	var seen string
+	err := withStagedContent(func() error {
+		seen = readFile(t, filepath.Join(dir, "app.ts"))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("withStagedContent() error = %v", err)
+	}
+	if !strings.Contains(seen, "staged") {
+		t.Errorf("checks saw %q, want the staged content", seen)
+	}
+	assertRestored(t, dir)
+}
+
+func TestWithStagedContentRestoresOnFailure(t *testing.T) {
+	dir := setupDivergentRepo(t)
+
+	checkErr := errors.New("lint failed")
+	if err := withStagedContent(func() error { return checkErr }); !errors.Is(err, checkErr) {
+		t.Errorf("withStagedContent() error = %v, want the check's error", err)
+	}
+	assertRestored(t, dir)
+}
+
+func TestWithStagedContentRestoresOnPanic(t *testing.T) {
+	dir := setupDivergentRepo(t)
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected the panic to propagate")
+			}
+		}()
+		_ = withStagedContent(func() error { panic("boom") })
+	}()
+	assertRestored(t, dir)
+}
+
+func TestWithStagedContentRollsBackConflictingEdits(t *testing.T) {
+	dir := setupDivergentRepo(t)
+
+	// A check rewrites the line the unstaged change also touches.
+	err := withStagedContent(func() error {
+		return os.WriteFile(filepath.Join(dir, "app.ts"), []byte("export const v = 'fixed';\n"), 0644)
+	})
+	if err != nil {
+		t.Fatalf("withStagedContent() error = %v", err)
+	}
+	assertRestored(t, dir)
+}
+
+func TestWithStagedContentCleanWorktree(t *testing.T) {
+	dir := setupFileHeaderRepo(t, map[string]string{"app.ts": "export {};\n"})
+
+	ran := false
+	if err := withStagedContent(func() error { ran = true; return nil }); err != nil || !ran {
+		t.Fatalf("withStagedContent() = %v, ran = %v", err, ran)
+	}
+	if got := readFile(t, filepath.Join(dir, "app.ts")); got != "export {};\n" {
+		t.Errorf("app.ts = %q", got)
+	}
+}
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/milehighideas/claude-hooks/internal/glyph"
+)
+
+// defaultStubSourcePatterns flag placeholder implementations when
+// stubSourceCheckConfig.patterns is empty: a thrown "not implemented" /
+// "todo" error.
+var defaultStubSourcePatterns = []string{
+	`throw\s+new\s+Error\(\s*["'` + "`" + `](?i:not\s+(?:yet\s+)?implemented|unimplemented|todo)[^"'` + "`" + `]*["'` + "`" + `]\s*\)`,
+}
+
+// functionBodyStartRe matches the opening brace of a function, method or
+// arrow body: `) {`, `): Promise<void> {`, `=> {`. Control-flow blocks
+// (`if (x) {`) match too, which is harmless: they only ever sit inside a
+// body that is itself inspected.
+var functionBodyStartRe = regexp.MustCompile(`(?:=>|\))\s*(?::\s*[^{};=]+?)?\s*\{`)
+
+// blockCommentRe and lineCommentRe strip comments before matching so a
+// commented-out throw or a "TODO" note next to real code is not a stub.
+// The line-comment form skips "://" to leave URLs in strings alone.
+var (
+	blockCommentRe = regexp.MustCompile(`(?s)/\*.*?\*/`)
+	lineCommentRe  = regexp.MustCompile(`(?m)(^|[^:])//.*$`)
+)
+
+// stubSourceFinding is one staged source file that has a test but whose
+// implementation looks like a placeholder.
+type stubSourceFinding struct {
+	Source string // absolute path
+	Test   string // absolute path
+	Marker string // matched pattern text or structural description
+}
+
+// stubSourceReport is the result of a stub-source scan.
+type stubSourceReport struct {
+	Findings []stubSourceFinding
+}
+
+// detectStubSource returns the stub marker found in content, or "" when the
+// file looks like a real implementation. A file is a stub when a configured
+// pattern matches, or when it declares functions and every body is empty or
+// only returns undefined.
+func detectStubSource(content string, patterns []*regexp.Regexp) string {
+	code := lineCommentRe.ReplaceAllString(blockCommentRe.ReplaceAllString(content, ""), "$1")
+
+	for _, re := range patterns {
+		if m := re.FindString(code); m != "" {
+			return strings.Join(strings.Fields(m), " ")
+		}
+	}
+
+	bodies := 0
+	for _, loc := range functionBodyStartRe.FindAllStringIndex(code, -1) {
+		body, ok := braceBody(code, loc[1]-1)
+		if !ok {
+			continue
+		}
+		bodies++
+		if !isStubBody(body) {
+			return ""
+		}
+	}
+	if bodies == 0 {
+		return ""
+	}
+	return fmt.Sprintf("all %d function bodies are empty or return undefined", bodies)
+}
+
+// braceBody returns the text between the brace at open and its matching
+// close brace. Braces inside string and template literals are ignored.
+func braceBody(code string, open int) (string, bool) {
+	depth := 0
+	var quote byte
+	for i := open; i < len(code); i++ {
+		c := code[i]
+		if quote != 0 {
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case '"', '\'', '`':
+			quote = c
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return code[open+1 : i], true
+			}
+		}
+	}
+	return "", false
+}
+
+// isStubBody reports whether a function body does nothing: empty, a bare
+// `return`, or `return undefined`.
+func isStubBody(body string) bool {
+	s := strings.TrimSpace(strings.TrimRight(strings.TrimSpace(body), ";"))
+	switch s {
+	case "", "return", "return undefined", "return void 0":
+		return true
+	}
+	return false
+}
+
+// compileStubSourcePatterns compiles cfg.Patterns, falling back to the
+// defaults when none are configured.
+func compileStubSourcePatterns(cfg StubSourceCheckConfig) ([]*regexp.Regexp, error) {
+	raw := cfg.Patterns
+	if len(raw) == 0 {
+		raw = defaultStubSourcePatterns
+	}
+	patterns := make([]*regexp.Regexp, 0, len(raw))
+	for _, p := range raw {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("stubSourceCheckConfig.patterns: invalid pattern %q: %w", p, err)
+		}
+		patterns = append(patterns, re)
+	}
+	return patterns, nil
+}
+
+// collectStubSourceReport inspects each staged source file that has a
+// co-located test and reports the ones that look like stubs. Files without
+// a test are left to missingTestsCheck.
+func collectStubSourceReport(cfg StubSourceCheckConfig, projectRoot string, stagedFiles []string) (*stubSourceReport, error) {
+	patterns, err := compileStubSourcePatterns(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &stubSourceReport{}
+	for _, f := range stagedFiles {
+		if !needsTest(f) || !stubSourceInScope(cfg, projectRoot, f) {
+			continue
+		}
+		testAbs, ok := resolveSiblingTest(f)
+		if !ok {
+			continue
+		}
+		content, err := os.ReadFile(f)
+		if err != nil {
+			continue
+		}
+		if marker := detectStubSource(string(content), patterns); marker != "" {
+			report.Findings = append(report.Findings, stubSourceFinding{Source: f, Test: testAbs, Marker: marker})
+		}
+	}
+
+	sort.Slice(report.Findings, func(i, j int) bool {
+		return report.Findings[i].Source < report.Findings[j].Source
+	})
+	return report, nil
+}
+
+// stubSourceInScope returns true when path falls inside the configured
+// AppPaths (or none are set) and isn't in ExcludePaths. Substring matching
+// on the project-relative path, like the other scoped checks.
+func stubSourceInScope(cfg StubSourceCheckConfig, projectRoot, path string) bool {
+	rel := path
+	if r, err := filepath.Rel(projectRoot, path); err == nil {
+		rel = r
+	}
+	rel = filepath.ToSlash(rel)
+
+	for _, ex := range cfg.ExcludePaths {
+		if ex != "" && strings.Contains(rel, ex) {
+			return false
+		}
+	}
+	if len(cfg.AppPaths) == 0 {
+		return true
+	}
+	for _, ap := range cfg.AppPaths {
+		if ap != "" && strings.Contains(rel, ap) {
+			return true
+		}
+	}
+	return false
+}
+
+// runStubSourceCheck is the pre-commit entry point. The detection is a
+// heuristic, so the check is always non-blocking: findings are returned as
+// an error that collectResult routes to the warnings list (see
+// nonBlockingChecks).
+func runStubSourceCheck(cfg StubSourceCheckConfig, projectRoot string, stagedFiles []string) error {
+	if !compactMode() {
+		fmt.Println("================================")
+		fmt.Println("  STUB SOURCE CHECK")
+		fmt.Println("================================")
+	}
+
+	report, err := collectStubSourceReport(cfg, projectRoot, stagedFiles)
+	if err != nil {
+		if compactMode() {
+			printStatus("Stub sources", false, err.Error())
+		} else {
+			fmt.Printf(glyph.Warn+"  Stub source check error: %v\n\n", err)
+		}
+		return err
+	}
+
+	count := len(report.Findings)
+	var listing strings.Builder
+	for _, f := range report.Findings {
+		rel, err := filepath.Rel(projectRoot, f.Source)
+		if err != nil {
+			rel = f.Source
+		}
+		fmt.Fprintf(&listing, "  "+glyph.Bullet+" %s: %s\n", rel, f.Marker)
+	}
+	if reportDir != "" {
+		_ = writeRunReport("stub-sources", "Stub sources", listing.String(), count > 0)
+	}
+
+	if compactMode() {
+		if count > 0 {
+			printStatus("Stub sources", false, fmt.Sprintf("%d file(s)", count))
+			printReportHint("stub-sources/")
+			return fmt.Errorf("%d tested source file(s) look like stubs", count)
+		}
+		printStatus("Stub sources", true, "")
+		return nil
+	}
+
+	if count == 0 {
+		fmt.Println(glyph.Pass + " No stub implementations in tested source files")
+		fmt.Println()
+		return nil
+	}
+
+	fmt.Printf(glyph.Warn+"  %d staged source file(s) have tests but look like stubs:\n\n", count)
+	fmt.Print(listing.String())
+	fmt.Println()
+	fmt.Println("Their tests exist, so enforcement passes, but the implementation does")
+	fmt.Println("nothing yet. This is a heuristic warning and does not block the commit.")
+	fmt.Println()
+	return fmt.Errorf("%d tested source file(s) look like stubs", count)
+}
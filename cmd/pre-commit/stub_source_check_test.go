@@ -0,0 +1,152 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDetectStubSource(t *testing.T) {
+	patterns, err := compileStubSourcePatterns(StubSourceCheckConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		content    string
+		wantMarker string // substring; "" means not a stub
+	}{
+		{
+			name: "throws not implemented",
+			content: `export function total(items: Item[]): number {
+  throw new Error("Not implemented");
+}`,
+			wantMarker: `throw new Error("Not implemented")`,
+		},
+		{
+			name:       "throws todo in template literal",
+			content:    "export const load = async () => {\n  throw new Error(`TODO: wire up api`);\n};\n",
+			wantMarker: "TODO: wire up api",
+		},
+		{
+			name: "every body returns undefined",
+			content: `export function parse(input: string): Result | undefined {
+  return undefined;
+}
+
+export const format = (r: Result): void => {};
+`,
+			wantMarker: "all 2 function bodies",
+		},
+		{
+			name: "real implementation",
+			content: `export function total(items: Item[]): number {
+  return items.reduce((sum, i) => sum + i.price, 0);
+}`,
+		},
+		{
+			name: "guard throw next to real code",
+			content: `export function divide(a: number, b: number): number {
+  if (b === 0) {
+    throw new Error("division by zero");
+  }
+  return a / b;
+}`,
+		},
+		{
+			name: "empty catch inside a real function",
+			content: `export function safeParse(s: string) {
+  try {
+    return JSON.parse(s);
+  } catch (e) {}
+}`,
+		},
+		{
+			name: "commented-out stub marker",
+			content: `// throw new Error("not implemented");
+export const double = (n: number) => {
+  return n * 2;
+};`,
+		},
+		{
+			name:    "no functions",
+			content: `export const LIMIT = 10;`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := detectStubSource(tt.content, patterns)
+			if tt.wantMarker == "" {
+				if got != "" {
+					t.Errorf("detectStubSource() = %q, want no stub", got)
+				}
+				return
+			}
+			if !strings.Contains(got, tt.wantMarker) {
+				t.Errorf("detectStubSource() = %q, want marker containing %q", got, tt.wantMarker)
+			}
+		})
+	}
+}
+
+func TestCollectStubSourceReport(t *testing.T) {
+	root := t.TempDir()
+	write := func(rel, content string) string {
+		t.Helper()
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	stub := write("apps/web/src/cart.ts", "export function total() {\n  throw new Error('not implemented');\n}\n")
+	write("apps/web/src/cart.test.ts", "it('totals', () => { expect(total()).toBe(3); });\n")
+	impl := write("apps/web/src/price.ts", "export function price(n: number) {\n  return n * 2;\n}\n")
+	write("apps/web/src/price.test.ts", "it('doubles', () => { expect(price(1)).toBe(2); });\n")
+	untested := write("apps/web/src/draft.ts", "export function draft() {\n  throw new Error('not implemented');\n}\n")
+	custom := write("apps/web/src/sync.ts", "export function sync() {\n  notYet();\n}\n")
+	write("apps/web/src/sync.test.ts", "it('syncs', () => { expect(sync()).toBe(1); });\n")
+
+	staged := []string{stub, impl, untested, custom}
+
+	report, err := collectStubSourceReport(StubSourceCheckConfig{}, root, staged)
+	if err != nil {
+		t.Fatalf("collectStubSourceReport() error = %v", err)
+	}
+	if len(report.Findings) != 1 || report.Findings[0].Source != stub {
+		t.Fatalf("findings = %+v, want only cart.ts (real code and untested files are skipped)", report.Findings)
+	}
+	if !strings.Contains(report.Findings[0].Marker, "not implemented") {
+		t.Errorf("marker = %q", report.Findings[0].Marker)
+	}
+
+	// Configured patterns replace the defaults.
+	report, err = collectStubSourceReport(StubSourceCheckConfig{Patterns: []string{`notYet\(\)`}}, root, staged)
+	if err != nil {
+		t.Fatalf("collectStubSourceReport() error = %v", err)
+	}
+	if len(report.Findings) != 1 || report.Findings[0].Source != custom || report.Findings[0].Marker != "notYet()" {
+		t.Errorf("custom pattern findings = %+v, want sync.ts with marker notYet()", report.Findings)
+	}
+
+	if _, err := collectStubSourceReport(StubSourceCheckConfig{Patterns: []string{"("}}, root, staged); err == nil {
+		t.Error("invalid pattern: want an error")
+	}
+}
+
+func TestStubSourceCheckIsNonBlocking(t *testing.T) {
+	cfg := &Config{}
+	if !cfg.IsWarningCheck("stubSourceCheck") {
+		t.Error("stubSourceCheck must always route to warnings")
+	}
+	if cfg.IsWarningCheck("stubTestCheck") {
+		t.Error("stubTestCheck must still block unless listed in warningChecks")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/milehighideas/claude-hooks/internal/glyph"
 	"github.com/milehighideas/claude-hooks/internal/stubs"
 )
 
@@ -230,7 +231,7 @@ func runStubTestCheck(cfg StubTestCheckConfig, projectRoot string, stagedFiles [
 		if compactMode() {
 			printStatus("Stub tests", false, err.Error())
 		} else {
-			fmt.Printf("❌ Stub test check error: %v\n\n", err)
+			fmt.Printf(glyph.Fail+" Stub test check error: %v\n\n", err)
 		}
 		return err
 	}
@@ -276,18 +277,18 @@ func runStubTestCheck(cfg StubTestCheckConfig, projectRoot string, stagedFiles [
 	}
 
 	if count == 0 {
-		fmt.Println("✅ Stub test check passed")
+		fmt.Println(glyph.Pass + " Stub test check passed")
 		fmt.Println()
 		return nil
 	}
 
-	fmt.Printf("❌ Found %d stub test file(s):\n\n", count)
+	fmt.Printf(glyph.Fail+" Found %d stub test file(s):\n\n", count)
 	for _, s := range report.Stubs {
 		rel, err := filepath.Rel(projectRoot, s)
 		if err != nil {
 			rel = s
 		}
-		fmt.Printf("  • %s\n", rel)
+		fmt.Printf("  "+glyph.Bullet+" %s\n", rel)
 	}
 	fmt.Println()
 	fmt.Println("Stub tests contain only placeholder assertions (expect(true).toBe(true))")
@@ -7,6 +7,8 @@ import (
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/milehighideas/claude-hooks/internal/glyph"
 )
 
 // TestCoverageViolation represents a source file missing its test file
@@ -159,7 +161,7 @@ func runTestCoverageCheck(config TestCoverageConfig) error {
 	// Skip if no app paths configured
 	if len(config.AppPaths) == 0 {
 		if !compactMode() {
-			fmt.Println("⚠️  No app paths configured for test coverage check")
+			fmt.Println(glyph.Warn + "  No app paths configured for test coverage check")
 			fmt.Println()
 		}
 		return nil
@@ -206,7 +208,7 @@ func runTestCoverageCheck(config TestCoverageConfig) error {
 
 	// Verbose output
 	if len(violations) == 0 {
-		fmt.Println("✅ All source files have corresponding test files")
+		fmt.Println(glyph.Pass + " All source files have corresponding test files")
 		fmt.Println()
 		return nil
 	}
@@ -217,16 +219,16 @@ func runTestCoverageCheck(config TestCoverageConfig) error {
 	}
 
 	for appPath, appViolations := range byApp {
-		fmt.Printf("\n❌ %s - %d file(s) missing tests:\n", appPath, len(appViolations))
+		fmt.Printf("\n"+glyph.Fail+" %s - %d file(s) missing tests:\n", appPath, len(appViolations))
 		for _, v := range appViolations {
 			relSource, _ := filepath.Rel(".", v.SourceFile)
 			relTest, _ := filepath.Rel(".", v.ExpectedTestFile)
 			fmt.Printf("   %s\n", relSource)
-			fmt.Printf("      → expected: %s\n", relTest)
+			fmt.Printf("      "+glyph.Arrow+" expected: %s\n", relTest)
 		}
 	}
 
-	fmt.Printf("\n❌ Found %d source file(s) missing test files\n", len(violations))
+	fmt.Printf("\n"+glyph.Fail+" Found %d source file(s) missing test files\n", len(violations))
 	fmt.Println()
 	fmt.Println("Every component, hook, and utility in the configured folders")
 	fmt.Println("should have a corresponding .test.ts(x) file.")
@@ -243,7 +245,7 @@ func writeTestCoverageReport(violations []TestCoverageViolation, baseDir string)
 		relSource, _ := filepath.Rel(".", v.SourceFile)
 		relTest, _ := filepath.Rel(".", v.ExpectedTestFile)
 		fmt.Fprintf(&fileList, "  %s\n", relSource)
-		fmt.Fprintf(&fileList, "    → expected: %s\n", relTest)
+		fmt.Fprintf(&fileList, "    "+glyph.Arrow+" expected: %s\n", relTest)
 	}
 
 	var sb strings.Builder
@@ -280,7 +282,7 @@ func writeTestCoverageReport(violations []TestCoverageViolation, baseDir string)
 				relSource, _ := filepath.Rel(".", v.SourceFile)
 				relTest, _ := filepath.Rel(".", v.ExpectedTestFile)
 				fmt.Fprintf(&sb, "    %s\n", relSource)
-				fmt.Fprintf(&sb, "      → expected: %s\n", relTest)
+				fmt.Fprintf(&sb, "      "+glyph.Arrow+" expected: %s\n", relTest)
 			}
 		}
 	}
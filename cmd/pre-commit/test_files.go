@@ -6,6 +6,8 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+
+	"github.com/milehighideas/claude-hooks/internal/glyph"
 )
 
 // E2E test extensions by app type
@@ -327,10 +329,10 @@ func runTestFilesCheck(stagedFiles []string) error {
 	// warnings are informational and appear only in the full report.
 	var out strings.Builder
 	for _, v := range errors {
-		fmt.Fprintf(&out, "❌ %s: %s\n   Reason: %s require tests\n   Expected: %s\n", v.File, v.Message, v.Reason, v.ExpectedPath)
+		fmt.Fprintf(&out, glyph.Fail+" %s: %s\n   Reason: %s require tests\n   Expected: %s\n", v.File, v.Message, v.Reason, v.ExpectedPath)
 	}
 	for _, v := range warnings {
-		fmt.Fprintf(&out, "⚠️  %s: %s\n   Reason: %s\n   Expected: %s\n", v.File, v.Message, v.Reason, v.ExpectedPath)
+		fmt.Fprintf(&out, glyph.Warn+"  %s: %s\n   Reason: %s\n   Expected: %s\n", v.File, v.Message, v.Reason, v.ExpectedPath)
 	}
 	failed := len(errors) > 0
 	_ = writeRunReport("test-files", "Test files", out.String(), failed)
@@ -347,19 +349,19 @@ func runTestFilesCheck(stagedFiles []string) error {
 
 	// Verbose output
 	for _, v := range warnings {
-		fmt.Printf("⚠️  %s: %s\n", filepath.Base(v.File), v.Message)
+		fmt.Printf(glyph.Warn+"  %s: %s\n", filepath.Base(v.File), v.Message)
 		fmt.Printf("   Reason: %s\n", v.Reason)
 		fmt.Printf("   Expected: %s\n", v.ExpectedPath)
 	}
 
 	for _, v := range errors {
-		fmt.Printf("❌ %s: %s\n", filepath.Base(v.File), v.Message)
+		fmt.Printf(glyph.Fail+" %s: %s\n", filepath.Base(v.File), v.Message)
 		fmt.Printf("   Reason: %s require tests\n", v.Reason)
 		fmt.Printf("   Expected: %s\n", v.ExpectedPath)
 	}
 
 	if len(errors) > 0 {
-		fmt.Printf("\n❌ Found %d missing test file(s)\n", len(errors))
+		fmt.Printf("\n"+glyph.Fail+" Found %d missing test file(s)\n", len(errors))
 		fmt.Println("\nTest requirements:")
 		fmt.Println("  - Screens: Unit test (.test.tsx)")
 		fmt.Println("  - Forms (create/update): Unit test")
@@ -371,10 +373,10 @@ func runTestFilesCheck(stagedFiles []string) error {
 	}
 
 	if len(warnings) > 0 {
-		fmt.Printf("\n⚠️  %d E2E test warning(s) - consider adding\n", len(warnings))
+		fmt.Printf("\n"+glyph.Warn+"  %d E2E test warning(s) - consider adding\n", len(warnings))
 	}
 
-	fmt.Println("✅ Test files check passed")
+	fmt.Println(glyph.Pass + " Test files check passed")
 	fmt.Println()
 	return nil
 }
@@ -7,6 +7,8 @@ import (
 	"regexp"
 	"strings"
 	"time"
+
+	"github.com/milehighideas/claude-hooks/internal/glyph"
 )
 
 // TestQualityViolation represents a test file that is just a stub
@@ -170,17 +172,17 @@ func runTestQualityCheck(config TestQualityConfig) error {
 
 	// Verbose output
 	if len(violations) == 0 {
-		fmt.Println("✅ No export-only test stubs found")
+		fmt.Println(glyph.Pass + " No export-only test stubs found")
 		fmt.Println()
 		return nil
 	}
 
 	for _, v := range violations {
-		fmt.Printf("❌ %s\n", v.FilePath)
+		fmt.Printf(glyph.Fail+" %s\n", v.FilePath)
 		fmt.Printf("   %s\n\n", v.Reason)
 	}
 
-	fmt.Printf("\n❌ Found %d export-only test stub(s)\n", len(violations))
+	fmt.Printf("\n"+glyph.Fail+" Found %d export-only test stub(s)\n", len(violations))
 	fmt.Println()
 	fmt.Println("Tests that only check toBeDefined() or typeof provide no behavioral coverage.")
 	fmt.Println("Replace with tests that verify actual behavior, edge cases, and error handling.")
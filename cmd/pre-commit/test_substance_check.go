@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/milehighideas/claude-hooks/internal/glyph"
 	"github.com/milehighideas/claude-hooks/internal/stubs"
 	"github.com/milehighideas/claude-hooks/internal/substance"
 )
@@ -51,7 +52,7 @@ func runTestSubstanceCheck(cfg TestSubstanceCheckConfig, projectRoot string, sta
 		if compactMode() {
 			printStatus("Test substance", false, err.Error())
 		} else {
-			fmt.Printf("❌ Test substance check error: %v\n\n", err)
+			fmt.Printf(glyph.Fail+" Test substance check error: %v\n\n", err)
 		}
 		return err
 	}
@@ -80,7 +81,7 @@ func runTestSubstanceCheck(cfg TestSubstanceCheckConfig, projectRoot string, sta
 	}
 
 	if count == 0 {
-		fmt.Println("✅ Test substance check passed")
+		fmt.Println(glyph.Pass + " Test substance check passed")
 		fmt.Println()
 		return nil
 	}
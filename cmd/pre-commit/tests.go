@@ -2,9 +2,12 @@ package main
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -15,10 +18,14 @@ type TestRunContext struct {
 	SharedChanged  bool
 	Config         TestConfig
 	GlobalEnabled  bool              // Global tests feature flag (can be overridden per-app)
-	PackageManager string            // Package manager to use (pnpm, bun, npm, yarn)
+	PackageManager string            // Global package manager (pnpm, bun, npm, yarn); AppConfig.PackageManager overrides per app
 	Env            map[string]string // Environment variables for commands
 }
 
+// testCommandRunner is the indirection point for tests of the captured
+// (compact-mode) path. Production code invokes runCommandCapturedWithEnv.
+var testCommandRunner = runCommandCapturedWithEnv
+
 // runTests runs tests based on configuration and affected files
 func runTests(ctx TestRunContext) error {
 	if !compactMode() {
@@ -42,126 +49,224 @@ func runTests(ctx TestRunContext) error {
 		printTestPlan(ctx, appsToTest)
 	}
 
-	pm := ctx.PackageManager
-	if pm == "" {
-		pm = "pnpm"
+	names := sortedAppNames(appsToTest)
+	parallelism := ctx.Config.parallelism()
+
+	// Serial verbose runs stream each suite straight to the terminal and stop
+	// at the first failure.
+	if !compactMode() && (parallelism == 1 || len(names) == 1) {
+		for _, appName := range names {
+			if err := streamAppTests(ctx, appName, appsToTest[appName]); err != nil {
+				return err
+			}
+		}
+		return nil
 	}
 
+	results := runAppTestsParallel(os.Stdout, ctx, names, appsToTest, parallelism)
+
 	var failedApps []string
 	var passedApps []string
 	failureCounts := make(map[string]int) // appName -> number of failed tests
 	retriedApps := make(map[string]int)   // appName -> retry attempts that ultimately passed
-
-	for appName, appConfig := range appsToTest {
-		// Use custom test command if specified, otherwise default to "test"
-		testCmd := appConfig.TestCommand
-		if testCmd == "" {
-			testCmd = "test"
+	for _, r := range results {
+		if r.err != nil {
+			failedApps = append(failedApps, r.app)
+			failureCounts[r.app] = r.failures
+			continue
 		}
-
-		// Build args based on package manager
-		var args []string
-		if pm == "bun" {
-			args = []string{"--filter", appConfig.Filter, testCmd}
-		} else {
-			args = []string{"--filter", appConfig.Filter, testCmd}
+		if r.retries > 0 {
+			retriedApps[r.app] = r.retries
 		}
+		passedApps = append(passedApps, r.app)
+	}
 
-		// Append per-app test args (e.g., --watchman=false for Jest)
-		if len(appConfig.TestArgs) > 0 {
-			args = append(args, "--")
-			args = append(args, appConfig.TestArgs...)
+	if !compactMode() {
+		if len(failedApps) > 0 {
+			return fmt.Errorf("%s tests failed", strings.Join(failedApps, ", "))
 		}
+		return nil
+	}
 
-		// Per-app start line so each app's run shows up live in compact mode
-		// instead of disappearing into the long Tests phase.
-		appCheck := "Tests " + appName
-		printStart(appCheck)
-
-		if compactMode() {
-			// Capture output and write to report file
-			output, err := runCommandCapturedWithEnv(ctx.Env, pm, args...)
-
-			// Retry policy: TestConfig.Retries gives every failed run a
-			// chance to recover from environmental flake. Files listed in
-			// TestConfig.FlakyTestFiles get one extra retry beyond that,
-			// but only when the failure output mentions one of them — a
-			// real regression in non-quarantined code still fails fast.
-			retries := ctx.Config.Retries
-			if err != nil && hasFlakyFailure(output, ctx.Config.FlakyTestFiles) {
-				retries++
-			}
-			retryAttempts := 0
-			for err != nil && retryAttempts < retries {
-				retryAttempts++
-				output, err = runCommandCapturedWithEnv(ctx.Env, pm, args...)
-			}
-
-			writeTestReport(appName, output, err, reportDir)
-			if err != nil {
-				failedApps = append(failedApps, appName)
-				failureCounts[appName] = parseTestFailureCount(output)
-				printStatus(appCheck, false, fmt.Sprintf("%d failed", failureCounts[appName]))
-			} else {
-				if retryAttempts > 0 {
-					retriedApps[appName] = retryAttempts
-					printStatus(appCheck, true, fmt.Sprintf("after %d retry", retryAttempts))
-				} else {
-					printStatus(appCheck, true, "")
-				}
-				passedApps = append(passedApps, appName)
-			}
-		} else {
-			fmt.Printf("\nRunning %s tests (command: %s)...\n", appName, testCmd)
-			err := runCommandWithEnv(ctx.Env, pm, args...)
-			retries := ctx.Config.Retries
-			retryAttempts := 0
-			for err != nil && retryAttempts < retries {
-				retryAttempts++
-				fmt.Printf("\nRetrying %s tests (attempt %d/%d)...\n", appName, retryAttempts, retries)
-				err = runCommandWithEnv(ctx.Env, pm, args...)
-			}
-			if err != nil {
-				fmt.Printf("%s tests failed\n", appName)
-				return fmt.Errorf("%s tests failed", appName)
-			}
-			if retryAttempts > 0 {
-				fmt.Printf("%s tests passed (after %d retry)\n", appName, retryAttempts)
+	if len(failedApps) > 0 {
+		parts := make([]string, len(failedApps))
+		for i, app := range failedApps {
+			if count, ok := failureCounts[app]; ok && count > 0 {
+				parts[i] = fmt.Sprintf("%s %d failed", app, count)
 			} else {
-				fmt.Printf("%s tests passed\n", appName)
+				parts[i] = app + " failed"
 			}
 		}
+		printStatus("Tests", false, strings.Join(parts, ", "))
+		printReportHint("tests/")
+		return fmt.Errorf("%s tests failed", strings.Join(failedApps, ", "))
 	}
-
-	if compactMode() {
-		if len(failedApps) > 0 {
-			parts := make([]string, len(failedApps))
-			for i, app := range failedApps {
-				if count, ok := failureCounts[app]; ok && count > 0 {
-					parts[i] = fmt.Sprintf("%s %d failed", app, count)
-				} else {
-					parts[i] = app + " failed"
-				}
+	summary := strings.Join(passedApps, ", ")
+	if len(retriedApps) > 0 {
+		retryParts := make([]string, 0, len(retriedApps))
+		for app, n := range retriedApps {
+			word := "retry"
+			if n != 1 {
+				word = "retries"
 			}
-			printStatus("Tests", false, strings.Join(parts, ", "))
-			printReportHint("tests/")
-			return fmt.Errorf("%s tests failed", strings.Join(failedApps, ", "))
+			retryParts = append(retryParts, fmt.Sprintf("%s after %d %s", app, n, word))
 		}
-		summary := strings.Join(passedApps, ", ")
-		if len(retriedApps) > 0 {
-			retryParts := make([]string, 0, len(retriedApps))
-			for app, n := range retriedApps {
-				word := "retry"
-				if n != 1 {
-					word = "retries"
-				}
-				retryParts = append(retryParts, fmt.Sprintf("%s after %d %s", app, n, word))
+		summary += " — recovered: " + strings.Join(retryParts, ", ")
+	}
+	printStatus("Tests", true, summary)
+
+	return nil
+}
+
+// appTestResult is the outcome of one app's captured test run.
+type appTestResult struct {
+	app      string
+	output   string
+	err      error
+	failures int // failed tests parsed from the output, 0 if unknown
+	retries  int // retries it took to pass
+}
+
+// testCommand returns appConfig's test script: its custom TestCommand, or
+// "test".
+func testCommand(appConfig AppConfig) string {
+	if appConfig.TestCommand == "" {
+		return "test"
+	}
+	return appConfig.TestCommand
+}
+
+// testArgs returns the package manager arguments that run appConfig's tests.
+func testArgs(appConfig AppConfig) []string {
+	// Build args based on package manager
+	// A glob filter expands to one --filter per matched package
+	args := append(appConfig.filterArgs(), testCommand(appConfig))
+
+	// Append per-app test args (e.g., --watchman=false for Jest)
+	if len(appConfig.TestArgs) > 0 {
+		args = append(args, "--")
+		args = append(args, appConfig.TestArgs...)
+	}
+	return args
+}
+
+// runAppTestsParallel runs each app's suite with captured output, at most
+// parallelism at a time, and returns the results in names order. Compact
+// mode reports each app as it finishes; verbose mode prints each app's
+// output to w as one block, so concurrent suites never interleave.
+func runAppTestsParallel(w io.Writer, ctx TestRunContext, names []string, apps map[string]AppConfig, parallelism int) []appTestResult {
+	if !compactMode() && len(names) > 1 {
+		fmt.Fprintf(w, "\nRunning %d test suites, up to %d at a time...\n", len(names), parallelism)
+	}
+
+	results := make([]appTestResult, len(names))
+	sem := make(chan struct{}, parallelism)
+	var printMu sync.Mutex
+	var wg sync.WaitGroup
+	for i, appName := range names {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(idx int, appName string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			r := runAppTestsCaptured(ctx, appName, apps[appName])
+			results[idx] = r
+			if !compactMode() {
+				printMu.Lock()
+				printAppTestGroup(w, r)
+				printMu.Unlock()
 			}
-			summary += " — recovered: " + strings.Join(retryParts, ", ")
-		}
-		printStatus("Tests", true, summary)
+		}(i, appName)
 	}
+	wg.Wait()
+	return results
+}
 
+// runAppTestsCaptured runs one app's suite with its output captured, retrying
+// per TestConfig, and writes its report.
+func runAppTestsCaptured(ctx TestRunContext, appName string, appConfig AppConfig) appTestResult {
+	pm := appConfig.packageManagerFor(ctx.PackageManager)
+	args := testArgs(appConfig)
+
+	// Per-app start line so each app's run shows up live in compact mode
+	// instead of disappearing into the long Tests phase.
+	appCheck := "Tests " + appName
+	printStart(appCheck)
+
+	output, err := testCommandRunner(ctx.Env, pm, args...)
+
+	// Retry policy: TestConfig.Retries gives every failed run a
+	// chance to recover from environmental flake. Files listed in
+	// TestConfig.FlakyTestFiles get one extra retry beyond that,
+	// but only when the failure output mentions one of them — a
+	// real regression in non-quarantined code still fails fast.
+	retries := ctx.Config.Retries
+	if err != nil && hasFlakyFailure(output, ctx.Config.FlakyTestFiles) {
+		retries++
+	}
+	retryAttempts := 0
+	for err != nil && retryAttempts < retries {
+		retryAttempts++
+		output, err = testCommandRunner(ctx.Env, pm, args...)
+	}
+
+	writeTestReport(appName, output, err, reportDir)
+	r := appTestResult{app: appName, output: output, err: err}
+	if err != nil {
+		r.failures = parseTestFailureCount(output)
+		printStatus(appCheck, false, fmt.Sprintf("%d failed", r.failures))
+		return r
+	}
+	r.retries = retryAttempts
+	if retryAttempts > 0 {
+		printStatus(appCheck, true, fmt.Sprintf("after %d retry", retryAttempts))
+	} else {
+		printStatus(appCheck, true, "")
+	}
+	return r
+}
+
+// printAppTestGroup prints one app's captured test output as a single block.
+func printAppTestGroup(w io.Writer, r appTestResult) {
+	fmt.Fprintf(w, "\n===== %s tests =====\n", r.app)
+	fmt.Fprint(w, r.output)
+	if r.output != "" && !strings.HasSuffix(r.output, "\n") {
+		fmt.Fprintln(w)
+	}
+	switch {
+	case r.err != nil:
+		fmt.Fprintf(w, "%s tests failed\n", r.app)
+	case r.retries > 0:
+		fmt.Fprintf(w, "%s tests passed (after %d retry)\n", r.app, r.retries)
+	default:
+		fmt.Fprintf(w, "%s tests passed\n", r.app)
+	}
+}
+
+// streamAppTests runs one app's suite with output going straight to the
+// terminal, retrying per TestConfig.
+func streamAppTests(ctx TestRunContext, appName string, appConfig AppConfig) error {
+	pm := appConfig.packageManagerFor(ctx.PackageManager)
+	args := testArgs(appConfig)
+
+	fmt.Printf("\nRunning %s tests (command: %s)...\n", appName, testCommand(appConfig))
+	err := runCommandWithEnv(ctx.Env, pm, args...)
+	retries := ctx.Config.Retries
+	retryAttempts := 0
+	for err != nil && retryAttempts < retries {
+		retryAttempts++
+		fmt.Printf("\nRetrying %s tests (attempt %d/%d)...\n", appName, retryAttempts, retries)
+		err = runCommandWithEnv(ctx.Env, pm, args...)
+	}
+	if err != nil {
+		fmt.Printf("%s tests failed\n", appName)
+		return fmt.Errorf("%s tests failed", appName)
+	}
+	if retryAttempts > 0 {
+		fmt.Printf("%s tests passed (after %d retry)\n", appName, retryAttempts)
+	} else {
+		fmt.Printf("%s tests passed\n", appName)
+	}
 	return nil
 }
 
@@ -1,6 +1,14 @@
 package main
 
-import "testing"
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
 
 func TestParseTestFailureCount(t *testing.T) {
 	tests := []struct {
@@ -73,3 +81,136 @@ Time:        2.611 s`,
 		})
 	}
 }
+
+func TestRunTests_PerAppPackageManager(t *testing.T) {
+	origDir := reportDir
+	reportDir = t.TempDir() // compact mode routes through testCommandRunner
+	t.Cleanup(func() { reportDir = origDir })
+
+	var mu sync.Mutex
+	invoked := map[string]string{} // filter -> package manager
+	orig := testCommandRunner
+	testCommandRunner = func(_ map[string]string, name string, args ...string) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		invoked[args[1]] = name
+		return "", nil
+	}
+	t.Cleanup(func() { testCommandRunner = orig })
+
+	runShared := true
+	err := runTests(TestRunContext{
+		AllApps: map[string]AppConfig{
+			"web":    {Path: "apps/web", Filter: "@acme/web"},
+			"mobile": {Path: "apps/mobile", Filter: "@acme/mobile", PackageManager: "bun"},
+		},
+		Config:         TestConfig{RunOnSharedChanges: &runShared},
+		GlobalEnabled:  true,
+		PackageManager: "pnpm",
+	})
+	if err != nil {
+		t.Fatalf("runTests() error = %v", err)
+	}
+
+	want := map[string]string{"@acme/web": "pnpm", "@acme/mobile": "bun"}
+	for filter, pm := range want {
+		if invoked[filter] != pm {
+			t.Errorf("%s ran with %q, want %q (all: %v)", filter, invoked[filter], pm, invoked)
+		}
+	}
+}
+
+func TestRunTests_ParallelismLimitsConcurrentSuites(t *testing.T) {
+	origDir := reportDir
+	reportDir = t.TempDir()
+	t.Cleanup(func() { reportDir = origDir })
+
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+	bothStarted := make(chan struct{})
+	var overlapped sync.Once
+	orig := testCommandRunner
+	testCommandRunner = func(_ map[string]string, _ string, args ...string) (string, error) {
+		mu.Lock()
+		running++
+		maxRunning = max(maxRunning, running)
+		if running == 2 {
+			overlapped.Do(func() { close(bothStarted) })
+		}
+		mu.Unlock()
+
+		// Hold each suite until two overlap, so a serial scheduler fails
+		// the test instead of passing by luck.
+		select {
+		case <-bothStarted:
+		case <-time.After(2 * time.Second):
+		}
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+		if args[1] == "@acme/api" {
+			return "Tests  2 failed | 5 passed", errors.New("exit status 1")
+		}
+		return "", nil
+	}
+	t.Cleanup(func() { testCommandRunner = orig })
+
+	runShared := true
+	err := runTests(TestRunContext{
+		AllApps: map[string]AppConfig{
+			"api":    {Path: "apps/api", Filter: "@acme/api"},
+			"mobile": {Path: "apps/mobile", Filter: "@acme/mobile"},
+			"web":    {Path: "apps/web", Filter: "@acme/web"},
+		},
+		Config:        TestConfig{RunOnSharedChanges: &runShared, Parallelism: 2},
+		GlobalEnabled: true,
+	})
+	if maxRunning != 2 {
+		t.Errorf("max concurrent suites = %d, want 2", maxRunning)
+	}
+	if err == nil || err.Error() != "api tests failed" {
+		t.Errorf("runTests() error = %v, want the failing app reported after all suites ran", err)
+	}
+}
+
+func TestRunAppTestsParallel_GroupsOutputPerApp(t *testing.T) {
+	origDir := reportDir
+	reportDir = "" // verbose mode prints grouped output
+	t.Cleanup(func() { reportDir = origDir })
+
+	orig := testCommandRunner
+	testCommandRunner = func(_ map[string]string, _ string, args ...string) (string, error) {
+		app := strings.TrimPrefix(args[1], "@acme/")
+		var out strings.Builder
+		for i := 0; i < 3; i++ {
+			fmt.Fprintf(&out, "%s line %d\n", app, i)
+			time.Sleep(time.Millisecond)
+		}
+		if app == "web" {
+			return out.String(), errors.New("exit status 1")
+		}
+		return out.String(), nil
+	}
+	t.Cleanup(func() { testCommandRunner = orig })
+
+	apps := map[string]AppConfig{
+		"mobile": {Path: "apps/mobile", Filter: "@acme/mobile"},
+		"web":    {Path: "apps/web", Filter: "@acme/web"},
+	}
+	var buf bytes.Buffer
+	results := runAppTestsParallel(&buf, TestRunContext{}, []string{"mobile", "web"}, apps, 2)
+
+	if results[0].app != "mobile" || results[0].err != nil || results[1].app != "web" || results[1].err == nil {
+		t.Fatalf("results = %+v, want mobile passed and web failed, in name order", results)
+	}
+	out := buf.String()
+	for _, block := range []string{
+		"===== mobile tests =====\nmobile line 0\nmobile line 1\nmobile line 2\nmobile tests passed\n",
+		"===== web tests =====\nweb line 0\nweb line 1\nweb line 2\nweb tests failed\n",
+	} {
+		if !strings.Contains(out, block) {
+			t.Errorf("output missing grouped block %q:\n%s", block, out)
+		}
+	}
+}
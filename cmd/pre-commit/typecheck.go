@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/exec"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -17,6 +18,25 @@ type tsError struct {
 	fullText  string
 }
 
+// tsErrorHeadRe splits the first line of a TypeScript error into its
+// location and message.
+var tsErrorHeadRe = regexp.MustCompile(`^(.+?)\((\d+),\d+\): error (TS\d+): (.*)$`)
+
+// violation converts a TypeScript error for the SARIF report. The message is
+// the first line's text plus any continuation lines.
+func (e tsError) violation() Violation {
+	v := Violation{RuleID: "typecheck/" + e.errorCode, Level: "error", File: e.filePath, Message: e.fullText}
+	head, rest, _ := strings.Cut(e.fullText, "\n")
+	if m := tsErrorHeadRe.FindStringSubmatch(head); m != nil {
+		v.Line, _ = strconv.Atoi(m[2])
+		v.Message = strings.TrimSpace(m[4])
+		if rest != "" {
+			v.Message += "\n" + rest
+		}
+	}
+	return v
+}
+
 // DefaultErrorCodes are the TypeScript error codes filtered by default
 var DefaultErrorCodes = []string{"TS2589", "TS2742"}
 
@@ -238,6 +258,9 @@ func runFilteredTypecheckBuffered(appName, appPath, filter, packageManager strin
 	if filteredCount > 0 {
 		fmt.Fprintf(&output, "   (filtered %d known errors)\n", filteredCount)
 	}
+	for _, e := range realErrors {
+		recordViolations(e.violation())
+	}
 
 	// Write report if reportDir is set
 	if reportDir != "" {
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/milehighideas/claude-hooks/internal/glyph"
+)
+
+// branchOverride, when set, is reported as the current branch instead of
+// asking git. --verify-staged sets it because its temporary worktree has a
+// detached HEAD.
+var branchOverride string
+
+// fixersApplied is set while --verify-staged runs the checks in its
+// worktree after runStagedFixers already ran the fixers in the real tree,
+// so run skips lint-staged and checks file headers without --fix.
+var fixersApplied bool
+
+// runStagedFixers runs the checks that rewrite and re-stage files in the
+// real tree, before --verify-staged builds its worktree: lint-staged, and
+// fileHeaderCheck under --fix. Run in the worktree, their fixes would land
+// in its throwaway index and the checks would pass on content that isn't
+// committed.
+func runStagedFixers() error {
+	config, err := configSource()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := filterChecks(config); err != nil {
+		return err
+	}
+	stagedFiles, err := stagedFilesSource()
+	if err != nil {
+		return fmt.Errorf("failed to get staged files: %w", err)
+	}
+	if len(stagedFiles) == 0 {
+		return nil
+	}
+
+	if fixFlag && config.Features.FileHeaderCheck && (checkName == "" || checkName == "fileHeaderCheck") {
+		printStart("File headers")
+		projectRoot, _ := os.Getwd()
+		if err := runFileHeaderCheck(config.FileHeaderCheckConfig, projectRoot, true); err != nil {
+			return err
+		}
+	}
+	if config.Features.LintStaged && checkName == "" {
+		printStart("Formatting")
+		if err := runLintStaged(config.LintStagedConfig); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stagedWorktree is the temporary git worktree --verify-staged runs the
+// checks in. It holds the staged content of every tracked file, and its
+// index matches the real one, so `git diff --cached` lists the same files.
+type stagedWorktree struct {
+	root string // toplevel of the real repo
+	dir  string // the temporary worktree
+	once sync.Once
+	err  error
+}
+
+// createStagedWorktree adds a detached worktree at HEAD in a temporary
+// directory and fills it from the current index. node_modules directories
+// are linked in from the real tree so lint, typecheck and tests can run.
+func createStagedWorktree() (*stagedWorktree, error) {
+	out, err := gitIn(".", "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	root := strings.TrimSpace(string(out))
+
+	if _, err := gitIn(root, "rev-parse", "--verify", "--quiet", "HEAD"); err != nil {
+		return nil, errors.New("the repository has no commits yet; run without --verify-staged for the first commit")
+	}
+	out, err = gitIn(root, "write-tree")
+	if err != nil {
+		return nil, err
+	}
+	tree := strings.TrimSpace(string(out))
+
+	dir, err := os.MkdirTemp("", "pre-commit-staged-")
+	if err != nil {
+		return nil, err
+	}
+	// Resolve symlinks (macOS /var -> /private/var) so paths git reports
+	// from inside the worktree match dir.
+	if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+		dir = resolved
+	}
+	w := &stagedWorktree{root: root, dir: dir}
+
+	if _, err := gitIn(root, "worktree", "add", "--detach", "--no-checkout", dir, "HEAD"); err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, err
+	}
+	for _, args := range [][]string{{"read-tree", tree}, {"checkout-index", "--all", "--force"}} {
+		if _, err := gitIn(dir, args...); err != nil {
+			if removeErr := w.remove(); removeErr != nil {
+				return nil, fmt.Errorf("%v (and %v)", err, removeErr)
+			}
+			return nil, err
+		}
+	}
+	if err := linkNodeModules(root, dir); err != nil {
+		if removeErr := w.remove(); removeErr != nil {
+			return nil, fmt.Errorf("%v (and %v)", err, removeErr)
+		}
+		return nil, err
+	}
+	return w, nil
+}
+
+// linkNodeModules symlinks each node_modules directory under root to the
+// same place in dir, where its parent directory exists. Dependencies are
+// not tracked, so the worktree would otherwise have none.
+func linkNodeModules(root, dir string) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+		switch d.Name() {
+		case ".git":
+			return filepath.SkipDir
+		case "node_modules":
+			rel, err := filepath.Rel(root, path)
+			if err != nil {
+				return filepath.SkipDir
+			}
+			target := filepath.Join(dir, rel)
+			if _, err := os.Stat(filepath.Dir(target)); err != nil {
+				return filepath.SkipDir
+			}
+			if err := os.Symlink(path, target); err != nil && !os.IsExist(err) {
+				return fmt.Errorf("linking %s: %w", rel, err)
+			}
+			return filepath.SkipDir
+		}
+		return nil
+	})
+}
+
+// remove deletes the worktree and its git metadata. Safe to call more than
+// once.
+func (w *stagedWorktree) remove() error {
+	w.once.Do(func() {
+		if _, err := gitIn(w.root, "worktree", "remove", "--force", w.dir); err != nil {
+			// Fall back to deleting the directory and pruning the stale entry.
+			if rmErr := os.RemoveAll(w.dir); rmErr != nil {
+				w.err = fmt.Errorf("could not remove temporary worktree %s: %v", w.dir, rmErr)
+				return
+			}
+			_, _ = gitIn(w.root, "worktree", "prune")
+		}
+	})
+	return w.err
+}
+
+// withStagedWorktree runs fn from inside a temporary worktree holding the
+// staged content, so every check sees exactly what is being committed while
+// the real working tree is left untouched. The worktree is removed when fn
+// returns, fails, or panics, and on SIGINT/SIGTERM.
+func withStagedWorktree(fn func() error) (err error) {
+	origDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("--verify-staged: %w", err)
+	}
+	w, err := createStagedWorktree()
+	if err != nil {
+		return fmt.Errorf("--verify-staged: %w", err)
+	}
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-sigs:
+			_ = os.Chdir(origDir)
+			if removeErr := w.remove(); removeErr != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", removeErr)
+			}
+			os.Exit(130)
+		case <-done:
+		}
+	}()
+
+	prevBranch, prevReportDir := branchOverride, reportDir
+	defer func() {
+		signal.Stop(sigs)
+		close(done)
+		branchOverride, reportDir = prevBranch, prevReportDir
+		_ = os.Chdir(origDir)
+		if removeErr := w.remove(); removeErr != nil {
+			if err == nil {
+				err = removeErr
+			} else {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", removeErr)
+			}
+		}
+	}()
+
+	// Keep the branch name, reports and an untracked config pointing at the
+	// real tree before moving into the worktree.
+	branchOverride = getGitBranch()
+	if reportDir != "" && !filepath.IsAbs(reportDir) {
+		reportDir = filepath.Join(origDir, reportDir)
+	}
+	rel, err := filepath.Rel(w.root, origDir)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		rel = "."
+	}
+	workDir := filepath.Join(w.dir, rel)
+	if err := copyUntrackedConfig(origDir, workDir); err != nil {
+		return fmt.Errorf("--verify-staged: %w", err)
+	}
+	if err := os.Chdir(workDir); err != nil {
+		return fmt.Errorf("--verify-staged: %w", err)
+	}
+
+	if !compactMode() {
+		fmt.Printf(glyph.Lock+" --verify-staged: checking staged content in %s\n\n", w.dir)
+	}
+	return fn()
+}
+
+// copyUntrackedConfig copies .pre-commit.json from the real working
+// directory into the worktree when it isn't tracked, so the checks still
+// find their config.
+func copyUntrackedConfig(origDir, workDir string) error {
+	const name = ".pre-commit.json"
+	dst := filepath.Join(workDir, name)
+	if _, err := os.Stat(dst); err == nil {
+		return nil
+	}
+	data, err := os.ReadFile(filepath.Join(origDir, name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if err := os.MkdirAll(workDir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(dst, data, 0644)
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// assertNoStagedWorktree checks that no temporary worktree is registered
+// or left on disk.
+func assertNoStagedWorktree(t *testing.T, worktreeDir string) {
+	t.Helper()
+	out, err := exec.Command("git", "worktree", "list", "--porcelain").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(out), "worktree "); n != 1 {
+		t.Errorf("expected only the main worktree, got:\n%s", out)
+	}
+	if worktreeDir != "" {
+		if _, err := os.Stat(worktreeDir); !os.IsNotExist(err) {
+			t.Errorf("temporary worktree %s still exists", worktreeDir)
+		}
+	}
+}
+
+func TestWithStagedWorktreeSeesStagedContent(t *testing.T) {
+	dir := setupDivergentRepo(t)
+
+	var seen, workDir string
+	var stagedList []byte
+	err := withStagedWorktree(func() error {
+		workDir, _ = os.Getwd()
+		seen = readFile(t, "app.ts")
+		var err error
+		stagedList, err = exec.Command("git", "diff", "--cached", "--name-only").Output()
+		return err
+	})
+	if err != nil {
+		t.Fatalf("withStagedWorktree() error = %v", err)
+	}
+	if workDir == dir {
+		t.Error("checks ran in the real working tree")
+	}
+	if !strings.Contains(seen, "staged") {
+		t.Errorf("checks saw %q, want the staged content", seen)
+	}
+	if strings.TrimSpace(string(stagedList)) != "app.ts" {
+		t.Errorf("staged files in worktree = %q, want app.ts", stagedList)
+	}
+	if _, err := os.Stat(filepath.Join(workDir, "notes.txt")); !os.IsNotExist(err) {
+		t.Error("untracked notes.txt should not be in the worktree")
+	}
+
+	if cwd, _ := os.Getwd(); cwd != dir {
+		t.Errorf("cwd = %q, want %q restored", cwd, dir)
+	}
+	if got := readFile(t, filepath.Join(dir, "app.ts")); !strings.Contains(got, "working") {
+		t.Errorf("real worktree app.ts = %q, want it untouched", got)
+	}
+	assertNoStagedWorktree(t, workDir)
+}
+
+func TestWithStagedWorktreeRemovesOnFailure(t *testing.T) {
+	setupDivergentRepo(t)
+
+	var workDir string
+	checkErr := errors.New("lint failed")
+	err := withStagedWorktree(func() error {
+		workDir, _ = os.Getwd()
+		return checkErr
+	})
+	if !errors.Is(err, checkErr) {
+		t.Errorf("withStagedWorktree() error = %v, want the check's error", err)
+	}
+	assertNoStagedWorktree(t, workDir)
+}
+
+func TestWithStagedWorktreeRemovesOnPanic(t *testing.T) {
+	setupDivergentRepo(t)
+
+	var workDir string
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected the panic to propagate")
+			}
+		}()
+		_ = withStagedWorktree(func() error {
+			workDir, _ = os.Getwd()
+			panic("boom")
+		})
+	}()
+	assertNoStagedWorktree(t, workDir)
+}
+
+func TestWithStagedWorktreeKeepsBranchAndLinksDependencies(t *testing.T) {
+	dir := setupDivergentRepo(t)
+	if err := os.MkdirAll(filepath.Join(dir, "node_modules", "dep"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".pre-commit.json"), []byte(`{"features":{}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	branch := getGitBranch()
+
+	err := withStagedWorktree(func() error {
+		if got := getGitBranch(); got != branch {
+			t.Errorf("branch in worktree = %q, want %q", got, branch)
+		}
+		if _, err := os.Stat(filepath.Join("node_modules", "dep")); err != nil {
+			t.Errorf("node_modules not linked: %v", err)
+		}
+		if _, err := os.Stat(".pre-commit.json"); err != nil {
+			t.Errorf("untracked config not copied: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("withStagedWorktree() error = %v", err)
+	}
+	if branchOverride != "" {
+		t.Errorf("branchOverride = %q after run, want it cleared", branchOverride)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "node_modules", "dep")); err != nil {
+		t.Errorf("real node_modules removed with the worktree: %v", err)
+	}
+}
+
+func TestCreateStagedWorktreeNeedsACommit(t *testing.T) {
+	dir := t.TempDir()
+	if err := runGitCommand(dir, "init", "-q"); err != nil {
+		t.Skipf("git not available: %v", err)
+	}
+	origDir, _ := os.Getwd()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(origDir) })
+
+	if _, err := createStagedWorktree(); err == nil || !strings.Contains(err.Error(), "no commits") {
+		t.Errorf("createStagedWorktree() error = %v, want a no-commits error", err)
+	}
+}
+
+func TestVerifyStagedRunsFixersInRealTree(t *testing.T) {
+	dir := setupFileHeaderRepo(t, map[string]string{"src/a.ts": "export const a = 1;\n"})
+
+	origFix, origVerify, origConfig := fixFlag, verifyStaged, configSource
+	t.Cleanup(func() { fixFlag, verifyStaged, configSource = origFix, origVerify, origConfig })
+	fixFlag, verifyStaged = true, true
+	configSource = func() (*Config, error) {
+		cfg := &Config{FileHeaderCheckConfig: FileHeaderCheckConfig{Header: testLicenseHeader}}
+		cfg.Features.FileHeaderCheck = true
+		return cfg, nil
+	}
+
+	if err := runOnce(); err != nil {
+		t.Fatalf("runOnce() error = %v", err)
+	}
+	staged, err := exec.Command("git", "show", ":src/a.ts").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(staged), "// Copyright ") {
+		t.Errorf("staged a.ts = %q, want the header fixed in the real index", staged)
+	}
+	if got := readFile(t, filepath.Join(dir, "src/a.ts")); !strings.HasPrefix(got, "// Copyright ") {
+		t.Errorf("working a.ts = %q, want the header fixed in the real tree", got)
+	}
+	if fixersApplied {
+		t.Error("fixersApplied left set after the run")
+	}
+	assertNoStagedWorktree(t, "")
+}
@@ -7,6 +7,8 @@ import (
 	"regexp"
 	"strings"
 	"time"
+
+	"github.com/milehighideas/claude-hooks/internal/glyph"
 )
 
 // VitestAssertionViolation represents a vitest config missing requireAssertions
@@ -138,13 +140,13 @@ func runVitestAssertionsCheck(apps map[string]AppConfig) error {
 
 	// Verbose output
 	if len(violations) == 0 {
-		fmt.Println("✅ All vitest configs have requireAssertions enabled")
+		fmt.Println(glyph.Pass + " All vitest configs have requireAssertions enabled")
 		fmt.Println()
 		return nil
 	}
 
 	for _, v := range violations {
-		fmt.Printf("❌ %s: %s\n", v.AppName, v.Message)
+		fmt.Printf(glyph.Fail+" %s: %s\n", v.AppName, v.Message)
 		fmt.Printf("   Config: %s\n", v.ConfigPath)
 		fmt.Println("   FIX: Add to vitest config:")
 		fmt.Println("     test: {")
@@ -155,7 +157,7 @@ func runVitestAssertionsCheck(apps map[string]AppConfig) error {
 		fmt.Println()
 	}
 
-	fmt.Printf("\n❌ Found %d vitest config(s) missing requireAssertions\n", len(violations))
+	fmt.Printf("\n"+glyph.Fail+" Found %d vitest config(s) missing requireAssertions\n", len(violations))
 	fmt.Println()
 	fmt.Println("Tests without assertions provide false confidence.")
 	fmt.Println("requireAssertions: true ensures every test has at least one expect() call.")
@@ -0,0 +1,316 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/milehighideas/claude-hooks/internal/glob"
+	"github.com/milehighideas/claude-hooks/internal/glyph"
+)
+
+// workspacePackage is one package declared by the workspace config.
+type workspacePackage struct {
+	Name string // package.json "name"
+	Dir  string // slash-separated, relative to the project root
+}
+
+// isGlobFilter reports whether an app filter is a package-name glob
+// (e.g. "@myapp/ui-*") rather than a single package.
+func isGlobFilter(filter string) bool {
+	return strings.ContainsAny(filter, "*?[")
+}
+
+// workspacePatterns returns the package directory globs declared in
+// pnpm-workspace.yaml, or in package.json "workspaces" when there is no pnpm
+// workspace file. Negated patterns keep their leading "!".
+func workspacePatterns(root string) []string {
+	if data, err := os.ReadFile(filepath.Join(root, "pnpm-workspace.yaml")); err == nil {
+		return parsePnpmWorkspacePackages(string(data))
+	}
+
+	data, err := os.ReadFile(filepath.Join(root, "package.json"))
+	if err != nil {
+		return nil
+	}
+	var pkg struct {
+		Workspaces json.RawMessage `json:"workspaces"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil || len(pkg.Workspaces) == 0 {
+		return nil
+	}
+	// "workspaces" is either an array or {"packages": [...]} (yarn classic).
+	var patterns []string
+	if err := json.Unmarshal(pkg.Workspaces, &patterns); err == nil {
+		return patterns
+	}
+	var nested struct {
+		Packages []string `json:"packages"`
+	}
+	_ = json.Unmarshal(pkg.Workspaces, &nested)
+	return nested.Packages
+}
+
+// parsePnpmWorkspacePackages extracts the "packages:" list from a
+// pnpm-workspace.yaml. Only the block-sequence form pnpm documents is
+// supported; quotes and trailing comments are stripped.
+func parsePnpmWorkspacePackages(yaml string) []string {
+	var patterns []string
+	inPackages := false
+	for _, raw := range strings.Split(yaml, "\n") {
+		line := strings.TrimRight(raw, " \t\r")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "-") {
+			inPackages = strings.HasPrefix(trimmed, "packages:")
+			continue
+		}
+		if !inPackages || !strings.HasPrefix(trimmed, "- ") {
+			continue
+		}
+		item := strings.TrimSpace(strings.TrimPrefix(trimmed, "- "))
+		if idx := strings.Index(item, " #"); idx >= 0 {
+			item = strings.TrimSpace(item[:idx])
+		}
+		item = strings.Trim(item, `"'`)
+		if item != "" {
+			patterns = append(patterns, item)
+		}
+	}
+	return patterns
+}
+
+// globBase returns the directory prefix of pattern before its first glob
+// segment, so discovery only walks the subtree the pattern can match.
+func globBase(pattern string) string {
+	var base []string
+	for _, seg := range strings.Split(pattern, "/") {
+		if strings.ContainsAny(seg, "*?[") {
+			break
+		}
+		base = append(base, seg)
+	}
+	return strings.Join(base, "/")
+}
+
+// discoverWorkspacePackages expands the workspace patterns under root into
+// the packages they declare, sorted by name. Directories without a named
+// package.json, node_modules, and dot directories are skipped.
+func discoverWorkspacePackages(root string) ([]workspacePackage, error) {
+	var include, exclude []*regexp.Regexp
+	var bases []string
+	for _, pattern := range workspacePatterns(root) {
+		negated := strings.HasPrefix(pattern, "!")
+		pattern = strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(pattern, "!"), "./"), "/")
+		if pattern == "" {
+			continue
+		}
+		if negated {
+			exclude = append(exclude, glob.MustCompile(pattern))
+			continue
+		}
+		include = append(include, glob.MustCompile(pattern))
+		bases = append(bases, globBase(pattern))
+	}
+
+	seen := make(map[string]bool)
+	var pkgs []workspacePackage
+	for _, base := range bases {
+		start := filepath.Join(root, filepath.FromSlash(base))
+		err := filepath.WalkDir(start, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return filepath.SkipDir
+				}
+				return err
+			}
+			if !d.IsDir() {
+				return nil
+			}
+			if p != start && (strings.HasPrefix(d.Name(), ".") || d.Name() == "node_modules") {
+				return filepath.SkipDir
+			}
+			rel, err := filepath.Rel(root, p)
+			if err != nil {
+				return nil
+			}
+			rel = filepath.ToSlash(rel)
+			if seen[rel] || !matchesAny(include, rel) || matchesAny(exclude, rel) {
+				return nil
+			}
+			seen[rel] = true
+			if name := readPackageName(p); name != "" {
+				pkgs = append(pkgs, workspacePackage{Name: name, Dir: rel})
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("scanning workspace %s: %w", base, err)
+		}
+	}
+
+	sort.Slice(pkgs, func(i, j int) bool { return pkgs[i].Name < pkgs[j].Name })
+	return pkgs, nil
+}
+
+// matchesAny reports whether s matches any of patterns.
+func matchesAny(patterns []*regexp.Regexp, s string) bool {
+	for _, re := range patterns {
+		if re.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// readPackageName returns the "name" from dir/package.json, or "".
+func readPackageName(dir string) string {
+	data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return ""
+	}
+	var pkg struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return ""
+	}
+	return pkg.Name
+}
+
+// matchWorkspaceFilter returns the packages an app filter selects: an exact
+// name match, or every name matching a glob filter. pnpm dependency
+// selectors ("...", "^") around the name are ignored.
+func matchWorkspaceFilter(filter string, pkgs []workspacePackage) []workspacePackage {
+	name := strings.TrimSuffix(strings.TrimPrefix(filter, "..."), "...")
+	name = strings.TrimPrefix(strings.TrimSuffix(name, "^"), "^")
+	var matched []workspacePackage
+	for _, pkg := range pkgs {
+		if ok, _ := path.Match(name, pkg.Name); ok || pkg.Name == name {
+			matched = append(matched, pkg)
+		}
+	}
+	return matched
+}
+
+// isDirectoryFilter reports whether filter selects packages by directory
+// ("./apps/web", "{apps/web}") rather than by name.
+func isDirectoryFilter(filter string) bool {
+	return strings.HasPrefix(filter, "./") || strings.HasPrefix(filter, "{")
+}
+
+// expandAppFilters resolves every glob filter in apps to the workspace
+// packages it matches, so lint, typecheck and tests can run once per
+// package. Apps with plain filters are left alone; a glob that matches
+// nothing keeps its raw filter and is reported by --check-config.
+func expandAppFilters(apps map[string]AppConfig, root string) {
+	hasGlob := false
+	for _, app := range apps {
+		if isGlobFilter(app.Filter) && !isDirectoryFilter(app.Filter) {
+			hasGlob = true
+			break
+		}
+	}
+	if !hasGlob {
+		return
+	}
+
+	pkgs, err := discoverWorkspacePackages(root)
+	if err != nil {
+		return
+	}
+	for name, app := range apps {
+		if !isGlobFilter(app.Filter) || isDirectoryFilter(app.Filter) {
+			continue
+		}
+		app.packages = matchWorkspaceFilter(app.Filter, pkgs)
+		apps[name] = app
+	}
+}
+
+// validateAppFilters reports every app whose filter does not resolve to a
+// workspace package (or, for directory filters, to an existing directory).
+// Apps without a filter are skipped. Problems are sorted by app name.
+func validateAppFilters(apps map[string]AppConfig, pkgs []workspacePackage, root string) []string {
+	var problems []string
+	for _, name := range sortedAppNames(apps) {
+		filter := apps[name].Filter
+		if filter == "" {
+			continue
+		}
+		if isDirectoryFilter(filter) {
+			dir := strings.Trim(filter, "{}")
+			if info, err := os.Stat(filepath.Join(root, filepath.FromSlash(dir))); err != nil || !info.IsDir() {
+				problems = append(problems, fmt.Sprintf("app %q: filter %q points to a missing directory", name, filter))
+			}
+			continue
+		}
+		if len(matchWorkspaceFilter(filter, pkgs)) == 0 {
+			problems = append(problems, fmt.Sprintf("app %q: filter %q does not match any workspace package", name, filter))
+		}
+	}
+	return problems
+}
+
+// filterArgs returns the package-manager --filter arguments for the app: one
+// per expanded package for glob filters, otherwise the raw filter.
+func (a AppConfig) filterArgs() []string {
+	if len(a.packages) == 0 {
+		return []string{"--filter", a.Filter}
+	}
+	args := make([]string, 0, 2*len(a.packages))
+	for _, pkg := range a.packages {
+		args = append(args, "--filter", pkg.Name)
+	}
+	return args
+}
+
+// runCheckConfig implements --check-config: it loads .pre-commit.json and
+// verifies that each app's filter resolves against the workspace.
+func runCheckConfig() error {
+	config, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	pkgs, err := discoverWorkspacePackages(".")
+	if err != nil {
+		return err
+	}
+
+	problems := validateAppFilters(config.Apps, pkgs, ".")
+	if len(problems) > 0 {
+		fmt.Printf(glyph.Fail+" %d config problem(s):\n\n", len(problems))
+		for _, p := range problems {
+			fmt.Printf("  "+glyph.Bullet+" %s\n", p)
+		}
+		fmt.Println()
+		fmt.Printf(glyph.Hint+" %d workspace package(s) found in pnpm-workspace.yaml / package.json workspaces\n", len(pkgs))
+		return fmt.Errorf("%d config problem(s)", len(problems))
+	}
+
+	fmt.Printf(glyph.Pass+" Config OK (%d app(s), %d workspace package(s))\n", len(config.Apps), len(pkgs))
+	for _, name := range sortedAppNames(config.Apps) {
+		if app := config.Apps[name]; len(app.packages) > 0 {
+			fmt.Printf("   %s: %q expands to %d package(s)\n", name, app.Filter, len(app.packages))
+		}
+	}
+	return nil
+}
+
+// sortedAppNames returns the app names in apps, sorted.
+func sortedAppNames(apps map[string]AppConfig) []string {
+	names := make([]string, 0, len(apps))
+	for name := range apps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeWorkspace creates a pnpm workspace under a temp root with one
+// package.json per dir -> package name entry.
+func writeWorkspace(t *testing.T, yaml string, packages map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "pnpm-workspace.yaml"), []byte(yaml), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for dir, name := range packages {
+		full := filepath.Join(root, filepath.FromSlash(dir))
+		if err := os.MkdirAll(full, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(full, "package.json"), []byte(`{"name": "`+name+`"}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestDiscoverWorkspacePackages(t *testing.T) {
+	root := writeWorkspace(t, `packages:
+  - "apps/*"
+  - 'packages/**' # shared code
+  - "!packages/**/fixtures"
+catalog:
+  react: ^18
+`, map[string]string{
+		"apps/web":                  "@acme/web",
+		"packages/ui-button":        "@acme/ui-button",
+		"packages/nested/ui-card":   "@acme/ui-card",
+		"packages/ui-card/fixtures": "@acme/fixtures",
+		"tools/scripts":             "@acme/scripts",
+	})
+
+	pkgs, err := discoverWorkspacePackages(root)
+	if err != nil {
+		t.Fatalf("discoverWorkspacePackages() error = %v", err)
+	}
+	want := []workspacePackage{
+		{Name: "@acme/ui-button", Dir: "packages/ui-button"},
+		{Name: "@acme/ui-card", Dir: "packages/nested/ui-card"},
+		{Name: "@acme/web", Dir: "apps/web"},
+	}
+	if !reflect.DeepEqual(pkgs, want) {
+		t.Errorf("packages = %+v, want %+v", pkgs, want)
+	}
+}
+
+func TestWorkspacePatternsFromPackageJSON(t *testing.T) {
+	for name, content := range map[string]string{
+		"array":  `{"workspaces": ["apps/*", "packages/*"]}`,
+		"object": `{"workspaces": {"packages": ["apps/*", "packages/*"]}}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			root := t.TempDir()
+			if err := os.WriteFile(filepath.Join(root, "package.json"), []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if got := workspacePatterns(root); !reflect.DeepEqual(got, []string{"apps/*", "packages/*"}) {
+				t.Errorf("workspacePatterns() = %v", got)
+			}
+		})
+	}
+}
+
+func TestGlobFilterExpands(t *testing.T) {
+	root := writeWorkspace(t, "packages:\n  - packages/*\n  - apps/*\n", map[string]string{
+		"apps/web":           "@acme/web",
+		"packages/ui-button": "@acme/ui-button",
+		"packages/ui-card":   "@acme/ui-card",
+	})
+	apps := map[string]AppConfig{
+		"ui":  {Path: "packages", Filter: "@acme/ui-*"},
+		"web": {Path: "apps/web", Filter: "@acme/web"},
+	}
+
+	expandAppFilters(apps, root)
+
+	ui := apps["ui"]
+	if got := ui.filterArgs(); !reflect.DeepEqual(got, []string{"--filter", "@acme/ui-button", "--filter", "@acme/ui-card"}) {
+		t.Errorf("glob filterArgs() = %v", got)
+	}
+	if got := apps["web"].filterArgs(); !reflect.DeepEqual(got, []string{"--filter", "@acme/web"}) {
+		t.Errorf("plain filterArgs() = %v", got)
+	}
+
+	// Lint/typecheck get one job per expanded package, scoped to its files.
+	appFiles := map[string][]string{"ui": {"packages/ui-card/src/Card.tsx"}}
+	jobs := collectJobs(map[string]AppConfig{"ui": ui}, appFiles, false, false, func(AppConfig) bool { return false })
+	if len(jobs) != 1 {
+		t.Fatalf("incremental jobs = %+v, want only the package with staged files", jobs)
+	}
+	if jobs[0].name != "ui-ui-card" || jobs[0].config.Path != "packages/ui-card" || jobs[0].config.Filter != "@acme/ui-card" {
+		t.Errorf("job = %+v", jobs[0])
+	}
+	if full := collectJobs(map[string]AppConfig{"ui": ui}, appFiles, false, true, func(AppConfig) bool { return false }); len(full) != 2 {
+		t.Errorf("full jobs = %d, want one per package", len(full))
+	}
+}
+
+func TestValidateAppFilters(t *testing.T) {
+	root := writeWorkspace(t, "packages:\n  - apps/*\n", map[string]string{
+		"apps/web": "@acme/web",
+	})
+	pkgs, err := discoverWorkspacePackages(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	apps := map[string]AppConfig{
+		"web":     {Path: "apps/web", Filter: "@acme/web"},
+		"deps":    {Path: "apps/web", Filter: "...@acme/web"},
+		"dir":     {Path: "apps/web", Filter: "./apps/web"},
+		"none":    {Path: "apps/none"},
+		"typo":    {Path: "apps/mobile", Filter: "@acme/moblie"},
+		"glob":    {Path: "packages", Filter: "@acme/ui-*"},
+		"missing": {Path: "apps/gone", Filter: "{apps/gone}"},
+	}
+
+	problems := validateAppFilters(apps, pkgs, root)
+	if len(problems) != 3 {
+		t.Fatalf("problems = %v, want glob, missing and typo", problems)
+	}
+	for i, want := range []string{`app "glob"`, `app "missing"`, `app "typo"`} {
+		if !strings.HasPrefix(problems[i], want) {
+			t.Errorf("problem %d = %q, want prefix %q", i, problems[i], want)
+		}
+	}
+}
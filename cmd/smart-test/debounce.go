@@ -0,0 +1,106 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// defaultDebounceSeconds is how long a passing run covers repeat edits of the
+// same files when CLAUDE_HOOKS_DEBOUNCE_SECONDS is unset.
+const defaultDebounceSeconds = 3
+
+// now is the clock used for debouncing; tests pin it.
+var now = time.Now
+
+// debounceState is the per-project record of the last passing run.
+type debounceState struct {
+	Finished time.Time `json:"finished"`
+	Files    []string  `json:"files"`
+}
+
+// debounceWindow returns CLAUDE_HOOKS_DEBOUNCE_SECONDS as a duration. Zero
+// (or a negative or malformed value) disables debouncing.
+func debounceWindow() time.Duration {
+	val := os.Getenv("CLAUDE_HOOKS_DEBOUNCE_SECONDS")
+	if val == "" {
+		return defaultDebounceSeconds * time.Second
+	}
+	seconds, err := strconv.ParseFloat(val, 64)
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// debounceStatePath returns the state file for root in the temp dir.
+func debounceStatePath(root string) string {
+	sum := sha256.Sum256([]byte(root))
+	return filepath.Join(os.TempDir(), "claude-hooks-smart-test-"+hex.EncodeToString(sum[:8])+".json")
+}
+
+// loadDebounceState reads root's state; a missing or corrupt file is a zero
+// state.
+func loadDebounceState(root string) debounceState {
+	var state debounceState
+	data, err := os.ReadFile(debounceStatePath(root))
+	if err != nil {
+		return debounceState{}
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return debounceState{}
+	}
+	return state
+}
+
+// recentlyRan reports whether a passing run for root finished less than
+// window ago and already covered file.
+func recentlyRan(root, file string, window time.Duration) bool {
+	if window <= 0 {
+		return false
+	}
+	state := loadDebounceState(root)
+	if state.Finished.IsZero() || now().Sub(state.Finished) >= window {
+		return false
+	}
+	for _, f := range state.Files {
+		if f == file {
+			return true
+		}
+	}
+	return false
+}
+
+// recordRun marks a passing run for root that covered file. Files from a run
+// still inside the window carry over, so alternating edits between files
+// that have all just passed stay debounced.
+func recordRun(root, file string, window time.Duration) {
+	if window <= 0 {
+		return
+	}
+	state := debounceState{Finished: now(), Files: []string{file}}
+	if prev := loadDebounceState(root); !prev.Finished.IsZero() && state.Finished.Sub(prev.Finished) < window {
+		for _, f := range prev.Files {
+			if f != file {
+				state.Files = append(state.Files, f)
+			}
+		}
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(debounceStatePath(root), data, 0644)
+}
+
+// exitRecentlyRan reports the skipped run the same way a passing run is
+// reported (exit 2 with a continuation message).
+func exitRecentlyRan(window time.Duration) {
+	fmt.Fprintf(os.Stderr, "⏭️  Tests recently ran (within %s) and covered this file. Continue with your task.\n", window)
+	os.Exit(2)
+}
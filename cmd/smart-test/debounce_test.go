@@ -0,0 +1,102 @@
+package main
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+// pinClock points the debounce clock at *at and isolates state files in a
+// fresh temp dir.
+func pinClock(t *testing.T, at *time.Time) {
+	t.Helper()
+	t.Setenv("TMPDIR", t.TempDir())
+	origNow := now
+	now = func() time.Time { return *at }
+	t.Cleanup(func() { now = origNow })
+}
+
+func TestDebounceWindow(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 3 * time.Second},
+		{"5", 5 * time.Second},
+		{"0.5", 500 * time.Millisecond},
+		{"0", 0},
+		{"-1", 0},
+		{"soon", 0},
+	}
+	for _, tt := range tests {
+		t.Setenv("CLAUDE_HOOKS_DEBOUNCE_SECONDS", tt.value)
+		if got := debounceWindow(); got != tt.want {
+			t.Errorf("debounceWindow() with %q = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestRecentlyRanWindowBoundary(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	clock := start
+	pinClock(t, &clock)
+
+	const root, file = "/repo", "/repo/pkg/a.go"
+	window := 3 * time.Second
+
+	if recentlyRan(root, file, window) {
+		t.Fatal("no previous run: expected a run")
+	}
+	recordRun(root, file, window)
+
+	clock = start.Add(window - time.Millisecond)
+	if !recentlyRan(root, file, window) {
+		t.Error("just inside the window: expected the run to be skipped")
+	}
+
+	clock = start.Add(window)
+	if recentlyRan(root, file, window) {
+		t.Error("at the window boundary: expected a run")
+	}
+}
+
+func TestRecentlyRanNewFileStillRuns(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	clock := start
+	pinClock(t, &clock)
+
+	const root = "/repo"
+	window := 3 * time.Second
+	recordRun(root, "/repo/a.go", window)
+
+	clock = start.Add(time.Second)
+	if recentlyRan(root, "/repo/b.go", window) {
+		t.Error("a file the last run did not cover must trigger a run")
+	}
+	if recentlyRan("/other", "/repo/a.go", window) {
+		t.Error("state is keyed by project root")
+	}
+
+	// A second passing run inside the window covers both files.
+	recordRun(root, "/repo/b.go", window)
+	clock = start.Add(2 * time.Second)
+	for _, f := range []string{"/repo/a.go", "/repo/b.go"} {
+		if !recentlyRan(root, f, window) {
+			t.Errorf("%s should be covered by the accumulated runs", f)
+		}
+	}
+}
+
+func TestRecentlyRanDisabled(t *testing.T) {
+	clock := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	pinClock(t, &clock)
+
+	recordRun("/repo", "/repo/a.go", 0)
+	if _, err := os.Stat(debounceStatePath("/repo")); err == nil {
+		t.Error("a zero window must not write state")
+	}
+	recordRun("/repo", "/repo/a.go", 3*time.Second)
+	if recentlyRan("/repo", "/repo/a.go", 0) {
+		t.Error("a zero window must never skip")
+	}
+}
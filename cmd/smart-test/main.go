@@ -2,13 +2,17 @@ package main
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
+
+	"github.com/milehighideas/claude-hooks/internal/pkgmanager"
 )
 
 // HookEvent represents the JSON input from Claude Code
@@ -21,9 +25,58 @@ type HookEvent struct {
 
 // ProjectConfig represents .claude-hooks.json configuration
 type ProjectConfig struct {
-	Lint      string `json:"lint"`      // Custom lint command
-	Test      string `json:"test"`      // Custom test command (e.g., "pnpm turbo test")
-	Typecheck string `json:"typecheck"` // Custom typecheck command
+	Lint           string            `json:"lint"`           // Custom lint command
+	Test           string            `json:"test"`           // Custom test command for every language (e.g., "pnpm turbo test")
+	Tests          map[string]string `json:"tests"`          // Per-language test commands keyed by language ("go", "javascript", ...)
+	Typecheck      string            `json:"typecheck"`      // Custom typecheck command
+	PackageManager string            `json:"packageManager"` // JavaScript package manager when package.json and lockfiles don't decide (default "npm")
+	SkipDirs       []string          `json:"skipDirs"`       // Extra directory names to skip when walking, merged with defaultSkipDirs
+}
+
+// defaultSkipDirs are directory names never walked during project detection
+// or test file discovery.
+var defaultSkipDirs = []string{".git", "node_modules", "venv", ".venv", "target", "dist", "build"}
+
+// skipDirs returns the directory names to skip while walking the project:
+// defaultSkipDirs plus the config's skipDirs.
+func (c *ProjectConfig) skipDirs() map[string]bool {
+	dirs := make(map[string]bool, len(defaultSkipDirs))
+	for _, d := range defaultSkipDirs {
+		dirs[d] = true
+	}
+	if c == nil {
+		return dirs
+	}
+	for _, d := range c.SkipDirs {
+		if d = strings.Trim(strings.TrimSpace(d), "/"); d != "" {
+			dirs[d] = true
+		}
+	}
+	return dirs
+}
+
+// languageTestCommand returns the configured test command for lang, or ""
+// when the built-in runner should be used.
+func (c *ProjectConfig) languageTestCommand(lang string) string {
+	if c == nil {
+		return ""
+	}
+	return c.Tests[lang]
+}
+
+// packageManager resolves the JavaScript package manager for the project at
+// root: package.json's packageManager field, then a lockfile, then the
+// configured one. Nothing found defaults to npm with pkgmanager.SourceNone.
+func (c *ProjectConfig) packageManager(root string) pkgmanager.Resolution {
+	configured := ""
+	if c != nil {
+		configured = c.PackageManager
+	}
+	r := pkgmanager.Resolve(root, configured)
+	if r.Name == "" {
+		r.Name = "npm"
+	}
+	return r
 }
 
 // ProjectType represents detected project languages
@@ -31,9 +84,11 @@ type ProjectType struct {
 	Languages []string
 }
 
-// ErrorCollector tracks test failures
+// ErrorCollector tracks test failures, and the counts each test run
+// reported when its output could be parsed.
 type ErrorCollector struct {
 	errors []string
+	counts []testCounts
 }
 
 func (ec *ErrorCollector) Add(msg string) {
@@ -45,6 +100,26 @@ func (ec *ErrorCollector) Count() int {
 	return len(ec.errors)
 }
 
+// recordCounts keeps the counts parsed from one run's output, if any.
+func (ec *ErrorCollector) recordCounts(output []byte) {
+	if c, ok := parseTestCounts(string(output)); ok {
+		ec.counts = append(ec.counts, c)
+	}
+}
+
+// countsSummary joins the recorded counts, e.g. " (vitest: 12 passed of 12
+// tests)", or returns "" when no run's output could be parsed.
+func (ec *ErrorCollector) countsSummary() string {
+	if len(ec.counts) == 0 {
+		return ""
+	}
+	parts := make([]string, len(ec.counts))
+	for i, c := range ec.counts {
+		parts[i] = c.String()
+	}
+	return " (" + strings.Join(parts, "; ") + ")"
+}
+
 func main() {
 	if err := run(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -95,24 +170,66 @@ func run() error {
 		os.Exit(0)
 	}
 
+	// A .claude-hooks-test-map.json rule for this file takes precedence over
+	// everything below, the non-code skip included.
+	var mapped *testMapRule
+	if projectRoot != "" {
+		if rel, err := filepath.Rel(projectRoot, filePath); err == nil {
+			mapped = matchTestMap(loadTestMap(projectRoot), rel)
+		}
+	}
+
+	// Docs, data, and images can't change test results; skip them before
+	// detecting the project or starting any runner.
+	if mapped == nil && isNonCodeEdit(filePath, projectRoot, ignorePatterns) {
+		return nil
+	}
+
+	// Debounce rapid successive edits: skip when a passing run for this
+	// project just covered the file.
+	debounceRoot := projectRoot
+	if debounceRoot == "" {
+		debounceRoot = fileDir
+	}
+	window := debounceWindow()
+	if recentlyRan(debounceRoot, filePath, window) {
+		exitRecentlyRan(window)
+	}
+
 	// Collect errors
 	errorCollector := &ErrorCollector{}
 
+	// finish records a passing run for the debounce, then reports the result.
+	finish := func() error {
+		if errorCollector.Count() == 0 {
+			recordRun(debounceRoot, filePath, window)
+		}
+		return exitWithResult(errorCollector)
+	}
+
+	if mapped != nil {
+		runCustomCommand(mapped.Command, projectRoot, errorCollector)
+		return finish()
+	}
+
 	// Check for project-level config first (.claude-hooks.json)
+	var config *ProjectConfig
 	if projectRoot != "" {
-		config, err := loadProjectConfig(projectRoot)
-		if err == nil && config.Test != "" {
+		if loaded, err := loadProjectConfig(projectRoot); err == nil {
+			config = loaded
+		}
+		if config != nil && config.Test != "" {
 			// Change to project root to run the command
 			if err := os.Chdir(projectRoot); err != nil {
 				return fmt.Errorf("failed to change to project root: %w", err)
 			}
-			runCustomCommand(config.Test, errorCollector)
-			return exitWithResult(errorCollector)
+			runCustomCommand(config.Test, "", errorCollector)
+			return finish()
 		}
 	}
 
 	// Detect project type
-	projectType := detectProjectType()
+	projectType := detectProjectType(config.skipDirs())
 	if len(projectType.Languages) == 0 {
 		// No recognized project type, exit silently
 		os.Exit(0)
@@ -121,15 +238,15 @@ func run() error {
 	// Try project commands (make test or scripts/test.sh)
 	if tryProjectCommand(filePath, ignorePatterns, errorCollector) {
 		// Project command handled testing
-		return exitWithResult(errorCollector)
+		return finish()
 	}
 
 	// Fall back to language-specific test runners
 	for _, lang := range projectType.Languages {
-		runLanguageTests(lang, filePath, ignorePatterns, errorCollector)
+		runLanguageTests(lang, filePath, ignorePatterns, config, projectRoot, errorCollector)
 	}
 
-	return exitWithResult(errorCollector)
+	return finish()
 }
 
 func parseHookEvent(r io.Reader) (*HookEvent, error) {
@@ -169,31 +286,77 @@ func isRaceEnabled() bool {
 	return val == "true" || val == "1"
 }
 
-func detectProjectType() *ProjectType {
+// isFullTestForced reports whether CLAUDE_HOOKS_FULL_TEST asks for the whole
+// suite instead of the packages/tests related to the edited file.
+func isFullTestForced() bool {
+	val := os.Getenv("CLAUDE_HOOKS_FULL_TEST")
+	return val == "true" || val == "1"
+}
+
+// isStreamEnabled reports whether CLAUDE_HOOKS_STREAM asks for test output to
+// be streamed live instead of shown after a failure.
+func isStreamEnabled() bool {
+	val := os.Getenv("CLAUDE_HOOKS_STREAM")
+	return val == "true" || val == "1"
+}
+
+// testOutput is where streamed test output goes; tests swap it for a buffer.
+var testOutput io.Writer = os.Stderr
+
+// runTestCommand runs cmd and records failMsg on failure. With streaming on,
+// stdout and stderr go to testOutput as they are written; otherwise the
+// combined output is printed only when the command fails. Either way the
+// output is parsed for the runner's pass/fail counts.
+func runTestCommand(cmd *exec.Cmd, failMsg string, ec *ErrorCollector) {
+	if isStreamEnabled() {
+		var output bytes.Buffer
+		cmd.Stdout = io.MultiWriter(testOutput, &output)
+		cmd.Stderr = cmd.Stdout
+		err := cmd.Run()
+		ec.recordCounts(output.Bytes())
+		if err != nil {
+			ec.Add(failMsg)
+		}
+		return
+	}
+
+	output, err := cmd.CombinedOutput()
+	ec.recordCounts(output)
+	if err != nil {
+		ec.Add(failMsg)
+		if len(output) > 0 {
+			fmt.Fprint(testOutput, string(output))
+		}
+	}
+}
+
+// detectProjectType identifies the project's languages from marker files
+// and source files, never looking inside skipDirs.
+func detectProjectType(skipDirs map[string]bool) *ProjectType {
 	pt := &ProjectType{Languages: []string{}}
 
 	// Go project
-	if fileExists("go.mod") || fileExists("go.sum") || hasFilesWithExtension(".go", 3) {
+	if fileExists("go.mod") || fileExists("go.sum") || hasFilesWithExtension(".go", 3, skipDirs) {
 		pt.Languages = append(pt.Languages, "go")
 	}
 
 	// Python project
-	if fileExists("pyproject.toml") || fileExists("setup.py") || fileExists("requirements.txt") || hasFilesWithExtension(".py", 3) {
+	if fileExists("pyproject.toml") || fileExists("setup.py") || fileExists("requirements.txt") || hasFilesWithExtension(".py", 3, skipDirs) {
 		pt.Languages = append(pt.Languages, "python")
 	}
 
 	// JavaScript/TypeScript project
-	if fileExists("package.json") || fileExists("tsconfig.json") || hasFilesWithExtensions([]string{".js", ".ts", ".jsx", ".tsx"}, 3) {
+	if fileExists("package.json") || fileExists("tsconfig.json") || hasFilesWithExtensions([]string{".js", ".ts", ".jsx", ".tsx"}, 3, skipDirs) {
 		pt.Languages = append(pt.Languages, "javascript")
 	}
 
 	// Rust project
-	if fileExists("Cargo.toml") || hasFilesWithExtension(".rs", 3) {
+	if fileExists("Cargo.toml") || hasFilesWithExtension(".rs", 3, skipDirs) {
 		pt.Languages = append(pt.Languages, "rust")
 	}
 
 	// Shell scripts
-	if hasFilesWithExtensions([]string{".sh", ".bash"}, 3) {
+	if hasFilesWithExtensions([]string{".sh", ".bash"}, 3, skipDirs) {
 		pt.Languages = append(pt.Languages, "shell")
 	}
 
@@ -205,7 +368,7 @@ func fileExists(path string) bool {
 	return err == nil
 }
 
-func hasFilesWithExtension(ext string, maxDepth int) bool {
+func hasFilesWithExtension(ext string, maxDepth int, skipDirs map[string]bool) bool {
 	found := false
 	_ = filepath.Walk(".", func(path string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -216,7 +379,7 @@ func hasFilesWithExtension(ext string, maxDepth int) bool {
 		}
 		if info.IsDir() {
 			depth := strings.Count(path, string(os.PathSeparator))
-			if depth > maxDepth {
+			if depth > maxDepth || (path != "." && skipDirs[info.Name()]) {
 				return filepath.SkipDir
 			}
 		}
@@ -229,9 +392,9 @@ func hasFilesWithExtension(ext string, maxDepth int) bool {
 	return found
 }
 
-func hasFilesWithExtensions(exts []string, maxDepth int) bool {
+func hasFilesWithExtensions(exts []string, maxDepth int, skipDirs map[string]bool) bool {
 	for _, ext := range exts {
-		if hasFilesWithExtension(ext, maxDepth) {
+		if hasFilesWithExtension(ext, maxDepth, skipDirs) {
 			return true
 		}
 	}
@@ -286,7 +449,9 @@ func loadProjectConfig(projectRoot string) (*ProjectConfig, error) {
 	return &config, nil
 }
 
-func runCustomCommand(command string, ec *ErrorCollector) {
+// runCustomCommand runs a configured test command in dir ("" = the current
+// directory).
+func runCustomCommand(command, dir string, ec *ErrorCollector) {
 	// Parse the command string into parts
 	parts := parseCommand(command)
 	if len(parts) == 0 {
@@ -294,13 +459,8 @@ func runCustomCommand(command string, ec *ErrorCollector) {
 	}
 
 	cmd := exec.Command(parts[0], parts[1:]...)
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		ec.Add(fmt.Sprintf("test command failed: %s", command))
-		if len(output) > 0 {
-			fmt.Fprint(os.Stderr, string(output))
-		}
-	}
+	cmd.Dir = dir
+	runTestCommand(cmd, fmt.Sprintf("test command failed: %s", command), ec)
 }
 
 func parseCommand(command string) []string {
@@ -398,17 +558,58 @@ func shouldSkipFile(filePath string, patterns []string) bool {
 	return false
 }
 
+// nonCodeExtensions are edits that never trigger a test run. Add more with
+// patterns in .claude-hooks-ignore; re-enable one with a negated pattern
+// (e.g. "!*.json").
+var nonCodeExtensions = map[string]bool{
+	".md": true, ".mdx": true, ".txt": true,
+	".json": true, ".yml": true, ".yaml": true,
+	".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".svg": true, ".webp": true, ".ico": true,
+}
+
+// testAffectingFiles are configs with a non-code extension that still change
+// how tests build or run. (go.mod and friends never match nonCodeExtensions.)
+var testAffectingFiles = map[string]bool{
+	"package.json":  true,
+	"tsconfig.json": true,
+}
+
+// isNonCodeEdit reports whether filePath is a non-code file whose edit should
+// not run tests. A "!pattern" line in .claude-hooks-ignore that matches the
+// file opts it back in; patterns with a directory ("!fixtures/*.json") match
+// relative to projectRoot, where the ignore file lives.
+func isNonCodeEdit(filePath, projectRoot string, ignorePatterns []string) bool {
+	if testAffectingFiles[filepath.Base(filePath)] {
+		return false
+	}
+	if !nonCodeExtensions[strings.ToLower(filepath.Ext(filePath))] {
+		return false
+	}
+	candidates := []string{filePath}
+	if projectRoot != "" {
+		if rel, err := filepath.Rel(projectRoot, filePath); err == nil && !strings.HasPrefix(rel, "..") {
+			candidates = append(candidates, filepath.ToSlash(rel))
+		}
+	}
+	for _, pattern := range ignorePatterns {
+		negated, ok := strings.CutPrefix(pattern, "!")
+		if !ok {
+			continue
+		}
+		for _, candidate := range candidates {
+			if shouldSkipFile(candidate, []string{negated}) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 func tryProjectCommand(filePath string, ignorePatterns []string, ec *ErrorCollector) bool {
 	// Try make test
 	if fileExists("Makefile") {
 		if commandExists("make") && makeTargetExists("test") {
-			output, err := exec.Command("make", "test").CombinedOutput()
-			if err != nil {
-				ec.Add("make test failed")
-				if len(output) > 0 {
-					fmt.Fprint(os.Stderr, string(output))
-				}
-			}
+			runTestCommand(exec.Command("make", "test"), "make test failed", ec)
 			return true
 		}
 	}
@@ -419,13 +620,7 @@ func tryProjectCommand(filePath string, ignorePatterns []string, ec *ErrorCollec
 		if !fileExists(scriptPath) {
 			scriptPath = "scripts/test"
 		}
-		output, err := exec.Command(scriptPath).CombinedOutput()
-		if err != nil {
-			ec.Add("scripts/test failed")
-			if len(output) > 0 {
-				fmt.Fprint(os.Stderr, string(output))
-			}
-		}
+		runTestCommand(exec.Command(scriptPath), "scripts/test failed", ec)
 		return true
 	}
 
@@ -440,29 +635,73 @@ func makeTargetExists(target string) bool {
 	return err == nil
 }
 
+// lookPath is the indirection point for tests; production code resolves
+// binaries with exec.LookPath.
+var lookPath = exec.LookPath
+
 func commandExists(name string) bool {
-	_, err := exec.LookPath(name)
+	_, err := lookPath(name)
 	return err == nil
 }
 
-func runLanguageTests(lang string, filePath string, ignorePatterns []string, ec *ErrorCollector) {
+// checkPackageManager verifies the JavaScript package manager up front so a
+// missing binary is reported by name instead of as a failed test run. A
+// defaulted npm that is absent is not an error; the runner just skips.
+func checkPackageManager(config *ProjectConfig, root string) (string, error) {
+	pm := config.packageManager(root)
+	if commandExists(pm.Name) {
+		return pm.Name, nil
+	}
+	if pm.Source != pkgmanager.SourceNone {
+		return "", fmt.Errorf("package manager %q (from %s) is not installed or not on PATH", pm.Name, pm.Describe("packageManager in .claude-hooks.json"))
+	}
+	return "", nil
+}
+
+// testScriptArgs returns the args that run the package.json "test" script.
+// bun needs an explicit `run` (`bun test` is bun's own test runner).
+func testScriptArgs(packageManager string) []string {
+	if packageManager == "bun" {
+		return []string{"run", "test"}
+	}
+	return []string{"test"}
+}
+
+// runLanguageTests runs lang's tests: the command from the config's tests map
+// when one is set (from the project root), else the built-in runner.
+func runLanguageTests(lang string, filePath string, ignorePatterns []string, config *ProjectConfig, projectRoot string, ec *ErrorCollector) {
+	if command := config.languageTestCommand(lang); command != "" {
+		runCustomCommand(command, projectRoot, ec)
+		return
+	}
+
+	skipDirs := config.skipDirs()
 	switch lang {
 	case "go":
-		testGo(filePath, ignorePatterns, ec)
+		testGo(filePath, ignorePatterns, skipDirs, ec)
 	case "python":
-		testPython(filePath, ignorePatterns, ec)
+		testPython(filePath, ignorePatterns, skipDirs, ec)
 	case "javascript":
-		testJavaScript(filePath, ignorePatterns, ec)
+		root := projectRoot
+		if root == "" {
+			root = "."
+		}
+		pm, err := checkPackageManager(config, root)
+		if err != nil {
+			ec.Add(err.Error())
+			return
+		}
+		testJavaScript(filePath, ignorePatterns, skipDirs, pm, ec)
 	case "rust":
-		testRust(filePath, ignorePatterns, ec)
+		testRust(filePath, ignorePatterns, skipDirs, ec)
 	case "shell":
 		testShell(filePath, ignorePatterns, ec)
 	}
 }
 
-func testGo(filePath string, ignorePatterns []string, ec *ErrorCollector) {
+func testGo(filePath string, ignorePatterns []string, skipDirs map[string]bool, ec *ErrorCollector) {
 	// Find Go files
-	files := findFiles([]string{".go"}, ignorePatterns)
+	files := findFiles([]string{".go"}, ignorePatterns, skipDirs)
 	if len(files) == 0 {
 		return
 	}
@@ -476,68 +715,157 @@ func testGo(filePath string, ignorePatterns []string, ec *ErrorCollector) {
 	if isRaceEnabled() {
 		args = append(args, "-race")
 	}
-	args = append(args, "./...")
+
+	// Scope to the edited package and its importers; fall back to the whole
+	// tree below the edited file when resolution fails.
+	dir, targets := "", []string{"./..."}
+	if !isFullTestForced() {
+		if root, pkgs, err := goTestTargets(); err == nil {
+			dir, targets = root, pkgs
+		}
+	}
+	args = append(args, targets...)
 
 	// Run tests
-	output, err := exec.Command("go", args...).CombinedOutput()
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+	runTestCommand(cmd, "go test failed", ec)
+}
+
+// goTestTargets resolves the Go packages affected by an edit in the current
+// directory: the edited package plus every package in its module that
+// imports it, directly or transitively. root is the module directory the
+// import paths must be tested from.
+func goTestTargets() (root string, pkgs []string, err error) {
+	out, err := exec.Command("go", "list", "-f", `{{.ImportPath}}{{"\t"}}{{with .Module}}{{.Dir}}{{end}}`, ".").Output()
 	if err != nil {
-		ec.Add("go test failed")
-		if len(output) > 0 {
-			fmt.Fprint(os.Stderr, string(output))
+		return "", nil, err
+	}
+	fields := strings.SplitN(strings.TrimSpace(string(out)), "\t", 2)
+	if len(fields) != 2 || fields[1] == "" {
+		return "", nil, fmt.Errorf("no module for package %q", fields[0])
+	}
+	target, root := fields[0], fields[1]
+
+	cmd := exec.Command("go", "list", "-f", `{{.ImportPath}}{{"\t"}}{{join .Deps " "}}`, "./...")
+	cmd.Dir = root
+	out, err = cmd.Output()
+	if err != nil {
+		return "", nil, err
+	}
+	return root, dependentPackages(target, string(out)), nil
+}
+
+// dependentPackages parses `go list -f '{{.ImportPath}}\t{{join .Deps " "}}'`
+// output and returns target followed by every package whose deps include it.
+func dependentPackages(target, listOutput string) []string {
+	pkgs := []string{target}
+	for _, line := range strings.Split(listOutput, "\n") {
+		importPath, deps, _ := strings.Cut(strings.TrimSpace(line), "\t")
+		if importPath == "" || importPath == target {
+			continue
+		}
+		for _, dep := range strings.Fields(deps) {
+			if dep == target {
+				pkgs = append(pkgs, importPath)
+				break
+			}
 		}
 	}
+	return pkgs
 }
 
-func testPython(filePath string, ignorePatterns []string, ec *ErrorCollector) {
-	files := findFiles([]string{".py"}, ignorePatterns)
+func testPython(filePath string, ignorePatterns []string, skipDirs map[string]bool, ec *ErrorCollector) {
+	files := findFiles([]string{".py"}, ignorePatterns, skipDirs)
 	if len(files) == 0 {
 		return
 	}
 
 	// Try pytest first
 	if commandExists("pytest") {
-		output, err := exec.Command("pytest").CombinedOutput()
-		if err != nil {
-			ec.Add("pytest failed")
-			if len(output) > 0 {
-				fmt.Fprint(os.Stderr, string(output))
-			}
-		}
+		runTestCommand(exec.Command("pytest"), "pytest failed", ec)
 		return
 	}
 
 	// Fall back to unittest
 	if commandExists("python") {
-		output, err := exec.Command("python", "-m", "unittest", "discover").CombinedOutput()
-		if err != nil {
-			ec.Add("python unittest failed")
-			if len(output) > 0 {
-				fmt.Fprint(os.Stderr, string(output))
-			}
-		}
+		runTestCommand(exec.Command("python", "-m", "unittest", "discover"), "python unittest failed", ec)
 	}
 }
 
-func testJavaScript(filePath string, ignorePatterns []string, ec *ErrorCollector) {
-	files := findFiles([]string{".js", ".ts", ".jsx", ".tsx"}, ignorePatterns)
+// testJavaScript runs the tests related to filePath when the project uses
+// vitest or jest, else the test script with packageManager ("" when none is
+// installed, in which case only the related-tests path can run).
+func testJavaScript(filePath string, ignorePatterns []string, skipDirs map[string]bool, packageManager string, ec *ErrorCollector) {
+	files := findFiles([]string{".js", ".ts", ".jsx", ".tsx"}, ignorePatterns, skipDirs)
 	if len(files) == 0 {
 		return
 	}
 
-	// Run npm test if package.json exists
-	if fileExists("package.json") && commandExists("npm") {
-		output, err := exec.Command("npm", "test").CombinedOutput()
-		if err != nil {
-			ec.Add("npm test failed")
-			if len(output) > 0 {
-				fmt.Fprint(os.Stderr, string(output))
-			}
+	// Prefer running only the tests related to the edited file when the
+	// project's test framework supports it.
+	if !isFullTestForced() {
+		framework := jsTestFramework("package.json")
+		if args := relatedTestsArgs(framework, filePath); args != nil && commandExists("npx") {
+			runTestCommand(exec.Command("npx", args...), framework+" related tests failed", ec)
+			return
 		}
 	}
+
+	// Run the test script if package.json exists
+	if fileExists("package.json") && packageManager != "" {
+		runTestCommand(exec.Command(packageManager, testScriptArgs(packageManager)...), packageManager+" test failed", ec)
+	}
 }
 
-func testRust(filePath string, ignorePatterns []string, ec *ErrorCollector) {
-	files := findFiles([]string{".rs"}, ignorePatterns)
+// jsTestFramework returns "vitest" or "jest" for the package.json at path, or
+// "" when neither is found. The "test" script decides when it names one,
+// otherwise the dependencies and devDependencies do, vitest first.
+func jsTestFramework(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	var pkg struct {
+		Scripts         map[string]string `json:"scripts"`
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return ""
+	}
+	fields := strings.Fields(pkg.Scripts["test"])
+	for _, framework := range []string{"vitest", "jest"} {
+		if slices.Contains(fields, framework) {
+			return framework
+		}
+	}
+	for _, framework := range []string{"vitest", "jest"} {
+		_, dep := pkg.Dependencies[framework]
+		_, devDep := pkg.DevDependencies[framework]
+		if dep || devDep {
+			return framework
+		}
+	}
+	return ""
+}
+
+// relatedTestsArgs returns the npx args that run only the tests related to
+// filePath under framework, or nil when the framework is unknown. jest exits
+// non-zero when nothing is related, so --passWithNoTests keeps an edit to an
+// untested file from failing.
+func relatedTestsArgs(framework, filePath string) []string {
+	switch framework {
+	case "vitest":
+		return []string{"vitest", "related", "--run", filePath}
+	case "jest":
+		return []string{"jest", "--findRelatedTests", "--passWithNoTests", filePath}
+	}
+	return nil
+}
+
+func testRust(filePath string, ignorePatterns []string, skipDirs map[string]bool, ec *ErrorCollector) {
+	files := findFiles([]string{".rs"}, ignorePatterns, skipDirs)
 	if len(files) == 0 {
 		return
 	}
@@ -547,13 +875,7 @@ func testRust(filePath string, ignorePatterns []string, ec *ErrorCollector) {
 	}
 
 	// Run cargo test
-	output, err := exec.Command("cargo", "test").CombinedOutput()
-	if err != nil {
-		ec.Add("cargo test failed")
-		if len(output) > 0 {
-			fmt.Fprint(os.Stderr, string(output))
-		}
-	}
+	runTestCommand(exec.Command("cargo", "test"), "cargo test failed", ec)
 }
 
 func testShell(filePath string, ignorePatterns []string, ec *ErrorCollector) {
@@ -572,18 +894,14 @@ func testShell(filePath string, ignorePatterns []string, ec *ErrorCollector) {
 
 	for _, testFile := range testFiles {
 		if fileExists(testFile) {
-			output, err := exec.Command("bash", testFile).CombinedOutput()
-			if err != nil {
-				ec.Add(fmt.Sprintf("shell test %s failed", testFile))
-				if len(output) > 0 {
-					fmt.Fprint(os.Stderr, string(output))
-				}
-			}
+			runTestCommand(exec.Command("bash", testFile), fmt.Sprintf("shell test %s failed", testFile), ec)
 		}
 	}
 }
 
-func findFiles(extensions []string, ignorePatterns []string) []string {
+// findFiles returns the files below the current directory with one of
+// extensions, skipping ignored files and any directory named in skipDirs.
+func findFiles(extensions []string, ignorePatterns []string, skipDirs map[string]bool) []string {
 	files := []string{}
 
 	extMap := make(map[string]bool)
@@ -596,12 +914,9 @@ func findFiles(extensions []string, ignorePatterns []string) []string {
 			return nil
 		}
 
-		// Skip hidden directories and common ignore patterns
-		if info.IsDir() {
-			base := filepath.Base(path)
-			if base == ".git" || base == "node_modules" || base == "venv" || base == ".venv" || base == "target" || base == "dist" || base == "build" {
-				return filepath.SkipDir
-			}
+		// Skip vendored and build output directories
+		if info.IsDir() && path != "." && skipDirs[info.Name()] {
+			return filepath.SkipDir
 		}
 
 		if !info.IsDir() && extMap[filepath.Ext(path)] {
@@ -618,13 +933,13 @@ func findFiles(extensions []string, ignorePatterns []string) []string {
 
 func exitWithResult(ec *ErrorCollector) error {
 	if ec.Count() > 0 {
-		fmt.Fprintf(os.Stderr, "\n❌ Tests failed with %d error(s)\n", ec.Count())
+		fmt.Fprintf(os.Stderr, "\n❌ Tests failed with %d error(s)%s\n", ec.Count(), ec.countsSummary())
 		fmt.Fprintf(os.Stderr, "⛔ BLOCKING: Fix ALL test failures above before continuing\n")
 		os.Exit(2)
 	}
 
 	// Success - exit with code 2 to show continuation message
-	fmt.Fprintf(os.Stderr, "✅ All tests passed. Continue with your task.\n")
+	fmt.Fprintf(os.Stderr, "✅ All tests passed%s. Continue with your task.\n", ec.countsSummary())
 	os.Exit(2)
 	return nil
 }
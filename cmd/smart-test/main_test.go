@@ -3,8 +3,11 @@ package main
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -183,7 +186,7 @@ func TestDetectProjectType(t *testing.T) {
 			}()
 
 			// Detect project type
-			pt := detectProjectType()
+			pt := detectProjectType(nil)
 
 			// Check results
 			if len(pt.Languages) != len(tt.wantLangs) {
@@ -531,3 +534,407 @@ func TestHookEventProcessing(t *testing.T) {
 		t.Errorf("file_path = %q, want %q", filePath, "/path/to/file.go")
 	}
 }
+
+func TestDependentPackages(t *testing.T) {
+	listOutput := "example.com/m/a\t\n" +
+		"example.com/m/b\texample.com/m/a fmt\n" +
+		"example.com/m/c\tfmt strings\n" +
+		"example.com/m/d\texample.com/m/a example.com/m/b fmt\n"
+
+	got := dependentPackages("example.com/m/a", listOutput)
+	want := []string{"example.com/m/a", "example.com/m/b", "example.com/m/d"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("dependentPackages() = %v, want %v", got, want)
+	}
+}
+
+func TestGoTestTargets(t *testing.T) {
+	if !commandExists("go") {
+		t.Skip("go not installed")
+	}
+
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/m\n\ngo 1.21\n",
+		"a/a.go": "package a\n\nfunc A() int { return 1 }\n",
+		"b/b.go": "package b\n\nimport \"example.com/m/a\"\n\nfunc B() int { return a.A() }\n",
+		"c/c.go": "package c\n\nfunc C() int { return 3 }\n",
+		"d/d.go": "package d\n\nimport \"example.com/m/b\"\n\nfunc D() int { return b.B() }\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	origDir, _ := os.Getwd()
+	defer func() { _ = os.Chdir(origDir) }()
+	if err := os.Chdir(filepath.Join(root, "a")); err != nil {
+		t.Fatal(err)
+	}
+
+	gotRoot, pkgs, err := goTestTargets()
+	if err != nil {
+		t.Fatalf("goTestTargets() error = %v", err)
+	}
+	wantRoot, _ := filepath.EvalSymlinks(root)
+	if r, _ := filepath.EvalSymlinks(gotRoot); r != wantRoot {
+		t.Errorf("root = %q, want %q", gotRoot, root)
+	}
+	// a is edited; b imports it directly and d transitively; c is unrelated.
+	want := []string{"example.com/m/a", "example.com/m/b", "example.com/m/d"}
+	if strings.Join(pkgs, ",") != strings.Join(want, ",") {
+		t.Errorf("pkgs = %v, want %v", pkgs, want)
+	}
+}
+
+func TestGoTestTargets_NoModuleFails(t *testing.T) {
+	if !commandExists("go") {
+		t.Skip("go not installed")
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "x.go"), []byte("package x\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	origDir, _ := os.Getwd()
+	defer func() { _ = os.Chdir(origDir) }()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GO111MODULE", "on")
+	if _, _, err := goTestTargets(); err == nil {
+		t.Error("expected error outside a module so testGo falls back to ./...")
+	}
+}
+
+func TestIsFullTestForced(t *testing.T) {
+	for _, tt := range []struct {
+		val  string
+		want bool
+	}{{"", false}, {"1", true}, {"true", true}, {"0", false}} {
+		t.Setenv("CLAUDE_HOOKS_FULL_TEST", tt.val)
+		if got := isFullTestForced(); got != tt.want {
+			t.Errorf("isFullTestForced() with %q = %v, want %v", tt.val, got, tt.want)
+		}
+	}
+}
+
+func TestJSTestFramework(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"vitest devDependency", `{"devDependencies": {"vitest": "^1.0.0"}}`, "vitest"},
+		{"vitest dependency", `{"dependencies": {"vitest": "^1.0.0"}}`, "vitest"},
+		{"jest devDependency", `{"devDependencies": {"jest": "^29.0.0"}}`, "jest"},
+		{"jest dependency", `{"dependencies": {"jest": "^29.0.0"}}`, "jest"},
+		{"both, no test script", `{"devDependencies": {"jest": "^29.0.0", "vitest": "^1.0.0"}}`, "vitest"},
+		{"test script names jest", `{"scripts": {"test": "jest --ci"}, "devDependencies": {"jest": "^29.0.0", "vitest": "^1.0.0"}}`, "jest"},
+		{"test script names vitest", `{"scripts": {"test": "vitest run"}}`, "vitest"},
+		{"unknown framework", `{"scripts": {"test": "mocha"}, "devDependencies": {"mocha": "^10.0.0"}}`, ""},
+		{"invalid json", `{`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, "package.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if got := jsTestFramework(path); got != tt.want {
+				t.Errorf("jsTestFramework() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+	if got := jsTestFramework(filepath.Join(dir, "missing.json")); got != "" {
+		t.Errorf("missing package.json reported %q, want none", got)
+	}
+}
+
+func TestRelatedTestsArgs(t *testing.T) {
+	tests := []struct {
+		framework string
+		want      []string
+	}{
+		{"vitest", []string{"vitest", "related", "--run", "src/app.ts"}},
+		{"jest", []string{"jest", "--findRelatedTests", "--passWithNoTests", "src/app.ts"}},
+		{"", nil},
+		{"mocha", nil},
+	}
+	for _, tt := range tests {
+		if got := relatedTestsArgs(tt.framework, "src/app.ts"); !slices.Equal(got, tt.want) {
+			t.Errorf("relatedTestsArgs(%q) = %v, want %v", tt.framework, got, tt.want)
+		}
+	}
+}
+
+func TestLoadProjectConfigPerLanguageTests(t *testing.T) {
+	dir := t.TempDir()
+	content := `{"tests": {"javascript": "pnpm vitest run"}}`
+	if err := os.WriteFile(filepath.Join(dir, ".claude-hooks.json"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := loadProjectConfig(dir)
+	if err != nil {
+		t.Fatalf("loadProjectConfig() error = %v", err)
+	}
+	if config.Test != "" {
+		t.Errorf("Test = %q, want empty (no catch-all)", config.Test)
+	}
+	if got := config.languageTestCommand("javascript"); got != "pnpm vitest run" {
+		t.Errorf("javascript command = %q, want override", got)
+	}
+	if got := config.languageTestCommand("go"); got != "" {
+		t.Errorf("go command = %q, want empty so the built-in runner is used", got)
+	}
+
+	var nilConfig *ProjectConfig
+	if got := nilConfig.languageTestCommand("go"); got != "" {
+		t.Errorf("nil config command = %q, want empty", got)
+	}
+}
+
+func TestRunLanguageTestsUsesOverride(t *testing.T) {
+	if !commandExists("false") {
+		t.Skip("false not available")
+	}
+	oldStderr := os.Stderr
+	_, w, _ := os.Pipe()
+	os.Stderr = w
+	defer func() {
+		_ = w.Close()
+		os.Stderr = oldStderr
+	}()
+
+	config := &ProjectConfig{Tests: map[string]string{"javascript": "false"}}
+	ec := &ErrorCollector{}
+	runLanguageTests("javascript", "src/app.ts", nil, config, t.TempDir(), ec)
+
+	if ec.Count() != 1 || !strings.Contains(ec.errors[0], "test command failed: false") {
+		t.Errorf("expected override command to run and fail, got %v", ec.errors)
+	}
+}
+
+func TestCheckPackageManager(t *testing.T) {
+	origLookPath := lookPath
+	t.Cleanup(func() { lookPath = origLookPath })
+
+	lookPath = func(name string) (string, error) {
+		if name == "pnpm" {
+			return "/usr/bin/pnpm", nil
+		}
+		return "", errors.New("executable file not found in $PATH")
+	}
+
+	// A .git marker keeps resolution from walking above the temp dir.
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	pm, err := checkPackageManager(&ProjectConfig{PackageManager: "pnpm"}, root)
+	if err != nil || pm != "pnpm" {
+		t.Fatalf("checkPackageManager(pnpm) = %q, %v", pm, err)
+	}
+
+	// An unconfigured, absent npm is skipped silently.
+	if pm, err := checkPackageManager(nil, root); pm != "" || err != nil {
+		t.Errorf("checkPackageManager(nil) = %q, %v; want \"\", nil", pm, err)
+	}
+
+	_, err = checkPackageManager(&ProjectConfig{PackageManager: "yarn"}, root)
+	if err == nil {
+		t.Fatal("expected an error for a configured but missing package manager")
+	}
+	if !strings.Contains(err.Error(), `"yarn"`) || !strings.Contains(err.Error(), ".claude-hooks.json") {
+		t.Errorf("error %q should name the manager and the config file", err)
+	}
+
+	// package.json's packageManager field overrides the config.
+	if err := os.WriteFile(filepath.Join(root, "package.json"), []byte(`{"packageManager": "pnpm@9.1.0"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if pm, err := checkPackageManager(&ProjectConfig{PackageManager: "yarn"}, root); err != nil || pm != "pnpm" {
+		t.Errorf("checkPackageManager() = %q, %v; want pnpm from package.json", pm, err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "package.json"), []byte(`{"packageManager": "bun@1.1.0"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, err = checkPackageManager(nil, root)
+	if err == nil || !strings.Contains(err.Error(), `"bun"`) || !strings.Contains(err.Error(), "package.json") {
+		t.Errorf("error %v should name the manager and package.json", err)
+	}
+}
+
+func TestRunTestCommandStreaming(t *testing.T) {
+	if !commandExists("sh") {
+		t.Skip("sh not available")
+	}
+	origOutput := testOutput
+	t.Cleanup(func() { testOutput = origOutput })
+
+	for _, stream := range []string{"", "1"} {
+		t.Setenv("CLAUDE_HOOKS_STREAM", stream)
+		var out bytes.Buffer
+		testOutput = &out
+		ec := &ErrorCollector{}
+
+		runTestCommand(exec.Command("sh", "-c", "echo progress; echo failure >&2; exit 1"), "sh failed", ec)
+
+		if ec.Count() != 1 {
+			t.Errorf("stream=%q: errors = %d, want 1", stream, ec.Count())
+		}
+		if !strings.Contains(out.String(), "progress") || !strings.Contains(out.String(), "failure") {
+			t.Errorf("stream=%q: output = %q, want stdout and stderr", stream, out.String())
+		}
+	}
+}
+
+func TestIsNonCodeEdit(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		patterns []string
+		want     bool
+	}{
+		{"markdown", "/repo/README.md", nil, true},
+		{"json data", "/repo/fixtures/data.json", nil, true},
+		{"yaml", "/repo/.github/workflows/ci.yml", nil, true},
+		{"image", "/repo/assets/logo.PNG", nil, true},
+		{"go source", "/repo/main.go", nil, false},
+		{"typescript", "/repo/src/app.ts", nil, false},
+		{"package.json affects tests", "/repo/package.json", nil, false},
+		{"tsconfig affects tests", "/repo/tsconfig.json", nil, false},
+		{"go.mod affects tests", "/repo/go.mod", nil, false},
+		{"negated glob opts back in", "/repo/fixtures/data.json", []string{"!*.json"}, false},
+		{"negated exact name opts back in", "/repo/testdata.yml", []string{"!testdata.yml"}, false},
+		{"unrelated negation", "/repo/README.md", []string{"!*.json"}, true},
+		{"path-relative negation opts back in", "/repo/fixtures/data.json", []string{"!fixtures/*.json"}, false},
+		{"path-relative negation is anchored to the root", "/repo/docs/fixtures/data.json", []string{"!fixtures/*.json"}, true},
+		{"path-relative directory negation", "/repo/fixtures/nested/data.json", []string{"!fixtures/**"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isNonCodeEdit(tt.path, "/repo", tt.patterns); got != tt.want {
+				t.Errorf("isNonCodeEdit(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunSkipsNonCodeEdits(t *testing.T) {
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	origStdin := os.Stdin
+	t.Cleanup(func() {
+		os.Stdin = origStdin
+		_ = os.Chdir(origDir)
+	})
+
+	dir := t.TempDir()
+	marker := filepath.Join(dir, "ran")
+	script := "#!/bin/sh\ntouch " + marker + "\n"
+	files := map[string]string{
+		"go.mod":          "module example.com/skip\n\ngo 1.21\n",
+		"README.md":       "# readme\n",
+		"scripts/test.sh": script,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Only the skipped edit can be exercised here: a run that reaches the
+	// runners ends in os.Exit via exitWithResult.
+	runFor := func(file string) {
+		t.Helper()
+		event := `{"hook_event_name":"PostToolUse","tool_name":"Edit","tool_input":{"file_path":"` + filepath.Join(dir, file) + `"}}`
+		stdin, err := os.CreateTemp(t.TempDir(), "stdin")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := stdin.WriteString(event); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := stdin.Seek(0, 0); err != nil {
+			t.Fatal(err)
+		}
+		os.Stdin = stdin
+		if err := run(); err != nil {
+			t.Fatalf("run() for %s: %v", file, err)
+		}
+	}
+
+	runFor("README.md")
+	if _, err := os.Stat(marker); err == nil {
+		t.Fatal("editing README.md invoked the test runner")
+	}
+}
+
+func TestConfiguredSkipDirs(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		".claude-hooks.json":      `{"skipDirs": ["vendor", "coverage/"]}`,
+		"package.json":            `{}`,
+		"src/app.ts":              "export const app = 1;\n",
+		"vendor/lib/decoy.go":     "package lib\n",
+		"coverage/report/gen.ts":  "export {};\n",
+		"node_modules/dep/dep.ts": "export {};\n",
+	}
+	for rel, content := range files {
+		path := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	oldDir, _ := os.Getwd()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = os.Chdir(oldDir)
+	}()
+
+	config, err := loadProjectConfig(dir)
+	if err != nil {
+		t.Fatalf("loadProjectConfig() error = %v", err)
+	}
+	skipDirs := config.skipDirs()
+
+	// The decoy under vendor/ must not make this look like a Go project.
+	if got := detectProjectType(skipDirs).Languages; len(got) != 1 || got[0] != "javascript" {
+		t.Errorf("detectProjectType() = %v, want [javascript]", got)
+	}
+	if got := detectProjectType(nil).Languages; len(got) != 2 {
+		t.Errorf("detectProjectType() without skips = %v, want the vendor decoy to add go", got)
+	}
+
+	// Configured dirs are merged with the defaults, not replacing them.
+	got := findFiles([]string{".ts", ".go"}, nil, skipDirs)
+	if len(got) != 1 || got[0] != filepath.Join("src", "app.ts") {
+		t.Errorf("findFiles() = %v, want only src/app.ts", got)
+	}
+
+	var nilConfig *ProjectConfig
+	if !nilConfig.skipDirs()["node_modules"] {
+		t.Error("nil config should still skip the default directories")
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// testCounts is what one test run reported about itself, parsed from the
+// runner's summary line.
+type testCounts struct {
+	Runner  string
+	Unit    string // "tests", or "packages" for go test without -v
+	Passed  int
+	Failed  int
+	Skipped int
+	Total   int
+}
+
+// String renders the counts as "vitest: 2 failed, 10 passed of 12 tests".
+func (c testCounts) String() string {
+	var parts []string
+	if c.Failed > 0 {
+		parts = append(parts, fmt.Sprintf("%d failed", c.Failed))
+	}
+	if c.Passed > 0 || c.Failed == 0 {
+		parts = append(parts, fmt.Sprintf("%d passed", c.Passed))
+	}
+	if c.Skipped > 0 {
+		parts = append(parts, fmt.Sprintf("%d skipped", c.Skipped))
+	}
+	return fmt.Sprintf("%s: %s of %d %s", c.Runner, strings.Join(parts, ", "), c.Total, c.Unit)
+}
+
+// ansiEscapeRe matches terminal color codes, which runners may emit even
+// when piped (e.g. FORCE_COLOR).
+var ansiEscapeRe = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// countRe matches one "<n> <label>" pair of a summary line.
+var countRe = regexp.MustCompile(`(\d+) ([a-z]+)`)
+
+var (
+	// jestSummaryRe: "Tests:       1 failed, 2 skipped, 10 passed, 13 total"
+	jestSummaryRe = regexp.MustCompile(`(?m)^Tests:[ \t]+(.*\d+ total)[ \t]*$`)
+	// vitestSummaryRe: "      Tests  2 failed | 10 passed (12)". "Test Files"
+	// doesn't match, so files aren't counted as tests.
+	vitestSummaryRe = regexp.MustCompile(`(?m)^[ \t]*Tests[ \t]+(.*?)[ \t]*\((\d+)\)[ \t]*$`)
+	// pytestSummaryRe: "===== 1 failed, 10 passed, 2 skipped in 0.12s =====",
+	// or the same without the rule under -q.
+	pytestSummaryRe = regexp.MustCompile(`(?m)^=*[ \t]*(\d+ [a-z]+(?:, \d+ [a-z]+)*) in \d+(?:\.\d+)?s\b`)
+	// goPackageOKRe and goPackageFailRe match the per-package result lines
+	// go test prints without -v: "ok  \tpkg\t0.01s" and "FAIL\tpkg\t0.02s".
+	goPackageOKRe   = regexp.MustCompile(`(?m)^ok[ \t]+\S+`)
+	goPackageFailRe = regexp.MustCompile(`(?m)^FAIL[ \t]+\S+`)
+)
+
+// parseTestCounts extracts pass/fail counts from test runner output,
+// trying each supported runner's summary format. Runners that print several
+// summaries (a workspace of vitest projects) are summed. ok is false when no
+// format matches or nothing ran.
+func parseTestCounts(output string) (testCounts, bool) {
+	output = ansiEscapeRe.ReplaceAllString(output, "")
+	for _, parse := range []func(string) testCounts{parseJestCounts, parseVitestCounts, parsePytestCounts, parseGoCounts} {
+		if c := parse(output); c.Total > 0 {
+			return c, true
+		}
+	}
+	return testCounts{}, false
+}
+
+// addCount adds n to the field of c that label names. Labels it doesn't
+// know (todo, warnings, deselected, ...) are left out of the counts, but
+// still part of a runner-reported total.
+func (c *testCounts) addCount(label string, n int) {
+	switch label {
+	case "passed":
+		c.Passed += n
+	case "failed", "error", "errors":
+		c.Failed += n
+	case "skipped":
+		c.Skipped += n
+	case "total":
+		c.Total += n
+	}
+}
+
+// addCounts adds every "<n> <label>" pair in text to c.
+func (c *testCounts) addCounts(text string) {
+	for _, m := range countRe.FindAllStringSubmatch(text, -1) {
+		n, _ := strconv.Atoi(m[1])
+		c.addCount(m[2], n)
+	}
+}
+
+func parseJestCounts(output string) testCounts {
+	c := testCounts{Runner: "jest", Unit: "tests"}
+	for _, m := range jestSummaryRe.FindAllStringSubmatch(output, -1) {
+		c.addCounts(m[1])
+	}
+	return c
+}
+
+func parseVitestCounts(output string) testCounts {
+	c := testCounts{Runner: "vitest", Unit: "tests"}
+	for _, m := range vitestSummaryRe.FindAllStringSubmatch(output, -1) {
+		c.addCounts(m[1])
+		n, _ := strconv.Atoi(m[2])
+		c.Total += n
+	}
+	return c
+}
+
+func parsePytestCounts(output string) testCounts {
+	c := testCounts{Runner: "pytest", Unit: "tests"}
+	for _, m := range pytestSummaryRe.FindAllStringSubmatch(output, -1) {
+		c.addCounts(m[1])
+	}
+	c.Total = c.Passed + c.Failed + c.Skipped
+	return c
+}
+
+func parseGoCounts(output string) testCounts {
+	c := testCounts{Runner: "go test", Unit: "packages"}
+	c.Passed = len(goPackageOKRe.FindAllString(output, -1))
+	c.Failed = len(goPackageFailRe.FindAllString(output, -1))
+	c.Total = c.Passed + c.Failed
+	return c
+}
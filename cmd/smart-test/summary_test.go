@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bytes"
+	"os/exec"
+	"testing"
+)
+
+func TestParseTestCounts(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   testCounts
+		ok     bool
+	}{
+		{
+			name: "go test packages",
+			output: "ok  \tgithub.com/acme/app/internal/auth\t0.012s\n" +
+				"--- FAIL: TestLogin (0.00s)\n" +
+				"    login_test.go:12: got 401, want 200\n" +
+				"FAIL\n" +
+				"FAIL\tgithub.com/acme/app/internal/api\t0.020s\n" +
+				"ok  \tgithub.com/acme/app/cmd/server\t(cached)\n" +
+				"?   \tgithub.com/acme/app/internal/gen\t[no test files]\n" +
+				"FAIL\n",
+			want: testCounts{Runner: "go test", Unit: "packages", Passed: 2, Failed: 1, Total: 3},
+			ok:   true,
+		},
+		{
+			name:   "go test build failure",
+			output: "# github.com/acme/app\n./main.go:3:2: undefined: x\nFAIL\tgithub.com/acme/app [build failed]\nFAIL\n",
+			want:   testCounts{Runner: "go test", Unit: "packages", Failed: 1, Total: 1},
+			ok:     true,
+		},
+		{
+			name: "vitest",
+			output: " ✓ src/math.test.ts (8 tests) 4ms\n" +
+				" ❯ src/login.test.ts (4 tests | 2 failed) 9ms\n\n" +
+				" Test Files  1 failed | 1 passed (2)\n" +
+				"      Tests  2 failed | 9 passed | 1 skipped (12)\n" +
+				"   Start at  10:14:03\n" +
+				"   Duration  412ms\n",
+			want: testCounts{Runner: "vitest", Unit: "tests", Passed: 9, Failed: 2, Skipped: 1, Total: 12},
+			ok:   true,
+		},
+		{
+			name:   "vitest colored workspace output is summed",
+			output: "\x1b[2m      Tests \x1b[22m \x1b[1m\x1b[32m5 passed\x1b[39m\x1b[22m\x1b[90m (5)\x1b[39m\n\x1b[2m      Tests \x1b[22m \x1b[1m\x1b[32m3 passed\x1b[39m\x1b[22m\x1b[90m (3)\x1b[39m\n",
+			want:   testCounts{Runner: "vitest", Unit: "tests", Passed: 8, Total: 8},
+			ok:     true,
+		},
+		{
+			name: "jest",
+			output: "FAIL src/login.test.ts\n" +
+				"Test Suites: 1 failed, 3 passed, 4 total\n" +
+				"Tests:       1 failed, 2 skipped, 10 passed, 13 total\n" +
+				"Snapshots:   0 total\n" +
+				"Time:        1.24 s\n",
+			want: testCounts{Runner: "jest", Unit: "tests", Passed: 10, Failed: 1, Skipped: 2, Total: 13},
+			ok:   true,
+		},
+		{
+			name: "pytest",
+			output: "tests/test_auth.py ..F.s                                      [100%]\n" +
+				"=================================== FAILURES ===================================\n" +
+				"=========================== short test summary info ============================\n" +
+				"FAILED tests/test_auth.py::test_login - AssertionError\n" +
+				"================= 1 failed, 3 passed, 1 skipped, 2 warnings in 0.12s =================\n",
+			want: testCounts{Runner: "pytest", Unit: "tests", Passed: 3, Failed: 1, Skipped: 1, Total: 5},
+			ok:   true,
+		},
+		{
+			name:   "pytest -q with collection errors",
+			output: "ERROR tests/test_api.py\n7 passed, 2 errors in 1.03s\n",
+			want:   testCounts{Runner: "pytest", Unit: "tests", Passed: 7, Failed: 2, Total: 9},
+			ok:     true,
+		},
+		{
+			name:   "pytest with nothing collected",
+			output: "============================ no tests ran in 0.01s =============================\n",
+		},
+		{
+			name:   "unrecognized runner",
+			output: "running 5 checks\nall good\n",
+		},
+		{
+			name: "empty output",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseTestCounts(tt.output)
+			if ok != tt.ok || got != tt.want {
+				t.Errorf("parseTestCounts() = (%+v, %v), want (%+v, %v)", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestTestCountsString(t *testing.T) {
+	tests := []struct {
+		counts testCounts
+		want   string
+	}{
+		{testCounts{Runner: "vitest", Unit: "tests", Passed: 9, Failed: 2, Skipped: 1, Total: 12}, "vitest: 2 failed, 9 passed, 1 skipped of 12 tests"},
+		{testCounts{Runner: "go test", Unit: "packages", Passed: 4, Total: 4}, "go test: 4 passed of 4 packages"},
+		{testCounts{Runner: "jest", Unit: "tests", Failed: 3, Total: 3}, "jest: 3 failed of 3 tests"},
+	}
+	for _, tt := range tests {
+		if got := tt.counts.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestCountsSummary(t *testing.T) {
+	ec := &ErrorCollector{}
+	if got := ec.countsSummary(); got != "" {
+		t.Errorf("no parsed runs: countsSummary() = %q, want the generic message", got)
+	}
+
+	ec.recordCounts([]byte("not a test runner\n"))
+	ec.recordCounts([]byte("ok  \tgithub.com/acme/app\t0.01s\n"))
+	ec.recordCounts([]byte("      Tests  12 passed (12)\n"))
+	if got, want := ec.countsSummary(), " (go test: 1 passed of 1 packages; vitest: 12 passed of 12 tests)"; got != want {
+		t.Errorf("countsSummary() = %q, want %q", got, want)
+	}
+}
+
+func TestRunTestCommandRecordsCounts(t *testing.T) {
+	if !commandExists("sh") {
+		t.Skip("sh not available")
+	}
+	origOutput := testOutput
+	t.Cleanup(func() { testOutput = origOutput })
+
+	for _, stream := range []string{"", "1"} {
+		t.Setenv("CLAUDE_HOOKS_STREAM", stream)
+		testOutput = &bytes.Buffer{}
+		ec := &ErrorCollector{}
+
+		runTestCommand(exec.Command("sh", "-c", `echo "Tests:       1 failed, 4 passed, 5 total"; exit 1`), "jest failed", ec)
+
+		want := []testCounts{{Runner: "jest", Unit: "tests", Passed: 4, Failed: 1, Total: 5}}
+		if len(ec.counts) != 1 || ec.counts[0] != want[0] {
+			t.Errorf("stream=%q: counts = %+v, want %+v", stream, ec.counts, want)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/milehighideas/claude-hooks/internal/glob"
+	"github.com/milehighideas/claude-hooks/internal/jsonc"
+)
+
+// testMapFile maps source globs to test commands at the project root. Its
+// rules are consulted before project and language detection.
+const testMapFile = ".claude-hooks-test-map.json"
+
+// testMapRule runs Command, from the project root, when an edited file
+// matches Glob.
+type testMapRule struct {
+	Glob    string `json:"glob"`    // relative to the project root; no "/" matches the file name anywhere
+	Command string `json:"command"` // e.g. "go test ./pkg/api/..."
+}
+
+// loadTestMap reads the rules in root's testMapFile. A missing file has no
+// rules; a malformed one is reported and ignored.
+func loadTestMap(root string) []testMapRule {
+	path := filepath.Join(root, testMapFile)
+	var rules []testMapRule
+	if err := jsonc.Unmarshal(path, &rules); err != nil {
+		if !os.IsNotExist(err) {
+			fmt.Fprintf(os.Stderr, "smart-test: ignoring %s: %v\n", path, err)
+		}
+		return nil
+	}
+	return rules
+}
+
+// matchTestMap returns the first rule whose glob matches relPath, or nil.
+func matchTestMap(rules []testMapRule, relPath string) *testMapRule {
+	relPath = filepath.ToSlash(relPath)
+	for i, rule := range rules {
+		if rule.Glob == "" || rule.Command == "" {
+			continue
+		}
+		target := relPath
+		if !strings.Contains(rule.Glob, "/") {
+			target = filepath.Base(relPath)
+		}
+		re, err := glob.Compile(rule.Glob)
+		if err != nil {
+			continue
+		}
+		if re.MatchString(target) {
+			return &rules[i]
+		}
+	}
+	return nil
+}
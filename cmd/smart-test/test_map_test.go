@@ -0,0 +1,76 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMatchTestMap(t *testing.T) {
+	rules := []testMapRule{
+		{Glob: "pkg/api/internal/**", Command: "go test ./pkg/api/internal/..."},
+		{Glob: "pkg/api/**", Command: "go test ./pkg/api/..."},
+		{Glob: "*.proto", Command: "make proto-verify"},
+		{Glob: "web/src/*.ts", Command: "pnpm vitest run web"},
+		{Glob: "", Command: "ignored"},
+		{Glob: "docs/**", Command: ""},
+		{Glob: "**/*.go", Command: "go test ./..."},
+	}
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"pkg/api/internal/auth/token.go", "go test ./pkg/api/internal/..."}, // first match wins over the broader rule
+		{"pkg/api/handler.go", "go test ./pkg/api/..."},
+		{"pkg/api/v1/schema.proto", "go test ./pkg/api/..."}, // earlier directory rule beats the later extension rule
+		{"proto/events.proto", "make proto-verify"},          // no "/" in the glob matches the file name anywhere
+		{"web/src/app.ts", "pnpm vitest run web"},
+		{"web/src/nested/app.ts", ""}, // "*" stays within one directory
+		{"cmd/tool/main.go", "go test ./..."},
+		{"docs/guide.md", ""}, // rules without a command are skipped
+		{"README.md", ""},
+	}
+	for _, tt := range tests {
+		got := ""
+		if rule := matchTestMap(rules, tt.path); rule != nil {
+			got = rule.Command
+		}
+		if got != tt.want {
+			t.Errorf("matchTestMap(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestLoadTestMap(t *testing.T) {
+	root := t.TempDir()
+	if rules := loadTestMap(root); rules != nil {
+		t.Errorf("missing map: rules = %v, want none", rules)
+	}
+
+	content := `[
+  // API changes run the API package tests
+  {"glob": "pkg/api/**", "command": "go test ./pkg/api/..."},
+  {"glob": "*.proto", "command": "make proto-verify"}
+]`
+	if err := os.WriteFile(filepath.Join(root, testMapFile), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	rules := loadTestMap(root)
+	if len(rules) != 2 || rules[0].Glob != "pkg/api/**" || rules[1].Command != "make proto-verify" {
+		t.Errorf("rules = %+v, want both rules in order", rules)
+	}
+
+	oldStderr := os.Stderr
+	_, w, _ := os.Pipe()
+	os.Stderr = w
+	defer func() {
+		_ = w.Close()
+		os.Stderr = oldStderr
+	}()
+	if err := os.WriteFile(filepath.Join(root, testMapFile), []byte(`{"glob": "x"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if rules := loadTestMap(root); rules != nil {
+		t.Errorf("malformed map: rules = %v, want none", rules)
+	}
+}
@@ -2,10 +2,11 @@ package main
 
 import (
 	"encoding/json"
-	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
+	"time"
+
+	"github.com/milehighideas/claude-hooks/internal/session"
 )
 
 // Input represents the JSON input from stdin
@@ -15,12 +16,12 @@ type Input struct {
 }
 
 // SessionData tracks files edited during a Claude session
-type SessionData struct {
-	SourceFiles []string `json:"source_files"`
-	TestFiles   []string `json:"test_files"`
-}
+type SessionData = session.Data
+
+// now is the clock used to stamp edits; tests replace it
+var now = time.Now
 
-// File patterns to skip (no test required)
+// Default file patterns to skip (no test required)
 var skipPatterns = []string{
 	"_generated/",
 	"schema.ts",
@@ -46,7 +47,7 @@ var skipPatterns = []string{
 }
 
 // shouldTrackFile determines if this file should be tracked for test enforcement
-func shouldTrackFile(filePath string) bool {
+func shouldTrackFile(filePath string, rules trackRules) bool {
 	// Only track TypeScript/JavaScript source files
 	validExtensions := []string{".ts", ".tsx", ".js", ".jsx"}
 	hasValidExtension := false
@@ -61,29 +62,12 @@ func shouldTrackFile(filePath string) bool {
 	}
 
 	// Skip files matching skip patterns
-	for _, pattern := range skipPatterns {
-		if strings.Contains(filePath, pattern) {
-			return false
-		}
-	}
-
-	// Only track files in backend/convex or mobile app
-	if strings.Contains(filePath, "packages/backend/convex/") {
-		return true
-	}
-
-	if strings.Contains(filePath, "apps/mobile/") {
-		// Skip mobile config/setup files
-		mobileSkipPatterns := []string{"metro.config", "babel.config", "app.config"}
-		for _, pattern := range mobileSkipPatterns {
-			if strings.Contains(filePath, pattern) {
-				return false
-			}
-		}
-		return true
+	if rules.skipped(filePath) {
+		return false
 	}
 
-	return false
+	// Only track files the include globs cover
+	return rules.included(filePath)
 }
 
 // isTestFile checks if this is a test file
@@ -93,49 +77,15 @@ func isTestFile(filePath string) bool {
 		strings.Contains(filePath, "__tests__/")
 }
 
-// loadSessionData loads existing session data or returns empty structure
+// loadSessionData loads existing session data or returns empty structure.
+// It is a thin wrapper around session.Load, which migrates legacy files.
 func loadSessionData(sessionFile string) (*SessionData, error) {
-	data := &SessionData{
-		SourceFiles: []string{},
-		TestFiles:   []string{},
-	}
-
-	if _, err := os.Stat(sessionFile); os.IsNotExist(err) {
-		return data, nil
-	}
-
-	content, err := os.ReadFile(sessionFile)
-	if err != nil {
-		return data, nil
-	}
-
-	if err := json.Unmarshal(content, data); err != nil {
-		return data, nil
-	}
-
-	return data, nil
+	return session.Load(sessionFile), nil
 }
 
 // saveSessionData saves session data to file
 func saveSessionData(sessionFile string, data *SessionData) error {
-	// Create directory if it doesn't exist
-	dir := filepath.Dir(sessionFile)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("creating sessions directory: %w", err)
-	}
-
-	// Marshal data to JSON
-	content, err := json.MarshalIndent(data, "", "  ")
-	if err != nil {
-		return fmt.Errorf("marshaling session data: %w", err)
-	}
-
-	// Write to file
-	if err := os.WriteFile(sessionFile, content, 0644); err != nil {
-		return fmt.Errorf("writing session file: %w", err)
-	}
-
-	return nil
+	return session.Save(sessionFile, data)
 }
 
 // contains checks if a string slice contains a string
@@ -148,6 +98,25 @@ func contains(slice []string, item string) bool {
 	return false
 }
 
+// trackEdit records an edit of filePath in data. Every path lands in
+// EditedFiles and has its edit count bumped; test and trackable source files
+// are also categorized for test enforcement, source files according to rules.
+func trackEdit(data *SessionData, filePath string, rules trackRules) {
+	data.RecordEdit(filePath, now())
+	if !contains(data.EditedFiles, filePath) {
+		data.EditedFiles = append(data.EditedFiles, filePath)
+	}
+	if isTestFile(filePath) {
+		if !contains(data.TestFiles, filePath) {
+			data.TestFiles = append(data.TestFiles, filePath)
+		}
+	} else if shouldTrackFile(filePath, rules) {
+		if !contains(data.SourceFiles, filePath) {
+			data.SourceFiles = append(data.SourceFiles, filePath)
+		}
+	}
+}
+
 func main() {
 	// Read input from stdin
 	var input Input
@@ -169,14 +138,12 @@ func main() {
 	}
 
 	// Determine session file path
-	homeDir, err := os.UserHomeDir()
+	sessionFile, err := session.Path(sessionID)
 	if err != nil {
 		// Can't determine home dir - exit with success (non-blocking)
 		os.Exit(0)
 	}
 
-	sessionFile := filepath.Join(homeDir, ".claude", "sessions", sessionID+".json")
-
 	// Load current session data
 	data, err := loadSessionData(sessionFile)
 	if err != nil {
@@ -184,23 +151,11 @@ func main() {
 		os.Exit(0)
 	}
 
-	// Categorize and track the file
-	if isTestFile(filePath) {
-		if !contains(data.TestFiles, filePath) {
-			data.TestFiles = append(data.TestFiles, filePath)
-			if err := saveSessionData(sessionFile, data); err != nil {
-				// Error saving - exit with success (non-blocking)
-				os.Exit(0)
-			}
-		}
-	} else if shouldTrackFile(filePath) {
-		if !contains(data.SourceFiles, filePath) {
-			data.SourceFiles = append(data.SourceFiles, filePath)
-			if err := saveSessionData(sessionFile, data); err != nil {
-				// Error saving - exit with success (non-blocking)
-				os.Exit(0)
-			}
-		}
+	// Record the edit, then categorize and track the file
+	trackEdit(data, filePath, loadTrackRules(filePath, os.Stderr))
+	if err := saveSessionData(sessionFile, data); err != nil {
+		// Error saving - exit with success (non-blocking)
+		os.Exit(0)
 	}
 
 	// Always exit 0 - tracking is non-blocking
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestShouldTrackFile(t *testing.T) {
@@ -135,7 +136,7 @@ func TestShouldTrackFile(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := shouldTrackFile(tt.filePath)
+			got := shouldTrackFile(tt.filePath, defaultTrackRules())
 			if got != tt.want {
 				t.Errorf("shouldTrackFile(%q) = %v, want %v", tt.filePath, got, tt.want)
 			}
@@ -495,20 +496,9 @@ func TestEndToEnd(t *testing.T) {
 					t.Fatalf("loadSessionData failed: %v", err)
 				}
 
-				if isTestFile(filePath) {
-					if !contains(data.TestFiles, filePath) {
-						data.TestFiles = append(data.TestFiles, filePath)
-						if err := saveSessionData(sessionFile, data); err != nil {
-							t.Fatalf("saveSessionData failed: %v", err)
-						}
-					}
-				} else if shouldTrackFile(filePath) {
-					if !contains(data.SourceFiles, filePath) {
-						data.SourceFiles = append(data.SourceFiles, filePath)
-						if err := saveSessionData(sessionFile, data); err != nil {
-							t.Fatalf("saveSessionData failed: %v", err)
-						}
-					}
+				trackEdit(data, filePath, defaultTrackRules())
+				if err := saveSessionData(sessionFile, data); err != nil {
+					t.Fatalf("saveSessionData failed: %v", err)
 				}
 			}
 
@@ -542,3 +532,86 @@ func TestEndToEnd(t *testing.T) {
 		})
 	}
 }
+
+func TestTrackEditRecordsEveryFile(t *testing.T) {
+	data := &SessionData{SourceFiles: []string{}, TestFiles: []string{}}
+
+	trackEdit(data, "/project/README.md", defaultTrackRules())
+	trackEdit(data, "/project/packages/backend/convex/users.ts", defaultTrackRules())
+	trackEdit(data, "/project/README.md", defaultTrackRules())
+
+	wantEdited := []string{"/project/README.md", "/project/packages/backend/convex/users.ts"}
+	if len(data.EditedFiles) != len(wantEdited) {
+		t.Fatalf("EditedFiles = %v, want %v", data.EditedFiles, wantEdited)
+	}
+	for i, want := range wantEdited {
+		if data.EditedFiles[i] != want {
+			t.Errorf("EditedFiles[%d] = %q, want %q", i, data.EditedFiles[i], want)
+		}
+	}
+	if len(data.SourceFiles) != 1 || len(data.TestFiles) != 0 {
+		t.Errorf("categories = %v / %v, want only the convex file as source", data.SourceFiles, data.TestFiles)
+	}
+}
+
+func TestTrackEditCountsEdits(t *testing.T) {
+	first := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	clock := first
+	now = func() time.Time { return clock }
+	t.Cleanup(func() { now = time.Now })
+
+	sessionFile := filepath.Join(t.TempDir(), "sessions", "s.json")
+	source := "/project/packages/backend/convex/users.ts"
+	for i := 0; i < 3; i++ {
+		data, _ := loadSessionData(sessionFile)
+		trackEdit(data, source, defaultTrackRules())
+		if err := saveSessionData(sessionFile, data); err != nil {
+			t.Fatal(err)
+		}
+		clock = clock.Add(time.Minute)
+	}
+
+	data, _ := loadSessionData(sessionFile)
+	stats, ok := data.Stats(source)
+	if !ok || stats.Edits != 3 || !stats.FirstSeen.Equal(first) {
+		t.Errorf("Stats = %+v, want 3 edits first seen at %v", stats, first)
+	}
+	if len(data.SourceFiles) != 1 || len(data.EditedFiles) != 1 {
+		t.Errorf("lists = %v / %v, want the file once in each", data.SourceFiles, data.EditedFiles)
+	}
+}
+
+func TestTrackEditMigratesLegacySession(t *testing.T) {
+	sessionFile := filepath.Join(t.TempDir(), "s.json")
+	legacy := `{"source_files": ["/project/apps/mobile/App.tsx"], "test_files": []}`
+	if err := os.WriteFile(sessionFile, []byte(legacy), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	data, _ := loadSessionData(sessionFile)
+	trackEdit(data, "/project/apps/mobile/App.tsx", defaultTrackRules())
+	trackEdit(data, "/project/apps/mobile/Home.tsx", defaultTrackRules())
+	if err := saveSessionData(sessionFile, data); err != nil {
+		t.Fatal(err)
+	}
+
+	// The rewritten file keeps string arrays for older readers
+	content, _ := os.ReadFile(sessionFile)
+	var raw struct {
+		SourceFiles []string `json:"source_files"`
+	}
+	if err := json.Unmarshal(content, &raw); err != nil {
+		t.Fatalf("source_files is no longer a string array: %v", err)
+	}
+	if len(raw.SourceFiles) != 2 {
+		t.Errorf("source_files = %v, want both files", raw.SourceFiles)
+	}
+
+	data, _ = loadSessionData(sessionFile)
+	if stats, _ := data.Stats("/project/apps/mobile/App.tsx"); stats.Edits != 2 {
+		t.Errorf("migrated file edits = %d, want 2 (1 legacy + 1 new)", stats.Edits)
+	}
+	if stats, _ := data.Stats("/project/apps/mobile/Home.tsx"); stats.Edits != 1 || stats.FirstSeen.IsZero() {
+		t.Errorf("new file stats = %+v, want 1 edit with a first-seen time", stats)
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/milehighideas/claude-hooks/internal/glob"
+	"github.com/milehighideas/claude-hooks/internal/jsonc"
+)
+
+// trackConfigFile chooses which files are tracked for test enforcement. It is
+// found by walking up from the edited file to the repository root.
+const trackConfigFile = ".claude-hooks-track.json"
+
+// Files tracked when no include list is configured
+var defaultInclude = []string{
+	"**/packages/backend/convex/**",
+	"**/apps/mobile/**",
+}
+
+// trackConfig is the parsed .claude-hooks-track.json:
+//
+//	{"include": ["src/**"], "extraSkipPatterns": [".stories.", "src/legacy/"]}
+//
+// Include globs are relative to the config file's directory; a trailing "/"
+// covers everything below it. skipPatterns replaces the defaults and
+// extraSkipPatterns adds to them; both are matched as substrings.
+type trackConfig struct {
+	Include           []string `json:"include"`
+	SkipPatterns      []string `json:"skipPatterns"`
+	ExtraSkipPatterns []string `json:"extraSkipPatterns"`
+}
+
+// trackRules decides which source files shouldTrackFile accepts.
+type trackRules struct {
+	root    string // include globs are relative to root; "" matches any prefix
+	include []*regexp.Regexp
+	skip    []string
+}
+
+// defaultTrackRules tracks the backend Convex functions and the mobile app.
+func defaultTrackRules() trackRules {
+	return trackConfig{}.rules("")
+}
+
+// loadTrackRules returns the rules for filePath from the nearest
+// .claude-hooks-track.json, or the defaults when there is none. A malformed
+// config is reported on stderr and the defaults are used.
+func loadTrackRules(filePath string, stderr io.Writer) trackRules {
+	abs, err := filepath.Abs(filePath)
+	if err != nil {
+		return defaultTrackRules()
+	}
+	configPath := findTrackConfig(filepath.Dir(abs))
+	if configPath == "" {
+		return defaultTrackRules()
+	}
+
+	var cfg trackConfig
+	if err := jsonc.Unmarshal(configPath, &cfg); err != nil {
+		fmt.Fprintf(stderr, "track-edited-files: ignoring %s: %v\n", configPath, err)
+		return defaultTrackRules()
+	}
+	return cfg.rules(filepath.Dir(configPath))
+}
+
+// rules resolves the config against the defaults.
+func (c trackConfig) rules(root string) trackRules {
+	include := c.Include
+	if include == nil {
+		include = defaultInclude
+	}
+	skip := c.SkipPatterns
+	if skip == nil {
+		skip = skipPatterns
+	}
+
+	r := trackRules{root: root}
+	for _, pattern := range include {
+		if strings.HasSuffix(pattern, "/") {
+			pattern += "**"
+		}
+		if re, err := glob.Compile(pattern); err == nil {
+			r.include = append(r.include, re)
+		}
+	}
+	r.skip = append(append([]string{}, skip...), c.ExtraSkipPatterns...)
+	return r
+}
+
+// included reports whether filePath matches an include glob.
+func (r trackRules) included(filePath string) bool {
+	path := filepath.ToSlash(filePath)
+	if r.root != "" {
+		rel, err := filepath.Rel(r.root, filePath)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return false
+		}
+		path = filepath.ToSlash(rel)
+	}
+	for _, re := range r.include {
+		if re.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// skipped reports whether filePath contains a skip pattern.
+func (r trackRules) skipped(filePath string) bool {
+	for _, pattern := range r.skip {
+		if strings.Contains(filePath, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// findTrackConfig walks up from dir looking for trackConfigFile, stopping at
+// the directory that holds .git. Returns "" when there is none.
+func findTrackConfig(dir string) string {
+	for {
+		candidate := filepath.Join(dir, trackConfigFile)
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return ""
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeTrackProject creates a repo root (with .git) holding the given
+// .claude-hooks-track.json content, or none when config is "".
+func writeTrackProject(t *testing.T, config string) string {
+	t.Helper()
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if config != "" {
+		if err := os.WriteFile(filepath.Join(root, trackConfigFile), []byte(config), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestLoadTrackRules_SrcLayout(t *testing.T) {
+	root := writeTrackProject(t, `{
+  // plain single-package repo
+  "include": ["src/**"],
+  "extraSkipPatterns": [".stories.", "src/legacy/"]
+}`)
+	var stderr bytes.Buffer
+
+	tests := []struct {
+		name string
+		rel  string
+		want bool
+	}{
+		{name: "source under src", rel: "src/components/Button.tsx", want: true},
+		{name: "nested source under src", rel: "src/lib/api/client.ts", want: true},
+		{name: "outside src", rel: "scripts/build.ts", want: false},
+		{name: "default include no longer applies", rel: "apps/mobile/App.tsx", want: false},
+		{name: "extra skip pattern", rel: "src/components/Button.stories.tsx", want: false},
+		{name: "extra skip directory", rel: "src/legacy/old.ts", want: false},
+		{name: "default skip patterns still apply", rel: "src/index.ts", want: false},
+		{name: "default config skip", rel: "src/vite.config.ts", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(root, tt.rel)
+			if got := shouldTrackFile(filePath, loadTrackRules(filePath, &stderr)); got != tt.want {
+				t.Errorf("shouldTrackFile(%q) = %v, want %v", tt.rel, got, tt.want)
+			}
+		})
+	}
+	if stderr.Len() != 0 {
+		t.Errorf("unexpected stderr: %s", stderr.String())
+	}
+}
+
+func TestLoadTrackRules_ReplaceSkipPatterns(t *testing.T) {
+	root := writeTrackProject(t, `{"include": ["lib/"], "skipPatterns": ["generated/"]}`)
+
+	tests := []struct {
+		rel  string
+		want bool
+	}{
+		{rel: "lib/index.ts", want: true}, // index.ts is only skipped by default
+		{rel: "lib/generated/api.ts", want: false},
+		{rel: "libs/other.ts", want: false}, // "lib/" is a directory, not a prefix
+	}
+	for _, tt := range tests {
+		filePath := filepath.Join(root, tt.rel)
+		if got := shouldTrackFile(filePath, loadTrackRules(filePath, &bytes.Buffer{})); got != tt.want {
+			t.Errorf("shouldTrackFile(%q) = %v, want %v", tt.rel, got, tt.want)
+		}
+	}
+}
+
+func TestLoadTrackRules_Defaults(t *testing.T) {
+	t.Run("no config", func(t *testing.T) {
+		root := writeTrackProject(t, "")
+		filePath := filepath.Join(root, "packages", "backend", "convex", "users.ts")
+		if !shouldTrackFile(filePath, loadTrackRules(filePath, &bytes.Buffer{})) {
+			t.Error("expected the default include to track convex files")
+		}
+		srcFile := filepath.Join(root, "src", "app.ts")
+		if shouldTrackFile(srcFile, loadTrackRules(srcFile, &bytes.Buffer{})) {
+			t.Error("expected src/ to be untracked by default")
+		}
+	})
+
+	t.Run("config without include keeps default include", func(t *testing.T) {
+		root := writeTrackProject(t, `{"extraSkipPatterns": ["Screen.tsx"]}`)
+		tracked := filepath.Join(root, "apps", "mobile", "src", "Button.tsx")
+		skipped := filepath.Join(root, "apps", "mobile", "src", "HomeScreen.tsx")
+		if !shouldTrackFile(tracked, loadTrackRules(tracked, &bytes.Buffer{})) {
+			t.Error("expected mobile component to be tracked")
+		}
+		if shouldTrackFile(skipped, loadTrackRules(skipped, &bytes.Buffer{})) {
+			t.Error("expected extra skip pattern to apply")
+		}
+	})
+
+	t.Run("malformed config", func(t *testing.T) {
+		root := writeTrackProject(t, `{"include": [`)
+		filePath := filepath.Join(root, "apps", "mobile", "App.tsx")
+		var stderr bytes.Buffer
+		if !shouldTrackFile(filePath, loadTrackRules(filePath, &stderr)) {
+			t.Error("expected defaults after a malformed config")
+		}
+		if !strings.Contains(stderr.String(), "ignoring") {
+			t.Errorf("expected a warning on stderr, got %q", stderr.String())
+		}
+	})
+
+	t.Run("config above the repo root is ignored", func(t *testing.T) {
+		outer := t.TempDir()
+		_ = os.WriteFile(filepath.Join(outer, trackConfigFile), []byte(`{"include": ["**"]}`), 0644)
+		root := filepath.Join(outer, "repo")
+		_ = os.MkdirAll(filepath.Join(root, ".git"), 0755)
+		filePath := filepath.Join(root, "src", "app.ts")
+		if shouldTrackFile(filePath, loadTrackRules(filePath, &bytes.Buffer{})) {
+			t.Error("expected the outer config to be ignored")
+		}
+	})
+}
+
+func TestTrackEdit_ConfiguredRules(t *testing.T) {
+	root := writeTrackProject(t, `{"include": ["src/**"]}`)
+	data := &SessionData{SourceFiles: []string{}, TestFiles: []string{}}
+
+	source := filepath.Join(root, "src", "cart.ts")
+	other := filepath.Join(root, "tools", "gen.ts")
+	for _, path := range []string{source, other} {
+		trackEdit(data, path, loadTrackRules(path, &bytes.Buffer{}))
+	}
+
+	if len(data.SourceFiles) != 1 || data.SourceFiles[0] != source {
+		t.Errorf("SourceFiles = %v, want [%s]", data.SourceFiles, source)
+	}
+	if len(data.EditedFiles) != 2 {
+		t.Errorf("EditedFiles = %v, want both files", data.EditedFiles)
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// StructureIssue is one structure violation. FixCommand is a shell command,
+// run from the project root, that fixes it; empty when the fix needs a
+// decision (such as where a loose file belongs).
+type StructureIssue struct {
+	Message    string
+	FixCommand string
+}
+
+// String returns the issue message, so issues print readably in test output.
+func (i StructureIssue) String() string {
+	return i.Message
+}
+
+// shellSafeRe matches paths that need no quoting in a shell command
+var shellSafeRe = regexp.MustCompile(`^[\w@%+=:,./-]+$`)
+
+// shellQuote single-quotes s unless it is shell-safe as is.
+func shellQuote(s string) string {
+	if shellSafeRe.MatchString(s) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// relPath returns path relative to root, or path itself when it isn't
+// under root.
+func relPath(root, path string) string {
+	rel, err := filepath.Rel(root, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return path
+	}
+	return rel
+}
+
+// mkdirFixCommand creates dir and touches files inside it.
+func mkdirFixCommand(dir string, files []string) string {
+	cmd := "mkdir -p " + shellQuote(dir)
+	if len(files) > 0 {
+		cmd += " && " + touchFixCommand(dir, files)
+	}
+	return cmd
+}
+
+// touchFixCommand touches files inside dir.
+func touchFixCommand(dir string, files []string) string {
+	args := make([]string, len(files))
+	for i, name := range files {
+		args[i] = shellQuote(filepath.Join(dir, name))
+	}
+	return "touch " + strings.Join(args, " ")
+}
+
+// fixCommands returns the issues' fix commands in order, without duplicates
+// or blanks.
+func fixCommands(issues []StructureIssue) []string {
+	var cmds []string
+	seen := make(map[string]bool)
+	for _, issue := range issues {
+		if issue.FixCommand == "" || seen[issue.FixCommand] {
+			continue
+		}
+		seen[issue.FixCommand] = true
+		cmds = append(cmds, issue.FixCommand)
+	}
+	return cmds
+}
+
+// formatIssues renders the issue list followed by the fix commands as one
+// block that can be pasted into a shell.
+func formatIssues(projectRoot string, issues []StructureIssue) string {
+	var sb strings.Builder
+	for _, issue := range issues {
+		sb.WriteString("  - " + issue.Message + "\n")
+	}
+
+	cmds := fixCommands(issues)
+	if len(cmds) == 0 {
+		return sb.String()
+	}
+	sb.WriteString("\nSuggested fix commands:\n\n")
+	sb.WriteString("  cd " + shellQuote(projectRoot) + "\n")
+	for _, cmd := range cmds {
+		sb.WriteString("  " + cmd + "\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCheckFeatureStructure_FixCommands(t *testing.T) {
+	root := setupTestProject(t)
+	routes := filepath.Join(root, "components", "routes")
+	createFeatureFolder(t, routes, "foo")
+	featurePath := filepath.Join(routes, "foo")
+
+	if err := os.RemoveAll(filepath.Join(featurePath, "create")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(featurePath, "read", "index.ts")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(featurePath, "index.ts")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(featurePath, "Loose.tsx"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	issues := checkFeatureStructure(root, featurePath, structureConfig{})
+	fixes := make(map[string]string)
+	for _, issue := range issues {
+		fixes[issue.Message] = issue.FixCommand
+	}
+
+	tests := []struct {
+		message string
+		fix     string
+	}{
+		{
+			message: "Missing required folder: foo/create/",
+			fix:     "mkdir -p components/routes/foo/create && touch components/routes/foo/create/.gitkeep components/routes/foo/create/index.ts",
+		},
+		{
+			message: "Missing Barrel export file: foo/read/index.ts",
+			fix:     "touch components/routes/foo/read/index.ts",
+		},
+		{
+			message: "Missing main barrel export: foo/index.ts",
+			fix:     "touch components/routes/foo/index.ts",
+		},
+		{
+			// Where a loose file belongs is a judgment call
+			message: "Loose component file in foo/Loose.tsx - move to appropriate folder (create/, read/, update/, delete/, hooks/, screens/, types/, utils/)",
+			fix:     "",
+		},
+	}
+	for _, tt := range tests {
+		fix, ok := fixes[tt.message]
+		if !ok {
+			t.Errorf("missing issue %q in %v", tt.message, issues)
+			continue
+		}
+		if fix != tt.fix {
+			t.Errorf("fix for %q\n got: %s\nwant: %s", tt.message, fix, tt.fix)
+		}
+	}
+	if len(issues) != len(tests) {
+		t.Errorf("expected %d issues, got %d: %v", len(tests), len(issues), issues)
+	}
+}
+
+func TestCheckFeatureStructure_FixCommandsQuotePaths(t *testing.T) {
+	root := t.TempDir()
+	featurePath := filepath.Join(root, "components", "shared", "my feature")
+	if err := os.MkdirAll(featurePath, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := structureConfig{featureStructure: featureStructure{
+		CRUD:          new(bool),
+		Folders:       []string{"ui"},
+		RequiredFiles: []string{"index.ts"},
+	}}
+	issues := checkFeatureStructure(root, featurePath, cfg)
+	want := "mkdir -p 'components/shared/my feature/ui' && touch 'components/shared/my feature/ui/index.ts'"
+	if len(issues) == 0 || issues[0].FixCommand != want {
+		t.Errorf("got %v, want first fix %q", issues, want)
+	}
+}
+
+func TestFixCommands(t *testing.T) {
+	issues := []StructureIssue{
+		{Message: "a", FixCommand: "touch a"},
+		{Message: "loose"},
+		{Message: "a again", FixCommand: "touch a"},
+		{Message: "b", FixCommand: "touch b"},
+	}
+	want := []string{"touch a", "touch b"}
+	if got := fixCommands(issues); !reflect.DeepEqual(got, want) {
+		t.Errorf("fixCommands() = %v, want %v", got, want)
+	}
+}
+
+func TestFormatIssues(t *testing.T) {
+	issues := []StructureIssue{
+		{Message: "Missing required folder: foo/ui/", FixCommand: "mkdir -p components/routes/foo/ui"},
+		{Message: "Loose component file in foo/A.tsx"},
+		{Message: "Missing main barrel export: foo/index.ts", FixCommand: "touch components/routes/foo/index.ts"},
+	}
+	got := formatIssues("/project", issues)
+	want := "  - Missing required folder: foo/ui/\n" +
+		"  - Loose component file in foo/A.tsx\n" +
+		"  - Missing main barrel export: foo/index.ts\n" +
+		"\nSuggested fix commands:\n\n" +
+		"  cd /project\n" +
+		"  mkdir -p components/routes/foo/ui\n" +
+		"  touch components/routes/foo/index.ts\n"
+	if got != want {
+		t.Errorf("formatIssues()\n got: %q\nwant: %q", got, want)
+	}
+
+	// Without fix commands there is no fix block
+	got = formatIssues("/project", issues[1:2])
+	if strings.Contains(got, "Suggested fix commands") {
+		t.Errorf("expected no fix block, got %q", got)
+	}
+}
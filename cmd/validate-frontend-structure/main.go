@@ -7,9 +7,11 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+
+	"github.com/milehighideas/claude-hooks/internal/filewrite"
 )
 
-// Required folders for each feature
+// Default required folders for each feature
 var requiredFolders = []string{
 	"create",
 	"read",
@@ -21,7 +23,7 @@ var requiredFolders = []string{
 	"utils",
 }
 
-// Required files for each folder
+// Default required files for each folder, with their descriptions
 var requiredFiles = map[string]string{
 	"index.ts": "Barrel export file",
 	".gitkeep": "Git tracking file",
@@ -63,24 +65,34 @@ func findProjectRoot(startPath string) (string, error) {
 	}
 }
 
-// checkFeatureStructure validates that a feature has the required folder structure
-func checkFeatureStructure(featurePath string) []string {
-	var issues []string
+// checkFeatureStructure validates that a feature has the required folder
+// structure, using cfg's light structure when the feature has the marker file.
+// Fix commands use paths relative to projectRoot.
+func checkFeatureStructure(projectRoot, featurePath string, cfg structureConfig) []StructureIssue {
+	var issues []StructureIssue
 	featureName := filepath.Base(featurePath)
+	featureDir := relPath(projectRoot, featurePath)
+	rules := cfg.rules(isLightFeature(featurePath))
 
 	// Check for required folders
-	for _, folder := range requiredFolders {
+	for _, folder := range rules.folders {
 		folderPath := filepath.Join(featurePath, folder)
 		if info, err := os.Stat(folderPath); err != nil || !info.IsDir() {
-			issues = append(issues, fmt.Sprintf("Missing required folder: %s/%s/", featureName, folder))
+			issues = append(issues, StructureIssue{
+				Message:    fmt.Sprintf("Missing required folder: %s/%s/", featureName, folder),
+				FixCommand: mkdirFixCommand(filepath.Join(featureDir, folder), rules.files),
+			})
 			continue
 		}
 
 		// Check for required files in each folder
-		for filename, description := range requiredFiles {
+		for _, filename := range rules.files {
 			filePath := filepath.Join(folderPath, filename)
 			if _, err := os.Stat(filePath); err != nil {
-				issues = append(issues, fmt.Sprintf("Missing %s: %s/%s/%s", description, featureName, folder, filename))
+				issues = append(issues, StructureIssue{
+					Message:    fmt.Sprintf("Missing %s: %s/%s/%s", fileDescription(filename), featureName, folder, filename),
+					FixCommand: touchFixCommand(filepath.Join(featureDir, folder), []string{filename}),
+				})
 			}
 		}
 	}
@@ -88,7 +100,10 @@ func checkFeatureStructure(featurePath string) []string {
 	// Check main barrel export
 	mainIndex := filepath.Join(featurePath, "index.ts")
 	if _, err := os.Stat(mainIndex); err != nil {
-		issues = append(issues, fmt.Sprintf("Missing main barrel export: %s/index.ts", featureName))
+		issues = append(issues, StructureIssue{
+			Message:    fmt.Sprintf("Missing main barrel export: %s/index.ts", featureName),
+			FixCommand: touchFixCommand(featureDir, []string{"index.ts"}),
+		})
 	}
 
 	// Check for loose component files directly in feature folder
@@ -97,14 +112,18 @@ func checkFeatureStructure(featurePath string) []string {
 		return issues
 	}
 
+	target := "a subfolder"
+	if len(rules.folders) > 0 {
+		target = fmt.Sprintf("appropriate folder (%s/)", strings.Join(rules.folders, "/, "))
+	}
 	for _, entry := range entries {
 		if !entry.IsDir() {
 			name := entry.Name()
 			ext := filepath.Ext(name)
 			if (ext == ".tsx" || ext == ".ts") && name != "index.ts" && name != "index.tsx" {
-				issues = append(issues,
-					fmt.Sprintf("Loose component file in %s/%s - move to appropriate CRUD folder (create/, read/, update/, delete/)",
-						featureName, name))
+				issues = append(issues, StructureIssue{
+					Message: fmt.Sprintf("Loose component file in %s/%s - move to %s", featureName, name, target),
+				})
 			}
 		}
 	}
@@ -113,8 +132,8 @@ func checkFeatureStructure(featurePath string) []string {
 }
 
 // checkNoLooseComponents checks for loose .tsx files in components root
-func checkNoLooseComponents(componentsDir string) []string {
-	var issues []string
+func checkNoLooseComponents(componentsDir string) []StructureIssue {
+	var issues []StructureIssue
 
 	if _, err := os.Stat(componentsDir); err != nil {
 		return issues
@@ -130,7 +149,9 @@ func checkNoLooseComponents(componentsDir string) []string {
 			name := entry.Name()
 			ext := filepath.Ext(name)
 			if (ext == ".tsx" || ext == ".ts") && name != "index.ts" {
-				issues = append(issues, fmt.Sprintf("Loose component file (must be in feature folder): components/%s", name))
+				issues = append(issues, StructureIssue{
+					Message: fmt.Sprintf("Loose component file (must be in feature folder): components/%s", name),
+				})
 			}
 		}
 	}
@@ -139,8 +160,8 @@ func checkNoLooseComponents(componentsDir string) []string {
 }
 
 // validateStructure validates the entire frontend structure
-func validateStructure(projectRoot string) []string {
-	var issues []string
+func validateStructure(projectRoot string, cfg structureConfig) []StructureIssue {
+	var issues []StructureIssue
 
 	// Check for apps/web/components or components directory
 	webComponents := filepath.Join(projectRoot, "apps", "web", "components")
@@ -167,7 +188,7 @@ func validateStructure(projectRoot string) []string {
 			for _, entry := range entries {
 				if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
 					featurePath := filepath.Join(routesDir, entry.Name())
-					issues = append(issues, checkFeatureStructure(featurePath)...)
+					issues = append(issues, checkFeatureStructure(projectRoot, featurePath, cfg)...)
 				}
 			}
 		}
@@ -176,7 +197,7 @@ func validateStructure(projectRoot string) []string {
 	// Check shared/ folder structure
 	sharedDir := filepath.Join(componentsDir, "shared")
 	if info, err := os.Stat(sharedDir); err == nil && info.IsDir() {
-		issues = append(issues, checkFeatureStructure(sharedDir)...)
+		issues = append(issues, checkFeatureStructure(projectRoot, sharedDir, cfg)...)
 	}
 
 	return issues
@@ -184,25 +205,16 @@ func validateStructure(projectRoot string) []string {
 
 // isStructureModifyingOperation checks if the operation modifies component structure
 func isStructureModifyingOperation(data ToolUseData) bool {
-	toolName := data.ToolName
-
-	// Only check Write and Edit operations in components/
-	if toolName != "Write" && toolName != "Edit" && toolName != "Bash" {
-		return false
-	}
-
-	// Check file paths for Write and Edit
-	if toolName == "Write" || toolName == "Edit" {
-		if filePath, ok := data.ToolInput["file_path"].(string); ok {
-			if strings.Contains(filePath, "/components/") &&
-				(strings.HasSuffix(filePath, ".tsx") || strings.HasSuffix(filePath, ".ts")) {
-				return true
-			}
+	// File writes (Write, Edit, MultiEdit, Bash heredoc/redirect) in components/
+	for _, w := range filewrite.FromMap(data.ToolName, data.ToolInput) {
+		if strings.Contains(w.Path, "/components/") &&
+			(strings.HasSuffix(w.Path, ".tsx") || strings.HasSuffix(w.Path, ".ts")) {
+			return true
 		}
 	}
 
 	// Check bash commands that might CREATE structure (not delete)
-	if toolName == "Bash" {
+	if data.ToolName == "Bash" {
 		if command, ok := data.ToolInput["command"].(string); ok {
 			if strings.Contains(command, "/components/") {
 				// Only check for create operations, not rm (deletes are fine)
@@ -277,22 +289,21 @@ func main() {
 		os.Exit(0)
 	}
 
+	cfg, err := loadStructureConfig(projectRoot)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "validate-frontend-structure: ignoring %s: %v\n", structureConfigFile, err)
+	}
+
 	// Validate structure
-	issues := validateStructure(projectRoot)
+	issues := validateStructure(projectRoot, cfg)
 
 	if len(issues) > 0 {
 		msg := "BLOCKED: Frontend structure validation failed\n\n" +
-			"The following issues were found with your frontend architecture:\n\n"
-
-		for _, issue := range issues {
-			msg += fmt.Sprintf("  - %s\n", issue)
-		}
+			"The following issues were found with your frontend architecture:\n\n" +
+			formatIssues(projectRoot, issues)
 
 		msg += "\nRequired structure for each feature in components/routes/ and components/shared/:\n" +
-			"  - All CRUD folders: create/, read/, update/, delete/\n" +
-			"  - Other folders: hooks/, screens/, types/, utils/\n" +
-			"  - Each folder must have: index.ts and .gitkeep\n" +
-			"  - Main feature folder must have: index.ts\n\n" +
+			describeStructure(cfg.rules(false)) + "\n" +
 			"No loose .tsx files allowed in components/ root - use feature folders!\n\n" +
 			"To fix:\n" +
 			"1. Create missing folders and files\n" +
@@ -208,7 +208,7 @@ func TestCheckFeatureStructure(t *testing.T) {
 			tt.setup(tmpDir)
 
 			featurePath := filepath.Join(tmpDir, "test-feature")
-			issues := checkFeatureStructure(featurePath)
+			issues := checkFeatureStructure(tmpDir, featurePath, structureConfig{})
 
 			if len(issues) != tt.wantIssues {
 				t.Errorf("Expected %d issues, got %d: %v", tt.wantIssues, len(issues), issues)
@@ -338,7 +338,7 @@ func TestValidateStructure(t *testing.T) {
 			tmpDir := setupTestProject(t)
 			tt.setup(tmpDir)
 
-			issues := validateStructure(tmpDir)
+			issues := validateStructure(tmpDir, structureConfig{})
 
 			if len(issues) != tt.wantIssues {
 				t.Errorf("Expected %d issues, got %d: %v", tt.wantIssues, len(issues), issues)
@@ -373,6 +373,29 @@ func TestIsStructureModifyingOperation(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			name: "MultiEdit operation in components",
+			data: ToolUseData{
+				ToolName: "MultiEdit",
+				ToolInput: map[string]interface{}{
+					"file_path": "/path/to/components/Feature.tsx",
+					"edits": []interface{}{
+						map[string]interface{}{"old_string": "a", "new_string": "b"},
+					},
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "Bash heredoc in components",
+			data: ToolUseData{
+				ToolName: "Bash",
+				ToolInput: map[string]interface{}{
+					"command": "cat > /path/to/components/Feature.tsx << 'EOF'\nexport const Feature = () => null;\nEOF",
+				},
+			},
+			expected: true,
+		},
 		{
 			name: "Bash mkdir in components",
 			data: ToolUseData{
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/milehighideas/claude-hooks/internal/jsonc"
+)
+
+const (
+	// structureConfigFile at the project root overrides the default structure
+	structureConfigFile = ".frontend-structure.json"
+
+	// lightFeatureMarker in a feature folder selects the config's light structure
+	lightFeatureMarker = ".light-feature"
+)
+
+// crudFolders are the folders required when a structure sets crud
+var crudFolders = []string{"create", "read", "update", "delete"}
+
+// featureStructure describes the folders a feature must contain. Unset
+// fields fall back to the defaults.
+type featureStructure struct {
+	CRUD          *bool    `json:"crud"`          // require create/, read/, update/, delete/
+	Folders       []string `json:"folders"`       // other required folders
+	RequiredFiles []string `json:"requiredFiles"` // files every required folder must contain
+}
+
+// structureConfig is the contents of .frontend-structure.json. Light applies
+// to features containing lightFeatureMarker.
+type structureConfig struct {
+	featureStructure
+	Light *featureStructure `json:"light"`
+}
+
+// structureRules is a featureStructure resolved against the defaults.
+type structureRules struct {
+	folders []string
+	files   []string
+}
+
+// loadStructureConfig reads .frontend-structure.json from the project root.
+// A missing file yields the zero config, which resolves to the defaults.
+func loadStructureConfig(projectRoot string) (structureConfig, error) {
+	var cfg structureConfig
+	err := jsonc.Unmarshal(filepath.Join(projectRoot, structureConfigFile), &cfg)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return structureConfig{}, err
+	}
+	return cfg, nil
+}
+
+// rules resolves the structure for a feature. Full features default to CRUD
+// plus the non-CRUD requiredFolders; light features default to no required
+// folders. Both default to the full structure's required files.
+func (c structureConfig) rules(light bool) structureRules {
+	files := c.RequiredFiles
+	if files == nil {
+		files = defaultRequiredFiles()
+	}
+
+	if !light {
+		return c.featureStructure.resolve(true, defaultOtherFolders(), files)
+	}
+	if c.Light == nil {
+		return structureRules{files: files}
+	}
+	return c.Light.resolve(false, nil, files)
+}
+
+// resolve fills unset fields with the given defaults.
+func (s featureStructure) resolve(crud bool, folders, files []string) structureRules {
+	if s.CRUD != nil {
+		crud = *s.CRUD
+	}
+	if s.Folders != nil {
+		folders = s.Folders
+	}
+	if s.RequiredFiles != nil {
+		files = s.RequiredFiles
+	}
+
+	var rules structureRules
+	if crud {
+		rules.folders = append(rules.folders, crudFolders...)
+	}
+	rules.folders = append(rules.folders, folders...)
+	rules.files = files
+	return rules
+}
+
+// isLightFeature reports whether a feature opted into the light structure.
+func isLightFeature(featurePath string) bool {
+	_, err := os.Stat(filepath.Join(featurePath, lightFeatureMarker))
+	return err == nil
+}
+
+// defaultOtherFolders returns requiredFolders without the CRUD folders.
+func defaultOtherFolders() []string {
+	var folders []string
+	for _, folder := range requiredFolders {
+		isCRUD := false
+		for _, crud := range crudFolders {
+			if folder == crud {
+				isCRUD = true
+				break
+			}
+		}
+		if !isCRUD {
+			folders = append(folders, folder)
+		}
+	}
+	return folders
+}
+
+// defaultRequiredFiles returns the requiredFiles names, sorted.
+func defaultRequiredFiles() []string {
+	files := make([]string, 0, len(requiredFiles))
+	for name := range requiredFiles {
+		files = append(files, name)
+	}
+	sort.Strings(files)
+	return files
+}
+
+// fileDescription names a required file in issue messages.
+func fileDescription(name string) string {
+	if description, ok := requiredFiles[name]; ok {
+		return description
+	}
+	return "required file"
+}
+
+// describeStructure summarizes the required structure for the block message.
+func describeStructure(rules structureRules) string {
+	var sb strings.Builder
+	if len(rules.folders) > 0 {
+		fmt.Fprintf(&sb, "  - Folders: %s/\n", strings.Join(rules.folders, "/, "))
+	}
+	if len(rules.files) > 0 {
+		fmt.Fprintf(&sb, "  - Each folder must have: %s\n", strings.Join(rules.files, " and "))
+	}
+	sb.WriteString("  - Main feature folder must have: index.ts\n")
+	return sb.String()
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// threeFolderConfig is a custom convention: ui/, data/ and types/, each with
+// an index.ts, and no CRUD folders
+const threeFolderConfig = `{
+  // no CRUD split in this project
+  "crud": false,
+  "folders": ["ui", "data", "types"],
+  "requiredFiles": ["index.ts"],
+  "light": { "folders": ["ui"] }
+}`
+
+// writeFeature creates a feature with the given folders, each holding files,
+// plus the main index.ts.
+func writeFeature(t *testing.T, featurePath string, folders, files []string) {
+	t.Helper()
+	if err := os.MkdirAll(featurePath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, folder := range folders {
+		if err := os.MkdirAll(filepath.Join(featurePath, folder), 0755); err != nil {
+			t.Fatal(err)
+		}
+		for _, name := range files {
+			if err := os.WriteFile(filepath.Join(featurePath, folder, name), nil, 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := os.WriteFile(filepath.Join(featurePath, "index.ts"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func loadTestConfig(t *testing.T, content string) structureConfig {
+	t.Helper()
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, structureConfigFile), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := loadStructureConfig(root)
+	if err != nil {
+		t.Fatalf("loadStructureConfig: %v", err)
+	}
+	return cfg
+}
+
+func TestLoadStructureConfig(t *testing.T) {
+	t.Run("missing file uses defaults", func(t *testing.T) {
+		cfg, err := loadStructureConfig(t.TempDir())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		rules := cfg.rules(false)
+		if !reflect.DeepEqual(rules.folders, requiredFolders) {
+			t.Errorf("folders = %v, want %v", rules.folders, requiredFolders)
+		}
+		if !reflect.DeepEqual(rules.files, []string{".gitkeep", "index.ts"}) {
+			t.Errorf("files = %v", rules.files)
+		}
+	})
+
+	t.Run("malformed file is an error", func(t *testing.T) {
+		root := t.TempDir()
+		_ = os.WriteFile(filepath.Join(root, structureConfigFile), []byte("{"), 0644)
+		if _, err := loadStructureConfig(root); err == nil {
+			t.Error("expected an error")
+		}
+	})
+
+	t.Run("custom convention", func(t *testing.T) {
+		cfg := loadTestConfig(t, threeFolderConfig)
+		rules := cfg.rules(false)
+		if !reflect.DeepEqual(rules.folders, []string{"ui", "data", "types"}) {
+			t.Errorf("folders = %v", rules.folders)
+		}
+		if !reflect.DeepEqual(rules.files, []string{"index.ts"}) {
+			t.Errorf("files = %v", rules.files)
+		}
+
+		light := cfg.rules(true)
+		if !reflect.DeepEqual(light.folders, []string{"ui"}) {
+			t.Errorf("light folders = %v", light.folders)
+		}
+		if !reflect.DeepEqual(light.files, []string{"index.ts"}) {
+			t.Errorf("light files should inherit requiredFiles, got %v", light.files)
+		}
+	})
+
+	t.Run("crud only keeps default folders", func(t *testing.T) {
+		cfg := loadTestConfig(t, `{"crud": false}`)
+		want := []string{"hooks", "screens", "types", "utils"}
+		if got := cfg.rules(false).folders; !reflect.DeepEqual(got, want) {
+			t.Errorf("folders = %v, want %v", got, want)
+		}
+	})
+}
+
+func TestCheckFeatureStructure_CustomConvention(t *testing.T) {
+	cfg := loadTestConfig(t, threeFolderConfig)
+
+	t.Run("valid three-folder feature", func(t *testing.T) {
+		feature := filepath.Join(t.TempDir(), "billing")
+		writeFeature(t, feature, []string{"ui", "data", "types"}, []string{"index.ts"})
+		if issues := checkFeatureStructure(filepath.Dir(feature), feature, cfg); len(issues) != 0 {
+			t.Errorf("expected no issues, got %v", issues)
+		}
+	})
+
+	t.Run("missing custom folder", func(t *testing.T) {
+		feature := filepath.Join(t.TempDir(), "billing")
+		writeFeature(t, feature, []string{"ui", "types"}, []string{"index.ts"})
+		issues := checkFeatureStructure(filepath.Dir(feature), feature, cfg)
+		if len(issues) != 1 || !strings.Contains(issues[0].Message, "billing/data/") {
+			t.Errorf("expected missing data/ folder, got %v", issues)
+		}
+	})
+
+	t.Run("missing custom required file", func(t *testing.T) {
+		feature := filepath.Join(t.TempDir(), "billing")
+		writeFeature(t, feature, []string{"ui", "data", "types"}, nil)
+		if issues := checkFeatureStructure(filepath.Dir(feature), feature, cfg); len(issues) != 3 {
+			t.Errorf("expected 3 missing index.ts issues, got %v", issues)
+		}
+	})
+
+	t.Run("loose file names custom folders", func(t *testing.T) {
+		feature := filepath.Join(t.TempDir(), "billing")
+		writeFeature(t, feature, []string{"ui", "data", "types"}, []string{"index.ts"})
+		_ = os.WriteFile(filepath.Join(feature, "Invoice.tsx"), nil, 0644)
+		issues := checkFeatureStructure(filepath.Dir(feature), feature, cfg)
+		if len(issues) != 1 || !strings.Contains(issues[0].Message, "(ui/, data/, types/)") {
+			t.Errorf("expected loose file issue naming custom folders, got %v", issues)
+		}
+	})
+
+	t.Run("light feature only needs light folders", func(t *testing.T) {
+		feature := filepath.Join(t.TempDir(), "banner")
+		writeFeature(t, feature, []string{"ui"}, []string{"index.ts"})
+		_ = os.WriteFile(filepath.Join(feature, lightFeatureMarker), nil, 0644)
+		if issues := checkFeatureStructure(filepath.Dir(feature), feature, cfg); len(issues) != 0 {
+			t.Errorf("expected no issues, got %v", issues)
+		}
+	})
+}
+
+func TestCheckFeatureStructure_LightDefaults(t *testing.T) {
+	feature := filepath.Join(t.TempDir(), "banner")
+	writeFeature(t, feature, nil, nil)
+	_ = os.WriteFile(filepath.Join(feature, lightFeatureMarker), nil, 0644)
+
+	// Without a config, a light feature needs only its main index.ts
+	if issues := checkFeatureStructure(filepath.Dir(feature), feature, structureConfig{}); len(issues) != 0 {
+		t.Errorf("expected no issues, got %v", issues)
+	}
+
+	_ = os.Remove(filepath.Join(feature, "index.ts"))
+	if issues := checkFeatureStructure(filepath.Dir(feature), feature, structureConfig{}); len(issues) != 1 {
+		t.Errorf("expected missing main index.ts, got %v", issues)
+	}
+}
+
+func TestValidateStructure_CustomConvention(t *testing.T) {
+	root := setupTestProject(t)
+	_ = os.WriteFile(filepath.Join(root, structureConfigFile), []byte(threeFolderConfig), 0644)
+	writeFeature(t, filepath.Join(root, "components", "routes", "billing"),
+		[]string{"ui", "data", "types"}, []string{"index.ts"})
+
+	cfg, err := loadStructureConfig(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issues := validateStructure(root, cfg); len(issues) != 0 {
+		t.Errorf("expected no issues, got %v", issues)
+	}
+	if issues := validateStructure(root, structureConfig{}); len(issues) == 0 {
+		t.Error("expected the default structure to flag the three-folder feature")
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/milehighideas/claude-hooks/internal/glyph"
+)
+
+func TestSourceLiteralsAreValidUTF8(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := glyph.Validate(data); err != nil {
+			t.Errorf("%s: %v", f, err)
+		}
+	}
+}
+
+func TestStandaloneOutputIsValidUTF8(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "screens", "HomeScreen.tsx")
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	code := "import { useQuery } from 'convex/react';\nexport function HomeScreen() { return null }\n"
+	if err := os.WriteFile(file, []byte(code), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	oldFile, oldStdout := fileFlag, os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fileFlag, os.Stdout = file, w
+	defer func() { fileFlag, os.Stdout = oldFile, oldStdout }()
+
+	exit := runStandalone()
+	_ = w.Close()
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if exit != 2 {
+		t.Fatalf("runStandalone() = %d, want 2 (violations)", exit)
+	}
+	if err := glyph.Validate(out); err != nil {
+		t.Fatalf("output %v: %q", err, out)
+	}
+	if !strings.Contains(string(out), glyph.Fail) {
+		t.Errorf("output missing %s marker: %s", glyph.Fail, out)
+	}
+}
@@ -8,9 +8,10 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
-	"regexp"
 	"strings"
 
+	"github.com/milehighideas/claude-hooks/internal/filewrite"
+	"github.com/milehighideas/claude-hooks/internal/glyph"
 	"github.com/milehighideas/claude-hooks/internal/jsonc"
 	"github.com/milehighideas/claude-hooks/internal/srp"
 )
@@ -35,6 +36,11 @@ var (
 	srpExcludePaths []string
 )
 
+// convexImportOptions carries srpConfig's allowed-Convex-import overrides
+// (allowedConvexReactImports / allowedDataModelTypes / convexImportExemptPaths)
+// into the shared detectors. Zero value → internal/srp defaults.
+var convexImportOptions srp.Options
+
 // inSRPScope reports whether filePath is in SRP scope. ExcludePaths always win;
 // empty appPaths = all files in scope (back-compat with the previous unscoped
 // behavior).
@@ -64,9 +70,12 @@ func loadScreenHooksConfig() {
 
 	var raw struct {
 		SRPConfig struct {
-			ScreenHooks  []string `json:"screenHooks"`
-			AppPaths     []string `json:"appPaths"`
-			ExcludePaths []string `json:"excludePaths"`
+			ScreenHooks               []string `json:"screenHooks"`
+			AppPaths                  []string `json:"appPaths"`
+			ExcludePaths              []string `json:"excludePaths"`
+			AllowedConvexReactImports []string `json:"allowedConvexReactImports"`
+			AllowedDataModelTypes     []string `json:"allowedDataModelTypes"`
+			ConvexImportExemptPaths   []string `json:"convexImportExemptPaths"`
 		} `json:"srpConfig"`
 	}
 	if err := jsonc.Unmarshal(".pre-commit.json", &raw); err != nil {
@@ -74,6 +83,11 @@ func loadScreenHooksConfig() {
 	}
 	srpAppPaths = raw.SRPConfig.AppPaths
 	srpExcludePaths = raw.SRPConfig.ExcludePaths
+	convexImportOptions = srp.Options{
+		AllowedConvexReactImports: srp.NameSet(raw.SRPConfig.AllowedConvexReactImports),
+		AllowedDataModelTypes:     srp.NameSet(raw.SRPConfig.AllowedDataModelTypes),
+		ConvexImportExemptPaths:   raw.SRPConfig.ConvexImportExemptPaths,
+	}
 
 	hooks := raw.SRPConfig.ScreenHooks
 	if len(hooks) == 0 {
@@ -100,19 +114,15 @@ func loadScreenHooksConfig() {
 type SRPViolation = srp.Violation
 type ASTAnalysis = srp.Analysis
 
-// ToolInput represents the input to a tool
-type ToolInput struct {
-	FilePath string                 `json:"file_path"`
-	Content  string                 `json:"content"`
-	Command  string                 `json:"command"`
-	Extra    map[string]interface{} `json:"-"`
-}
+// ToolInput represents the input to a tool (Write, Edit, MultiEdit, Bash)
+type ToolInput = filewrite.ToolInput
 
 // ToolData represents the JSON data from stdin
 type ToolData struct {
-	ToolName  string                 `json:"tool_name"`
-	ToolInput ToolInput              `json:"tool_input"`
-	Extra     map[string]interface{} `json:"-"`
+	HookEventName string                 `json:"hook_event_name"` // "PreToolUse" or "PostToolUse"
+	ToolName      string                 `json:"tool_name"`
+	ToolInput     ToolInput              `json:"tool_input"`
+	Extra         map[string]interface{} `json:"-"`
 }
 
 func init() {
@@ -125,11 +135,11 @@ func init() {
 }
 
 func printUsage() {
-	fmt.Println("validate-srp - Single Responsibility Principle validator for TypeScript/TSX")
+	fmt.Println("validate-srp - Single Responsibility Principle validator for TypeScript/TSX, Vue and Svelte")
 	fmt.Println()
 	fmt.Println("USAGE:")
 	fmt.Println("  Standalone mode:")
-	fmt.Println("    validate-srp --path <directory>    Check all TS/TSX files in directory")
+	fmt.Println("    validate-srp --path <directory>    Check all TS/TSX/Vue/Svelte files in directory")
 	fmt.Println("    validate-srp --file <file>         Check a single file")
 	fmt.Println()
 	fmt.Println("  Claude hook mode (reads JSON from stdin):")
@@ -148,6 +158,7 @@ func printUsage() {
 	fmt.Println("  4. File size limits (screens: 100, hooks: 150, components: 200)")
 	fmt.Println("  5. Type exports location (must be in types/ folder)")
 	fmt.Println("  6. Mixed concerns (data + UI + state in same file)")
+	fmt.Println("  7. Import cycles between files (--path only, warning)")
 	fmt.Println()
 	fmt.Println("EXIT CODES:")
 	fmt.Println("  0 - No violations")
@@ -187,8 +198,8 @@ func runStandalone() int {
 			fmt.Fprintf(os.Stderr, "File not found: %s\n", absPath)
 			return 1
 		}
-		if !isTypeScriptFile(absPath) {
-			fmt.Fprintf(os.Stderr, "Not a TypeScript file: %s\n", absPath)
+		if !isSourceFile(absPath) {
+			fmt.Fprintf(os.Stderr, "Not a TypeScript, Vue or Svelte file: %s\n", absPath)
 			return 1
 		}
 		files = append(files, absPath)
@@ -214,8 +225,8 @@ func runStandalone() int {
 				return nil
 			}
 
-			// Only process TypeScript files (not test files)
-			if isTypeScriptFile(path) && !strings.Contains(path, ".test.") && !strings.Contains(path, ".spec.") {
+			// Only process TypeScript and SFC files (not test files)
+			if isSourceFile(path) && !strings.Contains(path, ".test.") && !strings.Contains(path, ".spec.") {
 				files = append(files, path)
 			}
 
@@ -240,6 +251,7 @@ func runStandalone() int {
 		violation SRPViolation
 	}
 	filesChecked := 0
+	var analyses []*ASTAnalysis
 
 	for _, file := range files {
 		content, err := os.ReadFile(file)
@@ -250,6 +262,7 @@ func runStandalone() int {
 
 		analysis := analyzeCode(string(content), file)
 		violations := validateSRPCompliance(analysis, file)
+		analyses = append(analyses, analysis)
 
 		filesChecked++
 
@@ -268,7 +281,17 @@ func runStandalone() int {
 		}
 
 		if verboseFlag && len(violations) == 0 {
-			fmt.Printf("✅ %s\n", file)
+			fmt.Printf(glyph.Pass+" %s\n", file)
+		}
+	}
+
+	// Import cycles span files, so they're only visible when checking a directory
+	if pathFlag != "" {
+		for _, v := range checkImportCycles(analyses) {
+			allWarnings = append(allWarnings, struct {
+				file      string
+				violation SRPViolation
+			}{v.File, v})
 		}
 	}
 
@@ -278,18 +301,18 @@ func runStandalone() int {
 	fmt.Println(strings.Repeat("=", 60))
 
 	if len(allWarnings) > 0 {
-		fmt.Printf("\n⚠️  WARNINGS (%d):\n", len(allWarnings))
+		fmt.Printf("\n"+glyph.Warn+"  WARNINGS (%d):\n", len(allWarnings))
 		for _, w := range allWarnings {
 			fmt.Printf("\n  %s:\n", w.file)
 			fmt.Printf("    %s\n", w.violation.Message)
 			if w.violation.Suggestion != "" {
-				fmt.Printf("    → %s\n", w.violation.Suggestion)
+				fmt.Printf("    "+glyph.Arrow+" %s\n", w.violation.Suggestion)
 			}
 		}
 	}
 
 	if len(allErrors) > 0 {
-		fmt.Printf("\n❌ ERRORS (%d):\n", len(allErrors))
+		fmt.Printf("\n"+glyph.Fail+" ERRORS (%d):\n", len(allErrors))
 		for _, e := range allErrors {
 			fmt.Printf("\n  %s:\n", e.file)
 			fmt.Printf("    %s\n", e.violation.Message)
@@ -304,11 +327,11 @@ func runStandalone() int {
 	fmt.Printf("Errors: %d, Warnings: %d\n", len(allErrors), len(allWarnings))
 
 	if len(allErrors) > 0 {
-		fmt.Println("\n❌ SRP check failed")
+		fmt.Println("\n" + glyph.Fail + " SRP check failed")
 		return 2
 	}
 
-	fmt.Println("\n✅ SRP check passed")
+	fmt.Println("\n" + glyph.Pass + " SRP check passed")
 	return 0
 }
 
@@ -330,29 +353,13 @@ func runHookMode() {
 	}
 
 	// Only validate on TypeScript file operations
-	isTS, filePath, content := isComponentWriteOperation(data)
-	if !isTS {
-		os.Exit(0)
-	}
-
-	if !inSRPScope(filePath) {
-		os.Exit(0) // out of SRP scope (e.g. Convex backend) — handled by validate-convex
-	}
-
-	// Analyze the file
-	var analysis *ASTAnalysis
-	if content != "" {
-		analysis = analyzeCode(content, filePath)
-	} else if fileExists(filePath) {
-		fileContent, err := os.ReadFile(filePath)
-		if err != nil {
-			os.Exit(0)
-		}
-		analysis = analyzeCode(string(fileContent), filePath)
-	} else {
+	filePath, content, ok := hookFileContent(data)
+	if !ok {
 		os.Exit(0)
 	}
 
+	// Analyze the complete resulting file
+	analysis := analyzeCode(content, filePath)
 	if analysis == nil {
 		os.Exit(0)
 	}
@@ -371,10 +378,10 @@ func runHookMode() {
 	}
 
 	if len(errors) > 0 {
-		msg := fmt.Sprintf("\n❌ BLOCKED: SRP violation in %s\n", filepath.Base(filePath))
+		msg := fmt.Sprintf("\n"+glyph.Fail+" BLOCKED: SRP violation in %s\n", filepath.Base(filePath))
 		msg += strings.Repeat("=", 60) + "\n"
 		for _, v := range errors {
-			msg += fmt.Sprintf("\n  ✗ %s\n", v.Message)
+			msg += fmt.Sprintf("\n  "+glyph.Cross+" %s\n", v.Message)
 			if v.Suggestion != "" {
 				msg += fmt.Sprintf("    FIX: %s\n", v.Suggestion)
 			}
@@ -391,11 +398,11 @@ func runHookMode() {
 	}
 
 	if len(warnings) > 0 {
-		msg := fmt.Sprintf("\n⚠️  SRP Warnings for %s:\n", filepath.Base(filePath))
+		msg := fmt.Sprintf("\n"+glyph.Warn+"  SRP Warnings for %s:\n", filepath.Base(filePath))
 		for _, v := range warnings {
 			msg += fmt.Sprintf("\n  %s", v.Message)
 			if v.Suggestion != "" {
-				msg += fmt.Sprintf("\n  → %s", v.Suggestion)
+				msg += fmt.Sprintf("\n  "+glyph.Arrow+" %s", v.Suggestion)
 			}
 		}
 		msg += "\n"
@@ -447,67 +454,77 @@ func isTypeScriptFile(filePath string) bool {
 		!strings.HasSuffix(filePath, ".d.ts")
 }
 
+// extractBashFileWrite returns the first TypeScript or SFC file a shell command
+// writes (heredoc or echo redirect) and its content.
 func extractBashFileWrite(command string) (string, string) {
-	// Pattern for heredoc: cat > file.tsx << 'EOF' ... EOF
-	// Since Go doesn't support backreferences, we'll match and manually verify
-	heredocRe := regexp.MustCompile(`(?s)cat\s*>\s*([^\s<]+\.tsx?)\s*<<\s*['"]?(\w+)['"]?\s*\n(.*?)\n(\w+)`)
-	if matches := heredocRe.FindStringSubmatch(command); matches != nil {
-		delimiter := matches[2]
-		endDelimiter := matches[4]
-		if delimiter == endDelimiter {
-			return matches[1], matches[3]
+	for _, w := range filewrite.ParseBash(command) {
+		if isSourceFile(w.Path) {
+			return w.Path, w.Content
 		}
 	}
+	return "", ""
+}
 
-	// Pattern for echo redirect: echo "..." > file.tsx
-	echoRe := regexp.MustCompile(`echo\s+['"](.+?)['"]\s*>\s*([^\s]+\.tsx?)`)
-	if matches := echoRe.FindStringSubmatch(command); matches != nil {
-		return matches[2], matches[1]
+// hookFileContent returns the in-scope TypeScript or SFC file a hook call writes and
+// its complete content after the call. ok is false when there is nothing to
+// check: not a TypeScript write, out of SRP scope (e.g. the Convex backend,
+// handled by validate-convex), or an edited file that can't be read.
+func hookFileContent(data ToolData) (filePath, content string, ok bool) {
+	isTS, filePath, content := isComponentWriteOperation(data)
+	if !isTS || !inSRPScope(filePath) {
+		return "", "", false
 	}
-
-	// Pattern for tee: tee file.tsx << 'EOF'
-	teeRe := regexp.MustCompile(`(?s)tee\s+([^\s<]+\.tsx?)\s*<<\s*['"]?(\w+)['"]?\s*\n(.*?)\n(\w+)`)
-	if matches := teeRe.FindStringSubmatch(command); matches != nil {
-		delimiter := matches[2]
-		endDelimiter := matches[4]
-		if delimiter == endDelimiter {
-			return matches[1], matches[3]
-		}
+	if content != "" {
+		return filePath, content, true
 	}
-
-	return "", ""
+	if !fileExists(filePath) {
+		return "", "", false
+	}
+	fileContent, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", "", false
+	}
+	return filePath, string(fileContent), true
 }
 
+// isComponentWriteOperation reports the TypeScript or SFC file a Write, Edit,
+// MultiEdit, or Bash call writes, with its content after the call.
+//
+// Edit and MultiEdit inputs carry only old/new strings, so the full file has
+// to come from disk, and what disk holds depends on the hook timing:
+//   - PreToolUse (and payloads without hook_event_name): disk is the pre-edit
+//     file, so the edits are applied to it.
+//   - PostToolUse: disk already holds the edited file. Content is "" and the
+//     caller reads it as-is; re-applying the edit could change it again.
+//
+// Content is also "" when a pre-edit file can't be read.
 func isComponentWriteOperation(data ToolData) (bool, string, string) {
-	toolName := data.ToolName
-	toolInput := data.ToolInput
-
-	// Handle Write and Edit tools
-	if toolName == "Write" || toolName == "Edit" {
-		filePath := toolInput.FilePath
-		content := toolInput.Content
-		if isTypeScriptFile(filePath) {
-			return true, filePath, content
+	for _, w := range filewrite.Extract(data.ToolName, data.ToolInput) {
+		if !isSourceFile(w.Path) {
+			continue
 		}
-		return false, "", ""
-	}
-
-	// Handle Bash tool - detect file writes
-	if toolName == "Bash" {
-		command := toolInput.Command
-		filePath, content := extractBashFileWrite(command)
-		if filePath != "" && isTypeScriptFile(filePath) {
-			return true, filePath, content
+		content := w.Content
+		if content == "" && len(w.Edits) > 0 && data.HookEventName != "PostToolUse" {
+			if existing, err := os.ReadFile(w.Path); err == nil {
+				content = w.Apply(string(existing))
+			}
 		}
-		return false, "", ""
+		return true, w.Path, content
 	}
-
 	return false, "", ""
 }
 
-// analyzeCode parses a TS/TSX file via the shared tree-sitter analyzer.
+// analyzeCode parses a TS/TSX file via the shared tree-sitter analyzer. For
+// a .vue or .svelte file only its <script> blocks are analyzed, with line
+// numbers kept relative to the whole SFC.
 func analyzeCode(code, filePath string) *ASTAnalysis {
-	return srp.Analyze(code, filePath)
+	if !isSFCFile(filePath) {
+		return srp.Analyze(code, filePath)
+	}
+	script, lines := sfcScript(code)
+	analysis := srp.Analyze(script, filePath)
+	analysis.LineCount = lines
+	return analysis
 }
 
 // validateSRPCompliance runs the shared SRP detectors. The
@@ -516,5 +533,16 @@ func validateSRPCompliance(analysis *ASTAnalysis, filePath string) []SRPViolatio
 	if os.Getenv("CLAUDE_HOOKS_AST_VALIDATION") == "false" {
 		return nil
 	}
-	return srp.RunDetectors(analysis, filePath, srp.Options{ScreenHooks: screenHooksConfig})
+	opts := convexImportOptions
+	opts.ScreenHooks = screenHooksConfig
+	return srp.RunDetectors(analysis, filePath, opts)
+}
+
+// checkImportCycles runs the cross-file import cycle check over every file a
+// directory run analyzed.
+func checkImportCycles(analyses []*ASTAnalysis) []SRPViolation {
+	if os.Getenv("CLAUDE_HOOKS_AST_VALIDATION") == "false" {
+		return nil
+	}
+	return srp.CheckImportCycles(analyses, convexImportOptions)
 }
@@ -2,7 +2,11 @@ package main
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/milehighideas/claude-hooks/internal/filewrite"
 )
 
 func TestIsTypeScriptFile(t *testing.T) {
@@ -357,3 +361,84 @@ func btoa(b bool) string {
 	}
 	return "false"
 }
+
+func TestIsComponentWriteOperationMultiEdit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "Screen.tsx")
+	if err := os.WriteFile(path, []byte("export const A = 1;\nexport const B = 2;\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	data := ToolData{
+		ToolName: "MultiEdit",
+		ToolInput: ToolInput{
+			FilePath: path,
+			Edits: []filewrite.Edit{
+				{OldString: "A = 1", NewString: "A = 10"},
+				{OldString: "B = 2", NewString: "B = 20"},
+			},
+		},
+	}
+
+	isTS, gotPath, content := isComponentWriteOperation(data)
+	if !isTS || gotPath != path {
+		t.Fatalf("isComponentWriteOperation() = %v, %q; want true, %q", isTS, gotPath, path)
+	}
+	if want := "export const A = 10;\nexport const B = 20;\n"; content != want {
+		t.Errorf("content = %q, want %q (edits applied to the file on disk)", content, want)
+	}
+}
+
+// TestHookEditIntroducingViolation covers an Edit whose new_string adds a
+// direct Convex import: the violation must be found in the complete resulting
+// file under both hook timings.
+func TestHookEditIntroducingViolation(t *testing.T) {
+	srpAppPaths, srpExcludePaths = nil, nil
+
+	const before = "import { Text } from 'react-native';\n\nexport function Header() {\n  return <Text>Hi</Text>;\n}\n"
+	edit := ToolInput{
+		OldString: "import { Text } from 'react-native';",
+		NewString: "import { Text } from 'react-native';\nimport { useQuery } from 'convex/react';",
+	}
+	after := strings.Replace(before, edit.OldString, edit.NewString, 1)
+
+	tests := []struct {
+		name   string
+		event  string
+		onDisk string
+	}{
+		{name: "PreToolUse applies the edit to the pre-edit file", event: "PreToolUse", onDisk: before},
+		{name: "no hook_event_name behaves like PreToolUse", event: "", onDisk: before},
+		{name: "PostToolUse reads the already-edited file", event: "PostToolUse", onDisk: after},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "components", "Header.tsx")
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, []byte(tt.onDisk), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			in := edit
+			in.FilePath = path
+			gotPath, content, ok := hookFileContent(ToolData{HookEventName: tt.event, ToolName: "Edit", ToolInput: in})
+			if !ok || gotPath != path {
+				t.Fatalf("hookFileContent() = %q, ok=%v; want %q, true", gotPath, ok, path)
+			}
+			if content != after {
+				t.Fatalf("content = %q, want the complete post-edit file %q", content, after)
+			}
+
+			var caught bool
+			for _, v := range validateSRPCompliance(analyzeCode(content, path), path) {
+				if v.Severity == "error" && v.RuleID == "directConvexImports" {
+					caught = true
+				}
+			}
+			if !caught {
+				t.Error("Edit that introduces a direct convex/react import was not flagged")
+			}
+		})
+	}
+}
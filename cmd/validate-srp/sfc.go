@@ -0,0 +1,46 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+// scriptBlockRe matches a <script> block of a single-file component,
+// capturing its body. Vue's <script setup> and Svelte's
+// <script context="module"> are script blocks too.
+var scriptBlockRe = regexp.MustCompile(`(?is)<script\b[^>]*>(.*?)</script\s*>`)
+
+// isSFCFile reports whether filePath is a Vue or Svelte single-file
+// component.
+func isSFCFile(filePath string) bool {
+	return strings.HasSuffix(filePath, ".vue") || strings.HasSuffix(filePath, ".svelte")
+}
+
+// isSourceFile reports whether filePath is checked for SRP: TypeScript, or a
+// single-file component whose script blocks are analyzed.
+func isSourceFile(filePath string) bool {
+	return isTypeScriptFile(filePath) || isSFCFile(filePath)
+}
+
+// sfcScript blanks everything in an SFC outside its <script> blocks, keeping
+// newlines, so the analyzer sees only the script code while every line
+// number still points at the same line of the SFC. lines is the number of
+// lines the script bodies span, for the file size limits.
+func sfcScript(code string) (script string, lines int) {
+	out := []byte(code)
+	keep := make([]bool, len(code))
+	for _, m := range scriptBlockRe.FindAllStringSubmatchIndex(code, -1) {
+		for i := m[2]; i < m[3]; i++ {
+			keep[i] = true
+		}
+		if body := strings.TrimSpace(code[m[2]:m[3]]); body != "" {
+			lines += strings.Count(body, "\n") + 1
+		}
+	}
+	for i := range out {
+		if !keep[i] && out[i] != '\n' {
+			out[i] = ' '
+		}
+	}
+	return string(out), lines
+}
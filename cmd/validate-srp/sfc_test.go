@@ -0,0 +1,105 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+const crudVue = `<template>
+  <form @submit="save">
+    <input v-model="name" />
+  </form>
+</template>
+
+<script lang="ts">
+export const createUserDefaults = { name: "" };
+
+export function validateUser(name: string) {
+  return name.length > 0;
+}
+</script>
+
+<style scoped>
+form { display: flex; }
+</style>
+`
+
+func TestIsSourceFile(t *testing.T) {
+	tests := map[string]bool{
+		"Component.tsx":   true,
+		"utils.ts":        true,
+		"types.d.ts":      false,
+		"UserForm.vue":    true,
+		"UserForm.svelte": true,
+		"script.js":       false,
+	}
+	for path, want := range tests {
+		if got := isSourceFile(path); got != want {
+			t.Errorf("isSourceFile(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestSFCScriptKeepsLineNumbers(t *testing.T) {
+	script, lines := sfcScript(crudVue)
+
+	if strings.Count(script, "\n") != strings.Count(crudVue, "\n") {
+		t.Errorf("sfcScript changed the line count: got %d newlines, want %d",
+			strings.Count(script, "\n"), strings.Count(crudVue, "\n"))
+	}
+	for _, gone := range []string{"<template>", "v-model", "display: flex", "<script"} {
+		if strings.Contains(script, gone) {
+			t.Errorf("sfcScript kept %q outside the script block", gone)
+		}
+	}
+	if got := strings.Split(script, "\n")[7]; got != `export const createUserDefaults = { name: "" };` {
+		t.Errorf("line 8 = %q, want the first export", got)
+	}
+	if lines != 5 {
+		t.Errorf("lines = %d, want 5", lines)
+	}
+}
+
+func TestAnalyzeCodeVueMultipleExportsInCRUDFolder(t *testing.T) {
+	filePath := "/app/features/users/create/CreateUserForm.vue"
+	analysis := analyzeCode(crudVue, filePath)
+
+	if len(analysis.Exports) != 2 {
+		t.Fatalf("got %d exports, want 2: %+v", len(analysis.Exports), analysis.Exports)
+	}
+	if analysis.Exports[0].Line != 8 || analysis.Exports[1].Line != 10 {
+		t.Errorf("export lines = %d, %d, want 8, 10 (lines in the .vue file)",
+			analysis.Exports[0].Line, analysis.Exports[1].Line)
+	}
+
+	var found *SRPViolation
+	violations := validateSRPCompliance(analysis, filePath)
+	for i := range violations {
+		if violations[i].RuleID == "multipleExports" {
+			found = &violations[i]
+		}
+	}
+	if found == nil {
+		t.Fatalf("expected a multipleExports violation, got %+v", violations)
+	}
+	if found.Line != 10 {
+		t.Errorf("violation line = %d, want 10", found.Line)
+	}
+}
+
+func TestAnalyzeCodeSvelteScriptOnly(t *testing.T) {
+	code := `<script context="module" lang="ts">
+  import { useQuery } from "convex/react";
+</script>
+
+<p>{count}</p>
+`
+	analysis := analyzeCode(code, "/app/components/Counter.svelte")
+
+	if len(analysis.Imports) != 1 || analysis.Imports[0].Line != 2 {
+		t.Errorf("imports = %+v, want one on line 2", analysis.Imports)
+	}
+	if analysis.LineCount != 1 {
+		t.Errorf("LineCount = %d, want 1 (script lines only)", analysis.LineCount)
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/milehighideas/claude-hooks/internal/jsonc"
+	"github.com/milehighideas/claude-hooks/internal/stubs"
+)
+
+// fileViolations is one file's result in a batch check.
+type fileViolations struct {
+	File       string      `json:"file"`
+	Violations []Violation `json:"violations"`
+}
+
+// checkTestRequirementsBatch runs checkTestRequirements over files with one
+// config, for callers that check many files per run such as pre-commit.
+// Files without violations, and files that can't be checked, are left out.
+// Reads go through fileCache, so a file looked at by several checks is read
+// once.
+func checkTestRequirementsBatch(files []string, cfg testFilesConfig) []fileViolations {
+	var results []fileViolations
+	for _, file := range files {
+		violations, err := checkTestRequirements(file, cfg)
+		if err != nil || len(violations) == 0 {
+			continue
+		}
+		results = append(results, fileViolations{File: file, Violations: violations})
+	}
+	return results
+}
+
+// collectSourceFiles expands paths into the .ts and .tsx files they name or
+// contain, skipping the directories -list-stubs skips.
+func collectSourceFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, p := range paths {
+		err := filepath.WalkDir(p, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if path != p && stubs.SkipWalkDir(d.Name()) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if strings.HasSuffix(path, ".ts") || strings.HasSuffix(path, ".tsx") {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// checkPaths implements --check: every .ts/.tsx file under paths is checked
+// against its project's testFilesConfig (files outside appPaths or in
+// excludePaths are skipped), violations are printed to out, and the number
+// of files with errors is returned. Files outside any project use the
+// default config.
+func checkPaths(paths []string, out io.Writer) (int, error) {
+	files, err := collectSourceFiles(paths)
+	if err != nil {
+		return 0, err
+	}
+
+	// Files of the same project share its config; parse it once.
+	configs := map[string]projectConfig{}
+	byRoot := map[string][]string{}
+	var roots []string
+	for _, file := range files {
+		root := findProjectRoot(file)
+		if _, ok := configs[root]; !ok {
+			var cfg projectConfig
+			if root != "" {
+				_ = jsonc.Unmarshal(filepath.Join(root, ".pre-commit.json"), &cfg)
+			}
+			configs[root] = cfg
+			roots = append(roots, root)
+		}
+		if root != "" && !isFileInScope(root, file, configs[root]) {
+			continue
+		}
+		byRoot[root] = append(byRoot[root], file)
+	}
+
+	failed := 0
+	for _, root := range roots {
+		for _, result := range checkTestRequirementsBatch(byRoot[root], configs[root].TestFilesConfig) {
+			failed++
+			fmt.Fprintln(out, result.File)
+			for _, v := range result.Violations {
+				fmt.Fprintf(out, "  ❌ %s (%s)\n     Expected: %s\n", v.Message, v.Reason, v.ExpectedPath)
+			}
+		}
+	}
+	return failed, nil
+}
+
+// runCheck is the --check entry point; it returns the process exit code:
+// 0 when every file passes, 1 when any has violations, 2 on error.
+func runCheck(paths []string, stdout, stderr io.Writer) int {
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	failed, err := checkPaths(paths, stdout)
+	if err != nil {
+		fmt.Fprintf(stderr, "validate-test-files: %v\n", err)
+		return 2
+	}
+	if failed > 0 {
+		fmt.Fprintf(stdout, "\n%d file(s) missing tests\n", failed)
+		return 1
+	}
+	return 0
+}
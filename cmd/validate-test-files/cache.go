@@ -0,0 +1,47 @@
+package main
+
+import (
+	"os"
+	"sync"
+	"time"
+)
+
+// cachedFile is a file's content as of the mtime and size it was read at.
+type cachedFile struct {
+	modTime time.Time
+	size    int64
+	content []byte
+}
+
+// fileCache holds file contents keyed by path for one process. A batch run
+// (--check) asks about the same components and test files many times;
+// entries are re-read only when the file's mtime or size changes.
+var fileCache = struct {
+	sync.Mutex
+	files map[string]cachedFile
+}{files: map[string]cachedFile{}}
+
+// readFileCached returns the content of path, reading it from disk only when
+// it isn't cached or has changed since it was cached.
+func readFileCached(path string) ([]byte, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	fileCache.Lock()
+	cached, ok := fileCache.files[path]
+	fileCache.Unlock()
+	if ok && cached.modTime.Equal(info.ModTime()) && cached.size == info.Size() {
+		return cached.content, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	fileCache.Lock()
+	fileCache.files[path] = cachedFile{modTime: info.ModTime(), size: info.Size(), content: content}
+	fileCache.Unlock()
+	return content, nil
+}
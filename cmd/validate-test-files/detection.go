@@ -0,0 +1,92 @@
+package main
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Hooks whose call marks a component as interactive
+var defaultCallHooks = []string{
+	"useState",
+	"useReducer",
+	"useContext",
+	"useMutation",
+	"useQuery",
+}
+
+// Form hooks, which mark a component as interactive on any mention
+var defaultFormHooks = []string{
+	"useForm",
+	"useFormState",
+	"useFormContext",
+	"useController",
+}
+
+// interactivePatterns returns the patterns that mark a component as
+// interactive: the default hooks, minus cfg.NonInteractiveHooks, plus calls
+// to cfg.InteractiveHooks.
+func interactivePatterns(cfg testFilesConfig) []*regexp.Regexp {
+	excluded := make(map[string]bool)
+	for _, hook := range cfg.NonInteractiveHooks {
+		excluded[hook] = true
+	}
+
+	var patterns []*regexp.Regexp
+	addCall := func(hook string) {
+		if !excluded[hook] {
+			patterns = append(patterns, regexp.MustCompile(`\b`+regexp.QuoteMeta(hook)+`\s*\(`))
+		}
+	}
+	for _, hook := range defaultCallHooks {
+		addCall(hook)
+	}
+	for _, hook := range defaultFormHooks {
+		if !excluded[hook] {
+			patterns = append(patterns, regexp.MustCompile(`\b`+regexp.QuoteMeta(hook)+`\b`))
+		}
+	}
+	for _, hook := range cfg.InteractiveHooks {
+		addCall(hook)
+	}
+	return patterns
+}
+
+// e2eExtensionFor returns the E2E test extension for an app type, preferring
+// cfg.E2EExtensions over the e2eExtensions defaults.
+func e2eExtensionFor(appType string, cfg testFilesConfig) (string, bool) {
+	if ext, ok := cfg.E2EExtensions[appType]; ok {
+		return ext, ext != ""
+	}
+	ext, ok := e2eExtensions[appType]
+	return ext, ok
+}
+
+// assertionPattern matches a test block or assertion: expect(, it(, test(,
+// describe(, including modifiers such as it.each( or describe.skip(
+var assertionPattern = regexp.MustCompile(`\b(?:expect|it|test|describe)(?:\.\w+)*\s*\(`)
+
+// hasAssertions reports whether the test file at path has any content worth
+// running. Maestro flows (.yaml) only need to be non-blank; other files need
+// a test block or assertion outside comments. Unreadable files count as
+// having assertions so a read error never blocks.
+func hasAssertions(path string) bool {
+	content, err := readFileCached(path)
+	if err != nil {
+		return true
+	}
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+		return strings.TrimSpace(string(content)) != ""
+	}
+	return assertionPattern.Match(stripComments(content))
+}
+
+// commentPattern matches // line comments and /* */ block comments
+var commentPattern = regexp.MustCompile(`(?s)/\*.*?\*/|//[^\n]*`)
+
+// stripComments removes comments so commented-out tests don't count. It
+// doesn't understand strings, which only matters for a "//" inside one.
+func stripComments(content []byte) []byte {
+	return commentPattern.ReplaceAll(content, nil)
+}
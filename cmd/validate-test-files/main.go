@@ -7,15 +7,15 @@ import (
 	"io"
 	"os"
 	"path/filepath"
-	"regexp"
 	"strings"
 
+	"github.com/milehighideas/claude-hooks/internal/filewrite"
 	"github.com/milehighideas/claude-hooks/internal/jsonc"
 	"github.com/milehighideas/claude-hooks/internal/stubs"
 	"github.com/milehighideas/claude-hooks/internal/substance"
 )
 
-// E2E test extensions by app type
+// Default E2E test extensions by app type
 var e2eExtensions = map[string]string{
 	"mobile": ".maestro.yaml",
 	"native": ".maestro.yaml",
@@ -32,13 +32,8 @@ type Violation struct {
 	AppType      string `json:"app_type,omitempty"`
 }
 
-// ToolInput represents the input to a tool call
-type ToolInput struct {
-	FilePath  string `json:"file_path"`
-	Content   string `json:"content,omitempty"`    // Write
-	OldString string `json:"old_string,omitempty"` // Edit
-	NewString string `json:"new_string,omitempty"` // Edit
-}
+// ToolInput represents the input to a tool call (Write, Edit, MultiEdit, Bash)
+type ToolInput = filewrite.ToolInput
 
 // HookData represents the JSON input from Claude
 type HookData struct {
@@ -91,12 +86,12 @@ func getUnitTestPath(filePath string) string {
 }
 
 // getE2ETestPath returns expected E2E test file path
-func getE2ETestPath(filePath, appType string) string {
+func getE2ETestPath(filePath, appType string, cfg testFilesConfig) string {
 	if appType == "" {
 		return ""
 	}
 
-	extension, exists := e2eExtensions[appType]
+	extension, exists := e2eExtensionFor(appType, cfg)
 	if !exists {
 		return ""
 	}
@@ -132,47 +127,24 @@ func isHookOrUtil(filePath string) bool {
 	return false
 }
 
-// isInteractiveComponent determines if component is interactive using code patterns
-func isInteractiveComponent(filePath string) (bool, error) {
+// isInteractiveComponent determines if component is interactive using code
+// patterns; cfg can add and remove interactive hooks
+func isInteractiveComponent(filePath string, cfg testFilesConfig) (bool, error) {
 	// Forms are always interactive
 	if isCRUDFolder(filePath) && !strings.Contains(filePath, "/read/") {
 		return true, nil
 	}
 
-	// Read file content
-	content, err := os.ReadFile(filePath)
+	// Read file content (cached across a batch run)
+	content, err := readFileCached(filePath)
 	if err != nil {
 		return false, fmt.Errorf("failed to read file: %w", err)
 	}
 
 	code := string(content)
 
-	// Check for state management hooks
-	stateHooks := []string{
-		`useState`,
-		`useReducer`,
-		`useContext`,
-		`useMutation`,
-		`useQuery`,
-	}
-
-	for _, hook := range stateHooks {
-		pattern := regexp.MustCompile(`\b` + hook + `\s*\(`)
-		if pattern.MatchString(code) {
-			return true, nil
-		}
-	}
-
-	// Check for form hooks
-	formHooks := []string{
-		`useForm`,
-		`useFormState`,
-		`useFormContext`,
-		`useController`,
-	}
-
-	for _, hook := range formHooks {
-		pattern := regexp.MustCompile(`\b` + hook + `\b`)
+	// Check for state management, data and form hooks
+	for _, pattern := range interactivePatterns(cfg) {
 		if pattern.MatchString(code) {
 			return true, nil
 		}
@@ -182,7 +154,7 @@ func isInteractiveComponent(filePath string) (bool, error) {
 }
 
 // checkTestRequirements checks if file meets test requirements
-func checkTestRequirements(filePath string) ([]Violation, error) {
+func checkTestRequirements(filePath string, cfg testFilesConfig) ([]Violation, error) {
 	violations := []Violation{}
 
 	// Skip test files themselves
@@ -205,7 +177,7 @@ func checkTestRequirements(filePath string) ([]Violation, error) {
 
 	// Get expected test paths
 	unitTestPath := getUnitTestPath(filePath)
-	e2eTestPath := getE2ETestPath(filePath, appType)
+	e2eTestPath := getE2ETestPath(filePath, appType, cfg)
 
 	// Determine test requirements
 	needsUnitTest := false
@@ -234,7 +206,7 @@ func checkTestRequirements(filePath string) ([]Violation, error) {
 		reason = "Hooks and utilities"
 	} else {
 		// Other components - check if interactive
-		interactive, err := isInteractiveComponent(filePath)
+		interactive, err := isInteractiveComponent(filePath, cfg)
 		if err != nil {
 			// If we can't determine, skip validation
 			return violations, nil
@@ -261,6 +233,13 @@ func checkTestRequirements(filePath string) ([]Violation, error) {
 				Reason:       reason,
 				ExpectedPath: unitTestPath,
 			})
+		} else if cfg.RequireAssertions && !hasAssertions(unitTestPath) {
+			violations = append(violations, Violation{
+				Severity:     "error",
+				Message:      fmt.Sprintf("Test file has no assertions: %s", filepath.Base(unitTestPath)),
+				Reason:       reason,
+				ExpectedPath: unitTestPath,
+			})
 		}
 	}
 
@@ -274,6 +253,14 @@ func checkTestRequirements(filePath string) ([]Violation, error) {
 				ExpectedPath: e2eTestPath,
 				AppType:      appType,
 			})
+		} else if cfg.RequireAssertions && !hasAssertions(e2eTestPath) {
+			violations = append(violations, Violation{
+				Severity:     "error",
+				Message:      fmt.Sprintf("Test file has no assertions: %s", filepath.Base(e2eTestPath)),
+				Reason:       reason,
+				ExpectedPath: e2eTestPath,
+				AppType:      appType,
+			})
 		}
 	}
 
@@ -282,19 +269,16 @@ func checkTestRequirements(filePath string) ([]Violation, error) {
 
 // isComponentWriteOperation checks if operation creates/modifies a component file
 func isComponentWriteOperation(data HookData) (bool, string) {
-	// Only check Write and Edit operations
-	if data.ToolName != "Write" && data.ToolName != "Edit" {
-		return false, ""
-	}
-
-	filePath := data.ToolInput.FilePath
-
-	// Only check TypeScript/TSX files in components/
-	if strings.Contains(filePath, "/components/") {
-		if strings.HasSuffix(filePath, ".tsx") || strings.HasSuffix(filePath, ".ts") {
-			// Skip if it's already a test file
-			if !isTestFile(filePath) {
-				return true, filePath
+	for _, w := range filewrite.Extract(data.ToolName, data.ToolInput) {
+		filePath := w.Path
+
+		// Only check TypeScript/TSX files in components/
+		if strings.Contains(filePath, "/components/") {
+			if strings.HasSuffix(filePath, ".tsx") || strings.HasSuffix(filePath, ".ts") {
+				// Skip if it's already a test file
+				if !isTestFile(filePath) {
+					return true, filePath
+				}
 			}
 		}
 	}
@@ -304,12 +288,10 @@ func isComponentWriteOperation(data HookData) (bool, string) {
 
 // isTestFileWriteOperation checks if the tool call writes or edits a unit test file.
 func isTestFileWriteOperation(data HookData) (bool, string) {
-	if data.ToolName != "Write" && data.ToolName != "Edit" {
-		return false, ""
-	}
-	fp := data.ToolInput.FilePath
-	if strings.HasSuffix(fp, ".test.tsx") || strings.HasSuffix(fp, ".test.ts") {
-		return true, fp
+	for _, w := range filewrite.Extract(data.ToolName, data.ToolInput) {
+		if strings.HasSuffix(w.Path, ".test.tsx") || strings.HasSuffix(w.Path, ".test.ts") {
+			return true, w.Path
+		}
 	}
 	return false, ""
 }
@@ -322,21 +304,20 @@ func isStubContent(content string) bool {
 }
 
 // getResultingTestContent computes the file content that would exist after the
-// tool call completes. Write supplies content directly; Edit applies a single
-// replacement to the existing file.
+// tool call completes. Write and Bash supply content directly; Edit and
+// MultiEdit apply their replacements to the existing file.
 func getResultingTestContent(data HookData) (string, error) {
-	switch data.ToolName {
-	case "Write":
-		return data.ToolInput.Content, nil
-	case "Edit":
-		existing, err := os.ReadFile(data.ToolInput.FilePath)
+	for _, w := range filewrite.Extract(data.ToolName, data.ToolInput) {
+		if len(w.Edits) == 0 {
+			return w.Content, nil
+		}
+		existing, err := os.ReadFile(w.Path)
 		if err != nil {
 			return "", fmt.Errorf("failed to read file for edit simulation: %w", err)
 		}
-		return strings.Replace(string(existing), data.ToolInput.OldString, data.ToolInput.NewString, 1), nil
-	default:
-		return "", fmt.Errorf("unsupported tool: %s", data.ToolName)
+		return w.Apply(string(existing)), nil
 	}
+	return "", fmt.Errorf("unsupported tool: %s", data.ToolName)
 }
 
 // checkDisabled checks if the hook is disabled via environment variable
@@ -417,6 +398,20 @@ type testFilesConfig struct {
 	// ExcludePaths skips files whose project-relative path contains any of
 	// these substrings. Exclusions always win over AppPaths.
 	ExcludePaths []string `json:"excludePaths"`
+	// InteractiveHooks are extra hooks whose call marks a component as
+	// interactive, on top of the built-in state, data and form hooks.
+	InteractiveHooks []string `json:"interactiveHooks"`
+	// NonInteractiveHooks are removed from the interactive hooks, built-in
+	// or configured.
+	NonInteractiveHooks []string `json:"nonInteractiveHooks"`
+	// E2EExtensions overrides the E2E test extension per app type (mobile,
+	// native, web, portal). An empty extension turns E2E checks off for
+	// that app type.
+	E2EExtensions map[string]string `json:"e2eExtensions"`
+	// RequireAssertions reads each existing test file and reports it when
+	// it contains no test block or assertion. Off by default since it reads
+	// every expected test file.
+	RequireAssertions bool `json:"requireAssertions"`
 }
 
 // loadProjectConfig walks up from filePath for .pre-commit.json, parses it,
@@ -707,7 +702,7 @@ expected values: toBe / toEqual / toMatchObject / toHaveBeenCalledWith.
 		return 0
 	}
 
-	violations, err := checkTestRequirements(componentPath)
+	violations, err := checkTestRequirements(componentPath, cfg.TestFilesConfig)
 	if err != nil {
 		// Allow if we can't validate (don't block on errors)
 		return 0
@@ -742,7 +737,7 @@ Test requirements:
   - Interactive components: Unit test + E2E test
   - Display components: Unit test only
 
-E2E test types:
+E2E test types (unless testFilesConfig.e2eExtensions overrides them):
   - mobile/native: .maestro.yaml
   - web/portal: .e2e.ts
 
@@ -763,8 +758,14 @@ func listStubs(root string, out io.Writer) (int, error) {
 func main() {
 	listStubsMode := flag.Bool("list-stubs", false,
 		"scan positional paths (or cwd) for pure-stub test files and exit")
+	checkMode := flag.Bool("check", false,
+		"check every component under positional paths (or cwd) for missing tests and exit")
 	flag.Parse()
 
+	if *checkMode {
+		os.Exit(runCheck(flag.Args(), os.Stdout, os.Stderr))
+	}
+
 	if *listStubsMode {
 		roots := flag.Args()
 		if len(roots) == 0 {
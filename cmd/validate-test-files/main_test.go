@@ -2,11 +2,15 @@ package main
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/milehighideas/claude-hooks/internal/filewrite"
 )
 
 func TestGetAppType(t *testing.T) {
@@ -213,7 +217,7 @@ func TestGetE2ETestPath(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := getE2ETestPath(tt.filePath, tt.appType)
+			got := getE2ETestPath(tt.filePath, tt.appType, testFilesConfig{})
 			if got != tt.want {
 				t.Errorf("getE2ETestPath() = %v, want %v", got, tt.want)
 			}
@@ -221,6 +225,34 @@ func TestGetE2ETestPath(t *testing.T) {
 	}
 }
 
+func TestGetE2ETestPath_ConfiguredExtensions(t *testing.T) {
+	cfg := testFilesConfig{E2EExtensions: map[string]string{
+		"web":    ".spec.ts",
+		"portal": ".cy.ts",
+		"mobile": "",
+	}}
+
+	tests := []struct {
+		name     string
+		filePath string
+		appType  string
+		want     string
+	}{
+		{name: "playwright override", filePath: "/apps/web/Home.tsx", appType: "web", want: "/apps/web/Home.spec.ts"},
+		{name: "cypress override", filePath: "/apps/portal/Home.tsx", appType: "portal", want: "/apps/portal/Home.cy.ts"},
+		{name: "empty extension disables E2E", filePath: "/apps/mobile/Home.tsx", appType: "mobile", want: ""},
+		{name: "unset app type keeps default", filePath: "/apps/native/Home.tsx", appType: "native", want: "/apps/native/Home.maestro.yaml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getE2ETestPath(tt.filePath, tt.appType, cfg); got != tt.want {
+				t.Errorf("getE2ETestPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestIsScreen(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -411,7 +443,7 @@ export const Component = () => {
 				t.Fatalf("failed to write test file: %v", err)
 			}
 
-			got, err := isInteractiveComponent(tt.filePath)
+			got, err := isInteractiveComponent(tt.filePath, testFilesConfig{})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("isInteractiveComponent() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -423,6 +455,80 @@ export const Component = () => {
 	}
 }
 
+func TestIsInteractiveComponent_ConfiguredHooks(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	signalSrc := `import { useSignal } from '@preact/signals-react';
+export const Counter = () => { const count = useSignal(0); return <div>{count}</div>; };`
+	contextSrc := `import { useContext } from 'react';
+export const Theme = () => { const theme = useContext(ThemeContext); return <div>{theme}</div>; };`
+
+	tests := []struct {
+		name    string
+		content string
+		cfg     testFilesConfig
+		want    bool
+	}{
+		{
+			name:    "custom hook is display-only by default",
+			content: signalSrc,
+			want:    false,
+		},
+		{
+			name:    "custom interactive hook",
+			content: signalSrc,
+			cfg:     testFilesConfig{InteractiveHooks: []string{"useSignal"}},
+			want:    true,
+		},
+		{
+			name:    "custom hook must be called",
+			content: `// useSignal is not used here` + "\nexport const X = () => <div />;",
+			cfg:     testFilesConfig{InteractiveHooks: []string{"useSignal"}},
+			want:    false,
+		},
+		{
+			name:    "built-in hook is interactive by default",
+			content: contextSrc,
+			want:    true,
+		},
+		{
+			name:    "built-in hook removed by nonInteractiveHooks",
+			content: contextSrc,
+			cfg:     testFilesConfig{NonInteractiveHooks: []string{"useContext"}},
+			want:    false,
+		},
+		{
+			name:    "nonInteractiveHooks wins over interactiveHooks",
+			content: signalSrc,
+			cfg: testFilesConfig{
+				InteractiveHooks:    []string{"useSignal"},
+				NonInteractiveHooks: []string{"useSignal"},
+			},
+			want: false,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(tmpDir, "components", string(rune('a'+i))+".tsx")
+			if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+				t.Fatalf("mkdir: %v", err)
+			}
+			if err := os.WriteFile(filePath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+
+			got, err := isInteractiveComponent(filePath, tt.cfg)
+			if err != nil {
+				t.Fatalf("isInteractiveComponent() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("isInteractiveComponent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestIsComponentWriteOperation(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -576,7 +682,7 @@ func TestCheckTestRequirements(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			violations, err := checkTestRequirements(tt.filePath)
+			violations, err := checkTestRequirements(tt.filePath, testFilesConfig{})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("checkTestRequirements() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -607,6 +713,89 @@ func TestCheckTestRequirements(t *testing.T) {
 	}
 }
 
+func TestHasAssertions(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    bool
+	}{
+		{name: "empty test file", file: "Empty.test.tsx", content: "", want: false},
+		{name: "imports only", file: "Imports.test.tsx", content: `import { render } from "@testing-library/react";` + "\n", want: false},
+		{name: "commented-out test", file: "Commented.test.tsx", content: "// it(\"renders\", () => {})\n/* expect(x).toBe(1) */\n", want: false},
+		{name: "expect call", file: "Expect.test.tsx", content: `it("renders", () => { expect(screen.getByText("Hi")).toHaveTextContent("Hi"); });`, want: true},
+		{name: "describe block", file: "Describe.test.ts", content: `describe("sum", () => {});`, want: true},
+		{name: "test.each", file: "Each.test.ts", content: "test.each([[1, 2]])(\"adds\", (a, b) => {});", want: true},
+		{name: "blank maestro flow", file: "Home.maestro.yaml", content: "\n  \n", want: false},
+		{name: "maestro flow", file: "Flow.maestro.yaml", content: "appId: com.example\n---\n- launchApp\n", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(tmpDir, tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+			if got := hasAssertions(path); got != tt.want {
+				t.Errorf("hasAssertions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if !hasAssertions(filepath.Join(tmpDir, "Missing.test.tsx")) {
+		t.Error("unreadable file should not be reported")
+	}
+}
+
+func TestCheckTestRequirements_RequireAssertions(t *testing.T) {
+	tmpDir := t.TempDir()
+	dir := filepath.Join(tmpDir, "hooks")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	hook := filepath.Join(dir, "useThing.ts")
+	testPath := filepath.Join(dir, "useThing.test.ts")
+	if err := os.WriteFile(hook, []byte("export const useThing = () => 1;"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	strict := testFilesConfig{RequireAssertions: true}
+
+	// An empty test file satisfies the default existence check...
+	if err := os.WriteFile(testPath, nil, 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	violations, err := checkTestRequirements(hook, testFilesConfig{})
+	if err != nil || len(violations) != 0 {
+		t.Fatalf("default mode: violations = %+v, err = %v", violations, err)
+	}
+
+	// ...but not the strict one
+	violations, err = checkTestRequirements(hook, strict)
+	if err != nil {
+		t.Fatalf("checkTestRequirements() error = %v", err)
+	}
+	if len(violations) != 1 || violations[0].Message != "Test file has no assertions: useThing.test.ts" {
+		t.Fatalf("strict mode: violations = %+v, want one no-assertions violation", violations)
+	}
+	if violations[0].ExpectedPath != testPath {
+		t.Errorf("ExpectedPath = %q, want %q", violations[0].ExpectedPath, testPath)
+	}
+
+	// A test file with assertions passes the strict check
+	content := `import { useThing } from "./useThing";
+it("returns one", () => { expect(useThing()).toBe(1); });`
+	if err := os.WriteFile(testPath, []byte(content), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	violations, err = checkTestRequirements(hook, strict)
+	if err != nil || len(violations) != 0 {
+		t.Errorf("strict mode with assertions: violations = %+v, err = %v", violations, err)
+	}
+}
+
 func TestCheckDisabled(t *testing.T) {
 	// Save original value
 	originalValue := os.Getenv("CLAUDE_HOOKS_AST_VALIDATION")
@@ -690,6 +879,24 @@ func TestIsTestFileWriteOperation(t *testing.T) {
 			wantIsOp:     true,
 			wantFilePath: "/src/components/Button.test.tsx",
 		},
+		{
+			name: "MultiEdit .test.tsx",
+			data: HookData{
+				ToolName:  "MultiEdit",
+				ToolInput: ToolInput{FilePath: "/src/components/Button.test.tsx"},
+			},
+			wantIsOp:     true,
+			wantFilePath: "/src/components/Button.test.tsx",
+		},
+		{
+			name: "Bash heredoc .test.ts",
+			data: HookData{
+				ToolName:  "Bash",
+				ToolInput: ToolInput{Command: "cat > /src/hooks/useAuth.test.ts << 'EOF'\nit('x', () => {});\nEOF"},
+			},
+			wantIsOp:     true,
+			wantFilePath: "/src/hooks/useAuth.test.ts",
+		},
 		{
 			name: "Write non-test file",
 			data: HookData{
@@ -1305,6 +1512,51 @@ export const X = () => { const [v] = useState(0); return <div>{v}</div>; };`
 	}
 }
 
+func TestRunUsesConfiguredTestFilesOptions(t *testing.T) {
+	t.Setenv("CLAUDE_HOOKS_AST_VALIDATION", "")
+
+	projectRoot := t.TempDir()
+	if err := os.WriteFile(filepath.Join(projectRoot, ".pre-commit.json"), []byte(`{
+  "features": { "testFiles": true },
+  "testFilesConfig": {
+    "interactiveHooks": ["useSignal"],
+    "e2eExtensions": { "web": ".spec.ts" }
+  }
+}`), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	dir := filepath.Join(projectRoot, "apps", "web", "components")
+	component := filepath.Join(dir, "Counter.tsx")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	src := `export const Counter = () => { const count = useSignal(0); return <div>{count}</div>; };`
+	if err := os.WriteFile(component, []byte(src), 0644); err != nil {
+		t.Fatalf("write component: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "Counter.test.tsx"), nil, 0644); err != nil {
+		t.Fatalf("write unit test: %v", err)
+	}
+
+	// useSignal makes it interactive, so a Playwright spec is required
+	var buf bytes.Buffer
+	if got := run(HookData{ToolName: "Edit", ToolInput: ToolInput{FilePath: component}}, &buf); got != 2 {
+		t.Fatalf("run() = %d, want 2 (stderr: %q)", got, buf.String())
+	}
+	if !strings.Contains(buf.String(), "Counter.spec.ts") {
+		t.Errorf("expected the Playwright spec path in stderr, got %q", buf.String())
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "Counter.spec.ts"), nil, 0644); err != nil {
+		t.Fatalf("write e2e test: %v", err)
+	}
+	buf.Reset()
+	if got := run(HookData{ToolName: "Edit", ToolInput: ToolInput{FilePath: component}}, &buf); got != 0 {
+		t.Errorf("run() = %d, want 0 (stderr: %q)", got, buf.String())
+	}
+}
+
 func TestRunSplitGating(t *testing.T) {
 	// Stub rejection should be independently gated on features.stubTestCheck,
 	// not conflated with features.testFiles (which governs the "every
@@ -1606,6 +1858,30 @@ func TestGetResultingTestContent(t *testing.T) {
 			want:    `it("a", () => { expect(true).toBe(true); });`,
 			wantErr: false,
 		},
+		{
+			name: "MultiEdit applies replacements in order",
+			data: HookData{
+				ToolName: "MultiEdit",
+				ToolInput: ToolInput{
+					FilePath: existingPath,
+					Edits: []filewrite.Edit{
+						{OldString: `"a"`, NewString: `"b"`},
+						{OldString: `toBe(1)`, NewString: `toBe(2)`},
+					},
+				},
+			},
+			want:    `it("b", () => { expect(x).toBe(2); });`,
+			wantErr: false,
+		},
+		{
+			name: "Bash heredoc returns content",
+			data: HookData{
+				ToolName:  "Bash",
+				ToolInput: ToolInput{Command: "cat > /virtual/new.test.ts << 'EOF'\nexpect(true).toBe(true);\nEOF"},
+			},
+			want:    `expect(true).toBe(true);`,
+			wantErr: false,
+		},
 		{
 			name: "Edit on missing file errors",
 			data: HookData{
@@ -1643,3 +1919,110 @@ func TestGetResultingTestContent(t *testing.T) {
 		})
 	}
 }
+
+func TestReadFileCached(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "Button.tsx")
+	stamp := time.Now().Add(-time.Hour).Truncate(time.Second)
+	write := func(content string, mtime time.Time) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("const a = useState()", stamp)
+	if got, _ := readFileCached(path); string(got) != "const a = useState()" {
+		t.Fatalf("first read = %q", got)
+	}
+
+	// Same size and mtime: served from the cache without re-reading.
+	write("const b = useState()", stamp)
+	if got, _ := readFileCached(path); string(got) != "const a = useState()" {
+		t.Errorf("unchanged mtime read = %q, want the cached content", got)
+	}
+
+	write("const b = useState()", stamp.Add(time.Second))
+	if got, _ := readFileCached(path); string(got) != "const b = useState()" {
+		t.Errorf("after mtime change = %q, want the new content", got)
+	}
+
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readFileCached(path); err == nil {
+		t.Error("deleted file: want an error, not the cached content")
+	}
+}
+
+func TestRunCheck(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		".pre-commit.json":                             `{"testFilesConfig": {"excludePaths": ["legacy/"]}}`,
+		"packages/web/components/Counter.tsx":          "const [n, setN] = useState(0)",
+		"packages/web/components/Label.tsx":            "export const Label = () => null",
+		"packages/web/components/Label.test.tsx":       "it('renders', () => { expect(1).toBe(1) })",
+		"packages/web/legacy/Old.tsx":                  "export const Old = () => null",
+		"packages/web/node_modules/lib/Thing.tsx":      "export const Thing = () => null",
+		"packages/web/components/Counter.stories.json": "{}",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := runCheck([]string{root}, &stdout, &stderr); code != 1 {
+		t.Fatalf("exit code = %d, want 1; stderr: %s", code, stderr.String())
+	}
+	out := stdout.String()
+	for _, want := range []string{"Counter.tsx", "Missing unit test: Counter.test.tsx", "Missing E2E test: Counter.e2e.ts", "1 file(s) missing tests"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	for _, unwanted := range []string{"Label.tsx", "Old.tsx", "Thing.tsx"} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("output mentions %q:\n%s", unwanted, out)
+		}
+	}
+
+	if code := runCheck([]string{filepath.Join(root, "packages/web/legacy")}, &stdout, &stderr); code != 0 {
+		t.Errorf("excluded path: exit code = %d, want 0", code)
+	}
+}
+
+// BenchmarkCheckTestRequirementsBatch checks a directory of components
+// whose interactivity has to be read from disk, as --check does on every
+// pre-commit run.
+func BenchmarkCheckTestRequirementsBatch(b *testing.B) {
+	dir := filepath.Join(b.TempDir(), "packages", "web", "components")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		b.Fatal(err)
+	}
+	body := strings.Repeat("const row = <div className=\"row\">{label}</div>\n", 200)
+	var files []string
+	for i := 0; i < 200; i++ {
+		path := filepath.Join(dir, fmt.Sprintf("Widget%d.tsx", i))
+		content := body
+		if i%2 == 0 {
+			content += "const [open, setOpen] = useState(false)\n"
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			b.Fatal(err)
+		}
+		files = append(files, path)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		checkTestRequirementsBatch(files, testFilesConfig{})
+	}
+}
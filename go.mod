@@ -2,4 +2,4 @@ module github.com/milehighideas/claude-hooks
 
 go 1.23
 
-require github.com/smacker/go-tree-sitter v0.0.0-20240827094217-dd81d9e9be82 // indirect
+require github.com/smacker/go-tree-sitter v0.0.0-20240827094217-dd81d9e9be82
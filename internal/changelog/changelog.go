@@ -0,0 +1,66 @@
+// Package changelog parses changelog fragment entries, so changelog-add,
+// which writes and compiles fragments, and pre-commit, which checks the
+// staged ones, accept exactly the same format.
+//
+// An entry is a Conventional Commits header, "type(scope): description" or
+// "type: description", optionally followed by free-form body lines.
+package changelog
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ValidTypes are the commit types an entry may use.
+var ValidTypes = map[string]bool{
+	"feat":     true,
+	"fix":      true,
+	"chore":    true,
+	"docs":     true,
+	"test":     true,
+	"style":    true,
+	"refactor": true,
+	"perf":     true,
+	"build":    true,
+	"ci":       true,
+	"revert":   true,
+}
+
+var headerRe = regexp.MustCompile(`(?i)^([a-z]+)(?:\(([^)]+)\))?: (.+)$`)
+
+// HeaderLine returns the first non-blank line of a changelog entry. Multi-line
+// entries carry a conventional-commit header followed by free-form details.
+func HeaderLine(entry string) string {
+	entry = strings.TrimSpace(entry)
+	if idx := strings.IndexByte(entry, '\n'); idx >= 0 {
+		entry = entry[:idx]
+	}
+	return strings.TrimSpace(entry)
+}
+
+// ParseConventionalCommit parses a conventional commit message. Only the
+// first line is validated; any following lines are the entry's body.
+// Returns (type, scope, description, error)
+func ParseConventionalCommit(entry string) (string, string, string, error) {
+	match := headerRe.FindStringSubmatch(HeaderLine(entry))
+	if match == nil {
+		return "", "", "", fmt.Errorf("invalid format. Expected: 'type(scope): description' or 'type: description'")
+	}
+
+	commitType := strings.ToLower(match[1])
+	scope := match[2] // May be empty
+	description := strings.TrimSpace(match[3])
+
+	if !ValidTypes[commitType] {
+		types := make([]string, 0, len(ValidTypes))
+		for t := range ValidTypes {
+			types = append(types, t)
+		}
+		sort.Strings(types)
+		return "", "", "", fmt.Errorf("invalid type '%s'. Valid types: %s", commitType, strings.Join(types, ", "))
+	}
+
+	return commitType, scope, description, nil
+}
@@ -0,0 +1,37 @@
+package changelog
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConventionalCommit(t *testing.T) {
+	tests := []struct {
+		entry                     string
+		wantType, wantScope, desc string
+		wantErr                   string
+	}{
+		{entry: "feat(web): add login", wantType: "feat", wantScope: "web", desc: "add login"},
+		{entry: "FIX: resolve crash", wantType: "fix", desc: "resolve crash"},
+		{entry: "chore(native): bump deps\n\n- expo 51", wantType: "chore", wantScope: "native", desc: "bump deps"},
+		{entry: "added a thing", wantErr: "invalid format"},
+		{entry: "feature(web): add login", wantErr: "invalid type 'feature'"},
+	}
+	for _, tt := range tests {
+		gotType, gotScope, gotDesc, err := ParseConventionalCommit(tt.entry)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseConventionalCommit(%q) error = %v, want %q", tt.entry, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseConventionalCommit(%q) unexpected error: %v", tt.entry, err)
+			continue
+		}
+		if gotType != tt.wantType || gotScope != tt.wantScope || gotDesc != tt.desc {
+			t.Errorf("ParseConventionalCommit(%q) = (%q, %q, %q), want (%q, %q, %q)",
+				tt.entry, gotType, gotScope, gotDesc, tt.wantType, tt.wantScope, tt.desc)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+// Package filewrite recognizes tool calls that write files so every hook
+// reacts to the same set of operations: Write, Edit, MultiEdit, and Bash
+// commands that create a file with a heredoc or an echo/printf redirect.
+//
+// Hooks used to hardcode their own tool checks, which left MultiEdit (and in
+// most places Bash) unvalidated. Callers decode tool_input into ToolInput (or
+// pass the raw map to FromMap) and filter the returned writes by path.
+package filewrite
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Edit is one old/new replacement from an Edit or MultiEdit call.
+type Edit struct {
+	OldString  string `json:"old_string"`
+	NewString  string `json:"new_string"`
+	ReplaceAll bool   `json:"replace_all,omitempty"`
+}
+
+// ToolInput is the union of the tool_input fields the file-writing tools send.
+type ToolInput struct {
+	FilePath   string `json:"file_path"`
+	Content    string `json:"content,omitempty"`     // Write
+	OldString  string `json:"old_string,omitempty"`  // Edit
+	NewString  string `json:"new_string,omitempty"`  // Edit
+	ReplaceAll bool   `json:"replace_all,omitempty"` // Edit
+	Edits      []Edit `json:"edits,omitempty"`       // MultiEdit
+	Command    string `json:"command,omitempty"`     // Bash
+}
+
+// Write is one file a tool call creates or modifies.
+type Write struct {
+	Tool string
+	Path string
+	// Content is the full new file content (Write, Bash). Empty for Edit and
+	// MultiEdit, whose changes are in Edits.
+	Content string
+	Edits   []Edit
+}
+
+// IsWriteTool reports whether toolName always targets a file. Bash only
+// sometimes writes one; use Extract to find out.
+func IsWriteTool(toolName string) bool {
+	switch toolName {
+	case "Write", "Edit", "MultiEdit":
+		return true
+	}
+	return false
+}
+
+// Extract returns the files a tool call writes. Non-writing tools, and Bash
+// commands with no recognizable file write, return nil.
+func Extract(toolName string, in ToolInput) []Write {
+	switch toolName {
+	case "Write":
+		if in.FilePath == "" {
+			return nil
+		}
+		return []Write{{Tool: toolName, Path: in.FilePath, Content: in.Content}}
+	case "Edit":
+		if in.FilePath == "" {
+			return nil
+		}
+		return []Write{{
+			Tool:    toolName,
+			Path:    in.FilePath,
+			Content: in.Content,
+			Edits:   []Edit{{OldString: in.OldString, NewString: in.NewString, ReplaceAll: in.ReplaceAll}},
+		}}
+	case "MultiEdit":
+		if in.FilePath == "" {
+			return nil
+		}
+		return []Write{{Tool: toolName, Path: in.FilePath, Content: in.Content, Edits: in.Edits}}
+	case "Bash":
+		return ParseBash(in.Command)
+	}
+	return nil
+}
+
+// FromMap is Extract for hooks that decode tool_input as a generic map.
+func FromMap(toolName string, toolInput map[string]interface{}) []Write {
+	data, err := json.Marshal(toolInput)
+	if err != nil {
+		return nil
+	}
+	var in ToolInput
+	if err := json.Unmarshal(data, &in); err != nil {
+		return nil
+	}
+	return Extract(toolName, in)
+}
+
+// NewText returns the text the call introduces: the full content for Write
+// and Bash, or the replacement strings joined by newlines for edits.
+func (w Write) NewText() string {
+	if w.Content != "" || len(w.Edits) == 0 {
+		return w.Content
+	}
+	parts := make([]string, 0, len(w.Edits))
+	for _, e := range w.Edits {
+		parts = append(parts, e.NewString)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// Apply returns the file content after the call, given the content before
+// it. Edits are applied in order, matching how MultiEdit runs them.
+func (w Write) Apply(existing string) string {
+	if len(w.Edits) == 0 {
+		return w.Content
+	}
+	result := existing
+	for _, e := range w.Edits {
+		if e.ReplaceAll {
+			result = strings.ReplaceAll(result, e.OldString, e.NewString)
+		} else {
+			result = strings.Replace(result, e.OldString, e.NewString, 1)
+		}
+	}
+	return result
+}
+
+// Heredoc headers. Go's regexp has no backreferences, so these only find the
+// target and delimiter; heredocBody locates the closing line.
+var (
+	// cat > file <<'EOF'
+	catRedirectHeredocRe = regexp.MustCompile(`\bcat\s*>>?\s*([^\s<>;&|]+)\s*<<(-?)\s*['"]?(\w+)['"]?[^\n]*\n`)
+	// cat <<'EOF' > file
+	catHeredocRedirectRe = regexp.MustCompile(`\bcat\s*<<(-?)\s*['"]?(\w+)['"]?\s*>>?\s*([^\s<>;&|]+)[^\n]*\n`)
+	// tee [-a] file <<'EOF'
+	teeHeredocRe = regexp.MustCompile(`\btee\s+(?:-a\s+)?([^\s<>;&|]+)\s*<<(-?)\s*['"]?(\w+)['"]?[^\n]*\n`)
+	// echo "..." > file, printf '...' > file
+	echoRedirectRe = regexp.MustCompile(`\b(?:echo|printf)\s+(?:-[neE]+\s+)?(?:'([^']*)'|"((?:[^"\\]|\\.)*)")\s*>>?\s*([^\s<>;&|]+)`)
+)
+
+// ParseBash returns the files a shell command writes via heredoc or
+// echo/printf redirect, in the order they appear.
+func ParseBash(command string) []Write {
+	type found struct {
+		at    int
+		write Write
+	}
+	var all []found
+
+	add := func(at int, path, body string) {
+		all = append(all, found{at, Write{Tool: "Bash", Path: strings.Trim(path, `"'`), Content: body}})
+	}
+
+	for _, m := range catRedirectHeredocRe.FindAllStringSubmatchIndex(command, -1) {
+		if body, ok := heredocBody(command[m[1]:], command[m[6]:m[7]], m[5] > m[4]); ok {
+			add(m[0], command[m[2]:m[3]], body)
+		}
+	}
+	for _, m := range catHeredocRedirectRe.FindAllStringSubmatchIndex(command, -1) {
+		if body, ok := heredocBody(command[m[1]:], command[m[4]:m[5]], m[3] > m[2]); ok {
+			add(m[0], command[m[6]:m[7]], body)
+		}
+	}
+	for _, m := range teeHeredocRe.FindAllStringSubmatchIndex(command, -1) {
+		if body, ok := heredocBody(command[m[1]:], command[m[6]:m[7]], m[5] > m[4]); ok {
+			add(m[0], command[m[2]:m[3]], body)
+		}
+	}
+	for _, m := range echoRedirectRe.FindAllStringSubmatchIndex(command, -1) {
+		body := ""
+		if m[2] >= 0 {
+			body = command[m[2]:m[3]]
+		} else if m[4] >= 0 {
+			body = command[m[4]:m[5]]
+		}
+		add(m[0], command[m[6]:m[7]], body)
+	}
+
+	if len(all) == 0 {
+		return nil
+	}
+	// Restore command order across the pattern groups.
+	sort.SliceStable(all, func(i, j int) bool { return all[i].at < all[j].at })
+	writes := make([]Write, 0, len(all))
+	for _, f := range all {
+		writes = append(writes, f.write)
+	}
+	return writes
+}
+
+// heredocBody returns the text before the line that closes a heredoc with
+// delimiter. With stripTabs (<<-) leading tabs on the closing line are
+// ignored.
+func heredocBody(rest, delimiter string, stripTabs bool) (string, bool) {
+	lines := strings.Split(rest, "\n")
+	for i, line := range lines {
+		if stripTabs {
+			line = strings.TrimLeft(line, "\t")
+		}
+		if line == delimiter {
+			return strings.Join(lines[:i], "\n"), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,174 @@
+package filewrite
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIsWriteTool(t *testing.T) {
+	for tool, want := range map[string]bool{
+		"Write":     true,
+		"Edit":      true,
+		"MultiEdit": true,
+		"Bash":      false,
+		"Read":      false,
+		"":          false,
+	} {
+		if got := IsWriteTool(tool); got != want {
+			t.Errorf("IsWriteTool(%q) = %v, want %v", tool, got, want)
+		}
+	}
+}
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name string
+		tool string
+		in   ToolInput
+		want []Write
+	}{
+		{
+			name: "Write",
+			tool: "Write",
+			in:   ToolInput{FilePath: "/app/a.tsx", Content: "export const A = 1;"},
+			want: []Write{{Tool: "Write", Path: "/app/a.tsx", Content: "export const A = 1;"}},
+		},
+		{
+			name: "Edit",
+			tool: "Edit",
+			in:   ToolInput{FilePath: "/app/a.ts", OldString: "a", NewString: "b", ReplaceAll: true},
+			want: []Write{{Tool: "Edit", Path: "/app/a.ts", Edits: []Edit{{OldString: "a", NewString: "b", ReplaceAll: true}}}},
+		},
+		{
+			name: "MultiEdit",
+			tool: "MultiEdit",
+			in: ToolInput{FilePath: "/app/a.ts", Edits: []Edit{
+				{OldString: "one", NewString: "1"},
+				{OldString: "two", NewString: "2"},
+			}},
+			want: []Write{{Tool: "MultiEdit", Path: "/app/a.ts", Edits: []Edit{
+				{OldString: "one", NewString: "1"},
+				{OldString: "two", NewString: "2"},
+			}}},
+		},
+		{
+			name: "Bash heredoc",
+			tool: "Bash",
+			in:   ToolInput{Command: "cat > a.ts << 'EOF'\nexport const A = 1;\nEOF"},
+			want: []Write{{Tool: "Bash", Path: "a.ts", Content: "export const A = 1;"}},
+		},
+		{name: "Bash without a write", tool: "Bash", in: ToolInput{Command: "ls -la"}},
+		{name: "Write without a path", tool: "Write", in: ToolInput{Content: "x"}},
+		{name: "Read", tool: "Read", in: ToolInput{FilePath: "/app/a.ts"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Extract(tt.tool, tt.in); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Extract() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromMap(t *testing.T) {
+	got := FromMap("MultiEdit", map[string]interface{}{
+		"file_path": "/app/a.ts",
+		"edits": []interface{}{
+			map[string]interface{}{"old_string": "x", "new_string": "y"},
+		},
+	})
+	want := []Write{{Tool: "MultiEdit", Path: "/app/a.ts", Edits: []Edit{{OldString: "x", NewString: "y"}}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FromMap() = %+v, want %+v", got, want)
+	}
+}
+
+func TestParseBash(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		want    []Write
+	}{
+		{
+			name:    "cat redirect heredoc",
+			command: "cat > src/a.tsx << 'EOF'\nline 1\nline 2\nEOF",
+			want:    []Write{{Tool: "Bash", Path: "src/a.tsx", Content: "line 1\nline 2"}},
+		},
+		{
+			name:    "cat heredoc then redirect",
+			command: "cat <<EOF > src/a.ts\nbody\nEOF",
+			want:    []Write{{Tool: "Bash", Path: "src/a.ts", Content: "body"}},
+		},
+		{
+			name:    "append redirect",
+			command: "cat >> notes.md <<\"END\"\nmore\nEND",
+			want:    []Write{{Tool: "Bash", Path: "notes.md", Content: "more"}},
+		},
+		{
+			name:    "tee heredoc",
+			command: "tee a.ts << 'EOF'\nbody\nEOF",
+			want:    []Write{{Tool: "Bash", Path: "a.ts", Content: "body"}},
+		},
+		{
+			name:    "tab-stripped heredoc",
+			command: "cat > a.ts <<-EOF\nbody\n\tEOF",
+			want:    []Write{{Tool: "Bash", Path: "a.ts", Content: "body"}},
+		},
+		{
+			name:    "delimiter text inside body",
+			command: "cat > a.ts << 'EOF'\nconst EOFX = 1;\nEOF",
+			want:    []Write{{Tool: "Bash", Path: "a.ts", Content: "const EOFX = 1;"}},
+		},
+		{
+			name:    "echo double quotes",
+			command: `echo "export const Foo = 1;" > file.tsx`,
+			want:    []Write{{Tool: "Bash", Path: "file.tsx", Content: "export const Foo = 1;"}},
+		},
+		{
+			name:    "printf single quotes",
+			command: `printf 'a\nb' > out.txt`,
+			want:    []Write{{Tool: "Bash", Path: "out.txt", Content: `a\nb`}},
+		},
+		{
+			name:    "several writes keep command order",
+			command: "echo 'x' > b.ts && cat > a.ts << 'EOF'\ny\nEOF",
+			want: []Write{
+				{Tool: "Bash", Path: "b.ts", Content: "x"},
+				{Tool: "Bash", Path: "a.ts", Content: "y"},
+			},
+		},
+		{name: "unterminated heredoc", command: "cat > a.ts << 'EOF'\nbody"},
+		{name: "no write", command: "git status"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseBash(tt.command); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseBash() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteNewTextAndApply(t *testing.T) {
+	write := Write{Tool: "Write", Path: "a.ts", Content: "new"}
+	if got := write.NewText(); got != "new" {
+		t.Errorf("Write.NewText() = %q", got)
+	}
+	if got := write.Apply("old"); got != "new" {
+		t.Errorf("Write.Apply() = %q", got)
+	}
+
+	multi := Write{Tool: "MultiEdit", Path: "a.ts", Edits: []Edit{
+		{OldString: "a", NewString: "b"},
+		{OldString: "b", NewString: "c", ReplaceAll: true},
+	}}
+	if got := multi.NewText(); got != "b\nc" {
+		t.Errorf("MultiEdit.NewText() = %q", got)
+	}
+	// Edits apply in order: "aab" -> "bab" -> "cac".
+	if got := multi.Apply("aab"); got != "cac" {
+		t.Errorf("MultiEdit.Apply() = %q, want cac", got)
+	}
+}
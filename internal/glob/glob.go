@@ -0,0 +1,56 @@
+// Package glob matches slash-separated paths against the glob patterns the
+// hooks' config files use, so every hook reads "src/**" the same way.
+package glob
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ToRegex converts a glob to an anchored regex: "**/" matches any number of
+// directories, "**" anything, "*" and "?" stay within one segment. A
+// leading "./" is ignored.
+func ToRegex(glob string) string {
+	glob = strings.TrimPrefix(glob, "./")
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case glob[i] == '*':
+			b.WriteString("[^/]*")
+		case glob[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+	b.WriteString("$")
+	return b.String()
+}
+
+// Compile returns the compiled ToRegex of glob.
+func Compile(glob string) (*regexp.Regexp, error) {
+	return regexp.Compile(ToRegex(glob))
+}
+
+// MustCompile is like Compile but panics if the regex doesn't compile, which
+// ToRegex's output always does.
+func MustCompile(glob string) *regexp.Regexp {
+	return regexp.MustCompile(ToRegex(glob))
+}
+
+// MatchAny reports whether path matches any of the glob patterns.
+func MatchAny(path string, patterns []string) bool {
+	for _, p := range patterns {
+		if re, err := Compile(p); err == nil && re.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,34 @@
+package glob
+
+import "testing"
+
+func TestMatchAny(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"src/**", "src/index.ts", true},
+		{"src/**", "src/lib/deep/util.ts", true},
+		{"src/**", "lib/src/index.ts", false},
+		{"**/vendor/**", "vendor/a.js", true},
+		{"**/vendor/**", "src/vendor/lodash.js", true},
+		{"apps/*/src/**", "apps/web/src/page.tsx", true},
+		{"apps/*/src/**", "apps/web/nested/src/page.tsx", false},
+		{"*.test.ts", "a.test.ts", true},
+		{"*.test.ts", "src/a.test.ts", false},
+		{"file?.go", "file1.go", true},
+		{"file?.go", "file/.go", false},
+		{"./docs/*.md", "docs/readme.md", true},
+		{"a+b.txt", "a+b.txt", true},
+		{"a+b.txt", "aab.txt", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+			if got := MatchAny(tt.path, []string{tt.pattern}); got != tt.want {
+				t.Errorf("MatchAny(%q, %q) = %v, want %v", tt.path, tt.pattern, got, tt.want)
+			}
+		})
+	}
+}
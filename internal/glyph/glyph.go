@@ -0,0 +1,68 @@
+// Package glyph holds the emoji and symbols the hooks print, defined once so
+// every command emits the same bytes, and the check that keeps them from
+// being saved through a broken encoding round-trip.
+//
+// A literal like "✅" that passes through cp1252 and back is stored as its
+// three bytes read as cp1252 characters: it still compiles, but the hook
+// prints garbage. Commands print glyphs through the constants below, and
+// their tests run Validate over their .go files and output.
+package glyph
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Status and decoration glyphs.
+const (
+	Pass   = "✅"
+	Fail   = "❌"
+	Warn   = "⚠️"
+	Info   = "ℹ️"
+	Tick   = "✓"
+	Cross  = "✗"
+	Arrow  = "→"
+	Bullet = "•"
+	Search = "🔍"
+	Hint   = "💡"
+	Folder = "📁"
+	Lock   = "🔒"
+	Wrench = "🔧"
+	Skip   = "⏩"
+	Wait   = "⏳"
+	Start  = "▶"
+	Dot    = "●"
+)
+
+// mojibakeMarkers are the cp1252 renderings of the lead bytes of the glyphs
+// above. Any of them in a source file means a literal was saved through a
+// broken encoding round-trip. Written as escapes so this file doesn't trip
+// its own check.
+var mojibakeMarkers = []string{
+	"\u00e2\u0153", // ✅ / ✓ / ✗ (E2 9C ..)
+	"\u00e2\u0152", // ❌ (E2 9D 8C) with the undefined 0x9D dropped
+	"\u00e2\u009d", // ❌ (E2 9D 8C) with 0x9D kept as a C1 control
+	"\u00e2\u0161", // ⚠ (E2 9A A0)
+	"\u00e2\u201e", // ℹ (E2 84 B9)
+	"\u00e2\u2020", // → (E2 86 92)
+	"\u00e2\u20ac", // • and other general punctuation (E2 80 ..)
+	"\u00e2\u008f", // ⏩ / ⏳ (E2 8F ..) with 0x8F kept as a C1 control
+	"\u00e2\u2013", // ▶ (E2 96 B6)
+	"\u00e2\u2014", // ● (E2 97 8F)
+	"\u00f0\u0178", // 4-byte emoji (F0 9F ..)
+}
+
+// Validate reports whether data, the contents of a source file or a command's
+// output, is valid UTF-8 free of double-encoded glyphs.
+func Validate(data []byte) error {
+	if !utf8.Valid(data) {
+		return fmt.Errorf("not valid UTF-8")
+	}
+	for _, m := range mojibakeMarkers {
+		if strings.Contains(string(data), m) {
+			return fmt.Errorf("contains double-encoded sequence %q", m)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package glyph
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		ok   bool
+	}{
+		{"glyphs", Pass + " Lint " + Arrow + " " + Warn + " " + Folder + " " + Bullet, true},
+		{"progress glyphs", Start + " " + Skip + " " + Wait + " " + Dot, true},
+		{"info glyph", Info + "  note", true},
+		{"ascii", "plain text\n", true},
+		{"double-encoded pass", "\u00e2\u0153\u2026 Lint", false},
+		{"double-encoded folder", "\u00f0\u0178\u201c\u0081 apps/web", false},
+		{"double-encoded fail, 0x9D dropped", "\u00e2\u0152 Lint", false},
+		{"double-encoded start", "\u00e2\u2013\u00b6 Lint", false},
+		{"double-encoded dot", "\u00e2\u2014\u008f mobile", false},
+		{"double-encoded info", "\u00e2\u201e\u00b9 note", false},
+		{"invalid UTF-8", "\xe2\x9c Lint", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Validate([]byte(tt.data)); (err == nil) != tt.ok {
+				t.Errorf("Validate(%q) = %v, want ok %v", tt.data, err, tt.ok)
+			}
+		})
+	}
+}
+
+func TestSourceIsClean(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := Validate(data); err != nil {
+			t.Errorf("%s: %v", f, err)
+		}
+	}
+}
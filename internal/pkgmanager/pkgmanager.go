@@ -0,0 +1,149 @@
+// Package pkgmanager decides which JavaScript package manager runs a
+// project's scripts, so pre-commit, smart-test and enforce-tests-on-commit
+// all agree.
+//
+// The package.json "packageManager" field (corepack's "pnpm@9.1.0") is the
+// canonical answer and wins. Without it, the lockfile decides; the hook's
+// own configuration is only consulted when neither is present.
+package pkgmanager
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Source says where Resolve found the package manager.
+type Source string
+
+const (
+	SourceNone        Source = ""             // nothing found; callers apply their default
+	SourcePackageJSON Source = "package.json" // the "packageManager" field
+	SourceLockfile    Source = "lockfile"     // a lockfile such as pnpm-lock.yaml
+	SourceConfig      Source = "config"       // the hook's configured value
+)
+
+// Resolution is the result of Resolve.
+type Resolution struct {
+	Name   string // "pnpm", "npm", "yarn", "bun"; "" with SourceNone
+	Source Source
+	Path   string // the package.json or lockfile it came from; "" for config
+}
+
+// lockfiles maps lockfile names to their manager, checked in this order when
+// a directory holds more than one.
+var lockfiles = []struct {
+	name    string
+	manager string
+}{
+	{"pnpm-lock.yaml", "pnpm"},
+	{"bun.lock", "bun"},
+	{"bun.lockb", "bun"},
+	{"yarn.lock", "yarn"},
+	{"package-lock.json", "npm"},
+	{"npm-shrinkwrap.json", "npm"},
+}
+
+// Resolve returns the package manager for the project containing dir. It
+// looks for a package.json "packageManager" field in dir and its parents,
+// then for a lockfile the same way, then falls back to configured. The
+// search stops at the repository root (the first directory with .git).
+func Resolve(dir, configured string) Resolution {
+	dirs := ancestors(dir)
+
+	for _, d := range dirs {
+		if name, ok := FromPackageJSON(d); ok {
+			return Resolution{Name: name, Source: SourcePackageJSON, Path: filepath.Join(d, "package.json")}
+		}
+	}
+	for _, d := range dirs {
+		if name, lockfile, ok := FromLockfile(d); ok {
+			return Resolution{Name: name, Source: SourceLockfile, Path: filepath.Join(d, lockfile)}
+		}
+	}
+	if configured != "" {
+		return Resolution{Name: configured, Source: SourceConfig}
+	}
+	return Resolution{}
+}
+
+// Inputs returns every file Resolve(dir, ...) may read: package.json and
+// each lockfile in dir and its parents up to the repository root, whether
+// or not they exist. Callers that cache a resolution watch these.
+func Inputs(dir string) []string {
+	var files []string
+	for _, d := range ancestors(dir) {
+		files = append(files, filepath.Join(d, "package.json"))
+		for _, lf := range lockfiles {
+			files = append(files, filepath.Join(d, lf.name))
+		}
+	}
+	return files
+}
+
+// FromPackageJSON returns the manager named by the "packageManager" field of
+// dir/package.json, without its version ("pnpm@9.1.0+sha512..." → "pnpm").
+func FromPackageJSON(dir string) (string, bool) {
+	data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return "", false
+	}
+	var pkg struct {
+		PackageManager string `json:"packageManager"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return "", false
+	}
+	name, _, _ := strings.Cut(strings.TrimSpace(pkg.PackageManager), "@")
+	if name == "" {
+		return "", false
+	}
+	return name, true
+}
+
+// FromLockfile returns the manager whose lockfile is in dir, and the
+// lockfile's name.
+func FromLockfile(dir string) (string, string, bool) {
+	for _, lf := range lockfiles {
+		if _, err := os.Stat(filepath.Join(dir, lf.name)); err == nil {
+			return lf.manager, lf.name, true
+		}
+	}
+	return "", "", false
+}
+
+// Describe names where r came from for error messages, using configLabel
+// (e.g. "packageManager in .claude-hooks.json") for SourceConfig.
+func (r Resolution) Describe(configLabel string) string {
+	switch r.Source {
+	case SourcePackageJSON:
+		return `"packageManager" in ` + r.Path
+	case SourceLockfile:
+		return r.Path
+	case SourceConfig:
+		return configLabel
+	}
+	return "default"
+}
+
+// ancestors returns dir and its parents up to and including the first one
+// containing .git, or the filesystem root.
+func ancestors(dir string) []string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return []string{dir}
+	}
+	var dirs []string
+	for {
+		dirs = append(dirs, abs)
+		if _, err := os.Stat(filepath.Join(abs, ".git")); err == nil {
+			return dirs
+		}
+		parent := filepath.Dir(abs)
+		if parent == abs {
+			return dirs
+		}
+		abs = parent
+	}
+}
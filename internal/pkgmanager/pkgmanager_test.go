@@ -0,0 +1,149 @@
+package pkgmanager
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// repo creates a temp repository (bounded by .git) holding files.
+func repo(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	files[".git/HEAD"] = "ref: refs/heads/main\n"
+	for rel, content := range files {
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name       string
+		files      map[string]string
+		dir        string // relative to the repo root
+		configured string
+		want       string
+		wantSource Source
+	}{
+		{
+			name:       "packageManager field",
+			files:      map[string]string{"package.json": `{"packageManager": "pnpm@9.1.0+sha512.abc"}`},
+			want:       "pnpm",
+			wantSource: SourcePackageJSON,
+		},
+		{
+			name: "field absent, lockfile present",
+			files: map[string]string{
+				"package.json": `{"name": "app"}`,
+				"yarn.lock":    "",
+			},
+			configured: "npm",
+			want:       "yarn",
+			wantSource: SourceLockfile,
+		},
+		{
+			name: "field wins over a conflicting lockfile and config",
+			files: map[string]string{
+				"package.json":      `{"packageManager": "bun@1.1.0"}`,
+				"package-lock.json": "{}",
+			},
+			configured: "pnpm",
+			want:       "bun",
+			wantSource: SourcePackageJSON,
+		},
+		{
+			name: "workspace package inherits the root field",
+			files: map[string]string{
+				"package.json":          `{"packageManager": "pnpm@9.0.0"}`,
+				"pnpm-lock.yaml":        "",
+				"apps/web/package.json": `{"name": "web"}`,
+			},
+			dir:        "apps/web",
+			want:       "pnpm",
+			wantSource: SourcePackageJSON,
+		},
+		{
+			name:       "nothing found uses config",
+			files:      map[string]string{"package.json": `{"name": "app"}`},
+			configured: "bun",
+			want:       "bun",
+			wantSource: SourceConfig,
+		},
+		{
+			name:       "nothing at all",
+			files:      map[string]string{},
+			want:       "",
+			wantSource: SourceNone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := repo(t, tt.files)
+			got := Resolve(filepath.Join(root, tt.dir), tt.configured)
+			if got.Name != tt.want || got.Source != tt.wantSource {
+				t.Errorf("Resolve() = %+v, want %q from %q", got, tt.want, tt.wantSource)
+			}
+		})
+	}
+}
+
+func TestResolveStopsAtRepoRoot(t *testing.T) {
+	outer := t.TempDir()
+	if err := os.WriteFile(filepath.Join(outer, "yarn.lock"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	inner := filepath.Join(outer, "project")
+	if err := os.MkdirAll(filepath.Join(inner, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := Resolve(inner, ""); got.Source != SourceNone {
+		t.Errorf("Resolve() = %+v, want nothing (lockfile is outside the repo)", got)
+	}
+}
+
+func TestInputs(t *testing.T) {
+	root := repo(t, map[string]string{"apps/web/package.json": "{}"})
+	inputs := Inputs(filepath.Join(root, "apps", "web"))
+
+	for _, want := range []string{
+		filepath.Join(root, "apps", "web", "package.json"),
+		filepath.Join(root, "apps", "web", "pnpm-lock.yaml"),
+		filepath.Join(root, "apps", "package.json"),
+		filepath.Join(root, "package.json"),
+		filepath.Join(root, "yarn.lock"),
+	} {
+		found := false
+		for _, got := range inputs {
+			found = found || got == want
+		}
+		if !found {
+			t.Errorf("Inputs() is missing %s", want)
+		}
+	}
+	for _, got := range inputs {
+		if !strings.HasPrefix(got, root) {
+			t.Errorf("Inputs() reaches outside the repo: %s", got)
+		}
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	root := repo(t, map[string]string{"pnpm-lock.yaml": ""})
+	r := Resolve(root, "")
+	if got, want := r.Describe("cfg"), filepath.Join(root, "pnpm-lock.yaml"); got != want {
+		t.Errorf("Describe() = %q, want %q", got, want)
+	}
+	if got := (Resolution{Name: "npm", Source: SourceConfig}).Describe("packageManager in .claude-hooks.json"); got != "packageManager in .claude-hooks.json" {
+		t.Errorf("Describe() = %q", got)
+	}
+}
@@ -0,0 +1,144 @@
+// Package session reads and writes the per-session edit record that
+// track-edited-files keeps at ~/.claude/sessions/<session_id>.json, so the
+// hooks that consume it (enforce-tests-on-commit) agree on its format.
+//
+// The path lists are plain string arrays, as they always were, so older
+// readers keep working. Per-file details live alongside them in "files":
+//
+//	{
+//	  "source_files": ["/repo/src/cart.ts"],
+//	  "test_files": [],
+//	  "edited_files": ["/repo/src/cart.ts"],
+//	  "files": {"/repo/src/cart.ts": {"first_seen": "2026-10-16T21:04:05Z", "edits": 3}}
+//	}
+//
+// Session files written before "files" existed are migrated on Load: every
+// listed path gets an entry with one edit and an unknown first-seen time.
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileStats describes how a session edited one file.
+type FileStats struct {
+	// FirstSeen is when the session first edited the file. It is zero for
+	// files migrated from a legacy session file.
+	FirstSeen time.Time `json:"first_seen"`
+	// Edits counts the session's edits to the file.
+	Edits int `json:"edits"`
+}
+
+// Data is the contents of a session file.
+type Data struct {
+	SourceFiles []string `json:"source_files"`
+	TestFiles   []string `json:"test_files"`
+	// EditedFiles records every file the session edited, tracked or not, so
+	// other hooks can tell this session's uncommitted changes from foreign ones.
+	EditedFiles []string             `json:"edited_files,omitempty"`
+	Files       map[string]FileStats `json:"files,omitempty"`
+}
+
+// Dir returns ~/.claude/sessions, where session files live.
+func Dir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".claude", "sessions"), nil
+}
+
+// Path returns the session file for sessionID.
+func Path(sessionID string) (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, sessionID+".json"), nil
+}
+
+// Load reads a session file. Missing, unreadable or malformed files yield
+// empty data rather than an error, since a lost session record must never
+// break a hook.
+func Load(path string) *Data {
+	data := &Data{SourceFiles: []string{}, TestFiles: []string{}}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return data
+	}
+	if err := json.Unmarshal(content, data); err != nil {
+		return &Data{SourceFiles: []string{}, TestFiles: []string{}}
+	}
+	if data.SourceFiles == nil {
+		data.SourceFiles = []string{}
+	}
+	if data.TestFiles == nil {
+		data.TestFiles = []string{}
+	}
+	data.migrate()
+	return data
+}
+
+// Save writes data to path, creating the sessions directory if needed.
+func Save(path string, data *Data) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating sessions directory: %w", err)
+	}
+	content, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling session data: %w", err)
+	}
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("writing session file: %w", err)
+	}
+	return nil
+}
+
+// Paths returns every path the session has listed: source, test, then
+// other edited files.
+func (d *Data) Paths() []string {
+	all := make([]string, 0, len(d.SourceFiles)+len(d.TestFiles)+len(d.EditedFiles))
+	all = append(all, d.SourceFiles...)
+	all = append(all, d.TestFiles...)
+	return append(all, d.EditedFiles...)
+}
+
+// Stats returns the recorded details for path.
+func (d *Data) Stats(path string) (FileStats, bool) {
+	stats, ok := d.Files[path]
+	return stats, ok
+}
+
+// RecordEdit counts an edit to path made at the given time.
+func (d *Data) RecordEdit(path string, at time.Time) {
+	if d.Files == nil {
+		d.Files = make(map[string]FileStats)
+	}
+	stats := d.Files[path]
+	if stats.Edits == 0 {
+		stats.FirstSeen = at.UTC()
+	}
+	stats.Edits++
+	d.Files[path] = stats
+}
+
+// migrate gives every listed path without details a single edit of unknown
+// time, which is all a legacy session file can tell.
+func (d *Data) migrate() {
+	for _, list := range [][]string{d.SourceFiles, d.TestFiles, d.EditedFiles} {
+		for _, path := range list {
+			if _, ok := d.Files[path]; ok {
+				continue
+			}
+			if d.Files == nil {
+				d.Files = make(map[string]FileStats)
+			}
+			d.Files[path] = FileStats{Edits: 1}
+		}
+	}
+}
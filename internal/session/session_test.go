@@ -0,0 +1,165 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "session.json")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad_MigratesLegacyFormat(t *testing.T) {
+	path := writeFile(t, `{
+  "source_files": ["/repo/src/cart.ts"],
+  "test_files": ["/repo/src/cart.test.ts"],
+  "edited_files": ["/repo/src/cart.ts", "/repo/src/cart.test.ts", "/repo/README.md"]
+}`)
+
+	data := Load(path)
+	if !reflect.DeepEqual(data.SourceFiles, []string{"/repo/src/cart.ts"}) {
+		t.Errorf("SourceFiles = %v", data.SourceFiles)
+	}
+	if len(data.Files) != 3 {
+		t.Fatalf("Files = %v, want an entry per listed path", data.Files)
+	}
+	for _, p := range []string{"/repo/src/cart.ts", "/repo/src/cart.test.ts", "/repo/README.md"} {
+		stats, ok := data.Stats(p)
+		if !ok {
+			t.Errorf("Stats(%q) missing", p)
+			continue
+		}
+		if stats.Edits != 1 || !stats.FirstSeen.IsZero() {
+			t.Errorf("Stats(%q) = %+v, want one edit with unknown first-seen time", p, stats)
+		}
+	}
+}
+
+func TestLoad_OldestFormat(t *testing.T) {
+	// Session files from before edited_files existed
+	data := Load(writeFile(t, `{"source_files": ["/repo/a.ts"], "test_files": []}`))
+	if stats, ok := data.Stats("/repo/a.ts"); !ok || stats.Edits != 1 {
+		t.Errorf("Stats = %+v, %v", stats, ok)
+	}
+}
+
+func TestLoad_KeepsRichData(t *testing.T) {
+	path := writeFile(t, `{
+  "source_files": ["/repo/a.ts"],
+  "test_files": [],
+  "edited_files": ["/repo/a.ts"],
+  "files": {"/repo/a.ts": {"first_seen": "2026-10-16T21:04:05Z", "edits": 4}}
+}`)
+	stats, ok := Load(path).Stats("/repo/a.ts")
+	want := FileStats{FirstSeen: time.Date(2026, 10, 16, 21, 4, 5, 0, time.UTC), Edits: 4}
+	if !ok || !stats.FirstSeen.Equal(want.FirstSeen) || stats.Edits != want.Edits {
+		t.Errorf("Stats = %+v, want %+v", stats, want)
+	}
+}
+
+func TestLoad_MissingOrMalformed(t *testing.T) {
+	for name, path := range map[string]string{
+		"missing":   filepath.Join(t.TempDir(), "none.json"),
+		"malformed": writeFile(t, "not json"),
+		"empty":     writeFile(t, ""),
+	} {
+		data := Load(path)
+		if data.SourceFiles == nil || data.TestFiles == nil || len(data.SourceFiles)+len(data.TestFiles) != 0 {
+			t.Errorf("%s: Load = %+v, want empty non-nil lists", name, data)
+		}
+	}
+}
+
+func TestRecordEdit(t *testing.T) {
+	first := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	data := &Data{}
+
+	data.RecordEdit("/repo/a.ts", first)
+	data.RecordEdit("/repo/a.ts", first.Add(time.Hour))
+	data.RecordEdit("/repo/b.ts", first.Add(2*time.Hour))
+
+	if stats, _ := data.Stats("/repo/a.ts"); stats.Edits != 2 || !stats.FirstSeen.Equal(first) {
+		t.Errorf("a.ts = %+v, want 2 edits first seen at %v", stats, first)
+	}
+	if stats, _ := data.Stats("/repo/b.ts"); stats.Edits != 1 {
+		t.Errorf("b.ts = %+v, want 1 edit", stats)
+	}
+	if _, ok := data.Stats("/repo/c.ts"); ok {
+		t.Error("unedited file should have no stats")
+	}
+}
+
+func TestRecordEdit_MigratedFile(t *testing.T) {
+	// A legacy entry keeps its unknown first-seen time
+	data := Load(writeFile(t, `{"source_files": ["/repo/a.ts"], "test_files": []}`))
+	data.RecordEdit("/repo/a.ts", time.Now())
+	if stats, _ := data.Stats("/repo/a.ts"); stats.Edits != 2 || !stats.FirstSeen.IsZero() {
+		t.Errorf("Stats = %+v, want 2 edits with unknown first-seen time", stats)
+	}
+}
+
+func TestSaveRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions", "abc.json")
+	at := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+
+	data := &Data{SourceFiles: []string{"/repo/a.ts"}, TestFiles: []string{}, EditedFiles: []string{"/repo/a.ts"}}
+	data.RecordEdit("/repo/a.ts", at)
+	if err := Save(path, data); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded := Load(path)
+	if !reflect.DeepEqual(loaded.EditedFiles, data.EditedFiles) {
+		t.Errorf("EditedFiles = %v, want %v", loaded.EditedFiles, data.EditedFiles)
+	}
+	if stats, _ := loaded.Stats("/repo/a.ts"); stats.Edits != 1 || !stats.FirstSeen.Equal(at) {
+		t.Errorf("Stats = %+v", stats)
+	}
+}
+
+func TestPath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	got, err := Path("abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(home, ".claude", "sessions", "abc.json"); got != want {
+		t.Errorf("Path = %q, want %q", got, want)
+	}
+}
+
+func TestPaths(t *testing.T) {
+	data := &Data{
+		SourceFiles: []string{"/repo/a.ts"},
+		TestFiles:   []string{"/repo/a.test.ts"},
+		EditedFiles: []string{"/repo/README.md"},
+	}
+	want := []string{"/repo/a.ts", "/repo/a.test.ts", "/repo/README.md"}
+	if got := data.Paths(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Paths() = %v, want %v", got, want)
+	}
+}
+
+func TestPathIsInDir(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir, err := Dir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	path, err := Path("abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != filepath.Join(dir, "abc.json") {
+		t.Errorf("Path(abc) = %q, want it in %q", path, dir)
+	}
+}
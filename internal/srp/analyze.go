@@ -70,6 +70,7 @@ func Analyze(code, filePath string) *Analysis {
 	a.Exports = extractExports(root, src)
 	a.StateManagement = extractStateHooks(root, src)
 	a.HasResponsibilityComment = hasResponsibilityComment(root, src)
+	a.Suppressions = extractSuppressions(root, src)
 	return a
 }
 
@@ -125,7 +126,7 @@ func extractImports(root *sitter.Node, src []byte) []ImportInfo {
 		if m[3] != "" {
 			names = append(names, splitNames(m[3])...)
 		}
-		out = append(out, ImportInfo{Source: m[4], Names: names})
+		out = append(out, ImportInfo{Source: m[4], Names: names, Line: int(node.StartPoint().Row) + 1})
 	})
 	return out
 }
@@ -154,6 +155,7 @@ func extractExports(root *sitter.Node, src []byte) []ExportInfo {
 			Type:       exportType,
 			IsTypeOnly: exportTypeRe.MatchString(text),
 			Source:     source,
+			Line:       int(node.StartPoint().Row) + 1,
 		})
 	})
 	return out
@@ -194,3 +196,13 @@ func hasResponsibilityComment(root *sitter.Node, src []byte) bool {
 	})
 	return found
 }
+
+func extractSuppressions(root *sitter.Node, src []byte) Suppressions {
+	var s Suppressions
+	eachCapture(commentQuery, root, func(name string, node *sitter.Node) {
+		if name == "c" {
+			s.addComment(node.Content(src), int(node.EndPoint().Row)+1)
+		}
+	})
+	return s
+}
@@ -0,0 +1,160 @@
+package srp
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// CheckImportCycles finds import cycles among a set of analyzed files, such as
+// a screen importing its content component which imports back from the
+// screen. Only relative imports count, so the cycles it reports stay within a
+// feature folder. Each cycle is reported once, as a warning on its first file
+// in path order, unless an srp-disable comment covers one of its imports.
+func CheckImportCycles(analyses []*Analysis, opts Options) []Violation {
+	if !opts.ruleEnabled("importCycles") {
+		return nil
+	}
+	byPath := make(map[string]*Analysis, len(analyses))
+	for _, a := range analyses {
+		byPath[filepath.Clean(a.FilePath)] = a
+	}
+
+	// edges[from][to] is the line of from's first import of to.
+	edges := make(map[string]map[string]int)
+	for path, a := range byPath {
+		for _, imp := range a.Imports {
+			to := resolveRelativeImport(path, imp.Source, byPath)
+			if to == "" || to == path {
+				continue
+			}
+			if edges[path] == nil {
+				edges[path] = make(map[string]int)
+			}
+			if _, ok := edges[path][to]; !ok {
+				edges[path][to] = imp.Line
+			}
+		}
+	}
+
+	var cycles [][]string
+	seen := make(map[string]bool)
+	done := make(map[string]bool)
+	onStack := make(map[string]int)
+	var stack []string
+	var visit func(path string)
+	visit = func(path string) {
+		onStack[path] = len(stack)
+		stack = append(stack, path)
+		for _, to := range sortedKeys(edges[path]) {
+			if i, ok := onStack[to]; ok {
+				cycle := canonicalCycle(stack[i:])
+				if key := strings.Join(cycle, "\x00"); !seen[key] {
+					seen[key] = true
+					cycles = append(cycles, cycle)
+				}
+			} else if !done[to] {
+				visit(to)
+			}
+		}
+		stack = stack[:len(stack)-1]
+		delete(onStack, path)
+		done[path] = true
+	}
+	for _, path := range sortedKeys(byPath) {
+		if !done[path] {
+			visit(path)
+		}
+	}
+	sort.Slice(cycles, func(i, j int) bool {
+		return strings.Join(cycles[i], "\x00") < strings.Join(cycles[j], "\x00")
+	})
+
+	var v []Violation
+	for _, cycle := range cycles {
+		suppressed := false
+		for i, from := range cycle {
+			to := cycle[(i+1)%len(cycle)]
+			if byPath[from].Suppressions.Suppressed("importCycles", edges[from][to]) {
+				suppressed = true
+				break
+			}
+		}
+		if suppressed {
+			continue
+		}
+		v = append(v, Violation{
+			File:       cycle[0],
+			Severity:   "warning",
+			Message:    "Import cycle: " + cyclePath(cycle),
+			Suggestion: "Pass data down as props instead of importing back up, or move the shared code into a module both files import",
+			RuleID:     "importCycles",
+			Line:       edges[cycle[0]][cycle[1]],
+		})
+	}
+	return v
+}
+
+// resolveRelativeImport returns the analyzed file a relative import of from
+// refers to, trying the usual TypeScript extensions and index files, or ""
+// for package imports and files outside the set.
+func resolveRelativeImport(from, source string, files map[string]*Analysis) string {
+	if !strings.HasPrefix(source, "./") && !strings.HasPrefix(source, "../") {
+		return ""
+	}
+	base := filepath.Join(filepath.Dir(from), filepath.FromSlash(source))
+	for _, candidate := range []string{
+		base,
+		base + ".ts",
+		base + ".tsx",
+		filepath.Join(base, "index.ts"),
+		filepath.Join(base, "index.tsx"),
+	} {
+		if _, ok := files[candidate]; ok {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// canonicalCycle rotates cycle to start at its smallest path, so the same
+// cycle found from different files compares equal.
+func canonicalCycle(cycle []string) []string {
+	start := 0
+	for i, path := range cycle {
+		if path < cycle[start] {
+			start = i
+		}
+	}
+	return append(append([]string{}, cycle[start:]...), cycle[:start]...)
+}
+
+// cyclePath renders cycle as "a → b → a", with paths relative to the
+// directory the files share.
+func cyclePath(cycle []string) string {
+	common := filepath.Dir(cycle[0])
+	for _, path := range cycle[1:] {
+		for common != filepath.Dir(common) && !strings.HasPrefix(path, common+string(os.PathSeparator)) {
+			common = filepath.Dir(common)
+		}
+	}
+	names := make([]string, 0, len(cycle)+1)
+	for i := 0; i <= len(cycle); i++ {
+		path := cycle[i%len(cycle)]
+		if rel, err := filepath.Rel(common, path); err == nil {
+			path = rel
+		}
+		names = append(names, filepath.ToSlash(path))
+	}
+	return strings.Join(names, " → ")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
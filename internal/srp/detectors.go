@@ -2,16 +2,28 @@ package srp
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 )
 
 // RunDetectors runs the six structural SRP detectors against an analysis and
-// returns their violations with default severities. Callers apply their own
+// returns their violations with default severities. Violations disabled by an
+// srp-disable comment in the file are dropped. Callers apply their own
 // severity policy (warnOnly / errorScopes / warningOnlyPaths) afterward.
 func RunDetectors(a *Analysis, filePath string, opts Options) []Violation {
+	var out []Violation
+	for _, v := range runDetectors(a, filePath, opts) {
+		if !a.Suppressions.Suppressed(v.RuleID, v.Line) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func runDetectors(a *Analysis, filePath string, opts Options) []Violation {
 	var v []Violation
 	if opts.ruleEnabled("directConvexImports") {
-		v = append(v, checkDirectConvexImports(a, filePath)...)
+		v = append(v, checkDirectConvexImports(a, filePath, opts)...)
 	}
 	if opts.ruleEnabled("stateInScreens") {
 		v = append(v, checkStateInScreens(a, filePath, opts.screenHooks())...)
@@ -37,19 +49,19 @@ func isScreenOrPage(filePath string) bool {
 	return strings.Contains(filePath, "/screens/") || strings.HasSuffix(filePath, "page.tsx")
 }
 
-func checkDirectConvexImports(a *Analysis, filePath string) []Violation {
+func checkDirectConvexImports(a *Analysis, filePath string, opts Options) []Violation {
 	var v []Violation
-	if strings.Contains(filePath, "/data-layer/") ||
-		strings.Contains(filePath, "/backend/") ||
-		strings.Contains(filePath, "/convex/") ||
-		strings.Contains(filePath, "/scripts/") ||
-		strings.Contains(filePath, "/providers/") ||
-		strings.HasSuffix(filePath, "_layout.tsx") {
+	if strings.HasSuffix(filePath, "_layout.tsx") {
 		return v
 	}
+	for _, p := range opts.convexImportExemptPaths() {
+		if p != "" && strings.Contains(filePath, p) {
+			return v
+		}
+	}
 
-	allowedImports := map[string]bool{"Preloaded": true, "usePreloadedQuery": true}
-	allowedDataModelTypes := map[string]bool{"Id": true, "Doc": true}
+	allowedImports := opts.allowedConvexReactImports()
+	allowedDataModelTypes := opts.allowedDataModelTypes()
 
 	for _, imp := range a.Imports {
 		if imp.Source == "convex/react" {
@@ -61,6 +73,7 @@ func checkDirectConvexImports(a *Analysis, filePath string) []Violation {
 						Message:    "Direct Convex imports forbidden outside data-layer",
 						Suggestion: "Use data-layer hooks instead",
 						RuleID:     "directConvexImports",
+						Line:       imp.Line,
 					})
 					break
 				}
@@ -73,18 +86,21 @@ func checkDirectConvexImports(a *Analysis, filePath string) []Violation {
 				Message:    "Direct Convex API imports forbidden outside data-layer",
 				Suggestion: "Use data-layer hooks instead",
 				RuleID:     "directConvexImports",
+				Line:       imp.Line,
 			})
 		}
 		if strings.Contains(imp.Source, "_generated/dataModel") {
 			for _, name := range imp.Names {
 				clean := strings.TrimPrefix(name, "type ")
 				if !allowedDataModelTypes[clean] {
+					allowed := sortedNames(allowedDataModelTypes)
 					v = append(v, Violation{
 						File:       filePath,
 						Severity:   "error",
-						Message:    fmt.Sprintf("Only Id and Doc types allowed from _generated/dataModel, found: %s", name),
-						Suggestion: "Use data-layer types instead, or import only Id/Doc",
+						Message:    fmt.Sprintf("Only %s types allowed from _generated/dataModel, found: %s", strings.Join(allowed, ", "), name),
+						Suggestion: fmt.Sprintf("Use data-layer types instead, or import only %s", strings.Join(allowed, "/")),
 						RuleID:     "directConvexImports",
+						Line:       imp.Line,
 					})
 					break
 				}
@@ -94,15 +110,29 @@ func checkDirectConvexImports(a *Analysis, filePath string) []Violation {
 	return v
 }
 
+// sortedNames returns the keys of a name set in stable order for messages.
+func sortedNames(set map[string]bool) []string {
+	names := make([]string, 0, len(set))
+	for n := range set {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func checkStateInScreens(a *Analysis, filePath string, allowedHooks map[string]bool) []Violation {
 	var v []Violation
 	if !isScreenOrPage(filePath) {
 		return v
 	}
 	var flagged []string
+	line := 0
 	for _, s := range a.StateManagement {
-		if allowedHooks[s.Hook] {
+		if allowedHooks[s.Hook] && !a.Suppressions.Suppressed("stateInScreens", s.Line) {
 			flagged = append(flagged, s.Hook)
+			if line == 0 {
+				line = s.Line
+			}
 		}
 	}
 	if len(flagged) > 0 {
@@ -116,6 +146,7 @@ func checkStateInScreens(a *Analysis, filePath string, allowedHooks map[string]b
 			Message:    fmt.Sprintf("%s has state management (%s)", fileType, strings.Join(flagged, ", ")),
 			Suggestion: "Move state to content component or hook - screens are navigation-only",
 			RuleID:     "stateInScreens",
+			Line:       line,
 		})
 	}
 	return v
@@ -133,10 +164,16 @@ func checkMultipleExports(a *Analysis, filePath string) []Violation {
 	if !hasCRUD {
 		return v
 	}
-	nonType := 0
+	// An export preceded by srp-disable-next-line multiple-exports doesn't
+	// count toward the limit.
+	nonType, line := 0, 0
 	for _, e := range a.Exports {
-		if !e.IsTypeOnly && e.Type != "type" && e.Type != "interface" {
+		if !e.IsTypeOnly && e.Type != "type" && e.Type != "interface" &&
+			!a.Suppressions.Suppressed("multipleExports", e.Line) {
 			nonType++
+			if nonType == 2 {
+				line = e.Line
+			}
 		}
 	}
 	if nonType > 1 {
@@ -146,6 +183,7 @@ func checkMultipleExports(a *Analysis, filePath string) []Violation {
 			Message:    fmt.Sprintf("Multiple exports (%d) in CRUD component", nonType),
 			Suggestion: "Split into separate files (one component per file)",
 			RuleID:     "multipleExports",
+			Line:       line,
 		})
 	}
 	return v
@@ -210,6 +248,7 @@ func checkTypeExportsLocation(a *Analysis, filePath string) []Violation {
 				Message:    fmt.Sprintf("Type export '%s' found outside types/ folder", e.Name),
 				Suggestion: "Move type definitions to types/ folder",
 				RuleID:     "typeExportsLocation",
+				Line:       e.Line,
 			})
 		}
 	}
@@ -91,3 +91,174 @@ func TestParseErrorFailsOpen(t *testing.T) {
 		t.Fatal("analysis should be non-nil with LineCount on parse error")
 	}
 }
+
+func TestAllowedConvexReactImportsConfigurable(t *testing.T) {
+	code := `import { ConvexReactClient } from "convex/react";`
+	path := "apps/web/components/client.tsx"
+	a := Analyze(code, path)
+
+	if ruleIDs(RunDetectors(a, path, Options{}))["directConvexImports"] != 1 {
+		t.Fatal("default allowlist should flag ConvexReactClient")
+	}
+	opts := Options{AllowedConvexReactImports: map[string]bool{
+		"Preloaded": true, "usePreloadedQuery": true, "ConvexReactClient": true,
+	}}
+	if v := RunDetectors(a, path, opts); ruleIDs(v)["directConvexImports"] != 0 {
+		t.Fatalf("configured ConvexReactClient should be allowed, got %+v", v)
+	}
+}
+
+func TestAllowedDataModelTypesConfigurable(t *testing.T) {
+	code := `import { Id, TableNames } from "../_generated/dataModel";`
+	path := "apps/web/components/x.tsx"
+	a := Analyze(code, path)
+
+	if ruleIDs(RunDetectors(a, path, Options{}))["directConvexImports"] != 1 {
+		t.Fatal("default allowlist should flag TableNames")
+	}
+	opts := Options{AllowedDataModelTypes: map[string]bool{"Id": true, "Doc": true, "TableNames": true}}
+	if v := RunDetectors(a, path, opts); ruleIDs(v)["directConvexImports"] != 0 {
+		t.Fatalf("configured TableNames should be allowed, got %+v", v)
+	}
+}
+
+func TestConvexImportExemptPathsConfigurable(t *testing.T) {
+	code := `import { useQuery } from "convex/react";`
+	a := Analyze(code, "apps/web/lib/convex-bridge.tsx")
+	opts := Options{ConvexImportExemptPaths: []string{"/lib/"}}
+
+	if v := RunDetectors(a, "apps/web/lib/convex-bridge.tsx", opts); ruleIDs(v)["directConvexImports"] != 0 {
+		t.Fatalf("configured /lib/ should be exempt, got %+v", v)
+	}
+	// Configuring the list replaces the defaults.
+	if v := RunDetectors(a, "apps/web/providers/convex.tsx", opts); ruleIDs(v)["directConvexImports"] != 1 {
+		t.Fatalf("/providers/ no longer exempt once list is configured, got %+v", v)
+	}
+}
+
+func TestSuppressNextLine(t *testing.T) {
+	path := "apps/web/components/read/widget.tsx"
+	code := `export function A(){return null}
+// srp-disable-next-line multiple-exports -- legacy re-export kept for callers
+export function B(){return null}`
+	if v := RunDetectors(Analyze(code, path), path, Options{}); ruleIDs(v)["multipleExports"] != 0 {
+		t.Fatalf("suppressed export should not count, got %+v", v)
+	}
+
+	// The comment only covers the line directly below it.
+	code = `// srp-disable-next-line multiple-exports
+export function A(){return null}
+export function B(){return null}
+export function C(){return null}`
+	if v := RunDetectors(Analyze(code, path), path, Options{}); ruleIDs(v)["multipleExports"] != 1 {
+		t.Fatalf("two unsuppressed exports should still flag, got %+v", v)
+	}
+}
+
+func TestSuppressNextLineOnlyNamedRule(t *testing.T) {
+	path := "apps/web/components/x.tsx"
+	code := `// srp-disable-next-line file-size
+import { useQuery } from "convex/react";`
+	if v := RunDetectors(Analyze(code, path), path, Options{}); ruleIDs(v)["directConvexImports"] != 1 {
+		t.Fatalf("other rule should not suppress directConvexImports, got %+v", v)
+	}
+
+	code = `// srp-disable-next-line directConvexImports
+import { useQuery } from "convex/react";`
+	if v := RunDetectors(Analyze(code, path), path, Options{}); ruleIDs(v)["directConvexImports"] != 0 {
+		t.Fatalf("camelCase rule ID should suppress too, got %+v", v)
+	}
+}
+
+func TestSuppressFile(t *testing.T) {
+	path := "apps/web/components/foo.tsx"
+	code := `/* srp-disable-file type-exports-location */
+export type Bar = { b: string };
+export type Baz = { c: string };
+import { useQuery } from "convex/react";`
+	v := RunDetectors(Analyze(code, path), path, Options{})
+	ids := ruleIDs(v)
+	if ids["typeExportsLocation"] != 0 {
+		t.Fatalf("file-level suppression should drop typeExportsLocation, got %+v", v)
+	}
+	if ids["directConvexImports"] != 1 {
+		t.Fatalf("unrelated rules must still fire, got %+v", v)
+	}
+}
+
+func TestSuppressFileAllRules(t *testing.T) {
+	path := "apps/web/components/foo.tsx"
+	code := `// srp-disable-file
+export type Bar = { b: string };
+import { useQuery } from "convex/react";`
+	if v := RunDetectors(Analyze(code, path), path, Options{}); len(v) != 0 {
+		t.Fatalf("bare srp-disable-file should suppress everything, got %+v", v)
+	}
+}
+
+func TestCheckImportCyclesScreenAndContent(t *testing.T) {
+	screen := Analyze(`import { FooContent } from "../components/FooContent";
+export default function FooScreen() { return <FooContent /> }
+`, "features/foo/screens/FooScreen.tsx")
+	content := Analyze(`import { useState } from "react";
+import { FOO_TITLE } from "../screens/FooScreen";
+export function FooContent() { return null }
+`, "features/foo/components/FooContent.tsx")
+	other := Analyze(`import { FooContent } from "../components/FooContent";
+export function Bar() { return null }
+`, "features/foo/screens/Bar.tsx")
+
+	v := CheckImportCycles([]*Analysis{screen, content, other}, Options{})
+	if len(v) != 1 {
+		t.Fatalf("want one cycle, got %+v", v)
+	}
+	want := "Import cycle: components/FooContent.tsx → screens/FooScreen.tsx → components/FooContent.tsx"
+	if v[0].Message != want || v[0].Severity != "warning" || v[0].RuleID != "importCycles" {
+		t.Errorf("violation = %+v, want warning %q", v[0], want)
+	}
+	if v[0].File != "features/foo/components/FooContent.tsx" || v[0].Line != 2 {
+		t.Errorf("anchored at %s:%d, want FooContent.tsx:2", v[0].File, v[0].Line)
+	}
+}
+
+func TestCheckImportCyclesResolvesIndexAndSuppression(t *testing.T) {
+	screen := Analyze(`import { FooContent } from "./content";
+export default function FooScreen() { return null }
+`, "foo/FooScreen.tsx")
+	content := Analyze(`import FooScreen from "../FooScreen";
+export function FooContent() { return null }
+`, "foo/content/index.tsx")
+	if v := CheckImportCycles([]*Analysis{screen, content}, Options{}); len(v) != 1 {
+		t.Fatalf("want the cycle through index.tsx, got %+v", v)
+	}
+
+	suppressed := Analyze(`// srp-disable-next-line import-cycles
+import FooScreen from "../FooScreen";
+export function FooContent() { return null }
+`, "foo/content/index.tsx")
+	if v := CheckImportCycles([]*Analysis{screen, suppressed}, Options{}); len(v) != 0 {
+		t.Errorf("want the suppressed cycle dropped, got %+v", v)
+	}
+}
+
+func TestCheckImportCyclesIgnoresPackageImports(t *testing.T) {
+	a := Analyze(`import { b } from "@/features/foo/b";
+export const a = 1;
+`, "features/foo/a.ts")
+	b := Analyze(`import { a } from "@/features/foo/a";
+export const b = 1;
+`, "features/foo/b.ts")
+	if v := CheckImportCycles([]*Analysis{a, b}, Options{}); len(v) != 0 {
+		t.Errorf("want alias imports ignored, got %+v", v)
+	}
+}
+
+func TestNameSet(t *testing.T) {
+	if got := NameSet(nil); got != nil {
+		t.Errorf("NameSet(nil) = %v, want nil so defaults apply", got)
+	}
+	got := NameSet([]string{"Id", "Doc"})
+	if len(got) != 2 || !got["Id"] || !got["Doc"] {
+		t.Errorf("NameSet([Id Doc]) = %v", got)
+	}
+}
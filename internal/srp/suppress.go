@@ -0,0 +1,88 @@
+package srp
+
+import (
+	"regexp"
+	"strings"
+)
+
+// allRules is the wildcard key stored when a disable comment names no rules.
+const allRules = "*"
+
+var suppressRe = regexp.MustCompile(`srp-disable-(next-line|file)\b([^\n]*)`)
+
+// normalizeRule folds a rule name to its comparison form: lowercase with
+// hyphens and underscores removed ("multiple-exports" → "multipleexports").
+func normalizeRule(rule string) string {
+	r := strings.ToLower(rule)
+	r = strings.ReplaceAll(r, "-", "")
+	return strings.ReplaceAll(r, "_", "")
+}
+
+// parseSuppression extracts the directive and its rule set from one comment.
+// ok is false when the comment carries no srp-disable directive.
+func parseSuppression(comment string) (directive string, rules map[string]bool, ok bool) {
+	m := suppressRe.FindStringSubmatch(comment)
+	if m == nil {
+		return "", nil, false
+	}
+	rest := strings.TrimSuffix(strings.TrimSpace(m[2]), "*/")
+	// Anything after "--" is a free-form justification.
+	if i := strings.Index(rest, "--"); i >= 0 {
+		rest = rest[:i]
+	}
+	rules = map[string]bool{}
+	for _, f := range strings.FieldsFunc(rest, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+		rules[normalizeRule(f)] = true
+	}
+	if len(rules) == 0 {
+		rules[allRules] = true
+	}
+	return m[1], rules, true
+}
+
+// addComment folds one comment into the suppression set. endLine is the
+// 1-based line the comment ends on.
+func (s *Suppressions) addComment(text string, endLine int) {
+	directive, rules, ok := parseSuppression(text)
+	if !ok {
+		return
+	}
+	switch directive {
+	case "file":
+		if s.File == nil {
+			s.File = map[string]bool{}
+		}
+		for r := range rules {
+			s.File[r] = true
+		}
+	case "next-line":
+		if s.Lines == nil {
+			s.Lines = map[int]map[string]bool{}
+		}
+		target := endLine + 1
+		if s.Lines[target] == nil {
+			s.Lines[target] = map[string]bool{}
+		}
+		for r := range rules {
+			s.Lines[target][r] = true
+		}
+	}
+}
+
+// fileSuppressed reports whether rule is disabled for the whole file.
+func (s Suppressions) fileSuppressed(rule string) bool {
+	return s.File[allRules] || s.File[normalizeRule(rule)]
+}
+
+// Suppressed reports whether a finding for rule anchored at line is disabled,
+// either file-wide or by an srp-disable-next-line comment on the line above.
+func (s Suppressions) Suppressed(rule string, line int) bool {
+	if s.fileSuppressed(rule) {
+		return true
+	}
+	if line <= 0 {
+		return false
+	}
+	lr := s.Lines[line]
+	return lr[allRules] || lr[normalizeRule(rule)]
+}
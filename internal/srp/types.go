@@ -14,6 +14,7 @@ type Violation struct {
 	Message    string
 	Suggestion string
 	RuleID     string
+	Line       int // 1-based line the finding anchors to; 0 = file-level
 }
 
 // Analysis is the structural summary of one source file.
@@ -24,12 +25,30 @@ type Analysis struct {
 	StateManagement          []StateInfo
 	LineCount                int
 	HasResponsibilityComment bool
+	Suppressions             Suppressions
+}
+
+// Suppressions records the srp-disable comments found in a file.
+//
+//	// srp-disable-file type-exports-location
+//	// srp-disable-next-line multiple-exports
+//
+// Rule names match RuleIDs case- and hyphen-insensitively, so
+// "multiple-exports" and "multipleExports" are equivalent. A comment with no
+// rule names suppresses every rule.
+type Suppressions struct {
+	// File holds rules suppressed for the whole file.
+	File map[string]bool
+	// Lines maps a 1-based line number to the rules suppressed on it (the
+	// line after the srp-disable-next-line comment).
+	Lines map[int]map[string]bool
 }
 
 // ImportInfo is one import statement: the module source and the imported names.
 type ImportInfo struct {
 	Source string
 	Names  []string
+	Line   int
 }
 
 // ExportInfo is one named/declaration export.
@@ -38,6 +57,7 @@ type ExportInfo struct {
 	Type       string // const|let|var|function|class|type|interface|default
 	IsTypeOnly bool
 	Source     string // re-export source module, if any
+	Line       int
 }
 
 // StateInfo is one React state-hook call site.
@@ -53,12 +73,47 @@ type Options struct {
 	ScreenHooks map[string]bool
 	// EnabledRules limits which detectors run. nil/empty → all six.
 	EnabledRules map[string]bool
+	// AllowedConvexReactImports is the set of convex/react names that may be
+	// imported outside the data layer. Empty → Preloaded/usePreloadedQuery.
+	AllowedConvexReactImports map[string]bool
+	// AllowedDataModelTypes is the set of _generated/dataModel names that may
+	// be imported outside the data layer. Empty → Id/Doc.
+	AllowedDataModelTypes map[string]bool
+	// ConvexImportExemptPaths are path substrings that exempt a file from the
+	// directConvexImports rule. Empty → the data-layer/backend/convex/scripts/
+	// providers directories.
+	ConvexImportExemptPaths []string
+}
+
+// NameSet converts a configured name list to an Options lookup set. It
+// returns nil for an empty list so the corresponding default applies.
+func NameSet(names []string) map[string]bool {
+	if len(names) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(names))
+	for _, n := range names {
+		set[n] = true
+	}
+	return set
 }
 
 var defaultScreenHooks = map[string]bool{
 	"useState": true, "useReducer": true, "useContext": true,
 }
 
+var defaultAllowedConvexReactImports = map[string]bool{
+	"Preloaded": true, "usePreloadedQuery": true,
+}
+
+var defaultAllowedDataModelTypes = map[string]bool{
+	"Id": true, "Doc": true,
+}
+
+var defaultConvexImportExemptPaths = []string{
+	"/data-layer/", "/backend/", "/convex/", "/scripts/", "/providers/",
+}
+
 func (o Options) screenHooks() map[string]bool {
 	if len(o.ScreenHooks) == 0 {
 		return defaultScreenHooks
@@ -66,6 +121,27 @@ func (o Options) screenHooks() map[string]bool {
 	return o.ScreenHooks
 }
 
+func (o Options) allowedConvexReactImports() map[string]bool {
+	if len(o.AllowedConvexReactImports) == 0 {
+		return defaultAllowedConvexReactImports
+	}
+	return o.AllowedConvexReactImports
+}
+
+func (o Options) allowedDataModelTypes() map[string]bool {
+	if len(o.AllowedDataModelTypes) == 0 {
+		return defaultAllowedDataModelTypes
+	}
+	return o.AllowedDataModelTypes
+}
+
+func (o Options) convexImportExemptPaths() []string {
+	if len(o.ConvexImportExemptPaths) == 0 {
+		return defaultConvexImportExemptPaths
+	}
+	return o.ConvexImportExemptPaths
+}
+
 func (o Options) ruleEnabled(id string) bool {
 	if len(o.EnabledRules) == 0 {
 		return true
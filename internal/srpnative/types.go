@@ -33,6 +33,7 @@ type Violation struct {
 	Message    string
 	Suggestion string
 	RuleID     string
+	Line       int // 1-based anchor line; 0 = file-level
 }
 
 // Analysis is the structural summary of one native source file.
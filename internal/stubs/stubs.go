@@ -32,6 +32,12 @@ var walkSkipDirs = map[string]bool{
 	".vercel":      true,
 }
 
+// SkipWalkDir reports whether a directory walk should skip the directory
+// named name, the same way List and Find do.
+func SkipWalkDir(name string) bool {
+	return walkSkipDirs[name]
+}
+
 var (
 	anyExpectPattern = regexp.MustCompile(`\bexpect\s*\(`)
 